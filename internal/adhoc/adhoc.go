@@ -0,0 +1,220 @@
+// Package adhoc handles skills installed with 'lazyas install --from',
+// straight from a git URL or a local directory instead of a registry.
+// Git sources are cloned like registry skills; local directories are
+// copied, and updating the skill copies them again.
+package adhoc
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/integrity"
+)
+
+// Source is where an ad-hoc skill comes from
+type Source struct {
+	Repo  string // git clone URL, or the absolute path of a local directory
+	Path  string // subdirectory of the repository holding the skill
+	Ref   string // tag or branch; "" = remote default branch
+	Local bool   // Repo is a directory that is copied, not cloned
+}
+
+// Parse parses "url[#subdir][@ref]" for git sources or a directory path.
+// URLs have a scheme (https://, ssh://, file://...) or are scp-like
+// (git@host:org/repo); anything else is a local directory, taken as it is.
+func Parse(s string) (Source, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Source{}, fmt.Errorf("empty source")
+	}
+	if _, _, scp := git.SplitSCPURL(s); !scp && !strings.Contains(s, "://") {
+		dir, err := config.ExpandPath(s)
+		if err != nil {
+			return Source{}, err
+		}
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			return Source{}, err
+		}
+		info, err := os.Stat(dir)
+		if err != nil {
+			return Source{}, err
+		}
+		if !info.IsDir() {
+			return Source{}, fmt.Errorf("%s is not a directory", dir)
+		}
+		return Source{Repo: dir, Local: true}, nil
+	}
+
+	// Refs may hold slashes (feature/x): with a subdirectory the ref follows
+	// its last @, otherwise the first @ in the repository path, which tells
+	// it apart from the user name in ssh and scp-like URLs
+	var src Source
+	hasRef := false
+	repo, fragment, hasFragment := strings.Cut(s, "#")
+	if hasFragment {
+		if i := strings.LastIndex(fragment, "@"); i >= 0 {
+			fragment, src.Ref, hasRef = fragment[:i], fragment[i+1:], true
+		}
+		src.Path = strings.Trim(fragment, "/")
+	} else {
+		start := repoPathStart(repo)
+		if i := strings.Index(repo[start:], "@"); i >= 0 {
+			repo, src.Ref, hasRef = repo[:start+i], repo[start+i+1:], true
+		}
+	}
+	src.Repo = repo
+	if hasRef && src.Ref == "" {
+		return Source{}, fmt.Errorf("empty ref after @")
+	}
+	if src.Repo == "" {
+		return Source{}, fmt.Errorf("missing repository URL")
+	}
+	if err := git.ValidateRelPath(src.Path); err != nil {
+		return Source{}, err
+	}
+	return src, nil
+}
+
+// repoPathStart returns where the path of a git URL starts: after the host
+// of URLs with a scheme, after the ":" of scp-like ones
+func repoPathStart(url string) int {
+	if _, path, ok := git.SplitSCPURL(url); ok {
+		return len(url) - len(path)
+	}
+	i := strings.Index(url, "://")
+	if i < 0 {
+		return 0
+	}
+	i += len("://")
+	if j := strings.Index(url[i:], "/"); j >= 0 {
+		return i + j
+	}
+	return len(url)
+}
+
+// IsLocal reports whether a manifest source repository is a local
+// directory, which only ad-hoc installs record
+func IsLocal(sourceRepo string) bool {
+	return filepath.IsAbs(sourceRepo)
+}
+
+// String formats the source the way Parse reads it
+func (s Source) String() string {
+	out := s.Repo
+	if s.Path != "" {
+		out += "#" + s.Path
+	}
+	if s.Ref != "" {
+		out += "@" + s.Ref
+	}
+	return out
+}
+
+// SkillName is the name the skill is installed under by default: the last
+// element of its subdirectory, or of the repository without ".git"
+func (s Source) SkillName() string {
+	if s.Local {
+		return filepath.Base(s.Repo)
+	}
+	if s.Path != "" {
+		return path.Base(s.Path)
+	}
+	repo := strings.TrimSuffix(strings.TrimRight(s.Repo, "/"), ".git")
+	if i := strings.LastIndexAny(repo, "/:"); i >= 0 {
+		repo = repo[i+1:]
+	}
+	return repo
+}
+
+// Digest identifies the contents of a local directory; it stands in for a
+// commit in the manifest
+func Digest(dir string) (string, error) {
+	return integrity.HashDir(dir)
+}
+
+// Refresh copies the local skill directory src to dest, replacing what is
+// there, unless its digest is still digest. The copy is validated, and
+// inspected by check when set, before it replaces dest. It returns the new
+// digest, or "" when dest was up to date.
+func Refresh(src, dest, digest string, check func(dir string) error) (string, error) {
+	newDigest, err := Digest(src)
+	if err != nil {
+		return "", err
+	}
+	if newDigest == digest {
+		return "", nil
+	}
+
+	tmp, err := os.MkdirTemp(filepath.Dir(dest), ".adhoc-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	staged := filepath.Join(tmp, filepath.Base(dest))
+	if err := Copy(src, staged); err != nil {
+		return "", fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	if err := git.ValidateSkill(staged); err != nil {
+		return "", err
+	}
+	if check != nil {
+		if err := check(staged); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.RemoveAll(dest); err != nil {
+		return "", err
+	}
+	if err := os.Rename(staged, dest); err != nil {
+		return "", err
+	}
+	return newDigest, nil
+}
+
+// Copy copies the directory src to dest, which must not exist yet. Git
+// metadata and symlinks are left out.
+func Copy(src, dest string) error {
+	if err := os.Mkdir(dest, 0755); err != nil {
+		return err
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		target := filepath.Join(dest, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.Mkdir(target, info.Mode().Perm()|0700)
+		case !info.Mode().IsRegular():
+			return nil // symlinks could point outside the skill
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
@@ -0,0 +1,116 @@
+package adhoc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want Source
+		name string
+	}{
+		{"https://github.com/org/repo", Source{Repo: "https://github.com/org/repo"}, "repo"},
+		{"https://github.com/org/repo.git@v1.2.0", Source{Repo: "https://github.com/org/repo.git", Ref: "v1.2.0"}, "repo"},
+		{"https://github.com/org/repo#skills/pdf@main", Source{Repo: "https://github.com/org/repo", Path: "skills/pdf", Ref: "main"}, "pdf"},
+		{"ssh://git@example.com/org/repo#pdf/", Source{Repo: "ssh://git@example.com/org/repo", Path: "pdf"}, "pdf"},
+		{"git@github.com:org/repo", Source{Repo: "git@github.com:org/repo"}, "repo"},
+		{"git@github.com:org/repo.git@v2", Source{Repo: "git@github.com:org/repo.git", Ref: "v2"}, "repo"},
+		{"https://github.com/org/repo#pdf@feature/x", Source{Repo: "https://github.com/org/repo", Path: "pdf", Ref: "feature/x"}, "pdf"},
+		{"https://github.com/org/repo@feature/x", Source{Repo: "https://github.com/org/repo", Ref: "feature/x"}, "repo"},
+		{"ssh://git@example.com/org/repo@feature/x", Source{Repo: "ssh://git@example.com/org/repo", Ref: "feature/x"}, "repo"},
+		{"git@github.com:org/repo@feature/x", Source{Repo: "git@github.com:org/repo", Ref: "feature/x"}, "repo"},
+	} {
+		got, err := Parse(tc.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+		if got.SkillName() != tc.name {
+			t.Errorf("Parse(%q).SkillName() = %s, want %s", tc.in, got.SkillName(), tc.name)
+		}
+		if got.String() != tc.in && tc.in[len(tc.in)-1] != '/' {
+			t.Errorf("Parse(%q).String() = %s", tc.in, got.String())
+		}
+	}
+
+	for _, bad := range []string{"", "https://github.com/org/repo@", "https://github.com/org/repo#pdf@", "https://github.com/org/repo#../x"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParse_LocalDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	src, err := Parse(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !src.Local || src.Repo != dir || src.SkillName() != "my-skill" || !IsLocal(src.Repo) {
+		t.Errorf("Parse(%s) = %+v", dir, src)
+	}
+	if _, err := Parse(filepath.Join(dir, "missing")); err == nil {
+		t.Error("a missing directory should be refused")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	dest := filepath.Join(root, "skills", "my-skill")
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("SKILL.md", "# My skill\n")
+	write("scripts/run.sh", "echo hi\n")
+	write(".git/HEAD", "ref: refs/heads/main\n")
+	os.MkdirAll(filepath.Dir(dest), 0755)
+
+	digest, err := Refresh(src, dest, "", nil)
+	if err != nil || digest == "" {
+		t.Fatalf("Refresh = %q, %v", digest, err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "scripts", "run.sh")); err != nil {
+		t.Errorf("files were not copied: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".git")); !os.IsNotExist(err) {
+		t.Error("git metadata was copied")
+	}
+
+	if again, err := Refresh(src, dest, digest, nil); err != nil || again != "" {
+		t.Errorf("unchanged source: Refresh = %q, %v; want no copy", again, err)
+	}
+
+	write("SKILL.md", "# My skill, edited\n")
+	if again, err := Refresh(src, dest, digest, nil); err != nil || again == "" || again == digest {
+		t.Errorf("changed source: Refresh = %q, %v", again, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "SKILL.md")); string(data) != "# My skill, edited\n" {
+		t.Errorf("SKILL.md = %q", data)
+	}
+
+	// An invalid source leaves the installed copy alone
+	os.Remove(filepath.Join(src, "SKILL.md"))
+	if _, err := Refresh(src, dest, digest, nil); err == nil {
+		t.Error("a source without SKILL.md should be refused")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "SKILL.md")); err != nil {
+		t.Errorf("installed copy was touched: %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+// Package catalog renders the skill index as a shareable document, in
+// Markdown or standalone HTML, for team wikis: the skills grouped by
+// repository with their descriptions, tags and install commands.
+package catalog
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+	"lazyas/internal/remote"
+)
+
+// Formats are the supported output formats
+var Formats = []string{"markdown", "html"}
+
+// Catalog is the index grouped by repository
+type Catalog struct {
+	Generated time.Time
+	Repos     []Repo
+}
+
+// Repo is one configured repository and the skills it lists
+type Repo struct {
+	Name   string
+	URL    string
+	Skills []Skill
+}
+
+// Skill is a catalog entry
+type Skill struct {
+	Name        string
+	Version     string // registry tag, "" for the default branch
+	Description string
+	Tags        []string
+	Install     string // command installing exactly this skill
+	Link        string // web page of its source, "" when not on the web
+}
+
+// New groups skills by their config repository, in config order with the
+// skills sorted by name. Skills of repositories no longer configured are
+// left out, as are repositories without skills.
+func New(repos []config.Repo, skills []registry.SkillEntry, generated time.Time) Catalog {
+	byRepo := make(map[string][]Skill)
+	for _, s := range skills {
+		link := remote.WebURL(s.Source.Repo, s.Source.Tag, s.Source.Path)
+		if !strings.HasPrefix(link, "https://") && !strings.HasPrefix(link, "http://") {
+			link = "" // local paths and ssh URLs mean nothing to readers
+		}
+		byRepo[s.Source.RepoName] = append(byRepo[s.Source.RepoName], Skill{
+			Name:        s.Name,
+			Version:     s.Source.Tag,
+			Description: s.Description,
+			Tags:        s.Tags,
+			Install:     "lazyas install " + s.InstallRef(),
+			Link:        link,
+		})
+	}
+
+	c := Catalog{Generated: generated}
+	for _, repo := range repos {
+		list := byRepo[repo.Name]
+		if len(list) == 0 {
+			continue
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+		c.Repos = append(c.Repos, Repo{Name: repo.Name, URL: repo.URL, Skills: list})
+	}
+	return c
+}
+
+// Count returns the number of skills in the catalog
+func (c Catalog) Count() int {
+	n := 0
+	for _, repo := range c.Repos {
+		n += len(repo.Skills)
+	}
+	return n
+}
+
+// AddCommand is the command that configures the repository
+func (r Repo) AddCommand() string {
+	return fmt.Sprintf("lazyas config repo add %s %s", r.Name, r.URL)
+}
+
+// summary is the line under the catalog title
+func (c Catalog) summary() string {
+	return i18n.Tf("%d skill(s) from %d repo(s), generated by lazyas on %s.", c.Count(), len(c.Repos), c.Generated.Format("2006-01-02"))
+}
+
+// CheckFormat returns an error unless format is one of Formats
+func CheckFormat(format string) error {
+	if !slices.Contains(Formats, format) {
+		return fmt.Errorf("unknown format %q (want %s)", format, strings.Join(Formats, " or "))
+	}
+	return nil
+}
+
+// Write renders the catalog in format, one of Formats
+func (c Catalog) Write(w io.Writer, format string) error {
+	if err := CheckFormat(format); err != nil {
+		return err
+	}
+	if format == "html" {
+		return c.WriteHTML(w)
+	}
+	return c.WriteMarkdown(w)
+}
+
+// WriteMarkdown renders the catalog as a Markdown document
+func (c Catalog) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(i18n.T("# Skill catalog") + "\n\n")
+	b.WriteString(c.summary() + "\n")
+	if len(c.Repos) == 0 {
+		b.WriteString("\n" + i18n.T("No skills found.") + "\n")
+	}
+
+	for _, repo := range c.Repos {
+		fmt.Fprintf(&b, "\n## %s\n\n", repo.Name)
+		fmt.Fprintf(&b, "%s\n\n", repo.URL)
+		fmt.Fprintf(&b, "```bash\n%s\n```\n", repo.AddCommand())
+
+		for _, s := range repo.Skills {
+			fmt.Fprintf(&b, "\n### %s", s.Name)
+			if s.Version != "" {
+				fmt.Fprintf(&b, " `%s`", s.Version)
+			}
+			b.WriteString("\n\n")
+			if s.Description != "" {
+				b.WriteString(s.Description + "\n\n")
+			}
+			if len(s.Tags) > 0 {
+				b.WriteString(i18n.T("Tags:") + " `" + strings.Join(s.Tags, "` `") + "`\n\n")
+			}
+			fmt.Fprintf(&b, "```bash\n%s\n```\n", s.Install)
+			if s.Link != "" {
+				fmt.Fprintf(&b, "\n[%s](%s)\n", i18n.T("Source"), s.Link)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteHTML renders the catalog as a standalone HTML page
+func (c Catalog) WriteHTML(w io.Writer) error {
+	return htmlPage.Execute(w, struct {
+		Catalog
+		Title, Summary, Empty, TagsLabel, SourceLabel string
+	}{
+		Catalog:     c,
+		Title:       i18n.T("Skill catalog"),
+		Summary:     c.summary(),
+		Empty:       i18n.T("No skills found."),
+		TagsLabel:   i18n.T("Tags:"),
+		SourceLabel: i18n.T("Source"),
+	})
+}
+
+var htmlPage = template.Must(template.New("catalog").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 56rem; margin: 2rem auto; padding: 0 1rem; color: #1f2937; }
+h2 { border-bottom: 1px solid #e5e7eb; padding-bottom: .3rem; margin-top: 2.5rem; }
+.skill { margin: 1.2rem 0; }
+.skill h3 { margin-bottom: .3rem; }
+.version { font-weight: normal; color: #6b7280; font-size: .9em; }
+.tag { display: inline-block; background: #ede9fe; color: #5b21b6; border-radius: .3rem; padding: 0 .4rem; margin-right: .3rem; font-size: .85em; }
+pre { background: #f3f4f6; padding: .5rem .8rem; border-radius: .3rem; overflow-x: auto; }
+.muted { color: #6b7280; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="muted">{{.Summary}}</p>
+{{- if not .Repos}}
+<p>{{.Empty}}</p>
+{{- else}}
+<ul>
+{{- range .Repos}}
+<li><a href="#repo-{{.Name}}">{{.Name}}</a> ({{len .Skills}})</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- range .Repos}}
+<h2 id="repo-{{.Name}}">{{.Name}}</h2>
+<p class="muted">{{.URL}}</p>
+<pre><code>{{.AddCommand}}</code></pre>
+{{- range .Skills}}
+<div class="skill">
+<h3>{{.Name}}{{if .Version}} <span class="version">{{.Version}}</span>{{end}}</h3>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Tags}}
+<p>{{$.TagsLabel}} {{range .Tags}}<span class="tag">{{.}}</span>{{end}}</p>
+{{- end}}
+<pre><code>{{.Install}}</code></pre>
+{{- if .Link}}
+<p><a href="{{.Link}}">{{$.SourceLabel}}</a></p>
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
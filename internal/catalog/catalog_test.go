@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lazyas/internal/config"
+	"lazyas/internal/registry"
+)
+
+func testCatalog() Catalog {
+	repos := []config.Repo{
+		{Name: "team", URL: "https://github.com/acme/skills"},
+		{Name: "empty", URL: "https://github.com/acme/empty"},
+		{Name: "local", URL: "file:///srv/skills"},
+	}
+	skills := []registry.SkillEntry{
+		{Name: "pdf", Description: "Read <PDF> files", Tags: []string{"docs", "files"},
+			Source: registry.SkillSource{Repo: "https://github.com/acme/skills", Path: "pdf", Tag: "v1.2.0", RepoName: "team"}},
+		{Name: "docx", Source: registry.SkillSource{Repo: "https://github.com/acme/skills", Path: "docx", RepoName: "team"}},
+		{Name: "notes", Source: registry.SkillSource{Repo: "file:///srv/skills", RepoName: "local"}},
+		{Name: "orphan", Source: registry.SkillSource{Repo: "https://example.com/gone", RepoName: "gone"}},
+	}
+	return New(repos, skills, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+}
+
+func TestNew_GroupsByConfiguredRepo(t *testing.T) {
+	c := testCatalog()
+	if len(c.Repos) != 2 || c.Repos[0].Name != "team" || c.Repos[1].Name != "local" {
+		t.Fatalf("unexpected repos: %+v", c.Repos)
+	}
+	if c.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", c.Count())
+	}
+
+	team := c.Repos[0].Skills
+	if team[0].Name != "docx" || team[1].Name != "pdf" {
+		t.Errorf("expected skills sorted by name, got %s, %s", team[0].Name, team[1].Name)
+	}
+	if team[1].Install != "lazyas install team/pdf@v1.2.0" {
+		t.Errorf("unexpected install command %q", team[1].Install)
+	}
+	if team[1].Link != "https://github.com/acme/skills/tree/v1.2.0/pdf" {
+		t.Errorf("unexpected link %q", team[1].Link)
+	}
+	if link := c.Repos[1].Skills[0].Link; link != "" {
+		t.Errorf("expected no link for a local repo, got %q", link)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	c := testCatalog()
+
+	var md strings.Builder
+	if err := c.Write(&md, "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Skill catalog",
+		"3 skill(s) from 2 repo(s), generated by lazyas on 2026-01-02.",
+		"## team",
+		"lazyas config repo add team https://github.com/acme/skills",
+		"### pdf `v1.2.0`",
+		"Tags: `docs` `files`",
+		"lazyas install team/pdf@v1.2.0",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown lacks %q:\n%s", want, md.String())
+		}
+	}
+
+	var page strings.Builder
+	if err := c.Write(&page, "html"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<h2 id="repo-team">team</h2>`,
+		"Read &lt;PDF&gt; files",
+		`<span class="tag">docs</span>`,
+		`<a href="https://github.com/acme/skills/tree/v1.2.0/pdf">`,
+	} {
+		if !strings.Contains(page.String(), want) {
+			t.Errorf("html lacks %q:\n%s", want, page.String())
+		}
+	}
+
+	if err := c.Write(&page, "pdf"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,128 @@
+// Package clean finds what is left behind when skills or clones are
+// deleted by hand: manifest entries without files, skill directories
+// without a SKILL.md and repository clones nothing uses anymore.
+package clean
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/manifest"
+)
+
+// Report lists the leftovers found. Names in the skills lists are skill
+// directory names; clones are directory names under the repos directory.
+type Report struct {
+	MissingDirs  []string // tracked skills whose directory is gone
+	NoSkillMD    []string // skill directories without a SKILL.md
+	BrokenLinks  []string // untracked links whose target is gone
+	UnusedClones []string // clones of repositories that are not configured and used by no skill
+}
+
+// Empty reports whether there is nothing to clean
+func (r *Report) Empty() bool {
+	return len(r.MissingDirs) == 0 && len(r.NoSkillMD) == 0 && len(r.BrokenLinks) == 0 && len(r.UnusedClones) == 0
+}
+
+// Count is the number of leftovers found
+func (r *Report) Count() int {
+	return len(r.MissingDirs) + len(r.NoSkillMD) + len(r.BrokenLinks) + len(r.UnusedClones)
+}
+
+// Find looks for leftovers in the skills and repos directories. Clones of
+// configured repositories are kept even when no skill is installed from
+// them: the index and lazyas prefetch use them.
+func Find(cfg *config.Config, mfst *manifest.Manager) (*Report, error) {
+	report := &Report{}
+	installed := mfst.ListInstalled()
+	stale := make(map[string]bool)
+
+	for name := range installed {
+		if _, err := os.Stat(mfst.GetSkillPath(name)); os.IsNotExist(err) {
+			report.MissingDirs = append(report.MissingDirs, name)
+			stale[name] = true
+		}
+	}
+
+	entries, err := os.ReadDir(cfg.SkillsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var links []string // targets of the skills that stay
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") || stale[name] {
+			continue
+		}
+		if _, isAlias := mfst.AliasOwner(name); isAlias {
+			continue
+		}
+		path := filepath.Join(cfg.SkillsDir, name)
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			report.BrokenLinks = append(report.BrokenLinks, name)
+			continue
+		}
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(path, "SKILL.md")); os.IsNotExist(err) {
+			report.NoSkillMD = append(report.NoSkillMD, name)
+			stale[name] = true
+			continue
+		}
+		if target, err := filepath.EvalSymlinks(path); err == nil {
+			links = append(links, target)
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, repo := range cfg.Repos {
+		used[git.RepoDirName(repo.URL)] = true
+	}
+	for name, info := range installed {
+		if !stale[name] {
+			used[git.RepoDirName(info.SourceRepo)] = true
+		}
+	}
+	// Whatever the manifest says, a clone a skill links into is in use
+	if reposDir, err := filepath.EvalSymlinks(cfg.ReposDir); err == nil {
+		for _, target := range links {
+			if rel, err := filepath.Rel(reposDir, target); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+				used[strings.Split(rel, string(filepath.Separator))[0]] = true
+			}
+		}
+	}
+
+	clones, err := os.ReadDir(cfg.ReposDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range clones {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") && !used[e.Name()] {
+			report.UnusedClones = append(report.UnusedClones, e.Name())
+		}
+	}
+
+	sort.Strings(report.MissingDirs)
+	return report, nil
+}
+
+// DropMissing removes the manifest entries of the skills in MissingDirs,
+// along with the links of theirs whose target is gone
+func (r *Report) DropMissing(mfst *manifest.Manager) error {
+	for _, name := range r.MissingDirs {
+		if err := mfst.RemoveSkill(name); err != nil {
+			return fmt.Errorf("failed to update manifest: %w", err)
+		}
+		if err := os.Remove(mfst.GetSkillPath(name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", name, err)
+		}
+	}
+	return nil
+}
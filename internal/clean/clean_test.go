@@ -0,0 +1,126 @@
+package clean
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/manifest"
+)
+
+func writeSkill(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# skill"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		SkillsDir:    filepath.Join(tmp, "skills"),
+		ReposDir:     filepath.Join(tmp, "repos"),
+		ConfigDir:    tmp,
+		ManifestPath: filepath.Join(tmp, "manifest.yaml"),
+		Repos:        []config.Repo{{Name: "acme", URL: "https://github.com/acme/skills"}},
+	}
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// pdf is linked from a clone of an unconfigured repo; gone was deleted
+	// by hand and its clone is now unused
+	if err := os.MkdirAll(cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	pdfClone := filepath.Join(cfg.ReposDir, git.RepoDirName("https://github.com/old/tools"), "pdf")
+	writeSkill(t, pdfClone)
+	if err := os.Symlink(pdfClone, filepath.Join(cfg.SkillsDir, "pdf")); err != nil {
+		t.Fatal(err)
+	}
+	writeSkill(t, filepath.Join(cfg.ReposDir, git.RepoDirName("https://github.com/gone/skills"), "gone"))
+	writeSkill(t, filepath.Join(cfg.ReposDir, git.RepoDirName("https://github.com/acme/skills"), "csv"))
+	if err := mfst.AddSkill("gone", "", "aaa", "https://github.com/gone/skills", "gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(cfg.SkillsDir, "scratch"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(tmp, "nowhere"), filepath.Join(cfg.SkillsDir, "dangling")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(cfg.SkillsDir, ".lazyas"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Find(cfg, mfst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Report{
+		MissingDirs:  []string{"gone"},
+		NoSkillMD:    []string{"scratch"},
+		BrokenLinks:  []string{"dangling"},
+		UnusedClones: []string{"gone-skills"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("got %+v, want %+v", report, want)
+	}
+	if report.Count() != 4 || report.Empty() {
+		t.Errorf("unexpected count %d", report.Count())
+	}
+}
+
+func TestDropMissing_RemovesDanglingLink(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		SkillsDir:    filepath.Join(tmp, "skills"),
+		ReposDir:     filepath.Join(tmp, "repos"),
+		ConfigDir:    tmp,
+		ManifestPath: filepath.Join(tmp, "manifest.yaml"),
+	}
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// pdf's clone was deleted by hand, leaving its link dangling
+	if err := os.MkdirAll(cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(cfg.SkillsDir, "pdf")
+	if err := os.Symlink(filepath.Join(cfg.ReposDir, "gone", "pdf"), link); err != nil {
+		t.Fatal(err)
+	}
+	if err := mfst.AddSkill("pdf", "", "aaa", "https://github.com/gone/skills", "pdf"); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Find(cfg, mfst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report, &Report{MissingDirs: []string{"pdf"}}) {
+		t.Fatalf("got %+v", report)
+	}
+	if err := report.DropMissing(mfst); err != nil {
+		t.Fatal(err)
+	}
+	if mfst.IsInstalled("pdf") {
+		t.Error("manifest entry was kept")
+	}
+	if _, err := os.Lstat(link); !os.IsNotExist(err) {
+		t.Errorf("dangling link was kept: %v", err)
+	}
+	if again, _ := Find(cfg, mfst); !again.Empty() {
+		t.Errorf("a second clean found %+v", again)
+	}
+}
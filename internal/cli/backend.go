@@ -2,10 +2,17 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
 	"lazyas/internal/symlink"
+	"lazyas/internal/trash"
 )
 
 var backendCmd = &cobra.Command{
@@ -15,7 +22,9 @@ var backendCmd = &cobra.Command{
 and AI agent backend skill directories.
 
 lazyas manages skills in ~/.lazyas/skills/ and symlinks
-backend directories (e.g., ~/.claude/skills/) to it.`,
+backend directories (e.g., ~/.claude/skills/) to it. A backend in
+per-skill mode instead gets a directory with one link per enabled
+skill; see 'lazyas enable'.`,
 }
 
 var backendListCmd = &cobra.Command{
@@ -36,9 +45,15 @@ If no backend name is given, links all unlinked backends.
 If the backend directory already exists with files, lazyas will
 offer to migrate them to the central directory.
 
+With --per-skill the backend directory holds one link per skill
+instead, managed with 'lazyas enable' and 'lazyas disable', and its
+other files stay in place. A backend linked as a whole keeps seeing
+every skill installed now; otherwise it starts with none.
+
 Examples:
   lazyas backend link           # Link all unlinked backends
-  lazyas backend link claude    # Link specific backend`,
+  lazyas backend link claude    # Link specific backend
+  lazyas backend link codex --per-skill`,
 	RunE: runBackendLink,
 }
 
@@ -48,6 +63,9 @@ var backendUnlinkCmd = &cobra.Command{
 	Long: `Remove the symlink from a backend's skills directory.
 This does not delete any skills.
 
+A per-skill backend loses its skill links and returns to whole
+directory mode.
+
 Examples:
   lazyas backend unlink claude`,
 	Args: cobra.ExactArgs(1),
@@ -79,16 +97,70 @@ Examples:
 	RunE:    runBackendRemove,
 }
 
-var backendDescription string
+var backendWorkspaceCmd = &cobra.Command{
+	Use:   "workspace [dir]",
+	Short: "Expose skills to the agent folders of a project",
+	Long: `Detect the agent folders of a project (./.claude, ./.cursor, ...) and
+add a per-skill backend for each, so the agents working in that project
+see the skills you enable there, next to the project's own skills.
+
+The project is the nearest of the directory (default: the current one)
+and its parents holding an agent folder. Backends are named after the
+agent and project, e.g. claude-myapp, and enable no skills until you do.
+
+Examples:
+  lazyas backend workspace
+  lazyas backend workspace --enable pdf --enable docx
+  lazyas enable pdf --backend claude-myapp`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBackendWorkspace,
+}
+
+var backendVerifyCmd = &cobra.Command{
+	Use:   "verify [name]",
+	Short: "Check backend links and repair them with --fix",
+	Long: `Check the links of every backend (or one) for problems:
+
+  - a link whose target was moved or deleted
+  - a link to another skills directory than the current one, e.g. after
+    skills_dir changed
+  - per-skill links to removed skills, or into a skills directory that
+    is gone
+  - a backend directory a migration only partly moved into the skills
+    directory
+
+With --fix the problems are repaired: links are pointed at the skills
+directory, dead per-skill links removed, and a partly migrated directory
+is migrated again, its leftover copies of skills the skills directory
+already has going to the trash. Exits with status 1 while problems remain.
+
+Examples:
+  lazyas backend verify
+  lazyas backend verify claude --fix`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBackendVerify,
+}
+
+var (
+	backendDescription string
+	backendPerSkill    bool
+	backendFix         bool
+	workspaceEnable    []string
+)
 
 func init() {
 	backendAddCmd.Flags().StringVar(&backendDescription, "description", "", "Human-readable description for the backend")
+	backendLinkCmd.Flags().BoolVar(&backendPerSkill, "per-skill", false, "Link enabled skills one by one instead of the whole directory")
+	backendVerifyCmd.Flags().BoolVar(&backendFix, "fix", false, "Repair the problems found")
+	backendWorkspaceCmd.Flags().StringSliceVar(&workspaceEnable, "enable", nil, "Skill to enable for the project (repeatable)")
 
 	backendCmd.AddCommand(backendListCmd)
 	backendCmd.AddCommand(backendLinkCmd)
 	backendCmd.AddCommand(backendUnlinkCmd)
 	backendCmd.AddCommand(backendAddCmd)
 	backendCmd.AddCommand(backendRemoveCmd)
+	backendCmd.AddCommand(backendWorkspaceCmd)
+	backendCmd.AddCommand(backendVerifyCmd)
 }
 
 func runBackendList(cmd *cobra.Command, args []string) error {
@@ -100,15 +172,24 @@ func runBackendList(cmd *cobra.Command, args []string) error {
 	statuses := symlink.CheckBackendLinks(cfg.Backends, cfg.SkillsDir)
 
 	if len(statuses) == 0 {
-		fmt.Println("No backends configured.")
+		fmt.Println(i18n.T("No backends configured."))
 		return nil
 	}
 
-	fmt.Println("Backends:")
+	needsRepair := make(map[string]bool)
+	for _, p := range symlink.Verify(cfg.Backends, cfg.SkillsDir) {
+		needsRepair[p.Backend.Name] = true
+	}
+
+	fmt.Println(i18n.T("Backends:"))
 	for _, s := range statuses {
 		expandedPath, _ := config.ExpandPath(s.Backend.Path)
 		status := "○ not linked"
-		if s.Linked {
+		if needsRepair[s.Backend.Name] {
+			status = i18n.T("⚠ needs repair (run 'lazyas backend verify')")
+		} else if s.Linked && s.Backend.PerSkill() {
+			status = i18n.Tf("✓ per-skill (%d enabled)", len(symlink.EnabledSkills(s.Backend, cfg.SkillsDir)))
+		} else if s.Linked {
 			status = "✓ linked"
 		} else if s.HasFiles {
 			status = "○ has files (run 'lazyas backend link' to migrate)"
@@ -127,6 +208,78 @@ func runBackendList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	printWorkspaceHint(cfg)
+	return nil
+}
+
+// printWorkspaceHint points out agent folders of the current project
+// that no backend links yet
+func printWorkspaceHint(cfg *config.Config) {
+	found := cfg.WorkspaceBackends(".")
+	if len(found) == 0 {
+		return
+	}
+	var folders []string
+	for _, w := range found {
+		folders = append(folders, w.Folder)
+	}
+	fmt.Println()
+	fmt.Println(i18n.Tf("This project has agent folders lazyas does not link (%s).", strings.Join(folders, ", ")))
+	fmt.Println(i18n.T("Run 'lazyas backend workspace' to expose skills to them."))
+}
+
+func runBackendWorkspace(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	project, ok := config.FindWorkspace(dir)
+	if !ok {
+		return fmt.Errorf("no agent folders (.claude, .cursor, ...) found in %s or its parents", dir)
+	}
+	found := cfg.WorkspaceBackends(project)
+	if len(found) == 0 {
+		fmt.Println(i18n.Tf("Every agent folder of %s already has a backend.", project))
+		return nil
+	}
+	for _, name := range workspaceEnable {
+		if _, err := os.Stat(filepath.Join(cfg.SkillsDir, name)); err != nil {
+			return fmt.Errorf("skill %s is not installed", name)
+		}
+	}
+
+	fmt.Println(i18n.Tf("Agent folders in %s:", project))
+	for _, w := range found {
+		fmt.Printf("  %-20s %s\n", w.Name, w.Path)
+	}
+	if !confirm(i18n.T("Add them as per-skill backends? [y/N]: ")) {
+		fmt.Println(i18n.T("Cancelled"))
+		return nil
+	}
+
+	if err := cfg.EnsureDirs(); err != nil {
+		return fmt.Errorf("failed to create directories: %w", err)
+	}
+	for _, w := range found {
+		if err := symlink.ConvertToPerSkill(w.Backend, cfg.SkillsDir, workspaceEnable); err != nil {
+			return fmt.Errorf("failed to link '%s': %w", w.Name, err)
+		}
+		if err := cfg.AddBackend(w.Name, w.Path, w.Description); err != nil {
+			return fmt.Errorf("failed to add backend: %w", err)
+		}
+		if err := cfg.SetBackendMode(w.Name, config.BackendPerSkill); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Println(i18n.Tf("Linked '%s' per skill (%d enabled) ✓", w.Name, len(workspaceEnable)))
+	}
+	if len(workspaceEnable) == 0 {
+		fmt.Println(i18n.Tf("Enable skills with 'lazyas enable <skill> --backend %s'", found[0].Name))
+	}
 	return nil
 }
 
@@ -141,6 +294,13 @@ func runBackendLink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create directories: %w", err)
 	}
 
+	if backendPerSkill {
+		if len(args) == 0 {
+			return fmt.Errorf("--per-skill requires a backend name")
+		}
+		return linkPerSkill(cfg, args[0])
+	}
+
 	statuses := symlink.CheckBackendLinks(cfg.Backends, cfg.SkillsDir)
 
 	var toLink []symlink.LinkStatus
@@ -152,7 +312,7 @@ func runBackendLink(cmd *cobra.Command, args []string) error {
 			if s.Backend.Name == name {
 				found = true
 				if s.Linked {
-					fmt.Printf("Backend '%s' is already linked.\n", name)
+					fmt.Println(i18n.Tf("Backend '%s' is already linked.", name))
 					return nil
 				}
 				toLink = append(toLink, s)
@@ -166,7 +326,7 @@ func runBackendLink(cmd *cobra.Command, args []string) error {
 		// Link all unlinked backends
 		toLink = symlink.GetUnlinkedBackends(statuses)
 		if len(toLink) == 0 {
-			fmt.Println("All backends are already linked.")
+			fmt.Println(i18n.T("All backends are already linked."))
 			return nil
 		}
 	}
@@ -174,42 +334,94 @@ func runBackendLink(cmd *cobra.Command, args []string) error {
 	for _, s := range toLink {
 		expandedPath, _ := config.ExpandPath(s.Backend.Path)
 
-		if s.Exists && s.HasFiles && !s.IsSymlink {
+		if s.Backend.PerSkill() {
+			if err := symlink.ConvertToPerSkill(s.Backend, cfg.SkillsDir, nil); err != nil {
+				fmt.Println(i18n.Tf("Failed to link '%s': %v", s.Backend.Name, err))
+				continue
+			}
+			fmt.Println(i18n.Tf("Linked '%s' per skill: %s ✓", s.Backend.Name, expandedPath))
+		} else if s.Exists && s.HasFiles && !s.IsSymlink {
 			// Directory exists with files - offer to migrate
-			fmt.Printf("Backend '%s': %s exists with files.\n", s.Backend.Name, expandedPath)
-			fmt.Printf("Move files to %s and create symlink? [y/N]: ", cfg.SkillsDir)
-			var response string
-			fmt.Scanln(&response)
-			if response != "y" && response != "Y" {
-				fmt.Printf("Skipping '%s'.\n", s.Backend.Name)
+			fmt.Println(i18n.Tf("Backend '%s': %s exists with files.", s.Backend.Name, expandedPath))
+			if !confirm(i18n.Tf("Move files to %s and create symlink? [y/N]: ", cfg.SkillsDir)) {
+				fmt.Println(i18n.Tf("Skipping '%s'.", s.Backend.Name))
 				continue
 			}
 
-			if err := symlink.MigrateExistingDir(s.Backend, cfg.SkillsDir); err != nil {
-				fmt.Printf("Failed to migrate '%s': %v\n", s.Backend.Name, err)
+			if err := symlink.MigrateExistingDir(s.Backend, cfg.SkillsDir, trashStore(cfg).Discard(trash.ReasonMigrate)); err != nil {
+				fmt.Println(i18n.Tf("Failed to migrate '%s': %v", s.Backend.Name, err))
 				continue
 			}
-			fmt.Printf("Migrated and linked '%s' ✓\n", s.Backend.Name)
+			fmt.Println(i18n.Tf("Migrated and linked '%s' ✓", s.Backend.Name))
 		} else if s.Exists && !s.IsSymlink {
 			// Empty directory exists - remove and symlink
-			if err := symlink.MigrateExistingDir(s.Backend, cfg.SkillsDir); err != nil {
-				fmt.Printf("Failed to link '%s': %v\n", s.Backend.Name, err)
+			if err := symlink.MigrateExistingDir(s.Backend, cfg.SkillsDir, trashStore(cfg).Discard(trash.ReasonMigrate)); err != nil {
+				fmt.Println(i18n.Tf("Failed to link '%s': %v", s.Backend.Name, err))
 				continue
 			}
-			fmt.Printf("Linked '%s' ✓\n", s.Backend.Name)
+			fmt.Println(i18n.Tf("Linked '%s' ✓", s.Backend.Name))
 		} else if !s.Exists {
 			// Nothing exists - create symlink directly
 			if err := symlink.CreateLink(s.Backend, cfg.SkillsDir); err != nil {
-				fmt.Printf("Failed to link '%s': %v\n", s.Backend.Name, err)
+				fmt.Println(i18n.Tf("Failed to link '%s': %v", s.Backend.Name, err))
 				continue
 			}
-			fmt.Printf("Linked '%s': %s → %s ✓\n", s.Backend.Name, expandedPath, cfg.SkillsDir)
+			fmt.Println(i18n.Tf("Linked '%s': %s → %s ✓", s.Backend.Name, expandedPath, cfg.SkillsDir))
 		}
 	}
 
 	return nil
 }
 
+func runBackendVerify(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	backends := cfg.Backends
+	if len(args) > 0 {
+		backend := cfg.GetBackend(args[0])
+		if backend == nil {
+			return fmt.Errorf("backend '%s' not found. Use 'lazyas backend list' to see configured backends", args[0])
+		}
+		backends = []config.Backend{*backend}
+	}
+
+	problems := symlink.Verify(backends, cfg.SkillsDir)
+	if len(problems) == 0 {
+		fmt.Println(i18n.T("All backend links are healthy ✓"))
+		return nil
+	}
+
+	remaining := 0
+	for _, p := range problems {
+		fmt.Printf("  %-12s %s\n", p.Backend.Name, p)
+		if !backendFix {
+			fmt.Printf("  %-12s %s\n", "", i18n.Tf("fix: %s", p.Fix()))
+			remaining++
+			continue
+		}
+		if err := symlink.Repair(p, cfg.SkillsDir, trashStore(cfg).Discard(trash.ReasonMigrate)); err != nil {
+			fmt.Printf("  %-12s %s\n", "", i18n.Tf("✗ repair failed: %v", err))
+			remaining++
+			continue
+		}
+		fmt.Printf("  %-12s %s\n", "", i18n.Tf("✓ repaired: %s", p.Fix()))
+	}
+
+	fmt.Println()
+	switch {
+	case !backendFix:
+		fmt.Println(i18n.Tf("%d problem(s) found. Run 'lazyas backend verify --fix' to repair them.", remaining))
+	case remaining > 0:
+		fmt.Println(i18n.Tf("Repaired %d of %d problem(s).", len(problems)-remaining, len(problems)))
+	default:
+		fmt.Println(i18n.Tf("Repaired %d problem(s) ✓", len(problems)))
+	}
+	return exitCode(cmd, min(remaining, 1))
+}
+
 func runBackendUnlink(cmd *cobra.Command, args []string) error {
 	cfg, err := config.DefaultConfig()
 	if err != nil {
@@ -224,7 +436,19 @@ func runBackendUnlink(cmd *cobra.Command, args []string) error {
 
 	statuses := symlink.CheckBackendLinks([]config.Backend{*backend}, cfg.SkillsDir)
 	if len(statuses) == 0 || !statuses[0].Linked {
-		fmt.Printf("Backend '%s' is not linked.\n", name)
+		fmt.Println(i18n.Tf("Backend '%s' is not linked.", name))
+		return nil
+	}
+
+	if backend.PerSkill() {
+		removed, err := symlink.RemoveSkillLinks(*backend, cfg.SkillsDir)
+		if err != nil {
+			return fmt.Errorf("failed to unlink '%s': %w", name, err)
+		}
+		if err := cfg.SetBackendMode(name, ""); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Println(i18n.Tf("Unlinked '%s' (removed %d skill link(s)) ✓", name, removed))
 		return nil
 	}
 
@@ -232,7 +456,46 @@ func runBackendUnlink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to unlink '%s': %w", name, err)
 	}
 
-	fmt.Printf("Unlinked '%s' ✓\n", name)
+	fmt.Println(i18n.Tf("Unlinked '%s' ✓", name))
+	return nil
+}
+
+// linkPerSkill switches a backend to per-skill mode. A backend that was
+// linked as a whole keeps every skill it could see.
+func linkPerSkill(cfg *config.Config, name string) error {
+	backend := cfg.GetBackend(name)
+	if backend == nil {
+		return fmt.Errorf("backend '%s' not found. Use 'lazyas backend list' to see configured backends", name)
+	}
+	status := symlink.CheckBackendLinks([]config.Backend{*backend}, cfg.SkillsDir)[0]
+	if status.Linked && backend.PerSkill() {
+		fmt.Println(i18n.Tf("Backend '%s' is already linked per skill.", name))
+		return nil
+	}
+
+	var enable []string
+	if status.Linked {
+		mfst := manifest.NewManager(cfg)
+		if err := mfst.Load(); err != nil {
+			return fmt.Errorf("failed to load manifest: %w", err)
+		}
+		for skill := range mfst.ScanLocalSkills() {
+			enable = append(enable, skill)
+		}
+		sort.Strings(enable)
+	}
+
+	converted := *backend
+	converted.Mode = config.BackendPerSkill
+	if err := symlink.ConvertToPerSkill(converted, cfg.SkillsDir, enable); err != nil {
+		return fmt.Errorf("failed to link '%s': %w", name, err)
+	}
+	if err := cfg.SetBackendMode(name, config.BackendPerSkill); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("Linked '%s' per skill (%d enabled) ✓", name, len(enable)))
+	fmt.Println(i18n.Tf("Enable skills with 'lazyas enable <skill> --backend %s'", name))
 	return nil
 }
 
@@ -249,8 +512,8 @@ func runBackendAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to add backend: %w", err)
 	}
 
-	fmt.Printf("Added backend '%s': %s\n", name, path)
-	fmt.Printf("Run 'lazyas backend link %s' to create the symlink.\n", name)
+	fmt.Println(i18n.Tf("Added backend '%s': %s", name, path))
+	fmt.Println(i18n.Tf("Run 'lazyas backend link %s' to create the symlink.", name))
 	return nil
 }
 
@@ -275,7 +538,7 @@ func runBackendRemove(cmd *cobra.Command, args []string) error {
 		statuses := symlink.CheckBackendLinks([]config.Backend{*backend}, cfg.SkillsDir)
 		if len(statuses) > 0 && statuses[0].Linked {
 			if err := symlink.RemoveLink(*backend); err != nil {
-				fmt.Printf("Warning: failed to remove symlink: %v\n", err)
+				fmt.Println(i18n.Tf("Warning: failed to remove symlink: %v", err))
 			}
 		}
 	}
@@ -284,6 +547,6 @@ func runBackendRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to remove backend: %w", err)
 	}
 
-	fmt.Printf("Removed backend '%s'\n", name)
+	fmt.Println(i18n.Tf("Removed backend '%s'", name))
 	return nil
 }
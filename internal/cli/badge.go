@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/registry"
+	"lazyas/internal/remote"
+)
+
+var (
+	badgePin   bool
+	badgeStyle string
+)
+
+var badgeCmd = &cobra.Command{
+	Use:   "badge <name>",
+	Short: "Print a README badge and install snippet for a skill",
+	Long: `Print Markdown for skill authors to paste into a README: a
+shields.io badge with the skill's current version linking to its source,
+and the commands that install it with lazyas.
+
+The snippet adds the skill's repository under the name it has in your
+config and installs the skill from that repository, so it works even when
+readers have other repos providing a skill with the same name.
+
+Examples:
+  lazyas badge pdf
+  lazyas badge anthropic-official/pdf --pin --style flat-square`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBadge,
+}
+
+func init() {
+	badgeCmd.Flags().BoolVar(&badgePin, "pin", false, "Install the current version instead of the latest")
+	badgeCmd.Flags().StringVar(&badgeStyle, "style", "", "shields.io badge style (flat, flat-square, for-the-badge, ...)")
+}
+
+func runBadge(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	reg := registry.NewRegistry(cfg)
+	if err := reg.Fetch(false); err != nil {
+		return fmt.Errorf("failed to fetch registry: %w", err)
+	}
+	skill := reg.GetSkill(args[0])
+	if skill == nil {
+		return fmt.Errorf("skill %s not found in registry", args[0])
+	}
+
+	fmt.Print(badgeMarkdown(skill))
+	return nil
+}
+
+// badgeMarkdown renders the badge and install snippet for skill
+func badgeMarkdown(skill *registry.SkillEntry) string {
+	version := skill.Source.Tag
+	if version == "" {
+		version = "latest"
+	}
+
+	badge := fmt.Sprintf("https://img.shields.io/badge/%s-%s-blue",
+		shieldsEscape("lazyas"), shieldsEscape(skill.Name+" "+version))
+	if badgeStyle != "" {
+		badge += "?style=" + url.QueryEscape(badgeStyle)
+	}
+	link := remote.WebURL(skill.Source.Repo, skill.Source.Tag, skill.Source.Path)
+
+	ref := skill.Name
+	if skill.Source.RepoName != "" {
+		ref = skill.Source.RepoName + "/" + ref
+	}
+	if badgePin && skill.Source.Tag != "" {
+		ref += "@" + skill.Source.Tag
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[![lazyas: %s %s](%s)](%s)\n\n", skill.Name, version, badge, link)
+	b.WriteString("```bash\n")
+	if skill.Source.RepoName != "" {
+		fmt.Fprintf(&b, "lazyas config repo add %s %s\n", skill.Source.RepoName, skill.Source.Repo)
+	}
+	fmt.Fprintf(&b, "lazyas install %s\n", ref)
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// shieldsEscape encodes text for a shields.io static badge path segment,
+// where "-" and "_" are separators and must be doubled
+func shieldsEscape(s string) string {
+	s = strings.ReplaceAll(s, "-", "--")
+	s = strings.ReplaceAll(s, "_", "__")
+	return url.PathEscape(s)
+}
@@ -2,22 +2,54 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
 	"lazyas/internal/tui"
 )
 
+var (
+	browsePrint    bool
+	browseMarkdown bool
+	browseRepo     string
+)
+
 var browseCmd = &cobra.Command{
 	Use:   "browse",
 	Short: "Launch the interactive TUI browser",
-	Long:  `Browse available and installed skills using an interactive terminal UI.`,
+	Long: `Browse available and installed skills using an interactive terminal UI.
+
+With --print, write the browser's grouped skill list (groups, statuses,
+versions and descriptions) to stdout instead, for pagers, docs or chat
+messages. --markdown prints it as Markdown. --repo shows only the skills
+of one configured repository; in the TUI, P picks another and enter on a
+repository header toggles the scope.
+
+Examples:
+  lazyas browse
+  lazyas browse --repo community
+  lazyas browse --print | less
+  lazyas browse --markdown > SKILLS.md`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.DefaultConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		return tui.Run(cfg)
+		if err := checkRepoFilter(cfg, browseRepo); err != nil {
+			return err
+		}
+
+		if browsePrint || browseMarkdown {
+			return tui.Print(cfg, os.Stdout, browseMarkdown, browseRepo)
+		}
+		return tui.Run(cfg, browseRepo)
 	},
 }
+
+func init() {
+	browseCmd.Flags().BoolVar(&browsePrint, "print", false, "Print the skill list as text instead of starting the TUI")
+	browseCmd.Flags().BoolVar(&browseMarkdown, "markdown", false, "Print the skill list as Markdown (implies --print)")
+	browseCmd.Flags().StringVar(&browseRepo, "repo", "", "Show only the skills of this configured repository")
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/catalog"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+)
+
+var (
+	catalogFormat  string
+	catalogOut     string
+	catalogRepo    string
+	catalogRefresh bool
+)
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Export the skill index as a Markdown or HTML document",
+	Long: `Render every skill in the index into a document to share on a team
+wiki: skills grouped by repository, with their descriptions, tags, a link
+to their source and the command that installs them, plus the command that
+adds each repository.
+
+The format defaults to the extension of --out (.html or .htm for HTML),
+else Markdown. Without --out the document is printed.
+
+Examples:
+  lazyas catalog --out skills.md
+  lazyas catalog --format html --out skills.html
+  lazyas catalog --repo community > community.md`,
+	Args: cobra.NoArgs,
+	RunE: runCatalog,
+}
+
+func init() {
+	catalogCmd.Flags().StringVar(&catalogFormat, "format", "", "Output format: "+strings.Join(catalog.Formats, " or "))
+	catalogCmd.Flags().StringVarP(&catalogOut, "out", "o", "", "Write the document to this file instead of printing it")
+	catalogCmd.Flags().StringVar(&catalogRepo, "repo", "", "Export only skills of this configured repository")
+	catalogCmd.Flags().BoolVar(&catalogRefresh, "refresh", false, "Fetch the index now instead of using the cache")
+}
+
+func runCatalog(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := checkRepoFilter(cfg, catalogRepo); err != nil {
+		return err
+	}
+	format := catalogFormatFor(catalogFormat, catalogOut)
+	if err := catalog.CheckFormat(format); err != nil {
+		return err
+	}
+
+	reg := registry.NewRegistry(cfg)
+	if err := reg.Fetch(catalogRefresh); err != nil {
+		return fmt.Errorf("failed to fetch index: %w", err)
+	}
+	doc := catalog.New(cfg.Repos, repoSkills(reg.ListSkills(), catalogRepo), time.Now())
+
+	if catalogOut == "" {
+		return doc.Write(os.Stdout, format)
+	}
+	var buf bytes.Buffer
+	if err := doc.Write(&buf, format); err != nil {
+		return err
+	}
+	if err := os.WriteFile(catalogOut, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+	fmt.Println(i18n.Tf("Wrote %d skill(s) from %d repo(s) to %s", doc.Count(), len(doc.Repos), catalogOut))
+	return nil
+}
+
+// catalogFormatFor picks the format flag, else the one the output file
+// name suggests
+func catalogFormatFor(format, out string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	switch strings.ToLower(filepath.Ext(out)) {
+	case ".html", ".htm":
+		return "html"
+	}
+	return "markdown"
+}
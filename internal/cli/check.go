@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/clean"
+	"lazyas/internal/config"
+	"lazyas/internal/health"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+)
+
+var (
+	checkJSON     bool
+	checkExitCode bool
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check [name]",
+	Short: "Check that installed skills are usable",
+	Long: `Check installed skills end to end.
+
+For each skill the check confirms that SKILL.md exists and its
+frontmatter parses with a name and description, that paths named in the
+frontmatter exist, that relative Markdown links in every .md file point
+at existing files, and that scripts with a #! line are executable and no
+file is world-writable. All problems are listed in one report.
+
+Skills whose source repository is no longer configured are reported as
+orphaned: they get no updates until the repository is added back.
+
+When checking all skills, leftovers of skills and clones deleted by hand
+are listed too, with an offer to remove them (see 'lazyas clean').
+
+With --exit-code the command exits 0 when every skill is healthy, 1 when
+a problem or an orphaned skill was found and 2 when a check could not be run.
+
+Examples:
+  lazyas check pdf
+  lazyas check --json --exit-code`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCheck,
+}
+
+func init() {
+	checkCmd.Flags().BoolVar(&checkJSON, "json", false, "Print a machine-readable report")
+	checkCmd.Flags().BoolVar(&checkExitCode, "exit-code", false, "Exit 1 on problems, 2 on errors")
+}
+
+// checkReport is the --json output of lazyas check
+type checkReport struct {
+	SchemaVersion int          `json:"schema_version"`
+	Skills        []checkSkill `json:"skills"`
+	Unhealthy     int          `json:"unhealthy"`
+	Errors        int          `json:"errors"`
+	Orphaned      []string     `json:"orphaned,omitempty"` // source repo no longer configured
+}
+
+type checkSkill struct {
+	Name     string         `json:"name"`
+	Status   string         `json:"status"` // ok, problems, error
+	Problems []checkProblem `json:"problems,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+type checkProblem struct {
+	Path    string `json:"path"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	report, err := checkHealth(args)
+	if err != nil {
+		if checkExitCode {
+			fmt.Fprintln(os.Stderr, err)
+			return exitCode(cmd, exitError)
+		}
+		return err
+	}
+
+	if checkJSON {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printCheck(report)
+		// Scripts use --exit-code; only people get asked
+		if len(args) == 0 && !checkExitCode {
+			if err := offerClean(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !checkExitCode {
+		return nil
+	}
+	switch {
+	case report.Errors > 0:
+		return exitCode(cmd, exitError)
+	case report.Unhealthy > 0, len(report.Orphaned) > 0:
+		return exitCode(cmd, exitDrift)
+	}
+	return nil
+}
+
+// offerClean lists leftovers of deleted skills and clones and offers to
+// remove them, like lazyas clean
+func offerClean() error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	report, err := clean.Find(cfg, mfst)
+	if err != nil || report.Empty() {
+		return err
+	}
+	fmt.Println()
+	printClean(report)
+	if !confirmClean(report) {
+		fmt.Println(i18n.T("Left as is; run 'lazyas clean' to remove them later"))
+		return nil
+	}
+	return applyClean(cfg, mfst, report)
+}
+
+func checkHealth(args []string) (*checkReport, error) {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	names := args
+	if len(names) == 0 {
+		for name := range mfst.ListInstalled() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	} else if !mfst.IsInstalled(names[0]) {
+		return nil, fmt.Errorf("skill %s is not installed", names[0])
+	}
+
+	report := &checkReport{SchemaVersion: reportSchemaVersion, Skills: []checkSkill{}}
+	var urls []string
+	for _, repo := range cfg.Repos {
+		urls = append(urls, repo.URL)
+	}
+	for _, name := range mfst.Orphans(urls) {
+		if slices.Contains(names, name) {
+			report.Orphaned = append(report.Orphaned, name)
+		}
+	}
+
+	for _, name := range names {
+		s := checkSkill{Name: name, Status: "ok"}
+		result, err := health.Check(mfst.GetSkillPath(name))
+		switch {
+		case err != nil:
+			s.Status = "error"
+			s.Error = err.Error()
+			report.Errors++
+		case !result.OK():
+			s.Status = "problems"
+			for _, p := range result.Problems {
+				s.Problems = append(s.Problems, checkProblem{Path: p.Path, Kind: p.Kind, Message: p.Message})
+			}
+			report.Unhealthy++
+		}
+		report.Skills = append(report.Skills, s)
+	}
+	return report, nil
+}
+
+func printCheck(report *checkReport) {
+	if len(report.Skills) == 0 {
+		fmt.Println(i18n.T("No skills installed"))
+		return
+	}
+
+	for _, s := range report.Skills {
+		switch s.Status {
+		case "ok":
+			fmt.Println(i18n.Tf("  %s: ok", s.Name))
+		case "error":
+			fmt.Println(i18n.Tf("  %s: check failed: %s", s.Name, s.Error))
+		default:
+			fmt.Println(i18n.Tf("  %s: %d problem(s)", s.Name, len(s.Problems)))
+			for _, p := range s.Problems {
+				fmt.Printf("    [%s] %s: %s\n", p.Kind, p.Path, p.Message)
+			}
+		}
+	}
+
+	if report.Unhealthy == 0 && report.Errors == 0 {
+		fmt.Println(i18n.Tf("All %d skill(s) healthy", len(report.Skills)))
+	} else {
+		fmt.Println(i18n.Tf("\n%d with problems, %d failed", report.Unhealthy, report.Errors))
+	}
+
+	if len(report.Orphaned) > 0 {
+		fmt.Println(i18n.Tf("\nOrphaned (source repository no longer configured): %s", strings.Join(report.Orphaned, ", ")))
+		fmt.Println(i18n.T("  Add the repository back with 'lazyas config repo add', or remove them with 'lazyas remove'"))
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/clean"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/trash"
+)
+
+var cleanDryRun bool
+
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove leftovers of deleted skills and unused clones",
+	Long: `Find and remove what is left behind when skills or clones are deleted
+by hand:
+
+  - manifest entries whose skill directory is gone
+  - skill directories without a SKILL.md (moved to the trash)
+  - links in the skills directory whose target is gone
+  - clones in ~/.lazyas/repos that no installed skill uses and whose
+    repository is no longer configured
+
+'lazyas check' offers the same cleanup when it finds any of these.
+
+Examples:
+  lazyas clean
+  lazyas clean --dry-run   # Only list what would be removed
+  lazyas clean --yes       # Remove without prompting`,
+	Args: cobra.NoArgs,
+	RunE: runClean,
+}
+
+func init() {
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Only list what would be removed")
+}
+
+func runClean(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	report, err := clean.Find(cfg, mfst)
+	if err != nil {
+		return fmt.Errorf("failed to look for leftovers: %w", err)
+	}
+	if report.Empty() {
+		fmt.Println(i18n.T("Nothing to clean"))
+		return nil
+	}
+	printClean(report)
+	if cleanDryRun {
+		return nil
+	}
+	if !confirmClean(report) {
+		fmt.Println(i18n.T("Cancelled"))
+		return nil
+	}
+	return applyClean(cfg, mfst, report)
+}
+
+// printClean lists the leftovers by kind
+func printClean(report *clean.Report) {
+	sections := []struct {
+		title string
+		names []string
+	}{
+		{i18n.T("Manifest entries without a skill directory:"), report.MissingDirs},
+		{i18n.T("Skill directories without a SKILL.md:"), report.NoSkillMD},
+		{i18n.T("Links to missing targets:"), report.BrokenLinks},
+		{i18n.T("Unused repository clones:"), report.UnusedClones},
+	}
+	for _, s := range sections {
+		if len(s.names) == 0 {
+			continue
+		}
+		fmt.Println(s.title)
+		for _, name := range s.names {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+}
+
+func confirmClean(report *clean.Report) bool {
+	return confirm(i18n.Tf("Clean up %d item(s)? [y/N]: ", report.Count()))
+}
+
+// applyClean removes the leftovers in the report. Directories without a
+// SKILL.md go to the trash since they may hold work; everything else can
+// be recreated.
+func applyClean(cfg *config.Config, mfst *manifest.Manager, report *clean.Report) error {
+	if err := report.DropMissing(mfst); err != nil {
+		return err
+	}
+	for _, name := range report.NoSkillMD {
+		if err := trashSkill(cfg, mfst, name, trash.ReasonClean); err != nil {
+			return fmt.Errorf("failed to move %s to the trash: %w", name, err)
+		}
+		if _, tracked := mfst.GetInstalled(name); tracked {
+			if err := mfst.RemoveSkill(name); err != nil {
+				return fmt.Errorf("failed to update manifest: %w", err)
+			}
+		}
+	}
+	for _, name := range report.BrokenLinks {
+		if err := os.Remove(mfst.GetSkillPath(name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", name, err)
+		}
+	}
+	for _, dir := range report.UnusedClones {
+		if err := os.RemoveAll(filepath.Join(cfg.ReposDir, dir)); err != nil {
+			return fmt.Errorf("failed to remove clone %s: %w", dir, err)
+		}
+	}
+	pruneBackendLinks(cfg)
+
+	fmt.Println(i18n.Tf("Cleaned up %d item(s)", report.Count()))
+	return nil
+}
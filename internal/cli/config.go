@@ -3,9 +3,32 @@ package cli
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/registry"
+	"lazyas/internal/relocate"
+	"lazyas/internal/scan"
+	"lazyas/internal/semver"
+)
+
+var (
+	repoAddTrust         bool
+	repoAddPublicKey     string
+	repoRemoveSkills     bool
+	repoRemoveKeepSkills bool
+	teamRefresh          bool
+	moveHome             string
+	moveSkillsDir        string
+	moveReposDir         string
 )
 
 var configCmd = &cobra.Command{
@@ -17,7 +40,7 @@ var configCmd = &cobra.Command{
 var repoCmd = &cobra.Command{
 	Use:   "repo",
 	Short: "Manage skill repositories",
-	Long:  `Add, remove, and list skill repositories.`,
+	Long:  `Add, remove, rename, and list skill repositories.`,
 }
 
 var repoAddCmd = &cobra.Command{
@@ -25,9 +48,19 @@ var repoAddCmd = &cobra.Command{
 	Short: "Add a skill repository",
 	Long: `Add a skill repository to fetch skills from.
 
+The repository is fetched once and summarized (skills, top-level layout,
+scripts and binaries) before you confirm adding it. Use --trust to skip
+the confirmation.
+
+With --public-key, the repository's index.yaml must be signed with the
+matching private key (see 'lazyas sign-index'); skills from it are only
+listed when the signature checks out.
+
 Examples:
   lazyas config repo add official https://github.com/anthropics/skills
-  lazyas config repo add mycompany https://github.com/mycompany/skills`,
+  lazyas config repo add mycompany https://github.com/mycompany/skills
+  lazyas config repo add --trust mycompany https://github.com/mycompany/skills
+  lazyas config repo add mycompany https://github.com/mycompany/skills --public-key <key>`,
 	Args: cobra.ExactArgs(2),
 	RunE: runRepoAdd,
 }
@@ -35,8 +68,75 @@ Examples:
 var repoRemoveCmd = &cobra.Command{
 	Use:   "remove <name>",
 	Short: "Remove a skill repository",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runRepoRemove,
+	Long: `Remove a skill repository.
+
+Skills installed from the repository are listed first, and you are asked
+whether to remove them too. Kept skills stay installed but are marked
+orphaned: they no longer receive updates until the repository is added
+back. Skills that other installed skills depend on are always kept.
+
+Examples:
+  lazyas config repo remove mycompany
+  lazyas config repo remove mycompany --remove-skills   # Also remove its skills
+  lazyas config repo remove mycompany --keep-skills     # Keep them, orphaned`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoRemove,
+}
+
+var repoRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a skill repository",
+	Long: `Rename a skill repository, keeping its URL, cached index and the
+skills installed from it.
+
+Examples:
+  lazyas config repo rename mycompany acme`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRepoRename,
+}
+
+var repoSetURLCmd = &cobra.Command{
+	Use:   "set-url <name> <url>",
+	Short: "Change the URL of a skill repository",
+	Long: `Point a skill repository at a new URL, e.g. after it moved.
+
+Installed skills from the repository follow it: their manifest entries,
+the shared clone's origin and the skill links are updated, so they keep
+updating without a reinstall.
+
+Examples:
+  lazyas config repo set-url mycompany https://github.com/acme/skills`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRepoSetURL,
+}
+
+var repoPriorityCmd = &cobra.Command{
+	Use:   "priority <name> <n>",
+	Short: "Set which repository wins for shared skill names",
+	Long: `Set the priority of a skill repository (default 0).
+
+When several repositories provide a skill with the same name, the one
+with the highest priority is used; ties go to the repository listed
+first. The others are shadowed and can still be installed as
+<repo>/<name>. See 'lazyas info <name>' for which repo is active.
+
+Examples:
+  lazyas config repo priority anthropic-official 10`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRepoPriority,
+}
+
+var repoPinCmd = &cobra.Command{
+	Use:   "pin <skill> [repo]",
+	Short: "Always take a skill from one repository",
+	Long: `Pin a skill name to the repository that provides it, overriding
+repository priority. Without a repo the pin is removed.
+
+Examples:
+  lazyas config repo pin pdf community
+  lazyas config repo pin pdf            # Back to priority order`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runRepoPin,
 }
 
 var repoListCmd = &cobra.Command{
@@ -63,15 +163,69 @@ var configEditCmd = &cobra.Command{
 	RunE:  runConfigEdit,
 }
 
+var configTeamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Show the team-shared configuration",
+	Long: `Show the configuration published at team_config_url.
+
+The team config (TOML, or YAML when the URL ends in .yaml/.yml) lists
+repositories, required skills and policies. It is merged with the local
+config at load time from a cached copy, which 'lazyas sync' refetches
+every team_config_refresh_hours (default: cache_ttl_hours). Local
+settings win over team settings.
+
+Examples:
+  lazyas config team
+  lazyas config team --refresh   # Fetch now instead of using the cache`,
+	RunE: runConfigTeam,
+}
+
+var configMoveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move the skills store to another directory",
+	Long: `Move the skills directory, the repos directory or the whole lazyas
+directory elsewhere, e.g. into a dotfile manager's tree or off a network
+home. Links to the moved skills, from the skills directory and from
+backends, are re-pointed, and the new locations are saved as skills_dir
+and repos_dir in config.toml.
+
+Moving the whole lazyas directory with --home also needs LAZYAS_HOME set
+to the new location in your shell profile.
+
+Examples:
+  lazyas config move --skills-dir ~/dotfiles/skills
+  lazyas config move --repos-dir /var/cache/lazyas/repos
+  lazyas config move --home /mnt/data/lazyas`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMove,
+}
+
 func init() {
+	configMoveCmd.Flags().StringVar(&moveHome, "home", "", "New location of the whole lazyas directory")
+	configMoveCmd.Flags().StringVar(&moveSkillsDir, "skills-dir", "", "New location of the skills directory")
+	configMoveCmd.Flags().StringVar(&moveReposDir, "repos-dir", "", "New location of the repos directory")
+	configMoveCmd.MarkFlagsOneRequired("home", "skills-dir", "repos-dir")
+	configTeamCmd.Flags().BoolVar(&teamRefresh, "refresh", false, "Fetch the team config now")
+	repoAddCmd.Flags().BoolVar(&repoAddTrust, "trust", false, "Add without confirming the trust summary")
+	repoAddCmd.Flags().StringVar(&repoAddPublicKey, "public-key", "", "Verify the repository's index.yaml against this base64 ed25519 key")
+	repoRemoveCmd.Flags().BoolVar(&repoRemoveSkills, "remove-skills", false, "Also remove the skills installed from the repository")
+	repoRemoveCmd.Flags().BoolVar(&repoRemoveKeepSkills, "keep-skills", false, "Keep the skills installed from the repository, marked orphaned")
+	repoRemoveCmd.MarkFlagsMutuallyExclusive("remove-skills", "keep-skills")
+
 	repoCmd.AddCommand(repoAddCmd)
 	repoCmd.AddCommand(repoRemoveCmd)
+	repoCmd.AddCommand(repoRenameCmd)
+	repoCmd.AddCommand(repoSetURLCmd)
+	repoCmd.AddCommand(repoPriorityCmd)
+	repoCmd.AddCommand(repoPinCmd)
 	repoCmd.AddCommand(repoListCmd)
 
 	configCmd.AddCommand(repoCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configPathCmd)
 	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configTeamCmd)
+	configCmd.AddCommand(configMoveCmd)
 }
 
 func runRepoAdd(cmd *cobra.Command, args []string) error {
@@ -82,12 +236,48 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 
 	name := args[0]
 	url := args[1]
+	if repoAddPublicKey != "" {
+		if _, err := registry.ParsePublicKey(repoAddPublicKey); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(i18n.Tf("Inspecting %s...", url))
+	summary, err := registry.NewRegistry(cfg).InspectRepo(url)
+	if err != nil {
+		return fmt.Errorf("failed to inspect repo: %w", err)
+	}
+	for _, line := range summary.Lines() {
+		fmt.Printf("  %s\n", line)
+	}
+
+	if !repoAddTrust {
+		if !confirm(i18n.T("Add this repository? [y/N]: ")) {
+			fmt.Println(i18n.T("Cancelled"))
+			return nil
+		}
+	}
 
 	if err := cfg.AddRepo(name, url); err != nil {
 		return fmt.Errorf("failed to add repo: %w", err)
 	}
+	if repoAddPublicKey != "" {
+		cfg.GetRepo(name).PublicKey = strings.TrimSpace(repoAddPublicKey)
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	fmt.Println(i18n.Tf("Added repository '%s': %s", name, url))
 
-	fmt.Printf("Added repository '%s': %s\n", name, url)
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err == nil {
+		if adopted, err := mfst.SetOrphaned(url, false); err != nil {
+			return fmt.Errorf("failed to update manifest: %w", err)
+		} else if len(adopted) > 0 {
+			fmt.Println(i18n.Tf("No longer orphaned: %s", strings.Join(adopted, ", ")))
+		}
+	}
 	return nil
 }
 
@@ -99,14 +289,186 @@ func runRepoRemove(cmd *cobra.Command, args []string) error {
 
 	name := args[0]
 
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	var url string
+	var skills []string
+	if repo := cfg.GetRepo(name); repo != nil {
+		url = repo.URL
+		skills = mfst.FromRepo(url)
+	}
+
+	removeSkills := repoRemoveSkills
+	if len(skills) > 0 {
+		fmt.Println(i18n.Tf("Skills installed from '%s':", name))
+		for _, skill := range skills {
+			fmt.Printf("  %s\n", skill)
+		}
+		if !repoRemoveSkills && !repoRemoveKeepSkills {
+			removeSkills = confirm(i18n.Tf("Remove these %d skill(s) too? Kept skills are marked orphaned [y/N]: ", len(skills)))
+		}
+	}
+
 	if err := cfg.RemoveRepo(name); err != nil {
 		return fmt.Errorf("failed to remove repo: %w", err)
 	}
+	fmt.Println(i18n.Tf("Removed repository '%s'", name))
+
+	if len(skills) == 0 {
+		return nil
+	}
+	if removeSkills {
+		var kept []string
+		for _, skill := range skills {
+			if dependents := outside(mfst.Dependents(skill), skills); len(dependents) > 0 {
+				fmt.Println(i18n.Tf("Keeping %s: required by %s", skill, strings.Join(dependents, ", ")))
+				kept = append(kept, skill)
+				continue
+			}
+			if err := removeInstalled(cfg, mfst, skill); err != nil {
+				return err
+			}
+		}
+		if len(kept) == 0 {
+			return nil
+		}
+	}
+
+	orphaned, err := mfst.SetOrphaned(url, true)
+	if err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if len(orphaned) > 0 {
+		fmt.Println(i18n.Tf("Kept %d skill(s) as orphaned: %s", len(orphaned), strings.Join(orphaned, ", ")))
+	}
+	return nil
+}
+
+// outside returns the names that are not in set
+func outside(names, set []string) []string {
+	var rest []string
+	for _, name := range names {
+		if !slices.Contains(set, name) {
+			rest = append(rest, name)
+		}
+	}
+	return rest
+}
+
+func runRepoRename(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	oldName, newName := args[0], args[1]
+	if cfg.GetRepo(oldName) == nil {
+		return fmt.Errorf("repository %s not found", oldName)
+	}
 
-	fmt.Printf("Removed repository '%s'\n", name)
+	if err := cfg.RenameRepo(oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename repo: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("Renamed repository '%s' to '%s'", oldName, newName))
 	return nil
 }
 
+func runRepoSetURL(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name, url := args[0], args[1]
+	repo := cfg.GetRepo(name)
+	if repo == nil {
+		return fmt.Errorf("repository %s not found", name)
+	}
+	oldURL := repo.URL
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	// Move the installed skills first so the config never names a URL
+	// whose clones were left behind
+	moved, err := mfst.MoveRepo(oldURL, url)
+	if err != nil {
+		return fmt.Errorf("failed to move installed skills: %w", err)
+	}
+	if err := cfg.SetRepoURL(name, url); err != nil {
+		if _, undoErr := mfst.MoveRepo(url, oldURL); undoErr != nil {
+			return fmt.Errorf("failed to update repo: %w (moving the installed skills back failed: %v)", err, undoErr)
+		}
+		return fmt.Errorf("failed to update repo: %w", err)
+	}
+	remapRepoCache(cfg, oldURL, url)
+
+	fmt.Println(i18n.Tf("Repository '%s' now points to %s", name, url))
+	if len(moved) > 0 {
+		fmt.Println(i18n.Tf("  Moved %d installed skill(s): %s", len(moved), strings.Join(moved, ", ")))
+	}
+	return nil
+}
+
+func runRepoPriority(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := args[0]
+	priority, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid priority %q: must be a whole number", args[1])
+	}
+	if err := cfg.SetRepoPriority(name, priority); err != nil {
+		return fmt.Errorf("failed to update repo: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("Repository '%s' now has priority %d", name, priority))
+	return nil
+}
+
+func runRepoPin(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	skill, repo := args[0], ""
+	if len(args) > 1 {
+		repo = args[1]
+	}
+	if err := cfg.PinSkill(skill, repo); err != nil {
+		return fmt.Errorf("failed to pin skill: %w", err)
+	}
+
+	if repo == "" {
+		fmt.Println(i18n.Tf("Unpinned %s; repository priority decides", skill))
+	} else {
+		fmt.Println(i18n.Tf("Pinned %s to repository '%s'", skill, repo))
+	}
+	return nil
+}
+
+// remapRepoCache carries the cached skill list over to a moved repo.
+// A failure only costs a refetch, so it is reported as a warning.
+func remapRepoCache(cfg *config.Config, oldURL, newURL string) {
+	cache := registry.NewCacheManager(cfg)
+	err := cache.Load()
+	if err == nil {
+		err = cache.RemapRepo(oldURL, newURL)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to update the index cache: %v", err))
+	}
+}
+
 func runRepoList(cmd *cobra.Command, args []string) error {
 	cfg, err := config.DefaultConfig()
 	if err != nil {
@@ -114,16 +476,39 @@ func runRepoList(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(cfg.Repos) == 0 {
-		fmt.Println("No repositories configured.")
+		fmt.Println(i18n.T("No repositories configured."))
 		fmt.Println()
-		fmt.Println("Add a repository with:")
+		fmt.Println(i18n.T("Add a repository with:"))
 		fmt.Println("  lazyas config repo add <name> <url>")
 		return nil
 	}
 
-	fmt.Println("Configured repositories:")
+	// Sync records come from the cached index; no fetch is needed
+	reg := registry.NewRegistry(cfg)
+	reg.LoadCache()
+
+	fmt.Println(i18n.T("Configured repositories:"))
 	for _, repo := range cfg.Repos {
-		fmt.Printf("  %s: %s\n", repo.Name, repo.URL)
+		if repo.Team {
+			fmt.Println(i18n.Tf("  %s: %s (team)", repo.Name, repo.URL))
+		} else {
+			fmt.Printf("  %s: %s\n", repo.Name, repo.URL)
+		}
+		if repo.Priority != 0 {
+			fmt.Println(i18n.Tf("    priority %d", repo.Priority))
+		}
+		if repo.CacheTTL > 0 {
+			fmt.Println(i18n.Tf("    cached for %dh", repo.CacheTTL))
+		}
+		if sync, ok := reg.RepoSync(repo.Name); ok && sync.URL == repo.URL {
+			line := i18n.Tf("    synced %s", registry.FormatAge(sync.FetchedAt, time.Now()))
+			if len(sync.Commit) >= 7 {
+				line += fmt.Sprintf(" (%s)", sync.Commit[:7])
+			}
+			fmt.Println(line)
+		} else {
+			fmt.Println(i18n.T("    never synced"))
+		}
 	}
 
 	return nil
@@ -135,26 +520,114 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	fmt.Println("Configuration:")
+	fmt.Println(i18n.T("Configuration:"))
 	fmt.Printf("  config_file: %s\n", cfg.ConfigPath)
 	fmt.Printf("  skills_dir:  %s\n", cfg.SkillsDir)
-	fmt.Printf("  cache_ttl:   %d hours\n", cfg.CacheTTL)
+	fmt.Printf("  repos_dir:   %s\n", cfg.ReposDir)
+	fmt.Println(i18n.Tf("  cache_ttl:   %d hours", cfg.CacheTTL))
+	if interval := cfg.BackgroundRefresh(); interval > 0 {
+		fmt.Println(i18n.Tf("  refresh_interval: %s", interval))
+	} else {
+		fmt.Println(i18n.T("  refresh_interval: off"))
+	}
+	if cfg.Locale != "" {
+		fmt.Printf("  locale:      %s\n", cfg.Locale)
+	} else {
+		fmt.Println(i18n.Tf("  locale:      %s (detected)", i18n.Locale()))
+	}
+	fmt.Printf("  update_policy: %s\n", semver.EffectivePolicy(cfg.UpdatePolicy))
+	if cfg.RiskPolicy != "" {
+		fmt.Printf("  risk_policy: %s\n", cfg.RiskPolicy)
+	} else {
+		fmt.Printf("  risk_policy: %s\n", scan.PolicyAllow)
+	}
+	if cfg.VerifySignatures {
+		fmt.Println("  verify_signatures: true")
+	}
+	if cfg.Notifications {
+		fmt.Println("  notifications: true")
+		if cfg.QuietHours != "" {
+			fmt.Printf("  quiet_hours: %s\n", cfg.QuietHours)
+		}
+	}
+	if retention := cfg.TrashRetentionPeriod(); retention > 0 {
+		fmt.Println(i18n.Tf("  trash_retention: %d days", int(retention.Hours()/24)))
+	} else {
+		fmt.Println(i18n.T("  trash_retention: until emptied"))
+	}
+	fmt.Printf("  max_git_jobs: %d\n", cfg.GitJobs())
+	if cfg.GitBandwidthLimit > 0 {
+		if git.UserProxy() != "" {
+			fmt.Println(i18n.Tf("  git_bandwidth_limit: %d KB/s (not applied: git uses a proxy)", cfg.GitBandwidthLimit))
+		} else {
+			fmt.Println(i18n.Tf("  git_bandwidth_limit: %d KB/s", cfg.GitBandwidthLimit))
+		}
+	}
+	if cfg.DisableUsageStats {
+		fmt.Println("  disable_usage_stats: true")
+	}
+	if cfg.TemplatesRepo != "" {
+		fmt.Printf("  templates_repo: %s\n", cfg.TemplatesRepo)
+	}
+	if cfg.TeamConfigURL != "" {
+		fmt.Printf("  team_config_url: %s\n", cfg.TeamConfigURL)
+	}
+	if len(cfg.StatusPrecedence) > 0 {
+		fmt.Printf("  status_precedence: %s\n", strings.Join(cfg.StatusPrecedence, " > "))
+	}
+	if len(cfg.StatusIcons) > 0 {
+		states := make([]string, 0, len(cfg.StatusIcons))
+		for state := range cfg.StatusIcons {
+			states = append(states, state)
+		}
+		sort.Strings(states)
+		for i, state := range states {
+			states[i] = state + "=" + cfg.StatusIcons[state]
+		}
+		fmt.Printf("  status_icons: %s\n", strings.Join(states, " "))
+	}
+	if len(cfg.Theme) > 0 {
+		keys := make([]string, 0, len(cfg.Theme))
+		for key := range cfg.Theme {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			keys[i] = key + "=" + cfg.Theme[key]
+		}
+		fmt.Printf("  theme: %s\n", strings.Join(keys, " "))
+	}
 	fmt.Println()
 
 	if len(cfg.Repos) == 0 {
-		fmt.Println("Repositories: (none)")
+		fmt.Println(i18n.T("Repositories: (none)"))
 	} else {
-		fmt.Println("Repositories:")
+		fmt.Println(i18n.T("Repositories:"))
 		for _, repo := range cfg.Repos {
-			fmt.Printf("  %s: %s\n", repo.Name, repo.URL)
+			if repo.Team {
+				fmt.Println(i18n.Tf("  %s: %s (team)", repo.Name, repo.URL))
+			} else {
+				fmt.Printf("  %s: %s\n", repo.Name, repo.URL)
+			}
+		}
+	}
+	if len(cfg.SkillRepos) > 0 {
+		fmt.Println(i18n.T("Pinned skills:"))
+		skills := make([]string, 0, len(cfg.SkillRepos))
+		for skill := range cfg.SkillRepos {
+			skills = append(skills, skill)
+		}
+		sort.Strings(skills)
+		for _, skill := range skills {
+			fmt.Printf("  %s: %s\n", skill, cfg.SkillRepos[skill])
 		}
 	}
 	fmt.Println()
 
 	if len(cfg.Backends) == 0 {
-		fmt.Println("Backends: (none)")
+		fmt.Println(i18n.T("Backends: (none)"))
 	} else {
-		fmt.Println("Backends:")
+		fmt.Println(i18n.T("Backends:"))
 		for _, b := range cfg.Backends {
 			expandedPath, _ := config.ExpandPath(b.Path)
 			desc := b.Description
@@ -178,6 +651,28 @@ func runConfigPath(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runConfigMove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	plan := relocate.Plan{Home: moveHome, SkillsDir: moveSkillsDir, ReposDir: moveReposDir}
+	if err := relocate.Move(cfg, plan); err != nil {
+		return fmt.Errorf("failed to move: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("Skills directory: %s", cfg.SkillsDir))
+	fmt.Println(i18n.Tf("Repos directory:  %s", cfg.ReposDir))
+	if moveHome != "" {
+		if home, err := config.HomeDir(); err == nil && home != cfg.ConfigDir {
+			fmt.Println(i18n.Tf("Moved lazyas to %s. Add this to your shell profile:", cfg.ConfigDir))
+			fmt.Printf("  export %s=%s\n", config.HomeEnv, cfg.ConfigDir)
+		}
+	}
+	return nil
+}
+
 func runConfigEdit(cmd *cobra.Command, args []string) error {
 	cfg, err := config.DefaultConfig()
 	if err != nil {
@@ -218,3 +713,56 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 	_, err = process.Wait()
 	return err
 }
+
+func runConfigTeam(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.TeamConfigURL == "" {
+		fmt.Println(i18n.T("No team config configured."))
+		fmt.Println()
+		fmt.Println(i18n.Tf("Set team_config_url in %s", cfg.ConfigPath))
+		return nil
+	}
+
+	if teamRefresh {
+		cfg.RefreshTeamConfig(true)
+	}
+
+	fmt.Println(i18n.Tf("Team config: %s", cfg.TeamConfigURL))
+	if !cfg.TeamFetchedAt.IsZero() {
+		fmt.Println(i18n.Tf("  fetched %s", registry.FormatAge(cfg.TeamFetchedAt, time.Now())))
+	}
+	if cfg.TeamConfigErr != nil {
+		fmt.Println(i18n.Tf("  Warning: %v", cfg.TeamConfigErr))
+	}
+
+	var teamRepos []config.Repo
+	for _, r := range cfg.Repos {
+		if r.Team {
+			teamRepos = append(teamRepos, r)
+		}
+	}
+	if len(teamRepos) > 0 {
+		fmt.Println(i18n.T("Repositories:"))
+		for _, r := range teamRepos {
+			fmt.Printf("  %s: %s\n", r.Name, r.URL)
+		}
+	}
+
+	if len(cfg.RequiredSkills) > 0 {
+		mfst := manifest.NewManager(cfg)
+		mfst.Load()
+		fmt.Println(i18n.T("Required skills:"))
+		for _, name := range cfg.RequiredSkills {
+			if mfst.IsInstalled(name) {
+				fmt.Printf("  ✓ %s\n", name)
+			} else {
+				fmt.Println(i18n.Tf("  ✗ %s (not installed; run 'lazyas install %s')", name, name))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/remote"
+)
+
+var (
+	contributeBranch  string
+	contributeMessage string
+	contributeRemote  string
+	contributeNoFork  bool
+	contributeOpen    bool
+)
+
+var contributeCmd = &cobra.Command{
+	Use:   "contribute <name>",
+	Short: "Push local modifications as a branch for a pull request",
+	Long: `Commit a skill's local modifications onto a new branch based on the
+installed commit, push it and print the URL that opens a pull request
+against the skill's source repository.
+
+Where the branch is pushed:
+  --remote <url>   a fork or repository you choose
+  (default)        your GitHub fork, created with the gh CLI when available
+  --no-fork        the source repository itself (needs push access)
+
+Your working copy is left untouched: the modifications stay local.
+
+Examples:
+  lazyas contribute my-skill
+  lazyas contribute my-skill -m "Clarify PDF table extraction" --open
+  lazyas contribute my-skill --remote git@github.com:me/skills.git`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContribute,
+}
+
+func init() {
+	contributeCmd.Flags().StringVar(&contributeBranch, "branch", "", "Branch name (default lazyas/<name>-<date>)")
+	contributeCmd.Flags().StringVarP(&contributeMessage, "message", "m", "", "Commit message (default \"Update <name>\")")
+	contributeCmd.Flags().StringVar(&contributeRemote, "remote", "", "Push to this repository URL instead of a gh fork")
+	contributeCmd.Flags().BoolVar(&contributeNoFork, "no-fork", false, "Push the branch to the source repository")
+	contributeCmd.Flags().BoolVar(&contributeOpen, "open", false, "Open the pull request page in a browser")
+	contributeCmd.MarkFlagsMutuallyExclusive("remote", "no-fork")
+}
+
+func runContribute(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name := args[0]
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	info, ok := mfst.GetInstalled(name)
+	if !ok || !mfst.IsInstalled(name) {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+	if info.NeedsResolution() {
+		return fmt.Errorf("skill %s has unresolved conflicts (see 'lazyas resolve %s')", name, name)
+	}
+
+	skillLink := mfst.GetSkillPath(name)
+	if modified, _ := git.IsModified(skillLink); !modified {
+		fmt.Println(i18n.Tf("%s has no local modifications to contribute", name))
+		return nil
+	}
+
+	branch := contributeBranch
+	if branch == "" {
+		branch = fmt.Sprintf("lazyas/%s-%s", name, time.Now().Format("20060102-1504"))
+	}
+	message := contributeMessage
+	if message == "" {
+		message = fmt.Sprintf("Update %s", name)
+	}
+
+	commit, err := git.CommitChanges(skillLink, message)
+	if err != nil {
+		return fmt.Errorf("failed to commit changes: %w", err)
+	}
+
+	source, isHosted := remote.ParseRepo(info.SourceRepo)
+
+	// Pick where to push: explicit remote, a gh fork, or the source itself
+	pushURL, headOwner := "origin", ""
+	switch {
+	case contributeRemote != "":
+		pushURL = contributeRemote
+		if r, ok := remote.ParseRepo(contributeRemote); ok {
+			headOwner = r.Owner
+		}
+	case !contributeNoFork && isHosted && source.Host == remote.HostGitHub:
+		owner, url, err := ghFork(source)
+		if err != nil {
+			fmt.Println(i18n.Tf("Could not set up a fork with gh (%v); pushing to the source repository", err))
+		} else {
+			pushURL, headOwner = url, owner
+		}
+	}
+
+	fmt.Println(i18n.Tf("Pushing %s to %s...", branch, pushURL))
+	if err := git.PushCommit(skillLink, pushURL, commit, branch); err != nil {
+		return err
+	}
+
+	if !isHosted {
+		fmt.Println(i18n.Tf("Pushed branch %s. Open a pull request against %s.", branch, info.SourceRepo))
+		return nil
+	}
+
+	prURL := source.PullRequestURL(git.DefaultBranch(skillLink), headOwner, branch)
+	fmt.Println(i18n.T("Open a pull request:"))
+	fmt.Printf("  %s\n", prURL)
+	if contributeOpen {
+		if err := remote.OpenBrowser(prURL); err != nil {
+			fmt.Println(i18n.Tf("Failed to open browser: %v", err))
+		}
+	}
+	return nil
+}
+
+// ghFork ensures the user has a GitHub fork of repo using the gh CLI and
+// returns the fork owner and its push URL
+func ghFork(repo remote.Repo) (owner, url string, err error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return "", "", fmt.Errorf("gh CLI not found")
+	}
+	out, err := exec.Command("gh", "api", "user", "--jq", ".login").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("gh api user failed (run 'gh auth login'): %w", err)
+	}
+	owner = strings.TrimSpace(string(out))
+	if owner == repo.Owner {
+		return "", "", fmt.Errorf("you own %s/%s", repo.Owner, repo.Name)
+	}
+
+	// Idempotent: succeeds when the fork already exists
+	if err := exec.Command("gh", "repo", "fork", repo.Owner+"/"+repo.Name, "--clone=false", "--remote=false").Run(); err != nil {
+		return "", "", fmt.Errorf("gh repo fork failed: %w", err)
+	}
+	return owner, fmt.Sprintf("https://github.com/%s/%s.git", owner, repo.Name), nil
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/debugreport"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+	"lazyas/internal/symlink"
+)
+
+// logTailBytes is how much of each log file goes into a debug report
+const logTailBytes = 64 * 1024
+
+var debugReportOutput string
+
+var debugReportCmd = &cobra.Command{
+	Use:   "debug-report",
+	Short: "Bundle lazyas state for a bug report",
+	Long: `Write a tarball with the context needed to reproduce a problem:
+version and OS information, the config, the manifest, cache metadata,
+the skills directory layout, backend links and the tail of any log
+files in ~/.lazyas, including the TUI crash logs in ~/.lazyas/logs.
+
+Secrets are redacted before anything is written: credentials in URLs,
+token-like query parameters and keys such as token or password. Your
+home directory is replaced with ~. Review the tarball before attaching
+it to an issue.
+
+Examples:
+  lazyas debug-report
+  lazyas debug-report -o /tmp/lazyas-debug.tar.gz`,
+	Args: cobra.NoArgs,
+	RunE: runDebugReport,
+}
+
+func init() {
+	debugReportCmd.Flags().StringVarP(&debugReportOutput, "output", "o", "", "Tarball to write (default: lazyas-debug-<time>.tar.gz)")
+}
+
+func runDebugReport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	files := []debugreport.File{
+		{Name: "system.txt", Data: debugSystemInfo(cfg)},
+		{Name: "cache.txt", Data: debugCacheInfo(cfg)},
+		{Name: "skills.txt", Data: debugSkillsInfo(cfg)},
+	}
+	for _, path := range []string{cfg.ConfigPath, cfg.ManifestPath} {
+		if data, err := os.ReadFile(path); err == nil {
+			files = append(files, debugreport.File{Name: filepath.Base(path), Data: data})
+		}
+	}
+	logs, _ := filepath.Glob(filepath.Join(cfg.ConfigDir, "*.log"))
+	crashLogs, _ := filepath.Glob(filepath.Join(cfg.LogsDir, "*.log"))
+	logs = append(logs, crashLogs...)
+	for _, path := range logs {
+		if data, err := os.ReadFile(path); err == nil {
+			files = append(files, debugreport.File{Name: "logs/" + filepath.Base(path), Data: debugreport.Tail(data, logTailBytes)})
+		}
+	}
+
+	out := debugReportOutput
+	if out == "" {
+		out = fmt.Sprintf("lazyas-debug-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+	f, err := os.OpenFile(out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	home, _ := os.UserHomeDir()
+	if err := debugreport.Write(f, files, home); err != nil {
+		f.Close()
+		os.Remove(out)
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("Wrote %s", out))
+	fmt.Println(i18n.T("Secrets were redacted; review the contents before sharing."))
+	return nil
+}
+
+// debugSystemInfo describes the lazyas build and the environment it runs in
+func debugSystemInfo(cfg *config.Config) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "lazyas: %s\n", rootCmd.Version)
+	fmt.Fprintf(&b, "go: %s\n", runtime.Version())
+	fmt.Fprintf(&b, "os: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	if out, err := exec.Command("git", "--version").Output(); err == nil {
+		fmt.Fprintf(&b, "git: %s\n", strings.TrimSpace(string(out)))
+	} else {
+		fmt.Fprintf(&b, "git: %v\n", err)
+	}
+	for _, env := range []string{"TERM", "COLORTERM", "LANG", "LC_ALL", "SHELL"} {
+		fmt.Fprintf(&b, "%s: %s\n", env, os.Getenv(env))
+	}
+	fmt.Fprintf(&b, "locale: %s\n", i18n.Locale())
+	fmt.Fprintf(&b, "config_dir: %s\n", cfg.ConfigDir)
+
+	if cfg.TeamConfigURL != "" {
+		fmt.Fprintf(&b, "team_config_url: %s\n", cfg.TeamConfigURL)
+		if !cfg.TeamFetchedAt.IsZero() {
+			fmt.Fprintf(&b, "team_config_fetched: %s\n", cfg.TeamFetchedAt.Format(time.RFC3339))
+		}
+		if cfg.TeamConfigErr != nil {
+			fmt.Fprintf(&b, "team_config_error: %v\n", cfg.TeamConfigErr)
+		}
+	}
+
+	fmt.Fprintln(&b, "\nbackends:")
+	for _, s := range symlink.CheckBackendLinks(cfg.Backends, cfg.SkillsDir) {
+		fmt.Fprintf(&b, "  %s: %s available=%t linked=%t", s.Backend.Name, s.Backend.Path, s.Available, s.Linked)
+		if s.Error != nil {
+			fmt.Fprintf(&b, " error=%v", s.Error)
+		}
+		fmt.Fprintln(&b)
+	}
+	return b.Bytes()
+}
+
+// debugCacheInfo summarizes the cached index without including it
+func debugCacheInfo(cfg *config.Config) []byte {
+	var b bytes.Buffer
+	cache := registry.NewCacheManager(cfg)
+	if err := cache.Load(); err != nil {
+		fmt.Fprintf(&b, "error: %v\n", err)
+		return b.Bytes()
+	}
+	if cache.Get() == nil {
+		fmt.Fprintln(&b, "no cache")
+		return b.Bytes()
+	}
+
+	fmt.Fprintf(&b, "valid: %t (ttl %dh)\n", cache.IsValid(), cfg.CacheTTL)
+	fmt.Fprintf(&b, "skills: %d\n", len(cache.Get().Skills))
+
+	fmt.Fprintln(&b, "repos:")
+	for _, repo := range cfg.Repos {
+		entry, ok := cache.Repo(repo.URL)
+		if !ok {
+			fmt.Fprintf(&b, "  %s: %s not cached\n", repo.Name, repo.URL)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %s fetched %s commit %s skills %d fresh %t (ttl %s)\n", repo.Name, repo.URL, entry.FetchedAt.Format(time.RFC3339), entry.Commit, len(entry.Skills), cache.IsFresh(repo), cfg.RepoCacheTTL(repo))
+	}
+	return b.Bytes()
+}
+
+// debugSkillsInfo lists the skills directory with link targets
+func debugSkillsInfo(cfg *config.Config) []byte {
+	var b bytes.Buffer
+	entries, err := os.ReadDir(cfg.SkillsDir)
+	if err != nil {
+		fmt.Fprintf(&b, "error: %v\n", err)
+		return b.Bytes()
+	}
+	for _, e := range entries {
+		path := filepath.Join(cfg.SkillsDir, e.Name())
+		if target, err := os.Readlink(path); err == nil {
+			state := ""
+			if _, err := os.Stat(path); err != nil {
+				state = " (broken)"
+			}
+			fmt.Fprintf(&b, "%s -> %s%s\n", e.Name(), target, state)
+		} else if e.IsDir() {
+			fmt.Fprintf(&b, "%s (directory)\n", e.Name())
+		} else {
+			fmt.Fprintf(&b, "%s (file)\n", e.Name())
+		}
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/digest"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+)
+
+var (
+	digestMarkdown bool
+	digestNoRecord bool
+	digestRefresh  bool
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Report what changed in the skill repositories since the last digest",
+	Long: `Compare the skill index with the snapshot taken by the previous digest.
+
+For each repository the digest lists new skills, removed skills,
+description changes and new versions, then records the current index as
+the baseline for the next digest. The first run only records a snapshot.
+Run it weekly (from cron, for example) for a digest of the ecosystem.
+
+Examples:
+  lazyas digest
+  lazyas digest --markdown > digest.md
+  lazyas digest --no-record   # Peek without moving the baseline`,
+	Args: cobra.NoArgs,
+	RunE: runDigest,
+}
+
+func init() {
+	digestCmd.Flags().BoolVar(&digestMarkdown, "markdown", false, "Format the digest as Markdown")
+	digestCmd.Flags().BoolVar(&digestNoRecord, "no-record", false, "Do not record the current index as the new baseline")
+	digestCmd.Flags().BoolVar(&digestRefresh, "refresh", false, "Fetch the index now instead of using the cache")
+}
+
+func runDigest(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	reg := registry.NewRegistry(cfg)
+	if err := reg.Fetch(digestRefresh); err != nil {
+		return fmt.Errorf("failed to fetch index: %w", err)
+	}
+	commits := make(map[string]string)
+	for _, repo := range cfg.Repos {
+		if sync, ok := reg.RepoSync(repo.Name); ok {
+			commits[repo.Name] = sync.Commit
+		}
+	}
+	now := time.Now()
+	cur := digest.Take(reg.ListSkills(), commits, now)
+
+	prev, err := digest.Load(cfg.DigestPath)
+	if err != nil {
+		return err
+	}
+
+	if prev == nil {
+		if !digestNoRecord {
+			if err := digest.Save(cfg.DigestPath, cur); err != nil {
+				return fmt.Errorf("failed to record snapshot: %w", err)
+			}
+		}
+		fmt.Println(i18n.Tf("Recorded a snapshot of %d skill(s) in %d repo(s). Run 'lazyas digest' again later to see what changed.", len(reg.ListSkills()), len(cur.Repos)))
+		return nil
+	}
+
+	changes := digest.Compare(*prev, cur)
+	if digestMarkdown {
+		printDigestMarkdown(prev.At, now, changes)
+	} else {
+		printDigest(prev.At, now, changes)
+	}
+
+	if !digestNoRecord {
+		if err := digest.Save(cfg.DigestPath, cur); err != nil {
+			return fmt.Errorf("failed to record snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+func printDigest(since, now time.Time, changes []digest.RepoChanges) {
+	fmt.Println(i18n.Tf("Changes since %s (%s):", since.Format("2006-01-02 15:04"), registry.FormatAge(since, now)))
+	if len(changes) == 0 {
+		fmt.Println(i18n.T("  No changes"))
+		return
+	}
+
+	for _, c := range changes {
+		fmt.Println()
+		if c.OldCommit != "" && c.NewCommit != "" && c.OldCommit != c.NewCommit {
+			fmt.Printf("%s (%s → %s)\n", c.Repo, truncateString(c.OldCommit, 7), truncateString(c.NewCommit, 7))
+		} else {
+			fmt.Println(c.Repo)
+		}
+		for _, s := range c.Added {
+			fmt.Printf("  + %s  %s\n", s.Name, truncateString(s.New, 60))
+		}
+		for _, s := range c.Removed {
+			fmt.Printf("  - %s\n", s.Name)
+		}
+		for _, s := range c.Descriptions {
+			fmt.Println(i18n.Tf("  ~ %s  description: %s", s.Name, truncateString(s.New, 50)))
+		}
+		for _, s := range c.Versions {
+			fmt.Printf("  ↑ %s  %s → %s\n", s.Name, refLabel(s.Old), refLabel(s.New))
+		}
+	}
+}
+
+func printDigestMarkdown(since, now time.Time, changes []digest.RepoChanges) {
+	fmt.Println(i18n.Tf("# Skill digest: %s to %s", since.Format("2006-01-02"), now.Format("2006-01-02")))
+	if len(changes) == 0 {
+		fmt.Println()
+		fmt.Println(i18n.T("No changes."))
+		return
+	}
+
+	section := func(title string, list []digest.Change, line func(digest.Change) string) {
+		if len(list) == 0 {
+			return
+		}
+		fmt.Printf("\n### %s\n\n", title)
+		for _, s := range list {
+			fmt.Println("- " + line(s))
+		}
+	}
+	for _, c := range changes {
+		fmt.Printf("\n## %s\n", c.Repo)
+		section(i18n.T("New skills"), c.Added, func(s digest.Change) string {
+			if s.New == "" {
+				return fmt.Sprintf("`%s`", s.Name)
+			}
+			return fmt.Sprintf("`%s`: %s", s.Name, s.New)
+		})
+		section(i18n.T("Removed skills"), c.Removed, func(s digest.Change) string {
+			return fmt.Sprintf("`%s`", s.Name)
+		})
+		section(i18n.T("Description changes"), c.Descriptions, func(s digest.Change) string {
+			return fmt.Sprintf("`%s`: %s", s.Name, s.New)
+		})
+		section(i18n.T("New versions"), c.Versions, func(s digest.Change) string {
+			return fmt.Sprintf("`%s`: %s → %s", s.Name, refLabel(s.Old), refLabel(s.New))
+		})
+	}
+}
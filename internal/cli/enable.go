@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/symlink"
+)
+
+var enableBackends []string
+
+var enableCmd = &cobra.Command{
+	Use:   "enable <skill> --backend <name>",
+	Short: "Expose a skill to a per-skill backend",
+	Long: `Link an installed skill into the skills directory of a backend in
+per-skill mode, so only that agent sees it.
+
+A backend is switched to per-skill mode with
+'lazyas backend link <name> --per-skill'; backends linked as a whole
+see every installed skill.
+
+Examples:
+  lazyas enable pdf --backend claude
+  lazyas enable pdf --backend claude --backend codex`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetEnabled(args[0], true)
+	},
+}
+
+var disableCmd = &cobra.Command{
+	Use:   "disable <skill> --backend <name>",
+	Short: "Hide a skill from a per-skill backend",
+	Long: `Remove the link of a skill from the skills directory of a backend in
+per-skill mode. The skill stays installed and enabled elsewhere.
+
+Examples:
+  lazyas disable pdf --backend codex`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetEnabled(args[0], false)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{enableCmd, disableCmd} {
+		c.Flags().StringSliceVar(&enableBackends, "backend", nil, "Backend to change (repeatable)")
+		c.MarkFlagRequired("backend")
+	}
+}
+
+func runSetEnabled(name string, enable bool) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := validateSkillName(name); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(cfg.SkillsDir, name)); err != nil {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	// Check every backend before changing any
+	var backends []config.Backend
+	for _, b := range enableBackends {
+		backend := cfg.GetBackend(b)
+		if backend == nil {
+			return fmt.Errorf("backend '%s' not found. Use 'lazyas backend list' to see configured backends", b)
+		}
+		if !backend.PerSkill() {
+			return fmt.Errorf("backend '%s' links the whole skills directory; switch it with 'lazyas backend link %s --per-skill'", b, b)
+		}
+		backends = append(backends, *backend)
+	}
+
+	for _, backend := range backends {
+		if enable {
+			if err := symlink.EnableSkill(backend, cfg.SkillsDir, name); err != nil {
+				return fmt.Errorf("failed to enable %s for %s: %w", name, backend.Name, err)
+			}
+			fmt.Println(i18n.Tf("Enabled %s for %s ✓", name, backend.Name))
+		} else {
+			if err := symlink.DisableSkill(backend, cfg.SkillsDir, name); err != nil {
+				return fmt.Errorf("failed to disable %s for %s: %w", name, backend.Name, err)
+			}
+			fmt.Println(i18n.Tf("Disabled %s for %s ✓", name, backend.Name))
+		}
+	}
+	return nil
+}
+
+// perSkillBackends returns the configured backends in per-skill mode
+func perSkillBackends(cfg *config.Config) []config.Backend {
+	var backends []config.Backend
+	for _, b := range cfg.Backends {
+		if b.PerSkill() {
+			backends = append(backends, b)
+		}
+	}
+	return backends
+}
+
+// printEnableHint reminds that a new skill is not visible to per-skill
+// backends until it is enabled for them
+func printEnableHint(cfg *config.Config, name string) {
+	var names []string
+	for _, b := range perSkillBackends(cfg) {
+		if !slices.Contains(symlink.EnabledSkills(b, cfg.SkillsDir), name) {
+			names = append(names, b.Name)
+		}
+	}
+	if len(names) > 0 {
+		fmt.Println(i18n.Tf("  Not enabled for per-skill backends (%s); use 'lazyas enable %s --backend <name>'", strings.Join(names, ", "), name))
+	}
+}
+
+// pruneBackendLinks drops per-skill backend links to skills that are gone
+func pruneBackendLinks(cfg *config.Config) {
+	for _, b := range perSkillBackends(cfg) {
+		symlink.Prune(b, cfg.SkillsDir)
+	}
+}
+
+// relinkBackends follows a rename in the per-skill backends that had the
+// skill enabled. The old link stays while the old name is an alias.
+func relinkBackends(cfg *config.Config, oldName, newName string, keepOld bool) {
+	for _, b := range perSkillBackends(cfg) {
+		if !slices.Contains(symlink.EnabledSkills(b, cfg.SkillsDir), oldName) {
+			continue
+		}
+		if err := symlink.EnableSkill(b, cfg.SkillsDir, newName); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to enable %s for %s: %v", newName, b.Name, err))
+		}
+		if !keepOld {
+			symlink.DisableSkill(b, cfg.SkillsDir, oldName)
+		}
+	}
+}
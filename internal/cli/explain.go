@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/registry"
+	"lazyas/internal/release"
+	"lazyas/internal/tui/panels"
+)
+
+var explainOffline bool
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <name>",
+	Short: "Explain a skill's status icon and how to resolve it",
+	Long: `Explain in plain words why a skill shows the status it does in the TUI
+and in 'lazyas list' - modified, outdated, local, orphaned, unreachable -
+and list the commands that resolve each state that applies.
+
+Checking for updates and for the installed commit upstream needs the
+network; --offline skips both.
+
+Examples:
+  lazyas explain pdf
+  lazyas explain pdf --offline`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	explainCmd.Flags().BoolVar(&explainOffline, "offline", false, "Skip the update and upstream checks")
+}
+
+// explanation is one state that applies to a skill, why it applies and
+// the commands that resolve it
+type explanation struct {
+	state string
+	why   string
+	fixes [][2]string // command, what it does
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	reg := registry.NewRegistry(cfg)
+	if err := reg.Fetch(false); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to fetch index: %v", err))
+	}
+
+	// The panel carries the configured icons and precedence
+	display := panels.NewSkillsPanel(nil, nil, nil)
+	if err := display.SetStatusDisplay(cfg.StatusIcons, cfg.StatusPrecedence); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: %v", err))
+	}
+
+	name := args[0]
+	local, onDisk := mfst.ScanLocalSkills()[name]
+	info, tracked := mfst.GetInstalled(name)
+
+	if !onDisk && !tracked {
+		skill := reg.GetSkill(name)
+		if skill == nil {
+			return fmt.Errorf("skill %s not found", name)
+		}
+		printExplanation(display, name, []explanation{{
+			state: panels.StateAvailable,
+			why:   i18n.Tf("Not installed. %s provides it.", skill.Source.Repo),
+			fixes: [][2]string{{"lazyas install " + name, i18n.T("Install it")}},
+		}})
+		return nil
+	}
+	if !onDisk {
+		fmt.Println(i18n.Tf("%s is in the manifest but its directory %s is gone.", name, mfst.GetSkillPath(name)))
+		fmt.Println()
+		fmt.Println(i18n.T("Resolve:"))
+		printFixes([][2]string{
+			{"lazyas install " + name + " --force", i18n.T("Install it again")},
+			{"lazyas clean", i18n.T("Drop it from the manifest")},
+		})
+		return nil
+	}
+
+	found := explainStates(cfg, mfst, reg, name, local, info, tracked)
+	applies := make(map[string]explanation, len(found))
+	for _, e := range found {
+		applies[e.state] = e
+	}
+	var ordered []explanation
+	for _, state := range display.StatePrecedence() {
+		if e, ok := applies[state]; ok {
+			ordered = append(ordered, e)
+		}
+	}
+	if len(ordered) == 0 {
+		ordered = append(ordered, explanation{
+			state: panels.StateInstalled,
+			why:   i18n.Tf("Installed from %s and unchanged since. Nothing to do.", info.SourceRepo),
+		})
+	}
+	printExplanation(display, name, ordered)
+	return nil
+}
+
+// explainStates works out which states apply to an installed skill
+func explainStates(cfg *config.Config, mfst *manifest.Manager, reg *registry.Registry, name string, local manifest.LocalSkill, info manifest.InstalledSkill, tracked bool) []explanation {
+	var found []explanation
+
+	if local.IsModified {
+		found = append(found, explanation{
+			state: panels.StateModified,
+			why:   i18n.T("Files in the skill directory differ from the installed commit. Updates skip it so your edits are not lost."),
+			fixes: [][2]string{
+				{"lazyas patch save " + name, i18n.T("Keep the edits as a patch you can re-apply")},
+				{"lazyas update " + name + " --stash", i18n.T("Update and reapply the edits")},
+				{"lazyas contribute " + name, i18n.T("Send the edits upstream")},
+				{"lazyas fork " + name + " --to <url>", i18n.T("Keep the edits in your own repository")},
+				{"lazyas install " + name + " --force", i18n.T("Discard the edits")},
+			},
+		})
+	}
+
+	if !tracked {
+		e := explanation{
+			state: panels.StateLocal,
+			why:   i18n.Tf("%s is in the skills directory but lazyas did not install it, so it is never updated.", local.Path),
+			fixes: [][2]string{
+				{"lazyas publish " + name + " --repo <repo>", i18n.T("Publish it to a repository to share it")},
+				{"lazyas remove " + name, i18n.T("Remove it")},
+			},
+		}
+		if reg.GetSkill(name) != nil {
+			e.fixes = append(e.fixes, [2]string{"lazyas install " + name + " --force", i18n.T("Replace it with the version from the registry")})
+		}
+		return append(found, e)
+	}
+
+	var urls []string
+	for _, repo := range cfg.Repos {
+		urls = append(urls, repo.URL)
+	}
+	for _, orphan := range mfst.Orphans(urls) {
+		if orphan == name {
+			found = append(found, explanation{
+				state: panels.StateOrphaned,
+				why:   i18n.Tf("Installed from %s, which is no longer a configured repository, so it gets no updates.", info.SourceRepo),
+				fixes: [][2]string{
+					{"lazyas config repo add <name> " + info.SourceRepo, i18n.T("Configure the repository again")},
+					{"lazyas fork " + name + " --to <url>", i18n.T("Move it to your own repository")},
+					{"lazyas remove " + name, i18n.T("Remove it")},
+				},
+			})
+		}
+	}
+
+	if explainOffline || info.Commit == "" || release.IsSource(info.SourceRepo) || git.Available() != nil {
+		return found
+	}
+
+	mirror := filepath.Join(cfg.UpstreamDir, git.RepoDirName(info.SourceRepo)+".git")
+	if provenance, err := git.CheckProvenance(info.SourceRepo, mirror, []string{info.Commit}); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to look up %s upstream: %v", name, err))
+	} else if !provenance[info.Commit].Reachable {
+		found = append(found, explanation{
+			state: panels.StateUnreachable,
+			why:   i18n.Tf("The installed commit %s is no longer in %s: it was force-pushed away or its branch deleted, so where it came from cannot be verified.", truncateString(info.Commit, 7), info.SourceRepo),
+			fixes: [][2]string{
+				{"lazyas update " + name, i18n.T("Move to the current upstream version")},
+				{"lazyas versions " + name, i18n.T("List the versions you can move to with --to")},
+				{"lazyas info " + name, i18n.T("Show the recorded provenance")},
+			},
+		})
+	}
+
+	report, err := checkOutdated([]string{name})
+	if err != nil || len(report.Skills) == 0 {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to check for updates: %v", err))
+		}
+		return found
+	}
+	switch s := report.Skills[0]; s.Status {
+	case "outdated":
+		found = append(found, explanation{
+			state: panels.StateOutdated,
+			why:   i18n.Tf("A newer commit %s is available on %s (installed: %s).", truncateString(s.LatestCommit, 7), refLabel(s.TargetRef), truncateString(s.InstalledCommit, 7)),
+			fixes: [][2]string{
+				{"lazyas update " + name + " --dry-run", i18n.T("Preview the update")},
+				{"lazyas update " + name, i18n.T("Update it")},
+				{"lazyas pin " + name, i18n.T("Stay on the installed version")},
+			},
+		})
+	case "held":
+		// Not a state of its own: the list shows it installed
+		found = append(found, explanation{
+			state: panels.StateInstalled,
+			why:   i18n.Tf("A newer version is available on %s, but the update policy holds it back.", refLabel(s.TargetRef)),
+			fixes: [][2]string{
+				{"lazyas update " + name + " --major", i18n.T("Update once, ignoring the policy")},
+				{"lazyas policy " + name + " major", i18n.T("Allow every update from now on")},
+			},
+		})
+	case "error":
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to check for updates: %s", s.Error))
+	}
+	return found
+}
+
+// printExplanation prints the states that apply, the one the list shows first
+func printExplanation(display *panels.SkillsPanel, name string, states []explanation) {
+	shown := states[0].state
+	fmt.Println(i18n.Tf("%s is shown as %s (%s): %s", name, display.StatusGlyph(shown), shown, i18n.T(panels.StateDescriptions[shown])))
+	if len(states) > 1 {
+		fmt.Println(i18n.T("More than one state applies; the list shows the first, as set by status_precedence."))
+	}
+	for _, e := range states {
+		fmt.Println()
+		fmt.Printf("%s %s\n", display.StatusGlyph(e.state), e.state)
+		fmt.Printf("  %s\n", e.why)
+		if len(e.fixes) > 0 {
+			fmt.Println(i18n.T("  Resolve:"))
+			printFixes(e.fixes)
+		}
+	}
+}
+
+// printFixes prints commands with what each does, aligned
+func printFixes(fixes [][2]string) {
+	width := 0
+	for _, f := range fixes {
+		width = max(width, len(f[0]))
+	}
+	for _, f := range fixes {
+		fmt.Printf("    %-*s  # %s\n", width, f[0], f[1])
+	}
+}
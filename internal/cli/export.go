@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/adhoc"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/lockfile"
+	"lazyas/internal/manifest"
+	"lazyas/internal/release"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [lockfile]",
+	Short: "Write a lockfile of the installed skills",
+	Long: `Write a lockfile (default ./lazyas.lock) listing every installed skill
+with its source repository, path and the exact commit checked out.
+'lazyas import <lockfile>' (or sync-lock) on another machine installs
+the identical set, which makes onboarding a teammate one command.
+
+Use - to print the lockfile instead. Local changes and skills that
+lazyas did not install are not part of the lockfile; they are listed
+as warnings.
+
+Examples:
+  lazyas export                 # Write ./lazyas.lock
+  lazyas export team.lock
+  lazyas export - | less`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	lock, warnings := exportLock(mfst)
+	if plan := lockfile.NewPlan(lock, nil); len(plan.Conflicts) > 0 {
+		return fmt.Errorf("installed skills cannot be locked: %s", strings.Join(plan.Conflicts, "; "))
+	}
+
+	path := lockfile.FileName
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if path == "-" {
+		data, err := lock.Marshal()
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(data)
+	} else {
+		if err := lock.Write(path); err != nil {
+			return fmt.Errorf("failed to write lockfile: %w", err)
+		}
+		fmt.Println(i18n.Tf("Exported %d skill(s) to %s", len(lock.Skills), path))
+		fmt.Println(i18n.Tf("Reproduce them elsewhere with 'lazyas import %s'", path))
+	}
+
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: %s", w))
+	}
+	return nil
+}
+
+// exportLock pins the installed skills to the commit actually checked out,
+// which is what the manifest records unless the shared clone moved since
+func exportLock(mfst *manifest.Manager) (*lockfile.Lockfile, []string) {
+	var warnings []string
+	installed := make(map[string]manifest.InstalledSkill)
+	for name, info := range mfst.ListInstalled() {
+		skillPath := mfst.GetSkillPath(name)
+		if _, err := os.Stat(skillPath); err != nil {
+			warnings = append(warnings, i18n.Tf("%s is missing from %s; not exported", name, skillPath))
+			continue
+		}
+		if release.IsSource(info.SourceRepo) {
+			warnings = append(warnings, i18n.Tf("%s was installed from a release asset; not exported (install it with 'lazyas install %s')", name, info.SourceRepo))
+			continue
+		}
+		if info.AdHoc && adhoc.IsLocal(info.SourceRepo) {
+			warnings = append(warnings, i18n.Tf("%s was copied from %s; not exported (install it with 'lazyas install --from')", name, info.SourceRepo))
+			continue
+		}
+		if commit, err := git.HeadCommit(skillPath); err == nil {
+			info.Commit = commit
+		}
+		if info.NeedsResolution() {
+			warnings = append(warnings, i18n.Tf("%s has unresolved conflicts; the lockfile pins the upstream commit", name))
+		} else if modified, _ := git.IsModified(skillPath); modified {
+			warnings = append(warnings, i18n.Tf("%s has local changes that the lockfile does not include", name))
+		}
+		installed[name] = info
+	}
+
+	for name := range mfst.ScanLocalSkills() {
+		if _, tracked := mfst.GetInstalled(name); !tracked {
+			warnings = append(warnings, i18n.Tf("%s is a local skill; copy it separately", name))
+		}
+	}
+	sort.Strings(warnings)
+	return lockfile.FromInstalled(installed), warnings
+}
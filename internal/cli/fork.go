@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/manifest"
+)
+
+var (
+	forkTo   string
+	forkPath string
+)
+
+var forkCmd = &cobra.Command{
+	Use:   "fork <name> --to <repo-url>",
+	Short: "Publish a modified skill to your own repository",
+	Long: `Copy a skill, including its local modifications, into a repository
+you own, commit and push it, then point the skill at that repository.
+
+The skill is placed under skills/<name> in the target repository (change
+with --path) so the repository can also be added as a registry source.
+Afterwards the skill follows the fork's branch and its local changes no
+longer count as modifications. Paths in .lazyasignore are not published.
+
+The target repository must exist (it may be empty) and you need push
+access to it.
+
+Examples:
+  lazyas fork my-skill --to git@github.com:me/my-skills
+  lazyas fork my-skill --to git@github.com:me/my-skill --path .`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFork,
+}
+
+func init() {
+	forkCmd.Flags().StringVar(&forkTo, "to", "", "URL of the repository to push the fork to")
+	forkCmd.Flags().StringVar(&forkPath, "path", "", "Directory for the skill inside the target repository (default skills/<name>)")
+	forkCmd.MarkFlagRequired("to")
+}
+
+func runFork(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name := args[0]
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	info, ok := mfst.GetInstalled(name)
+	if !ok || !mfst.IsInstalled(name) {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+	if info.NeedsResolution() {
+		return fmt.Errorf("skill %s has unresolved conflicts (see 'lazyas resolve %s')", name, name)
+	}
+
+	path := forkPath
+	if path == "" {
+		path = filepath.Join("skills", name)
+	}
+	path = filepath.Clean(path)
+	if path == "." {
+		path = ""
+	}
+
+	skillLink := mfst.GetSkillPath(name)
+	repoDir := filepath.Join(cfg.ReposDir, git.RepoDirName(forkTo))
+
+	fmt.Println(i18n.Tf("Forking %s to %s...", name, forkTo))
+	result, err := git.Fork(git.ForkOptions{
+		SkillPath: skillLink,
+		RepoURL:   forkTo,
+		RepoDir:   repoDir,
+		Path:      path,
+		Message:   fmt.Sprintf("Fork %s from %s at %s", name, info.SourceRepo, truncateString(info.Commit, 7)),
+	})
+	if err != nil {
+		return err
+	}
+
+	branch, err := git.CurrentBranch(repoDir)
+	if err != nil {
+		return err
+	}
+
+	// The edits now live in the fork; restore the original clone
+	if modified, _ := git.IsModified(skillLink); modified {
+		if err := git.ResetChanges(skillLink); err != nil {
+			fmt.Println(i18n.Tf("Warning: failed to discard changes in the original clone: %v", err))
+		}
+	}
+
+	// Point the skill link at the fork
+	if err := os.Remove(skillLink); err != nil {
+		return fmt.Errorf("failed to remove old link: %w", err)
+	}
+	if err := os.Symlink(result.Path, skillLink); err != nil {
+		return fmt.Errorf("failed to create symlink %s -> %s: %w", skillLink, result.Path, err)
+	}
+
+	if err := mfst.SetFork(name, forkTo, path, result.Commit, branch); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if hash, err := integrity.HashDir(skillLink); err == nil {
+		mfst.SetHashes(map[string]string{name: hash})
+	}
+
+	fmt.Println(i18n.Tf("Forked %s (%s), now tracking branch %s of %s", name, truncateString(result.Commit, 7), branch, forkTo))
+	return nil
+}
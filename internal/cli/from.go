@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"lazyas/internal/adhoc"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/history"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/manifest"
+	"lazyas/internal/remote"
+	"lazyas/internal/scan"
+	"lazyas/internal/skillpolicy"
+	"lazyas/internal/trash"
+	"lazyas/internal/usage"
+)
+
+// runFromInstall installs a skill straight from the git URL or local
+// directory given with --from, bypassing the registry. The manifest marks
+// it ad-hoc, so update and remove handle it like any other skill.
+func runFromInstall(cfg *config.Config, from string) error {
+	src, err := adhoc.Parse(from)
+	if err != nil {
+		return fmt.Errorf("invalid --from source: %w", err)
+	}
+	if !src.Local {
+		if err := requireGit(); err != nil {
+			return err
+		}
+	}
+	localName := src.SkillName()
+	if installAs != "" {
+		localName = installAs
+	}
+	if err := validateSkillName(localName); err != nil {
+		return fmt.Errorf("%w (choose one with --as)", err)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	if other, clash := mfst.CaseClash(localName); clash {
+		return fmt.Errorf("%s differs from the installed %s only by case; choose another name with --as", localName, other)
+	}
+	if owner, ok := mfst.AliasOwner(localName); ok {
+		return fmt.Errorf("%s is an alias of %s (remove it first with 'lazyas remove %s')", localName, owner, localName)
+	}
+
+	if installDryRun {
+		fmt.Println(i18n.Tf("Would install %s", localName))
+		if src.Local {
+			fmt.Println(i18n.Tf("  Directory: %s (copied)", src.Repo))
+		} else {
+			fmt.Println(i18n.Tf("  Repository: %s", src.Repo))
+			if src.Path != "" {
+				fmt.Println(i18n.Tf("  Path: %s", src.Path))
+			}
+			if src.Ref != "" {
+				fmt.Println(i18n.Tf("  Version: %s", src.Ref))
+			}
+		}
+		if mfst.IsInstalled(localName) {
+			fmt.Println(i18n.T("  Already installed (would be replaced)"))
+		}
+		if !src.Local {
+			repoDir := filepath.Join(cfg.ReposDir, git.RepoDirName(src.Repo))
+			_, statErr := os.Stat(repoDir)
+			printSizeEstimate(remote.EstimateSize(src.Repo, src.Ref, src.Path, 0, statErr == nil), statErr == nil)
+		}
+		return nil
+	}
+
+	policy, err := scan.ParsePolicy(cfg.RiskPolicy)
+	if err != nil {
+		return err
+	}
+	rules, err := skillpolicy.Load(cfg.SkillPolicyPath())
+	if err != nil {
+		return err
+	}
+	if err := rules.Check(skillpolicy.Subject{Name: localName, Repo: src.Repo}); err != nil {
+		return err
+	}
+
+	if mfst.IsInstalled(localName) {
+		if !installForce {
+			return fmt.Errorf("skill %s is already installed (use --force to replace it)", localName)
+		}
+		if err := trashSkill(cfg, mfst, localName, trash.ReasonOverwrite); err != nil {
+			return fmt.Errorf("failed to move %s to the trash: %w", localName, err)
+		}
+	}
+
+	fmt.Println(i18n.Tf("Installing %s from %s...", localName, src))
+	skillLink := mfst.GetSkillPath(localName)
+	var risk *scan.Report
+	check := func(skillPath string) error {
+		risk, err = scan.Check(skillPath, policy)
+		if err != nil {
+			return err
+		}
+		return rules.CheckRisk(localName, risk)
+	}
+
+	if err := os.MkdirAll(cfg.SkillsDir, 0755); err != nil {
+		return err
+	}
+	var commit string
+	if src.Local {
+		commit, err = adhoc.Refresh(src.Repo, skillLink, "", check)
+	} else {
+		var result *git.CloneResult
+		result, err = git.RepoInstall(git.RepoInstallOptions{
+			RepoURL:   src.Repo,
+			Path:      src.Path,
+			RepoDir:   filepath.Join(cfg.ReposDir, git.RepoDirName(src.Repo)),
+			SkillName: localName,
+			SkillLink: skillLink,
+			Check:     check,
+		})
+		if result != nil {
+			commit = result.Commit
+		}
+	}
+	if risk != nil {
+		printRiskReport(risk)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to install skill: %w", err)
+	}
+
+	// A ref is checked out after the install: a branch is tracked, a tag
+	// pins the skill
+	branch := false
+	if src.Ref != "" {
+		if err := enforceRisk(rules, localName, skillLink, src.Ref); err != nil {
+			return err
+		}
+		result, err := git.Update(skillLink, src.Ref)
+		if err != nil {
+			return fmt.Errorf("failed to check out %s: %w", src.Ref, err)
+		}
+		commit = result.Commit
+		branch, _ = git.RemoteBranchExists(skillLink, src.Ref)
+	}
+
+	if err := mfst.AddSkill(localName, src.Ref, commit, src.Repo, src.Path); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if err := mfst.SetUpstream(localName, localName); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if err := mfst.SetAdHoc(localName, true); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if branch {
+		err = mfst.SetBranch(localName, src.Ref)
+	} else {
+		err = mfst.SetPin(localName, src.Ref)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if hash, err := integrity.HashDir(skillLink); err == nil {
+		mfst.SetHashes(map[string]string{localName: hash})
+	}
+
+	fmt.Println(i18n.Tf("Successfully installed %s", localName))
+	printEnableHint(cfg, localName)
+	usageCounts.Add(usage.Installs, 1)
+	return nil
+}
+
+// onlyAdHoc reports whether the named skills, or all installed ones when
+// names is empty, were installed with --from, so checking them needs no
+// registry
+func onlyAdHoc(installed map[string]manifest.InstalledSkill, names []string) bool {
+	if len(names) == 0 {
+		for name := range installed {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		if !installed[name].AdHoc {
+			return false
+		}
+	}
+	return true
+}
+
+// updateLocalCopy copies a skill installed from a local directory again
+// when the directory changed. It prints what it does and returns the
+// history status and problem of the update.
+func updateLocalCopy(cfg *config.Config, mfst *manifest.Manager, rules *skillpolicy.Policy, name string, info manifest.InstalledSkill) (status, problem string) {
+	if err := rules.Check(skillpolicy.Subject{Name: name, Repo: info.SourceRepo}); err != nil {
+		fmt.Println(i18n.Tf("  %s: %v, skipping", name, err))
+		return history.StatusBlocked, err.Error()
+	}
+	skillDir := mfst.GetSkillPath(name)
+	if hash, err := integrity.HashDir(skillDir); err == nil && info.Hash != "" && hash != info.Hash && !updateForce {
+		fmt.Println(i18n.Tf("  %s: has local changes, skipping (use --force to overwrite)", name))
+		return history.StatusSkipped, "local changes"
+	}
+	if updateDryRun {
+		digest, err := adhoc.Digest(info.SourceRepo)
+		switch {
+		case err != nil:
+			fmt.Println(i18n.Tf("  %s: cannot read %s (would skip)", name, info.SourceRepo))
+		case digest == info.Commit:
+			fmt.Println(i18n.Tf("  %s: up to date", name))
+		default:
+			fmt.Println(i18n.Tf("  %s: would copy %s again", name, info.SourceRepo))
+			return history.StatusUpdated, ""
+		}
+		return history.StatusSkipped, ""
+	}
+
+	policy, err := scan.ParsePolicy(cfg.RiskPolicy)
+	if err != nil {
+		fmt.Println(i18n.Tf("  %s: %v, skipping", name, err))
+		return history.StatusFailed, err.Error()
+	}
+	fmt.Println(i18n.Tf("Updating %s...", name))
+	digest, err := adhoc.Refresh(info.SourceRepo, skillDir, info.Commit, func(dir string) error {
+		report, err := scan.Check(dir, policy)
+		if err != nil {
+			return err
+		}
+		return rules.CheckRisk(name, report)
+	})
+	if err != nil {
+		fmt.Println(i18n.Tf("  Failed: %v", err))
+		return history.StatusFailed, err.Error()
+	}
+	if digest == "" {
+		fmt.Println(i18n.T("  Already up to date"))
+		return history.StatusUpToDate, ""
+	}
+	mfst.AddSkill(name, info.Version, digest, info.SourceRepo, info.SourcePath)
+	fmt.Println(i18n.Tf("  Copied again from %s", info.SourceRepo))
+	return history.StatusUpdated, ""
+}
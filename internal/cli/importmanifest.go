@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/lockfile"
+	"lazyas/internal/manifest"
+	"lazyas/internal/scan"
+	"lazyas/internal/skillpolicy"
+)
+
+var importManifestDryRun bool
+
+var importManifestCmd = &cobra.Command{
+	Use:   "import-manifest <manifest.yaml>",
+	Short: "Install the skills listed in another machine's manifest",
+	Long: `Install the skills of a manifest.yaml copied from another machine
+(~/.lazyas/manifest.yaml there) at the same commits, with their update
+channel, pin and update policy.
+
+Unlike 'lazyas import' with a lockfile, this only adds: skills installed
+here are neither moved nor removed. Entries that cannot be installed are
+reported, e.g. a name taken here by another skill, a release asset, or a
+repository checked out here at another commit (skills of one repository
+share a clone).
+
+Examples:
+  lazyas import-manifest ~/old-laptop/manifest.yaml --dry-run
+  lazyas import-manifest ~/old-laptop/manifest.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportManifest,
+}
+
+func init() {
+	importManifestCmd.Flags().BoolVar(&importManifestDryRun, "dry-run", false, "Show what would be installed without installing")
+}
+
+func runImportManifest(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	path := args[0]
+	other, err := manifest.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	imp := lockfile.NewImport(other.Installed, mfst.ListInstalled())
+	for _, e := range imp.Install {
+		fmt.Println(i18n.Tf("  + %s @ %s (install)", e.Name, truncateString(e.Commit, 7)))
+	}
+	for _, name := range imp.Present {
+		fmt.Println(i18n.Tf("  = %s (already installed)", name))
+	}
+	for _, reason := range imp.Unresolved {
+		fmt.Println(i18n.Tf("  ! %s", reason))
+	}
+	fmt.Println(i18n.Tf("%d to install, %d already installed, %d cannot be imported\n",
+		len(imp.Install), len(imp.Present), len(imp.Unresolved)))
+	if importManifestDryRun {
+		return nil
+	}
+
+	failed := len(imp.Unresolved)
+	if len(imp.Install) > 0 {
+		if err := requireGit(); err != nil {
+			return err
+		}
+		policy, err := scan.ParsePolicy(cfg.RiskPolicy)
+		if err != nil {
+			return err
+		}
+		rules, err := skillpolicy.Load(cfg.SkillPolicyPath())
+		if err != nil {
+			return err
+		}
+
+		var changed []integrity.Target
+		for _, e := range imp.Install {
+			fmt.Println(i18n.Tf("Installing %s@%s...", e.Name, truncateString(e.Commit, 7)))
+			if err := installPinned(cfg, mfst, e, policy, rules); err != nil {
+				fmt.Println(i18n.Tf("  Failed: %v", err))
+				failed++
+				continue
+			}
+			if err := keepChannel(mfst, e.Name, other.Installed[e.Name]); err != nil {
+				fmt.Println(i18n.Tf("  Failed to update manifest: %v", err))
+			}
+			printEnableHint(cfg, e.Name)
+			changed = append(changed, integrity.Target{Name: e.Name, Path: mfst.GetSkillPath(e.Name)})
+		}
+		fmt.Println(i18n.Tf("\nImported %d skill(s)", len(changed)))
+		if len(changed) > 0 {
+			reportValidation(mfst, changed)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d skill(s) of %s could not be imported", failed, path)
+	}
+	return nil
+}
+
+// keepChannel carries the update channel, pin and policy of an imported
+// skill over, so it keeps updating the way it did on the other machine
+func keepChannel(mfst *manifest.Manager, name string, info manifest.InstalledSkill) error {
+	if info.Branch != "" {
+		if err := mfst.SetBranch(name, info.Branch); err != nil {
+			return err
+		}
+	}
+	if info.Pin != "" {
+		if err := mfst.SetPin(name, info.Pin); err != nil {
+			return err
+		}
+	}
+	if info.Policy != "" {
+		return mfst.SetPolicy(name, info.Policy)
+	}
+	return nil
+}
@@ -2,11 +2,18 @@ package cli
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"lazyas/internal/adhoc"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
 	"lazyas/internal/manifest"
 	"lazyas/internal/registry"
+	"lazyas/internal/scan"
 )
 
 var infoCmd = &cobra.Command{
@@ -42,46 +49,126 @@ func runInfo(cmd *cobra.Command, args []string) error {
 
 	skill := reg.GetSkill(name)
 	installed, isInstalled := mfst.GetInstalled(name)
+	if installed.AdHoc {
+		skill = nil // installed with --from; a registry skill of that name is another one
+	}
 
 	if skill == nil && !isInstalled {
 		return fmt.Errorf("skill %s not found", name)
 	}
 
 	// Display info
-	fmt.Printf("Name: %s\n", name)
+	fmt.Println(i18n.Tf("Name: %s", name))
 
 	if skill != nil {
 		if skill.Description != "" {
-			fmt.Printf("Description: %s\n", skill.Description)
+			fmt.Println(i18n.Tf("Description: %s", skill.Description))
 		}
 		if skill.Author != "" {
-			fmt.Printf("Author: %s\n", skill.Author)
+			fmt.Println(i18n.Tf("Author: %s", skill.Author))
+		}
+		fmt.Println(i18n.Tf("Repository: %s", skill.Source.Repo))
+		if providers := reg.Providers(skill.Name); len(providers) > 1 {
+			var parts []string
+			for _, p := range providers {
+				state := i18n.T("shadowed")
+				if p == providers[0] {
+					state = i18n.T("active")
+				}
+				parts = append(parts, fmt.Sprintf("%s [%s]", p.Source.RepoName, state))
+			}
+			fmt.Println(i18n.Tf("Provided by: %s", strings.Join(parts, ", ")))
 		}
-		fmt.Printf("Repository: %s\n", skill.Source.Repo)
 		if skill.Source.Path != "" {
-			fmt.Printf("Path: %s\n", skill.Source.Path)
+			fmt.Println(i18n.Tf("Path: %s", skill.Source.Path))
 		}
 		version := skill.Source.Tag
 		if version == "" {
 			version = "latest"
 		}
-		fmt.Printf("Version: %s\n", version)
+		fmt.Println(i18n.Tf("Version: %s", version))
 		if len(skill.Tags) > 0 {
-			fmt.Printf("Tags: %v\n", skill.Tags)
+			fmt.Println(i18n.Tf("Tags: %v", skill.Tags))
 		}
 	}
 
 	fmt.Println()
 	if isInstalled {
-		fmt.Println("Status: INSTALLED")
-		fmt.Printf("  Installed version: %s\n", installed.Version)
-		fmt.Printf("  Commit: %s\n", installed.Commit)
-		fmt.Printf("  Installed at: %s\n", installed.InstalledAt.Format("2006-01-02 15:04:05"))
-		fmt.Printf("  Location: %s\n", mfst.GetSkillPath(name))
+		fmt.Println(i18n.T("Status: INSTALLED"))
+		fmt.Println(i18n.Tf("  Installed version: %s", installed.Version))
+		fmt.Println(i18n.Tf("  Commit: %s", installed.Commit))
+		if installed.AdHoc {
+			fmt.Println(i18n.Tf("  Installed from: %s (not from a registry)", adhoc.Source{Repo: installed.SourceRepo, Path: installed.SourcePath}))
+		}
+		if installed.SourceRepo != "" && installed.Commit != "" && !adhoc.IsLocal(installed.SourceRepo) && git.Available() == nil {
+			fmt.Println(i18n.Tf("  Upstream: %s", describeProvenance(cfg, installed)))
+		}
+		fmt.Println(i18n.Tf("  Channel: %s", installed.Channel()))
+		if cfg.VerifySignatures && installed.Commit != "" {
+			if sig, err := git.CommitSignature(mfst.GetSkillPath(name), installed.Commit); err == nil {
+				fmt.Println(i18n.Tf("  Signature: %s", sig.Describe()))
+				if sig.Verified() {
+					fmt.Println(i18n.Tf("  Signed by: %s", sig.Identity()))
+				}
+			}
+		}
+		if installed.Orphaned {
+			fmt.Println(i18n.Tf("  Orphaned: %s is no longer a configured repository", installed.SourceRepo))
+		}
+		if installed.ForkedFrom != "" {
+			fmt.Println(i18n.Tf("  Fork of: %s", installed.ForkedFrom))
+			fmt.Println(i18n.Tf("  Source: %s", installed.SourceRepo))
+		}
+		fmt.Println(i18n.Tf("  Installed at: %s", installed.InstalledAt.Format("2006-01-02 15:04:05")))
+		fmt.Println(i18n.Tf("  Location: %s", mfst.GetSkillPath(name)))
+		if report, err := scan.Dir(mfst.GetSkillPath(name)); err == nil {
+			printRiskReport(report)
+		}
+		printProvenanceRecord(installed.Provenance)
 	} else {
-		fmt.Println("Status: Not installed")
-		fmt.Printf("\nInstall with: lazyas install %s\n", name)
+		fmt.Println(i18n.T("Status: Not installed"))
+		fmt.Println(i18n.Tf("\nInstall with: lazyas install %s", name))
 	}
 
 	return nil
 }
+
+// printProvenanceRecord prints the provenance recorded at install time
+func printProvenanceRecord(p *manifest.Provenance) {
+	fmt.Println()
+	fmt.Println(i18n.T("Provenance:"))
+	if p == nil {
+		fmt.Println(i18n.T("  not recorded (installed before lazyas kept provenance; reinstall or update to record it)"))
+		return
+	}
+	fmt.Println(i18n.Tf("  URL: %s", p.URL))
+	if p.Ref != "" {
+		fmt.Println(i18n.Tf("  Requested ref: %s", p.Ref))
+	} else {
+		fmt.Println(i18n.T("  Requested ref: default branch"))
+	}
+	fmt.Println(i18n.Tf("  Commit: %s", p.Commit))
+	if p.Index != "" {
+		fmt.Println(i18n.Tf("  Index: %s", p.Index))
+	}
+	fmt.Println(i18n.Tf("  Installed by: lazyas %s", p.Installer))
+	fmt.Println(i18n.Tf("  Recorded at: %s", p.At.Format(time.RFC3339)))
+}
+
+// describeProvenance says whether the installed commit still exists in its
+// source repository
+func describeProvenance(cfg *config.Config, installed manifest.InstalledSkill) string {
+	mirror := filepath.Join(cfg.UpstreamDir, git.RepoDirName(installed.SourceRepo)+".git")
+	found, err := git.CheckProvenance(installed.SourceRepo, mirror, []string{installed.Commit})
+	if err != nil {
+		return i18n.Tf("unknown (%v)", err)
+	}
+	p := found[installed.Commit]
+	switch {
+	case p.Ref != "":
+		return i18n.Tf("at the tip of %s", p.Ref)
+	case p.Reachable:
+		return i18n.T("in the upstream history")
+	}
+	return i18n.T("NOT FOUND - the commit was force-pushed away or its branch deleted; its provenance cannot be verified")
+}
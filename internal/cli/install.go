@@ -9,30 +9,72 @@ import (
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
 	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
 	"lazyas/internal/manifest"
+	"lazyas/internal/patch"
 	"lazyas/internal/registry"
+	"lazyas/internal/release"
+	"lazyas/internal/remote"
+	"lazyas/internal/scan"
+	"lazyas/internal/skillpolicy"
+	"lazyas/internal/trash"
+	"lazyas/internal/usage"
 )
 
-var installForce bool
+var (
+	installForce  bool
+	installDryRun bool
+	installAs     string
+	installFrom   string
+)
 
 var installCmd = &cobra.Command{
-	Use:   "install <name>[@version]",
+	Use:   "install [repo/]<name>[@version] | gh-release://<owner>/<repo>@<tag>/<asset> | --from <url|path>",
 	Short: "Install a skill from the registry",
 	Long: `Install a skill from the registry.
 
+A gh-release:// source installs a skill packaged as a .tar.gz asset of a
+GitHub release instead. The asset must be listed in a checksums file of
+the same release (checksums.txt, SHA256SUMS or <asset>.sha256) and is
+refused when its sha256 does not match. Such skills are not updated by
+'lazyas update'; install a newer release to move on.
+
+--from installs a skill that is in no registry, from a git URL
+(url[#subdir][@ref]) or a local directory, which is copied. Its
+SKILL.md is validated as usual, and the source is recorded in the
+manifest: 'lazyas update' pulls the repository again (following the
+branch, or held at the tag, given as @ref) or copies the directory
+again, and 'lazyas remove' removes it.
+
 If the skill already exists and has local modifications, you'll be
 prompted to confirm overwrite. Use --force to skip confirmation.
 
+The skill is scanned for scripts, executables and suspicious patterns
+(downloads piped to a shell, large base64 blobs) before it is linked.
+Set risk_policy in config.toml to block-high or block-medium to refuse
+risky skills.
+
 Examples:
   lazyas install my-skill
-  lazyas install my-skill@v1.2.0
-  lazyas install --force my-skill`,
-	Args: cobra.ExactArgs(1),
+  lazyas install my-skill@v1.2.0   # Pinned: 'lazyas update' keeps it at v1.2.0
+  lazyas install my-skill@v1.2     # Updates stay within v1.2.x
+  lazyas install anthropic/my-skill@v1.2.0   # From a specific repository
+  lazyas install --force my-skill
+  lazyas install pdf --as pdf-tools-v2   # Install under another local name
+  lazyas install --dry-run my-skill   # Show source and estimated size only
+  lazyas install gh-release://acme/skills@v1.2.0/pdf.tar.gz
+  lazyas install --from https://github.com/acme/skills#pdf@v1.2.0
+  lazyas install --from ~/work/my-skill`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runInstall,
 }
 
 func init() {
 	installCmd.Flags().BoolVarP(&installForce, "force", "f", false, "Force install, overwriting local modifications")
+	installCmd.Flags().BoolVar(&installDryRun, "dry-run", false, "Show what would be installed and its estimated size")
+	installCmd.Flags().StringVar(&installAs, "as", "", "Install under a different local name")
+	installCmd.Flags().StringVar(&installFrom, "from", "", "Install from a git URL (url[#subdir][@ref]) or local directory instead of the registry")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
@@ -40,9 +82,35 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if installFrom != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--from takes no skill name (use --as to name it)")
+		}
+		return runFromInstall(cfg, installFrom)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("requires a skill name")
+	}
+	if release.IsSource(args[0]) {
+		return runReleaseInstall(cfg, args[0])
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
 
-	// Parse name@version
-	name, version := parseSkillArg(args[0])
+	// Parse [repo/]name@version
+	ref, version := parseSkillArg(args[0])
+	name := ref
+	if _, n, ok := strings.Cut(ref, "/"); ok {
+		name = n
+	}
+	localName := name
+	if installAs != "" {
+		if err := validateSkillName(installAs); err != nil {
+			return err
+		}
+		localName = installAs
+	}
 
 	// Load manifest
 	mfst := manifest.NewManager(cfg)
@@ -50,46 +118,91 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load manifest: %w", err)
 	}
 
+	// "My-Skill" and "my-skill" share a directory on case-insensitive
+	// filesystems; never let one install over the other
+	if other, clash := mfst.CaseClash(localName); clash {
+		if installAs != "" {
+			return fmt.Errorf("%s differs from the installed %s only by case; choose another name", localName, other)
+		}
+		localName = mfst.UniqueName(localName)
+		fmt.Println(i18n.Tf("Warning: %s differs from the installed %s only by case; installing as %s", name, other, localName))
+	}
+
+	if installDryRun {
+		return runInstallDryRun(cfg, mfst, ref, localName, version)
+	}
+
+	policy, err := scan.ParsePolicy(cfg.RiskPolicy)
+	if err != nil {
+		return err
+	}
+	rules, err := skillpolicy.Load(cfg.SkillPolicyPath())
+	if err != nil {
+		return err
+	}
+
+	// Fetch registry
+	fmt.Println(i18n.T("Fetching skill index..."))
+	reg := registry.NewRegistry(cfg)
+	if err := reg.Fetch(false); err != nil {
+		return fmt.Errorf("failed to fetch index: %w", err)
+	}
+
+	// Find skill
+	skill := reg.GetSkill(ref)
+	if skill == nil {
+		return fmt.Errorf("skill %s not found in registry", ref)
+	}
+	if err := rules.Check(policySubject(skill)); err != nil {
+		return err
+	}
+	if reg.Unverified(skill) {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: %s is not listed by a signed index.yaml; its source is unverified", skill.Name))
+	}
+
+	// A different skill under the same name is not overwritten: offer a
+	// name of its own instead
+	if occupant := mfst.Occupant(localName, skill.Source.Repo, skill.Source.Path); occupant != "" && !installForce {
+		localName, err = promptAlternateName(mfst, localName, occupant)
+		if err != nil {
+			return err
+		}
+		if localName == "" {
+			fmt.Println(i18n.T("Cancelled"))
+			return nil
+		}
+	}
+
+	if owner, ok := mfst.AliasOwner(localName); ok {
+		return fmt.Errorf("%s is an alias of %s (remove it first with 'lazyas remove %s')", localName, owner, localName)
+	}
+
 	// Check if already installed
-	if mfst.IsInstalled(name) {
+	if mfst.IsInstalled(localName) {
 		// Check for local modifications
-		skillPath := mfst.GetSkillPath(name)
+		skillPath := mfst.GetSkillPath(localName)
 		modified, _ := git.IsModified(skillPath)
 		if modified && !installForce {
-			fmt.Printf("Skill %s has local modifications.\n", name)
+			fmt.Println(i18n.Tf("Skill %s has local modifications.", localName))
 			modFiles, _ := git.GetModifiedFiles(skillPath)
 			if len(modFiles) > 0 {
-				fmt.Println("Modified files:")
+				fmt.Println(i18n.T("Modified files:"))
 				for _, f := range modFiles {
 					fmt.Printf("  %s\n", f)
 				}
 			}
-			fmt.Printf("Overwrite? [y/N]: ")
-			var response string
-			fmt.Scanln(&response)
-			if response != "y" && response != "Y" {
-				fmt.Println("Cancelled")
+			if !confirm(i18n.T("Overwrite? [y/N]: ")) {
+				fmt.Println(i18n.T("Cancelled"))
 				return nil
 			}
 		} else if !modified && !installForce {
-			return fmt.Errorf("skill %s is already installed (use 'lazyas update' to update)", name)
+			return fmt.Errorf("skill %s is already installed (use 'lazyas update' to update)", localName)
 		}
 
-		// Remove existing to reinstall
-		os.RemoveAll(skillPath)
-	}
-
-	// Fetch registry
-	fmt.Println("Fetching skill index...")
-	reg := registry.NewRegistry(cfg)
-	if err := reg.Fetch(false); err != nil {
-		return fmt.Errorf("failed to fetch index: %w", err)
-	}
-
-	// Find skill
-	skill := reg.GetSkill(name)
-	if skill == nil {
-		return fmt.Errorf("skill %s not found in registry", name)
+		// Move the existing copy to the trash to reinstall
+		if err := trashSkill(cfg, mfst, localName, trash.ReasonOverwrite); err != nil {
+			return fmt.Errorf("failed to move %s to the trash: %w", localName, err)
+		}
 	}
 
 	// Use specified version or default
@@ -98,30 +211,56 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		skillVersion = version
 	}
 
-	fmt.Printf("Installing %s", name)
+	fmt.Print(i18n.Tf("Installing %s", name))
 	if skillVersion != "" {
 		fmt.Printf("@%s", skillVersion)
 	}
+	if localName != name {
+		fmt.Print(i18n.Tf(" as %s", localName))
+	}
 	fmt.Println("...")
 
 	// Install via per-repo sparse clone
 	repoDir := filepath.Join(cfg.ReposDir, git.RepoDirName(skill.Source.Repo))
-	skillLink := mfst.GetSkillPath(name)
+	skillLink := mfst.GetSkillPath(localName)
 
+	var risk *scan.Report
 	result, err := git.RepoInstall(git.RepoInstallOptions{
 		RepoURL:   skill.Source.Repo,
 		Path:      skill.Source.Path,
 		RepoDir:   repoDir,
-		SkillName: name,
+		SkillName: localName,
 		SkillLink: skillLink,
+		Check: func(skillPath string) error {
+			risk, err = scan.Check(skillPath, policy)
+			if err != nil {
+				return err
+			}
+			return rules.CheckRisk(localName, risk)
+		},
 	})
+	if risk != nil {
+		printRiskReport(risk)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to install skill: %w", err)
 	}
 
+	// Move a pinned install to the newest version within its pin
+	if version != "" {
+		skillVersion = git.PinTarget(skillLink, name, skill.Source.Path, version)
+		if err := enforceRisk(rules, localName, skillLink, skillVersion); err != nil {
+			return err
+		}
+		if result, err = git.Update(skillLink, skillVersion); err != nil {
+			return fmt.Errorf("failed to check out %s: %w", skillVersion, err)
+		}
+		fmt.Println(i18n.Tf("  Pinned to %s (%s)", version, skillVersion))
+	}
+
 	// Update manifest
 	if err := mfst.AddSkill(
-		name,
+		localName,
 		skillVersion,
 		result.Commit,
 		skill.Source.Repo,
@@ -129,11 +268,81 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	); err != nil {
 		return fmt.Errorf("failed to update manifest: %w", err)
 	}
+	if err := mfst.SetUpstream(localName, name); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if err := mfst.SetIndexSource(localName, reg.IndexSource(skill)); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	// An explicit @version pins the skill; installing without one unpins it
+	if err := mfst.SetPin(localName, version); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
 
-	fmt.Printf("Successfully installed %s\n", name)
+	// Record a content hash so later drift can be detected
+	if hash, err := integrity.HashDir(skillLink); err == nil {
+		mfst.SetHashes(map[string]string{localName: hash})
+	}
+
+	fmt.Println(i18n.Tf("Successfully installed %s", localName))
+	printEnableHint(cfg, localName)
+	usageCounts.Add(usage.Installs, 1)
+	if patches, _ := patch.NewStore(cfg.PatchesDir).List(localName); len(patches) > 0 {
+		fmt.Println(i18n.Tf("  %d saved patch(es) for %s; re-apply with 'lazyas patch apply %s'", len(patches), localName, localName))
+	}
 	return nil
 }
 
+// printRiskReport lists what the security scan found in a skill
+func printRiskReport(report *scan.Report) {
+	fmt.Println(i18n.Tf("  Risk: %s", report.Summary()))
+	const maxFindings = 10
+	for i, f := range report.Findings {
+		if i == maxFindings {
+			fmt.Println(i18n.Tf("    … %d more", len(report.Findings)-maxFindings))
+			break
+		}
+		fmt.Printf("    [%s] %s (%s)\n", f.Level, f.Path, f.Kind)
+	}
+}
+
+// promptAlternateName asks for another name to install under when name
+// holds a different skill. An empty answer takes the suggested name, "n"
+// or end of input cancels: "" is returned.
+func promptAlternateName(mfst *manifest.Manager, name, occupant string) (string, error) {
+	if occupant == "local" {
+		fmt.Println(i18n.Tf("%s is already taken by a local skill.", name))
+	} else {
+		fmt.Println(i18n.Tf("%s is already taken by a skill from %s.", name, occupant))
+	}
+	suggested := mfst.FreeName(name)
+	response, ok := answer(i18n.Tf("Install as [%s] (enter to accept, another name, or n to cancel): ", suggested), "")
+	if !ok {
+		return "", nil
+	}
+	switch response {
+	case "":
+		return suggested, nil
+	case "n", "N":
+		return "", nil
+	}
+	if err := validateSkillName(response); err != nil {
+		return "", err
+	}
+	if _, taken := mfst.GetInstalled(response); taken || mfst.IsInstalled(response) {
+		return "", fmt.Errorf("%s is already taken too", response)
+	}
+	if other, clash := mfst.CaseClash(response); clash {
+		return "", fmt.Errorf("%s differs from the installed %s only by case", response, other)
+	}
+	return response, nil
+}
+
+// policySubject describes a registry skill for the skill policy file
+func policySubject(skill *registry.SkillEntry) skillpolicy.Subject {
+	return skillpolicy.Subject{Name: skill.Name, Tags: skill.Tags, Repo: skill.Source.Repo}
+}
+
 func parseSkillArg(arg string) (name, version string) {
 	parts := strings.SplitN(arg, "@", 2)
 	name = parts[0]
@@ -142,3 +351,63 @@ func parseSkillArg(arg string) (name, version string) {
 	}
 	return
 }
+
+// runInstallDryRun reports where a skill would come from and how much it
+// would download, without touching the skills directory
+func runInstallDryRun(cfg *config.Config, mfst *manifest.Manager, name, localName, version string) error {
+	reg := registry.NewRegistry(cfg)
+	if err := reg.Fetch(false); err != nil {
+		return fmt.Errorf("failed to fetch index: %w", err)
+	}
+
+	skill := reg.GetSkill(name)
+	if skill == nil {
+		return fmt.Errorf("skill %s not found in registry", name)
+	}
+
+	ref := skill.Source.Tag
+	if version != "" {
+		ref = version
+	}
+
+	if localName != skill.Name {
+		fmt.Println(i18n.Tf("Would install %s as %s", skill.Name, localName))
+	} else {
+		fmt.Println(i18n.Tf("Would install %s", skill.Name))
+	}
+	fmt.Println(i18n.Tf("  Repository: %s", skill.Source.Repo))
+	if skill.Source.Path != "" {
+		fmt.Println(i18n.Tf("  Path: %s", skill.Source.Path))
+	}
+	if ref != "" {
+		fmt.Println(i18n.Tf("  Version: %s", ref))
+	}
+	if mfst.IsInstalled(localName) {
+		fmt.Println(i18n.T("  Already installed (would be replaced)"))
+	}
+	if rules, err := skillpolicy.Load(cfg.SkillPolicyPath()); err != nil {
+		fmt.Println(i18n.Tf("  Warning: %v", err))
+	} else if err := rules.Check(policySubject(skill)); err != nil {
+		fmt.Println(i18n.Tf("  Blocked: %v", err))
+	}
+
+	repoDir := filepath.Join(cfg.ReposDir, git.RepoDirName(skill.Source.Repo))
+	_, statErr := os.Stat(repoDir)
+	est := remote.EstimateSize(skill.Source.Repo, ref, skill.Source.Path, skill.Size, statErr == nil)
+	printSizeEstimate(est, statErr == nil)
+	return nil
+}
+
+func printSizeEstimate(est remote.SizeEstimate, repoCloned bool) {
+	if est.SkillBytes > 0 {
+		fmt.Println(i18n.Tf("  Skill size: %s", remote.FormatSize(est.SkillBytes)))
+	} else {
+		fmt.Println(i18n.T("  Skill size: unknown"))
+	}
+	switch {
+	case repoCloned:
+		fmt.Println(i18n.T("  Download: none (repository already cloned)"))
+	case est.DownloadBytes > 0:
+		fmt.Println(i18n.Tf("  Download: ~%s (repository clone)", remote.FormatSize(est.DownloadBytes)))
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
 	"lazyas/internal/manifest"
 	"lazyas/internal/registry"
 )
@@ -13,6 +15,8 @@ import (
 var (
 	listAvailable bool
 	listAll       bool
+	listVerified  bool
+	listRepo      string
 )
 
 var listCmd = &cobra.Command{
@@ -24,13 +28,22 @@ var listCmd = &cobra.Command{
 Examples:
   lazyas list              # List installed skills
   lazyas list --available  # List available skills from registry
-  lazyas list --all        # List all skills with install status`,
+  lazyas list --all        # List all skills with install status
+  lazyas list --verified   # Installed skills with a verified signature
+  lazyas list --all --repo community  # Only skills of one repository
+
+With verify_signatures = true in config.toml, installed skills show who
+signed their commit.`,
 	RunE: runList,
 }
 
 func init() {
 	listCmd.Flags().BoolVarP(&listAvailable, "available", "a", false, "List available skills from registry")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "List all skills with install status")
+	listCmd.Flags().BoolVar(&listVerified, "verified", false, "List only installed skills whose commit has a verified signature")
+	listCmd.Flags().StringVar(&listRepo, "repo", "", "List only skills of this configured repository")
+	listCmd.MarkFlagsMutuallyExclusive("verified", "available")
+	listCmd.MarkFlagsMutuallyExclusive("verified", "all")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -38,6 +51,9 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := checkRepoFilter(cfg, listRepo); err != nil {
+		return err
+	}
 
 	// Load manifest
 	mfst := manifest.NewManager(cfg)
@@ -49,15 +65,74 @@ func runList(cmd *cobra.Command, args []string) error {
 		return listFromRegistry(cfg, mfst, listAll)
 	}
 
-	return listInstalled(mfst)
+	return listInstalled(cfg, mfst, cfg.VerifySignatures || listVerified, listVerified)
+}
+
+// checkRepoFilter fails when a --repo value names no configured repository
+func checkRepoFilter(cfg *config.Config, name string) error {
+	if name != "" && cfg.GetRepo(name) == nil {
+		return fmt.Errorf("repository %s not found", name)
+	}
+	return nil
+}
+
+// repoSkills returns the skills listed by the configured repository name,
+// or all of them for ""
+func repoSkills(skills []registry.SkillEntry, name string) []registry.SkillEntry {
+	if name == "" {
+		return skills
+	}
+	var scoped []registry.SkillEntry
+	for _, skill := range skills {
+		if skill.Source.RepoName == name {
+			scoped = append(scoped, skill)
+		}
+	}
+	return scoped
+}
+
+// installedFrom returns the installed skills that came from the configured
+// repository name, or all of them for "". A skill belongs to the repository
+// that lists it, which for index.yaml and include listings is not the one
+// it is cloned from; skills no registry lists are matched by their URL.
+func installedFrom(cfg *config.Config, mfst *manifest.Manager, reg *registry.Registry, name string) map[string]manifest.InstalledSkill {
+	repo := cfg.GetRepo(name)
+	if repo == nil {
+		return mfst.ListInstalled()
+	}
+	type source struct{ repo, path string }
+	listed := make(map[source]bool)
+	for _, skill := range repoSkills(reg.ListSkills(), name) {
+		listed[source{skill.Source.Repo, skill.Source.Path}] = true
+	}
+	installed := make(map[string]manifest.InstalledSkill)
+	for skill, info := range mfst.ListInstalled() {
+		if listed[source{info.SourceRepo, info.SourcePath}] || info.SourceRepo == repo.URL {
+			installed[skill] = info
+		}
+	}
+	return installed
 }
 
-func listInstalled(mfst *manifest.Manager) error {
-	installed := mfst.ListInstalled()
+// listInstalled prints the installed skills. With signatures each shows who
+// signed its commit; verifiedOnly leaves out those without a good signature.
+func listInstalled(cfg *config.Config, mfst *manifest.Manager, signatures, verifiedOnly bool) error {
+	var reg *registry.Registry
+	if listRepo != "" {
+		// The registry tells which repository listed each skill; without
+		// it skills are matched by their URL
+		reg = registry.NewRegistry(cfg)
+		reg.Fetch(false)
+	}
+	installed := installedFrom(cfg, mfst, reg, listRepo)
 
+	if len(installed) == 0 && listRepo != "" {
+		fmt.Println(i18n.Tf("No skills installed from %s", listRepo))
+		return nil
+	}
 	if len(installed) == 0 {
-		fmt.Println("No skills installed")
-		fmt.Println("\nUse 'lazyas browse' or 'lazyas list --available' to see available skills")
+		fmt.Println(i18n.T("No skills installed"))
+		fmt.Println(i18n.T("\nUse 'lazyas browse' or 'lazyas list --available' to see available skills"))
 		return nil
 	}
 
@@ -68,42 +143,66 @@ func listInstalled(mfst *manifest.Manager) error {
 	}
 	sort.Strings(names)
 
-	fmt.Println("Installed skills:")
+	fmt.Println(i18n.T("Installed skills:"))
 	fmt.Println()
 
+	shown := 0
 	for _, name := range names {
 		info := installed[name]
+		var sig git.Signature
+		if signatures {
+			sig, _ = git.CommitSignature(mfst.GetSkillPath(name), info.Commit)
+			if verifiedOnly && !sig.Verified() {
+				continue
+			}
+		}
+		shown++
 		version := info.Version
 		if version == "" {
 			version = "latest"
 		}
-		fmt.Printf("  ● %s@%s\n", name, version)
+		if info.Branch != "" {
+			fmt.Println(i18n.Tf("  ● %s@%s (tracking %s)", name, version, info.Branch))
+		} else {
+			fmt.Printf("  ● %s@%s\n", name, version)
+		}
 		if info.Commit != "" {
-			fmt.Printf("    commit: %s\n", truncateString(info.Commit, 7))
+			fmt.Println(i18n.Tf("    commit: %s", truncateString(info.Commit, 7)))
 		}
+		if info.Orphaned {
+			fmt.Println(i18n.Tf("    orphaned: %s is no longer a configured repository", info.SourceRepo))
+		}
+		if sig.Verified() {
+			fmt.Println(i18n.Tf("    signed by: %s", sig.Identity()))
+		} else if signatures {
+			fmt.Println(i18n.Tf("    signature: %s", sig.Describe()))
+		}
+	}
+	if verifiedOnly && shown == 0 {
+		fmt.Println(i18n.T("  No installed skill has a verified signature"))
 	}
 
 	return nil
 }
 
 func listFromRegistry(cfg *config.Config, mfst *manifest.Manager, showStatus bool) error {
-	fmt.Println("Fetching skill index...")
+	fmt.Println(i18n.T("Fetching skill index..."))
 
 	reg := registry.NewRegistry(cfg)
 	if err := reg.Fetch(false); err != nil {
 		return fmt.Errorf("failed to fetch index: %w", err)
 	}
 
-	skills := reg.ListSkills()
+	skills := repoSkills(reg.ListSkills(), listRepo)
 	if len(skills) == 0 {
-		fmt.Println("No skills available in registry")
+		fmt.Println(i18n.T("No skills available in registry"))
 		return nil
 	}
 
 	if showStatus {
-		fmt.Println("Skills (● installed, ○ available):")
+		fmt.Println(i18n.T("Skills (● installed, ○ available):"))
 	} else {
-		fmt.Println("Available skills:")
+		fmt.Println(i18n.T("Available skills:"))
 	}
 	fmt.Println()
 
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/skillmd"
+	"lazyas/internal/templates"
+)
+
+var (
+	newDescription  string
+	newTags         string
+	newGit          bool
+	newTemplate     string
+	newTemplateRepo string
+	newAuthor       string
+)
+
+var newCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Create a new skill from a template",
+	Long: `Create a skill directory in ~/.lazyas/skills with a starter SKILL.md
+(frontmatter name, description and tags). The skill is local-only: it is
+not tracked in the manifest, every backend sees it right away, and the
+TUI lists it next to installed skills.
+
+--template copies a skeleton from the templates repository (templates_repo
+in config.toml, or --template-repo) instead: a directory with a SKILL.md,
+at the top level of the repository or under templates/. Its files may use
+{{name}}, {{author}} and {{description}}; lazyas asks for the author
+(default: git's user.name) and the description unless they are given.
+
+Examples:
+  lazyas new my-skill
+  lazyas new pdf-forms --description "Fill in PDF forms" --tags pdf,forms
+  lazyas new my-skill --git   # Also make it a git repository
+  lazyas new csv-report --template python-tool`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNew,
+}
+
+func init() {
+	newCmd.Flags().StringVarP(&newDescription, "description", "d", "", "Description for the SKILL.md frontmatter")
+	newCmd.Flags().StringVarP(&newTags, "tags", "t", "", "Comma-separated tags for the SKILL.md frontmatter (not with --template)")
+	newCmd.Flags().BoolVar(&newGit, "git", false, "Initialize a git repository with the template committed")
+	newCmd.Flags().StringVar(&newTemplate, "template", "", "Create the skill from this template of the templates repository")
+	newCmd.Flags().StringVar(&newTemplateRepo, "template-repo", "", "Templates repository to use instead of templates_repo")
+	newCmd.Flags().StringVar(&newAuthor, "author", "", "Author filled into the template")
+}
+
+func runNew(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.EnsureDirs(); err != nil {
+		return fmt.Errorf("failed to create directories: %w", err)
+	}
+
+	name := args[0]
+	if err := validateSkillName(name); err != nil {
+		return err
+	}
+	if newGit || newTemplate != "" {
+		if err := requireGit(); err != nil {
+			return err
+		}
+	}
+	templateRepo := newTemplateRepo
+	if templateRepo == "" {
+		templateRepo = cfg.TemplatesRepo
+	}
+	if newTemplate != "" && templateRepo == "" {
+		return fmt.Errorf("no templates repository configured - set templates_repo in %s or pass --template-repo", cfg.ConfigPath)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	if owner, ok := mfst.AliasOwner(name); ok {
+		return fmt.Errorf("%s is an alias of %s; choose another name", name, owner)
+	}
+	if other, clash := mfst.CaseClash(name); clash {
+		return fmt.Errorf("%s differs from the installed %s only by case; choose another name", name, other)
+	}
+	skillDir := mfst.GetSkillPath(name)
+	if _, err := os.Lstat(skillDir); err == nil {
+		return fmt.Errorf("%s already exists", skillDir)
+	}
+
+	skillMd := filepath.Join(skillDir, "SKILL.md")
+	if newTemplate != "" {
+		if err := newFromTemplate(templateRepo, name, skillDir); err != nil {
+			return err
+		}
+	} else {
+		var tags []string
+		for _, tag := range strings.Split(newTags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		if err := os.Mkdir(skillDir, 0755); err != nil {
+			return fmt.Errorf("failed to create skill directory: %w", err)
+		}
+		if err := os.WriteFile(skillMd, []byte(skillmd.Template(name, newDescription, tags)), 0644); err != nil {
+			os.RemoveAll(skillDir)
+			return fmt.Errorf("failed to write SKILL.md: %w", err)
+		}
+	}
+	fmt.Println(i18n.Tf("Created %s", skillMd))
+
+	if newGit {
+		if err := git.InitRepo(skillDir, "Scaffold "+name); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("Warning: %v", err))
+		} else {
+			fmt.Println(i18n.T("Initialized a git repository with the template committed"))
+		}
+	}
+
+	fmt.Println(i18n.Tf("Edit SKILL.md to write the skill; it is available to all linked backends as %s.", name))
+	return nil
+}
+
+// newFromTemplate creates skillDir from the template newTemplate of
+// repoURL, asking for the author and description not given as flags
+func newFromTemplate(repoURL, name, skillDir string) error {
+	tmpDir, err := os.MkdirTemp("", "lazyas-templates-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fmt.Println(i18n.Tf("Fetching templates from %s...", repoURL))
+	repoDir := filepath.Join(tmpDir, "repo")
+	if err := git.CloneShallow(repoURL, repoDir); err != nil {
+		return fmt.Errorf("failed to fetch templates: %w", err)
+	}
+	src, err := templates.Find(repoDir, newTemplate)
+	if err != nil {
+		return err
+	}
+
+	values := templates.Values{Name: name, Author: newAuthor, Description: newDescription}
+	if values.Author == "" {
+		values.Author = git.UserName()
+		values.Author = ask(i18n.Tf("Author [%s]: ", values.Author), values.Author)
+	}
+	if values.Description == "" {
+		values.Description = ask(i18n.T("Description: "), skillmd.TodoDescription)
+	}
+	if err := templates.Render(src, skillDir, values); err != nil {
+		return err
+	}
+
+	// A template that hard-codes the name leaves a skill agents know
+	// under another one
+	if data, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md")); err == nil {
+		if fm, err := skillmd.ParseFrontmatter(string(data)); err == nil && fm.Name != name {
+			fmt.Fprintln(os.Stderr, i18n.Tf("Warning: the template's SKILL.md names the skill %q; the template should use {{name}}", fm.Name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/adhoc"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/registry"
+	"lazyas/internal/release"
+	"lazyas/internal/semver"
+)
+
+// reportSchemaVersion is bumped when fields of the --json reports change
+// incompatibly
+const reportSchemaVersion = 1
+
+// Exit codes for --exit-code; a clean result exits 0
+const (
+	exitDrift = 1 // outdated or drifted skills found
+	exitError = 2 // a check could not be completed
+)
+
+var (
+	outdatedJSON     bool
+	outdatedExitCode bool
+)
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated [name]",
+	Short: "List installed skills with newer upstream versions",
+	Long: `Check installed skills against their upstream without changing anything.
+
+Each skill is compared with the ref 'lazyas update' would move it to: the
+tracked branch, the registry tag, the newest release within a pin, or the
+remote default branch. Updates held back by the semver policy are reported
+as "held".
+
+With --exit-code the command exits 0 when everything is up to date, 1 when
+a skill is outdated and 2 when a check failed, for gating CI pipelines.
+
+Examples:
+  lazyas outdated
+  lazyas outdated --json --exit-code`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOutdated,
+}
+
+func init() {
+	outdatedCmd.Flags().BoolVar(&outdatedJSON, "json", false, "Print a machine-readable report")
+	outdatedCmd.Flags().BoolVar(&outdatedExitCode, "exit-code", false, "Exit 1 when outdated, 2 on errors")
+}
+
+// outdatedReport is the --json output of lazyas outdated
+type outdatedReport struct {
+	SchemaVersion int             `json:"schema_version"`
+	Skills        []outdatedSkill `json:"skills"`
+	Outdated      int             `json:"outdated"`
+	Held          int             `json:"held"`
+	Errors        int             `json:"errors"`
+}
+
+type outdatedSkill struct {
+	Name             string `json:"name"`
+	Status           string `json:"status"` // up-to-date, outdated, held, error, release
+	InstalledVersion string `json:"installed_version"`
+	InstalledCommit  string `json:"installed_commit"`
+	TargetRef        string `json:"target_ref"`
+	LatestCommit     string `json:"latest_commit,omitempty"`
+	SourceRepo       string `json:"source_repo"`
+	Error            string `json:"error,omitempty"`
+}
+
+func runOutdated(cmd *cobra.Command, args []string) error {
+	report, err := checkOutdated(args)
+	if err != nil {
+		if outdatedExitCode {
+			fmt.Fprintln(os.Stderr, err)
+			return exitCode(cmd, exitError)
+		}
+		return err
+	}
+
+	if outdatedJSON {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printOutdated(report)
+	}
+
+	if !outdatedExitCode {
+		return nil
+	}
+	switch {
+	case report.Errors > 0:
+		return exitCode(cmd, exitError)
+	case report.Outdated > 0:
+		return exitCode(cmd, exitDrift)
+	}
+	return nil
+}
+
+func checkOutdated(args []string) (*outdatedReport, error) {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return nil, err
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	installed := mfst.ListInstalled()
+	names := args
+	if len(names) == 0 {
+		for name := range installed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	} else if _, ok := installed[names[0]]; !ok {
+		return nil, fmt.Errorf("skill %s is not installed", names[0])
+	}
+
+	reg := registry.NewRegistry(cfg)
+	if len(installed) > 0 {
+		if err := reg.Fetch(false); err != nil && !onlyAdHoc(installed, names) {
+			return nil, fmt.Errorf("failed to fetch index: %w", err)
+		}
+	}
+
+	report := &outdatedReport{SchemaVersion: reportSchemaVersion, Skills: []outdatedSkill{}}
+	resolved := make(map[string]string) // "repo\x00ref" -> commit; skills of a repo share a clone
+	for _, name := range names {
+		info := installed[name]
+		registryTag := ""
+		if skill := reg.GetSkill(info.RegistryName(name)); skill != nil && !info.AdHoc {
+			registryTag = skill.Source.Tag
+		}
+
+		s := outdatedSkill{
+			Name:             name,
+			InstalledVersion: info.Version,
+			InstalledCommit:  info.Commit,
+			TargetRef:        info.TargetRef(registryTag),
+			SourceRepo:       info.SourceRepo,
+		}
+		if release.IsSource(info.SourceRepo) {
+			s.Status = "release"
+			report.Skills = append(report.Skills, s)
+			continue
+		}
+		if info.AdHoc && adhoc.IsLocal(info.SourceRepo) {
+			// A copied directory is outdated when its contents changed
+			digest, err := adhoc.Digest(info.SourceRepo)
+			switch {
+			case err != nil:
+				s.Status = "error"
+				s.Error = err.Error()
+				report.Errors++
+			case digest == info.Commit:
+				s.Status = "up-to-date"
+			default:
+				s.Status = "outdated"
+				s.LatestCommit = digest
+				report.Outdated++
+			}
+			report.Skills = append(report.Skills, s)
+			continue
+		}
+		if info.Pin != "" {
+			s.TargetRef = git.PinTarget(mfst.GetSkillPath(name), info.RegistryName(name), info.SourcePath, info.Pin)
+		}
+
+		key := info.SourceRepo + "\x00" + s.TargetRef
+		latest, ok := resolved[key]
+		if !ok {
+			latest, err = git.RemoteRef(mfst.GetSkillPath(name), s.TargetRef)
+			if err != nil {
+				s.Status = "error"
+				s.Error = err.Error()
+				report.Errors++
+				report.Skills = append(report.Skills, s)
+				continue
+			}
+			resolved[key] = latest
+		}
+		s.LatestCommit = latest
+
+		policy := semver.EffectivePolicy(info.Policy, cfg.UpdatePolicy)
+		switch {
+		case latest == info.Commit:
+			s.Status = "up-to-date"
+		case !policy.Allows(info.Version, s.TargetRef):
+			s.Status = "held"
+			report.Held++
+		default:
+			s.Status = "outdated"
+			report.Outdated++
+		}
+		report.Skills = append(report.Skills, s)
+	}
+	return report, nil
+}
+
+func printOutdated(report *outdatedReport) {
+	if len(report.Skills) == 0 {
+		fmt.Println(i18n.T("No skills installed"))
+		return
+	}
+
+	for _, s := range report.Skills {
+		switch s.Status {
+		case "outdated":
+			if adhoc.IsLocal(s.SourceRepo) {
+				fmt.Println(i18n.Tf("  %s: %s changed since it was copied", s.Name, s.SourceRepo))
+			} else {
+				fmt.Println(i18n.Tf("  %s: %s → %s (%s)", s.Name, truncateString(s.InstalledCommit, 7), truncateString(s.LatestCommit, 7), refLabel(s.TargetRef)))
+			}
+		case "held":
+			fmt.Println(i18n.Tf("  %s: %s held by policy", s.Name, refLabel(s.TargetRef)))
+		case "error":
+			fmt.Println(i18n.Tf("  %s: check failed: %s", s.Name, s.Error))
+		case "release":
+			fmt.Println(i18n.Tf("  %s: %s from a release asset, not checked", s.Name, s.InstalledVersion))
+		}
+	}
+
+	if report.Outdated == 0 && report.Errors == 0 {
+		fmt.Println(i18n.T("All skills are up to date"))
+		return
+	}
+	fmt.Println(i18n.Tf("\n%d outdated, %d held, %d failed", report.Outdated, report.Held, report.Errors))
+}
+
+// refLabel names a target ref for display; "" is the remote default branch
+func refLabel(ref string) string {
+	if ref == "" {
+		return "latest"
+	}
+	return ref
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/patch"
+)
+
+var patchCmd = &cobra.Command{
+	Use:   "patch",
+	Short: "Save and re-apply local modifications as named patches",
+	Long: `Capture a skill's local modifications as a named patch stored in
+~/.lazyas/patches/<skill>/<name>.patch, so customizations survive
+overwrite-and-reinstall flows and can be re-applied after updates.`,
+}
+
+var patchSaveCmd = &cobra.Command{
+	Use:   "save <skill> [name]",
+	Short: "Save the skill's current local diff as a patch",
+	Long: `Save the skill's current local modifications (including new and
+deleted files) as a named patch. The name defaults to "local"; saving
+under an existing name replaces that patch.
+
+Examples:
+  lazyas patch save my-skill
+  lazyas patch save my-skill shorter-prompts`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPatchSave,
+}
+
+var patchApplyCmd = &cobra.Command{
+	Use:   "apply <skill> [name]",
+	Short: "Apply a saved patch to the skill",
+	Long: `Apply a saved patch (default "local") to the skill's working tree.
+If it no longer applies cleanly, a three-way apply is attempted and
+conflicting files are left with conflict markers.
+
+Examples:
+  lazyas patch apply my-skill
+  lazyas patch apply my-skill shorter-prompts`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPatchApply,
+}
+
+var patchListCmd = &cobra.Command{
+	Use:     "list [skill]",
+	Short:   "List saved patches",
+	Aliases: []string{"ls"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runPatchList,
+}
+
+var patchDropCmd = &cobra.Command{
+	Use:   "drop <skill> <name>",
+	Short: "Delete a saved patch",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runPatchDrop,
+}
+
+func init() {
+	patchCmd.AddCommand(patchSaveCmd)
+	patchCmd.AddCommand(patchApplyCmd)
+	patchCmd.AddCommand(patchListCmd)
+	patchCmd.AddCommand(patchDropCmd)
+}
+
+// patchName returns the optional patch name argument
+func patchName(args []string) string {
+	if len(args) > 1 {
+		return args[1]
+	}
+	return "local"
+}
+
+func runPatchSave(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name, patchNm := args[0], patchName(args)
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	info, ok := mfst.GetInstalled(name)
+	if !ok || !mfst.IsInstalled(name) {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	diff, err := git.CaptureDiff(mfst.GetSkillPath(name))
+	if err != nil {
+		return fmt.Errorf("failed to capture changes: %w", err)
+	}
+	if len(diff) == 0 {
+		fmt.Println(i18n.Tf("%s has no local modifications", name))
+		return nil
+	}
+
+	p, err := patch.NewStore(cfg.PatchesDir).Save(name, patchNm, info.Commit, diff)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.Tf("Saved patch %s for %s", p.Name, name))
+	fmt.Printf("  %s\n", p.Path)
+	return nil
+}
+
+func runPatchApply(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name, patchNm := args[0], patchName(args)
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	if !mfst.IsInstalled(name) {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	_, data, err := patch.NewStore(cfg.PatchesDir).Load(name, patchNm)
+	if err != nil {
+		return err
+	}
+
+	conflicts, err := git.ApplyPatch(mfst.GetSkillPath(name), data)
+	if err != nil {
+		return fmt.Errorf("failed to apply patch %s: %w", patchNm, err)
+	}
+
+	if len(conflicts) > 0 {
+		if _, tracked := mfst.GetInstalled(name); tracked {
+			mfst.SetConflicts(name, conflicts)
+		}
+		fmt.Println(i18n.Tf("Applied patch %s with conflicts (fix the markers in these files):", patchNm))
+		for _, f := range conflicts {
+			fmt.Printf("  %s\n", f)
+		}
+		fmt.Println(i18n.Tf("Then run: lazyas resolve %s", name))
+		return nil
+	}
+
+	fmt.Println(i18n.Tf("Applied patch %s to %s", patchNm, name))
+	return nil
+}
+
+func runPatchList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	skill := ""
+	if len(args) > 0 {
+		skill = args[0]
+	}
+
+	patches, err := patch.NewStore(cfg.PatchesDir).List(skill)
+	if err != nil {
+		return fmt.Errorf("failed to list patches: %w", err)
+	}
+	if len(patches) == 0 {
+		fmt.Println(i18n.T("No saved patches"))
+		return nil
+	}
+
+	fmt.Println(i18n.T("Saved patches:"))
+	current := ""
+	for _, p := range patches {
+		if p.Skill != current {
+			fmt.Printf("\n  %s\n", p.Skill)
+			current = p.Skill
+		}
+		line := fmt.Sprintf("    %s", p.Name)
+		if p.Base != "" {
+			line += fmt.Sprintf("  base %s", truncateString(p.Base, 7))
+		}
+		if !p.Saved.IsZero() {
+			line += "  " + p.Saved.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runPatchDrop(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := patch.NewStore(cfg.PatchesDir).Drop(args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.Tf("Dropped patch %s for %s", args[1], args[0]))
+	return nil
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/semver"
+	"lazyas/internal/skillpolicy"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <name> [version]",
+	Short: "Pin an installed skill to a version",
+	Long: `Pin an installed skill so 'lazyas update' leaves it alone.
+
+A full version (v1.2.0), another tag or a commit holds the skill exactly
+there. A partial version lets updates move within it: v1.2 follows the
+newest v1.2.x release and v1 the newest v1.x.y. Without a version the skill
+is pinned where it is. A skill outside its new pin is moved into it.
+
+Pinning stops the skill from tracking a branch. 'lazyas install
+name@version' pins too.
+
+Examples:
+  lazyas pin my-skill            # Keep the installed version
+  lazyas pin my-skill v1.2.0     # Hold at v1.2.0
+  lazyas pin my-skill v1.2       # Patch releases only`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPin,
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <name>",
+	Short: "Let a pinned skill follow release tags again",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUnpin,
+}
+
+func runPin(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name := args[0]
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	info, ok := mfst.GetInstalled(name)
+	if !ok {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	pin := info.Version
+	if len(args) == 2 {
+		pin = args[1]
+	} else if pin == "" {
+		pin = info.Commit
+	}
+
+	if err := mfst.SetPin(name, pin); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if info.Branch != "" {
+		fmt.Println(i18n.Tf("%s no longer follows branch %s", name, info.Branch))
+	}
+	fmt.Println(i18n.Tf("%s is pinned to %s", name, pin))
+
+	if pin == info.Commit || semver.MatchesPin(pin, info.Version) {
+		return nil
+	}
+	rules, err := skillpolicy.Load(cfg.SkillPolicyPath())
+	if err != nil {
+		return err
+	}
+	target := git.PinTarget(mfst.GetSkillPath(name), info.RegistryName(name), info.SourcePath, pin)
+	return runUpdateTo(cfg, mfst, rules, name, target)
+}
+
+func runUnpin(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := args[0]
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	info, ok := mfst.GetInstalled(name)
+	if !ok {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+	if info.Pin == "" {
+		fmt.Println(i18n.Tf("%s is not pinned", name))
+		return nil
+	}
+
+	if err := mfst.SetPin(name, ""); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	fmt.Println(i18n.Tf("%s is no longer pinned to %s", name, info.Pin))
+	fmt.Println(i18n.Tf("Run 'lazyas update %s' to move it to the latest release.", name))
+	return nil
+}
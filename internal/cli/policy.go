@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/semver"
+)
+
+var policyCmd = &cobra.Command{
+	Use:   "policy <name> [patch|minor|major|default]",
+	Short: "Show or set a skill's semver update policy",
+	Long: `Show or set how far 'lazyas update' may move a skill whose versions
+are semver tags:
+
+  patch   only v1.2.x updates
+  minor   v1.x.y updates, never a new major version
+  major   any newer version
+
+'default' clears the per-skill setting so the global update_policy from
+config.toml applies (major when unset). Skills tracking a branch are not
+affected.
+
+Examples:
+  lazyas policy my-skill           # Show effective policy
+  lazyas policy my-skill minor     # Hold back major versions
+  lazyas policy my-skill default   # Use the global policy`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPolicy,
+}
+
+func runPolicy(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := args[0]
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	info, ok := mfst.GetInstalled(name)
+	if !ok {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	if len(args) == 1 {
+		policy := semver.EffectivePolicy(info.Policy, cfg.UpdatePolicy)
+		if info.Policy == "" {
+			fmt.Println(i18n.Tf("%s: %s (global default)", name, policy))
+		} else {
+			fmt.Println(i18n.Tf("%s: %s", name, policy))
+		}
+		return nil
+	}
+
+	value := args[1]
+	if value == "default" {
+		value = ""
+	} else {
+		policy, err := semver.ParsePolicy(value)
+		if err != nil {
+			return err
+		}
+		value = string(policy)
+	}
+
+	if err := mfst.SetPolicy(name, value); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("%s: update policy set to %s", name, semver.EffectivePolicy(value, cfg.UpdatePolicy)))
+	return nil
+}
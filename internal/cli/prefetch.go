@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+)
+
+var prefetchRefresh bool
+
+var prefetchCmd = &cobra.Command{
+	Use:   "prefetch [repo|skill...]",
+	Short: "Download skills ahead of time so installs are instant",
+	Long: `Clone repositories into ~/.lazyas/repos and check out the files of the
+given skills, and refresh the skill index cache, so that installing those
+skills later (from the CLI or the TUI) needs no download.
+
+Arguments are configured repository names or skill names ([repo/]name).
+Without arguments every skill of every repository is prefetched.
+
+Skills are not installed. Clones that already exist are fetched but not
+moved, so installed skills keep their version. Run it on a schedule, e.g.
+overnight from cron, to keep installs fast.
+
+Examples:
+  lazyas prefetch
+  lazyas prefetch anthropic
+  lazyas prefetch pdf anthropic/docx
+  lazyas prefetch --refresh   # Also bypass the index cache TTL`,
+	RunE: runPrefetch,
+}
+
+func init() {
+	prefetchCmd.Flags().BoolVar(&prefetchRefresh, "refresh", false, "Refresh the skill index even if the cache is fresh")
+}
+
+func runPrefetch(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.T("Fetching skill index..."))
+	reg := registry.NewRegistry(cfg)
+	if err := reg.Fetch(prefetchRefresh); err != nil {
+		return fmt.Errorf("failed to fetch index: %w", err)
+	}
+
+	skills, err := prefetchTargets(cfg, reg, args)
+	if err != nil {
+		return err
+	}
+	if len(skills) == 0 {
+		fmt.Println(i18n.T("No skills to prefetch"))
+		return nil
+	}
+
+	// One clone per repository, with the paths of all its skills
+	var repos []string
+	paths := make(map[string][]string)
+	for _, skill := range skills {
+		if _, ok := paths[skill.Source.Repo]; !ok {
+			repos = append(repos, skill.Source.Repo)
+		}
+		paths[skill.Source.Repo] = append(paths[skill.Source.Repo], skill.Source.Path)
+	}
+
+	failed := 0
+	for _, repoURL := range repos {
+		fmt.Println(i18n.Tf("Prefetching %d skill(s) from %s...", len(paths[repoURL]), repoURL))
+		repoDir := filepath.Join(cfg.ReposDir, git.RepoDirName(repoURL))
+		if err := git.Prefetch(repoURL, repoDir, paths[repoURL]); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to prefetch %s: %v", repoURL, err))
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories could not be prefetched", failed, len(repos))
+	}
+
+	fmt.Println(i18n.Tf("Prefetched %d skill(s) from %d repositories", len(skills), len(repos)))
+	return nil
+}
+
+// prefetchTargets resolves the arguments to registry skills: a configured
+// repository stands for all of its skills. No arguments means every skill.
+func prefetchTargets(cfg *config.Config, reg *registry.Registry, args []string) ([]registry.SkillEntry, error) {
+	all := reg.ListSkills()
+	if len(args) == 0 {
+		return all, nil
+	}
+
+	var skills []registry.SkillEntry
+	seen := make(map[string]bool)
+	add := func(skill registry.SkillEntry) {
+		key := skill.Source.Repo + "\x00" + skill.Source.Path
+		if !seen[key] {
+			seen[key] = true
+			skills = append(skills, skill)
+		}
+	}
+	for _, arg := range args {
+		if repo := cfg.GetRepo(arg); repo != nil {
+			for _, skill := range all {
+				if skill.Source.Repo == repo.URL {
+					add(skill)
+				}
+			}
+			continue
+		}
+		skill := reg.GetSkill(arg)
+		if skill == nil {
+			return nil, fmt.Errorf("%s is neither a configured repository nor a skill in the registry", arg)
+		}
+		add(*skill)
+	}
+	return skills, nil
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"os"
+
+	"lazyas/internal/progress"
+)
+
+// startProgress tracks a long-running command: progress is drawn as a live
+// line on stderr and Ctrl+C cancels the operation after the current step.
+// The returned line is cleared before the command prints; call done when
+// the operation has finished.
+func startProgress(op string) (t *progress.Tracker, line *progress.Line, done func()) {
+	line = progress.NewLine(os.Stderr)
+	t = progress.New(op, line.Draw)
+	stop := progress.CancelOnInterrupt(t)
+	return t, line, func() {
+		stop()
+		line.Clear()
+	}
+}
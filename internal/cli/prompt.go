@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"lazyas/internal/i18n"
+)
+
+// assumeYes answers every prompt as if yes was typed (--yes)
+var assumeYes bool
+
+// answer prints prompt and reads a one-word answer. With --yes the answer
+// is yes without reading. When stdin is not a terminal, as in CI or a
+// provisioning script, nobody is there to type one: ok is false and
+// nothing is read, so lazyas never waits on input that will not come.
+func answer(prompt, yes string) (response string, ok bool) {
+	fmt.Print(prompt)
+	if assumeYes {
+		fmt.Println(yes)
+		return yes, true
+	}
+	if !stdinIsTerminal() {
+		fmt.Println()
+		fmt.Println(i18n.T("Not a terminal, declining; pass --yes to accept."))
+		return "", false
+	}
+	fmt.Scanln(&response)
+	return response, true
+}
+
+// confirm asks a yes/no question whose prompt ends in "[y/N]: "
+func confirm(prompt string) bool {
+	response, ok := answer(prompt, "y")
+	return ok && (response == "y" || response == "Y")
+}
+
+// stdinLines reads the lines typed at ask prompts
+var stdinLines = bufio.NewReader(os.Stdin)
+
+// ask prints prompt and reads a line of text; an empty line keeps def.
+// With --yes, or when stdin is not a terminal, def is used without reading.
+func ask(prompt, def string) string {
+	fmt.Print(prompt)
+	if assumeYes || !stdinIsTerminal() {
+		fmt.Println(def)
+		return def
+	}
+	line, _ := stdinLines.ReadString('\n')
+	if line = strings.TrimSpace(line); line != "" {
+		return line
+	}
+	return def
+}
+
+func stdinIsTerminal() bool {
+	return term.IsTerminal(os.Stdin.Fd())
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/registry"
+	"lazyas/internal/remote"
+)
+
+var (
+	publishRepo    string
+	publishPath    string
+	publishBranch  string
+	publishMessage string
+	publishPush    bool
+	publishOpen    bool
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish <skill> --repo <name>",
+	Short: "Add a local skill to a configured repository on a new branch",
+	Long: `Copy a skill from ~/.lazyas/skills into a working clone of a configured
+repository, list it in the repository's index.yaml and commit on a new
+branch. With --push the branch is pushed, ready for a pull request.
+
+The skill goes to the path index.yaml already lists for it, otherwise
+to skills/<skill> (or <skill> when the repository has no skills/
+directory). Its index entry takes the description, tags and author
+from the SKILL.md frontmatter; the rest of index.yaml is left as is.
+Repositories without an index.yaml are scanned, so nothing is listed.
+
+The working clone is kept in ~/.lazyas/publish/, so an unpushed commit
+can be reviewed and pushed later.
+
+Examples:
+  lazyas publish pdf-forms --repo team
+  lazyas publish pdf-forms --repo team --push --open
+  lazyas publish pdf-forms --repo team --path tools/pdf-forms -m "Add PDF form filling"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPublish,
+}
+
+func init() {
+	publishCmd.Flags().StringVar(&publishRepo, "repo", "", "Name of the configured repository to publish to")
+	publishCmd.Flags().StringVar(&publishPath, "path", "", "Directory for the skill inside the repository")
+	publishCmd.Flags().StringVar(&publishBranch, "branch", "", "Branch name (default lazyas/publish-<skill>-<date>)")
+	publishCmd.Flags().StringVarP(&publishMessage, "message", "m", "", "Commit message (default \"Publish <skill>\")")
+	publishCmd.Flags().BoolVar(&publishPush, "push", false, "Push the branch to the repository")
+	publishCmd.Flags().BoolVar(&publishOpen, "open", false, "Open the pull request page in a browser (with --push)")
+	publishCmd.MarkFlagRequired("repo")
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name := args[0]
+	repo := cfg.GetRepo(publishRepo)
+	if repo == nil {
+		return fmt.Errorf("repository %s not found (see 'lazyas config repo list')", publishRepo)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	skillPath := mfst.GetSkillPath(name)
+	if _, err := os.Stat(filepath.Join(skillPath, "SKILL.md")); err != nil {
+		return fmt.Errorf("skill %s not found in %s", name, cfg.SkillsDir)
+	}
+	if info, ok := mfst.GetInstalled(name); ok && info.NeedsResolution() {
+		return fmt.Errorf("skill %s has unresolved conflicts (see 'lazyas resolve %s')", name, name)
+	}
+
+	branch := publishBranch
+	if branch == "" {
+		branch = fmt.Sprintf("lazyas/publish-%s-%s", name, time.Now().Format("20060102-1504"))
+	}
+	message := publishMessage
+	if message == "" {
+		message = fmt.Sprintf("Publish %s", name)
+	}
+
+	repoDir := filepath.Join(cfg.PublishDir, git.RepoDirName(repo.URL))
+	fmt.Println(i18n.Tf("Preparing %s in %s...", branch, repoDir))
+	if err := git.PublishBranch(repo.URL, repoDir, branch); err != nil {
+		return err
+	}
+
+	dest := publishPath
+	if dest == "" {
+		dest = registry.IndexEntryPath(repoDir, name)
+	}
+	if dest == "" {
+		dest = name
+		if info, err := os.Stat(filepath.Join(repoDir, "skills")); err == nil && info.IsDir() {
+			dest = path.Join("skills", name)
+		}
+	}
+	dest = strings.Trim(filepath.ToSlash(filepath.Clean(dest)), "/")
+	if dest == "" || dest == "." || strings.HasPrefix(dest, "../") || dest == ".." {
+		return fmt.Errorf("invalid path %q inside the repository", publishPath)
+	}
+
+	if err := git.PublishSkill(skillPath, repoDir, filepath.FromSlash(dest)); err != nil {
+		return err
+	}
+	fmt.Println(i18n.Tf("Copied %s to %s", name, dest))
+
+	entry := registry.PublishEntry(name, filepath.Join(repoDir, filepath.FromSlash(dest)), repoDir, repo.URL)
+	listed, err := registry.UpdateIndexFile(repoDir, entry, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to update index.yaml: %w", err)
+	}
+	if listed {
+		fmt.Println(i18n.Tf("Listed %s in index.yaml", name))
+	} else {
+		fmt.Println(i18n.T("No index.yaml: the repository is scanned for skills"))
+	}
+
+	commit, err := git.CommitAll(repoDir, message)
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.Tf("Committed %s on %s", truncateString(commit, 7), branch))
+
+	if !publishPush {
+		fmt.Println(i18n.T("Review and push it with:"))
+		fmt.Printf("  git -C %s push -u origin %s\n", repoDir, branch)
+		return nil
+	}
+
+	fmt.Println(i18n.Tf("Pushing %s...", branch))
+	if err := git.PushBranch(repoDir, branch); err != nil {
+		return err
+	}
+	source, isHosted := remote.ParseRepo(repo.URL)
+	if !isHosted {
+		fmt.Println(i18n.Tf("Pushed branch %s. Open a pull request against %s.", branch, repo.URL))
+		return nil
+	}
+	prURL := source.PullRequestURL(git.DefaultBranch(repoDir), "", branch)
+	fmt.Println(i18n.T("Open a pull request:"))
+	fmt.Printf("  %s\n", prURL)
+	if publishOpen {
+		if err := remote.OpenBrowser(prURL); err != nil {
+			fmt.Println(i18n.Tf("Failed to open browser: %v", err))
+		}
+	}
+	return nil
+}
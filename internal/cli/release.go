@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/manifest"
+	"lazyas/internal/release"
+	"lazyas/internal/scan"
+	"lazyas/internal/skillpolicy"
+	"lazyas/internal/trash"
+	"lazyas/internal/usage"
+)
+
+// runReleaseInstall installs a skill packaged as a GitHub release asset.
+// The unpacked files are copied into the skills directory; there is no
+// clone, so updates mean installing a newer release.
+func runReleaseInstall(cfg *config.Config, arg string) error {
+	src, err := release.Parse(arg)
+	if err != nil {
+		return err
+	}
+	localName := src.SkillName()
+	if installAs != "" {
+		localName = installAs
+	}
+	if err := validateSkillName(localName); err != nil {
+		return fmt.Errorf("%w (choose one with --as)", err)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	if other, clash := mfst.CaseClash(localName); clash {
+		return fmt.Errorf("%s differs from the installed %s only by case; choose another name with --as", localName, other)
+	}
+	if owner, ok := mfst.AliasOwner(localName); ok {
+		return fmt.Errorf("%s is an alias of %s (remove it first with 'lazyas remove %s')", localName, owner, localName)
+	}
+
+	if installDryRun {
+		fmt.Println(i18n.Tf("Would install %s", localName))
+		fmt.Println(i18n.Tf("  Release: %s %s", src.RepoURL(), src.Tag))
+		fmt.Println(i18n.Tf("  Asset: %s (verified against the release's checksums file)", src.Asset))
+		if mfst.IsInstalled(localName) {
+			fmt.Println(i18n.T("  Already installed (would be replaced)"))
+		}
+		return nil
+	}
+
+	policy, err := scan.ParsePolicy(cfg.RiskPolicy)
+	if err != nil {
+		return err
+	}
+	rules, err := skillpolicy.Load(cfg.SkillPolicyPath())
+	if err != nil {
+		return err
+	}
+	if err := rules.Check(skillpolicy.Subject{Name: localName, Repo: src.RepoURL()}); err != nil {
+		return err
+	}
+
+	installed := mfst.IsInstalled(localName)
+	if installed && !installForce {
+		return fmt.Errorf("skill %s is already installed (use --force to replace it)", localName)
+	}
+
+	fmt.Println(i18n.Tf("Installing %s@%s from %s...", localName, src.Tag, src.Asset))
+	data, digest, checksums, err := release.Download(src)
+	if err != nil {
+		return fmt.Errorf("failed to download release asset: %w", err)
+	}
+	fmt.Println(i18n.Tf("  Verified sha256 %s (%s)", truncateString(digest, 12), checksums))
+
+	if err := os.MkdirAll(cfg.SkillsDir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(cfg.SkillsDir, ".release-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	if err := release.Extract(data, tmp); err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", src.Asset, err)
+	}
+	skillDir, err := release.SkillDir(tmp)
+	if err != nil {
+		return err
+	}
+	if err := git.ValidateSkill(skillDir); err != nil {
+		return fmt.Errorf("invalid skill in %s: %w", src.Asset, err)
+	}
+
+	risk, err := scan.Check(skillDir, policy)
+	if risk != nil {
+		printRiskReport(risk)
+	}
+	if err == nil {
+		err = rules.CheckRisk(localName, risk)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to install skill: %w", err)
+	}
+
+	// Replace an existing copy only once the new one is known to be good
+	if installed {
+		if err := trashSkill(cfg, mfst, localName, trash.ReasonOverwrite); err != nil {
+			return fmt.Errorf("failed to move %s to the trash: %w", localName, err)
+		}
+	}
+	skillPath := mfst.GetSkillPath(localName)
+	if err := os.Rename(skillDir, skillPath); err != nil {
+		return fmt.Errorf("failed to install skill: %w", err)
+	}
+
+	// The asset digest stands in for a commit
+	if err := mfst.AddSkill(localName, src.Tag, "sha256:"+digest, src.String(), ""); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if err := mfst.SetUpstream(localName, localName); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if err := mfst.SetPin(localName, ""); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if hash, err := integrity.HashDir(skillPath); err == nil {
+		mfst.SetHashes(map[string]string{localName: hash})
+	}
+
+	fmt.Println(i18n.Tf("Successfully installed %s", localName))
+	printEnableHint(cfg, localName)
+	usageCounts.Add(usage.Installs, 1)
+	return nil
+}
@@ -3,34 +3,64 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
+	"lazyas/internal/i18n"
 	"lazyas/internal/manifest"
+	"lazyas/internal/trash"
+	"lazyas/internal/usage"
 )
 
 var (
-	removeForce bool
+	removeForce        bool
+	removeCascade      bool
+	removePurgeOrphans bool
 )
 
 var removeCmd = &cobra.Command{
-	Use:     "remove <name>",
+	Use:     "remove <name> | --purge-orphans",
 	Aliases: []string{"rm", "uninstall"},
 	Short:   "Remove an installed skill",
 	Long: `Remove an installed skill from the local system.
 
+Removed skills are moved to the trash, where 'lazyas trash restore'
+can bring them back until trash_retention_days have passed.
+
+Removing a skill also removes the compatibility aliases left by
+'lazyas rename'. Removing an alias removes only the alias.
+
+Skills that list the skill under "dependencies" in their SKILL.md
+frontmatter block the removal. Use --cascade to remove them as well.
+
 Examples:
   lazyas remove my-skill
-  lazyas rm my-skill`,
-	Args: cobra.ExactArgs(1),
+  lazyas rm my-skill
+  lazyas remove --cascade my-skill   # Also remove skills that depend on it
+  lazyas remove --purge-orphans      # Same as 'lazyas clean'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runRemove,
 }
 
 func init() {
 	removeCmd.Flags().BoolVarP(&removeForce, "force", "f", false, "Force removal without confirmation")
+	removeCmd.Flags().BoolVar(&removeCascade, "cascade", false, "Also remove installed skills that depend on this one")
+	removeCmd.Flags().BoolVar(&removePurgeOrphans, "purge-orphans", false, "Remove leftovers of deleted skills and unused clones instead (see 'lazyas clean')")
 }
 
 func runRemove(cmd *cobra.Command, args []string) error {
+	if removePurgeOrphans {
+		if len(args) > 0 {
+			return fmt.Errorf("--purge-orphans takes no skill name")
+		}
+		assumeYes = assumeYes || removeForce
+		return runClean(cmd, nil)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("requires a skill name")
+	}
+
 	cfg, err := config.DefaultConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -44,35 +74,62 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load manifest: %w", err)
 	}
 
+	if owner, ok := mfst.AliasOwner(name); ok {
+		if err := os.Remove(mfst.GetSkillPath(name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove alias: %w", err)
+		}
+		if err := mfst.DropAlias(name); err != nil {
+			return fmt.Errorf("failed to update manifest: %w", err)
+		}
+		fmt.Println(i18n.Tf("Removed alias %s (%s is still installed)", name, owner))
+		return nil
+	}
+
 	// Check if installed
 	if !mfst.IsInstalled(name) {
 		return fmt.Errorf("skill %s is not installed", name)
 	}
 
+	dependents := mfst.Dependents(name)
+	if len(dependents) > 0 && !removeCascade {
+		return fmt.Errorf("skill %s is required by %s (use --cascade to remove them too)", name, strings.Join(dependents, ", "))
+	}
+
 	// Confirm unless forced
 	if !removeForce {
-		fmt.Printf("Remove skill %s? [y/N]: ", name)
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" {
-			fmt.Println("Cancelled")
+		if len(dependents) > 0 {
+			fmt.Println(i18n.Tf("Dependent skills that will also be removed: %s", strings.Join(dependents, ", ")))
+		}
+		if !confirm(i18n.Tf("Remove skill %s? [y/N]: ", name)) {
+			fmt.Println(i18n.T("Cancelled"))
 			return nil
 		}
 	}
 
-	fmt.Printf("Removing %s...\n", name)
+	// Dependents first, so a failure never leaves a skill without what it needs
+	for _, skill := range append(dependents, name) {
+		if err := removeInstalled(cfg, mfst, skill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeInstalled moves a skill to the trash and drops its manifest entry
+func removeInstalled(cfg *config.Config, mfst *manifest.Manager, name string) error {
+	fmt.Println(i18n.Tf("Removing %s...", name))
 
-	// Remove directory
-	skillDir := mfst.GetSkillPath(name)
-	if err := os.RemoveAll(skillDir); err != nil {
-		return fmt.Errorf("failed to remove skill directory: %w", err)
+	if err := trashSkill(cfg, mfst, name, trash.ReasonRemove); err != nil {
+		return fmt.Errorf("failed to move skill to the trash: %w", err)
 	}
 
 	// Update manifest
 	if err := mfst.RemoveSkill(name); err != nil {
 		return fmt.Errorf("failed to update manifest: %w", err)
 	}
+	pruneBackendLinks(cfg)
 
-	fmt.Printf("Successfully removed %s\n", name)
+	fmt.Println(i18n.Tf("Successfully removed %s (restore with 'lazyas trash restore %s')", name, name))
+	usageCounts.Add(usage.Removals, 1)
 	return nil
 }
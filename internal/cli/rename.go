@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/patch"
+)
+
+var renameNoLink bool
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename an installed skill",
+	Long: `Rename an installed skill.
+
+The skill keeps following its registry entry under the new name. A
+compatibility symlink is left at the old name so agents and scripts that
+still refer to it keep working; use --no-link to skip it. Saved patches
+move with the skill. Remove the alias later with 'lazyas remove <old>'.
+
+Examples:
+  lazyas rename pdf pdf-tools-v2
+  lazyas rename --no-link pdf pdf-tools-v2`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRename,
+}
+
+func init() {
+	renameCmd.Flags().BoolVar(&renameNoLink, "no-link", false, "Don't leave a compatibility symlink at the old name")
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	oldName, newName := args[0], args[1]
+	if err := validateSkillName(newName); err != nil {
+		return err
+	}
+	if oldName == newName {
+		return fmt.Errorf("skill is already named %s", newName)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	if _, ok := mfst.GetInstalled(oldName); !ok {
+		if owner, isAlias := mfst.AliasOwner(oldName); isAlias {
+			return fmt.Errorf("%s is an alias of %s; rename %s instead", oldName, owner, owner)
+		}
+		return fmt.Errorf("skill %s is not installed", oldName)
+	}
+	oldPath, newPath := mfst.GetSkillPath(oldName), mfst.GetSkillPath(newName)
+	if _, err := os.Lstat(newPath); err == nil {
+		if owner, isAlias := mfst.AliasOwner(newName); !isAlias || owner != oldName {
+			return fmt.Errorf("%s already exists in %s", newName, cfg.SkillsDir)
+		}
+		// Renaming back to a former name replaces its compatibility alias
+		if err := os.Remove(newPath); err != nil {
+			return fmt.Errorf("failed to remove alias %s: %w", newName, err)
+		}
+	}
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("failed to rename skill: %w", err)
+	}
+	// Point aliases from earlier renames straight at the new name
+	info, _ := mfst.GetInstalled(oldName)
+	for _, alias := range info.Aliases {
+		if alias == newName {
+			continue
+		}
+		aliasPath := mfst.GetSkillPath(alias)
+		os.Remove(aliasPath)
+		if err := os.Symlink(newName, aliasPath); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to update alias %s: %v", alias, err))
+		}
+	}
+	keepAlias := !renameNoLink
+	if keepAlias {
+		if err := os.Symlink(newName, oldPath); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to create alias %s: %v", oldName, err))
+			keepAlias = false
+		}
+	}
+
+	if err := mfst.Rename(oldName, newName, keepAlias); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	relinkBackends(cfg, oldName, newName, keepAlias)
+	if err := patch.NewStore(cfg.PatchesDir).Rename(oldName, newName); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to move patches: %v", err))
+	}
+
+	fmt.Println(i18n.Tf("Renamed %s to %s", oldName, newName))
+	if keepAlias {
+		fmt.Println(i18n.Tf("  %s remains as an alias", oldName))
+	}
+	return nil
+}
+
+// validateSkillName checks that a local skill name is safe to use as a
+// directory name
+func validateSkillName(name string) error {
+	if !manifest.ValidName(name) {
+		return fmt.Errorf("invalid skill name %q (use letters, digits, '.', '_' or '-')", name)
+	}
+	return nil
+}
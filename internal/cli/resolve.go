@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+)
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve <name>",
+	Short: "Mark a skill's merge conflicts as resolved",
+	Long: `Clear the "needs resolution" state left by 'lazyas update --merge'
+or a conflicted 'lazyas update --stash'.
+
+The command refuses while any conflicted file still contains conflict
+markers (<<<<<<< / >>>>>>>). Your edits stay as local modifications.
+
+Examples:
+  lazyas resolve my-skill`,
+	Args: cobra.ExactArgs(1),
+	RunE: runResolve,
+}
+
+func runResolve(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name := args[0]
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	info, ok := mfst.GetInstalled(name)
+	if !ok {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+	if !info.NeedsResolution() {
+		fmt.Println(i18n.Tf("%s has no conflicts to resolve", name))
+		return nil
+	}
+
+	skillDir := mfst.GetSkillPath(name)
+	var remaining []string
+	for _, f := range info.Conflicts {
+		if git.HasConflictMarkers(filepath.Join(skillDir, f)) {
+			remaining = append(remaining, f)
+		}
+	}
+	if len(remaining) > 0 {
+		fmt.Println(i18n.T("Conflict markers remain in:"))
+		for _, f := range remaining {
+			fmt.Printf("  %s\n", f)
+		}
+		return fmt.Errorf("skill %s still has %d conflicted file(s)", name, len(remaining))
+	}
+
+	if err := git.MarkResolved(skillDir); err != nil {
+		return err
+	}
+	if err := mfst.SetConflicts(name, nil); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("Marked %s as resolved", name))
+	return nil
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/manifest"
+)
+
+var (
+	rollbackList  bool
+	rollbackForce bool
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <name>",
+	Short: "Move a skill back to the commit it had before its last update",
+	Long: `Move an installed skill back to the commit it had before its last
+update, e.g. after an update that broke it.
+
+The manifest keeps the last few commits each skill was installed at.
+Rolling back again goes one more step back. The next 'lazyas update'
+moves the skill forward again; pin it to stay on the older commit.
+
+Examples:
+  lazyas rollback my-skill
+  lazyas rollback my-skill --list   # Show the commits kept
+  lazyas pin my-skill               # Then stay there`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRollback,
+}
+
+func init() {
+	rollbackCmd.Flags().BoolVar(&rollbackList, "list", false, "List the earlier commits kept for the skill")
+	rollbackCmd.Flags().BoolVar(&rollbackForce, "force", false, "Discard local modifications")
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := args[0]
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	info, ok := mfst.GetInstalled(name)
+	if !ok || !mfst.IsInstalled(name) {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	if rollbackList {
+		printPastInstall("●", manifest.PastInstall{Version: info.Version, Commit: info.Commit, InstalledAt: info.InstalledAt})
+		for _, h := range info.History {
+			printPastInstall("○", h)
+		}
+		if len(info.History) == 0 {
+			fmt.Println(i18n.Tf("%s has no earlier commits to roll back to", name))
+		}
+		return nil
+	}
+
+	if len(info.History) == 0 {
+		return fmt.Errorf("skill %s has no earlier commit to roll back to", name)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	skillDir := mfst.GetSkillPath(name)
+	if modified, _ := git.IsModified(skillDir); modified {
+		if !rollbackForce {
+			return fmt.Errorf("skill %s has local modifications (use --force to discard them)", name)
+		}
+		fmt.Println(i18n.T("  Discarding local changes..."))
+		if err := git.ResetChanges(skillDir); err != nil {
+			return fmt.Errorf("failed to reset changes: %w", err)
+		}
+	}
+
+	prev := info.History[0]
+	fmt.Println(i18n.Tf("Rolling back %s: %s → %s...", name, truncateString(info.Commit, 7), truncateString(prev.Commit, 7)))
+	if _, err := git.Update(skillDir, prev.Commit); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", truncateString(prev.Commit, 7), err)
+	}
+	if _, err := mfst.RollBack(name); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if hash, err := integrity.HashDir(skillDir); err == nil {
+		mfst.SetHashes(map[string]string{name: hash})
+	}
+
+	fmt.Println(i18n.Tf("  Now at %s (%s)", refLabel(prev.Version), truncateString(prev.Commit, 7)))
+	fmt.Println(i18n.Tf("Run 'lazyas pin %s' to keep it there", name))
+	return nil
+}
+
+// printPastInstall prints one commit of a skill's history. Commits moved
+// over from older manifests have no install date.
+func printPastInstall(marker string, h manifest.PastInstall) {
+	line := fmt.Sprintf("  %s %s (%s)", marker, refLabel(h.Version), truncateString(h.Commit, 7))
+	if !h.InstalledAt.IsZero() {
+		line += i18n.Tf("  installed %s", h.InstalledAt.Format("2006-01-02"))
+	}
+	fmt.Println(line)
+}
@@ -1,12 +1,19 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
 	"lazyas/internal/symlink"
 	"lazyas/internal/tui"
+	"lazyas/internal/usage"
 )
 
 var rootCmd = &cobra.Command{
@@ -29,6 +36,10 @@ central skills directory at ~/.lazyas/skills/.`,
 		if cmd.Name() == "backend" {
 			return
 		}
+		// Keep machine-readable output clean
+		if f := cmd.Flags().Lookup("json"); f != nil && f.Changed {
+			return
+		}
 
 		checkBackendLinks()
 	},
@@ -38,7 +49,7 @@ central skills directory at ~/.lazyas/skills/.`,
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
-		return tui.Run(cfg)
+		return tui.Run(cfg, "")
 	},
 }
 
@@ -57,29 +68,145 @@ func checkBackendLinks() {
 		}
 	}
 	if availableUnlinked > 0 {
-		fmt.Printf("Hint: %d backend(s) not linked. Run 'lazyas backend link' to connect them.\n\n", availableUnlinked)
+		fmt.Println(i18n.Tf("Hint: %d backend(s) not linked. Run 'lazyas backend link' to connect them.\n", availableUnlinked))
+	}
+}
+
+// requireGit fails commands that cannot work without git, with a hint on
+// how to install it instead of a raw exec error
+func requireGit() error {
+	if err := git.Available(); err != nil {
+		return fmt.Errorf("%w\n%s", err, git.InstallHint())
+	}
+	return nil
+}
+
+// initSettings applies what every command needs from the config: the UI
+// language from config or the environment, and the git bandwidth limit.
+// A broken community catalog only produces a warning; messages fall back to English.
+func initSettings() {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return
+	}
+	if err := i18n.SetLocale(i18n.DetectLocale(cfg.Locale), cfg.LocalesDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	initGitLimits(cfg)
+}
+
+// initGitLimits applies git_bandwidth_limit_kb to the git commands this
+// process runs
+func initGitLimits(cfg *config.Config) {
+	if cfg.GitBandwidth() == 0 {
+		return
+	}
+	if err := git.LimitBandwidth(cfg.GitBandwidth()); err != nil && !errors.Is(err, git.ErrProxyConfigured) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// ExitCodeError ends the process with Code. The command has already
+// reported the outcome, so nothing more is printed.
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// exitCode returns an ExitCodeError for cmd, silencing cobra's own error
+// and usage output
+func exitCode(cmd *cobra.Command, code int) error {
+	if code == 0 {
+		return nil
 	}
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &ExitCodeError{Code: code}
 }
 
 // SetVersion sets the version string for the CLI
 func SetVersion(v string) {
 	rootCmd.Version = v
+	manifest.InstallerVersion = v
 }
 
+// usageCounts collects what this run did for the local usage stats
+var usageCounts usage.Counts
+
+// recordUsage is cleared by 'lazyas stats --usage --reset' so the reset
+// is not undone on exit
+var recordUsage = true
+
 // Execute runs the CLI
 func Execute() error {
-	return rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
+	flushUsage(cmd)
+	return err
+}
+
+// flushUsage adds the command that ran and what it counted to the usage
+// stats. The TUI records its own sessions and actions.
+func flushUsage(cmd *cobra.Command) {
+	if !recordUsage {
+		return
+	}
+	if cmd != nil && cmd != rootCmd {
+		usageCounts.AddAction("cli " + strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "))
+	}
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return
+	}
+	usage.New(cfg.UsagePath, !cfg.DisableUsageStats).Flush(&usageCounts)
 }
 
 func init() {
+	cobra.OnInitialize(initSettings)
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Answer yes to every prompt; without it prompts are declined when stdin is not a terminal")
+
 	rootCmd.AddCommand(browseCmd)
 	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(removeCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(explainCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(backendCmd)
+	rootCmd.AddCommand(enableCmd)
+	rootCmd.AddCommand(disableCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(prefetchCmd)
+	rootCmd.AddCommand(trackCmd)
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(unpinCmd)
+	rootCmd.AddCommand(policyCmd)
+	rootCmd.AddCommand(versionsCmd)
+	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(resolveCmd)
+	rootCmd.AddCommand(patchCmd)
+	rootCmd.AddCommand(forkCmd)
+	rootCmd.AddCommand(contributeCmd)
+	rootCmd.AddCommand(publishCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(outdatedCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(syncLockCmd)
+	rootCmd.AddCommand(importManifestCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(debugReportCmd)
+	rootCmd.AddCommand(badgeCmd)
+	rootCmd.AddCommand(catalogCmd)
+	rootCmd.AddCommand(signIndexCmd)
+	rootCmd.AddCommand(digestCmd)
+	rootCmd.AddCommand(trashCmd)
+	rootCmd.AddCommand(cleanCmd)
 }
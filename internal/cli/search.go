@@ -2,32 +2,57 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"slices"
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
+	"lazyas/internal/fulltext"
+	"lazyas/internal/i18n"
 	"lazyas/internal/manifest"
 	"lazyas/internal/registry"
 )
 
+var (
+	searchContent bool
+	searchRepo    string
+)
+
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search for skills by name, description, or tags",
 	Long: `Search for skills in the registry by name, description, or tags.
 
+With --content, search the SKILL.md text of installed skills and of
+registry skills whose SKILL.md is on disk (previewed in the TUI or in a
+repo clone). Every word of the query must match, fuzzily; the best
+matches come first, each with the line that matched.
+
 Examples:
   lazyas search ros
   lazyas search robotics
-  lazyas search cli`,
+  lazyas search cli
+  lazyas search pdf --repo community
+  lazyas search --content "fill pdf forms"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSearch,
 }
 
+func init() {
+	searchCmd.Flags().BoolVar(&searchContent, "content", false, "Search SKILL.md text instead of names, descriptions and tags")
+	searchCmd.Flags().StringVar(&searchRepo, "repo", "", "Search only skills of this configured repository")
+}
+
 func runSearch(cmd *cobra.Command, args []string) error {
 	cfg, err := config.DefaultConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if err := checkRepoFilter(cfg, searchRepo); err != nil {
+		return err
+	}
+
 	query := args[0]
 
 	// Load manifest
@@ -37,21 +62,29 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fetch registry
-	fmt.Println("Searching...")
+	fmt.Println(i18n.T("Searching..."))
 
 	reg := registry.NewRegistry(cfg)
 	if err := reg.Fetch(false); err != nil {
-		return fmt.Errorf("failed to fetch index: %w", err)
+		if !searchContent {
+			return fmt.Errorf("failed to fetch index: %w", err)
+		}
+		// Installed skills can be searched without an index
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: failed to fetch index: %v", err))
+	}
+
+	if searchContent {
+		return printContentSearch(cfg, mfst, reg, query)
 	}
 
 	// Search
-	results := reg.SearchSkills(query)
+	results := repoSkills(reg.SearchSkills(query), searchRepo)
 	if len(results) == 0 {
-		fmt.Printf("No skills matching '%s'\n", query)
+		fmt.Println(i18n.Tf("No skills matching '%s'", query))
 		return nil
 	}
 
-	fmt.Printf("Found %d skill(s) matching '%s':\n\n", len(results), query)
+	fmt.Println(i18n.Tf("Found %d skill(s) matching '%s':\n", len(results), query))
 
 	for _, skill := range results {
 		var status string
@@ -71,10 +104,43 @@ func runSearch(cmd *cobra.Command, args []string) error {
 			fmt.Printf("    %s\n", skill.Description)
 		}
 		if len(skill.Tags) > 0 {
-			fmt.Printf("    tags: %v\n", skill.Tags)
+			fmt.Println(i18n.Tf("    tags: %v", skill.Tags))
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// printContentSearch lists skills whose SKILL.md matches query, best first
+func printContentSearch(cfg *config.Config, mfst *manifest.Manager, reg *registry.Registry, query string) error {
+	docs, missing := fulltext.Collect(cfg, repoSkills(reg.ListSkills(), searchRepo))
+	if searchRepo != "" {
+		installed := installedFrom(cfg, mfst, reg, searchRepo)
+		docs = slices.DeleteFunc(docs, func(d fulltext.Doc) bool {
+			_, ok := installed[d.Name]
+			return d.Installed && !ok
+		})
+	}
+	matches := fulltext.Search(query, docs)
+	if len(matches) == 0 {
+		fmt.Println(i18n.Tf("No SKILL.md matching '%s'", query))
+	} else {
+		fmt.Println(i18n.Tf("Found %d skill(s) whose SKILL.md matches '%s':\n", len(matches), query))
+	}
+
+	for _, m := range matches {
+		status := "○ "
+		if m.Installed {
+			status = "● "
 		}
+		fmt.Println(status + m.Name)
+		fmt.Printf("    %d: %s\n", m.Line, truncateString(m.Text, 100))
 		fmt.Println()
 	}
 
+	if missing > 0 {
+		fmt.Println(i18n.Tf("%d registry skill(s) were not searched: their SKILL.md is not on disk yet (preview them in the TUI to include them)", missing))
+	}
 	return nil
 }
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+)
+
+var (
+	signIndexKey    string
+	signIndexKeygen bool
+)
+
+var signIndexCmd = &cobra.Command{
+	Use:   "sign-index [index.yaml]",
+	Short: "Sign the index.yaml of a skills repository",
+	Long: `Sign the index.yaml of a skills repository you publish, so users can
+verify it before trusting the skill sources it lists.
+
+The signature is written to index.yaml.sig next to it; commit both. Users
+verify it by setting the public key on the repository:
+
+  [[repos]]
+  name = "mycompany"
+  url = "https://github.com/mycompany/skills"
+  public_key = "<key printed by --keygen>"
+
+Sign again after every change to index.yaml: with a public key set, an
+index whose signature does not match is refused.
+
+Examples:
+  lazyas sign-index --keygen --key ~/.lazyas-index.key   # Once
+  lazyas sign-index index.yaml --key ~/.lazyas-index.key`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSignIndex,
+}
+
+func init() {
+	signIndexCmd.Flags().StringVar(&signIndexKey, "key", "", "Private key file")
+	signIndexCmd.Flags().BoolVar(&signIndexKeygen, "keygen", false, "Create a new key pair in the --key file and print its public key")
+	signIndexCmd.MarkFlagRequired("key")
+}
+
+func runSignIndex(cmd *cobra.Command, args []string) error {
+	if signIndexKeygen {
+		return keygenIndex(signIndexKey)
+	}
+
+	data, err := os.ReadFile(signIndexKey)
+	if err != nil {
+		return fmt.Errorf("failed to read key: %w", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(raw) != ed25519.PrivateKeySize {
+		return fmt.Errorf("%s is not a key created by 'lazyas sign-index --keygen'", signIndexKey)
+	}
+	key := ed25519.PrivateKey(raw)
+
+	indexPath := "index.yaml"
+	if len(args) > 0 {
+		indexPath = args[0]
+	}
+	index, err := os.ReadFile(indexPath)
+	if err != nil {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
+
+	sigPath := filepath.Join(filepath.Dir(indexPath), registry.SignatureFile)
+	if err := os.WriteFile(sigPath, registry.SignIndex(key, index), 0644); err != nil {
+		return fmt.Errorf("failed to write signature: %w", err)
+	}
+	fmt.Println(i18n.Tf("Wrote %s", sigPath))
+	fmt.Println(i18n.Tf("Public key: %s", base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))))
+	return nil
+}
+
+// keygenIndex creates a key pair for signing indexes, refusing to
+// overwrite an existing key
+func keygenIndex(path string) error {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create key: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, base64.StdEncoding.EncodeToString(priv)); err != nil {
+		return fmt.Errorf("failed to write key: %w", err)
+	}
+
+	fmt.Println(i18n.Tf("Wrote private key to %s; keep it secret", path))
+	fmt.Println(i18n.Tf("Public key: %s", base64.StdEncoding.EncodeToString(pub)))
+	return nil
+}
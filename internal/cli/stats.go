@@ -0,0 +1,292 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/registry"
+	"lazyas/internal/usage"
+)
+
+var (
+	statsJSON    bool
+	statsOffline bool
+	statsUsage   bool
+	statsReset   bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize installed skills",
+	Long: `Summarize the installed skills by repository, tag and status.
+
+Status counts cover installed, locally modified, outdated and local-only
+skills (directories with a SKILL.md that lazyas did not install). Install
+dates are grouped by month. Checking for outdated skills contacts each
+repository; use --offline to skip it.
+
+With --usage, show how you use lazyas instead: installs, updates,
+removals, TUI sessions and the most used commands and TUI actions.
+These counters are kept in ~/.lazyas/usage.yaml and never sent
+anywhere. Set disable_usage_stats = true in config.toml to stop
+counting, and use --reset to delete them.
+
+Examples:
+  lazyas stats
+  lazyas stats --json --offline
+  lazyas stats --usage
+  lazyas stats --usage --reset`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Print a machine-readable report")
+	statsCmd.Flags().BoolVar(&statsOffline, "offline", false, "Skip the outdated check")
+	statsCmd.Flags().BoolVar(&statsUsage, "usage", false, "Show local usage counters instead")
+	statsCmd.Flags().BoolVar(&statsReset, "reset", false, "Delete the usage counters (with --usage)")
+}
+
+// statsReport is the --json output of lazyas stats
+type statsReport struct {
+	SchemaVersion int          `json:"schema_version"`
+	Installed     int          `json:"installed"`
+	Modified      int          `json:"modified"`
+	Outdated      *int         `json:"outdated"` // null with --offline or when the check failed
+	LocalOnly     int          `json:"local_only"`
+	ByRepo        []statsCount `json:"by_repo"`
+	ByTag         []statsCount `json:"by_tag"`
+	InstallMonths []statsCount `json:"install_months"`
+	Warnings      []string     `json:"warnings,omitempty"`
+}
+
+type statsCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// usageReport is the --usage --json output of lazyas stats
+type usageReport struct {
+	SchemaVersion int            `json:"schema_version"`
+	Enabled       bool           `json:"enabled"`
+	Since         *time.Time     `json:"since"` // null before anything was counted
+	Events        map[string]int `json:"events"`
+	Actions       []usage.Count  `json:"actions"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if statsReset && !statsUsage {
+		return fmt.Errorf("--reset only applies to --usage")
+	}
+	if statsUsage {
+		return runUsageStats(cfg)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	report := collectStats(cfg, mfst)
+	if statsJSON {
+		return printJSON(report)
+	}
+	printStats(report)
+	return nil
+}
+
+// runUsageStats shows or resets the local usage counters
+func runUsageStats(cfg *config.Config) error {
+	if statsReset {
+		if err := usage.Reset(cfg.UsagePath); err != nil {
+			return fmt.Errorf("failed to reset usage stats: %w", err)
+		}
+		recordUsage = false
+		fmt.Println(i18n.T("Usage stats reset"))
+		return nil
+	}
+
+	counts, err := usage.Load(cfg.UsagePath)
+	if err != nil {
+		return err
+	}
+
+	if statsJSON {
+		report := usageReport{
+			SchemaVersion: reportSchemaVersion,
+			Enabled:       !cfg.DisableUsageStats,
+			Events:        map[string]int{},
+			Actions:       counts.TopActions(0),
+		}
+		for _, event := range []string{usage.Installs, usage.Updates, usage.Removals, usage.TUISessions} {
+			report.Events[event] = counts.Events[event]
+		}
+		if !counts.Since.IsZero() {
+			report.Since = &counts.Since
+		}
+		return printJSON(report)
+	}
+
+	if cfg.DisableUsageStats {
+		fmt.Println(i18n.T("Usage stats are off (disable_usage_stats = true); showing what was counted before."))
+	}
+	if counts.Empty() {
+		fmt.Println(i18n.T("Nothing counted yet."))
+		return nil
+	}
+	fmt.Println(i18n.Tf("Usage since %s (kept in %s, never sent anywhere):", counts.Since.Local().Format("2006-01-02"), cfg.UsagePath))
+	fmt.Println(i18n.Tf("  installs:     %d", counts.Events[usage.Installs]))
+	fmt.Println(i18n.Tf("  updates:      %d", counts.Events[usage.Updates]))
+	fmt.Println(i18n.Tf("  removals:     %d", counts.Events[usage.Removals]))
+	fmt.Println(i18n.Tf("  TUI sessions: %d", counts.Events[usage.TUISessions]))
+
+	var top []statsCount
+	for _, c := range counts.TopActions(10) {
+		top = append(top, statsCount{Name: c.Name, Count: c.Count})
+	}
+	printCounts(i18n.T("Most used actions:"), top, true)
+	return nil
+}
+
+func collectStats(cfg *config.Config, mfst *manifest.Manager) *statsReport {
+	report := &statsReport{
+		SchemaVersion: reportSchemaVersion,
+		ByRepo:        []statsCount{},
+		ByTag:         []statsCount{},
+		InstallMonths: []statsCount{},
+	}
+	installed := mfst.ListInstalled()
+	report.Installed = len(installed)
+
+	reg := registry.NewRegistry(cfg)
+	if len(installed) > 0 {
+		if err := reg.Fetch(false); err != nil {
+			report.Warnings = append(report.Warnings, i18n.Tf("tags unavailable: %v", err))
+		}
+	}
+
+	repoNames := make(map[string]string, len(cfg.Repos))
+	for _, r := range cfg.Repos {
+		repoNames[r.URL] = r.Name
+	}
+
+	repos := make(map[string]int)
+	tags := make(map[string]int)
+	months := make(map[string]int)
+	for name, info := range installed {
+		repo := info.SourceRepo
+		if n, ok := repoNames[repo]; ok {
+			repo = n
+		}
+		repos[repo]++
+
+		if skill := reg.GetSkill(info.RegistryName(name)); skill != nil {
+			for _, tag := range skill.Tags {
+				tags[strings.ToLower(tag)]++
+			}
+		}
+		if !info.InstalledAt.IsZero() {
+			months[info.InstalledAt.Format("2006-01")]++
+		}
+		if modified, _ := git.IsModified(mfst.GetSkillPath(name)); modified {
+			report.Modified++
+		}
+	}
+
+	for name := range mfst.ScanLocalSkills() {
+		if _, ok := installed[name]; !ok {
+			report.LocalOnly++
+		}
+	}
+
+	report.ByRepo = sortedCounts(repos, true)
+	report.ByTag = sortedCounts(tags, true)
+	report.InstallMonths = sortedCounts(months, false)
+
+	if !statsOffline && len(installed) > 0 {
+		if outdated, err := checkOutdated(nil); err != nil {
+			report.Warnings = append(report.Warnings, i18n.Tf("outdated check failed: %v", err))
+		} else {
+			report.Outdated = &outdated.Outdated
+			if outdated.Errors > 0 {
+				report.Warnings = append(report.Warnings, i18n.Tf("outdated check failed for %d skill(s)", outdated.Errors))
+			}
+		}
+	}
+	return report
+}
+
+// sortedCounts orders counts by count (descending) or by name
+func sortedCounts(m map[string]int, byCount bool) []statsCount {
+	counts := make([]statsCount, 0, len(m))
+	for name, n := range m {
+		counts = append(counts, statsCount{Name: name, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if byCount && counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
+
+func printStats(r *statsReport) {
+	fmt.Println(i18n.T("Status:"))
+	fmt.Println(i18n.Tf("  installed:  %d", r.Installed))
+	fmt.Println(i18n.Tf("  modified:   %d", r.Modified))
+	if r.Outdated != nil {
+		fmt.Println(i18n.Tf("  outdated:   %d", *r.Outdated))
+	} else {
+		fmt.Println(i18n.T("  outdated:   unknown"))
+	}
+	fmt.Println(i18n.Tf("  local-only: %d", r.LocalOnly))
+
+	printCounts(i18n.T("By repository:"), r.ByRepo, false)
+	printCounts(i18n.T("By tag:"), r.ByTag, false)
+	printCounts(i18n.T("Installed per month:"), r.InstallMonths, true)
+
+	for _, w := range r.Warnings {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Warning: %s", w))
+	}
+}
+
+// printCounts prints a titled list of counts, optionally as a bar chart
+func printCounts(title string, counts []statsCount, bars bool) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println(title)
+
+	width, top := 0, 0
+	for _, c := range counts {
+		width = max(width, len(c.Name))
+		top = max(top, c.Count)
+	}
+	const barWidth = 30
+	for _, c := range counts {
+		line := fmt.Sprintf("  %-*s %4d", width, c.Name, c.Count)
+		if bars {
+			n := c.Count * barWidth / top
+			if n == 0 {
+				n = 1
+			}
+			line += " " + strings.Repeat("█", n)
+		}
+		fmt.Println(line)
+	}
+}
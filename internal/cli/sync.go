@@ -2,14 +2,17 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
 	"lazyas/internal/registry"
 )
 
 var syncCmd = &cobra.Command{
-	Use:   "sync",
+	Use:   "sync [repo...]",
 	Short: "Force refresh the registry from all repositories",
 	Long: `Force refresh the registry index from all configured repositories,
 bypassing the cache TTL.
@@ -17,8 +20,13 @@ bypassing the cache TTL.
 This is useful when you want to see the latest available skills
 without waiting for the cache to expire.
 
+Each repository's skill list is cached on its own, for cache_ttl_hours
+or the repository's own cache_ttl_hours. Naming repositories refreshes
+only those; the others are fetched only if their cache expired.
+
 Examples:
-  lazyas sync`,
+  lazyas sync
+  lazyas sync official   # Refresh one repository`,
 	RunE: runSync,
 }
 
@@ -28,14 +36,45 @@ func runSync(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	fmt.Println("Syncing repositories...")
+	// The team config is only fetched here, once its refresh interval has
+	// passed; loading the config uses the cached copy so other commands never
+	// wait on it
+	if cfg.TeamConfigURL != "" {
+		cfg.RefreshTeamConfig(false)
+		if cfg.TeamConfigErr != nil {
+			fmt.Println(i18n.Tf("Warning: %v", cfg.TeamConfigErr))
+		}
+	}
+	for _, name := range args {
+		if cfg.GetRepo(name) == nil {
+			return fmt.Errorf("repository %s not found", name)
+		}
+	}
+	fmt.Println(i18n.T("Syncing repositories..."))
 
+	tracker, _, done := startProgress(i18n.T("Syncing"))
 	reg := registry.NewRegistry(cfg)
-	if err := reg.Fetch(true); err != nil {
+	reg.SetProgress(tracker)
+	if len(args) > 0 {
+		err = reg.FetchRepos(args)
+	} else {
+		err = reg.Fetch(true)
+	}
+	done()
+	if err != nil {
+		if len(cfg.Repos) > 0 && git.Available() != nil {
+			return fmt.Errorf("failed to sync: %w\n%s", err, git.InstallHint())
+		}
 		return fmt.Errorf("failed to sync: %w", err)
 	}
 
 	skills := reg.ListSkills()
-	fmt.Printf("Synced. %d skill(s) available.\n", len(skills))
+	fmt.Println(i18n.Tf("Synced. %d skill(s) available.", len(skills)))
+	for _, names := range reg.CaseClashes() {
+		fmt.Println(i18n.Tf("Warning: %s differ only by case; they collide on case-insensitive filesystems, so the second one installed gets a numbered name", strings.Join(names, ", ")))
+	}
+	for _, warning := range reg.Warnings() {
+		fmt.Println(i18n.Tf("Warning: %s", warning))
+	}
 	return nil
 }
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/lockfile"
+	"lazyas/internal/manifest"
+	"lazyas/internal/scan"
+	"lazyas/internal/skillpolicy"
+	"lazyas/internal/trash"
+	"lazyas/internal/usage"
+)
+
+var (
+	syncLockDryRun bool
+	syncLockForce  bool
+)
+
+var syncLockCmd = &cobra.Command{
+	Use:     "sync-lock [lockfile]",
+	Aliases: []string{"import"},
+	Short:   "Make the installed skills exactly match a lockfile",
+	Long: `Reconcile the installed skills with a lockfile (default ./lazyas.lock).
+
+Skills missing locally are installed at their pinned commit, installed
+skills at another commit are moved to it, and skills not listed in the
+lockfile are removed. The plan is printed first; removals ask for
+confirmation unless --yes is given. 'lazyas export' writes a lockfile
+of the installed skills; 'lazyas import' is another name for this
+command.
+
+Skills with local modifications are not moved unless --force is used.
+
+Examples:
+  lazyas sync-lock --dry-run            # Show the plan only
+  lazyas sync-lock                      # Apply ./lazyas.lock
+  lazyas sync-lock team.lock --yes      # Apply without prompting
+  lazyas import team.lock               # The same, by its other name`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSyncLock,
+}
+
+func init() {
+	syncLockCmd.Flags().BoolVar(&syncLockDryRun, "dry-run", false, "Show the reconciliation plan without making changes")
+	syncLockCmd.Flags().BoolVarP(&syncLockForce, "force", "f", false, "Discard local modifications of skills that are moved")
+}
+
+func runSyncLock(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	path := lockfile.FileName
+	if len(args) > 0 {
+		path = args[0]
+	}
+	lock, err := lockfile.Read(path)
+	if err != nil {
+		return fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	plan := lockfile.NewPlan(lock, mfst.ListInstalled())
+	if len(plan.Conflicts) > 0 {
+		fmt.Println(i18n.T("The lockfile cannot be applied:"))
+		for _, c := range plan.Conflicts {
+			fmt.Printf("  %s\n", c)
+		}
+		return fmt.Errorf("lockfile %s has %d conflict(s)", path, len(plan.Conflicts))
+	}
+
+	printSyncLockPlan(plan)
+	if plan.Empty() || syncLockDryRun {
+		return nil
+	}
+
+	policy, err := scan.ParsePolicy(cfg.RiskPolicy)
+	if err != nil {
+		return err
+	}
+	rules, err := skillpolicy.Load(cfg.SkillPolicyPath())
+	if err != nil {
+		return err
+	}
+
+	var done, failed int
+	var changed []integrity.Target
+
+	for _, e := range plan.Install {
+		fmt.Println(i18n.Tf("Installing %s@%s...", e.Name, truncateString(e.Commit, 7)))
+		if err := installPinned(cfg, mfst, e, policy, rules); err != nil {
+			fmt.Println(i18n.Tf("  Failed: %v", err))
+			failed++
+			continue
+		}
+		done++
+		changed = append(changed, integrity.Target{Name: e.Name, Path: mfst.GetSkillPath(e.Name)})
+	}
+
+	for _, c := range plan.Repin {
+		skillDir := mfst.GetSkillPath(c.Name)
+		if err := rules.Check(skillpolicy.Subject{Name: c.Name, Repo: c.Repo}); err != nil {
+			fmt.Println(i18n.Tf("  %s: %v, skipping", c.Name, err))
+			failed++
+			continue
+		}
+		if modified, _ := git.IsModified(skillDir); modified {
+			if !syncLockForce {
+				fmt.Println(i18n.Tf("  %s: has local changes, skipping (use --force to overwrite)", c.Name))
+				failed++
+				continue
+			}
+			if !c.Reinstall {
+				if err := git.ResetChanges(skillDir); err != nil {
+					fmt.Println(i18n.Tf("  Failed to reset changes: %v", err))
+					failed++
+					continue
+				}
+			}
+		}
+
+		fmt.Println(i18n.Tf("Moving %s to %s...", c.Name, truncateString(c.Commit, 7)))
+		if c.Reinstall {
+			if err = trashSkill(cfg, mfst, c.Name, trash.ReasonOverwrite); err == nil {
+				err = installPinned(cfg, mfst, c.Entry, policy, rules)
+			}
+		} else {
+			err = checkoutPinned(mfst, c.Entry, rules)
+		}
+		if err != nil {
+			fmt.Println(i18n.Tf("  Failed: %v", err))
+			failed++
+			continue
+		}
+		done++
+		changed = append(changed, integrity.Target{Name: c.Name, Path: skillDir})
+	}
+
+	removed := 0
+	if len(plan.Remove) > 0 {
+		if !confirm(i18n.Tf("Remove %d skill(s) not in the lockfile? [y/N]: ", len(plan.Remove))) {
+			fmt.Println(i18n.T("Keeping extra skills"))
+			plan.Remove = nil
+		}
+		for _, name := range plan.Remove {
+			if err := trashSkill(cfg, mfst, name, trash.ReasonRemove); err != nil {
+				fmt.Println(i18n.Tf("  Failed to remove %s: %v", name, err))
+				failed++
+				continue
+			}
+			if err := mfst.RemoveSkill(name); err != nil {
+				fmt.Println(i18n.Tf("  Failed to update manifest: %v", err))
+				failed++
+				continue
+			}
+			fmt.Println(i18n.Tf("Removed %s", name))
+			usageCounts.Add(usage.Removals, 1)
+			removed++
+		}
+	}
+
+	fmt.Print(i18n.Tf("\nSynced %d skill(s), removed %d", done, removed))
+	if failed > 0 {
+		fmt.Print(i18n.Tf(", %d failed", failed))
+	}
+	fmt.Println()
+
+	if len(changed) > 0 {
+		reportValidation(mfst, changed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("installed skills do not fully match %s", path)
+	}
+	return nil
+}
+
+// printSyncLockPlan lists what sync-lock is about to change
+func printSyncLockPlan(p *lockfile.Plan) {
+	if p.Empty() {
+		fmt.Println(i18n.Tf("Installed skills match the lockfile (%d skill(s))", len(p.Unchanged)))
+		return
+	}
+
+	fmt.Println(i18n.T("Plan:"))
+	for _, e := range p.Install {
+		fmt.Println(i18n.Tf("  + %s @ %s (install)", e.Name, truncateString(e.Commit, 7)))
+	}
+	for _, c := range p.Repin {
+		if c.Reinstall {
+			fmt.Println(i18n.Tf("  ~ %s: %s → %s @ %s (reinstall)", c.Name, c.From.SourceRepo, c.Repo, truncateString(c.Commit, 7)))
+		} else {
+			fmt.Println(i18n.Tf("  ~ %s: %s → %s", c.Name, truncateString(c.From.Commit, 7), truncateString(c.Commit, 7)))
+		}
+	}
+	for _, name := range p.Remove {
+		fmt.Println(i18n.Tf("  - %s (remove)", name))
+	}
+	fmt.Println(i18n.Tf("%d to install, %d to move, %d to remove, %d unchanged\n",
+		len(p.Install), len(p.Repin), len(p.Remove), len(p.Unchanged)))
+}
+
+// installPinned installs a lockfile entry via the shared repo clone and
+// checks it out at the pinned commit
+func installPinned(cfg *config.Config, mfst *manifest.Manager, e lockfile.Entry, policy scan.Policy, rules *skillpolicy.Policy) error {
+	if err := rules.Check(skillpolicy.Subject{Name: e.Name, Repo: e.Repo}); err != nil {
+		return err
+	}
+
+	var risk *scan.Report
+	_, err := git.RepoInstall(git.RepoInstallOptions{
+		RepoURL:   e.Repo,
+		Path:      e.Path,
+		RepoDir:   filepath.Join(cfg.ReposDir, git.RepoDirName(e.Repo)),
+		SkillName: e.Name,
+		SkillLink: mfst.GetSkillPath(e.Name),
+		Check: func(skillPath string) error {
+			var err error
+			risk, err = scan.Check(skillPath, policy)
+			return err
+		},
+	})
+	if risk != nil {
+		printRiskReport(risk)
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkoutPinned(mfst, e, rules); err != nil {
+		os.Remove(mfst.GetSkillPath(e.Name))
+		return err
+	}
+	usageCounts.Add(usage.Installs, 1)
+	return nil
+}
+
+// checkoutPinned checks the entry's commit against max_risk of the skill
+// policy file, moves an installed skill to it and records it in the manifest
+func checkoutPinned(mfst *manifest.Manager, e lockfile.Entry, rules *skillpolicy.Policy) error {
+	skillDir := mfst.GetSkillPath(e.Name)
+	if err := enforceRisk(rules, e.Name, skillDir, e.Commit); err != nil {
+		return err
+	}
+	result, err := git.Update(skillDir, e.Commit)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(result.Commit, e.Commit) {
+		return fmt.Errorf("checked out %s, expected %s", truncateString(result.Commit, 7), truncateString(e.Commit, 7))
+	}
+	return mfst.AddSkill(e.Name, e.Version, result.Commit, e.Repo, e.Path)
+}
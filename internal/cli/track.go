@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+)
+
+var (
+	trackBranch string
+	trackTags   bool
+)
+
+var trackCmd = &cobra.Command{
+	Use:   "track <name>",
+	Short: "Choose whether a skill follows release tags or a branch",
+	Long: `Set the update channel for an installed skill.
+
+By default skills follow the release tag published in the registry.
+Tracking a branch makes 'lazyas update' move the skill to the tip of that
+branch instead. Skills installed from the same repository share one
+clone, so they move together when updated.
+
+Without flags, shows the current channel.
+
+Examples:
+  lazyas track my-skill --branch main   # Follow the main branch
+  lazyas track my-skill --tags          # Back to release tags
+  lazyas track my-skill                 # Show current channel`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrack,
+}
+
+func init() {
+	trackCmd.Flags().StringVar(&trackBranch, "branch", "", "Branch to track")
+	trackCmd.Flags().BoolVar(&trackTags, "tags", false, "Follow release tags (default channel)")
+	trackCmd.MarkFlagsMutuallyExclusive("branch", "tags")
+}
+
+func runTrack(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name := args[0]
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	info, ok := mfst.GetInstalled(name)
+	if !ok {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	if trackBranch == "" && !trackTags {
+		fmt.Println(i18n.Tf("%s follows %s", name, info.Channel()))
+		return nil
+	}
+
+	if trackBranch != "" {
+		exists, err := git.RemoteBranchExists(mfst.GetSkillPath(name), trackBranch)
+		if err != nil {
+			return fmt.Errorf("failed to check branch: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("branch %s not found in %s", trackBranch, info.SourceRepo)
+		}
+	}
+
+	if err := mfst.SetBranch(name, trackBranch); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	info, _ = mfst.GetInstalled(name)
+	fmt.Println(i18n.Tf("%s now follows %s", name, info.Channel()))
+	fmt.Println(i18n.Tf("Run 'lazyas update %s' to switch.", name))
+	return nil
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/trash"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List, restore or empty removed and overwritten skills",
+	Long: `Skills removed or overwritten by lazyas, and files cleaned up by a
+backend migration, are moved to ~/.lazyas/trash/ instead of being
+deleted. They are purged after trash_retention_days (default 30; a
+negative value keeps them until the trash is emptied).`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List trashed skills, newest first",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE:    runTrashList,
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id|name>",
+	Short: "Put a trashed skill back",
+	Long: `Put a trashed skill back where it was and track it in the manifest
+again. A skill name restores its most recently trashed copy.
+
+Examples:
+  lazyas trash restore my-skill
+  lazyas trash restore 20261015-142301-my-skill`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrashRestore,
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Delete everything in the trash for good",
+	Args:  cobra.NoArgs,
+	RunE:  runTrashEmpty,
+}
+
+func init() {
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashEmptyCmd)
+}
+
+// trashStore returns the trash configured in cfg
+func trashStore(cfg *config.Config) *trash.Store {
+	return trash.NewStore(cfg.TrashDir, cfg.TrashRetentionPeriod())
+}
+
+// trashSkill moves a skill's directory or link to the trash, keeping its
+// manifest entry so a restore can track it again
+func trashSkill(cfg *config.Config, mfst *manifest.Manager, name, reason string) error {
+	var entry *manifest.InstalledSkill
+	if info, ok := mfst.GetInstalled(name); ok {
+		entry = &info
+	}
+	_, err := trashStore(cfg).Move(mfst.GetSkillPath(name), name, reason, entry)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func runTrashList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store := trashStore(cfg)
+	store.Purge(time.Now())
+	items, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to read trash: %w", err)
+	}
+	if len(items) == 0 {
+		fmt.Println(i18n.T("Trash is empty"))
+		return nil
+	}
+
+	fmt.Println(i18n.T("Trash:"))
+	for _, item := range items {
+		line := fmt.Sprintf("  %-40s %-10s %s", item.ID, item.Reason, item.DeletedAt.Local().Format("2006-01-02 15:04"))
+		if expires := store.Expires(item); !expires.IsZero() {
+			line += "  " + i18n.Tf("purged %s", expires.Local().Format("2006-01-02"))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	fmt.Println(i18n.T("Restore with: lazyas trash restore <id|name>"))
+	return nil
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	store := trashStore(cfg)
+	item, err := store.Find(args[0])
+	if err != nil {
+		return err
+	}
+	if mfst.IsInstalled(item.Name) {
+		return fmt.Errorf("skill %s is installed; remove it before restoring the trashed copy", item.Name)
+	}
+
+	item, err = store.Restore(item.ID)
+	if err != nil {
+		return err
+	}
+	if item.Skill != nil {
+		if err := mfst.RestoreSkill(item.Name, *item.Skill); err != nil {
+			return fmt.Errorf("failed to update manifest: %w", err)
+		}
+	}
+	fmt.Println(i18n.Tf("Restored %s to %s", item.Name, item.Path))
+	return nil
+}
+
+func runTrashEmpty(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store := trashStore(cfg)
+	if !assumeYes {
+		items, err := store.List()
+		if err != nil {
+			return fmt.Errorf("failed to read trash: %w", err)
+		}
+		if len(items) == 0 {
+			fmt.Println(i18n.T("Trash is empty"))
+			return nil
+		}
+		if !confirm(i18n.Tf("Permanently delete %d item(s)? [y/N]: ", len(items))) {
+			fmt.Println(i18n.T("Cancelled"))
+			return nil
+		}
+	}
+
+	n, err := store.Empty()
+	if err != nil {
+		return fmt.Errorf("failed to empty trash: %w", err)
+	}
+	fmt.Println(i18n.Tf("Deleted %d item(s) from the trash", n))
+	return nil
+}
@@ -1,18 +1,36 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"lazyas/internal/adhoc"
 	"lazyas/internal/config"
 	"lazyas/internal/git"
+	"lazyas/internal/history"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
 	"lazyas/internal/manifest"
 	"lazyas/internal/registry"
+	"lazyas/internal/release"
+	"lazyas/internal/scan"
+	"lazyas/internal/semver"
+	"lazyas/internal/skillpolicy"
+	"lazyas/internal/usage"
 )
 
 var (
 	updateDryRun bool
 	updateForce  bool
+	updateStash  bool
+	updateMerge  bool
+	updateMajor  bool
+	updateTo     string
+	updateLog    bool
+	updateLast   bool
 )
 
 var updateCmd = &cobra.Command{
@@ -20,20 +38,48 @@ var updateCmd = &cobra.Command{
 	Short: "Update installed skill(s)",
 	Long: `Update one or all installed skills to their latest versions.
 
+Each skill updates along its channel: the registry's release tag by
+default, or a branch selected with 'lazyas track'. A skill pinned with
+'lazyas pin' or 'lazyas install name@version' stays at its pinned
+version, or moves to the newest release within a partial pin like v1.2.
+
 Skills with local modifications are skipped unless --force is used.
-Use --dry-run to preview what would be updated.
+With --stash, local edits are stashed, the update is applied and the
+edits are reapplied on top; conflicts are reported and the stash is kept.
+With --merge, local edits are three-way merged with the upstream changes
+using the installed commit as the base. Files that conflict get conflict
+markers; fix them and run 'lazyas resolve <name>'.
+Use --dry-run to preview what would be updated. The results of each run
+are kept in update-history.yaml; --last shows the most recent one.
+
+When versions are semver tags, the update policy (update_policy in
+config.toml, or per skill via 'lazyas policy') limits how far a skill may
+move. Use --major to allow a jump the policy would hold back.
 
 Examples:
   lazyas update                # Update all skills
   lazyas update my-skill    # Update specific skill
   lazyas update --dry-run      # Preview updates
-  lazyas update --force        # Update even modified skills`,
+  lazyas update --force        # Update even modified skills
+  lazyas update --stash        # Keep local edits across the update
+  lazyas update --merge        # Three-way merge local edits with upstream
+  lazyas update --major        # Allow major version jumps
+  lazyas update --changelog --dry-run  # Review what changed before updating
+  lazyas update my-skill --to v1.2.0   # Move to an exact tag or commit
+  lazyas update --last         # Review the results of the last update`,
 	RunE: runUpdate,
 }
 
 func init() {
 	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Preview updates without making changes")
 	updateCmd.Flags().BoolVarP(&updateForce, "force", "f", false, "Update even skills with local modifications")
+	updateCmd.Flags().BoolVar(&updateStash, "stash", false, "Stash local modifications, update, then reapply them")
+	updateCmd.Flags().BoolVar(&updateMerge, "merge", false, "Three-way merge local modifications with the upstream update")
+	updateCmd.MarkFlagsMutuallyExclusive("force", "stash", "merge")
+	updateCmd.Flags().BoolVar(&updateMajor, "major", false, "Allow updates across major versions regardless of policy")
+	updateCmd.Flags().BoolVar(&updateLog, "changelog", false, "Show commits and CHANGELOG.md entries between installed and target versions")
+	updateCmd.Flags().StringVar(&updateTo, "to", "", "Check out an exact tag or commit (upgrade or downgrade)")
+	updateCmd.Flags().BoolVar(&updateLast, "last", false, "Show the results of the last update run")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
@@ -41,6 +87,12 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if updateLast {
+		return printLastRun(cfg)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
 
 	// Load manifest
 	mfst := manifest.NewManager(cfg)
@@ -50,14 +102,32 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	installed := mfst.ListInstalled()
 	if len(installed) == 0 {
-		fmt.Println("No skills installed")
+		fmt.Println(i18n.T("No skills installed"))
 		return nil
 	}
 
+	rules, err := skillpolicy.Load(cfg.SkillPolicyPath())
+	if err != nil {
+		return err
+	}
+
+	if updateTo != "" {
+		if len(args) != 1 {
+			return fmt.Errorf("--to requires exactly one skill name")
+		}
+		return runUpdateTo(cfg, mfst, rules, args[0], updateTo)
+	}
+
+	tracker, line, done := startProgress(i18n.T("Fetching"))
+	defer done()
+
 	// Fetch registry for version info
-	fmt.Println("Fetching skill index...")
+	fmt.Println(i18n.T("Fetching skill index..."))
 	reg := registry.NewRegistry(cfg)
-	if err := reg.Fetch(true); err != nil {
+	reg.SetProgress(tracker)
+	err = reg.Fetch(true)
+	line.Clear()
+	if err != nil && !onlyAdHoc(installed, args) {
 		return fmt.Errorf("failed to fetch index: %w", err)
 	}
 
@@ -77,33 +147,129 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	// Update each skill
 	var updated, skipped, failed int
-	for _, name := range toUpdate {
+	var changed []integrity.Target
+	tracker.Begin(i18n.T("Updating"), len(toUpdate))
+	cancelled := false
+	run := history.Run{At: time.Now(), Source: "cli"}
+	for i, name := range toUpdate {
+		if tracker.Err() != nil {
+			cancelled = true
+			break
+		}
 		info := installed[name]
-		skill := reg.GetSkill(name)
+		skill := reg.GetSkill(info.RegistryName(name))
+		if info.AdHoc {
+			skill = nil // installed with --from; a registry skill of that name is another one
+		}
 		skillDir := mfst.GetSkillPath(name)
+		res := history.Result{Name: name, OldVersion: info.Version, OldCommit: info.Commit}
+		record := func(status, problem string) {
+			res.Status, res.Error = status, problem
+			run.Set(res)
+		}
+
+		// Release assets have no clone to move; a newer release is a reinstall
+		if release.IsSource(info.SourceRepo) {
+			fmt.Println(i18n.Tf("  %s: installed from a release asset, skipping (install a newer release to update)", name))
+			record(history.StatusSkipped, "release asset")
+			skipped++
+			continue
+		}
+
+		// A copy of a local directory is refreshed from it
+		if info.AdHoc && adhoc.IsLocal(info.SourceRepo) {
+			status, problem := updateLocalCopy(cfg, mfst, rules, name, info)
+			record(status, problem)
+			switch status {
+			case history.StatusUpdated:
+				updated++
+				if !updateDryRun {
+					changed = append(changed, integrity.Target{Name: name, Path: skillDir})
+				}
+			case history.StatusFailed:
+				failed++
+			default:
+				skipped++
+			}
+			continue
+		}
 
 		// Check for local modifications
 		modified, _ := git.IsModified(skillDir)
-		if modified && !updateForce {
+		if modified && !updateForce && !updateStash && !updateMerge {
 			if updateDryRun {
-				fmt.Printf("  %s: has local changes (would skip)\n", name)
+				fmt.Println(i18n.Tf("  %s: has local changes (would skip)", name))
 			} else {
-				fmt.Printf("  %s: has local changes, skipping (use --force to overwrite)\n", name)
+				fmt.Println(i18n.Tf("  %s: has local changes, skipping (use --force to overwrite)", name))
 			}
+			record(history.StatusSkipped, "local changes")
+			skipped++
+			continue
+		}
+
+		// Conflicts from an earlier merge must be resolved (or discarded) first
+		if info.NeedsResolution() && !updateForce {
+			fmt.Println(i18n.Tf("  %s: has unresolved conflicts, skipping (see 'lazyas resolve %s')", name, name))
+			record(history.StatusSkipped, "unresolved conflicts")
 			skipped++
 			continue
 		}
 
-		// Determine target version
-		targetTag := ""
+		// Determine target ref: tracked branch, else the registry tag
+		registryTag := ""
+		if skill != nil {
+			registryTag = skill.Source.Tag
+		}
+		targetRef := info.TargetRef(registryTag)
+
+		// A pinned skill only moves to a newer version within its pin
+		if info.Pin != "" {
+			targetRef = git.PinTarget(skillDir, info.RegistryName(name), info.SourcePath, info.Pin)
+			if targetRef == info.Version {
+				fmt.Println(i18n.Tf("  %s: pinned to %s, skipping", name, info.Pin))
+				record(history.StatusHeld, "pinned to "+info.Pin)
+				skipped++
+				continue
+			}
+		}
+
+		// Check the skill policy file against where the update comes from
+		subject := skillpolicy.Subject{Name: name, Repo: info.SourceRepo}
 		if skill != nil {
-			targetTag = skill.Source.Tag
+			subject.Tags = skill.Tags
+			if info.ForkedFrom == "" {
+				subject.Repo = skill.Source.Repo
+			}
+		}
+		if err := rules.Check(subject); err != nil {
+			fmt.Println(i18n.Tf("  %s: %v, skipping", name, err))
+			record(history.StatusBlocked, err.Error())
+			skipped++
+			continue
+		}
+
+		// Check the semver update policy: move to the newest version it allows
+		policy := semver.EffectivePolicy(info.Policy, cfg.UpdatePolicy)
+		if !updateMajor && !policy.Allows(info.Version, targetRef) {
+			allowed := git.PolicyTarget(skillDir, info.RegistryName(name), info.SourcePath, info.Version, targetRef, policy)
+			if allowed == info.Version {
+				fmt.Println(i18n.Tf("  %s: %s → %s held by %s policy (use --major to allow)", name, info.Version, targetRef, policy))
+				record(history.StatusHeld, fmt.Sprintf("%s policy", policy))
+				skipped++
+				continue
+			}
+			fmt.Println(i18n.Tf("  %s: %s held by %s policy, moving to %s (use --major to allow)", name, targetRef, policy, allowed))
+			targetRef = allowed
+		}
+
+		if updateLog && (skill != nil || info.AdHoc) {
+			printChangelog(name, skillDir, info.Commit, targetRef)
 		}
 
 		if updateDryRun {
 			// Dry run mode - just show what would happen
-			if skill == nil {
-				fmt.Printf("  %s: not found in registry (would skip)\n", name)
+			if skill == nil && !info.AdHoc {
+				fmt.Println(i18n.Tf("  %s: not found in registry (would skip)", name))
 				skipped++
 				continue
 			}
@@ -112,67 +278,387 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			if currentVersion == "" {
 				currentVersion = "latest"
 			}
-			newVersion := targetTag
+			newVersion := targetRef
 			if newVersion == "" {
 				newVersion = "latest"
 			}
 
-			if modified {
-				fmt.Printf("  %s: %s → %s (would force update)\n", name, currentVersion, newVersion)
+			if modified && updateMerge {
+				fmt.Println(i18n.Tf("  %s: %s → %s (would merge with local changes)", name, currentVersion, newVersion))
+			} else if modified && updateStash {
+				fmt.Println(i18n.Tf("  %s: %s → %s (would update, keeping local changes)", name, currentVersion, newVersion))
+			} else if modified {
+				fmt.Println(i18n.Tf("  %s: %s → %s (would force update)", name, currentVersion, newVersion))
 			} else {
-				fmt.Printf("  %s: %s → %s (would update)\n", name, currentVersion, newVersion)
+				fmt.Println(i18n.Tf("  %s: %s → %s (would update)", name, currentVersion, newVersion))
 			}
 			updated++
 			continue
 		}
 
-		fmt.Printf("Updating %s...\n", name)
+		fmt.Println(i18n.Tf("Updating %s...", name))
+		res.NewVersion = targetRef
+
+		if err := enforceRisk(rules, name, skillDir, targetRef); err != nil {
+			var violation *skillpolicy.Violation
+			if errors.As(err, &violation) {
+				fmt.Println(i18n.Tf("  %s: %v, skipping", name, err))
+				record(history.StatusBlocked, err.Error())
+				skipped++
+			} else {
+				fmt.Println(i18n.Tf("  Failed: %v", err))
+				record(history.StatusFailed, err.Error())
+				failed++
+			}
+			continue
+		}
 
 		// If force and modified, reset changes first
 		if modified && updateForce {
-			fmt.Printf("  Discarding local changes...\n")
+			fmt.Println(i18n.T("  Discarding local changes..."))
 			if err := git.ResetChanges(skillDir); err != nil {
-				fmt.Printf("  Failed to reset changes: %v\n", err)
+				fmt.Println(i18n.Tf("  Failed to reset changes: %v", err))
+				record(history.StatusFailed, err.Error())
 				failed++
 				continue
 			}
 		}
 
-		result, err := git.Update(skillDir, targetTag)
-		if err != nil {
-			fmt.Printf("  Failed: %v\n", err)
-			failed++
-			continue
+		var result *git.CloneResult
+		var conflicts []string
+		tracker.Set(i+1, name)
+		switch {
+		case modified && updateMerge:
+			merged, err := git.MergeUpstream(skillDir, info.Commit, targetRef)
+			line.Clear()
+			if err != nil {
+				fmt.Println(i18n.Tf("  Failed: %v", err))
+				record(history.StatusFailed, err.Error())
+				failed++
+				continue
+			}
+			printMergeResult(name, merged)
+			result = &git.CloneResult{Commit: merged.Commit, Path: skillDir}
+			conflicts = merged.Conflicts
+		case modified && updateStash:
+			stashed, err := git.UpdateWithStash(skillDir, targetRef)
+			line.Clear()
+			if err != nil {
+				fmt.Println(i18n.Tf("  Failed: %v", err))
+				record(history.StatusFailed, err.Error())
+				failed++
+				continue
+			}
+			printStashResult(stashed)
+			result = &git.CloneResult{Commit: stashed.Commit, Path: skillDir}
+			conflicts = stashed.Conflicts
+		default:
+			result, err = git.Update(skillDir, targetRef)
+			line.Clear()
+			if err != nil {
+				fmt.Println(i18n.Tf("  Failed: %v", err))
+				record(history.StatusFailed, err.Error())
+				failed++
+				continue
+			}
 		}
 
+		res.NewCommit = result.Commit
 		if result.Commit != info.Commit {
 			sourceRepo := info.SourceRepo
 			sourcePath := info.SourcePath
-			if skill != nil {
+			if skill != nil && info.ForkedFrom == "" {
 				sourceRepo = skill.Source.Repo
 				sourcePath = skill.Source.Path
 			}
-			mfst.AddSkill(name, targetTag, result.Commit, sourceRepo, sourcePath)
-			fmt.Printf("  Updated to %s\n", truncateString(result.Commit, 7))
+			mfst.AddSkill(name, targetRef, result.Commit, sourceRepo, sourcePath)
+			fmt.Println(i18n.Tf("  Updated to %s", truncateString(result.Commit, 7)))
+			record(history.StatusUpdated, "")
 			updated++
+			changed = append(changed, integrity.Target{Name: name, Path: skillDir})
 		} else {
-			fmt.Printf("  Already up to date\n")
+			fmt.Println(i18n.T("  Already up to date"))
+			record(history.StatusUpToDate, "")
 			skipped++
 		}
+
+		if len(conflicts) > 0 || info.NeedsResolution() {
+			mfst.SetConflicts(name, conflicts)
+		}
+	}
+
+	if cancelled {
+		fmt.Println(i18n.T("\nCancelled; the remaining skills were not updated"))
 	}
 
 	if updateDryRun {
-		fmt.Printf("\nWould update: %d, Skip: %d\n", updated, skipped)
+		fmt.Println(i18n.Tf("\nWould update: %d, Skip: %d", updated, skipped))
 	} else {
-		fmt.Printf("\nUpdated %d skill(s)", updated)
+		fmt.Print(i18n.Tf("\nUpdated %d skill(s)", updated))
 		if skipped > 0 {
-			fmt.Printf(", %d skipped", skipped)
+			fmt.Print(i18n.Tf(", %d skipped", skipped))
 		}
 		if failed > 0 {
-			fmt.Printf(", %d failed", failed)
+			fmt.Print(i18n.Tf(", %d failed", failed))
 		}
 		fmt.Println()
+
+		if len(changed) > 0 {
+			for _, r := range reportValidation(mfst, changed) {
+				for i := range run.Results {
+					if run.Results[i].Name == r.Name {
+						run.Results[i].Status = history.StatusInvalid
+						run.Results[i].Error = r.Err.Error()
+					}
+				}
+			}
+		}
+		saveHistory(cfg, run)
 	}
 
 	return nil
 }
+
+// reportValidation validates and hashes updated skills concurrently, records
+// the hashes and prints a consolidated report of any that failed, which
+// it returns.
+func reportValidation(mfst *manifest.Manager, targets []integrity.Target) []integrity.Result {
+	results := integrity.ValidateAll(targets)
+	if err := mfst.SetHashes(integrity.Hashes(results)); err != nil {
+		fmt.Println(i18n.Tf("Warning: failed to record hashes: %v", err))
+	}
+
+	failed := integrity.Failed(results)
+	if len(failed) == 0 {
+		fmt.Println(i18n.Tf("Validation: %d skill(s) OK", len(results)))
+		return nil
+	}
+
+	fmt.Println(i18n.Tf("\nValidation failed for %d of %d skill(s):", len(failed), len(results)))
+	for _, r := range failed {
+		fmt.Printf("  ✗ %s: %v\n", r.Name, r.Err)
+	}
+	return failed
+}
+
+// saveHistory records an update run for 'lazyas update --last' and counts
+// its updates for the usage stats
+func saveHistory(cfg *config.Config, run history.Run) {
+	if len(run.Results) == 0 {
+		return
+	}
+	updated, _, _ := run.Counts()
+	usageCounts.Add(usage.Updates, updated)
+	if err := history.Append(cfg.HistoryPath, run); err != nil {
+		fmt.Println(i18n.Tf("Warning: failed to record update history: %v", err))
+	}
+}
+
+// printLastRun shows the most recent recorded update run
+func printLastRun(cfg *config.Config) error {
+	run, err := history.Last(cfg.HistoryPath)
+	if err != nil {
+		return err
+	}
+	if run == nil {
+		fmt.Println(i18n.T("No update has been recorded yet"))
+		return nil
+	}
+
+	fmt.Println(i18n.Tf("Last update: %s (%s)", run.At.Local().Format("2006-01-02 15:04"), run.Source))
+	for _, res := range run.Results {
+		line := fmt.Sprintf("  %-12s %s", res.Status, res.Name)
+		if res.NewCommit != "" && res.NewCommit != res.OldCommit {
+			line += fmt.Sprintf(" %s → %s", truncateString(res.OldCommit, 7), truncateString(res.NewCommit, 7))
+		}
+		if res.Error != "" {
+			line += ": " + res.Error
+		}
+		fmt.Println(line)
+		for _, change := range res.Changes {
+			fmt.Printf("      %s\n", change)
+		}
+	}
+
+	updated, skipped, failed := run.Counts()
+	fmt.Println(i18n.Tf("\nUpdated %d, skipped %d, failed %d", updated, skipped, failed))
+	return nil
+}
+
+// runUpdateTo moves a single skill to an exact tag or commit, in either
+// direction. Local modifications are only discarded with --force.
+func runUpdateTo(cfg *config.Config, mfst *manifest.Manager, rules *skillpolicy.Policy, name, ref string) error {
+	info, ok := mfst.GetInstalled(name)
+	if !ok || !mfst.IsInstalled(name) {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+	if !semver.MatchesPin(info.Pin, ref) {
+		return fmt.Errorf("skill %s is pinned to %s (use 'lazyas pin %s %s' to change the pin)", name, info.Pin, name, ref)
+	}
+	if err := rules.Check(skillpolicy.Subject{Name: name, Repo: info.SourceRepo}); err != nil {
+		return err
+	}
+	skillDir := mfst.GetSkillPath(name)
+
+	modified, _ := git.IsModified(skillDir)
+	if modified {
+		fmt.Println(i18n.Tf("Warning: %s has local modifications.", name))
+		modFiles, _ := git.GetModifiedFiles(skillDir)
+		for _, f := range modFiles {
+			fmt.Printf("  %s\n", f)
+		}
+		if !updateForce && !updateStash {
+			return fmt.Errorf("skill %s has local modifications (use --force to discard them or --stash to keep them)", name)
+		}
+		if updateStash {
+			fmt.Println(i18n.T("Local changes will be stashed and reapplied."))
+		} else if updateDryRun {
+			fmt.Println(i18n.T("Local changes would be discarded."))
+		} else {
+			fmt.Println(i18n.T("  Discarding local changes..."))
+			if err := git.ResetChanges(skillDir); err != nil {
+				return fmt.Errorf("failed to reset changes: %w", err)
+			}
+		}
+	}
+
+	current := info.Version
+	if current == "" {
+		current = truncateString(info.Commit, 7)
+	}
+	msgFormat := "Switching %s: %s → %s..."
+	if from, okFrom := semver.Parse(info.Version); okFrom {
+		if to, okTo := semver.Parse(ref); okTo {
+			switch semver.Compare(to, from) {
+			case 1:
+				msgFormat = "Upgrading %s: %s → %s..."
+			case -1:
+				msgFormat = "Downgrading %s: %s → %s..."
+			}
+		}
+	}
+
+	if updateDryRun {
+		fmt.Println(i18n.Tf("  %s: %s → %s (would check out)", name, current, ref))
+		return nil
+	}
+
+	fmt.Println(i18n.Tf(msgFormat, name, current, ref))
+	run := history.Run{At: time.Now(), Source: "cli"}
+	res := history.Result{Name: name, OldVersion: info.Version, NewVersion: ref, OldCommit: info.Commit}
+	if err := enforceRisk(rules, name, skillDir, ref); err != nil {
+		res.Status, res.Error = history.StatusFailed, err.Error()
+		var violation *skillpolicy.Violation
+		if errors.As(err, &violation) {
+			res.Status = history.StatusBlocked
+		}
+		run.Set(res)
+		saveHistory(cfg, run)
+		return err
+	}
+	stashed, err := git.UpdateWithStash(skillDir, ref)
+	if err != nil {
+		res.Status, res.Error = history.StatusFailed, err.Error()
+		run.Set(res)
+		saveHistory(cfg, run)
+		return fmt.Errorf("failed to check out %s: %w", ref, err)
+	}
+	printStashResult(stashed)
+	result := &git.CloneResult{Commit: stashed.Commit, Path: skillDir}
+
+	if err := mfst.AddSkill(name, ref, result.Commit, info.SourceRepo, info.SourcePath); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if len(stashed.Conflicts) > 0 || info.NeedsResolution() {
+		mfst.SetConflicts(name, stashed.Conflicts)
+	}
+
+	fmt.Println(i18n.Tf("  Now at %s (%s)", ref, truncateString(result.Commit, 7)))
+	res.Status, res.NewCommit = history.StatusUpdated, result.Commit
+	if result.Commit == info.Commit {
+		res.Status = history.StatusUpToDate
+	}
+	if invalid := reportValidation(mfst, []integrity.Target{{Name: name, Path: skillDir}}); len(invalid) > 0 {
+		res.Status, res.Error = history.StatusInvalid, invalid[0].Err.Error()
+	}
+	run.Set(res)
+	saveHistory(cfg, run)
+	return nil
+}
+
+// enforceRisk scans the skill's files at ref against max_risk of the skill
+// policy file before the checkout moves, so a refused update never lands.
+// Local changes are not part of the scan.
+func enforceRisk(rules *skillpolicy.Policy, name, skillDir, ref string) error {
+	if !rules.LimitsRisk() {
+		return nil
+	}
+	staged, err := git.StageRef(skillDir, ref)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s for scanning: %w", name, err)
+	}
+	defer os.RemoveAll(staged)
+	report, err := scan.Dir(staged)
+	if err != nil {
+		return err
+	}
+	return rules.CheckRisk(name, report)
+}
+
+// printStashResult reports how reapplying stashed local changes went
+func printStashResult(r *git.StashUpdateResult) {
+	if !r.Stashed {
+		return
+	}
+	if len(r.Conflicts) == 0 {
+		fmt.Println(i18n.T("  Reapplied local changes"))
+		return
+	}
+	fmt.Println(i18n.T("  Local changes conflict with the update:"))
+	for _, f := range r.Conflicts {
+		fmt.Printf("    %s\n", f)
+	}
+	fmt.Println(i18n.Tf("  Resolve the conflict markers; your changes are also kept in stash %s", truncateString(r.StashRef, 7)))
+}
+
+// printMergeResult reports the outcome of a three-way merge
+func printMergeResult(name string, r *git.MergeResult) {
+	if len(r.Merged) > 0 {
+		fmt.Println(i18n.Tf("  Merged local changes in %d file(s)", len(r.Merged)))
+	}
+	if len(r.Conflicts) == 0 {
+		return
+	}
+	fmt.Println(i18n.T("  Conflicts (fix the markers in these files):"))
+	for _, f := range r.Conflicts {
+		fmt.Printf("    %s\n", f)
+	}
+	fmt.Println(i18n.Tf("  Then run: lazyas resolve %s", name))
+}
+
+// printChangelog shows what an update would bring in for one skill
+func printChangelog(name, skillDir, fromCommit, ref string) {
+	cl, err := git.FetchChangelog(skillDir, fromCommit, ref)
+	if err != nil {
+		fmt.Println(i18n.Tf("  %s: changelog unavailable: %v", name, err))
+		return
+	}
+	if cl.Empty() {
+		fmt.Println(i18n.Tf("  %s: no changes", name))
+		return
+	}
+
+	fmt.Println(i18n.Tf("  %s changes:", name))
+	for _, note := range cl.Notes {
+		fmt.Printf("    %s\n", note)
+	}
+	if len(cl.Notes) > 0 && len(cl.Commits) > 0 {
+		fmt.Println()
+	}
+	for _, c := range cl.Commits {
+		fmt.Printf("    %s\n", c)
+	}
+	if cl.Truncated {
+		fmt.Println(i18n.T("    (installed commit not in fetched history; showing recent commits)"))
+	}
+}
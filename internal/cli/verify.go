@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/manifest"
+)
+
+var (
+	verifyJSON     bool
+	verifyExitCode bool
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [name]",
+	Short: "Check installed skills for local drift",
+	Long: `Verify that installed skills still match what lazyas installed.
+
+A skill is "modified" when its files have local edits, "drifted" when its
+content no longer matches the hash recorded at install time without any
+local edits (e.g. its clone was moved by hand), "invalid" when it fails
+validation and "missing" when it is gone from the skills directory.
+Paths matched by .lazyasignore are not considered.
+
+With --exit-code the command exits 0 when every skill is clean, 1 when a
+skill has drifted and 2 when a check failed, for gating CI pipelines.
+
+Examples:
+  lazyas verify
+  lazyas verify --json --exit-code`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyJSON, "json", false, "Print a machine-readable report")
+	verifyCmd.Flags().BoolVar(&verifyExitCode, "exit-code", false, "Exit 1 on drift, 2 on errors")
+}
+
+// verifyReport is the --json output of lazyas verify
+type verifyReport struct {
+	SchemaVersion int           `json:"schema_version"`
+	Skills        []verifySkill `json:"skills"`
+	Drifted       int           `json:"drifted"`
+	Errors        int           `json:"errors"`
+}
+
+type verifySkill struct {
+	Name          string   `json:"name"`
+	Status        string   `json:"status"` // ok, modified, drifted, invalid, missing, error
+	Commit        string   `json:"commit"`
+	RecordedHash  string   `json:"recorded_hash,omitempty"`
+	Hash          string   `json:"hash,omitempty"`
+	ModifiedFiles []string `json:"modified_files,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	report, err := checkVerify(args)
+	if err != nil {
+		if verifyExitCode {
+			fmt.Fprintln(os.Stderr, err)
+			return exitCode(cmd, exitError)
+		}
+		return err
+	}
+
+	if verifyJSON {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printVerify(report)
+	}
+
+	if !verifyExitCode {
+		return nil
+	}
+	switch {
+	case report.Errors > 0:
+		return exitCode(cmd, exitError)
+	case report.Drifted > 0:
+		return exitCode(cmd, exitDrift)
+	}
+	return nil
+}
+
+func checkVerify(args []string) (*verifyReport, error) {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	installed := mfst.ListInstalled()
+	names := args
+	if len(names) == 0 {
+		for name := range installed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	} else if _, ok := installed[names[0]]; !ok {
+		return nil, fmt.Errorf("skill %s is not installed", names[0])
+	}
+
+	report := &verifyReport{SchemaVersion: reportSchemaVersion, Skills: []verifySkill{}}
+	var targets []integrity.Target
+	for _, name := range names {
+		skillDir := mfst.GetSkillPath(name)
+		if _, err := os.Stat(skillDir); err != nil {
+			continue // reported as missing below
+		}
+		targets = append(targets, integrity.Target{Name: name, Path: skillDir})
+	}
+	results := make(map[string]integrity.Result, len(targets))
+	for _, r := range integrity.ValidateAll(targets) {
+		results[r.Name] = r
+	}
+
+	for _, name := range names {
+		info := installed[name]
+		s := verifySkill{Name: name, Commit: info.Commit, RecordedHash: info.Hash}
+
+		r, ok := results[name]
+		switch {
+		case !ok:
+			s.Status = "missing"
+		case r.Err != nil:
+			s.Status = "invalid"
+			s.Error = r.Err.Error()
+		default:
+			s.Hash = r.Hash
+			modified, err := git.GetModifiedFiles(mfst.GetSkillPath(name))
+			switch {
+			case err != nil:
+				s.Status = "error"
+				s.Error = err.Error()
+			case len(modified) > 0:
+				s.Status = "modified"
+				s.ModifiedFiles = modified
+			case info.Hash != "" && info.Hash != r.Hash:
+				s.Status = "drifted"
+			default:
+				s.Status = "ok"
+			}
+		}
+
+		switch s.Status {
+		case "ok":
+		case "error":
+			report.Errors++
+		default:
+			report.Drifted++
+		}
+		report.Skills = append(report.Skills, s)
+	}
+	return report, nil
+}
+
+func printVerify(report *verifyReport) {
+	if len(report.Skills) == 0 {
+		fmt.Println(i18n.T("No skills installed"))
+		return
+	}
+
+	for _, s := range report.Skills {
+		switch s.Status {
+		case "ok":
+			continue
+		case "modified":
+			fmt.Println(i18n.Tf("  %s: modified (%d file(s))", s.Name, len(s.ModifiedFiles)))
+			for _, f := range s.ModifiedFiles {
+				fmt.Printf("    %s\n", f)
+			}
+		case "drifted":
+			fmt.Println(i18n.Tf("  %s: content differs from the installed version", s.Name))
+		case "invalid":
+			fmt.Println(i18n.Tf("  %s: invalid: %s", s.Name, s.Error))
+		case "missing":
+			fmt.Println(i18n.Tf("  %s: missing from the skills directory", s.Name))
+		default:
+			fmt.Println(i18n.Tf("  %s: check failed: %s", s.Name, s.Error))
+		}
+	}
+
+	if report.Drifted == 0 && report.Errors == 0 {
+		fmt.Println(i18n.Tf("All %d skill(s) verified", len(report.Skills)))
+		return
+	}
+	fmt.Println(i18n.Tf("\n%d drifted, %d failed", report.Drifted, report.Errors))
+}
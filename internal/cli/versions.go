@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions <name>",
+	Short: "List available versions of an installed skill",
+	Long: `List the tags published by a skill's source repository, newest first.
+
+In monorepos, tags scoped to the skill ("skills/my-skill/v1.0.0",
+"my-skill@1.0.0", "my-skill-v1.0.0") are shown when present; otherwise
+all repository tags are listed. The installed version is marked with ●.
+
+Examples:
+  lazyas versions my-skill
+  lazyas update my-skill --to <tag>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVersions,
+}
+
+func runVersions(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := requireGit(); err != nil {
+		return err
+	}
+
+	name := args[0]
+
+	// Load manifest
+	mfst := manifest.NewManager(cfg)
+	if err := mfst.Load(); err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	info, ok := mfst.GetInstalled(name)
+	if !ok || !mfst.IsInstalled(name) {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	tags, err := git.SkillVersions(mfst.GetSkillPath(name), info.RegistryName(name), info.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	if len(tags) == 0 {
+		fmt.Println(i18n.Tf("%s has no tagged versions", name))
+		return nil
+	}
+
+	fmt.Println(i18n.Tf("Versions of %s:", name))
+	fmt.Println()
+	for _, t := range tags {
+		if t.Name == info.Version || (info.Commit != "" && t.Commit == info.Commit) {
+			fmt.Println(i18n.Tf("  ● %s  (installed)", t.Name))
+		} else {
+			fmt.Printf("  ○ %s\n", t.Name)
+		}
+	}
+
+	return nil
+}
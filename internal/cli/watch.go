@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+	"lazyas/internal/notify"
+	"lazyas/internal/watch"
+)
+
+var (
+	watchPrune   bool
+	watchUpdates bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Report changes made to the skills directory",
+	Long: `Watch the skills directory and report changes made outside lazyas:
+skills added by hand, tracked skills deleted, and files edited inside
+a skill. Runs until interrupted.
+
+Paths matched by .lazyasignore are not reported. Backends link to the
+skills directory, so they see every change without re-syncing.
+
+With --updates, installed skills are also checked for updates every
+refresh_interval_minutes (or cache_ttl_hours). Newly available updates
+are printed and, with notifications = true in config.toml, shown as a
+desktop notification outside quiet_hours.
+
+Examples:
+  lazyas watch
+  lazyas watch --prune     # Drop deleted skills from the manifest
+  lazyas watch --updates   # Also report available updates`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().BoolVar(&watchPrune, "prune", false, "Remove tracked skills from the manifest when they are deleted")
+	watchCmd.Flags().BoolVar(&watchUpdates, "updates", false, "Periodically check installed skills for updates")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	cfg, err := config.DefaultConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.EnsureDirs(); err != nil {
+		return fmt.Errorf("failed to create directories: %w", err)
+	}
+
+	w, err := watch.New(cfg.SkillsDir, watch.DefaultDebounce)
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", cfg.SkillsDir, err)
+	}
+	defer w.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	var updateTick <-chan time.Time
+	var notifier *notify.Notifier
+	notified := make(map[string]string) // skill -> latest commit already reported
+	if watchUpdates {
+		if err := requireGit(); err != nil {
+			return err
+		}
+		if notifier, err = notify.New(cfg.Notifications, cfg.QuietHours); err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+		interval := cfg.BackgroundRefresh()
+		if interval <= 0 {
+			interval = time.Hour
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		updateTick = ticker.C
+		reportWatchUpdates(notifier, notified)
+	}
+
+	fmt.Println(i18n.Tf("Watching %s (Ctrl+C to stop)", cfg.SkillsDir))
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case <-updateTick:
+			reportWatchUpdates(notifier, notified)
+		case err := <-w.Errors:
+			fmt.Fprintln(os.Stderr, i18n.Tf("warning: %v", err))
+		case ev := <-w.Events:
+			// Re-read the manifest so installs made meanwhile are recognized
+			mfst := manifest.NewManager(cfg)
+			if err := mfst.Load(); err != nil {
+				return fmt.Errorf("failed to load manifest: %w", err)
+			}
+			reportWatchEvent(mfst, ev)
+		}
+	}
+}
+
+// reportWatchUpdates prints the skills with an update available that has
+// not been reported yet, and notifies about them
+func reportWatchUpdates(notifier *notify.Notifier, notified map[string]string) {
+	report, err := checkOutdated(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("warning: update check failed: %v", err))
+		return
+	}
+
+	stamp := time.Now().Format("15:04:05")
+	var names []string
+	for _, s := range report.Skills {
+		if s.Status != "outdated" || notified[s.Name] == s.LatestCommit {
+			continue
+		}
+		notified[s.Name] = s.LatestCommit
+		names = append(names, s.Name)
+		fmt.Println(i18n.Tf("%s update available for %s: %s → %s (%s)", stamp, s.Name, truncateString(s.InstalledCommit, 7), truncateString(s.LatestCommit, 7), refLabel(s.TargetRef)))
+	}
+	if len(names) == 0 {
+		return
+	}
+	if err := notifier.Notify(i18n.T("lazyas: updates available"), strings.Join(names, ", ")); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("warning: notification failed: %v", err))
+	}
+}
+
+func reportWatchEvent(mfst *manifest.Manager, ev watch.Event) {
+	stamp := time.Now().Format("15:04:05")
+	skillPath := mfst.GetSkillPath(ev.Skill)
+	_, tracked := mfst.GetInstalled(ev.Skill)
+
+	switch ev.Kind {
+	case watch.KindAdded:
+		if tracked {
+			fmt.Println(i18n.Tf("%s %s reappeared", stamp, ev.Skill))
+			return
+		}
+		if _, err := os.Stat(filepath.Join(skillPath, "SKILL.md")); err != nil {
+			return // not a skill (yet)
+		}
+		fmt.Println(i18n.Tf("%s new untracked skill %s (not managed by lazyas)", stamp, ev.Skill))
+
+	case watch.KindRemoved:
+		if !tracked {
+			fmt.Println(i18n.Tf("%s untracked skill %s removed", stamp, ev.Skill))
+			return
+		}
+		if _, err := os.Lstat(skillPath); err == nil {
+			return // replaced in place, e.g. by a reinstall
+		}
+		if !watchPrune {
+			fmt.Println(i18n.Tf("%s tracked skill %s was deleted (run 'lazyas install %s' to restore)", stamp, ev.Skill, ev.Skill))
+			return
+		}
+		if err := mfst.RemoveSkill(ev.Skill); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Tf("warning: failed to update manifest: %v", err))
+			return
+		}
+		fmt.Println(i18n.Tf("%s tracked skill %s was deleted; removed from manifest", stamp, ev.Skill))
+
+	case watch.KindChanged:
+		fmt.Println(i18n.Tf("%s %s changed: %s", stamp, ev.Skill, strings.Join(ev.Files, ", ")))
+		if !tracked {
+			return
+		}
+		if modified, _ := git.GetModifiedFiles(skillPath); len(modified) > 0 {
+			fmt.Println(i18n.Tf("  %d file(s) now differ from the installed version", len(modified)))
+		} else {
+			fmt.Println(i18n.T("  matches the installed version again"))
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -13,20 +15,50 @@ const (
 	ConfigFileName       = "config.toml"
 	ManifestFileName     = "manifest.yaml"
 	CacheFileName        = "cache.yaml"
+	LocalesDirName       = "locales"
+	PreviewsDirName      = "previews"
+	PatchesDirName       = "patches"
+	SkillPolicyFileName  = "skill-policy.toml"
+	HistoryFileName      = "update-history.yaml"
+	UpstreamDirName      = "upstream"
+	DigestFileName       = "digest.yaml"
+	TrashDirName         = "trash"
+	PublishDirName       = "publish"
+	UsageFileName        = "usage.yaml"
+	LogsDirName          = "logs"
+	SkillsDirName        = "skills"
+	ReposDirName         = "repos"
+
+	// HomeEnv relocates the whole lazyas directory (default ~/.lazyas)
+	HomeEnv = "LAZYAS_HOME"
 )
 
 // Repo represents an upstream skills repository
 type Repo struct {
-	Name string `toml:"name"`
-	URL  string `toml:"url"`
+	Name      string `toml:"name"`
+	URL       string `toml:"url"`
+	Priority  int    `toml:"priority,omitzero"`                               // Higher wins when repos share a skill name; ties go to config order
+	PublicKey string `toml:"public_key,omitempty" yaml:"public_key"`          // base64 ed25519 key index.yaml must be signed with (index.yaml.sig)
+	CacheTTL  int    `toml:"cache_ttl_hours,omitzero" yaml:"cache_ttl_hours"` // Hours its cached skill list is reused; 0 = cache_ttl_hours
+	Team      bool   `toml:"-" yaml:"-"`                                      // Runtime: provided by the team config
 }
 
 // Backend represents a target AI agent backend
 type Backend struct {
 	Name        string `toml:"name"`
-	Path        string `toml:"path"`        // Expected symlink location (e.g., ~/.claude/skills)
-	Description string `toml:"description"` // Human-readable name
-	Linked      bool   `toml:"-"`           // Runtime: is symlink active?
+	Path        string `toml:"path"`           // Expected symlink location (e.g., ~/.claude/skills)
+	Description string `toml:"description"`    // Human-readable name
+	Mode        string `toml:"mode,omitempty"` // "" links the whole skills directory; BackendPerSkill links enabled skills one by one
+	Linked      bool   `toml:"-"`              // Runtime: is symlink active?
+}
+
+// BackendPerSkill is the backend mode where the backend directory holds
+// one lazyas-managed symlink per enabled skill
+const BackendPerSkill = "per-skill"
+
+// PerSkill reports whether the backend links skills one by one
+func (b Backend) PerSkill() bool {
+	return b.Mode == BackendPerSkill
 }
 
 // StarterKitRepos are popular skill repositories offered on first run
@@ -90,13 +122,34 @@ func (s *TOMLStore) Load() (*ConfigFile, error) {
 
 // ConfigFile represents the TOML config file structure
 type ConfigFile struct {
-	Repos               []Repo    `toml:"repos"`
-	CacheTTL            int       `toml:"cache_ttl_hours,omitempty"`
-	Viewer              string    `toml:"viewer,omitempty"`
-	Backends            []Backend `toml:"backends,omitempty"`
-	DismissedBackends   []string  `toml:"dismissed_backends,omitempty"`
-	StarterKitDismissed bool      `toml:"starter_kit_dismissed,omitempty"`
-	CollapsedGroups     []string  `toml:"collapsed_groups,omitempty"`
+	Repos               []Repo            `toml:"repos"`
+	SkillsDir           string            `toml:"skills_dir,omitempty"`
+	ReposDir            string            `toml:"repos_dir,omitempty"`
+	CacheTTL            int               `toml:"cache_ttl_hours,omitempty"`
+	RefreshInterval     int               `toml:"refresh_interval_minutes,omitempty"`
+	Viewer              string            `toml:"viewer,omitempty"`
+	Locale              string            `toml:"locale,omitempty"`
+	UpdatePolicy        string            `toml:"update_policy,omitempty"`
+	RiskPolicy          string            `toml:"risk_policy,omitempty"`
+	SkillPolicyFile     string            `toml:"skill_policy_file,omitempty"`
+	VerifySignatures    bool              `toml:"verify_signatures,omitempty"`
+	Notifications       bool              `toml:"notifications,omitempty"`
+	QuietHours          string            `toml:"quiet_hours,omitempty"`
+	TrashRetention      int               `toml:"trash_retention_days,omitempty"`
+	MaxGitJobs          int               `toml:"max_git_jobs,omitempty"`
+	GitBandwidthLimit   int               `toml:"git_bandwidth_limit_kb,omitempty"`
+	DisableUsageStats   bool              `toml:"disable_usage_stats,omitempty"`
+	TemplatesRepo       string            `toml:"templates_repo,omitempty"`
+	TeamConfigURL       string            `toml:"team_config_url,omitempty"`
+	TeamConfigRefresh   int               `toml:"team_config_refresh_hours,omitempty"`
+	Backends            []Backend         `toml:"backends,omitempty"`
+	DismissedBackends   []string          `toml:"dismissed_backends,omitempty"`
+	StarterKitDismissed bool              `toml:"starter_kit_dismissed,omitempty"`
+	CollapsedGroups     []string          `toml:"collapsed_groups,omitempty"`
+	SkillRepos          map[string]string `toml:"skill_repos,omitempty"`
+	StatusIcons         map[string]string `toml:"status_icons,omitempty"`
+	StatusPrecedence    []string          `toml:"status_precedence,omitempty"`
+	Theme               map[string]string `toml:"theme,omitempty"`
 }
 
 // Config holds the runtime configuration
@@ -106,15 +159,52 @@ type Config struct {
 	ConfigPath          string
 	ManifestPath        string
 	CachePath           string
-	SkillsDir           string // Always ~/.lazyas/skills/ - the central skills directory
-	ReposDir            string // Always ~/.lazyas/repos/ - per-repo sparse clones
+	SkillsDir           string // ~/.lazyas/skills/ unless skills_dir is set - the central skills directory
+	ReposDir            string // ~/.lazyas/repos/ unless repos_dir is set - per-repo sparse clones
+	LocalesDir          string // ~/.lazyas/locales/ - community message catalogs (<lang>.toml)
+	PreviewCacheDir     string // ~/.lazyas/previews/ - SKILL.md previews of not-installed skills
+	PatchesDir          string // ~/.lazyas/patches/ - saved local modifications (<skill>/<name>.patch)
+	HistoryPath         string // ~/.lazyas/update-history.yaml - results of recent update runs
+	UpstreamDir         string // ~/.lazyas/upstream/ - commit-only mirrors for provenance checks
+	DigestPath          string // ~/.lazyas/digest.yaml - index snapshot from the last digest
+	TrashDir            string // ~/.lazyas/trash/ - removed and overwritten skills, kept for restore
+	PublishDir          string // ~/.lazyas/publish/ - working clones of repos skills are published to
+	UsagePath           string // ~/.lazyas/usage.yaml - local usage counters, never transmitted
+	LogsDir             string // ~/.lazyas/logs/ - crash logs of the TUI
 	Repos               []Repo
 	CacheTTL            int
-	Viewer              string    // Command to view SKILL.md (e.g. "glow -t"); empty = auto-detect
-	Backends            []Backend // Configured backends (symlink targets)
-	DismissedBackends   []string  // Backend names dismissed from auto-show
-	StarterKitDismissed bool      // Whether starter kit modal was dismissed
-	CollapsedGroups     []string  // Group names that are collapsed in the TUI
+	RefreshInterval     int               // TUI background refresh in minutes; 0 = every CacheTTL, negative = off
+	Viewer              string            // Command to view SKILL.md (e.g. "glow -t"); empty = auto-detect
+	Locale              string            // UI language (e.g. "de"); empty = detect from LANG
+	UpdatePolicy        string            // Default semver policy: patch, minor or major; empty = major
+	RiskPolicy          string            // Install-time scan policy: allow, block-high or block-medium; empty = allow
+	SkillPolicyFile     string            // Allow/deny rules for installable skills; empty = ~/.lazyas/skill-policy.toml
+	VerifySignatures    bool              // Verify installed commits' signatures and show who signed them
+	Notifications       bool              // Desktop notification when background checks find updates
+	QuietHours          string            // No notifications in this daily window (e.g. "22:00-07:00")
+	TrashRetention      int               // Days trashed skills are kept; 0 = 30, negative = until emptied
+	MaxGitJobs          int               // Repositories updated at once by bulk updates; 0 = 4
+	GitBandwidthLimit   int               // KB/s cap on git's HTTP(S) transfers; 0 = unlimited
+	DisableUsageStats   bool              // Stop counting installs, updates and actions in UsagePath
+	TemplatesRepo       string            // Repository of skill skeletons for 'lazyas new --template'
+	Backends            []Backend         // Configured backends (symlink targets)
+	DismissedBackends   []string          // Backend names dismissed from auto-show
+	StarterKitDismissed bool              // Whether starter kit modal was dismissed
+	CollapsedGroups     []string          // Group names that are collapsed in the TUI
+	SkillRepos          map[string]string // Skill name -> repo that provides it, overriding priority
+	StatusIcons         map[string]string // Skill state -> icon in the TUI list, overriding the defaults
+	StatusPrecedence    []string          // Which state an installed skill shows when several apply, first wins
+	Theme               map[string]string // TUI colors: a preset ("preset" key) and colors overriding it
+	TeamConfigURL       string            // Remote team config (TOML or YAML) merged at load time
+	TeamConfigRefresh   int               // Hours a fetched team config is reused; 0 = CacheTTL
+	TeamFetchedAt       time.Time         // When the merged team config was fetched
+	TeamConfigErr       error             // Last team config fetch or parse error
+	RequiredSkills      []string          // Skills the team config asks every developer to install
+
+	skillsDirSetting string // skills_dir as written, kept so Save does not expand it
+	reposDirSetting  string // repos_dir as written
+	teamUpdatePolicy bool   // UpdatePolicy came from the team config
+	teamRiskPolicy   bool   // RiskPolicy came from the team config
 }
 
 // xdgConfigHome returns $XDG_CONFIG_HOME, falling back to ~/.config per spec.
@@ -151,34 +241,21 @@ func ExpandPath(path string) (string, error) {
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() (*Config, error) {
-	home, err := os.UserHomeDir()
+	configDir, err := HomeDir()
 	if err != nil {
 		return nil, err
 	}
 
-	// Central lazyas directory is ~/.lazyas/
-	configDir := filepath.Join(home, ".lazyas")
-	skillsDir := filepath.Join(configDir, "skills")
-	reposDir := filepath.Join(configDir, "repos")
-
 	// Initialize default backends from KnownBackends
 	backends := make([]Backend, len(KnownBackends))
 	copy(backends, KnownBackends)
 
-	configPath := filepath.Join(configDir, ConfigFileName)
-
 	cfg := &Config{
-		Store:        &TOMLStore{Path: configPath},
-		ConfigDir:    configDir,
-		ConfigPath:   configPath,
-		ManifestPath: filepath.Join(configDir, ManifestFileName),
-		CachePath:    filepath.Join(configDir, CacheFileName),
-		SkillsDir:    skillsDir,
-		ReposDir:     reposDir,
-		CacheTTL:     DefaultCacheTTLHours,
-		Repos:        []Repo{},
-		Backends:     backends,
+		CacheTTL: DefaultCacheTTLHours,
+		Repos:    []Repo{},
+		Backends: backends,
 	}
+	cfg.SetHome(configDir)
 
 	// Try to load existing config
 	if err := cfg.Load(); err != nil && !os.IsNotExist(err) {
@@ -188,6 +265,139 @@ func DefaultConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// HomeDir returns the central lazyas directory: $LAZYAS_HOME, or ~/.lazyas
+func HomeDir() (string, error) {
+	if v := os.Getenv(HomeEnv); v != "" {
+		dir, err := ExpandPath(v)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Abs(dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".lazyas"), nil
+}
+
+// SetHome points every path under the central lazyas directory at dir.
+// Skills and repos directories set with skills_dir and repos_dir stay put.
+func (c *Config) SetHome(dir string) {
+	if c.SkillsDir == "" || c.SkillsDir == filepath.Join(c.ConfigDir, SkillsDirName) {
+		c.SkillsDir = filepath.Join(dir, SkillsDirName)
+	}
+	if c.ReposDir == "" || c.ReposDir == filepath.Join(c.ConfigDir, ReposDirName) {
+		c.ReposDir = filepath.Join(dir, ReposDirName)
+	}
+
+	c.ConfigDir = dir
+	c.ConfigPath = filepath.Join(dir, ConfigFileName)
+	c.Store = &TOMLStore{Path: c.ConfigPath}
+	c.ManifestPath = filepath.Join(dir, ManifestFileName)
+	c.CachePath = filepath.Join(dir, CacheFileName)
+	c.LocalesDir = filepath.Join(dir, LocalesDirName)
+	c.PreviewCacheDir = filepath.Join(dir, PreviewsDirName)
+	c.PatchesDir = filepath.Join(dir, PatchesDirName)
+	c.HistoryPath = filepath.Join(dir, HistoryFileName)
+	c.UpstreamDir = filepath.Join(dir, UpstreamDirName)
+	c.DigestPath = filepath.Join(dir, DigestFileName)
+	c.TrashDir = filepath.Join(dir, TrashDirName)
+	c.PublishDir = filepath.Join(dir, PublishDirName)
+	c.UsagePath = filepath.Join(dir, UsageFileName)
+	c.LogsDir = filepath.Join(dir, LogsDirName)
+}
+
+// storeDir resolves a skills_dir or repos_dir setting. Relative paths are
+// taken relative to the central lazyas directory.
+func (c *Config) storeDir(setting, name string) (string, error) {
+	if setting == "" {
+		return filepath.Join(c.ConfigDir, name), nil
+	}
+	dir, err := ExpandPath(setting)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(c.ConfigDir, dir)
+	}
+	return filepath.Clean(dir), nil
+}
+
+// storeSetting is the skills_dir or repos_dir value saved for dir: the
+// setting as loaded while it still points there, empty for the default
+// location, otherwise dir itself
+func (c *Config) storeSetting(dir, setting, name string) string {
+	if setting != "" {
+		if resolved, err := c.storeDir(setting, name); err == nil && resolved == dir {
+			return setting
+		}
+	}
+	if dir == filepath.Join(c.ConfigDir, name) {
+		return ""
+	}
+	return dir
+}
+
+// RepoCacheTTL returns how long the cached skill list of repo is reused
+// before it is fetched again: its own cache_ttl_hours, else the global one
+func (c *Config) RepoCacheTTL(repo Repo) time.Duration {
+	if repo.CacheTTL > 0 {
+		return time.Duration(repo.CacheTTL) * time.Hour
+	}
+	return time.Duration(c.CacheTTL) * time.Hour
+}
+
+// BackgroundRefresh returns how often the TUI refreshes the index while it
+// runs, or 0 when background refresh is disabled
+func (c *Config) BackgroundRefresh() time.Duration {
+	switch {
+	case c.RefreshInterval < 0:
+		return 0
+	case c.RefreshInterval > 0:
+		return time.Duration(c.RefreshInterval) * time.Minute
+	default:
+		return time.Duration(c.CacheTTL) * time.Hour
+	}
+}
+
+// TrashRetentionPeriod returns how long trashed skills are kept, or 0 when
+// they are kept until the trash is emptied
+func (c *Config) TrashRetentionPeriod() time.Duration {
+	switch {
+	case c.TrashRetention < 0:
+		return 0
+	case c.TrashRetention > 0:
+		return time.Duration(c.TrashRetention) * 24 * time.Hour
+	default:
+		return 30 * 24 * time.Hour
+	}
+}
+
+// GitJobs returns how many repositories bulk updates work on at once
+func (c *Config) GitJobs() int {
+	if c.MaxGitJobs > 0 {
+		return c.MaxGitJobs
+	}
+	return 4
+}
+
+// GitBandwidth returns the cap on git's transfers in bytes per second, or
+// 0 when they are not limited
+func (c *Config) GitBandwidth() int64 {
+	return int64(max(c.GitBandwidthLimit, 0)) * 1024
+}
+
+// SkillPolicyPath returns the policy file restricting installable skills
+func (c *Config) SkillPolicyPath() string {
+	if c.SkillPolicyFile != "" {
+		if p, err := ExpandPath(c.SkillPolicyFile); err == nil {
+			return p
+		}
+	}
+	return filepath.Join(c.ConfigDir, SkillPolicyFileName)
+}
+
 // Load reads the config via the configured store
 func (c *Config) Load() error {
 	cf, err := c.Store.Load()
@@ -198,6 +408,13 @@ func (c *Config) Load() error {
 	if len(cf.Repos) > 0 {
 		c.Repos = cf.Repos
 	}
+	c.skillsDirSetting, c.reposDirSetting = cf.SkillsDir, cf.ReposDir
+	if c.SkillsDir, err = c.storeDir(cf.SkillsDir, SkillsDirName); err != nil {
+		return fmt.Errorf("invalid skills_dir: %w", err)
+	}
+	if c.ReposDir, err = c.storeDir(cf.ReposDir, ReposDirName); err != nil {
+		return fmt.Errorf("invalid repos_dir: %w", err)
+	}
 	if cf.CacheTTL > 0 {
 		c.CacheTTL = cf.CacheTTL
 	}
@@ -206,11 +423,31 @@ func (c *Config) Load() error {
 		c.Backends = mergeBackends(KnownBackends, cf.Backends)
 	}
 
+	c.RefreshInterval = cf.RefreshInterval
 	c.Viewer = cf.Viewer
+	c.Locale = cf.Locale
+	c.UpdatePolicy = cf.UpdatePolicy
+	c.RiskPolicy = cf.RiskPolicy
+	c.SkillPolicyFile = cf.SkillPolicyFile
+	c.VerifySignatures = cf.VerifySignatures
+	c.Notifications = cf.Notifications
+	c.QuietHours = cf.QuietHours
+	c.TrashRetention = cf.TrashRetention
+	c.MaxGitJobs = cf.MaxGitJobs
+	c.GitBandwidthLimit = cf.GitBandwidthLimit
+	c.DisableUsageStats = cf.DisableUsageStats
+	c.TemplatesRepo = cf.TemplatesRepo
 	c.DismissedBackends = cf.DismissedBackends
 	c.StarterKitDismissed = cf.StarterKitDismissed
 	c.CollapsedGroups = cf.CollapsedGroups
-
+	c.SkillRepos = cf.SkillRepos
+	c.StatusIcons = cf.StatusIcons
+	c.StatusPrecedence = cf.StatusPrecedence
+	c.Theme = cf.Theme
+	c.TeamConfigURL = cf.TeamConfigURL
+	c.TeamConfigRefresh = cf.TeamConfigRefresh
+
+	c.LoadTeamConfig()
 	return nil
 }
 
@@ -242,12 +479,45 @@ func (c *Config) Save() error {
 	}
 
 	cf := ConfigFile{
-		Repos:               c.Repos,
+		SkillsDir:           c.storeSetting(c.SkillsDir, c.skillsDirSetting, SkillsDirName),
+		ReposDir:            c.storeSetting(c.ReposDir, c.reposDirSetting, ReposDirName),
 		CacheTTL:            c.CacheTTL,
+		RefreshInterval:     c.RefreshInterval,
 		Viewer:              c.Viewer,
+		Locale:              c.Locale,
+		UpdatePolicy:        c.UpdatePolicy,
+		RiskPolicy:          c.RiskPolicy,
+		SkillPolicyFile:     c.SkillPolicyFile,
+		VerifySignatures:    c.VerifySignatures,
+		Notifications:       c.Notifications,
+		QuietHours:          c.QuietHours,
+		TrashRetention:      c.TrashRetention,
+		MaxGitJobs:          c.MaxGitJobs,
+		GitBandwidthLimit:   c.GitBandwidthLimit,
+		DisableUsageStats:   c.DisableUsageStats,
+		TemplatesRepo:       c.TemplatesRepo,
+		TeamConfigURL:       c.TeamConfigURL,
+		TeamConfigRefresh:   c.TeamConfigRefresh,
 		DismissedBackends:   c.DismissedBackends,
 		StarterKitDismissed: c.StarterKitDismissed,
 		CollapsedGroups:     c.CollapsedGroups,
+		SkillRepos:          c.SkillRepos,
+		StatusIcons:         c.StatusIcons,
+		StatusPrecedence:    c.StatusPrecedence,
+		Theme:               c.Theme,
+	}
+
+	// Team-provided values are not written back to the local file
+	for _, r := range c.Repos {
+		if !r.Team {
+			cf.Repos = append(cf.Repos, r)
+		}
+	}
+	if c.teamUpdatePolicy {
+		cf.UpdatePolicy = ""
+	}
+	if c.teamRiskPolicy {
+		cf.RiskPolicy = ""
 	}
 
 	// Only save backends that differ from known backends or are custom
@@ -272,7 +542,7 @@ func filterCustomBackends(backends []Backend) []Backend {
 		if !isKnown {
 			// Custom backend
 			custom = append(custom, b)
-		} else if b.Path != known.Path || b.Description != known.Description {
+		} else if b.Path != known.Path || b.Description != known.Description || b.Mode != known.Mode {
 			// Modified known backend
 			custom = append(custom, b)
 		}
@@ -297,6 +567,7 @@ func (c *Config) AddRepo(name, url string) error {
 	for i, r := range c.Repos {
 		if r.Name == name {
 			c.Repos[i].URL = url
+			c.Repos[i].Team = false
 			return c.Save()
 		}
 	}
@@ -304,10 +575,14 @@ func (c *Config) AddRepo(name, url string) error {
 	return c.Save()
 }
 
-// RemoveRepo removes a repository from the config
+// RemoveRepo removes a repository from the config. Repositories from the
+// team config cannot be removed locally.
 func (c *Config) RemoveRepo(name string) error {
 	for i, r := range c.Repos {
 		if r.Name == name {
+			if r.Team {
+				return fmt.Errorf("repository %s is managed by the team config", name)
+			}
 			c.Repos = append(c.Repos[:i], c.Repos[i+1:]...)
 			return c.Save()
 		}
@@ -315,6 +590,74 @@ func (c *Config) RemoveRepo(name string) error {
 	return nil
 }
 
+// RenameRepo changes a repository's name, keeping its URL
+func (c *Config) RenameRepo(oldName, newName string) error {
+	repo, err := c.localRepo(oldName)
+	if err != nil {
+		return err
+	}
+	if c.GetRepo(newName) != nil {
+		return fmt.Errorf("repository %s already exists", newName)
+	}
+	repo.Name = newName
+	for skill, pinned := range c.SkillRepos {
+		if pinned == oldName {
+			c.SkillRepos[skill] = newName
+		}
+	}
+	return c.Save()
+}
+
+// SetRepoURL points a repository at a new URL, keeping its name
+func (c *Config) SetRepoURL(name, url string) error {
+	repo, err := c.localRepo(name)
+	if err != nil {
+		return err
+	}
+	repo.URL = url
+	return c.Save()
+}
+
+// SetRepoPriority sets the priority of a repository. When several repos
+// provide a skill with the same name, the highest priority one is used.
+func (c *Config) SetRepoPriority(name string, priority int) error {
+	repo, err := c.localRepo(name)
+	if err != nil {
+		return err
+	}
+	repo.Priority = priority
+	return c.Save()
+}
+
+// PinSkill makes the named repository provide skill regardless of repo
+// priority. An empty repo removes the pin.
+func (c *Config) PinSkill(skill, repo string) error {
+	if repo == "" {
+		delete(c.SkillRepos, skill)
+		return c.Save()
+	}
+	if c.GetRepo(repo) == nil {
+		return fmt.Errorf("repository %s not found", repo)
+	}
+	if c.SkillRepos == nil {
+		c.SkillRepos = make(map[string]string)
+	}
+	c.SkillRepos[skill] = repo
+	return c.Save()
+}
+
+// localRepo returns a repository that may be edited locally
+func (c *Config) localRepo(name string) (*Repo, error) {
+	repo := c.GetRepo(name)
+	if repo == nil {
+		return nil, fmt.Errorf("repository %s not found", name)
+	}
+	if repo.Team {
+		return nil, fmt.Errorf("repository %s is managed by the team config", name)
+	}
+	return repo, nil
+}
+
 // GetBackend returns a backend by name
 func (c *Config) GetBackend(name string) *Backend {
 	for i := range c.Backends {
@@ -357,6 +700,28 @@ func (c *Config) AddBackend(name, path, description string) error {
 	return c.Save()
 }
 
+// SetBackendMode switches how a backend is linked and saves the config
+func (c *Config) SetBackendMode(name, mode string) error {
+	backend := c.GetBackend(name)
+	if backend == nil {
+		return fmt.Errorf("backend '%s' not found", name)
+	}
+	backend.Mode = mode
+	return c.Save()
+}
+
+// SetUpdatePolicy sets the update policy in the local config, where it
+// takes precedence over the team config's
+func (c *Config) SetUpdatePolicy(policy string) {
+	c.UpdatePolicy, c.teamUpdatePolicy = policy, false
+}
+
+// SetRiskPolicy sets the risk policy in the local config, where it takes
+// precedence over the team config's
+func (c *Config) SetRiskPolicy(policy string) {
+	c.RiskPolicy, c.teamRiskPolicy = policy, false
+}
+
 // DismissBackend adds a backend name to the dismissed list
 func (c *Config) DismissBackend(name string) {
 	for _, d := range c.DismissedBackends {
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRenameRepoAndSetURL(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Repos = []Repo{
+		{Name: "official", URL: "https://example.com/old"},
+		{Name: "other", URL: "https://example.com/other"},
+		{Name: "team", URL: "https://example.com/team", Team: true},
+	}
+
+	if err := cfg.RenameRepo("official", "other"); err == nil {
+		t.Error("expected rename onto an existing name to fail")
+	}
+	if err := cfg.RenameRepo("team", "mine"); err == nil {
+		t.Error("expected team repo rename to fail")
+	}
+	if err := cfg.RenameRepo("official", "upstream"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetRepoURL("upstream", "https://example.com/new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetRepoURL("missing", "https://example.com/x"); err == nil {
+		t.Error("expected unknown repo to fail")
+	}
+
+	cf, err := cfg.Store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Repos) != 2 || cf.Repos[0].Name != "upstream" || cf.Repos[0].URL != "https://example.com/new" {
+		t.Errorf("unexpected saved repos: %+v", cf.Repos)
+	}
+}
+
+func TestSetBackendMode_SavesBuiltinBackend(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Backends = append([]Backend(nil), KnownBackends...)
+
+	if err := cfg.SetBackendMode("claude", BackendPerSkill); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetBackendMode("missing", BackendPerSkill); err == nil {
+		t.Error("expected unknown backend to fail")
+	}
+
+	cf, err := cfg.Store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Backends) != 1 || cf.Backends[0].Name != "claude" || !cf.Backends[0].PerSkill() {
+		t.Errorf("unexpected saved backends: %+v", cf.Backends)
+	}
+}
+
+func TestStoreDirs_LoadAndSave(t *testing.T) {
+	cfg := testConfig(t)
+	t.Setenv("HOME", cfg.ConfigDir)
+	cf := &ConfigFile{SkillsDir: "~/dotfiles/skills", ReposDir: "cache/repos"}
+	if err := cfg.Store.Save(cf); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(cfg.ConfigDir, "dotfiles", "skills"); cfg.SkillsDir != want {
+		t.Errorf("SkillsDir = %s, want %s", cfg.SkillsDir, want)
+	}
+	if want := filepath.Join(cfg.ConfigDir, "cache", "repos"); cfg.ReposDir != want {
+		t.Errorf("ReposDir = %s, want %s", cfg.ReposDir, want)
+	}
+
+	// Settings are written back as given, and dropped at the default
+	cfg.ReposDir = filepath.Join(cfg.ConfigDir, ReposDirName)
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := cfg.Store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.SkillsDir != "~/dotfiles/skills" || saved.ReposDir != "" {
+		t.Errorf("saved skills_dir %q, repos_dir %q", saved.SkillsDir, saved.ReposDir)
+	}
+}
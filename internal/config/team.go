@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// TeamCacheFileName caches the last fetched team config
+const TeamCacheFileName = "team-config.yaml"
+
+// maxTeamConfigSize caps the downloaded team config
+const maxTeamConfigSize = 1 << 20
+
+var teamHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// TeamConfig is the remote configuration published by a platform team.
+// It is merged into the local config: team repos are added next to the
+// user's own, and team policies apply where the local config sets none.
+type TeamConfig struct {
+	Repos          []Repo   `toml:"repos" yaml:"repos"`
+	RequiredSkills []string `toml:"required_skills" yaml:"required_skills"`
+	UpdatePolicy   string   `toml:"update_policy" yaml:"update_policy"`
+	RiskPolicy     string   `toml:"risk_policy" yaml:"risk_policy"`
+}
+
+// teamCache is the on-disk copy of the team config, kept so lazyas works
+// offline and only refetches once the refresh interval has passed
+type teamCache struct {
+	URL       string    `yaml:"url"`
+	FetchedAt time.Time `yaml:"fetched_at"`
+	Data      string    `yaml:"data"`
+}
+
+// ParseTeamConfig decodes a team config. Sources ending in .yaml or .yml
+// are read as YAML, everything else as TOML.
+func ParseTeamConfig(source string, data []byte) (*TeamConfig, error) {
+	var tc TeamConfig
+	switch strings.ToLower(path.Ext(strings.SplitN(source, "?", 2)[0])) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &tc); err != nil {
+			return nil, fmt.Errorf("invalid team config: %w", err)
+		}
+	default:
+		if _, err := toml.Decode(string(data), &tc); err != nil {
+			return nil, fmt.Errorf("invalid team config: %w", err)
+		}
+	}
+	for _, r := range tc.Repos {
+		if r.Name == "" || r.URL == "" {
+			return nil, fmt.Errorf("invalid team config: every repo needs name and url")
+		}
+	}
+	return &tc, nil
+}
+
+// TeamRefresh returns how long a fetched team config is reused
+func (c *Config) TeamRefresh() time.Duration {
+	if c.TeamConfigRefresh > 0 {
+		return time.Duration(c.TeamConfigRefresh) * time.Hour
+	}
+	return time.Duration(c.CacheTTL) * time.Hour
+}
+
+// LoadTeamConfig merges the cached copy of the team config into c. It
+// never fetches, so loading the config does not wait on the network; see
+// RefreshTeamConfig.
+func (c *Config) LoadTeamConfig() {
+	c.TeamConfigErr = nil
+	if c.TeamConfigURL == "" {
+		return
+	}
+	cache, _ := c.readTeamCache()
+	if cache == nil {
+		return
+	}
+
+	tc, err := ParseTeamConfig(cache.URL, []byte(cache.Data))
+	if err != nil {
+		c.TeamConfigErr = err
+		return
+	}
+	c.TeamFetchedAt = cache.FetchedAt
+	c.mergeTeam(tc)
+}
+
+// RefreshTeamConfig fetches the team config when the cached copy is
+// missing, stale or force is set, caches it and merges it into c. When the
+// fetch fails the cached copy is used and the error is kept in
+// TeamConfigErr.
+func (c *Config) RefreshTeamConfig(force bool) {
+	if c.TeamConfigURL == "" {
+		return
+	}
+
+	var fetchErr error
+	cache, _ := c.readTeamCache()
+	if force || cache == nil || time.Since(cache.FetchedAt) > c.TeamRefresh() {
+		data, err := fetchTeamConfig(c.TeamConfigURL)
+		if err == nil {
+			if _, err = ParseTeamConfig(c.TeamConfigURL, data); err == nil {
+				c.writeTeamCache(&teamCache{URL: c.TeamConfigURL, FetchedAt: time.Now(), Data: string(data)})
+			}
+		}
+		if err != nil {
+			fetchErr = fmt.Errorf("team config %s: %w", c.TeamConfigURL, err)
+		}
+	}
+
+	c.LoadTeamConfig()
+	if fetchErr != nil {
+		c.TeamConfigErr = fetchErr
+	}
+}
+
+// mergeTeam adds team repos whose name is not configured locally and fills
+// in policies the local config leaves unset, replacing an earlier merge
+func (c *Config) mergeTeam(tc *TeamConfig) {
+	local := c.Repos[:0]
+	for _, r := range c.Repos {
+		if !r.Team {
+			local = append(local, r)
+		}
+	}
+	c.Repos = local
+	if c.teamUpdatePolicy {
+		c.UpdatePolicy, c.teamUpdatePolicy = "", false
+	}
+	if c.teamRiskPolicy {
+		c.RiskPolicy, c.teamRiskPolicy = "", false
+	}
+
+	for _, r := range tc.Repos {
+		if c.GetRepo(r.Name) != nil {
+			continue
+		}
+		r.Team = true
+		c.Repos = append(c.Repos, r)
+	}
+	c.RequiredSkills = tc.RequiredSkills
+	if c.UpdatePolicy == "" && tc.UpdatePolicy != "" {
+		c.UpdatePolicy = tc.UpdatePolicy
+		c.teamUpdatePolicy = true
+	}
+	if c.RiskPolicy == "" && tc.RiskPolicy != "" {
+		c.RiskPolicy = tc.RiskPolicy
+		c.teamRiskPolicy = true
+	}
+}
+
+func (c *Config) teamCachePath() string {
+	return filepath.Join(c.ConfigDir, TeamCacheFileName)
+}
+
+// readTeamCache returns the cached team config for the configured URL
+func (c *Config) readTeamCache() (*teamCache, error) {
+	data, err := os.ReadFile(c.teamCachePath())
+	if err != nil {
+		return nil, err
+	}
+	var tc teamCache
+	if err := yaml.Unmarshal(data, &tc); err != nil {
+		return nil, err
+	}
+	if tc.URL != c.TeamConfigURL {
+		return nil, nil
+	}
+	return &tc, nil
+}
+
+func (c *Config) writeTeamCache(tc *teamCache) error {
+	if err := os.MkdirAll(c.ConfigDir, 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(tc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.teamCachePath(), data, 0644)
+}
+
+// fetchTeamConfig downloads the team config over HTTP(S), or reads it from
+// disk for file:// URLs and plain paths
+func fetchTeamConfig(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		p, err := ExpandPath(strings.TrimPrefix(source, "file://"))
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(p)
+	}
+
+	resp, err := teamHTTPClient.Get(source)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", source, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTeamConfigSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(data) > maxTeamConfigSize {
+		return nil, fmt.Errorf("team config exceeds %d bytes", maxTeamConfigSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testConfig(t *testing.T) *Config {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ConfigFileName)
+	return &Config{
+		Store:      &TOMLStore{Path: path},
+		ConfigDir:  dir,
+		ConfigPath: path,
+		SkillsDir:  filepath.Join(dir, "skills"),
+		ReposDir:   filepath.Join(dir, "repos"),
+		CacheTTL:   DefaultCacheTTLHours,
+	}
+}
+
+func TestParseTeamConfig_Formats(t *testing.T) {
+	toml := []byte("required_skills = [\"pdf\"]\n[[repos]]\nname = \"team\"\nurl = \"https://example.com/team\"\n")
+	tc, err := ParseTeamConfig("https://example.com/team.toml", toml)
+	if err != nil || len(tc.Repos) != 1 || tc.RequiredSkills[0] != "pdf" {
+		t.Fatalf("toml: %+v, %v", tc, err)
+	}
+
+	yml := []byte("repos:\n  - name: team\n    url: https://example.com/team\nrisk_policy: block-high\n")
+	tc, err = ParseTeamConfig("https://example.com/team.yaml?token=x", yml)
+	if err != nil || len(tc.Repos) != 1 || tc.RiskPolicy != "block-high" {
+		t.Fatalf("yaml: %+v, %v", tc, err)
+	}
+
+	if _, err := ParseTeamConfig("team.toml", []byte("[[repos]]\nname = \"x\"\n")); err == nil {
+		t.Error("expected error for repo without url")
+	}
+}
+
+func TestLoadTeamConfig_MergesWithoutSavingTeamValues(t *testing.T) {
+	cfg := testConfig(t)
+	teamPath := filepath.Join(t.TempDir(), "team.toml")
+	os.WriteFile(teamPath, []byte(`update_policy = "minor"
+risk_policy = "block-high"
+[[repos]]
+name = "mine"
+url = "https://example.com/ignored"
+[[repos]]
+name = "team"
+url = "https://example.com/team"
+`), 0644)
+
+	cfg.Repos = []Repo{{Name: "mine", URL: "https://example.com/mine"}}
+	cfg.RiskPolicy = "allow"
+	cfg.TeamConfigURL = teamPath
+
+	// Loading only merges the cache, which does not exist yet
+	cfg.LoadTeamConfig()
+	if len(cfg.Repos) != 1 || cfg.UpdatePolicy != "" {
+		t.Fatalf("team config fetched on load: %+v", cfg.Repos)
+	}
+	cfg.RefreshTeamConfig(false)
+
+	if cfg.TeamConfigErr != nil {
+		t.Fatal(cfg.TeamConfigErr)
+	}
+	if len(cfg.Repos) != 2 || cfg.Repos[0].URL != "https://example.com/mine" || !cfg.Repos[1].Team {
+		t.Fatalf("unexpected repos: %+v", cfg.Repos)
+	}
+	if cfg.UpdatePolicy != "minor" || cfg.RiskPolicy != "allow" {
+		t.Errorf("policies = %q, %q", cfg.UpdatePolicy, cfg.RiskPolicy)
+	}
+	if err := cfg.RemoveRepo("team"); err == nil {
+		t.Error("expected team repo removal to fail")
+	}
+
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	cf, err := cfg.Store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cf.Repos) != 1 || cf.UpdatePolicy != "" || cf.TeamConfigURL != teamPath {
+		t.Errorf("team values leaked into local config: %+v", cf)
+	}
+
+	// The cached copy is used when the source becomes unavailable
+	os.Remove(teamPath)
+	cfg.LoadTeamConfig()
+	if cfg.TeamConfigErr != nil || len(cfg.Repos) != 2 {
+		t.Errorf("expected cached team config, got err=%v repos=%+v", cfg.TeamConfigErr, cfg.Repos)
+	}
+	cfg.RefreshTeamConfig(true)
+	if cfg.TeamConfigErr == nil || len(cfg.Repos) != 2 {
+		t.Errorf("expected the fetch error and the cached team config, got err=%v repos=%+v", cfg.TeamConfigErr, cfg.Repos)
+	}
+}
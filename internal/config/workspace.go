@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// WorkspaceBackend is a project-local agent folder, e.g. ./.claude in a
+// project, that lazyas can expose skills to
+type WorkspaceBackend struct {
+	Backend        // Per-skill backend for the folder's skills directory
+	Folder  string // The agent folder, e.g. ".claude"
+	Project string // Project directory holding it
+}
+
+// unsafeNameChars are replaced in the project part of a backend name
+var unsafeNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// FindWorkspace returns the project directory of dir: the nearest of dir
+// and its parents holding the agent folder of a known backend (.claude,
+// .cursor, ...). The home directory, whose agent folders are the global
+// backends, is never a project; ok is false when there is none.
+func FindWorkspace(dir string) (project string, ok bool) {
+	home, _ := os.UserHomeDir()
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		if dir == home {
+			return "", false
+		}
+		if len(agentFolders(dir)) > 0 {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// WorkspaceBackends returns the agent folders of the project around dir
+// that no configured backend links yet, as per-skill backends named
+// after the agent and project (claude-myapp). Per-skill mode leaves the
+// project's own skills in the folder alone.
+func (c *Config) WorkspaceBackends(dir string) []WorkspaceBackend {
+	project, ok := FindWorkspace(dir)
+	if !ok {
+		return nil
+	}
+	configured := make(map[string]bool, len(c.Backends))
+	for _, b := range c.Backends {
+		if path, err := ExpandPath(b.Path); err == nil {
+			configured[filepath.Clean(path)] = true
+		}
+	}
+
+	base := strings.Trim(unsafeNameChars.ReplaceAllString(strings.ToLower(filepath.Base(project)), "-"), "-")
+	if base == "" {
+		base = "project"
+	}
+	var found []WorkspaceBackend
+	for _, known := range agentFolders(project) {
+		folder := agentFolder(known)
+		path := filepath.Join(project, folder, "skills")
+		if configured[path] {
+			continue
+		}
+		found = append(found, WorkspaceBackend{
+			Backend: Backend{
+				Name:        known.Name + "-" + base,
+				Path:        path,
+				Description: known.Description + " in " + filepath.Base(project),
+				Mode:        BackendPerSkill,
+			},
+			Folder:  folder,
+			Project: project,
+		})
+	}
+	return found
+}
+
+// agentFolders returns the known backends whose agent folder is in dir
+func agentFolders(dir string) []Backend {
+	var found []Backend
+	for _, known := range KnownBackends {
+		folder := agentFolder(known)
+		if folder == "" {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(dir, folder)); err == nil && info.IsDir() {
+			found = append(found, known)
+		}
+	}
+	return found
+}
+
+// agentFolder is the home directory folder of a known backend, ".claude"
+// for ~/.claude/skills. Backends under $XDG_CONFIG_HOME have no project
+// counterpart and return "".
+func agentFolder(b Backend) string {
+	rest, ok := strings.CutPrefix(b.Path, "~/")
+	if !ok {
+		return ""
+	}
+	folder, _, _ := strings.Cut(rest, "/")
+	return folder
+}
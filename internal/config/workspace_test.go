@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaceBackends(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	project := filepath.Join(t.TempDir(), "My App")
+	for _, dir := range []string{".claude/skills", ".cursor", ".vscode", "src/pkg"} {
+		if err := os.MkdirAll(filepath.Join(project, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := testConfig(t)
+	cfg.Backends = append([]Backend(nil), KnownBackends...)
+
+	// Found from a subdirectory too
+	found := cfg.WorkspaceBackends(filepath.Join(project, "src", "pkg"))
+	if len(found) != 2 {
+		t.Fatalf("found %+v, want claude and cursor", found)
+	}
+	if w := found[0]; w.Name != "claude-my-app" || w.Path != filepath.Join(project, ".claude", "skills") || !w.PerSkill() || w.Folder != ".claude" {
+		t.Errorf("unexpected claude backend: %+v", w)
+	}
+
+	// Folders with a backend are not suggested again
+	if err := cfg.AddBackend("claude-my-app", found[0].Path, ""); err != nil {
+		t.Fatal(err)
+	}
+	if found := cfg.WorkspaceBackends(project); len(found) != 1 || found[0].Name != "cursor-my-app" {
+		t.Errorf("after adding claude: %+v", found)
+	}
+
+	// The home directory holds the global backends, not a project
+	home, _ := os.UserHomeDir()
+	if err := os.MkdirAll(filepath.Join(home, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if found := cfg.WorkspaceBackends(home); len(found) != 0 {
+		t.Errorf("home: %+v", found)
+	}
+}
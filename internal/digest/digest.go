@@ -0,0 +1,160 @@
+// Package digest compares the skill index with the snapshot recorded by
+// the previous digest in ~/.lazyas/digest.yaml, reporting what changed in
+// each repository since then.
+package digest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"lazyas/internal/registry"
+)
+
+// Snapshot is the index as seen by one digest
+type Snapshot struct {
+	At    time.Time       `yaml:"at"`
+	Repos map[string]Repo `yaml:"repos"` // by config repo name
+}
+
+// Repo is one repository in a snapshot
+type Repo struct {
+	Commit string           `yaml:"commit,omitempty"`
+	Skills map[string]Skill `yaml:"skills"` // by skill name
+}
+
+// Skill is what a snapshot remembers about a skill
+type Skill struct {
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version,omitempty"`
+}
+
+// Take builds a snapshot of skills; commits maps repo names to the commit
+// they were read at
+func Take(skills []registry.SkillEntry, commits map[string]string, at time.Time) Snapshot {
+	snap := Snapshot{At: at, Repos: make(map[string]Repo)}
+	for _, s := range skills {
+		name := s.Source.RepoName
+		if name == "" {
+			name = s.Source.Repo
+		}
+		repo, ok := snap.Repos[name]
+		if !ok {
+			repo = Repo{Commit: commits[name], Skills: make(map[string]Skill)}
+		}
+		repo.Skills[s.Name] = Skill{Description: s.Description, Version: s.Source.Tag}
+		snap.Repos[name] = repo
+	}
+	return snap
+}
+
+// Change is a skill that was added, removed or changed. Old and New hold
+// the description or version, depending on the list it is in.
+type Change struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// RepoChanges are the changes in one repository
+type RepoChanges struct {
+	Repo         string
+	OldCommit    string
+	NewCommit    string
+	Added        []Change
+	Removed      []Change
+	Descriptions []Change
+	Versions     []Change
+}
+
+// Empty reports whether nothing about the skills changed
+func (c RepoChanges) Empty() bool {
+	return len(c.Added)+len(c.Removed)+len(c.Descriptions)+len(c.Versions) == 0
+}
+
+// Compare lists the changes from prev to cur, one entry per repository
+// with changes, sorted by name
+func Compare(prev, cur Snapshot) []RepoChanges {
+	names := make(map[string]bool)
+	for name := range prev.Repos {
+		names[name] = true
+	}
+	for name := range cur.Repos {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []RepoChanges
+	for _, name := range sorted {
+		old, now := prev.Repos[name], cur.Repos[name]
+		c := RepoChanges{Repo: name, OldCommit: old.Commit, NewCommit: now.Commit}
+		for _, skill := range sortedKeys(now.Skills) {
+			s := now.Skills[skill]
+			was, existed := old.Skills[skill]
+			switch {
+			case !existed:
+				c.Added = append(c.Added, Change{Name: skill, New: s.Description})
+			default:
+				if was.Description != s.Description {
+					c.Descriptions = append(c.Descriptions, Change{Name: skill, Old: was.Description, New: s.Description})
+				}
+				if was.Version != s.Version {
+					c.Versions = append(c.Versions, Change{Name: skill, Old: was.Version, New: s.Version})
+				}
+			}
+		}
+		for _, skill := range sortedKeys(old.Skills) {
+			if _, ok := now.Skills[skill]; !ok {
+				c.Removed = append(c.Removed, Change{Name: skill, Old: old.Skills[skill].Description})
+			}
+		}
+		if !c.Empty() {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func sortedKeys(skills map[string]Skill) []string {
+	keys := make([]string, 0, len(skills))
+	for k := range skills {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Load returns the last recorded snapshot, or nil when there is none
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid digest snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// Save records snap as the baseline for the next digest
+func Save(path string, snap Snapshot) error {
+	data, err := yaml.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
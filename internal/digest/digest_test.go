@@ -0,0 +1,80 @@
+package digest
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lazyas/internal/registry"
+)
+
+func entry(repo, name, desc, tag string) registry.SkillEntry {
+	return registry.SkillEntry{Name: name, Description: desc, Source: registry.SkillSource{RepoName: repo, Tag: tag}}
+}
+
+func TestCompare(t *testing.T) {
+	prev := Take([]registry.SkillEntry{
+		entry("a", "pdf", "Read PDFs", "v1.0.0"),
+		entry("a", "docx", "Word files", ""),
+		entry("a", "old", "Going away", ""),
+		entry("gone", "x", "", ""),
+	}, map[string]string{"a": "111"}, time.Unix(0, 0))
+	cur := Take([]registry.SkillEntry{
+		entry("a", "pdf", "Read PDFs", "v1.1.0"),
+		entry("a", "docx", "Word documents", ""),
+		entry("a", "new", "Fresh", ""),
+		entry("b", "y", "", ""),
+	}, map[string]string{"a": "222"}, time.Unix(1, 0))
+
+	changes := Compare(prev, cur)
+	if len(changes) != 3 {
+		t.Fatalf("got %d repos with changes, want 3: %+v", len(changes), changes)
+	}
+
+	a := changes[0]
+	if a.Repo != "a" || a.OldCommit != "111" || a.NewCommit != "222" {
+		t.Errorf("unexpected repo a: %+v", a)
+	}
+	if len(a.Added) != 1 || a.Added[0].Name != "new" || a.Added[0].New != "Fresh" {
+		t.Errorf("Added = %+v", a.Added)
+	}
+	if len(a.Removed) != 1 || a.Removed[0].Name != "old" {
+		t.Errorf("Removed = %+v", a.Removed)
+	}
+	if len(a.Descriptions) != 1 || a.Descriptions[0].New != "Word documents" {
+		t.Errorf("Descriptions = %+v", a.Descriptions)
+	}
+	if len(a.Versions) != 1 || a.Versions[0].Old != "v1.0.0" || a.Versions[0].New != "v1.1.0" {
+		t.Errorf("Versions = %+v", a.Versions)
+	}
+
+	if changes[1].Repo != "b" || len(changes[1].Added) != 1 {
+		t.Errorf("expected b's skill as added, got %+v", changes[1])
+	}
+	if changes[2].Repo != "gone" || len(changes[2].Removed) != 1 {
+		t.Errorf("expected gone's skill as removed, got %+v", changes[2])
+	}
+
+	if got := Compare(cur, cur); len(got) != 0 {
+		t.Errorf("expected no changes against itself, got %+v", got)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digest.yaml")
+	if snap, err := Load(path); err != nil || snap != nil {
+		t.Fatalf("Load on a missing file = %+v, %v", snap, err)
+	}
+
+	want := Take([]registry.SkillEntry{entry("a", "pdf", "Read PDFs", "v1")}, nil, time.Unix(100, 0).UTC())
+	if err := Save(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil || got == nil {
+		t.Fatalf("Load = %+v, %v", got, err)
+	}
+	if !got.At.Equal(want.At) || got.Repos["a"].Skills["pdf"].Version != "v1" {
+		t.Errorf("round trip lost data: %+v", got)
+	}
+}
@@ -0,0 +1,185 @@
+// Package fulltext finds skills by what their SKILL.md says. Bodies are
+// read from installed skills, fetched previews and repo clones only, so a
+// search never touches the network.
+package fulltext
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/registry"
+	"lazyas/internal/remote"
+)
+
+// Doc is the SKILL.md of one skill
+type Doc struct {
+	Name      string
+	Installed bool
+	Body      string
+}
+
+// Match is a skill whose SKILL.md matches every term of a query
+type Match struct {
+	Name      string
+	Installed bool
+	Score     int
+	Line      int    // 1-based line that matches best
+	Text      string // That line, trimmed
+}
+
+// Collect reads the SKILL.md of every installed skill and of the given
+// registry skills that are available locally. Returns how many registry
+// skills were skipped because no copy of their SKILL.md is on disk.
+func Collect(cfg *config.Config, skills []registry.SkillEntry) ([]Doc, int) {
+	var docs []Doc
+	seen := make(map[string]bool)
+
+	entries, _ := os.ReadDir(cfg.SkillsDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.SkillsDir, e.Name(), "SKILL.md"))
+		if err != nil {
+			continue
+		}
+		docs = append(docs, Doc{Name: e.Name(), Installed: true, Body: string(data)})
+		seen[e.Name()] = true
+	}
+
+	previews := remote.NewPreviewCache(cfg.PreviewCacheDir, time.Duration(cfg.CacheTTL)*time.Hour)
+	missing := 0
+	for _, skill := range skills {
+		if seen[skill.Name] {
+			continue
+		}
+		seen[skill.Name] = true
+		body, ok := previews.Cached(skill.Source.Repo, skill.Source.Tag, skill.Source.Path)
+		if !ok {
+			// Clones made for scanning repos without an index check out SKILL.md
+			clone := filepath.Join(cfg.ReposDir, git.RepoDirName(skill.Source.Repo), skill.Source.Path, "SKILL.md")
+			if data, err := os.ReadFile(clone); err == nil {
+				body, ok = string(data), true
+			}
+		}
+		if !ok {
+			missing++
+			continue
+		}
+		docs = append(docs, Doc{Name: skill.Name, Body: body})
+	}
+	return docs, missing
+}
+
+// Search ranks the docs matching every whitespace-separated term of query.
+// Terms match fuzzily: their characters must appear in order within a
+// short stretch of one line. Contiguous runs, word starts and terms that
+// meet on the same line score higher.
+func Search(query string, docs []Doc) []Match {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var matches []Match
+	for _, doc := range docs {
+		best := make([]int, len(terms))
+		var bestLine, bestLineScore int
+		var bestText string
+		for i, line := range strings.Split(doc.Body, "\n") {
+			lower := strings.ToLower(line)
+			lineScore := 0
+			for t, term := range terms {
+				s := score(lower, term)
+				lineScore += s
+				if s > best[t] {
+					best[t] = s
+				}
+			}
+			if lineScore > bestLineScore {
+				bestLine, bestLineScore, bestText = i+1, lineScore, line
+			}
+		}
+
+		total := bestLineScore
+		for _, s := range best {
+			if s == 0 {
+				total = 0
+				break
+			}
+			total += s
+		}
+		if total == 0 {
+			continue
+		}
+		matches = append(matches, Match{
+			Name:      doc.Name,
+			Installed: doc.Installed,
+			Score:     total,
+			Line:      bestLine,
+			Text:      strings.TrimSpace(bestText),
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
+
+// maxSpread bounds how far apart the characters of a term may be: a match
+// spans at most maxSpread times the term's length
+const maxSpread = 3
+
+// score rates the best fuzzy match of term in line, both lower case.
+// 0 means no match.
+func score(line, term string) int {
+	best := 0
+	for start := strings.IndexByte(line, term[0]); start >= 0; {
+		if s := scoreFrom(line, term, start); s > best {
+			best = s
+		}
+		next := strings.IndexByte(line[start+1:], term[0])
+		if next < 0 {
+			break
+		}
+		start += next + 1
+	}
+	return best
+}
+
+// scoreFrom matches term greedily starting at line[start]
+func scoreFrom(line, term string, start int) int {
+	limit := start + maxSpread*len(term)
+	s, j, prev := 0, 0, -2
+	for i := start; i < len(line) && i < limit && j < len(term); i++ {
+		if line[i] != term[j] {
+			continue
+		}
+		s++
+		if i == prev+1 {
+			s += 4
+		}
+		if i == 0 || !isWordChar(line[i-1]) {
+			s += 3
+		}
+		prev = i
+		j++
+	}
+	if j < len(term) {
+		return 0
+	}
+	return s
+}
+
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c >= 0x80
+}
@@ -0,0 +1,84 @@
+package fulltext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/registry"
+)
+
+func TestSearch_RanksContentMatches(t *testing.T) {
+	docs := []Doc{
+		{Name: "forms", Body: "# Forms\n\nFill in PDF forms and flatten them.\n"},
+		{Name: "scattered", Body: "# Misc\n\nupsized files\nsome form\n"},
+		{Name: "images", Body: "# Images\n\nResize and crop pictures.\n"},
+	}
+
+	matches := Search("pdf form", docs)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+	if matches[0].Name != "forms" {
+		t.Errorf("expected the contiguous match first, got %+v", matches)
+	}
+	if matches[0].Line != 3 || matches[0].Text != "Fill in PDF forms and flatten them." {
+		t.Errorf("expected the matching line, got %d %q", matches[0].Line, matches[0].Text)
+	}
+
+	if got := Search("crp pictrs", docs); len(got) != 1 || got[0].Name != "images" {
+		t.Errorf("expected a fuzzy match on images, got %+v", got)
+	}
+	if got := Search("pdf video", docs); len(got) != 0 {
+		t.Errorf("expected every term to be required, got %+v", got)
+	}
+	if got := Search("  ", docs); got != nil {
+		t.Errorf("expected no matches for an empty query, got %+v", got)
+	}
+}
+
+func TestScore_BoundsSpread(t *testing.T) {
+	if score("p....................d..................f", "pdf") != 0 {
+		t.Error("expected characters far apart not to match")
+	}
+	if score("my pdf tools", "pdf") <= score("xpydf", "pdf") {
+		t.Error("expected a whole word to beat a scattered match")
+	}
+}
+
+func TestCollect(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		SkillsDir:       filepath.Join(tmp, "skills"),
+		ReposDir:        filepath.Join(tmp, "repos"),
+		PreviewCacheDir: filepath.Join(tmp, "previews"),
+		CacheTTL:        24,
+	}
+	write := func(path, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := "https://github.com/acme/skills"
+	write(filepath.Join(cfg.SkillsDir, "pdf", "SKILL.md"), "installed pdf")
+	write(filepath.Join(cfg.ReposDir, git.RepoDirName(repo), "skills", "csv", "SKILL.md"), "cloned csv")
+
+	skills := []registry.SkillEntry{
+		{Name: "pdf", Source: registry.SkillSource{Repo: repo, Path: "skills/pdf"}},
+		{Name: "csv", Source: registry.SkillSource{Repo: repo, Path: "skills/csv"}},
+		{Name: "xlsx", Source: registry.SkillSource{Repo: repo, Path: "skills/xlsx"}},
+	}
+	docs, missing := Collect(cfg, skills)
+	if missing != 1 {
+		t.Errorf("expected xlsx to be missing, got %d", missing)
+	}
+	if len(docs) != 2 || docs[0] != (Doc{Name: "pdf", Installed: true, Body: "installed pdf"}) || docs[1] != (Doc{Name: "csv", Body: "cloned csv"}) {
+		t.Errorf("unexpected docs %+v", docs)
+	}
+}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"sync"
+)
+
+// ErrNotInstalled is returned when no git binary can be found on PATH
+var ErrNotInstalled = errors.New("git is not installed or not on PATH")
+
+var lookGit = sync.OnceValue(func() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return ErrNotInstalled
+	}
+	return nil
+})
+
+// Available returns ErrNotInstalled when git cannot be run. The lookup is
+// done once per process.
+func Available() error {
+	return lookGit()
+}
+
+// InstallHint tells the user how to get git on this platform
+func InstallHint() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "Install it with 'xcode-select --install' or 'brew install git'."
+	case "windows":
+		return "Install it with 'winget install Git.Git' or from https://git-scm.com/download/win."
+	default:
+		return "Install it with your package manager (for example 'apt install git' or 'dnf install git')."
+	}
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"lazyas/internal/throttle"
+)
+
+// ErrProxyConfigured is returned by LimitBandwidth when git already goes
+// through a proxy of the user's, which the limit would replace
+var ErrProxyConfigured = errors.New("git already uses a proxy; bandwidth limit not applied")
+
+var (
+	bandwidthMu    sync.Mutex
+	bandwidthProxy *throttle.Proxy
+)
+
+// LimitBandwidth caps git's HTTP(S) transfers at bytesPerSec for every git
+// command this process runs from now on; 0 lifts the cap. git has no rate
+// option, so its http.proxy is pointed at a local throttling proxy. SSH
+// and local transfers are not limited.
+func LimitBandwidth(bytesPerSec int64) error {
+	bandwidthMu.Lock()
+	defer bandwidthMu.Unlock()
+
+	if bandwidthProxy != nil {
+		bandwidthProxy.Limiter.SetRate(bytesPerSec)
+		return nil
+	}
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	if UserProxy() != "" {
+		return ErrProxyConfigured
+	}
+	proxy, err := throttle.StartProxy(bytesPerSec)
+	if err != nil {
+		return fmt.Errorf("failed to start bandwidth limiting proxy: %w", err)
+	}
+	addConfigEnv("http.proxy", proxy.URL())
+	bandwidthProxy = proxy
+	return nil
+}
+
+// UserProxy returns the proxy git is configured to use through its config
+// or the environment, or "" for none
+func UserProxy() string {
+	for _, key := range []string{"https_proxy", "HTTPS_PROXY", "http_proxy", "HTTP_PROXY", "all_proxy", "ALL_PROXY"} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	out, err := exec.Command("git", "config", "--get", "http.proxy").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// addConfigEnv sets a git config value for child processes through
+// GIT_CONFIG_COUNT (git 2.31+), keeping entries already set there
+func addConfigEnv(key, value string) {
+	n, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	os.Setenv(fmt.Sprintf("GIT_CONFIG_KEY_%d", n), key)
+	os.Setenv(fmt.Sprintf("GIT_CONFIG_VALUE_%d", n), value)
+	os.Setenv("GIT_CONFIG_COUNT", strconv.Itoa(n+1))
+}
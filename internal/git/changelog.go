@@ -0,0 +1,112 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// changelogDepth bounds how much history is fetched to build a changelog
+const changelogDepth = 200
+
+// Changelog summarizes what changed for a skill between two commits
+type Changelog struct {
+	Commits   []string // "abc1234 subject", newest first
+	Notes     []string // lines added to the skill's CHANGELOG.md
+	Truncated bool     // the installed commit was not in the fetched history
+}
+
+// Empty reports whether there is nothing to show
+func (c *Changelog) Empty() bool {
+	return c == nil || (len(c.Commits) == 0 && len(c.Notes) == 0)
+}
+
+// FetchChangelog returns the commits and CHANGELOG.md additions between the
+// installed commit and ref, scoped to the skill directory. ref "" means the
+// remote default branch. History is read from a throwaway clone of origin,
+// so the skill's clone is neither fetched into nor deepened.
+func FetchChangelog(skillPath, fromCommit, ref string) (*Changelog, error) {
+	prefix, err := repoPrefix(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	origin, err := gitOutput(skillPath, "remote", "get-url", "origin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read origin: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "lazyas-changelog-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	args := []string{"clone", "-q", "--bare", "--filter=blob:none", "--depth", fmt.Sprint(changelogDepth), "--single-branch"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, strings.TrimSpace(origin), tmpDir)
+	if err := runGit(".", args...); err != nil {
+		return nil, fmt.Errorf("git clone failed: %w", err)
+	}
+
+	// Limit log and diff to the skill directory
+	var paths []string
+	if prefix != "" {
+		paths = []string{"--", prefix}
+	}
+
+	cl := &Changelog{}
+	rangeSpec := fromCommit + "..HEAD"
+	if fromCommit == "" || runGit(tmpDir, "cat-file", "-e", fromCommit+"^{commit}") != nil {
+		// Installed commit unknown or outside fetched history: show recent commits
+		cl.Truncated = true
+		rangeSpec = "HEAD"
+	}
+
+	logArgs := []string{"log", "--format=%h %s", rangeSpec}
+	if cl.Truncated {
+		logArgs = []string{"log", "-n", "20", "--format=%h %s", rangeSpec}
+	}
+	out, err := gitOutput(tmpDir, append(logArgs, paths...)...)
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+	cl.Commits = nonEmptyLines(out)
+
+	if !cl.Truncated {
+		diff, err := gitOutput(tmpDir, "diff", "--unified=0", fromCommit, "HEAD", "--", prefix+"CHANGELOG.md")
+		if err == nil {
+			for _, line := range strings.Split(diff, "\n") {
+				if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+					if note := strings.TrimSpace(line[1:]); note != "" {
+						cl.Notes = append(cl.Notes, note)
+					}
+				}
+			}
+		}
+	}
+
+	return cl, nil
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func nonEmptyLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
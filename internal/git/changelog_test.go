@@ -0,0 +1,36 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchChangelog_LeavesCloneAlone(t *testing.T) {
+	url, _ := newRemote(t,
+		map[string]string{"skills/a/SKILL.md": "a1\n", "skills/a/CHANGELOG.md": "- one\n", "skills/b/SKILL.md": "b1\n"},
+		map[string]string{"skills/a/SKILL.md": "a2\n", "skills/a/CHANGELOG.md": "- one\n- two\n"},
+		map[string]string{"skills/b/SKILL.md": "b2\n"},
+	)
+	skillsDir, repoDir := installShared(t, url, "skills/a")
+	link := filepath.Join(skillsDir, "a")
+	if _, err := Update(link, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	from := gitRun(t, "-C", repoDir, "rev-parse", "HEAD")
+	depth := gitRun(t, "-C", repoDir, "rev-list", "--count", "--all")
+
+	cl, err := FetchChangelog(link, from, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cl.Truncated || len(cl.Commits) != 1 || !strings.HasSuffix(cl.Commits[0], "commit 2") {
+		t.Errorf("commits = %v (truncated %v), want commit 2 only", cl.Commits, cl.Truncated)
+	}
+	if len(cl.Notes) != 1 || cl.Notes[0] != "- two" {
+		t.Errorf("notes = %v, want the added line", cl.Notes)
+	}
+	if got := gitRun(t, "-C", repoDir, "rev-list", "--count", "--all"); got != depth {
+		t.Errorf("clone history went from %s to %s commits", depth, got)
+	}
+}
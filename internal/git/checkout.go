@@ -0,0 +1,266 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// OwnCloneSep joins a shared clone's directory name and a skill name into
+// the name of the skill's own clone, "<clone>@<skill>". RepoDirName never
+// produces it, so own clones cannot collide with shared ones.
+const OwnCloneSep = "@"
+
+// isolate gives the skill linked at skillLink a clone of its own when other
+// links next to it point into the same checkout, so moving the skill to
+// another ref leaves them where they are. The clone is made from the
+// shared one at its HEAD, local changes move along, and the link is
+// re-pointed. Anything that is not a link into a clone is left alone.
+func isolate(skillLink string) error {
+	target, err := os.Readlink(skillLink)
+	if err != nil || !filepath.IsAbs(target) {
+		return nil
+	}
+	out, err := gitOutput(target, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil
+	}
+	top := strings.TrimSpace(out)
+	if strings.Contains(filepath.Base(top), OwnCloneSep) || !sharesCheckout(skillLink, top) {
+		return nil
+	}
+	prefix, err := repoPrefix(target)
+	if err != nil {
+		return err
+	}
+
+	dir := top + OwnCloneSep + filepath.Base(skillLink)
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(dir); os.IsNotExist(err) {
+			break
+		}
+		dir = fmt.Sprintf("%s%s%s-%d", top, OwnCloneSep, filepath.Base(skillLink), i)
+	}
+	if err := cloneCheckout(top, dir, strings.TrimSuffix(prefix, "/")); err != nil {
+		return err
+	}
+	skillPath := filepath.Join(dir, prefix)
+	if err := moveChanges(target, skillPath); err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("failed to move local changes of %s: %w", filepath.Base(skillLink), err)
+	}
+
+	if err := os.Remove(skillLink); err != nil {
+		return err
+	}
+	if err := os.Symlink(skillPath, skillLink); err != nil {
+		return fmt.Errorf("failed to create symlink %s -> %s: %w", skillLink, skillPath, err)
+	}
+	return nil
+}
+
+// sharesCheckout reports whether another link in skillLink's directory
+// resolves into the checkout at top. Aliases, which are relative links to
+// a skill link, do not count.
+func sharesCheckout(skillLink, top string) bool {
+	dir := filepath.Dir(skillLink)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if e.Name() == filepath.Base(skillLink) || e.Type()&fs.ModeSymlink == 0 {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if target, err := os.Readlink(path); err != nil || !filepath.IsAbs(target) {
+			continue
+		}
+		if real, err := filepath.EvalSymlinks(path); err == nil && within(top, real) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneCheckout clones the shared clone at top into dir, at top's HEAD and
+// with only path checked out ("" = everything). The local clone shares
+// top's objects through hard links; origin is pointed at top's origin.
+func cloneCheckout(top, dir, path string) error {
+	origin, err := gitOutput(top, "remote", "get-url", "origin")
+	if err != nil {
+		return fmt.Errorf("failed to read the origin of %s: %w", top, err)
+	}
+	head, err := getHeadCommit(top)
+	if err != nil {
+		return err
+	}
+
+	if err := runGit(".", "clone", "-q", "--no-checkout", top, dir); err != nil {
+		return fmt.Errorf("git clone failed: %w", err)
+	}
+	steps := [][]string{{"remote", "set-url", "origin", strings.TrimSpace(origin)}}
+	if path != "" {
+		steps = append(steps, []string{"sparse-checkout", "init", "--cone"}, []string{"sparse-checkout", "set", path})
+	}
+	steps = append(steps, []string{"checkout", "-q", "--detach", head})
+	for _, args := range steps {
+		if err := runGit(dir, args...); err != nil {
+			os.RemoveAll(dir)
+			return fmt.Errorf("git %s failed: %w", args[0], err)
+		}
+	}
+	return nil
+}
+
+// moveChanges moves what differs from HEAD under the skill directory from
+// (edited, deleted, untracked and ignored files) to the same place under
+// to, then restores from's checkout
+func moveChanges(from, to string) error {
+	prefix, err := repoPrefix(from)
+	if err != nil {
+		return err
+	}
+	out, err := gitOutput(from, "status", "--porcelain", "-z", "--ignored", "--", ".")
+	if err != nil {
+		return fmt.Errorf("git status failed: %w", err)
+	}
+	if out == "" {
+		return nil
+	}
+
+	var paths []string
+	fields := strings.Split(out, "\x00")
+	for i := 0; i < len(fields); i++ {
+		entry := fields[i]
+		if len(entry) <= 3 {
+			continue
+		}
+		paths = append(paths, entry[3:])
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++ // the original path follows a rename or copy
+			if i < len(fields) {
+				paths = append(paths, fields[i])
+			}
+		}
+	}
+
+	for _, p := range paths {
+		rel := filepath.FromSlash(strings.TrimSuffix(strings.TrimPrefix(p, prefix), "/"))
+		src, dst := filepath.Join(from, rel), filepath.Join(to, rel)
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+		if _, err := os.Lstat(src); os.IsNotExist(err) {
+			continue // deleted locally
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+	}
+
+	if err := runGit(from, "reset", "-q", "--", "."); err != nil {
+		return fmt.Errorf("git reset failed: %w", err)
+	}
+	if err := runGit(from, "checkout", "--", "."); err != nil {
+		return fmt.Errorf("git checkout failed: %w", err)
+	}
+	return nil
+}
+
+// StageRef fetches ref from origin and writes the skill's files at that ref
+// into a new temporary directory, leaving the skill's checkout alone, so an
+// update can be inspected before the skill moves. The caller removes the
+// directory. ref may be a tag, branch or commit; "" means the remote
+// default branch.
+func StageRef(skillPath, ref string) (string, error) {
+	args := []string{"fetch", "--depth", "1", "origin"}
+	if ref != "" {
+		args = append(args, ref)
+	}
+	if err := runGit(skillPath, args...); err != nil {
+		return "", fmt.Errorf("git fetch failed: %w", err)
+	}
+	prefix, err := repoPrefix(skillPath)
+	if err != nil {
+		return "", err
+	}
+	top, err := gitOutput(skillPath, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w", err)
+	}
+	tree := "FETCH_HEAD"
+	if prefix != "" {
+		tree += ":" + strings.TrimSuffix(prefix, "/")
+	}
+
+	// Run from the top: in a subdirectory git archive limits the tree to it again
+	cmd := exec.Command("git", "archive", "--format=tar", tree)
+	cmd.Dir = strings.TrimSpace(top)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	archive, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git archive failed: %w\n%s", err, stderr.String())
+	}
+
+	dir, err := os.MkdirTemp("", "lazyas-stage-")
+	if err != nil {
+		return "", err
+	}
+	if err := extractTar(archive, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// extractTar writes the directories and regular files of a tar archive
+// under dir. Symlinks are left out, as when copying a local skill.
+func extractTar(archive []byte, dir string) error {
+	r := tar.NewReader(bytes.NewReader(archive))
+	for {
+		h, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !filepath.IsLocal(h.Name) {
+			continue
+		}
+		target := filepath.Join(dir, h.Name)
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(h.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, r)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
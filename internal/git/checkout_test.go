@@ -0,0 +1,182 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitRun runs git with a fixed identity and fails the test on error
+func gitRun(t *testing.T, args ...string) string {
+	t.Helper()
+	args = append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s", args, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// gitIdentity sets the identity for commits made by the code under test
+func gitIdentity(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "t")
+	t.Setenv("GIT_AUTHOR_EMAIL", "t@t")
+	t.Setenv("GIT_COMMITTER_NAME", "t")
+	t.Setenv("GIT_COMMITTER_EMAIL", "t@t")
+}
+
+// writeFiles writes files (slash-separated path -> content) under dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newRemote creates a bare repository whose main branch has one commit per
+// element of commits, each tagged v1, v2, ... It returns its file:// URL
+// and a working copy to add commits from.
+func newRemote(t *testing.T, commits ...map[string]string) (url, seed string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	seed = filepath.Join(root, "seed")
+	gitRun(t, "init", "-q", "--bare", remote)
+	gitRun(t, "--git-dir", remote, "symbolic-ref", "HEAD", "refs/heads/main")
+	gitRun(t, "init", "-q", seed)
+	for i, files := range commits {
+		writeFiles(t, seed, files)
+		gitRun(t, "-C", seed, "add", "-A")
+		gitRun(t, "-C", seed, "commit", "-qm", "commit "+string(rune('1'+i)))
+		gitRun(t, "-C", seed, "tag", "v"+string(rune('1'+i)))
+	}
+	gitRun(t, "-C", seed, "push", "-q", "--tags", remote, "HEAD:refs/heads/main")
+	return "file://" + remote, seed
+}
+
+// installShared installs the skills at paths from url into one shared
+// clone and returns the skills directory
+func installShared(t *testing.T, url string, paths ...string) (skillsDir, repoDir string) {
+	t.Helper()
+	root := t.TempDir()
+	skillsDir = filepath.Join(root, "skills")
+	repoDir = filepath.Join(root, "repos", "acme-skills")
+	if err := os.MkdirAll(skillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range paths {
+		name := filepath.Base(p)
+		if _, err := RepoInstall(RepoInstallOptions{
+			RepoURL:   url,
+			Path:      p,
+			RepoDir:   repoDir,
+			SkillName: name,
+			SkillLink: filepath.Join(skillsDir, name),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return skillsDir, repoDir
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestUpdate_SharedCloneGetsOwnClone(t *testing.T) {
+	url, _ := newRemote(t,
+		map[string]string{"skills/a/SKILL.md": "a1\n", "skills/b/SKILL.md": "b1\n"},
+		map[string]string{"skills/a/SKILL.md": "a2\n", "skills/b/SKILL.md": "b2\n"},
+	)
+	gitIdentity(t)
+	skillsDir, repoDir := installShared(t, url, "skills/a", "skills/b")
+	linkA, linkB := filepath.Join(skillsDir, "a"), filepath.Join(skillsDir, "b")
+	if err := os.Symlink("a", filepath.Join(skillsDir, "alias")); err != nil {
+		t.Fatal(err)
+	}
+	head := gitRun(t, "-C", repoDir, "rev-parse", "HEAD")
+	writeFiles(t, linkA, map[string]string{"notes.txt": "mine\n"})
+
+	result, err := UpdateWithStash(linkA, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Stashed || len(result.Conflicts) > 0 {
+		t.Errorf("result = %+v, want the notes reapplied", result)
+	}
+	if target, _ := os.Readlink(linkA); target != filepath.Join(repoDir+OwnCloneSep+"a", "skills", "a") {
+		t.Errorf("a links to %s, want its own clone", target)
+	}
+	if got := readFile(t, filepath.Join(linkA, "SKILL.md")); got != "a1\n" {
+		t.Errorf("a's SKILL.md = %q, want v1", got)
+	}
+	if got := readFile(t, filepath.Join(linkA, "notes.txt")); got != "mine\n" {
+		t.Errorf("notes.txt = %q, want the local edit kept", got)
+	}
+
+	// The sibling and the shared clone stay where they were
+	if got := readFile(t, filepath.Join(linkB, "SKILL.md")); got != "b2\n" {
+		t.Errorf("b's SKILL.md = %q, want it untouched", got)
+	}
+	if got := gitRun(t, "-C", repoDir, "rev-parse", "HEAD"); got != head {
+		t.Errorf("shared clone moved from %s to %s", head, got)
+	}
+	if status := gitRun(t, "-C", repoDir, "status", "--porcelain"); status != "" {
+		t.Errorf("shared clone left with changes: %s", status)
+	}
+
+	// A skill alone in its clone is updated in place
+	if _, err := Update(linkB, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if target, _ := os.Readlink(linkB); target != filepath.Join(repoDir, "skills", "b") {
+		t.Errorf("b links to %s, want the shared clone", target)
+	}
+}
+
+func TestStageRef_LeavesCheckoutAlone(t *testing.T) {
+	url, _ := newRemote(t,
+		map[string]string{"skills/a/SKILL.md": "a1\n"},
+		map[string]string{"skills/a/SKILL.md": "a2\n", "skills/a/run.sh": "echo hi\n"},
+	)
+	skillsDir, repoDir := installShared(t, url, "skills/a")
+	link := filepath.Join(skillsDir, "a")
+	if _, err := Update(link, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	head := gitRun(t, "-C", repoDir, "rev-parse", "HEAD")
+
+	dir, err := StageRef(link, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if got := readFile(t, filepath.Join(dir, "SKILL.md")); got != "a2\n" {
+		t.Errorf("staged SKILL.md = %q, want v2", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "run.sh")); got != "echo hi\n" {
+		t.Errorf("staged run.sh = %q", got)
+	}
+	if got := gitRun(t, "-C", repoDir, "rev-parse", "HEAD"); got != head {
+		t.Errorf("checkout moved from %s to %s", head, got)
+	}
+	if got := readFile(t, filepath.Join(link, "SKILL.md")); got != "a1\n" {
+		t.Errorf("SKILL.md = %q, want v1 still checked out", got)
+	}
+}
@@ -40,6 +40,11 @@ func getHeadCommit(dir string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// HeadCommit returns the commit checked out at path
+func HeadCommit(path string) (string, error) {
+	return getHeadCommit(path)
+}
+
 // IsGitRepo checks if the path is a git repository.
 // Accepts both .git directories and .git files (gitlinks used by worktrees/submodules).
 func IsGitRepo(path string) bool {
@@ -48,49 +53,90 @@ func IsGitRepo(path string) bool {
 	return err == nil
 }
 
-// IsModified checks if a git repo has local modifications
+// IsModified checks if a git repo has local modifications. Paths listed in
+// .lazyasignore files are not counted (see LoadIgnore).
 func IsModified(path string) (bool, error) {
-	if !IsGitRepo(path) {
-		return false, nil // Not a git repo, can't be modified
-	}
-
-	// Check for uncommitted changes (staged or unstaged), scoped to current dir
-	cmd := exec.Command("git", "status", "--porcelain", "--", ".")
-	cmd.Dir = path
-	out, err := cmd.Output()
+	files, err := GetModifiedFiles(path)
 	if err != nil {
-		return false, fmt.Errorf("git status failed: %w", err)
+		return false, err
 	}
-
-	return len(strings.TrimSpace(string(out))) > 0, nil
+	return len(files) > 0, nil
 }
 
-// GetModifiedFiles returns list of modified files in a git repo
+// GetModifiedFiles returns the modified files in a git repo, relative to
+// path, excluding paths matched by .lazyasignore
 func GetModifiedFiles(path string) ([]string, error) {
-	if !IsGitRepo(path) {
-		return nil, nil
+	return statusFiles(path, false)
+}
+
+// statusFiles lists changed paths under path. With allUntracked, files in
+// new directories are listed individually instead of as "dir/".
+func statusFiles(path string, allUntracked bool) ([]string, error) {
+	if !IsGitRepo(path) && !isLinkedCheckout(path) {
+		return nil, nil // Not a git repo, can't be modified
 	}
 
-	cmd := exec.Command("git", "status", "--porcelain", "--", ".")
+	// Porcelain paths are relative to the repo root; strip the skill's prefix
+	prefix, err := repoPrefix(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for uncommitted changes (staged or unstaged), scoped to current dir
+	args := []string{"status", "--porcelain"}
+	if allUntracked {
+		args = append(args, "--untracked-files=all")
+	}
+	cmd := exec.Command("git", append(args, "--", ".")...)
 	cmd.Dir = path
 	out, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("git status failed: %w", err)
 	}
 
+	ignore := LoadIgnore(path)
 	var files []string
 	for _, line := range strings.Split(string(out), "\n") {
-		line = strings.TrimSpace(line)
-		if len(line) > 3 {
-			files = append(files, line[3:]) // Skip status prefix
+		if len(line) <= 3 {
+			continue
 		}
+		file := line[3:] // Skip status prefix
+		if idx := strings.Index(file, " -> "); idx != -1 {
+			file = file[idx+4:] // Renames: keep the new path
+		}
+		file = strings.TrimPrefix(strings.Trim(file, "\""), prefix)
+		if ignore.Match(file) {
+			continue
+		}
+		files = append(files, file)
 	}
 	return files, nil
 }
 
+// repoPrefix returns path's location inside its worktree ("skills/foo/")
+func repoPrefix(path string) (string, error) {
+	prefix, err := gitOutput(path, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w", err)
+	}
+	return strings.TrimSpace(prefix), nil
+}
+
+// isLinkedCheckout reports whether path is a skill link pointing into a
+// git worktree (a subdirectory of a shared repo clone). Plain directories
+// are not checked so a skills dir inside e.g. a dotfiles repo is unaffected.
+func isLinkedCheckout(path string) bool {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return false
+	}
+	out, err := gitOutput(path, "rev-parse", "--is-inside-work-tree")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
 // GetDiff returns the diff of local changes
 func GetDiff(path string) (string, error) {
-	if !IsGitRepo(path) {
+	if !IsGitRepo(path) && !isLinkedCheckout(path) {
 		return "", nil
 	}
 
@@ -119,23 +165,58 @@ func RemoteHEAD(repoDir string) (string, error) {
 	return fields[0], nil
 }
 
-// IsRepoOutdated checks whether the local HEAD differs from the remote HEAD.
-// Returns false (not outdated) on any error so callers can silently ignore failures.
-func IsRepoOutdated(repoDir string) (bool, error) {
-	localHead, err := getHeadCommit(repoDir)
+// RemoteRef resolves ref on origin to a commit without fetching. ref may be
+// a branch or tag name; "" resolves the remote HEAD. Annotated tags resolve
+// to the commit they point at.
+func RemoteRef(repoDir, ref string) (string, error) {
+	if ref == "" {
+		return RemoteHEAD(repoDir)
+	}
+	cmd := exec.Command("git", "ls-remote", "origin", "refs/heads/"+ref, "refs/tags/"+ref, "refs/tags/"+ref+"^{}")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
 	if err != nil {
-		return false, err
+		return "", fmt.Errorf("git ls-remote failed: %w", err)
 	}
-	remoteHead, err := RemoteHEAD(repoDir)
+
+	var commit string
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		// A peeled tag wins over the tag object; otherwise the first match
+		if strings.HasSuffix(fields[1], "^{}") || commit == "" {
+			commit = fields[0]
+		}
+	}
+	if commit == "" {
+		return "", fmt.Errorf("ref %s not found on origin", ref)
+	}
+	return commit, nil
+}
+
+// RemoteBranchExists reports whether origin has a branch with the given name
+func RemoteBranchExists(repoDir, branch string) (bool, error) {
+	cmd := exec.Command("git", "ls-remote", "--heads", "origin", branch)
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("git ls-remote failed: %w", err)
 	}
-	return localHead != remoteHead, nil
+	return len(strings.TrimSpace(string(out))) > 0, nil
 }
 
-// Update pulls the latest changes for a skill
-// Returns error if there are local modifications (to prevent losing changes)
-func Update(skillPath, tag string) (*CloneResult, error) {
+// Update pulls the latest changes for a skill.
+// ref may be a tag or branch name; "" fetches the remote default branch.
+// Returns error if there are local modifications (to prevent losing changes);
+// edits to files matched by .lazyasignore are carried over the update.
+// A skill sharing its clone with others gets a clone of its own first.
+func Update(skillPath, ref string) (*CloneResult, error) {
+	if err := isolate(skillPath); err != nil {
+		return nil, err
+	}
+
 	// Check for local modifications first
 	modified, err := IsModified(skillPath)
 	if err != nil {
@@ -145,9 +226,16 @@ func Update(skillPath, tag string) (*CloneResult, error) {
 		return nil, fmt.Errorf("skill has local modifications; commit or discard changes before updating")
 	}
 
-	// Fetch and reset to the target tag (or default branch)
-	if tag != "" {
-		if err := runGit(skillPath, "fetch", "--depth", "1", "origin", tag); err != nil {
+	// Edits to tracked files matched by .lazyasignore are not modifications,
+	// but the reset below would still discard them
+	kept, err := ignoredEdits(skillPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch and reset to the target ref (or default branch)
+	if ref != "" {
+		if err := runGit(skillPath, "fetch", "--depth", "1", "origin", ref); err != nil {
 			return nil, fmt.Errorf("git fetch failed: %w", err)
 		}
 		if err := runGit(skillPath, "reset", "--hard", "FETCH_HEAD"); err != nil {
@@ -162,6 +250,10 @@ func Update(skillPath, tag string) (*CloneResult, error) {
 		}
 	}
 
+	if err := kept.restore(skillPath); err != nil {
+		return nil, fmt.Errorf("failed to restore ignored files: %w", err)
+	}
+
 	commit, err := getHeadCommit(skillPath)
 	if err != nil {
 		return nil, err
@@ -173,9 +265,86 @@ func Update(skillPath, tag string) (*CloneResult, error) {
 	}, nil
 }
 
+// keptFiles holds the content of edited files by path relative to the
+// skill; nil content marks a file deleted locally
+type keptFiles map[string]*keptFile
+
+type keptFile struct {
+	data []byte
+	mode os.FileMode
+}
+
+// ignoredEdits saves tracked files under skillPath that were edited or
+// deleted but are matched by .lazyasignore
+func ignoredEdits(skillPath string) (keptFiles, error) {
+	prefix, err := repoPrefix(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	out, err := gitOutput(skillPath, "status", "--porcelain", "-z", "--untracked-files=no", "--", ".")
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+
+	ignore := LoadIgnore(skillPath)
+	kept := keptFiles{}
+	fields := strings.Split(out, "\x00")
+	for i := 0; i < len(fields); i++ {
+		entry := fields[i]
+		if len(entry) <= 3 {
+			continue
+		}
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++ // skip the original path of a rename or copy
+		}
+		rel := strings.TrimPrefix(entry[3:], prefix)
+		if !ignore.Match(rel) {
+			continue
+		}
+		file := filepath.Join(skillPath, filepath.FromSlash(rel))
+		info, err := os.Lstat(file)
+		if os.IsNotExist(err) {
+			kept[rel] = nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		kept[rel] = &keptFile{data: data, mode: info.Mode().Perm()}
+	}
+	return kept, nil
+}
+
+// restore writes the saved files back under skillPath
+func (k keptFiles) restore(skillPath string) error {
+	for rel, f := range k {
+		file := filepath.Join(skillPath, filepath.FromSlash(rel))
+		if f == nil {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, f.data, f.mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ResetChanges discards all local modifications
 func ResetChanges(path string) error {
-	if !IsGitRepo(path) {
+	if !IsGitRepo(path) && !isLinkedCheckout(path) {
 		return fmt.Errorf("not a git repository")
 	}
 
@@ -187,3 +356,61 @@ func ResetChanges(path string) error {
 	}
 	return nil
 }
+
+// ShowRemoteFile reads one file from a remote repository without a full
+// checkout: a shallow, blob-less clone fetches only the blobs git show needs.
+func ShowRemoteFile(repoURL, ref, filePath string) ([]byte, error) {
+	tmpDir, err := os.MkdirTemp("", "lazyas-show-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	args := []string{"clone", "--depth", "1", "--filter=blob:none", "--no-checkout"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, repoURL, tmpDir)
+	if err := runGit(".", args...); err != nil {
+		return nil, fmt.Errorf("git clone failed: %w", err)
+	}
+
+	cmd := exec.Command("git", "show", "HEAD:"+filePath)
+	cmd.Dir = tmpDir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s not found in repository", filePath)
+	}
+	return out, nil
+}
+
+// CloneShallow clones the tip of repoURL's default branch into dir
+func CloneShallow(repoURL, dir string) error {
+	if err := runGit(".", "clone", "--depth", "1", repoURL, dir); err != nil {
+		return fmt.Errorf("git clone failed: %w", err)
+	}
+	return nil
+}
+
+// UserName returns git's user.name, or "" when it is not set
+func UserName() string {
+	out, err := gitOutput(".", "config", "user.name")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// InitRepo makes dir a git repository and commits its files with message
+func InitRepo(dir, message string) error {
+	if err := runGit(dir, "init", "-q"); err != nil {
+		return fmt.Errorf("git init failed: %w", err)
+	}
+	if err := runGit(dir, "add", "-A"); err != nil {
+		return fmt.Errorf("git add failed: %w", err)
+	}
+	if err := runGit(dir, "commit", "-q", "-m", message); err != nil {
+		return fmt.Errorf("git commit failed: %w", err)
+	}
+	return nil
+}
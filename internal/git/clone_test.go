@@ -0,0 +1,42 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdate_KeepsIgnoredEdits(t *testing.T) {
+	url, _ := newRemote(t,
+		map[string]string{"skills/a/SKILL.md": "a1\n", "skills/a/config.json": "{}\n", "skills/a/cache.txt": "c\n"},
+		map[string]string{"skills/a/SKILL.md": "a2\n"},
+	)
+	skillsDir, _ := installShared(t, url, "skills/a")
+	link := filepath.Join(skillsDir, "a")
+	if _, err := Update(link, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, link, map[string]string{
+		IgnoreFileName: "config.json\ncache.txt\n",
+		"config.json":  `{"token": "mine"}` + "\n",
+	})
+	if err := os.Remove(filepath.Join(link, "cache.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if modified, err := IsModified(link); err != nil || modified {
+		t.Fatalf("IsModified = %v, %v; want ignored edits not counted", modified, err)
+	}
+
+	if _, err := Update(link, "v2"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(link, "SKILL.md")); got != "a2\n" {
+		t.Errorf("SKILL.md = %q, want v2", got)
+	}
+	if got := readFile(t, filepath.Join(link, "config.json")); got != `{"token": "mine"}`+"\n" {
+		t.Errorf("config.json = %q, want the local edit kept", got)
+	}
+	if _, err := os.Stat(filepath.Join(link, "cache.txt")); !os.IsNotExist(err) {
+		t.Errorf("cache.txt came back after the update: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CommitChanges records the skill's local modifications as a commit on top
+// of HEAD without touching the worktree, the index or the current branch
+// (the clone may be shared with other skills). Returns the commit hash.
+func CommitChanges(skillPath, message string) (string, error) {
+	diff, err := CaptureDiff(skillPath)
+	if err != nil {
+		return "", err
+	}
+	if len(diff) == 0 {
+		return "", fmt.Errorf("no local modifications to commit")
+	}
+	prefix, err := repoPrefix(skillPath)
+	if err != nil {
+		return "", err
+	}
+
+	tmpIndex, err := os.CreateTemp("", "lazyas-index-")
+	if err != nil {
+		return "", err
+	}
+	tmpIndex.Close()
+	defer os.Remove(tmpIndex.Name())
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+tmpIndex.Name())
+	run := func(stdin []byte, args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = skillPath
+		cmd.Env = env
+		if stdin != nil {
+			cmd.Stdin = bytes.NewReader(stdin)
+		}
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("git %s failed: %w\n%s", args[0], err, stderr.String())
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	if _, err := run(nil, "read-tree", "HEAD"); err != nil {
+		return "", err
+	}
+	applyArgs := []string{"apply", "--cached", "--whitespace=nowarn"}
+	if prefix != "" {
+		applyArgs = append(applyArgs, "--directory="+filepath.ToSlash(filepath.Clean(prefix)))
+	}
+	if _, err := run(diff, append(applyArgs, "-")...); err != nil {
+		return "", err
+	}
+	tree, err := run(nil, "write-tree")
+	if err != nil {
+		return "", err
+	}
+	return run(nil, "commit-tree", tree, "-p", "HEAD", "-m", message)
+}
+
+// PushCommit pushes commit to a new branch on remote (a URL or remote name)
+func PushCommit(skillPath, remote, commit, branch string) error {
+	if err := runGit(skillPath, "push", remote, commit+":refs/heads/"+branch); err != nil {
+		return fmt.Errorf("git push failed: %w", err)
+	}
+	return nil
+}
+
+// DefaultBranch returns the default branch of origin ("main" when unknown)
+func DefaultBranch(repoDir string) string {
+	out, err := gitOutput(repoDir, "ls-remote", "--symref", "origin", "HEAD")
+	if err == nil {
+		for _, line := range nonEmptyLines(out) {
+			// "ref: refs/heads/main\tHEAD"
+			if ref, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok {
+				if idx := strings.IndexAny(ref, " \t"); idx != -1 {
+					return ref[:idx]
+				}
+			}
+		}
+	}
+	return "main"
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCommitChanges_Prefix(t *testing.T) {
+	url, _ := newRemote(t, map[string]string{"skills/a/SKILL.md": "a1\n", "skills/b/SKILL.md": "b1\n"})
+	gitIdentity(t)
+	skillsDir, repoDir := installShared(t, url, "skills/a")
+	link := filepath.Join(skillsDir, "a")
+	head := gitRun(t, "-C", repoDir, "rev-parse", "HEAD")
+	writeFiles(t, link, map[string]string{"SKILL.md": "a2\n", "new.txt": "new\n"})
+
+	commit, err := CommitChanges(link, "Improve a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent := gitRun(t, "-C", repoDir, "rev-parse", commit+"^"); parent != head {
+		t.Errorf("parent = %s, want HEAD %s", parent, head)
+	}
+	for path, want := range map[string]string{
+		"skills/a/SKILL.md": "a2",
+		"skills/a/new.txt":  "new",
+		"skills/b/SKILL.md": "b1",
+	} {
+		if got := gitRun(t, "-C", repoDir, "show", commit+":"+path); got != want {
+			t.Errorf("%s in the commit = %q, want %q", path, got, want)
+		}
+	}
+
+	// The skill's own index and HEAD are left alone
+	if got := gitRun(t, "-C", repoDir, "rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD moved to %s", got)
+	}
+	if staged := gitRun(t, "-C", repoDir, "diff", "--cached", "--name-only"); staged != "" {
+		t.Errorf("changes staged in the clone: %s", staged)
+	}
+}
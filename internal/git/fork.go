@@ -0,0 +1,132 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ForkOptions describes publishing a skill's current files to another repo
+type ForkOptions struct {
+	SkillPath string // installed skill (link into the source clone)
+	RepoURL   string // user-owned target repository
+	RepoDir   string // local clone of the target (created when missing)
+	Path      string // directory inside the target repo for the skill
+	Message   string // commit message
+}
+
+// Fork copies the skill's files (local modifications included, .lazyasignore
+// matches and .git excluded) into a clone of the target repository, commits
+// and pushes them. Empty target repositories are supported. Returns the new
+// commit and the skill's path inside the target clone.
+func Fork(opts ForkOptions) (*CloneResult, error) {
+	src, err := filepath.EvalSymlinks(opts.SkillPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve skill path: %w", err)
+	}
+
+	if _, err := os.Stat(opts.RepoDir); os.IsNotExist(err) {
+		if err := runGit(".", "clone", opts.RepoURL, opts.RepoDir); err != nil {
+			return nil, fmt.Errorf("git clone of %s failed: %w", opts.RepoURL, err)
+		}
+	} else if isSparse(opts.RepoDir) {
+		// Existing sparse clone (skills installed from this repo): include the
+		// path, or everything when the skill goes to the repository root
+		args := []string{"sparse-checkout", "add", opts.Path}
+		if opts.Path == "" {
+			args = []string{"sparse-checkout", "disable"}
+		}
+		if err := runGit(opts.RepoDir, args...); err != nil {
+			return nil, fmt.Errorf("%s failed: %w", strings.Join(args[:2], " "), err)
+		}
+	}
+
+	// The repository root is copied over in place; removing it would take
+	// the clone with it
+	dest := filepath.Join(opts.RepoDir, opts.Path)
+	pathspec := opts.Path
+	if pathspec == "" {
+		pathspec = "."
+	} else if err := os.RemoveAll(dest); err != nil {
+		return nil, err
+	}
+	if err := copySkill(src, dest, LoadIgnore(opts.SkillPath)); err != nil {
+		return nil, fmt.Errorf("failed to copy skill: %w", err)
+	}
+
+	if err := runGit(opts.RepoDir, "add", "-A", "--", pathspec); err != nil {
+		return nil, fmt.Errorf("git add failed: %w", err)
+	}
+	if err := runGit(opts.RepoDir, "commit", "-m", opts.Message, "--", pathspec); err != nil {
+		return nil, fmt.Errorf("git commit failed: %w", err)
+	}
+	if err := runGit(opts.RepoDir, "push", "-u", "origin", "HEAD"); err != nil {
+		return nil, fmt.Errorf("git push failed (the commit is kept in %s): %w", opts.RepoDir, err)
+	}
+
+	commit, err := getHeadCommit(opts.RepoDir)
+	if err != nil {
+		return nil, err
+	}
+	return &CloneResult{Commit: commit, Path: dest}, nil
+}
+
+// CurrentBranch returns the checked-out branch of a repository
+func CurrentBranch(repoDir string) (string, error) {
+	out, err := gitOutput(repoDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func isSparse(repoDir string) bool {
+	out, err := gitOutput(repoDir, "config", "--bool", "core.sparseCheckout")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// copySkill copies regular files from src to dest, preserving modes
+func copySkill(src, dest string, ignore *IgnoreList) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if d.Name() == ".git" || (rel != "." && ignore.Match(rel+"/")) {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(dest, rel), 0755)
+		}
+		// A per-skill ignore file is part of the skill; publish it
+		if !d.Type().IsRegular() || (rel != IgnoreFileName && ignore.Match(rel)) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(filepath.Join(dest, rel), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
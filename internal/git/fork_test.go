@@ -0,0 +1,71 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestFork_RepositoryRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "t")
+	t.Setenv("GIT_AUTHOR_EMAIL", "t@t")
+	t.Setenv("GIT_COMMITTER_NAME", "t")
+	t.Setenv("GIT_COMMITTER_EMAIL", "t@t")
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "fork.git")
+	seed := filepath.Join(root, "seed")
+	skill := filepath.Join(root, "skill")
+	for _, dir := range []string{seed, skill} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(seed, "LICENSE"), []byte("MIT\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skill, "SKILL.md"), []byte("# Skill\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", remote},
+		{"-C", seed, "init", "-q"},
+		{"-C", seed, "add", "-A"},
+		{"-C", seed, "commit", "-qm", "init"},
+		{"-C", seed, "push", "-q", remote, "HEAD"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	repoDir := filepath.Join(root, "clone")
+	result, err := Fork(ForkOptions{
+		SkillPath: skill,
+		RepoURL:   remote,
+		RepoDir:   repoDir,
+		Message:   "Fork skill",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Path != repoDir {
+		t.Errorf("path = %s, want %s", result.Path, repoDir)
+	}
+	for _, name := range []string{".git", "LICENSE", "SKILL.md"} {
+		if _, err := os.Stat(filepath.Join(repoDir, name)); err != nil {
+			t.Errorf("%s missing from the clone: %v", name, err)
+		}
+	}
+	out, err := exec.Command("git", "--git-dir", remote, "ls-tree", "--name-only", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "LICENSE\nSKILL.md\n" {
+		t.Errorf("pushed tree = %q", out)
+	}
+}
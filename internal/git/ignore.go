@@ -0,0 +1,91 @@
+package git
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName lists paths excluded from modification detection. It is
+// read from the skill directory and from the skills directory that holds
+// the skill link (~/.lazyas/skills/.lazyasignore applies to every skill).
+const IgnoreFileName = ".lazyasignore"
+
+// IgnoreList is a set of gitignore-style patterns relative to a skill root.
+// Supported: "#" comments, "name" (matches at any depth), "dir/" (directory
+// and everything below), "a/b.txt" and globs like "*.log" or "cache/**".
+type IgnoreList struct {
+	patterns []string
+}
+
+// LoadIgnore reads the per-skill and global ignore files for a skill path.
+// Missing files are not an error.
+func LoadIgnore(skillPath string) *IgnoreList {
+	l := &IgnoreList{}
+	l.readFile(filepath.Join(filepath.Dir(skillPath), IgnoreFileName))
+	l.readFile(filepath.Join(skillPath, IgnoreFileName))
+	return l
+}
+
+func (l *IgnoreList) readFile(file string) {
+	f, err := os.Open(file)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		l.patterns = append(l.patterns, strings.TrimPrefix(line, "/"))
+	}
+}
+
+// Match reports whether a slash-separated path relative to the skill root
+// is ignored; a trailing "/" marks a directory (git reports untracked
+// directories that way). The ignore file itself is always ignored.
+func (l *IgnoreList) Match(rel string) bool {
+	isDir := strings.HasSuffix(rel, "/")
+	rel = strings.TrimSuffix(rel, "/")
+	if rel == IgnoreFileName {
+		return true
+	}
+	for _, p := range l.patterns {
+		if matchIgnorePattern(p, rel, isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchIgnorePattern(pattern, rel string, isDir bool) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(strings.TrimSuffix(pattern, "/"), "/**")
+
+	// Patterns without a slash match any path component; others are anchored
+	var candidates []string
+	parts := strings.Split(rel, "/")
+	if strings.Contains(pattern, "/") {
+		for i := 1; i <= len(parts); i++ {
+			candidates = append(candidates, strings.Join(parts[:i], "/"))
+		}
+	} else {
+		candidates = parts
+	}
+
+	for i, c := range candidates {
+		if ok, _ := path.Match(pattern, c); ok {
+			// A dir-only pattern must match a directory, not a file
+			if dirOnly && !isDir && i == len(candidates)-1 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MergeResult is the outcome of merging upstream changes into a modified skill
+type MergeResult struct {
+	Commit    string   // new HEAD (the upstream version)
+	Merged    []string // local files merged cleanly on top of upstream
+	Conflicts []string // files left with conflict markers
+}
+
+// mergeSide is one version of a file; missing means it does not exist there
+type mergeSide struct {
+	data    []byte
+	missing bool
+}
+
+// MergeUpstream moves a locally modified skill to ref and re-applies the
+// local edits with a three-way merge per file, using baseCommit (the
+// installed commit) as the common ancestor. Files that cannot be merged
+// cleanly get conflict markers and are listed in the result. Like Update,
+// a skill sharing its clone gets one of its own first.
+func MergeUpstream(skillPath, baseCommit, ref string) (*MergeResult, error) {
+	if baseCommit == "" {
+		return nil, fmt.Errorf("no recorded base commit to merge from")
+	}
+	if conflicts, _ := conflictedFiles(skillPath); len(conflicts) > 0 {
+		return nil, fmt.Errorf("skill has unresolved conflicts in %s", strings.Join(conflicts, ", "))
+	}
+	if err := isolate(skillPath); err != nil {
+		return nil, err
+	}
+
+	files, err := statusFiles(skillPath, true)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := repoPrefix(skillPath)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"fetch", "--depth", "1", "origin"}
+	if ref != "" {
+		args = append(args, ref)
+	}
+	if err := runGit(skillPath, args...); err != nil {
+		return nil, fmt.Errorf("git fetch failed: %w", err)
+	}
+
+	// Capture all three sides before the worktree moves
+	type sides struct{ ours, base, theirs mergeSide }
+	versions := make(map[string]sides, len(files))
+	for _, f := range files {
+		var v sides
+		if data, err := os.ReadFile(filepath.Join(skillPath, f)); err == nil {
+			v.ours = mergeSide{data: data}
+		} else {
+			v.ours = mergeSide{missing: true}
+		}
+		v.base = showBlob(skillPath, baseCommit, prefix+f)
+		v.theirs = showBlob(skillPath, "FETCH_HEAD", prefix+f)
+		versions[f] = v
+	}
+
+	if err := runGit(skillPath, "reset", "--hard", "FETCH_HEAD"); err != nil {
+		return nil, fmt.Errorf("git reset failed: %w", err)
+	}
+
+	result := &MergeResult{}
+	for _, f := range files {
+		v := versions[f]
+		target := filepath.Join(skillPath, f)
+		conflict := false
+
+		switch {
+		case v.ours.missing:
+			// Deleted locally: honor it unless upstream changed the file
+			if !v.theirs.missing && !v.base.missing && bytes.Equal(v.theirs.data, v.base.data) {
+				os.Remove(target)
+			} else if !v.theirs.missing {
+				conflict = true // upstream version stays in place
+			}
+		case v.theirs.missing && !v.base.missing:
+			// Deleted upstream: fine if we never touched it
+			if !bytes.Equal(v.ours.data, v.base.data) {
+				conflict = true
+				if err := writeFile(target, v.ours.data); err != nil {
+					return nil, err
+				}
+			}
+		case v.theirs.missing:
+			// New local file
+			if err := writeFile(target, v.ours.data); err != nil {
+				return nil, err
+			}
+		default:
+			merged, n, err := mergeFile(v.ours.data, v.base.data, v.theirs.data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to merge %s: %w", f, err)
+			}
+			conflict = n > 0
+			if err := writeFile(target, merged); err != nil {
+				return nil, err
+			}
+		}
+
+		if conflict {
+			result.Conflicts = append(result.Conflicts, f)
+		} else {
+			result.Merged = append(result.Merged, f)
+		}
+	}
+
+	commit, err := getHeadCommit(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	result.Commit = commit
+	return result, nil
+}
+
+// HasConflictMarkers reports whether a file still contains merge markers
+func HasConflictMarkers(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkResolved clears unmerged index entries left under skillPath by a
+// conflicted stash pop; the working tree is not touched
+func MarkResolved(skillPath string) error {
+	if err := runGit(skillPath, "reset", "-q", "--", "."); err != nil {
+		return fmt.Errorf("git reset failed: %w", err)
+	}
+	return nil
+}
+
+func showBlob(dir, commit, path string) mergeSide {
+	data, err := exec.Command("git", "-C", dir, "show", commit+":"+path).Output()
+	if err != nil {
+		return mergeSide{missing: true}
+	}
+	return mergeSide{data: data}
+}
+
+// mergeFile runs git merge-file and returns the merged content and the
+// number of conflicts
+func mergeFile(ours, base, theirs []byte) ([]byte, int, error) {
+	dir, err := os.MkdirTemp("", "lazyas-merge-")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	paths := make([]string, 3)
+	for i, data := range [][]byte{ours, base, theirs} {
+		paths[i] = filepath.Join(dir, fmt.Sprint(i))
+		if err := os.WriteFile(paths[i], data, 0644); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	cmd := exec.Command("git", "merge-file", "-p", "-L", "local", "-L", "base", "-L", "upstream", paths[0], paths[1], paths[2])
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return out, exitErr.ExitCode(), nil // exit code = number of conflicts
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, 0, nil
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mergeBase = "one\ntwo\nthree\nfour\nfive\n"
+
+// installAtV1 installs skills/a from a remote whose v2 changes the last
+// line of SKILL.md, checks out v1 and returns the link and v1's commit
+func installAtV1(t *testing.T) (link, base string) {
+	t.Helper()
+	url, _ := newRemote(t,
+		map[string]string{"skills/a/SKILL.md": mergeBase},
+		map[string]string{"skills/a/SKILL.md": "one\ntwo\nthree\nfour\nfive upstream\n"},
+	)
+	gitIdentity(t)
+	skillsDir, repoDir := installShared(t, url, "skills/a")
+	link = filepath.Join(skillsDir, "a")
+	if _, err := Update(link, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	return link, gitRun(t, "-C", repoDir, "rev-parse", "HEAD")
+}
+
+func TestMergeUpstream_Clean(t *testing.T) {
+	link, base := installAtV1(t)
+	writeFiles(t, link, map[string]string{
+		"SKILL.md":  "one local\ntwo\nthree\nfour\nfive\n",
+		"notes.txt": "mine\n",
+	})
+
+	result, err := MergeUpstream(link, base, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) > 0 || !reflect.DeepEqual(result.Merged, []string{"SKILL.md", "notes.txt"}) {
+		t.Errorf("result = %+v, want both files merged cleanly", result)
+	}
+	if got := readFile(t, filepath.Join(link, "SKILL.md")); got != "one local\ntwo\nthree\nfour\nfive upstream\n" {
+		t.Errorf("SKILL.md = %q, want both edits", got)
+	}
+	if got := readFile(t, filepath.Join(link, "notes.txt")); got != "mine\n" {
+		t.Errorf("notes.txt = %q, want the new local file kept", got)
+	}
+	if result.Commit == base {
+		t.Error("HEAD did not move to v2")
+	}
+}
+
+func TestMergeUpstream_Conflict(t *testing.T) {
+	link, base := installAtV1(t)
+	writeFiles(t, link, map[string]string{"SKILL.md": "one\ntwo\nthree\nfour\nfive local\n"})
+
+	result, err := MergeUpstream(link, base, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Conflicts, []string{"SKILL.md"}) {
+		t.Errorf("conflicts = %v, want SKILL.md", result.Conflicts)
+	}
+	got := readFile(t, filepath.Join(link, "SKILL.md"))
+	if !HasConflictMarkers(filepath.Join(link, "SKILL.md")) || !strings.Contains(got, "five local") || !strings.Contains(got, "five upstream") {
+		t.Errorf("SKILL.md = %q, want both sides between conflict markers", got)
+	}
+}
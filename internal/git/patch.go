@@ -0,0 +1,98 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// CaptureDiff returns the skill's local modifications (tracked, deleted and
+// untracked files, minus .lazyasignore matches) as a binary-safe patch with
+// paths relative to the skill directory. The real index is not touched.
+func CaptureDiff(skillPath string) ([]byte, error) {
+	files, err := statusFiles(skillPath, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	// Stage everything into a throwaway index and diff that against HEAD
+	tmp, err := os.CreateTemp("", "lazyas-index-")
+	if err != nil {
+		return nil, err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+tmp.Name())
+	run := func(args ...string) ([]byte, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = skillPath
+		cmd.Env = env
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("git %s failed: %w\n%s", args[0], err, stderr.String())
+		}
+		return out, nil
+	}
+
+	if _, err := run("read-tree", "HEAD"); err != nil {
+		return nil, err
+	}
+	if _, err := run(append([]string{"add", "-A", "--"}, files...)...); err != nil {
+		return nil, err
+	}
+	return run("diff", "--cached", "--binary", "--relative", "HEAD", "--", ".")
+}
+
+// ApplyPatch applies a patch captured by CaptureDiff to the skill. When it
+// does not apply cleanly a three-way apply is attempted; files left with
+// conflict markers are returned.
+func ApplyPatch(skillPath string, patch []byte) ([]string, error) {
+	prefix, err := repoPrefix(skillPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "lazyas-patch-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(patch); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	tmp.Close()
+
+	args := []string{"apply", "--whitespace=nowarn"}
+	if prefix != "" {
+		args = append(args, "--directory="+filepath.ToSlash(filepath.Clean(prefix)))
+	}
+
+	if err := runGit(skillPath, append(args, tmp.Name())...); err == nil {
+		return nil, nil
+	}
+
+	// A three-way apply needs the touched files to match the index
+	if modified, _ := IsModified(skillPath); modified {
+		return nil, fmt.Errorf("patch does not apply on top of the current local modifications; discard or stash them first")
+	}
+
+	// Fall back to a three-way apply; conflicts leave markers behind
+	threeWayErr := runGit(skillPath, append(args, "--3way", tmp.Name())...)
+	conflicts, _ := conflictedFiles(skillPath)
+	if len(conflicts) > 0 {
+		return conflicts, nil
+	}
+	if threeWayErr != nil {
+		return nil, fmt.Errorf("patch does not apply: %w", threeWayErr)
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+
+	"lazyas/internal/progress"
+)
+
+// progressLine matches the phases git prints with --progress, e.g.
+// "Receiving objects:  45% (450/1000)" or "remote: Counting objects: 12%"
+var progressLine = regexp.MustCompile(`^(?:remote: )?([A-Z][a-z]+(?: [a-z]+)*):\s+(\d+)%`)
+
+// reportPhase shows a phase of an install; percent < 0 when unknown
+func reportPhase(t *progress.Tracker, phase string, percent int) {
+	if percent < 0 {
+		t.SetTotal(0)
+		percent = 0
+	} else {
+		t.SetTotal(100)
+	}
+	t.Set(percent, phase)
+}
+
+// runGitProgress runs a git command that accepts --progress and reports
+// its phases and percentages into t. Without a tracker it is runGit.
+func runGitProgress(dir string, t *progress.Tracker, args ...string) error {
+	if t == nil {
+		return runGit(dir, args...)
+	}
+	// --progress goes right after the subcommand
+	cmd := exec.Command("git", append([]string{args[0], "--progress"}, args[1:]...)...)
+	cmd.Dir = dir
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// Progress lines are rewritten in place with \r; keep the others for
+	// the error message
+	var messages bytes.Buffer
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := progressLine.FindStringSubmatch(line); m != nil {
+			percent, _ := strconv.Atoi(m[2])
+			reportPhase(t, m[1], percent)
+			continue
+		}
+		if line != "" {
+			messages.WriteString(line + "\n")
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if messages.Len() > 0 {
+			return fmt.Errorf("%w\n%s", err, messages.String())
+		}
+		return err
+	}
+	return nil
+}
+
+// scanProgressLines splits on \n and on the \r git uses to redraw a line
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Provenance is where an installed commit is found on its remote
+type Provenance struct {
+	Ref       string // branch or tag whose tip is the commit, if any
+	Reachable bool   // the commit is a tip or in the history of one
+}
+
+// CheckProvenance reports, for each commit, whether it still exists in
+// repoURL. Tips are matched with ls-remote; other commits are looked up in
+// mirrorDir, a bare commit-only mirror of the remote that is created or
+// refreshed as needed. A commit found in neither was force-pushed away or
+// its branch deleted.
+func CheckProvenance(repoURL, mirrorDir string, commits []string) (map[string]Provenance, error) {
+	out, err := exec.Command("git", "ls-remote", repoURL).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-remote failed: %w", err)
+	}
+
+	tips := make(map[string]string) // commit -> branch or tag name
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		ref, ok := strings.CutPrefix(fields[1], "refs/tags/")
+		if !ok {
+			if ref, ok = strings.CutPrefix(fields[1], "refs/heads/"); !ok {
+				continue
+			}
+		}
+		if _, seen := tips[fields[0]]; !seen {
+			tips[fields[0]] = strings.TrimSuffix(ref, "^{}")
+		}
+	}
+
+	result := make(map[string]Provenance, len(commits))
+	var rest []string
+	for _, commit := range commits {
+		if ref, ok := tips[commit]; ok {
+			result[commit] = Provenance{Ref: ref, Reachable: true}
+		} else {
+			rest = append(rest, commit)
+		}
+	}
+	if len(rest) == 0 {
+		return result, nil
+	}
+
+	if err := syncMirror(repoURL, mirrorDir); err != nil {
+		return nil, err
+	}
+	for _, commit := range rest {
+		cmd := exec.Command("git", "for-each-ref", "--count=1", "--contains", commit, "refs/heads", "refs/tags")
+		cmd.Dir = mirrorDir
+		// An unknown object fails: nothing upstream leads to the commit
+		out, err := cmd.Output()
+		result[commit] = Provenance{Reachable: err == nil && len(strings.TrimSpace(string(out))) > 0}
+	}
+	return result, nil
+}
+
+// syncMirror clones or refreshes a bare mirror of the remote's branches and
+// tags. Only commits are fetched; trees and files are left out.
+func syncMirror(repoURL, mirrorDir string) error {
+	if _, err := os.Stat(mirrorDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(mirrorDir), 0755); err != nil {
+			return err
+		}
+		if err := runGit(".", "clone", "--quiet", "--bare", "--filter=tree:0", repoURL, mirrorDir); err != nil {
+			os.RemoveAll(mirrorDir)
+			return fmt.Errorf("git clone failed: %w", err)
+		}
+		return nil
+	}
+	if err := runGit(mirrorDir, "fetch", "--quiet", "--prune", "--filter=tree:0", repoURL,
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"); err != nil {
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PublishBranch prepares the working clone at repoDir for publishing to
+// repoURL: it clones when missing, fetches, and checks out a fresh branch
+// from the default branch of origin, discarding earlier unpushed work.
+// Empty repositories get an orphan branch.
+func PublishBranch(repoURL, repoDir, branch string) error {
+	if _, err := os.Stat(repoDir); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(repoDir), 0755); err != nil {
+			return err
+		}
+		if err := runGit(".", "clone", "-q", repoURL, repoDir); err != nil {
+			return fmt.Errorf("git clone of %s failed: %w", repoURL, err)
+		}
+	} else {
+		if err := runGit(repoDir, "remote", "set-url", "origin", repoURL); err != nil {
+			return fmt.Errorf("failed to set origin: %w", err)
+		}
+		if err := runGit(repoDir, "fetch", "-q", "--prune", "origin"); err != nil {
+			return fmt.Errorf("git fetch failed: %w", err)
+		}
+	}
+
+	base := "origin/" + DefaultBranch(repoDir)
+	if _, err := gitOutput(repoDir, "rev-parse", "--verify", "-q", base); err != nil {
+		if err := runGit(repoDir, "checkout", "-q", "--orphan", branch); err != nil {
+			return fmt.Errorf("git checkout failed: %w", err)
+		}
+		return runGit(repoDir, "rm", "-rfq", "--ignore-unmatch", ".")
+	}
+	if err := runGit(repoDir, "checkout", "-q", "-f", "-B", branch, base); err != nil {
+		return fmt.Errorf("git checkout failed: %w", err)
+	}
+	return runGit(repoDir, "clean", "-q", "-fd")
+}
+
+// PublishSkill replaces the directory path inside repoDir with the files of
+// the skill at skillPath (.lazyasignore matches and .git excluded)
+func PublishSkill(skillPath, repoDir, path string) error {
+	src, err := filepath.EvalSymlinks(skillPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve skill path: %w", err)
+	}
+	dest := filepath.Join(repoDir, path)
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := copySkill(src, dest, LoadIgnore(skillPath)); err != nil {
+		return fmt.Errorf("failed to copy skill: %w", err)
+	}
+	return nil
+}
+
+// CommitAll commits every change in repoDir and returns the new commit.
+// It fails when there is nothing to commit.
+func CommitAll(repoDir, message string) (string, error) {
+	if err := runGit(repoDir, "add", "-A"); err != nil {
+		return "", fmt.Errorf("git add failed: %w", err)
+	}
+	if out, _ := gitOutput(repoDir, "status", "--porcelain"); len(nonEmptyLines(out)) == 0 {
+		return "", fmt.Errorf("nothing to commit: the repository already has these files")
+	}
+	if err := runGit(repoDir, "commit", "-q", "-m", message); err != nil {
+		return "", fmt.Errorf("git commit failed: %w", err)
+	}
+	return getHeadCommit(repoDir)
+}
+
+// PushBranch pushes branch to origin
+func PushBranch(repoDir, branch string) error {
+	if err := runGit(repoDir, "push", "-u", "origin", branch); err != nil {
+		return fmt.Errorf("git push failed (the commit is kept in %s): %w", repoDir, err)
+	}
+	return nil
+}
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+
+	"lazyas/internal/progress"
 )
 
 // RepoDirName derives a filesystem-safe name from a repo URL.
@@ -43,24 +47,44 @@ type RepoInstallOptions struct {
 	RepoDir   string // full path to repo clone (e.g., ~/.lazyas/repos/anthropics-skills)
 	SkillName string // skill name
 	SkillLink string // full path to symlink target (e.g., ~/.lazyas/skills/my-skill)
+
+	// Check, when set, inspects the checked-out skill before it is linked.
+	// A non-nil error aborts the install.
+	Check func(skillPath string) error
+
+	// Progress, when set, receives the clone and checkout phases. A
+	// cancelled tracker stops the install before the skill is linked.
+	Progress *progress.Tracker
 }
 
 // RepoInstall ensures the repo clone exists, adds the skill path to sparse
-// checkout, validates SKILL.md, and creates the symlink.
+// checkout, validates SKILL.md, and creates the symlink. Paths that would
+// leave the repository or the skills directory are refused.
 func RepoInstall(opts RepoInstallOptions) (*CloneResult, error) {
+	if err := validateLinkName(opts.SkillName); err != nil {
+		return nil, err
+	}
+	if filepath.Base(opts.SkillLink) != opts.SkillName {
+		return nil, fmt.Errorf("skill link %s does not match skill name %s", opts.SkillLink, opts.SkillName)
+	}
+	if err := ValidateRelPath(opts.Path); err != nil {
+		return nil, err
+	}
+
 	sparse := opts.Path != ""
 	isNew := false
 
 	// Step 1: Ensure repo clone exists
 	if _, err := os.Stat(opts.RepoDir); os.IsNotExist(err) {
 		isNew = true
-		if err := ensureRepoClone(opts.RepoURL, opts.RepoDir, sparse); err != nil {
+		if err := ensureRepoClone(opts.RepoURL, opts.RepoDir, sparse, opts.Progress); err != nil {
 			return nil, err
 		}
 	}
 
 	// Step 2: Add sparse path
 	if sparse {
+		reportPhase(opts.Progress, "Checking out files", -1)
 		if isNew {
 			// First clone was --sparse, set the path
 			if err := runGit(opts.RepoDir, "sparse-checkout", "set", opts.Path); err != nil {
@@ -87,7 +111,7 @@ func RepoInstall(opts RepoInstallOptions) (*CloneResult, error) {
 		// Existing sparse clones can be stale (new skill path added upstream).
 		// Try a fast-forward refresh once and re-apply sparse checkout.
 		if sparse && !isNew {
-			if err := refreshExistingClone(opts.RepoDir); err != nil {
+			if err := refreshExistingClone(opts.RepoDir, opts.Progress); err != nil {
 				return nil, fmt.Errorf("skill path %s not found in repository after checkout (failed to refresh existing clone: %w)", opts.Path, err)
 			}
 			if err := runGit(opts.RepoDir, "sparse-checkout", "add", opts.Path); err != nil {
@@ -103,12 +127,37 @@ func RepoInstall(opts RepoInstallOptions) (*CloneResult, error) {
 		return nil, fmt.Errorf("failed to validate skill path %s: %w", opts.Path, err)
 	}
 
+	// The skill directory may itself be a symlink in the repository
+	realRepo, err := filepath.EvalSymlinks(opts.RepoDir)
+	if err != nil {
+		return nil, err
+	}
+	realSkill, err := filepath.EvalSymlinks(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	if !within(realRepo, realSkill) {
+		return nil, &ValidationError{Path: skillPath, Message: fmt.Sprintf("skill path %s links outside the repository", opts.Path)}
+	}
+
 	// Step 4: Validate SKILL.md exists
+	reportPhase(opts.Progress, "Validating", -1)
 	if err := ValidateSkill(skillPath); err != nil {
 		return nil, err
 	}
 
+	// Step 4b: Caller-supplied content check (e.g. risk scan)
+	if opts.Check != nil {
+		if err := opts.Check(skillPath); err != nil {
+			return nil, err
+		}
+	}
+
 	// Step 5: Create symlink
+	if err := opts.Progress.Err(); err != nil {
+		return nil, err
+	}
+	reportPhase(opts.Progress, "Linking", -1)
 	// Remove any existing item at the symlink path (symlink or dir)
 	if info, err := os.Lstat(opts.SkillLink); err == nil {
 		if info.Mode()&os.ModeSymlink != 0 {
@@ -136,11 +185,53 @@ func RepoInstall(opts RepoInstallOptions) (*CloneResult, error) {
 	}, nil
 }
 
+// Prefetch makes sure the clone of a repo exists and has the given skill
+// paths checked out, so installing those skills later downloads nothing.
+// An existing clone is only fetched: its checkout, which installed skills
+// link into, stays where it is. A "" path means the repo is the skill.
+func Prefetch(repoURL, repoDir string, paths []string) error {
+	for _, p := range paths {
+		if err := ValidateRelPath(p); err != nil {
+			return err
+		}
+	}
+	sparse := !slices.Contains(paths, "")
+
+	if _, err := os.Stat(repoDir); os.IsNotExist(err) {
+		if err := ensureRepoClone(repoURL, repoDir, sparse, nil); err != nil {
+			return err
+		}
+		if sparse && len(paths) > 0 {
+			if err := runGit(repoDir, append([]string{"sparse-checkout", "set"}, paths...)...); err != nil {
+				return fmt.Errorf("sparse-checkout set failed: %w", err)
+			}
+		}
+		return nil
+	}
+
+	if err := runGit(repoDir, "fetch", "--depth", "1", "origin"); err != nil {
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+	// A full clone already has every path checked out
+	if sparse && len(paths) > 0 && isSparseCheckout(repoDir) {
+		if err := runGit(repoDir, append([]string{"sparse-checkout", "add"}, paths...)...); err != nil {
+			return fmt.Errorf("sparse-checkout add failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// isSparseCheckout reports whether a clone has sparse checkout enabled
+func isSparseCheckout(repoDir string) bool {
+	out, err := exec.Command("git", "-C", repoDir, "config", "--bool", "core.sparseCheckout").Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
 // refreshExistingClone fast-forwards an existing clone to origin without
 // destructive resets. This is used when sparse checkout paths were added
 // upstream after the local clone was first created.
-func refreshExistingClone(repoDir string) error {
-	if err := runGit(repoDir, "fetch", "--depth", "1", "origin"); err != nil {
+func refreshExistingClone(repoDir string, t *progress.Tracker) error {
+	if err := runGitProgress(repoDir, t, "fetch", "--depth", "1", "origin"); err != nil {
 		return fmt.Errorf("git fetch failed: %w", err)
 	}
 	if err := runGit(repoDir, "merge", "--ff-only", "FETCH_HEAD"); err != nil {
@@ -161,29 +252,30 @@ func refreshExistingClone(repoDir string) error {
 }
 
 // ensureRepoClone clones a repository. If sparse is true, uses --sparse for
-// cone-mode sparse checkout (only root files checked out initially).
-func ensureRepoClone(repoURL, repoDir string, sparse bool) error {
+// cone-mode sparse checkout (only root files checked out initially). t, when
+// set, receives git's progress.
+func ensureRepoClone(repoURL, repoDir string, sparse bool, t *progress.Tracker) error {
 	if err := os.MkdirAll(filepath.Dir(repoDir), 0755); err != nil {
 		return fmt.Errorf("failed to create repos directory: %w", err)
 	}
 
 	if !sparse {
 		// Full clone (the repo IS the skill)
-		if err := runGit(".", "clone", repoURL, repoDir); err != nil {
+		if err := runGitProgress(".", t, "clone", repoURL, repoDir); err != nil {
 			return fmt.Errorf("git clone failed: %w", err)
 		}
 		return nil
 	}
 
 	// Try sparse clone (cone mode)
-	err := runGit(".", "clone", "--sparse", repoURL, repoDir)
+	err := runGitProgress(".", t, "clone", "--sparse", repoURL, repoDir)
 	if err == nil {
 		return nil
 	}
 
 	// Fallback: --no-checkout then init sparse-checkout manually
 	os.RemoveAll(repoDir)
-	if err := runGit(".", "clone", "--no-checkout", repoURL, repoDir); err != nil {
+	if err := runGitProgress(".", t, "clone", "--no-checkout", repoURL, repoDir); err != nil {
 		return fmt.Errorf("git clone --no-checkout failed: %w", err)
 	}
 	if err := runGit(repoDir, "sparse-checkout", "init", "--cone"); err != nil {
@@ -197,3 +289,11 @@ func ensureRepoClone(repoURL, repoDir string, sparse bool) error {
 
 	return nil
 }
+
+// SetRemoteURL points a clone's origin at a new URL
+func SetRemoteURL(repoDir, repoURL string) error {
+	if err := runGit(repoDir, "remote", "set-url", "origin", repoURL); err != nil {
+		return fmt.Errorf("git remote set-url failed: %w", err)
+	}
+	return nil
+}
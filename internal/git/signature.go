@@ -0,0 +1,86 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Signature is git's verdict on the signature of a commit. GPG, SSH and
+// x509 signatures (including keyless gitsign ones) are checked with the
+// verification setup of the user's git config.
+type Signature struct {
+	Status string // %G? code: G good, U good but key validity unknown, B bad, X/Y expired, R revoked, E cannot be checked, N unsigned
+	Signer string // key owner (GPG, SSH) or certificate subject (x509)
+	Key    string // GPG key ID or SSH key fingerprint
+	Issuer string // OIDC issuer of a keyless signature
+}
+
+// keylessSigner matches gitsign's "Good signature from [subject](issuer)"
+var keylessSigner = regexp.MustCompile(`Good signature from \[([^\]]+)\]\(([^)]+)\)`)
+
+// CommitSignature verifies the signature of commit in the repository at dir
+func CommitSignature(dir, commit string) (Signature, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%G?%x00%GS%x00%GK%x00%GG", commit)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return Signature{}, fmt.Errorf("git log failed: %w", err)
+	}
+
+	fields := strings.SplitN(string(out), "\x00", 4)
+	for len(fields) < 4 {
+		fields = append(fields, "")
+	}
+	sig := Signature{
+		Status: strings.TrimSpace(fields[0]),
+		Signer: strings.TrimSpace(fields[1]),
+		Key:    strings.TrimSpace(fields[2]),
+	}
+	if m := keylessSigner.FindStringSubmatch(fields[3]); m != nil {
+		sig.Signer, sig.Issuer = m[1], m[2]
+	}
+	return sig, nil
+}
+
+// Verified reports whether the signature is good. A good signature by a
+// key whose validity git cannot establish counts; the identity says so.
+func (s Signature) Verified() bool {
+	return s.Status == "G" || s.Status == "U"
+}
+
+// Identity describes who signed: "subject via issuer" for keyless
+// signatures, otherwise the signer and key
+func (s Signature) Identity() string {
+	switch {
+	case s.Issuer != "":
+		return s.Signer + " via " + s.Issuer
+	case s.Signer != "" && s.Key != "":
+		return s.Signer + " (key " + s.Key + ")"
+	case s.Key != "":
+		return "key " + s.Key
+	}
+	return s.Signer
+}
+
+// Describe explains the status in a few words
+func (s Signature) Describe() string {
+	switch s.Status {
+	case "G":
+		return "good signature"
+	case "U":
+		return "good signature, key validity unknown"
+	case "B":
+		return "BAD signature"
+	case "X":
+		return "good signature that has expired"
+	case "Y":
+		return "good signature by an expired key"
+	case "R":
+		return "good signature by a revoked key"
+	case "E":
+		return "cannot be checked (missing key or verifier)"
+	}
+	return "unsigned"
+}
@@ -1,11 +1,15 @@
 package git
 
 import (
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
-// ValidateSkill checks if a skill directory is valid
+// ValidateSkill checks if a skill directory is valid: it has a SKILL.md
+// and no symlink inside it points outside the skill directory
 func ValidateSkill(skillPath string) error {
 	// Check for SKILL.md
 	skillMD := filepath.Join(skillPath, "SKILL.md")
@@ -15,9 +19,77 @@ func ValidateSkill(skillPath string) error {
 			Message: "SKILL.md not found",
 		}
 	}
+	return checkSymlinks(skillPath)
+}
+
+// checkSymlinks rejects symlinks under skillPath whose target resolves
+// outside it; agents and copies would follow them to arbitrary files
+func checkSymlinks(skillPath string) error {
+	root, err := filepath.EvalSymlinks(skillPath)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		target, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			// Dangling: judge the link text itself
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if target = link; !filepath.IsAbs(link) {
+				target = filepath.Join(filepath.Dir(path), link)
+			}
+		}
+		if !within(root, target) {
+			rel, _ := filepath.Rel(root, path)
+			return &ValidationError{
+				Path:    skillPath,
+				Message: fmt.Sprintf("%s links outside the skill directory", filepath.ToSlash(rel)),
+			}
+		}
+		return nil
+	})
+}
+
+// ValidateRelPath checks that path, a location inside a repository, is
+// relative and has no ".." components, so it cannot leave the repository
+func ValidateRelPath(path string) error {
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") || strings.HasPrefix(path, `\`) {
+		return fmt.Errorf("path %s must be relative to the repository", path)
+	}
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == ".." {
+			return fmt.Errorf("path %s leaves the repository", path)
+		}
+	}
 	return nil
 }
 
+// validateLinkName checks that a skill name is a single path element, so
+// its link stays inside the skills directory
+func validateLinkName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid skill name %q", name)
+	}
+	return nil
+}
+
+// within reports whether path is root or lies below it
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
 // ValidationError represents a skill validation error
 type ValidationError struct {
 	Path    string
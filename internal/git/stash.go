@@ -0,0 +1,88 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StashUpdateResult is the outcome of an update that preserved local edits
+type StashUpdateResult struct {
+	Commit    string   // HEAD after the update
+	Stashed   bool     // local changes were stashed and reapplied
+	Conflicts []string // files (relative to the skill) left with conflict markers
+	StashRef  string   // stash commit kept for recovery when reapplying conflicted
+}
+
+// UpdateWithStash stashes the skill's local modifications (including
+// untracked files), updates to ref and reapplies the stash. Conflicts are
+// reported in the result rather than as an error; the stash is then kept so
+// nothing is lost. Skills without modifications are updated normally, and
+// like Update a skill sharing its clone gets one of its own first.
+func UpdateWithStash(skillPath, ref string) (*StashUpdateResult, error) {
+	if err := isolate(skillPath); err != nil {
+		return nil, err
+	}
+	modified, err := IsModified(skillPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for modifications: %w", err)
+	}
+	if !modified {
+		result, err := Update(skillPath, ref)
+		if err != nil {
+			return nil, err
+		}
+		return &StashUpdateResult{Commit: result.Commit}, nil
+	}
+
+	if conflicts, _ := conflictedFiles(skillPath); len(conflicts) > 0 {
+		return nil, fmt.Errorf("skill has unresolved conflicts in %s", strings.Join(conflicts, ", "))
+	}
+
+	// Stash only this skill's directory
+	if err := runGit(skillPath, "stash", "push", "--include-untracked", "-m", "lazyas: local changes before update", "--", "."); err != nil {
+		return nil, fmt.Errorf("git stash failed: %w", err)
+	}
+	stashRef, err := gitOutput(skillPath, "rev-parse", "stash@{0}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate stash: %w", err)
+	}
+	stashRef = strings.TrimSpace(stashRef)
+
+	result, err := Update(skillPath, ref)
+	if err != nil {
+		// Put the edits back where they were before giving up
+		if popErr := runGit(skillPath, "stash", "pop", "--index"); popErr != nil {
+			return nil, fmt.Errorf("%w (local changes kept in stash %s)", err, stashRef[:7])
+		}
+		return nil, err
+	}
+
+	res := &StashUpdateResult{Commit: result.Commit, Stashed: true}
+	if popErr := runGit(skillPath, "stash", "pop"); popErr != nil {
+		conflicts, _ := conflictedFiles(skillPath)
+		if len(conflicts) == 0 {
+			return nil, fmt.Errorf("updated to %s but failed to reapply local changes (kept in stash %s): %w",
+				result.Commit[:7], stashRef[:7], popErr)
+		}
+		res.Conflicts = conflicts
+		res.StashRef = stashRef
+	}
+	return res, nil
+}
+
+// conflictedFiles lists unmerged paths under skillPath, relative to it
+func conflictedFiles(skillPath string) ([]string, error) {
+	prefix, err := repoPrefix(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	out, err := gitOutput(skillPath, "diff", "--name-only", "--diff-filter=U", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range nonEmptyLines(out) {
+		files = append(files, strings.TrimPrefix(f, prefix))
+	}
+	return files, nil
+}
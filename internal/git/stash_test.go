@@ -0,0 +1,51 @@
+package git
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateWithStash_RoundTrip(t *testing.T) {
+	link, _ := installAtV1(t)
+	writeFiles(t, link, map[string]string{
+		"SKILL.md":  "one local\ntwo\nthree\nfour\nfive\n",
+		"notes.txt": "mine\n",
+	})
+
+	result, err := UpdateWithStash(link, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Stashed || len(result.Conflicts) > 0 || result.StashRef != "" {
+		t.Errorf("result = %+v, want the stash reapplied cleanly", result)
+	}
+	if got := readFile(t, filepath.Join(link, "SKILL.md")); got != "one local\ntwo\nthree\nfour\nfive upstream\n" {
+		t.Errorf("SKILL.md = %q, want both edits", got)
+	}
+	if got := readFile(t, filepath.Join(link, "notes.txt")); got != "mine\n" {
+		t.Errorf("notes.txt = %q, want the untracked file back", got)
+	}
+	if stashes := gitRun(t, "-C", link, "stash", "list"); stashes != "" {
+		t.Errorf("stash left behind: %s", stashes)
+	}
+}
+
+func TestUpdateWithStash_ConflictKeepsStash(t *testing.T) {
+	link, _ := installAtV1(t)
+	writeFiles(t, link, map[string]string{"SKILL.md": "one\ntwo\nthree\nfour\nfive local\n"})
+
+	result, err := UpdateWithStash(link, "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Conflicts, []string{"SKILL.md"}) || result.StashRef == "" {
+		t.Errorf("result = %+v, want SKILL.md conflicted and the stash kept", result)
+	}
+	if !HasConflictMarkers(filepath.Join(link, "SKILL.md")) {
+		t.Error("SKILL.md has no conflict markers")
+	}
+	if _, err := UpdateWithStash(link, "v2"); err == nil {
+		t.Error("expected an update to refuse unresolved conflicts")
+	}
+}
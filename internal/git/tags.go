@@ -0,0 +1,151 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"lazyas/internal/semver"
+)
+
+// RemoteTag is a tag published by a repository's origin
+type RemoteTag struct {
+	Name   string // tag name without refs/tags/
+	Commit string // commit the tag points to (annotated tags are peeled)
+}
+
+// ListRemoteTags returns the tags published by origin, in the order git
+// reports them. Annotated tags resolve to the commit they point at.
+func ListRemoteTags(repoDir string) ([]RemoteTag, error) {
+	cmd := exec.Command("git", "ls-remote", "--tags", "origin")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-remote failed: %w", err)
+	}
+
+	var tags []RemoteTag
+	index := make(map[string]int)
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "refs/tags/")
+		peeled := strings.HasSuffix(name, "^{}")
+		name = strings.TrimSuffix(name, "^{}")
+
+		if i, ok := index[name]; ok {
+			if peeled {
+				tags[i].Commit = fields[0]
+			}
+			continue
+		}
+		index[name] = len(tags)
+		tags = append(tags, RemoteTag{Name: name, Commit: fields[0]})
+	}
+	return tags, nil
+}
+
+// FilterSkillTags narrows a monorepo's tags to those scoped to one skill,
+// using the common "<path>/v1.0.0", "<name>/v1.0.0", "<name>@1.0.0" and
+// "<name>-v1.0.0" conventions. When no tag is scoped to the skill, all tags
+// are returned (the repo versions as a whole).
+func FilterSkillTags(tags []RemoteTag, skillName, sourcePath string) []RemoteTag {
+	var prefixes []string
+	if sourcePath != "" {
+		prefixes = append(prefixes, strings.Trim(sourcePath, "/")+"/")
+	}
+	if skillName != "" {
+		prefixes = append(prefixes, skillName+"/", skillName+"@", skillName+"-v")
+	}
+	if sourcePath != "" {
+		if base := path.Base(sourcePath); base != skillName {
+			prefixes = append(prefixes, base+"/", base+"@", base+"-v")
+		}
+	}
+
+	var scoped []RemoteTag
+	for _, t := range tags {
+		for _, p := range prefixes {
+			if strings.HasPrefix(t.Name, p) {
+				scoped = append(scoped, t)
+				break
+			}
+		}
+	}
+	if len(scoped) == 0 {
+		return tags
+	}
+	return scoped
+}
+
+// SkillVersions lists the versions available for an installed skill,
+// newest first. skillDir is any path inside the skill's repo clone.
+func SkillVersions(skillDir, skillName, sourcePath string) ([]RemoteTag, error) {
+	tags, err := ListRemoteTags(skillDir)
+	if err != nil {
+		return nil, err
+	}
+	tags = FilterSkillTags(tags, skillName, sourcePath)
+
+	names := make([]string, len(tags))
+	byName := make(map[string]RemoteTag, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+		byName[t.Name] = t
+	}
+	semver.SortDesc(names)
+
+	sorted := make([]RemoteTag, len(names))
+	for i, n := range names {
+		sorted[i] = byName[n]
+	}
+	return sorted, nil
+}
+
+// PinTarget returns the tag a skill pinned to pin should be at: the newest
+// of its versions within the pin, or pin itself when no tag matches (an
+// exact tag or commit). skillDir is any path inside the skill's repo clone.
+func PinTarget(skillDir, skillName, sourcePath, pin string) string {
+	versions, err := SkillVersions(skillDir, skillName, sourcePath)
+	if err != nil {
+		return pin
+	}
+	for _, v := range versions {
+		if semver.MatchesPin(pin, v.Name) {
+			return v.Name
+		}
+	}
+	return pin
+}
+
+// PolicyTarget returns the newest of a skill's versions that policy allows
+// moving to from the current version without passing target, or current
+// when there is none. Pre-releases are skipped. skillDir is any path inside
+// the skill's repo clone.
+func PolicyTarget(skillDir, skillName, sourcePath, current, target string, policy semver.Policy) string {
+	limit, ok := semver.Parse(target)
+	from, okFrom := semver.Parse(current)
+	if !ok || !okFrom {
+		return current
+	}
+	versions, err := SkillVersions(skillDir, skillName, sourcePath)
+	if err != nil {
+		return current
+	}
+	for _, v := range versions {
+		parsed, ok := semver.Parse(v.Name)
+		if !ok || parsed.Pre != "" || semver.Compare(parsed, limit) > 0 {
+			continue
+		}
+		if semver.Compare(parsed, from) <= 0 {
+			break // newest first: the rest are not newer either
+		}
+		if policy.Allows(current, v.Name) {
+			return v.Name
+		}
+	}
+	return current
+}
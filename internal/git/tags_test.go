@@ -0,0 +1,34 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"lazyas/internal/semver"
+)
+
+func TestPolicyTarget(t *testing.T) {
+	url, seed := newRemote(t, map[string]string{"skills/a/SKILL.md": "a\n"})
+	for _, tag := range []string{"v1.0.0", "v1.0.2", "v1.1.0", "v1.1.1", "v1.2.0-rc.1", "v2.0.0", "v2.1.0"} {
+		gitRun(t, "-C", seed, "tag", tag)
+	}
+	gitRun(t, "-C", seed, "push", "-q", "--tags", strings.TrimPrefix(url, "file://"))
+	_, repoDir := installShared(t, url, "skills/a")
+
+	tests := []struct {
+		policy          semver.Policy
+		current, target string
+		want            string
+	}{
+		{semver.PolicyPatch, "v1.0.0", "v2.1.0", "v1.0.2"},
+		{semver.PolicyMinor, "v1.0.0", "v2.1.0", "v1.1.1"},
+		{semver.PolicyMinor, "v1.1.0", "v1.1.1", "v1.1.1"},
+		{semver.PolicyPatch, "v1.1.1", "v2.0.0", "v1.1.1"},
+		{semver.PolicyMinor, "main", "v2.0.0", "main"},
+	}
+	for _, tt := range tests {
+		if got := PolicyTarget(repoDir, "a", "skills/a", tt.current, tt.target, tt.policy); got != tt.want {
+			t.Errorf("PolicyTarget(%s, %s → %s) = %s, want %s", tt.policy, tt.current, tt.target, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// scpURL matches the scp-like form git accepts for ssh: user@host:path
+var scpURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@([A-Za-z0-9.-]+):(.+)$`)
+
+// SplitSCPURL splits an scp-like URL such as git@github.com:org/repo into
+// its host and path. ok is false for any other form.
+func SplitSCPURL(s string) (host, path string, ok bool) {
+	m := scpURL.FindStringSubmatch(s)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// CheckURL reports what is wrong with a repository URL without contacting
+// it. Accepted forms are http(s)://, ssh://, git://, file://, the scp-like
+// user@host:path and absolute local paths.
+func CheckURL(raw string) error {
+	if raw == "" {
+		return errors.New("URL is required")
+	}
+	if strings.ContainsAny(raw, " \t") {
+		return errors.New("URL must not contain spaces")
+	}
+	if filepath.IsAbs(raw) {
+		return nil
+	}
+	if _, path, ok := SplitSCPURL(raw); ok {
+		if strings.Trim(path, "/") == "" {
+			return errors.New("missing repository path")
+		}
+		return nil
+	}
+
+	scheme, _, ok := strings.Cut(raw, "://")
+	if !ok {
+		return errors.New("missing scheme (https://, ssh://, git@host:path or file://)")
+	}
+	switch strings.ToLower(scheme) {
+	case "https", "http", "ssh", "git", "file":
+	default:
+		return fmt.Errorf("unsupported scheme %q", scheme)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "file" && u.Host == "" {
+		return errors.New("missing host")
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		return errors.New("missing repository path")
+	}
+	return nil
+}
+
+// RepoName suggests a short name for the repository at repoURL: its last
+// path segment without ".git"
+func RepoName(repoURL string) string {
+	p := repoURL
+	if _, path, ok := SplitSCPURL(p); ok {
+		p = path
+	} else if u, err := url.Parse(p); err == nil && u.Scheme != "" {
+		p = u.Path
+	}
+	p = strings.TrimSuffix(strings.Trim(p, "/"), ".git")
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		p = p[i+1:]
+	}
+	return sanitizeDirName(p)
+}
+
+// CheckReachable runs ls-remote against repoURL to confirm it is a git
+// repository that can be read. Credential prompts are disabled so a
+// private repo fails instead of hanging.
+func CheckReachable(repoURL string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--heads", repoURL)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if os.Getenv("GIT_SSH_COMMAND") == "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	}
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return fmt.Errorf("no answer from %s within %s", repoURL, timeout)
+	}
+	if err != nil {
+		// The first fatal line says what went wrong; the rest is advice
+		msg := err.Error()
+		for _, line := range strings.Split(string(out), "\n") {
+			if reason, ok := strings.CutPrefix(strings.TrimSpace(line), "fatal: "); ok {
+				msg = reason
+				break
+			}
+		}
+		return fmt.Errorf("not a reachable git repository: %s", msg)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Package health checks that an installed skill is usable end to end: its
+// SKILL.md parses, the files it references exist and its scripts can run.
+package health
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"lazyas/internal/skillmd"
+)
+
+// Problem kinds
+const (
+	KindSkillMD     = "skill.md"
+	KindFrontmatter = "frontmatter"
+	KindMissingPath = "missing path"
+	KindBrokenLink  = "broken link"
+	KindPermissions = "permissions"
+)
+
+// Problem is one thing that keeps a skill from working as written
+type Problem struct {
+	Path    string // file the problem was found in, relative to the skill root
+	Kind    string
+	Message string
+}
+
+// Report is the result of checking a skill
+type Report struct {
+	Problems []Problem
+}
+
+// OK reports whether the skill passed every check
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// mdLink matches inline Markdown links and images: [text](target "title")
+var mdLink = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// Check validates the skill in dir (following a top-level symlink)
+func Check(dir string) (*Report, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	add := func(path, kind, format string, args ...any) {
+		report.Problems = append(report.Problems, Problem{Path: path, Kind: kind, Message: fmt.Sprintf(format, args...)})
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, "SKILL.md"))
+	if err != nil {
+		add("SKILL.md", KindSkillMD, "SKILL.md not found")
+		return report, nil
+	}
+	checkFrontmatter(root, string(content), add)
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if err := checkPermissions(path, rel, add); err != nil {
+			return err
+		}
+		if strings.EqualFold(filepath.Ext(rel), ".md") {
+			return checkLinks(root, path, rel, add)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(report.Problems, func(i, j int) bool {
+		return report.Problems[i].Path < report.Problems[j].Path
+	})
+	return report, nil
+}
+
+func checkFrontmatter(root, content string, add func(path, kind, format string, args ...any)) {
+	fm, err := skillmd.ParseFrontmatter(content)
+	if err != nil {
+		add("SKILL.md", KindFrontmatter, "%v", err)
+		return
+	}
+	if fm.Name == "" {
+		add("SKILL.md", KindFrontmatter, "no name in frontmatter")
+	}
+	if fm.Description == "" {
+		add("SKILL.md", KindFrontmatter, "no description in frontmatter")
+	}
+
+	// Any value that looks like a relative path must exist
+	var raw map[string]any
+	if header, ok := frontmatterText(content); ok && yaml.Unmarshal([]byte(header), &raw) == nil {
+		keys := make([]string, 0, len(raw))
+		for k := range raw {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			for _, v := range stringValues(raw[k]) {
+				if !looksLikePath(v) {
+					continue
+				}
+				if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(v))); err != nil {
+					add("SKILL.md", KindMissingPath, "%s: %s does not exist", k, v)
+				}
+			}
+		}
+	}
+}
+
+// frontmatterText returns the YAML between the leading "---" markers
+func frontmatterText(content string) (string, bool) {
+	lines := skillmd.SplitLines(content)
+	if len(lines) == 0 || skillmd.TrimSpace(lines[0]) != "---" {
+		return "", false
+	}
+	for i := 1; i < len(lines); i++ {
+		if skillmd.TrimSpace(lines[i]) == "---" {
+			return strings.Join(lines[1:i], "\n"), true
+		}
+	}
+	return "", false
+}
+
+// stringValues flattens the strings in a decoded YAML value
+func stringValues(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, item := range v {
+			out = append(out, stringValues(item)...)
+		}
+		return out
+	case map[string]any:
+		var out []string
+		for _, item := range v {
+			out = append(out, stringValues(item)...)
+		}
+		return out
+	}
+	return nil
+}
+
+// looksLikePath accepts relative file paths such as "./scripts/run.sh" or
+// "reference/forms.md", and rejects prose, URLs and absolute paths
+func looksLikePath(s string) bool {
+	if s == "" || strings.ContainsAny(s, " \t\n") || strings.Contains(s, "://") || filepath.IsAbs(s) {
+		return false
+	}
+	return strings.HasPrefix(s, "./") || strings.HasPrefix(s, "../") || strings.Contains(s, "/")
+}
+
+// checkLinks reports relative Markdown links whose target does not exist.
+// Links inside fenced code blocks are ignored.
+func checkLinks(root, path, rel string, add func(path, kind, format string, args ...any)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	inFence := false
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, m := range mdLink.FindAllStringSubmatch(line, -1) {
+			target := m[1]
+			if strings.HasPrefix(target, "#") || strings.Contains(target, ":") {
+				continue // anchor, URL or mailto
+			}
+			target, _, _ = strings.Cut(target, "#")
+			target, _, _ = strings.Cut(target, "?")
+			if unescaped, err := url.PathUnescape(target); err == nil {
+				target = unescaped
+			}
+			resolved := filepath.Join(filepath.Dir(path), filepath.FromSlash(target))
+			if strings.HasPrefix(target, "/") {
+				resolved = filepath.Join(root, filepath.FromSlash(target))
+			}
+			if _, err := os.Stat(resolved); err != nil {
+				add(rel, KindBrokenLink, "line %d: %s does not exist", lineNo, m[1])
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// checkPermissions reports scripts that cannot be executed and files anyone
+// can modify
+func checkPermissions(path, rel string, add func(path, kind, format string, args ...any)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&0002 != 0 {
+		add(rel, KindPermissions, "file is world-writable")
+	}
+	if info.Mode()&0111 != 0 {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(f, head); err == nil && bytes.Equal(head, []byte("#!")) {
+		add(rel, KindPermissions, "script has a #! line but is not executable")
+	}
+	return nil
+}
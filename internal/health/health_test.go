@@ -0,0 +1,87 @@
+package health
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func write(t *testing.T, dir, name, content string, mode os.FileMode) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+	// WriteFile is subject to the umask
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheck_HealthySkill(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "SKILL.md", `---
+name: pdf
+description: Work with PDF files
+script: scripts/fill.sh
+---
+# PDF
+
+See [forms](reference/forms.md#fields), [the site](https://example.com)
+and [below](#usage).
+
+`+"```"+`
+[not a link](missing.md)
+`+"```"+`
+`, 0644)
+	write(t, dir, "reference/forms.md", "Back to [skill](../SKILL.md)", 0644)
+	write(t, dir, "scripts/fill.sh", "#!/bin/sh\n", 0755)
+
+	report, err := Check(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() {
+		t.Errorf("expected no problems, got %+v", report.Problems)
+	}
+}
+
+func TestCheck_Problems(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "SKILL.md", `---
+name: pdf
+script: ./scripts/missing.sh
+---
+Read [the guide](guide.md).
+`, 0644)
+	write(t, dir, "scripts/run.sh", "#!/bin/sh\n", 0644)
+	write(t, dir, "notes.txt", "shared", 0666)
+
+	report, err := Check(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[string]int)
+	for _, p := range report.Problems {
+		kinds[p.Kind]++
+	}
+	want := map[string]int{KindFrontmatter: 1, KindMissingPath: 1, KindBrokenLink: 1, KindPermissions: 2}
+	for kind, n := range want {
+		if kinds[kind] != n {
+			t.Errorf("%s problems = %d, want %d (all: %+v)", kind, kinds[kind], n, report.Problems)
+		}
+	}
+}
+
+func TestCheck_MissingSkillMD(t *testing.T) {
+	report, err := Check(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Problems) != 1 || report.Problems[0].Kind != KindSkillMD {
+		t.Errorf("unexpected problems: %+v", report.Problems)
+	}
+}
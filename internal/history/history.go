@@ -0,0 +1,123 @@
+// Package history records the results of update runs in
+// ~/.lazyas/update-history.yaml so they can be reviewed after the output
+// is gone.
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Keep is how many runs the history file retains
+const Keep = 20
+
+// Statuses of a skill in an update run
+const (
+	StatusUpdated  = "updated"
+	StatusUpToDate = "up-to-date"
+	StatusSkipped  = "skipped" // local changes or unresolved conflicts
+	StatusHeld     = "held"    // semver update policy or version pin
+	StatusBlocked  = "blocked" // skill policy or risk limit
+	StatusFailed   = "failed"
+	StatusInvalid  = "invalid" // updated but failed validation
+)
+
+// Run is one update run
+type Run struct {
+	At      time.Time `yaml:"at"`
+	Source  string    `yaml:"source"` // "cli" or "tui"
+	Results []Result  `yaml:"results"`
+}
+
+// Result is the outcome for one skill
+type Result struct {
+	Name       string   `yaml:"name"`
+	Status     string   `yaml:"status"`
+	OldVersion string   `yaml:"old_version,omitempty"`
+	NewVersion string   `yaml:"new_version,omitempty"`
+	OldCommit  string   `yaml:"old_commit,omitempty"`
+	NewCommit  string   `yaml:"new_commit,omitempty"`
+	Error      string   `yaml:"error,omitempty"`
+	Changes    []string `yaml:"changes,omitempty"` // changelog lines
+}
+
+type file struct {
+	Runs []Run `yaml:"runs"`
+}
+
+// Counts returns how many skills were updated, skipped and failed
+func (r *Run) Counts() (updated, skipped, failed int) {
+	for _, res := range r.Results {
+		switch res.Status {
+		case StatusUpdated:
+			updated++
+		case StatusFailed, StatusInvalid:
+			failed++
+		default:
+			skipped++
+		}
+	}
+	return updated, skipped, failed
+}
+
+// Set replaces the result for a skill
+func (r *Run) Set(res Result) {
+	for i := range r.Results {
+		if r.Results[i].Name == res.Name {
+			r.Results[i] = res
+			return
+		}
+	}
+	r.Results = append(r.Results, res)
+}
+
+// Load returns the recorded runs, oldest first. A missing file is empty.
+func Load(path string) ([]Run, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid update history %s: %w", path, err)
+	}
+	return f.Runs, nil
+}
+
+// Last returns the most recent run, or nil when there is none
+func Last(path string) (*Run, error) {
+	runs, err := Load(path)
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return &runs[len(runs)-1], nil
+}
+
+// Append records run, dropping the oldest runs beyond Keep
+func Append(path string, run Run) error {
+	runs, err := Load(path)
+	if err != nil {
+		// A corrupt history is not worth failing an update for
+		runs = nil
+	}
+	runs = append(runs, run)
+	if len(runs) > Keep {
+		runs = runs[len(runs)-Keep:]
+	}
+
+	data, err := yaml.Marshal(file{Runs: runs})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,61 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLast(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "update-history.yaml")
+
+	if run, err := Last(path); err != nil || run != nil {
+		t.Fatalf("Last on a missing file = %+v, %v", run, err)
+	}
+
+	for i := 0; i < Keep+3; i++ {
+		run := Run{At: time.Unix(int64(i), 0).UTC(), Source: "cli"}
+		run.Set(Result{Name: "pdf", Status: StatusFailed, Error: "boom"})
+		run.Set(Result{Name: "pdf", Status: StatusUpdated, OldCommit: "a", NewCommit: fmt.Sprint(i)})
+		run.Set(Result{Name: "docx", Status: StatusHeld})
+		if err := Append(path, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != Keep {
+		t.Errorf("kept %d runs, want %d", len(runs), Keep)
+	}
+	last, err := Last(path)
+	if err != nil || last == nil {
+		t.Fatalf("Last = %+v, %v", last, err)
+	}
+	if last.Results[0].NewCommit != fmt.Sprint(Keep+2) {
+		t.Errorf("unexpected last run %+v", last)
+	}
+	if u, s, f := last.Counts(); u != 1 || s != 1 || f != 0 {
+		t.Errorf("Counts() = %d, %d, %d", u, s, f)
+	}
+}
+
+func TestAppend_ReplacesCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "update-history.yaml")
+	if err := os.WriteFile(path, []byte("runs: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for a corrupt file")
+	}
+	if err := Append(path, Run{Source: "tui"}); err != nil {
+		t.Fatal(err)
+	}
+	if runs, err := Load(path); err != nil || len(runs) != 1 {
+		t.Errorf("Load = %+v, %v", runs, err)
+	}
+}
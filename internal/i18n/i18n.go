@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultLocale is the source language of all message strings
+const DefaultLocale = "en"
+
+// Catalog maps source (English) strings to their translation.
+// Strings missing from a catalog fall back to the source string.
+type Catalog map[string]string
+
+var (
+	mu     sync.RWMutex
+	locale = DefaultLocale
+	active Catalog
+)
+
+// builtinCatalogs are translations shipped with the binary, keyed by language
+var builtinCatalogs = map[string]Catalog{}
+
+// DetectLocale returns the language to use, preferring the configured value,
+// then LC_ALL, LC_MESSAGES and LANG. Falls back to DefaultLocale.
+func DetectLocale(configured string) string {
+	candidates := []string{
+		configured,
+		os.Getenv("LC_ALL"),
+		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
+	}
+	for _, c := range candidates {
+		if lang := normalize(c); lang != "" {
+			return lang
+		}
+	}
+	return DefaultLocale
+}
+
+// normalize reduces a POSIX locale ("de_DE.UTF-8", "pt-BR") to its language
+// code ("de", "pt"). "C" and "POSIX" map to the default locale.
+func normalize(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	if idx := strings.IndexAny(s, ".@"); idx != -1 {
+		s = s[:idx]
+	}
+	if idx := strings.IndexAny(s, "_-"); idx != -1 {
+		s = s[:idx]
+	}
+	s = strings.ToLower(s)
+	if s == "c" || s == "posix" {
+		return DefaultLocale
+	}
+	return s
+}
+
+// SetLocale activates a language. Built-in translations are merged with
+// <localesDir>/<lang>.toml when present, so community translations can be
+// dropped in without rebuilding. The file entries take precedence.
+func SetLocale(lang, localesDir string) error {
+	lang = normalize(lang)
+	if lang == "" {
+		lang = DefaultLocale
+	}
+
+	catalog := make(Catalog)
+	for k, v := range builtinCatalogs[lang] {
+		catalog[k] = v
+	}
+
+	var loadErr error
+	if localesDir != "" && lang != DefaultLocale {
+		path := filepath.Join(localesDir, lang+".toml")
+		if _, err := os.Stat(path); err == nil {
+			var fromFile Catalog
+			if _, err := toml.DecodeFile(path, &fromFile); err != nil {
+				loadErr = fmt.Errorf("failed to load %s: %w", path, err)
+			}
+			for k, v := range fromFile {
+				catalog[k] = v
+			}
+		}
+	}
+
+	mu.Lock()
+	locale = lang
+	active = catalog
+	mu.Unlock()
+
+	return loadErr
+}
+
+// Locale returns the active language code
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// T translates a message, returning it unchanged when no translation exists
+func T(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if tr, ok := active[msg]; ok && tr != "" {
+		return tr
+	}
+	return msg
+}
+
+// Tf translates a format string and applies fmt.Sprintf to it
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
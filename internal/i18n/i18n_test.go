@@ -0,0 +1,89 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"":            "",
+		"de_DE.UTF-8": "de",
+		"pt-BR":       "pt",
+		"fr":          "fr",
+		"C":           "en",
+		"POSIX":       "en",
+		"sr@latin":    "sr",
+		"  ES_es  ":   "es",
+	}
+	for in, want := range cases {
+		if got := normalize(in); got != want {
+			t.Errorf("normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDetectLocale_PrefersConfigured(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	if got := DetectLocale("de"); got != "de" {
+		t.Errorf("DetectLocale(de) = %q, want de", got)
+	}
+	if got := DetectLocale(""); got != "fr" {
+		t.Errorf("DetectLocale(\"\") = %q, want fr (from LANG)", got)
+	}
+}
+
+func TestDetectLocale_DefaultsToEnglish(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "")
+
+	if got := DetectLocale(""); got != DefaultLocale {
+		t.Errorf("DetectLocale = %q, want %q", got, DefaultLocale)
+	}
+}
+
+func TestSetLocale_LoadsCatalogFile(t *testing.T) {
+	dir := t.TempDir()
+	content := "\"quit\" = \"beenden\"\n\"Installed %s\" = \"%s installiert\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "de.toml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetLocale(DefaultLocale, "") })
+
+	if err := SetLocale("de_DE.UTF-8", dir); err != nil {
+		t.Fatalf("SetLocale: %v", err)
+	}
+	if Locale() != "de" {
+		t.Errorf("Locale() = %q, want de", Locale())
+	}
+	if got := T("quit"); got != "beenden" {
+		t.Errorf("T(quit) = %q, want beenden", got)
+	}
+	if got := Tf("Installed %s", "pdf"); got != "pdf installiert" {
+		t.Errorf("Tf = %q, want %q", got, "pdf installiert")
+	}
+	// Untranslated strings fall back to the source text
+	if got := T("search"); got != "search" {
+		t.Errorf("T(search) = %q, want fallback", got)
+	}
+}
+
+func TestSetLocale_InvalidFileKeepsFallback(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "xx.toml"), []byte("not = [valid"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetLocale(DefaultLocale, "") })
+
+	if err := SetLocale("xx", dir); err == nil {
+		t.Error("expected error for malformed catalog")
+	}
+	if got := T("quit"); got != "quit" {
+		t.Errorf("T(quit) = %q, want source string", got)
+	}
+}
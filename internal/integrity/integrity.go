@@ -0,0 +1,144 @@
+package integrity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"lazyas/internal/git"
+)
+
+// Target is a skill to validate after an install or update
+type Target struct {
+	Name string
+	Path string // skill directory (may be a symlink into a repo clone)
+}
+
+// Result is the validation outcome for one skill
+type Result struct {
+	Name string
+	Hash string // content hash; empty when hashing failed
+	Err  error  // validation or hashing error
+}
+
+// HashDir returns a sha256 over the relative paths and contents of all
+// regular files under dir (skipping .git and paths matched by
+// .lazyasignore), so any edit changes the hash.
+func HashDir(dir string) (string, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	ignore := git.LoadIgnore(dir)
+
+	h := sha256.New()
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel != "." && ignore.Match(filepath.ToSlash(rel)+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || ignore.Match(filepath.ToSlash(rel)) {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		h.Write([]byte{0})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ValidateAll validates and hashes skills concurrently. Results are returned
+// in the same order as targets.
+func ValidateAll(targets []Target) []Result {
+	results := make([]Result, len(targets))
+	workers := runtime.NumCPU()
+	if workers > len(targets) {
+		workers = len(targets)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = validate(targets[i])
+			}
+		}()
+	}
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func validate(t Target) Result {
+	r := Result{Name: t.Name}
+	if err := git.ValidateSkill(t.Path); err != nil {
+		r.Err = err
+		return r
+	}
+	hash, err := HashDir(t.Path)
+	if err != nil {
+		r.Err = fmt.Errorf("failed to hash: %w", err)
+		return r
+	}
+	r.Hash = hash
+	return r
+}
+
+// Failed returns the results that did not pass validation
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Hashes maps skill name to hash for the results that passed
+func Hashes(results []Result) map[string]string {
+	hashes := make(map[string]string, len(results))
+	for _, r := range results {
+		if r.Err == nil {
+			hashes[r.Name] = r.Hash
+		}
+	}
+	return hashes
+}
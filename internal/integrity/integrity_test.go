@@ -0,0 +1,122 @@
+package integrity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSkill(t *testing.T, dir, body string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHashDir_ChangesWithContent(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "skill")
+	writeSkill(t, dir, "v1")
+
+	h1, err := HashDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, _ := HashDir(dir)
+	if h1 != h2 {
+		t.Errorf("hash not stable: %s vs %s", h1, h2)
+	}
+
+	writeSkill(t, dir, "v2")
+	h3, _ := HashDir(dir)
+	if h3 == h1 {
+		t.Error("hash did not change after edit")
+	}
+}
+
+func TestHashDir_IgnoresGitDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "skill")
+	writeSkill(t, dir, "body")
+	before, _ := HashDir(dir)
+
+	os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref"), 0644)
+
+	after, _ := HashDir(dir)
+	if before != after {
+		t.Error(".git contents should not affect the hash")
+	}
+}
+
+func TestHashDir_SkipsIgnoredPaths(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "skill")
+	writeSkill(t, dir, "body")
+	os.WriteFile(filepath.Join(dir, ".lazyasignore"), []byte("cache/\n*.log\n"), 0644)
+	before, _ := HashDir(dir)
+
+	os.MkdirAll(filepath.Join(dir, "cache"), 0755)
+	os.WriteFile(filepath.Join(dir, "cache", "state.json"), []byte("{}"), 0644)
+	os.WriteFile(filepath.Join(dir, "run.log"), []byte("x"), 0644)
+
+	after, _ := HashDir(dir)
+	if before != after {
+		t.Error("ignored runtime files should not affect the hash")
+	}
+}
+
+func TestValidateAll_FlagsMissingSkillMD(t *testing.T) {
+	base := t.TempDir()
+	good := filepath.Join(base, "good")
+	writeSkill(t, good, "ok")
+	bad := filepath.Join(base, "bad")
+	os.MkdirAll(bad, 0755)
+
+	results := ValidateAll([]Target{{"good", good}, {"bad", bad}, {"missing", filepath.Join(base, "nope")}})
+	if len(results) != 3 || results[0].Name != "good" || results[1].Name != "bad" {
+		t.Fatalf("results out of order: %+v", results)
+	}
+	if results[0].Err != nil || results[0].Hash == "" {
+		t.Errorf("good skill should validate: %+v", results[0])
+	}
+
+	failed := Failed(results)
+	if len(failed) != 2 {
+		t.Errorf("expected 2 failures, got %+v", failed)
+	}
+	if hashes := Hashes(results); len(hashes) != 1 || hashes["good"] == "" {
+		t.Errorf("Hashes = %v", hashes)
+	}
+}
+
+func TestValidateAll_RejectsSymlinksOutsideSkill(t *testing.T) {
+	base := t.TempDir()
+	secret := filepath.Join(base, "secret.txt")
+	os.WriteFile(secret, []byte("token"), 0600)
+
+	inside := filepath.Join(base, "inside")
+	writeSkill(t, inside, "ok")
+	os.Symlink("SKILL.md", filepath.Join(inside, "README.md"))
+
+	escaping := filepath.Join(base, "escaping")
+	writeSkill(t, escaping, "ok")
+	os.MkdirAll(filepath.Join(escaping, "docs"), 0755)
+	os.Symlink("../../secret.txt", filepath.Join(escaping, "docs", "notes.md"))
+
+	dangling := filepath.Join(base, "dangling")
+	writeSkill(t, dangling, "ok")
+	os.Symlink("/nonexistent/elsewhere", filepath.Join(dangling, "data"))
+
+	results := ValidateAll([]Target{{"inside", inside}, {"escaping", escaping}, {"dangling", dangling}})
+	if results[0].Err != nil {
+		t.Errorf("a link inside the skill should pass, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "docs/notes.md") {
+		t.Errorf("expected the escaping link to be named, got %v", results[1].Err)
+	}
+	if results[2].Err == nil {
+		t.Error("expected a dangling link outside the skill to fail")
+	}
+}
@@ -0,0 +1,71 @@
+package lockfile
+
+import (
+	"fmt"
+	"sort"
+
+	"lazyas/internal/manifest"
+	"lazyas/internal/release"
+)
+
+// Import plans installing the skills of another machine's manifest next
+// to the installed ones. Unlike a Plan it only adds: installed skills are
+// neither moved nor removed.
+type Import struct {
+	Install    []Entry  // not installed here, to install at the recorded commit
+	Present    []string // already installed here from the same source
+	Unresolved []string // entries that cannot be installed, with the reason
+}
+
+// NewImport compares the skills of another manifest with the installed
+// ones. Skills of one repository share a clone, so an entry whose
+// repository is checked out at another commit here, or for another entry,
+// cannot be installed without moving those skills.
+func NewImport(other, installed map[string]manifest.InstalledSkill) *Import {
+	imp := &Import{}
+	repoCommit := make(map[string]string) // commit each repository is checked out at
+	repoHolder := make(map[string]string) // skill that checked it out
+	for _, name := range sortedNames(installed) {
+		info := installed[name]
+		if _, ok := repoCommit[info.SourceRepo]; !ok && info.Commit != "" {
+			repoCommit[info.SourceRepo], repoHolder[info.SourceRepo] = info.Commit, name
+		}
+	}
+
+	for _, name := range sortedNames(other) {
+		info := other[name]
+		local, isInstalled := installed[name]
+		commit, checkedOut := repoCommit[info.SourceRepo]
+		switch {
+		case release.IsSource(info.SourceRepo):
+			imp.Unresolved = append(imp.Unresolved, fmt.Sprintf("%s was installed from a release asset; install it with 'lazyas install %s'", name, info.SourceRepo))
+		case info.SourceRepo == "" || info.Commit == "":
+			imp.Unresolved = append(imp.Unresolved, fmt.Sprintf("%s has no source repository or commit", name))
+		case isInstalled && (local.SourceRepo != info.SourceRepo || local.SourcePath != info.SourcePath):
+			imp.Unresolved = append(imp.Unresolved, fmt.Sprintf("%s is taken here by a skill from %s", name, local.SourceRepo))
+		case isInstalled:
+			imp.Present = append(imp.Present, name)
+		case checkedOut && commit != info.Commit:
+			imp.Unresolved = append(imp.Unresolved, fmt.Sprintf("%s needs %s at %.7s, which is at %.7s here for %s", name, info.SourceRepo, info.Commit, commit, repoHolder[info.SourceRepo]))
+		default:
+			repoCommit[info.SourceRepo], repoHolder[info.SourceRepo] = info.Commit, name
+			imp.Install = append(imp.Install, Entry{
+				Name:    name,
+				Repo:    info.SourceRepo,
+				Path:    info.SourcePath,
+				Commit:  info.Commit,
+				Version: info.Version,
+			})
+		}
+	}
+	return imp
+}
+
+func sortedNames(skills map[string]manifest.InstalledSkill) []string {
+	names := make([]string, 0, len(skills))
+	for name := range skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
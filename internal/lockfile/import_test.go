@@ -0,0 +1,53 @@
+package lockfile
+
+import (
+	"strings"
+	"testing"
+
+	"lazyas/internal/manifest"
+)
+
+func TestNewImport(t *testing.T) {
+	other := map[string]manifest.InstalledSkill{
+		"pdf":     {SourceRepo: "r1", SourcePath: "skills/pdf", Commit: "aaaaaaaa"},
+		"docx":    {SourceRepo: "r1", SourcePath: "skills/docx", Commit: "aaaaaaaa"},
+		"xlsx":    {SourceRepo: "r1", SourcePath: "skills/xlsx", Commit: "bbbbbbbb"},
+		"shared":  {SourceRepo: "r2", Commit: "cccccccc"},
+		"here":    {SourceRepo: "r3", Commit: "dddddddd"},
+		"taken":   {SourceRepo: "r4", Commit: "eeeeeeee"},
+		"release": {SourceRepo: "gh-release://o/r@v1/skill.tar.gz", Commit: "ffffffff"},
+		"bare":    {SourceRepo: "r5"},
+	}
+	installed := map[string]manifest.InstalledSkill{
+		"local-r2": {SourceRepo: "r2", Commit: "99999999"},
+		"here":     {SourceRepo: "r3", Commit: "00000000"},
+		"taken":    {SourceRepo: "r9", Commit: "eeeeeeee"},
+	}
+
+	imp := NewImport(other, installed)
+	var install []string
+	for _, e := range imp.Install {
+		install = append(install, e.Name)
+	}
+	// xlsx wants r1 at another commit than docx, installed first
+	if strings.Join(install, " ") != "docx pdf" {
+		t.Errorf("Install = %v", install)
+	}
+	// here is present at another commit, which an import leaves alone
+	if len(imp.Present) != 1 || imp.Present[0] != "here" {
+		t.Errorf("Present = %v", imp.Present)
+	}
+
+	want := []string{"bare", "release", "shared", "taken", "xlsx"}
+	if len(imp.Unresolved) != len(want) {
+		t.Fatalf("Unresolved = %q", imp.Unresolved)
+	}
+	for i, name := range want {
+		if !strings.HasPrefix(imp.Unresolved[i], name+" ") {
+			t.Errorf("Unresolved[%d] = %q, want %s", i, imp.Unresolved[i], name)
+		}
+	}
+	if !strings.Contains(imp.Unresolved[2], "at 9999999 here for local-r2") {
+		t.Errorf("shared: %q", imp.Unresolved[2])
+	}
+}
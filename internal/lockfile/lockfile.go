@@ -0,0 +1,150 @@
+// Package lockfile reads and writes lazyas.lock, a list of skills pinned to
+// exact commits, and plans how to make the installed set match it.
+package lockfile
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+	"lazyas/internal/manifest"
+)
+
+// FileName is the default lockfile name, looked up in the working directory
+const FileName = "lazyas.lock"
+
+// Version is the current lockfile format version
+const Version = 1
+
+// Lockfile pins a set of skills to exact commits
+type Lockfile struct {
+	Version int     `yaml:"version"`
+	Skills  []Entry `yaml:"skills"`
+}
+
+// Entry is one pinned skill
+type Entry struct {
+	Name    string `yaml:"name"`
+	Repo    string `yaml:"repo"`
+	Path    string `yaml:"path,omitempty"`
+	Commit  string `yaml:"commit"`
+	Version string `yaml:"version,omitempty"` // tag or branch the commit came from, for display
+}
+
+// Read parses a lockfile
+func Read(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var l Lockfile
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("invalid lockfile %s: %w", path, err)
+	}
+	if l.Version > Version {
+		return nil, fmt.Errorf("lockfile %s has version %d; this lazyas supports up to %d", path, l.Version, Version)
+	}
+
+	seen := make(map[string]bool, len(l.Skills))
+	for _, e := range l.Skills {
+		switch {
+		case e.Name == "" || e.Repo == "" || e.Commit == "":
+			return nil, fmt.Errorf("invalid lockfile %s: every skill needs name, repo and commit", path)
+		case seen[e.Name]:
+			return nil, fmt.Errorf("invalid lockfile %s: skill %s is listed twice", path, e.Name)
+		}
+		seen[e.Name] = true
+	}
+	return &l, nil
+}
+
+// FromInstalled pins the installed skills to their recorded commits
+func FromInstalled(installed map[string]manifest.InstalledSkill) *Lockfile {
+	l := &Lockfile{Version: Version, Skills: make([]Entry, 0, len(installed))}
+	for name, info := range installed {
+		l.Skills = append(l.Skills, Entry{
+			Name:    name,
+			Repo:    info.SourceRepo,
+			Path:    info.SourcePath,
+			Commit:  info.Commit,
+			Version: info.Version,
+		})
+	}
+	sort.Slice(l.Skills, func(i, j int) bool { return l.Skills[i].Name < l.Skills[j].Name })
+	return l
+}
+
+// Marshal encodes the lockfile with skills sorted by name
+func (l *Lockfile) Marshal() ([]byte, error) {
+	l.Version = Version
+	sort.Slice(l.Skills, func(i, j int) bool { return l.Skills[i].Name < l.Skills[j].Name })
+	return yaml.Marshal(l)
+}
+
+// Write saves the lockfile with skills sorted by name
+func (l *Lockfile) Write(path string) error {
+	data, err := l.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Change is a pinned skill that is installed but differs from the lockfile
+type Change struct {
+	Entry
+	From      manifest.InstalledSkill
+	Reinstall bool // source repo or path differs, so the skill is replaced
+}
+
+// Plan reconciles the installed skills with a lockfile
+type Plan struct {
+	Install   []Entry  // pinned but not installed
+	Repin     []Change // installed at another commit or from another source
+	Remove    []string // installed but not in the lockfile
+	Unchanged []string
+	Conflicts []string // problems that make the lockfile impossible to apply
+}
+
+// NewPlan compares the installed skills with the lockfile. Skills of one
+// repository share a clone, so they must all be pinned to the same commit.
+func NewPlan(l *Lockfile, installed map[string]manifest.InstalledSkill) *Plan {
+	p := &Plan{}
+	pinned := make(map[string]bool, len(l.Skills))
+	repoCommit := make(map[string]Entry)
+
+	for _, e := range l.Skills {
+		pinned[e.Name] = true
+		if other, ok := repoCommit[e.Repo]; ok && other.Commit != e.Commit {
+			p.Conflicts = append(p.Conflicts, fmt.Sprintf("%s and %s share %s but are pinned to different commits", other.Name, e.Name, e.Repo))
+		} else if !ok {
+			repoCommit[e.Repo] = e
+		}
+
+		info, ok := installed[e.Name]
+		switch {
+		case !ok:
+			p.Install = append(p.Install, e)
+		case info.SourceRepo != e.Repo || info.SourcePath != e.Path:
+			p.Repin = append(p.Repin, Change{Entry: e, From: info, Reinstall: true})
+		case info.Commit != e.Commit:
+			p.Repin = append(p.Repin, Change{Entry: e, From: info})
+		default:
+			p.Unchanged = append(p.Unchanged, e.Name)
+		}
+	}
+
+	for name := range installed {
+		if !pinned[name] {
+			p.Remove = append(p.Remove, name)
+		}
+	}
+	sort.Strings(p.Remove)
+	return p
+}
+
+// Empty reports whether the installed set already matches the lockfile
+func (p *Plan) Empty() bool {
+	return len(p.Install) == 0 && len(p.Repin) == 0 && len(p.Remove) == 0
+}
@@ -0,0 +1,105 @@
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lazyas/internal/manifest"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	l := &Lockfile{Skills: []Entry{
+		{Name: "zeta", Repo: "https://example.com/a", Commit: "c2"},
+		{Name: "alpha", Repo: "https://example.com/a", Path: "skills/alpha", Commit: "c1", Version: "v1.0.0"},
+	}}
+	if err := l.Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != Version || len(got.Skills) != 2 {
+		t.Fatalf("unexpected lockfile: %+v", got)
+	}
+	if got.Skills[0].Name != "alpha" || got.Skills[0].Path != "skills/alpha" {
+		t.Errorf("expected sorted entries with paths, got %+v", got.Skills)
+	}
+}
+
+func TestFromInstalled_RoundTripsToEmptyPlan(t *testing.T) {
+	installed := map[string]manifest.InstalledSkill{
+		"pdf":  {SourceRepo: "https://example.com/a", SourcePath: "skills/pdf", Commit: "c1", Version: "v1.2.0"},
+		"docx": {SourceRepo: "https://example.com/a", SourcePath: "skills/docx", Commit: "c1"},
+		"solo": {SourceRepo: "https://example.com/solo", Commit: "c9"},
+	}
+	l := FromInstalled(installed)
+	if len(l.Skills) != 3 || l.Skills[0].Name != "docx" || l.Skills[1].Version != "v1.2.0" {
+		t.Fatalf("unexpected lockfile: %+v", l.Skills)
+	}
+
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := l.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := NewPlan(got, installed); !p.Empty() || len(p.Conflicts) != 0 || len(p.Unchanged) != 3 {
+		t.Errorf("expected the exported set to match itself, got %+v", p)
+	}
+}
+
+func TestRead_RejectsIncompleteEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	os.WriteFile(path, []byte("version: 1\nskills:\n  - name: foo\n    repo: https://example.com/a\n"), 0644)
+
+	if _, err := Read(path); err == nil || !strings.Contains(err.Error(), "commit") {
+		t.Errorf("expected missing commit error, got %v", err)
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	l := &Lockfile{Skills: []Entry{
+		{Name: "same", Repo: "r1", Commit: "a"},
+		{Name: "moved", Repo: "r1", Commit: "a"},
+		{Name: "other-source", Repo: "r2", Commit: "b"},
+		{Name: "missing", Repo: "r3", Commit: "c"},
+	}}
+	installed := map[string]manifest.InstalledSkill{
+		"same":         {SourceRepo: "r1", Commit: "a"},
+		"moved":        {SourceRepo: "r1", Commit: "old"},
+		"other-source": {SourceRepo: "r9", Commit: "b"},
+		"extra":        {SourceRepo: "r1", Commit: "a"},
+	}
+
+	p := NewPlan(l, installed)
+	if len(p.Install) != 1 || p.Install[0].Name != "missing" {
+		t.Errorf("Install = %+v", p.Install)
+	}
+	if len(p.Repin) != 2 || p.Repin[0].Name != "moved" || p.Repin[0].Reinstall || !p.Repin[1].Reinstall {
+		t.Errorf("Repin = %+v", p.Repin)
+	}
+	if len(p.Remove) != 1 || p.Remove[0] != "extra" {
+		t.Errorf("Remove = %v", p.Remove)
+	}
+	if len(p.Unchanged) != 1 || len(p.Conflicts) != 0 || p.Empty() {
+		t.Errorf("unexpected plan: %+v", p)
+	}
+}
+
+func TestNewPlan_FlagsSharedCloneConflicts(t *testing.T) {
+	l := &Lockfile{Skills: []Entry{
+		{Name: "a", Repo: "r1", Commit: "x"},
+		{Name: "b", Repo: "r1", Commit: "y"},
+	}}
+	p := NewPlan(l, nil)
+	if len(p.Conflicts) != 1 {
+		t.Errorf("expected one conflict, got %v", p.Conflicts)
+	}
+}
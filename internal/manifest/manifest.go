@@ -1,16 +1,33 @@
 package manifest
 
 import (
+	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
 	"lazyas/internal/skillmd"
 )
 
+// InstallerVersion is the lazyas version recorded in provenance records
+var InstallerVersion = "dev"
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidName reports whether name is safe as a single path component: a
+// skill or patch name, or a GitHub owner or repository name. It starts with
+// a letter or digit and holds only letters, digits, '.', '_' and '-'.
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
 // Manager handles manifest operations
 type Manager struct {
 	cfg      *config.Config
@@ -26,7 +43,7 @@ func NewManager(cfg *config.Config) *Manager {
 
 // Load reads the manifest from disk
 func (m *Manager) Load() error {
-	data, err := os.ReadFile(m.cfg.ManifestPath)
+	manifest, err := ReadFile(m.cfg.ManifestPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			m.manifest = NewManifest()
@@ -34,18 +51,26 @@ func (m *Manager) Load() error {
 		}
 		return err
 	}
+	m.manifest = manifest
+	return nil
+}
+
+// ReadFile parses a manifest file, such as one copied from another machine
+func ReadFile(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
 
 	var manifest Manifest
 	if err := yaml.Unmarshal(data, &manifest); err != nil {
-		return err
+		return nil, err
 	}
 
 	if manifest.Installed == nil {
 		manifest.Installed = make(map[string]InstalledSkill)
 	}
-
-	m.manifest = &manifest
-	return nil
+	return &manifest, nil
 }
 
 // Save writes the manifest to disk
@@ -76,27 +101,590 @@ func (m *Manager) AddSkill(name, version, commit, sourceRepo, sourcePath string)
 		m.manifest = NewManifest()
 	}
 
-	m.manifest.Installed[name] = InstalledSkill{
-		Version:     version,
-		Commit:      commit,
-		InstalledAt: time.Now(),
-		SourceRepo:  sourceRepo,
-		SourcePath:  sourcePath,
+	// Start from the existing entry so per-skill settings (tracked branch,
+	// etc.) survive reinstalls and updates
+	entry := m.manifest.Installed[name]
+	if entry.ForkedFrom != "" && entry.SourceRepo != sourceRepo {
+		// Reinstalled from another source: the skill is no longer the fork
+		entry.ForkedFrom = ""
+		entry.Branch = ""
+	}
+	if entry.AdHoc && entry.SourceRepo != sourceRepo {
+		// Reinstalled from another source, which records its own origin
+		entry.AdHoc = false
 	}
+	if entry.Commit != "" && entry.Commit != commit {
+		entry.History = pushHistory(entry.History, PastInstall{Version: entry.Version, Commit: entry.Commit, InstalledAt: entry.InstalledAt})
+	}
+	entry.SourceRepo = sourceRepo
+	entry.SourcePath = sourcePath
+	entry.Orphaned = false
+	m.manifest.Installed[name] = checkedOut(entry, version, commit)
 
 	return m.Save()
 }
 
-// RemoveSkill removes a skill from the manifest
+// RollBack records that a skill was checked out at the newest commit of
+// its history. That entry is taken off the history instead of the
+// abandoned commit being added, so rolling back again goes one step
+// further back.
+func (m *Manager) RollBack(name string) (PastInstall, error) {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return PastInstall{}, fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	if len(entry.History) == 0 {
+		return PastInstall{}, fmt.Errorf("skill %s has no earlier commit to roll back to", name)
+	}
+	prev := entry.History[0]
+	entry.History = entry.History[1:]
+	m.manifest.Installed[name] = checkedOut(entry, prev.Version, prev.Commit)
+
+	return prev, m.Save()
+}
+
+// pushHistory adds a past install to the front of a skill's history,
+// dropping older entries for the same commit and beyond HistoryLimit
+func pushHistory(history []PastInstall, past PastInstall) []PastInstall {
+	updated := []PastInstall{past}
+	for _, h := range history {
+		if h.Commit != past.Commit && len(updated) < HistoryLimit {
+			updated = append(updated, h)
+		}
+	}
+	return updated
+}
+
+// checkedOut returns entry at a new version and commit, with its
+// provenance updated to match
+func checkedOut(entry InstalledSkill, version, commit string) InstalledSkill {
+	entry.Version = version
+	entry.Commit = commit
+	entry.InstalledAt = time.Now()
+
+	// The listing index is set by SetIndexSource and survives updates from
+	// the same repository
+	var index string
+	if entry.Provenance != nil && entry.Provenance.URL == entry.SourceRepo {
+		index = entry.Provenance.Index
+	}
+	entry.Provenance = &Provenance{
+		URL:       entry.SourceRepo,
+		Ref:       version,
+		Commit:    commit,
+		Index:     index,
+		Installer: InstallerVersion,
+		At:        entry.InstalledAt,
+	}
+	return entry
+}
+
+// SetIndexSource records in a skill's provenance where it was listed
+func (m *Manager) SetIndexSource(name, index string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	if entry.Provenance == nil {
+		entry.Provenance = &Provenance{URL: entry.SourceRepo, Ref: entry.Version, Commit: entry.Commit, Installer: InstallerVersion, At: entry.InstalledAt}
+	}
+	entry.Provenance.Index = index
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// SetBranch switches a skill's update channel. An empty branch returns the
+// skill to following release tags. Tracking a branch drops the pin.
+func (m *Manager) SetBranch(name, branch string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	entry.Branch = branch
+	if branch != "" {
+		entry.Pin = ""
+	}
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// SetPin pins a skill to a version, stopping it from tracking a branch. An
+// empty pin lets it follow release tags again.
+func (m *Manager) SetPin(name, pin string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	entry.Pin = pin
+	if pin != "" {
+		entry.Branch = ""
+	}
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// RemoveSkill removes a skill from the manifest, along with the
+// compatibility symlinks left by renaming it
 func (m *Manager) RemoveSkill(name string) error {
 	if m.manifest == nil {
 		return nil
 	}
 
+	for _, alias := range m.manifest.Installed[name].Aliases {
+		os.Remove(m.GetSkillPath(alias))
+	}
 	delete(m.manifest.Installed, name)
 	return m.Save()
 }
 
+// RestoreSkill tracks a skill again with the entry it had when it was
+// removed. Its compatibility symlinks went with it, so aliases are dropped.
+func (m *Manager) RestoreSkill(name string, skill InstalledSkill) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	skill.Aliases = nil
+	m.manifest.Installed[name] = skill
+	return m.Save()
+}
+
+// SetPolicy sets a skill's semver update policy. An empty policy falls back
+// to the global update_policy.
+func (m *Manager) SetPolicy(name, policy string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	entry.Policy = policy
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// SetFork rewires a skill's source to a fork, remembering the original
+// repository. The skill then follows branch in the fork.
+func (m *Manager) SetFork(name, repo, path, commit, branch string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	if entry.ForkedFrom == "" {
+		entry.ForkedFrom = entry.SourceRepo
+	}
+	entry.SourceRepo = repo
+	entry.SourcePath = path
+	entry.Commit = commit
+	entry.Version = branch
+	entry.Branch = branch
+	entry.Conflicts = nil
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// SetAdHoc records whether a skill was installed with --from, straight
+// from its source instead of a registry
+func (m *Manager) SetAdHoc(name string, adhoc bool) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	entry.AdHoc = adhoc
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// SetUpstream records the registry name of a skill installed under another
+// local name. Setting it to the local name clears it.
+func (m *Manager) SetUpstream(name, upstream string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	if upstream == name {
+		upstream = ""
+	}
+	entry.Upstream = upstream
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// Rename moves a skill's entry to a new local name, remembering the
+// registry name. With keepAlias the old name is recorded as an alias.
+func (m *Manager) Rename(oldName, newName string, keepAlias bool) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[oldName]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", oldName)
+	}
+	if _, taken := m.manifest.Installed[newName]; taken {
+		return fmt.Errorf("skill %s is already installed", newName)
+	}
+
+	if entry.Upstream == "" {
+		entry.Upstream = oldName
+	} else if entry.Upstream == newName {
+		entry.Upstream = ""
+	}
+	aliases := entry.Aliases[:0]
+	for _, a := range entry.Aliases {
+		if a != newName {
+			aliases = append(aliases, a)
+		}
+	}
+	if keepAlias {
+		aliases = append(aliases, oldName)
+	}
+	entry.Aliases = aliases
+
+	delete(m.manifest.Installed, oldName)
+	m.manifest.Installed[newName] = entry
+
+	return m.Save()
+}
+
+// AliasOwner returns the skill a compatibility alias points to
+func (m *Manager) AliasOwner(alias string) (string, bool) {
+	if m.manifest == nil {
+		return "", false
+	}
+	for name, entry := range m.manifest.Installed {
+		for _, a := range entry.Aliases {
+			if a == alias {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// DropAlias forgets a compatibility alias
+func (m *Manager) DropAlias(alias string) error {
+	owner, ok := m.AliasOwner(alias)
+	if !ok {
+		return nil
+	}
+	entry := m.manifest.Installed[owner]
+	aliases := entry.Aliases[:0]
+	for _, a := range entry.Aliases {
+		if a != alias {
+			aliases = append(aliases, a)
+		}
+	}
+	entry.Aliases = aliases
+	m.manifest.Installed[owner] = entry
+
+	return m.Save()
+}
+
+// MoveRepo points the skills installed from oldURL at newURL: their
+// manifest entries, the origin and directory of the shared clone and of
+// the skills' own clones, and the skill symlinks into them. It returns the
+// names of the skills that moved.
+func (m *Manager) MoveRepo(oldURL, newURL string) ([]string, error) {
+	if m.manifest == nil || oldURL == newURL {
+		return nil, nil
+	}
+
+	oldDir := filepath.Join(m.cfg.ReposDir, git.RepoDirName(oldURL))
+	newDir := filepath.Join(m.cfg.ReposDir, git.RepoDirName(newURL))
+	dirs := []string{oldDir}
+	entries, _ := os.ReadDir(m.cfg.ReposDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), filepath.Base(oldDir)+git.OwnCloneSep) {
+			dirs = append(dirs, filepath.Join(m.cfg.ReposDir, e.Name()))
+		}
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		if err := git.SetRemoteURL(dir, newURL); err != nil {
+			return nil, err
+		}
+		if newDir != oldDir {
+			dest := newDir + strings.TrimPrefix(dir, oldDir)
+			if _, err := os.Stat(dest); err == nil {
+				return nil, fmt.Errorf("%s already exists", dest)
+			}
+			if err := os.Rename(dir, dest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var moved []string
+	for name, entry := range m.manifest.Installed {
+		if entry.SourceRepo != oldURL {
+			continue
+		}
+		entry.SourceRepo = newURL
+		m.manifest.Installed[name] = entry
+		moved = append(moved, name)
+
+		if newDir != oldDir {
+			link := m.GetSkillPath(name)
+			if target, err := os.Readlink(link); err == nil && strings.HasPrefix(target, oldDir) {
+				os.Remove(link)
+				if err := os.Symlink(newDir+strings.TrimPrefix(target, oldDir), link); err != nil {
+					return moved, fmt.Errorf("failed to relink %s: %w", name, err)
+				}
+			}
+		}
+	}
+	sort.Strings(moved)
+
+	return moved, m.Save()
+}
+
+// FromRepo returns the names of the skills installed from repoURL, sorted
+func (m *Manager) FromRepo(repoURL string) []string {
+	if m.manifest == nil {
+		return nil
+	}
+
+	var names []string
+	for name, entry := range m.manifest.Installed {
+		if entry.SourceRepo == repoURL {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetOrphaned marks the skills installed from repoURL as orphaned when their
+// repository is removed, or clears the mark when it is added back. It
+// returns the names of the skills it changed.
+func (m *Manager) SetOrphaned(repoURL string, orphaned bool) ([]string, error) {
+	var changed []string
+	for _, name := range m.FromRepo(repoURL) {
+		entry := m.manifest.Installed[name]
+		if entry.Orphaned == orphaned {
+			continue
+		}
+		entry.Orphaned = orphaned
+		m.manifest.Installed[name] = entry
+		changed = append(changed, name)
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	return changed, m.Save()
+}
+
+// Orphans returns the tracked skills whose source repository is not among
+// repoURLs, sorted. Forks and ad-hoc installs are left out: they update
+// from sources that are not configured repositories.
+func (m *Manager) Orphans(repoURLs []string) []string {
+	if m.manifest == nil {
+		return nil
+	}
+
+	var names []string
+	for name, entry := range m.manifest.Installed {
+		if entry.SourceRepo == "" || entry.ForkedFrom != "" || entry.AdHoc || slices.Contains(repoURLs, entry.SourceRepo) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Untrack turns a tracked skill into a local one: a skill linked into a
+// repository clone is replaced by a copy of its files, then its manifest
+// entry is dropped.
+func (m *Manager) Untrack(name string) error {
+	if _, ok := m.GetInstalled(name); !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+
+	link := m.GetSkillPath(name)
+	if target, err := os.Readlink(link); err == nil {
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(link), target)
+		}
+		tmp := link + ".lazyas-local"
+		if err := copyTree(target, tmp); err != nil {
+			os.RemoveAll(tmp)
+			return fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+		if err := os.Remove(link); err != nil {
+			os.RemoveAll(tmp)
+			return err
+		}
+		if err := os.Rename(tmp, link); err != nil {
+			return err
+		}
+	}
+
+	return m.RemoveSkill(name)
+}
+
+// copyTree copies the files under src to dst, leaving out git metadata
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		out := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(out, 0755)
+		case d.Type()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, out)
+		default:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(out, data, info.Mode().Perm())
+		}
+	})
+}
+
+// SetConflicts records the files a merge left with conflict markers.
+// nil clears the "needs resolution" state.
+func (m *Manager) SetConflicts(name string, files []string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	entry, ok := m.manifest.Installed[name]
+	if !ok {
+		return fmt.Errorf("skill %s is not in the manifest", name)
+	}
+	entry.Conflicts = files
+	m.manifest.Installed[name] = entry
+
+	return m.Save()
+}
+
+// SetHashes records content hashes for several skills with a single save.
+// Names not in the manifest are ignored.
+func (m *Manager) SetHashes(hashes map[string]string) error {
+	if m.manifest == nil {
+		m.manifest = NewManifest()
+	}
+
+	for name, hash := range hashes {
+		entry, ok := m.manifest.Installed[name]
+		if !ok {
+			continue
+		}
+		entry.Hash = hash
+		m.manifest.Installed[name] = entry
+	}
+
+	return m.Save()
+}
+
+// Dependencies returns the skills an installed skill declares in its
+// SKILL.md frontmatter
+func (m *Manager) Dependencies(name string) []string {
+	content, err := os.ReadFile(filepath.Join(m.GetSkillPath(name), "SKILL.md"))
+	if err != nil {
+		return nil
+	}
+	fm, _ := skillmd.ParseFrontmatter(string(content))
+	return fm.Dependencies
+}
+
+// Dependents returns the installed skills that need name, directly or
+// through other skills, sorted by name
+func (m *Manager) Dependents(name string) []string {
+	// Dependencies refer to registry names; index both those and local names
+	needs := make(map[string][]string) // skill -> local names depending on it
+	for local := range m.ListInstalled() {
+		for _, dep := range m.Dependencies(local) {
+			needs[dep] = append(needs[dep], local)
+		}
+	}
+
+	seen := map[string]bool{name: true}
+	queue := []string{name}
+	var result []string
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		keys := []string{cur}
+		if info, ok := m.GetInstalled(cur); ok && info.RegistryName(cur) != cur {
+			keys = append(keys, info.RegistryName(cur))
+		}
+		for _, key := range keys {
+			for _, dependent := range needs[key] {
+				if !seen[dependent] {
+					seen[dependent] = true
+					result = append(result, dependent)
+					queue = append(queue, dependent)
+				}
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
 // IsInstalled checks if a skill is installed (exists on disk with SKILL.md)
 func (m *Manager) IsInstalled(name string) bool {
 	skillPath := filepath.Join(m.cfg.SkillsDir, name)
@@ -127,6 +715,84 @@ func (m *Manager) GetSkillPath(name string) string {
 	return filepath.Join(m.cfg.SkillsDir, name)
 }
 
+// CaseClash returns the installed skill or directory whose name differs
+// from name only by case. Such names collide on case-insensitive
+// filesystems like the macOS and Windows defaults. A name that is itself
+// taken, spelled exactly, does not clash: it refers to that skill.
+func (m *Manager) CaseClash(name string) (string, bool) {
+	taken := m.takenNames()
+	if slices.Contains(taken, name) {
+		return "", false
+	}
+	for _, other := range taken {
+		if strings.EqualFold(other, name) {
+			return other, true
+		}
+	}
+	return "", false
+}
+
+// UniqueName returns name, or name with a numeric suffix when it would
+// collide by case with an existing skill, so the install gets a
+// directory of its own on every filesystem
+func (m *Manager) UniqueName(name string) string {
+	if _, clash := m.CaseClash(name); !clash {
+		return name
+	}
+	return m.FreeName(name)
+}
+
+// FreeName returns name with the lowest numeric suffix, from 2, that no
+// skill uses in any spelling
+func (m *Manager) FreeName(name string) string {
+	taken := make(map[string]bool)
+	for _, other := range m.takenNames() {
+		taken[strings.ToLower(other)] = true
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", name, n)
+		if !taken[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
+
+// Occupant describes the skill installed under name when it is not the
+// skill at sourcePath in sourceRepo: "" when name is free or holds that
+// skill (or a fork of it), "local" for an untracked skill, else the
+// repository the other skill came from
+func (m *Manager) Occupant(name, sourceRepo, sourcePath string) string {
+	if !m.IsInstalled(name) {
+		return ""
+	}
+	info, tracked := m.GetInstalled(name)
+	if !tracked {
+		return "local"
+	}
+	// A fork keeps the name but not the path of the original
+	if info.SourceRepo == sourceRepo && info.SourcePath == sourcePath || info.ForkedFrom != "" && info.ForkedFrom == sourceRepo {
+		return ""
+	}
+	return info.SourceRepo
+}
+
+// takenNames lists the entries of the skills directory and the skills in
+// the manifest, as spelled
+func (m *Manager) takenNames() []string {
+	var names []string
+	if entries, err := os.ReadDir(m.cfg.SkillsDir); err == nil {
+		for _, entry := range entries {
+			if entry.Name() != ".lazyas" {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+	for name := range m.ListInstalled() {
+		names = append(names, name)
+	}
+	return names
+}
+
 // ScanLocalSkills scans the skills directory for locally installed skills
 // Returns a map of skill name -> LocalSkill for each directory containing SKILL.md
 func (m *Manager) ScanLocalSkills() map[string]LocalSkill {
@@ -138,10 +804,13 @@ func (m *Manager) ScanLocalSkills() map[string]LocalSkill {
 	}
 
 	for _, entry := range entries {
-		// Skip the .lazyas directory
+		// Skip the .lazyas directory and compatibility aliases of renamed skills
 		if entry.Name() == ".lazyas" {
 			continue
 		}
+		if _, isAlias := m.AliasOwner(entry.Name()); isAlias {
+			continue
+		}
 
 		skillPath := filepath.Join(m.cfg.SkillsDir, entry.Name())
 
@@ -154,10 +823,15 @@ func (m *Manager) ScanLocalSkills() map[string]LocalSkill {
 		skillMdPath := filepath.Join(skillPath, "SKILL.md")
 
 		if _, err := os.Stat(skillMdPath); err == nil {
-			// Read SKILL.md to extract description
-			description := ""
+			// Read SKILL.md to extract description and declared name
+			description, declaredName := "", ""
+			var tags []string
 			if content, err := os.ReadFile(skillMdPath); err == nil {
 				description = skillmd.ExtractDescription(string(content))
+				if fm, err := skillmd.ParseFrontmatter(string(content)); err == nil {
+					declaredName = fm.Name
+					tags = fm.SkillTags()
+				}
 			}
 
 			// Check if it's a git repo and if it's modified
@@ -168,11 +842,13 @@ func (m *Manager) ScanLocalSkills() map[string]LocalSkill {
 			}
 
 			result[entry.Name()] = LocalSkill{
-				Name:        entry.Name(),
-				Path:        skillPath,
-				Description: description,
-				IsGitRepo:   isGitRepo,
-				IsModified:  isModified,
+				Name:         entry.Name(),
+				Path:         skillPath,
+				Description:  description,
+				DeclaredName: declaredName,
+				Tags:         tags,
+				IsGitRepo:    isGitRepo,
+				IsModified:   isModified,
 			}
 		}
 	}
@@ -188,13 +864,9 @@ func isGitRepository(path string) bool {
 	return err == nil
 }
 
-// hasLocalModifications checks if a git repo has uncommitted changes
+// hasLocalModifications checks if a git repo has uncommitted changes,
+// honoring .lazyasignore
 func hasLocalModifications(path string) bool {
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = path
-	out, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	return len(skillmd.TrimSpace(string(out))) > 0
+	modified, _ := git.IsModified(path)
+	return modified
 }
@@ -0,0 +1,69 @@
+package manifest
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lazyas/internal/config"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.SetHome(filepath.Join(t.TempDir(), ".lazyas"))
+	m := NewManager(cfg)
+	if err := m.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestAddSkill_KeepsHistoryAndRollsBack(t *testing.T) {
+	m := newTestManager(t)
+	for i, commit := range []string{"c1", "c2", "c3", "c2", "c4", "c5", "c6", "c7"} {
+		if err := m.AddSkill("pdf", "v"+commit, commit, "https://example.com/skills", "pdf"); err != nil {
+			t.Fatalf("install %d: %v", i, err)
+		}
+	}
+
+	info, _ := m.GetInstalled("pdf")
+	var commits []string
+	for _, h := range info.History {
+		commits = append(commits, h.Commit)
+	}
+	// Newest first, each commit once, at most HistoryLimit
+	if want := "c6 c5 c4 c2 c3"; strings.Join(commits, " ") != want {
+		t.Fatalf("history %v, want %s", commits, want)
+	}
+
+	prev, err := m.RollBack("pdf")
+	if err != nil || prev.Commit != "c6" {
+		t.Fatalf("rolled back to %+v (%v), want c6", prev, err)
+	}
+	if _, err := m.RollBack("pdf"); err != nil {
+		t.Fatal(err)
+	}
+	info, _ = m.GetInstalled("pdf")
+	if info.Commit != "c5" || info.Version != "vc5" || info.Provenance.Commit != "c5" || len(info.History) != 3 {
+		t.Errorf("after two rollbacks got %s %s, %d in history", info.Version, info.Commit, len(info.History))
+	}
+}
+
+func TestOrphans_SkipsAdHocInstalls(t *testing.T) {
+	m := newTestManager(t)
+	m.AddSkill("pdf", "v1", "c1", "https://example.com/skills", "pdf")
+	m.AddSkill("mine", "", "c2", "https://example.com/mine", "")
+	if err := m.SetAdHoc("mine", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Orphans(nil); strings.Join(got, " ") != "pdf" {
+		t.Fatalf("Orphans = %v, want [pdf]", got)
+	}
+
+	// Reinstalling from elsewhere drops the mark
+	m.AddSkill("mine", "v1", "c3", "https://example.com/skills", "mine")
+	if info, _ := m.GetInstalled("mine"); info.AdHoc {
+		t.Error("reinstall from another source kept the ad-hoc mark")
+	}
+}
@@ -10,20 +10,90 @@ type Manifest struct {
 
 // InstalledSkill represents an installed skill tracked in manifest
 type InstalledSkill struct {
-	Version     string    `yaml:"version"`
+	Version     string        `yaml:"version"`
+	Commit      string        `yaml:"commit"`
+	InstalledAt time.Time     `yaml:"installed_at"`
+	SourceRepo  string        `yaml:"source_repo"`
+	SourcePath  string        `yaml:"source_path,omitempty"`
+	Branch      string        `yaml:"branch,omitempty"`        // tracked branch; empty = follow release tags
+	Pin         string        `yaml:"pin,omitempty"`           // version updates stay within; see semver.MatchesPin
+	Policy      string        `yaml:"update_policy,omitempty"` // semver update policy; empty = config default
+	Hash        string        `yaml:"hash,omitempty"`          // content hash recorded after validation
+	Conflicts   []string      `yaml:"conflicts,omitempty"`     // files left with conflict markers by a merge
+	ForkedFrom  string        `yaml:"forked_from,omitempty"`   // original repo when the source is a user fork
+	History     []PastInstall `yaml:"history,omitempty"`       // earlier installed commits, newest first, for rollback
+	Upstream    string        `yaml:"upstream_name,omitempty"` // registry name when installed under another local name
+	Aliases     []string      `yaml:"aliases,omitempty"`       // former local names kept as compatibility symlinks
+	Orphaned    bool          `yaml:"orphaned,omitempty"`      // source repository was removed from the config
+	AdHoc       bool          `yaml:"adhoc,omitempty"`         // installed with --from, outside any registry
+	Provenance  *Provenance   `yaml:"provenance,omitempty"`    // where the installed files came from
+}
+
+// HistoryLimit is how many earlier commits are kept per skill
+const HistoryLimit = 5
+
+// PastInstall is a commit a skill was installed at before an update
+type PastInstall struct {
+	Version     string    `yaml:"version,omitempty"`
 	Commit      string    `yaml:"commit"`
 	InstalledAt time.Time `yaml:"installed_at"`
-	SourceRepo  string    `yaml:"source_repo"`
-	SourcePath  string    `yaml:"source_path,omitempty"`
+}
+
+// Provenance records the origin of the files of the last install or
+// update of a skill, for supply-chain audits
+type Provenance struct {
+	URL       string    `yaml:"url"`             // clone URL the files were fetched from
+	Ref       string    `yaml:"ref,omitempty"`   // tag or branch requested; empty = remote default branch
+	Commit    string    `yaml:"commit"`          // commit checked out
+	Index     string    `yaml:"index,omitempty"` // where the skill was listed (registry.IndexSource)
+	Installer string    `yaml:"installer"`       // lazyas version that wrote the record
+	At        time.Time `yaml:"at"`
+}
+
+// RegistryName returns the skill's name in the registry; local is the name
+// it is installed under
+func (s InstalledSkill) RegistryName(local string) string {
+	if s.Upstream != "" {
+		return s.Upstream
+	}
+	return local
+}
+
+// TargetRef returns the git ref an update should move to: the tracked branch
+// when set, otherwise the registry tag ("" means the remote default branch).
+func (s InstalledSkill) TargetRef(registryTag string) string {
+	if s.Branch != "" {
+		return s.Branch
+	}
+	return registryTag
+}
+
+// NeedsResolution reports whether a merge left conflicts to resolve
+func (s InstalledSkill) NeedsResolution() bool {
+	return len(s.Conflicts) > 0
+}
+
+// Channel describes the update channel for display ("branch main",
+// "pinned v1.2" or "tags")
+func (s InstalledSkill) Channel() string {
+	if s.Branch != "" {
+		return "branch " + s.Branch
+	}
+	if s.Pin != "" {
+		return "pinned " + s.Pin
+	}
+	return "tags"
 }
 
 // LocalSkill represents a skill found on the local filesystem
 type LocalSkill struct {
-	Name        string
-	Path        string
-	Description string
-	IsGitRepo   bool
-	IsModified  bool
+	Name         string
+	Path         string
+	Description  string
+	DeclaredName string   // name from the SKILL.md frontmatter, if any
+	Tags         []string // tags from the SKILL.md frontmatter
+	IsGitRepo    bool
+	IsModified   bool
 }
 
 // NewManifest creates a new manifest with defaults
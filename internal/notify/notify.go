@@ -0,0 +1,138 @@
+// Package notify shows native desktop notifications: notify-send on Linux
+// and the BSDs, osascript on macOS and a toast through PowerShell on
+// Windows. Notifications are optional and held back during quiet hours.
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuietHours is a daily window, in minutes after midnight, in which no
+// notifications are shown. A window may wrap past midnight; Start == End
+// means no quiet hours.
+type QuietHours struct {
+	Start int
+	End   int
+}
+
+// ParseQuietHours parses a "22:00-07:00" window. An empty string means no
+// quiet hours.
+func ParseQuietHours(s string) (QuietHours, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return QuietHours{}, nil
+	}
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours %q (expected HH:MM-HH:MM)", s)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours %q: %w", s, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours %q: %w", s, err)
+	}
+	return QuietHours{Start: start, End: end}, nil
+}
+
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	hour, err := strconv.Atoi(h)
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	minute, err := strconv.Atoi(m)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// Contains reports whether t falls within the quiet hours
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.Start == q.End {
+		return false
+	}
+	now := t.Hour()*60 + t.Minute()
+	if q.Start < q.End {
+		return now >= q.Start && now < q.End
+	}
+	return now >= q.Start || now < q.End
+}
+
+// Notifier sends notifications when enabled and outside quiet hours
+type Notifier struct {
+	Enabled bool
+	Quiet   QuietHours
+}
+
+// New returns a notifier for the notifications and quiet_hours settings
+func New(enabled bool, quietHours string) (*Notifier, error) {
+	quiet, err := ParseQuietHours(quietHours)
+	if err != nil {
+		return nil, err
+	}
+	return &Notifier{Enabled: enabled, Quiet: quiet}, nil
+}
+
+// Notify shows a notification unless notifications are off or it is now
+// quiet hours. A nil notifier does nothing.
+func (n *Notifier) Notify(title, body string) error {
+	return n.notifyAt(title, body, time.Now())
+}
+
+func (n *Notifier) notifyAt(title, body string, now time.Time) error {
+	if n == nil || !n.Enabled || n.Quiet.Contains(now) {
+		return nil
+	}
+	name, args, err := command(runtime.GOOS, title, body)
+	if err != nil {
+		return err
+	}
+	if out, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %v %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// command returns the program and arguments that show a notification on goos
+func command(goos, title, body string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleString(body), appleString(title))
+		return "osascript", []string{"-e", script}, nil
+	case "windows":
+		script := fmt.Sprintf(`[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $xml.GetElementsByTagName('text')
+$text.Item(0).AppendChild($xml.CreateTextNode(%s)) > $null
+$text.Item(1).AppendChild($xml.CreateTextNode(%s)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('lazyas').Show([Windows.UI.Notifications.ToastNotification]::new($xml))`,
+			powershellString(title), powershellString(body))
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}, nil
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		return "notify-send", []string{"--app-name=lazyas", title, body}, nil
+	}
+	return "", nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+}
+
+// appleString quotes s as an AppleScript string literal
+func appleString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// powershellString quotes s as a single-quoted PowerShell string
+func powershellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
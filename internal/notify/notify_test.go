@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuietHours(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 1, 1, h, m, 0, 0, time.Local) }
+
+	overnight, err := ParseQuietHours("22:00-07:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(21, 59), false},
+		{at(22, 0), true},
+		{at(3, 0), true},
+		{at(7, 29), true},
+		{at(7, 30), false},
+	} {
+		if got := overnight.Contains(c.t); got != c.want {
+			t.Errorf("22:00-07:30 Contains(%s) = %v, want %v", c.t.Format("15:04"), got, c.want)
+		}
+	}
+
+	lunch, _ := ParseQuietHours("12:00-13:00")
+	if !lunch.Contains(at(12, 30)) || lunch.Contains(at(13, 0)) {
+		t.Error("12:00-13:00 window is wrong")
+	}
+	if none, _ := ParseQuietHours(""); none.Contains(at(3, 0)) {
+		t.Error("expected no quiet hours for an empty setting")
+	}
+	for _, bad := range []string{"22:00", "25:00-07:00", "22:00-7", "ten-eleven"} {
+		if _, err := ParseQuietHours(bad); err == nil {
+			t.Errorf("ParseQuietHours(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	name, args, err := command("linux", "lazyas", "2 updates")
+	if err != nil || name != "notify-send" || args[len(args)-1] != "2 updates" {
+		t.Errorf("linux: %s %v %v", name, args, err)
+	}
+
+	name, args, err = command("darwin", `say "hi"`, `a\b`)
+	if err != nil || name != "osascript" || args[1] != `display notification "a\\b" with title "say \"hi\""` {
+		t.Errorf("darwin: %s %v %v", name, args, err)
+	}
+
+	name, args, err = command("windows", "lazyas", "it's here")
+	if err != nil || name != "powershell" || !strings.Contains(args[len(args)-1], "'it''s here'") {
+		t.Errorf("windows: %s %v %v", name, args, err)
+	}
+
+	if _, _, err := command("plan9", "a", "b"); err == nil {
+		t.Error("expected an error on an unsupported OS")
+	}
+}
+
+func TestNotify_DisabledOrQuiet(t *testing.T) {
+	var n *Notifier
+	if err := n.Notify("a", "b"); err != nil {
+		t.Errorf("nil notifier: %v", err)
+	}
+	if err := (&Notifier{}).Notify("a", "b"); err != nil {
+		t.Errorf("disabled notifier: %v", err)
+	}
+	night := &Notifier{Enabled: true, Quiet: QuietHours{Start: 22 * 60, End: 7 * 60}}
+	if err := night.notifyAt("a", "b", time.Date(2026, 1, 1, 23, 0, 0, 0, time.Local)); err != nil {
+		t.Errorf("quiet notifier: %v", err)
+	}
+}
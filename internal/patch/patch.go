@@ -0,0 +1,174 @@
+package patch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"lazyas/internal/manifest"
+)
+
+// headerPrefix starts the metadata line written above the diff. git apply
+// ignores text before the first "diff --git" line.
+const headerPrefix = "lazyas-patch:"
+
+// Patch describes a saved set of local modifications to a skill
+type Patch struct {
+	Skill string
+	Name  string
+	Base  string    // commit the diff was taken against
+	Saved time.Time // when it was saved
+	Path  string
+}
+
+// Store keeps patches as <Dir>/<skill>/<name>.patch
+type Store struct {
+	Dir string
+}
+
+// NewStore creates a patch store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// ValidateName checks that a patch name is safe to use as a file name
+func ValidateName(name string) error {
+	if !manifest.ValidName(name) {
+		return fmt.Errorf("invalid patch name %q (use letters, digits, '.', '_' or '-')", name)
+	}
+	return nil
+}
+
+// validate checks the skill and patch names before they are joined into a
+// path, so neither can reach outside the store
+func validate(skill, name string) error {
+	if !manifest.ValidName(skill) {
+		return fmt.Errorf("invalid skill name %q", skill)
+	}
+	return ValidateName(name)
+}
+
+func (s *Store) path(skill, name string) string {
+	return filepath.Join(s.Dir, skill, name+".patch")
+}
+
+// Save writes diff as a named patch, replacing any patch with that name
+func (s *Store) Save(skill, name, base string, diff []byte) (*Patch, error) {
+	if err := validate(skill, name); err != nil {
+		return nil, err
+	}
+	p := &Patch{Skill: skill, Name: name, Base: base, Saved: time.Now().UTC(), Path: s.path(skill, name)}
+
+	if err := os.MkdirAll(filepath.Dir(p.Path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create patch directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s skill=%s base=%s saved=%s\n", headerPrefix, skill, base, p.Saved.Format(time.RFC3339))
+	buf.Write(diff)
+	if err := os.WriteFile(p.Path, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write patch: %w", err)
+	}
+	return p, nil
+}
+
+// Load returns a patch's metadata and diff
+func (s *Store) Load(skill, name string) (*Patch, []byte, error) {
+	if err := validate(skill, name); err != nil {
+		return nil, nil, err
+	}
+	path := s.path(skill, name)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("patch %s not found for %s", name, skill)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	p := parseHeader(skill, name, path, data)
+	return p, data, nil
+}
+
+// List returns the patches for skill, or for all skills when skill is "",
+// sorted by skill then name
+func (s *Store) List(skill string) ([]Patch, error) {
+	pattern := filepath.Join(s.Dir, "*", "*.patch")
+	if skill != "" {
+		if !manifest.ValidName(skill) {
+			return nil, fmt.Errorf("invalid skill name %q", skill)
+		}
+		pattern = filepath.Join(s.Dir, skill, "*.patch")
+	}
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var patches []Patch
+	for _, path := range paths {
+		skillName := filepath.Base(filepath.Dir(path))
+		name := strings.TrimSuffix(filepath.Base(path), ".patch")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		patches = append(patches, *parseHeader(skillName, name, path, data))
+	}
+
+	sort.Slice(patches, func(i, j int) bool {
+		if patches[i].Skill != patches[j].Skill {
+			return patches[i].Skill < patches[j].Skill
+		}
+		return patches[i].Name < patches[j].Name
+	})
+	return patches, nil
+}
+
+// Drop deletes a saved patch
+func (s *Store) Drop(skill, name string) error {
+	if err := validate(skill, name); err != nil {
+		return err
+	}
+	path := s.path(skill, name)
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("patch %s not found for %s", name, skill)
+		}
+		return err
+	}
+	os.Remove(filepath.Dir(path)) // only succeeds once the skill has no patches left
+	return nil
+}
+
+// Rename moves the patches of a skill to its new local name
+func (s *Store) Rename(oldSkill, newSkill string) error {
+	err := os.Rename(filepath.Join(s.Dir, oldSkill), filepath.Join(s.Dir, newSkill))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func parseHeader(skill, name, path string, data []byte) *Patch {
+	p := &Patch{Skill: skill, Name: name, Path: path}
+	line, _, _ := bufio.NewReader(bytes.NewReader(data)).ReadLine()
+	header, ok := strings.CutPrefix(string(line), headerPrefix)
+	if !ok {
+		return p
+	}
+	for _, field := range strings.Fields(header) {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "base":
+			p.Base = value
+		case "saved":
+			p.Saved, _ = time.Parse(time.RFC3339, value)
+		}
+	}
+	return p
+}
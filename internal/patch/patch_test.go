@@ -0,0 +1,90 @@
+package patch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStore_SaveListLoadDrop(t *testing.T) {
+	s := NewStore(t.TempDir())
+	diff := []byte("diff --git a/SKILL.md b/SKILL.md\n")
+
+	if _, err := s.Save("pdf", "local", "abc1234", diff); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Save("pdf", "tone", "abc1234", diff); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Save("docx", "local", "def5678", diff); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := s.List("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 || all[0].Skill != "docx" || all[1].Name != "local" || all[2].Name != "tone" {
+		t.Errorf("List(\"\") = %+v", all)
+	}
+
+	p, data, err := s.Load("pdf", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Base != "abc1234" || p.Saved.IsZero() {
+		t.Errorf("header not parsed: %+v", p)
+	}
+	if !strings.HasSuffix(string(data), string(diff)) {
+		t.Errorf("diff not preserved: %q", data)
+	}
+
+	if err := s.Drop("pdf", "local"); err != nil {
+		t.Fatal(err)
+	}
+	if left, _ := s.List("pdf"); len(left) != 1 || left[0].Name != "tone" {
+		t.Errorf("after drop: %+v", left)
+	}
+	if err := s.Drop("pdf", "local"); err == nil {
+		t.Error("dropping a missing patch should fail")
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"local", "v1.2", "my_patch-2"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "../x", "a/b", ".hidden", "with space"} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should fail", name)
+		}
+	}
+}
+
+func TestStore_RefusesPathsOutsideTheStore(t *testing.T) {
+	root := t.TempDir()
+	s := NewStore(filepath.Join(root, "patches"))
+	outside := filepath.Join(root, "secret.patch")
+	if err := os.WriteFile(outside, []byte("keep\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := s.Load("..", "secret"); err == nil {
+		t.Error("Load should refuse a skill name of ..")
+	}
+	if err := s.Drop("..", "secret"); err == nil {
+		t.Error("Drop should refuse a skill name of ..")
+	}
+	if err := s.Drop("pdf", "../../secret"); err == nil {
+		t.Error("Drop should refuse a patch name with ..")
+	}
+	if _, err := s.Save("../x", "local", "abc", nil); err == nil {
+		t.Error("Save should refuse a skill name with ..")
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("file outside the store was touched: %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+// Package progress reports how far long-running operations have come and
+// lets the caller cancel them between steps. Operations report into a
+// Tracker; the TUI polls it for the footer bar and the CLI draws it as a
+// live terminal line.
+package progress
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+)
+
+// ErrCancelled is returned by operations stopped through their Tracker
+var ErrCancelled = errors.New("cancelled")
+
+// State is a snapshot of an operation's progress
+type State struct {
+	Op      string // what is running, e.g. "Syncing repositories"
+	Step    string // the item being worked on
+	Current int    // 1-based index of the current step
+	Total   int    // number of steps; 0 while unknown
+}
+
+// Percent returns how much of the operation is done, 0-100
+func (s State) Percent() int {
+	if s.Total <= 0 || s.Current <= 0 {
+		return 0
+	}
+	return min(s.Current*100/s.Total, 100)
+}
+
+// Bar renders the state as "[=====     ] 3/10 step" with a bar of width
+// cells. Without a total only the step is shown.
+func (s State) Bar(width int) string {
+	if s.Total <= 0 {
+		return strings.TrimSpace(s.Op + " " + s.Step)
+	}
+	filled := width * s.Percent() / 100
+	bar := "[" + strings.Repeat("=", filled) + strings.Repeat(" ", width-filled) + "]"
+	return strings.TrimSpace(fmt.Sprintf("%s %d/%d %s", bar, s.Current, s.Total, s.Step))
+}
+
+// Tracker receives progress from one operation. All methods are safe for
+// concurrent use and do nothing on a nil Tracker, so operations can report
+// unconditionally.
+type Tracker struct {
+	mu        sync.Mutex
+	state     State
+	cancelled bool
+	sink      func(State)
+}
+
+// New starts tracking op. sink, when not nil, is called with every change.
+func New(op string, sink func(State)) *Tracker {
+	return &Tracker{state: State{Op: op}, sink: sink}
+}
+
+// Begin starts a new phase of the operation, e.g. fetching the index
+// before updating skills
+func (t *Tracker) Begin(op string, total int) {
+	t.update(func(s *State) { *s = State{Op: op, Total: total} })
+}
+
+// SetTotal sets the number of steps
+func (t *Tracker) SetTotal(total int) {
+	t.update(func(s *State) { s.Total = total })
+}
+
+// Set reports that the current-th step, named step, has started
+func (t *Tracker) Set(current int, step string) {
+	t.update(func(s *State) {
+		s.Current = current
+		s.Step = step
+	})
+}
+
+func (t *Tracker) update(change func(*State)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	change(&t.state)
+	state, sink := t.state, t.sink
+	t.mu.Unlock()
+	if sink != nil {
+		sink(state)
+	}
+}
+
+// State returns the latest progress
+func (t *Tracker) State() State {
+	if t == nil {
+		return State{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+// Cancel asks the operation to stop before its next step
+func (t *Tracker) Cancel() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.cancelled = true
+	t.mu.Unlock()
+}
+
+// Err returns ErrCancelled once Cancel was called
+func (t *Tracker) Err() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancelled {
+		return ErrCancelled
+	}
+	return nil
+}
+
+// CancelOnInterrupt cancels t on the first Ctrl+C so the operation can
+// stop cleanly after the current step; a second Ctrl+C exits at once.
+// Call the returned function when the operation is done.
+func CancelOnInterrupt(t *Tracker) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			t.Cancel()
+		case <-done:
+			return
+		}
+		select {
+		case <-signals:
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// Line draws progress as a single terminal line that is rewritten in
+// place. Nothing is drawn when the output is not a terminal.
+type Line struct {
+	mu    sync.Mutex
+	w     io.Writer
+	live  bool
+	drawn bool
+}
+
+// NewLine draws on f, usually os.Stderr
+func NewLine(f *os.File) *Line {
+	live := false
+	if info, err := f.Stat(); err == nil {
+		live = info.Mode()&os.ModeCharDevice != 0
+	}
+	return &Line{w: f, live: live}
+}
+
+// Draw replaces the line with s
+func (l *Line) Draw(s State) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.live {
+		return
+	}
+	text := s.Bar(20)
+	if s.Total > 0 && s.Op != "" {
+		text = s.Op + " " + text
+	}
+	fmt.Fprint(l.w, "\r\033[K"+text)
+	l.drawn = true
+}
+
+// Clear erases the line so other output can be printed
+func (l *Line) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.drawn {
+		fmt.Fprint(l.w, "\r\033[K")
+		l.drawn = false
+	}
+}
@@ -0,0 +1,53 @@
+package progress
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTracker_ReportsAndCancels(t *testing.T) {
+	var seen []State
+	tr := New("Syncing", func(s State) { seen = append(seen, s) })
+	tr.SetTotal(4)
+	tr.Set(1, "official")
+	tr.Set(2, "community")
+
+	s := tr.State()
+	if s.Op != "Syncing" || s.Current != 2 || s.Total != 4 || s.Step != "community" {
+		t.Errorf("unexpected state %+v", s)
+	}
+	if s.Percent() != 50 {
+		t.Errorf("Percent() = %d, want 50", s.Percent())
+	}
+	if len(seen) != 3 {
+		t.Errorf("sink called %d times, want 3", len(seen))
+	}
+
+	if tr.Err() != nil {
+		t.Error("expected no error before Cancel")
+	}
+	tr.Cancel()
+	if !errors.Is(tr.Err(), ErrCancelled) {
+		t.Errorf("Err() = %v, want ErrCancelled", tr.Err())
+	}
+}
+
+func TestTracker_NilIsNoop(t *testing.T) {
+	var tr *Tracker
+	tr.SetTotal(3)
+	tr.Set(1, "x")
+	tr.Cancel()
+	if tr.Err() != nil || tr.State() != (State{}) {
+		t.Error("expected a nil tracker to do nothing")
+	}
+}
+
+func TestState_Bar(t *testing.T) {
+	s := State{Op: "Updating", Step: "pdf", Current: 1, Total: 4}
+	if got := s.Bar(8); got != "[==      ] 1/4 pdf" {
+		t.Errorf("Bar() = %q", got)
+	}
+	if got := (State{Op: "Fetching", Step: "index"}).Bar(8); got != "Fetching index" {
+		t.Errorf("Bar() without total = %q", got)
+	}
+}
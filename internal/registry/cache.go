@@ -1,17 +1,40 @@
 package registry
 
 import (
+	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"gopkg.in/yaml.v3"
 	"lazyas/internal/config"
 )
 
-// Cache represents the cached index
+// cacheVersion is the layout of the cache file. Caches of another layout,
+// like the single merged index written before repos were cached one by
+// one, are dropped and fetched again.
+const cacheVersion = 2
+
+// Cache holds the skill list of each configured repository as last
+// fetched. Every repo is fetched on its own schedule, so one slow or
+// short-lived repo does not force a refetch of the others.
 type Cache struct {
-	Index     *Index    `yaml:"index"`
+	Version int                  `yaml:"version"`
+	Repos   map[string]RepoCache `yaml:"repos,omitempty"` // keyed by repo URL
+}
+
+// RepoCache is the skill list of one repository and when it was fetched
+type RepoCache struct {
+	FetchedAt time.Time    `yaml:"fetched_at"`
+	Commit    string       `yaml:"commit,omitempty"`
+	Skills    []SkillEntry `yaml:"skills"`
+}
+
+// RepoSync records the last successful fetch of a configured repository
+type RepoSync struct {
+	URL       string    `yaml:"url"`
 	FetchedAt time.Time `yaml:"fetched_at"`
+	Commit    string    `yaml:"commit,omitempty"`
 }
 
 // CacheManager handles index caching
@@ -42,6 +65,10 @@ func (c *CacheManager) Load() error {
 	if err := yaml.Unmarshal(data, &cache); err != nil {
 		return err
 	}
+	if cache.Version != cacheVersion {
+		c.cache = nil
+		return nil
+	}
 
 	c.cache = &cache
 	return nil
@@ -65,29 +92,133 @@ func (c *CacheManager) Save() error {
 	return os.WriteFile(c.cfg.CachePath, data, 0644)
 }
 
-// IsValid checks if the cache is valid
+// IsFresh reports whether the cached skill list of repo is younger than
+// its TTL, so it needs no fetch
+func (c *CacheManager) IsFresh(repo config.Repo) bool {
+	entry, ok := c.Repo(repo.URL)
+	return ok && time.Since(entry.FetchedAt) < c.cfg.RepoCacheTTL(repo)
+}
+
+// IsValid reports whether the cached skill lists of all configured repos
+// are fresh
 func (c *CacheManager) IsValid() bool {
-	if c.cache == nil || c.cache.Index == nil {
+	if c.cache == nil || len(c.cfg.Repos) == 0 {
 		return false
 	}
+	for _, repo := range c.cfg.Repos {
+		if !c.IsFresh(repo) {
+			return false
+		}
+	}
+	return true
+}
+
+// Repo returns the cached skill list of the repository at url
+func (c *CacheManager) Repo(url string) (RepoCache, bool) {
+	if c.cache == nil {
+		return RepoCache{}, false
+	}
+	entry, ok := c.cache.Repos[url]
+	return entry, ok
+}
 
-	ttl := time.Duration(c.cfg.CacheTTL) * time.Hour
-	return time.Since(c.cache.FetchedAt) < ttl
+// SetRepo replaces the cached skill list of the repository at url. It is
+// written by the next Save.
+func (c *CacheManager) SetRepo(url string, entry RepoCache) {
+	if c.cache == nil {
+		c.cache = &Cache{Version: cacheVersion}
+	}
+	if c.cache.Repos == nil {
+		c.cache.Repos = make(map[string]RepoCache)
+	}
+	c.cache.Repos[url] = entry
+}
+
+// Prune drops the skill lists of repositories that are no longer
+// configured
+func (c *CacheManager) Prune() {
+	if c.cache == nil {
+		return
+	}
+	for url := range c.cache.Repos {
+		if !slices.ContainsFunc(c.cfg.Repos, func(r config.Repo) bool { return r.URL == url }) {
+			delete(c.cache.Repos, url)
+		}
+	}
 }
 
-// Get returns the cached index
+// Get merges the cached skill lists of the configured repos, in config
+// order, into an index. Skills are tagged with the name of their config
+// repo, which the cache does not store so renamed repos are picked up.
+// It returns nil when nothing is cached.
 func (c *CacheManager) Get() *Index {
 	if c.cache == nil {
 		return nil
 	}
-	return c.cache.Index
+	index := &Index{}
+	for _, repo := range c.cfg.Repos {
+		entry, ok := c.cache.Repos[repo.URL]
+		if !ok {
+			continue
+		}
+		for _, skill := range entry.Skills {
+			if skill.Source.RepoName == "" {
+				skill.Source.RepoName = repo.Name
+			}
+			index.Skills = append(index.Skills, skill)
+		}
+	}
+	return index
+}
+
+// Repos returns the sync records of the configured repos that have a
+// cached skill list, keyed by repo name
+func (c *CacheManager) Repos() map[string]RepoSync {
+	if c.cache == nil {
+		return nil
+	}
+	syncs := make(map[string]RepoSync)
+	for _, repo := range c.cfg.Repos {
+		if entry, ok := c.cache.Repos[repo.URL]; ok {
+			syncs[repo.Name] = RepoSync{URL: repo.URL, FetchedAt: entry.FetchedAt, Commit: entry.Commit}
+		}
+	}
+	return syncs
+}
+
+// FormatAge renders how long ago t was in a compact form, e.g. "2h ago"
+func FormatAge(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
 }
 
-// Set updates the cache
-func (c *CacheManager) Set(index *Index) error {
-	c.cache = &Cache{
-		Index:     index,
-		FetchedAt: time.Now(),
+// RemapRepo follows a moved repository: its cached skill list is kept
+// under the new URL, and the skills it hosts point there
+func (c *CacheManager) RemapRepo(oldURL, newURL string) error {
+	if c.cache == nil || oldURL == newURL {
+		return nil
+	}
+
+	entry, ok := c.cache.Repos[oldURL]
+	if !ok {
+		return nil
+	}
+	delete(c.cache.Repos, oldURL)
+	for i := range entry.Skills {
+		src := &entry.Skills[i].Source
+		if src.Repo == oldURL {
+			src.Repo = newURL
+		}
 	}
+	c.cache.Repos[newURL] = entry
 	return c.Save()
 }
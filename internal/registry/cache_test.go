@@ -0,0 +1,182 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lazyas/internal/config"
+)
+
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{2 * time.Hour, "2h ago"},
+		{47 * time.Hour, "47h ago"},
+		{72 * time.Hour, "3d ago"},
+	}
+	for _, tt := range tests {
+		if got := FormatAge(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("FormatAge(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestCacheManager_RepoSyncRoundTrip(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		ConfigDir: tmp,
+		SkillsDir: filepath.Join(tmp, "skills"),
+		ReposDir:  filepath.Join(tmp, "repos"),
+		CachePath: filepath.Join(tmp, "cache.yaml"),
+		Repos:     []config.Repo{{Name: "official", URL: "https://example.com/skills"}},
+	}
+
+	fetched := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	c := NewCacheManager(cfg)
+	c.SetRepo("https://example.com/skills", RepoCache{FetchedAt: fetched, Commit: "abc1234def", Skills: []SkillEntry{{Name: "pdf"}}})
+	c.SetRepo("https://example.com/removed", RepoCache{FetchedAt: fetched})
+	c.Prune()
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewCacheManager(cfg)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	sync, ok := loaded.Repos()["official"]
+	if !ok {
+		t.Fatal("expected sync record for official")
+	}
+	if !sync.FetchedAt.Equal(fetched) || sync.Commit != "abc1234def" || sync.URL != "https://example.com/skills" {
+		t.Errorf("unexpected sync record: %+v", sync)
+	}
+	if _, ok := loaded.Repo("https://example.com/removed"); ok {
+		t.Error("unconfigured repo was not pruned")
+	}
+	if skills := loaded.Get().Skills; len(skills) != 1 || skills[0].Source.RepoName != "official" {
+		t.Errorf("merged skills = %+v", skills)
+	}
+	if NewCacheManager(cfg).Repos() != nil {
+		t.Error("expected no sync records before Load")
+	}
+}
+
+func TestCacheManager_DropsMonolithicCache(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{CachePath: filepath.Join(tmp, "cache.yaml")}
+	old := "index:\n  skills:\n    - name: pdf\nfetched_at: 2026-01-10T12:00:00Z\nrepos:\n  official:\n    url: https://example.com/skills\n"
+	if err := os.WriteFile(cfg.CachePath, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCacheManager(cfg)
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if c.Get() != nil {
+		t.Error("a cache of the old layout should be dropped")
+	}
+}
+
+func TestCacheManager_IsFreshPerRepoTTL(t *testing.T) {
+	cfg := &config.Config{
+		CacheTTL: 24,
+		Repos: []config.Repo{
+			{Name: "slow", URL: "https://example.com/slow"},
+			{Name: "fast", URL: "https://example.com/fast", CacheTTL: 1},
+		},
+	}
+	c := NewCacheManager(cfg)
+	twoHours := time.Now().Add(-2 * time.Hour)
+	c.SetRepo("https://example.com/slow", RepoCache{FetchedAt: twoHours})
+	c.SetRepo("https://example.com/fast", RepoCache{FetchedAt: twoHours})
+
+	if !c.IsFresh(cfg.Repos[0]) {
+		t.Error("slow repo should be fresh within the global TTL")
+	}
+	if c.IsFresh(cfg.Repos[1]) {
+		t.Error("fast repo should have expired after its own TTL")
+	}
+	if c.IsValid() {
+		t.Error("cache with an expired repo should not be valid")
+	}
+}
+
+func TestCacheManager_RemapRepo(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		ConfigDir: tmp,
+		SkillsDir: filepath.Join(tmp, "skills"),
+		ReposDir:  filepath.Join(tmp, "repos"),
+		CachePath: filepath.Join(tmp, "cache.yaml"),
+		Repos:     []config.Repo{{Name: "official", URL: "https://example.com/new"}},
+	}
+
+	c := NewCacheManager(cfg)
+	c.SetRepo("https://example.com/old", RepoCache{Commit: "abc1234", Skills: []SkillEntry{
+		{Name: "pdf", Source: SkillSource{Repo: "https://example.com/old"}},
+		{Name: "docx", Source: SkillSource{Repo: "https://example.com/other"}},
+	}})
+	if err := c.RemapRepo("https://example.com/old", "https://example.com/new"); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewCacheManager(cfg)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.Repo("https://example.com/old"); ok {
+		t.Error("old skill list kept")
+	}
+	if sync := loaded.Repos()["official"]; sync.URL != "https://example.com/new" || sync.Commit != "abc1234" {
+		t.Errorf("unexpected sync record: %+v", sync)
+	}
+	skills := loaded.Get().Skills
+	if skills[0].Source.Repo != "https://example.com/new" || skills[1].Source.Repo != "https://example.com/other" {
+		t.Errorf("unexpected skills: %+v", skills)
+	}
+}
+
+func TestIndexSource(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		ConfigDir: tmp,
+		SkillsDir: filepath.Join(tmp, "skills"),
+		ReposDir:  filepath.Join(tmp, "repos"),
+		CachePath: filepath.Join(tmp, "cache.yaml"),
+	}
+	r := NewRegistry(cfg)
+
+	indexed := filepath.Join(tmp, "indexed")
+	createSkill(t, indexed, "skills", "pdf")
+	if err := os.WriteFile(filepath.Join(indexed, "index.yaml"), []byte("skills:\n  - name: pdf\n    source:\n      repo: https://example.com/pdf\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	skills, err := r.readRepo(indexed, "https://example.com/index")
+	if err != nil || len(skills) != 1 || skills[0].Source.Listed != "index.yaml" {
+		t.Fatalf("readRepo(index) = %+v, %v", skills, err)
+	}
+	scanned := filepath.Join(tmp, "scanned")
+	createSkill(t, scanned, "docx")
+	skills, err = r.readRepo(scanned, "https://example.com/skills")
+	if err != nil || len(skills) != 1 || skills[0].Source.Listed != "scan" {
+		t.Fatalf("readRepo(scan) = %+v, %v", skills, err)
+	}
+
+	cfg.Repos = []config.Repo{{Name: "official", URL: "https://example.com/skills"}}
+	r.cache.SetRepo("https://example.com/skills", RepoCache{FetchedAt: time.Now(), Commit: "abc1234"})
+	skills[0].Source.RepoName = "official"
+	if got, want := r.IndexSource(&skills[0]), "official https://example.com/skills@abc1234 (scan)"; got != want {
+		t.Errorf("IndexSource = %q, want %q", got, want)
+	}
+	if got := r.IndexSource(&SkillEntry{Name: "local"}); got != "" {
+		t.Errorf("expected no index source without a config repo, got %q", got)
+	}
+}
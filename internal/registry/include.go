@@ -0,0 +1,128 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxIncludeDepth bounds how many levels of index.yaml includes are
+// followed, so a long chain of registries cannot stall a sync
+const maxIncludeDepth = 3
+
+// maxIncludeSize caps an index.yaml downloaded over HTTP
+const maxIncludeSize = 8 << 20
+
+var includeHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// includeKey normalizes an include URL for loop detection
+func includeKey(url string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(url), "/"), ".git")
+}
+
+// isIndexFileURL reports whether an include names an index.yaml served
+// over HTTP rather than a git repository
+func isIndexFileURL(url string) bool {
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return false
+	}
+	switch strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0])) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// indexSkills returns the valid skills of a parsed index, described by
+// from in warnings, followed by those of the indexes it includes. seen
+// holds the indexes already on the path, so an include loop is cut where
+// it closes. Includes that cannot be fetched are skipped, like
+// repositories that fail during a sync.
+func (r *Registry) indexSkills(index Index, from string, seen map[string]bool, depth int) []SkillEntry {
+	skills := r.validEntries(index.Skills, from)
+	if depth >= maxIncludeDepth {
+		return skills
+	}
+
+	for _, url := range index.Include {
+		key := includeKey(url)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		included, err := r.fetchInclude(url, seen, depth+1)
+		if err != nil {
+			continue
+		}
+		for i := range included {
+			if !strings.HasPrefix(included[i].Source.Listed, "include ") {
+				included[i].Source.Listed = "include " + url
+			}
+		}
+		skills = append(skills, included...)
+	}
+	return dedupeSkills(skills)
+}
+
+// fetchInclude lists the skills of an included index: an index.yaml URL
+// or a git repository, which may itself be scanned for SKILL.md files
+func (r *Registry) fetchInclude(url string, seen map[string]bool, depth int) ([]SkillEntry, error) {
+	if !isIndexFileURL(url) {
+		skills, _, err := r.listRepo(url, seen, depth)
+		return skills, err
+	}
+
+	data, err := getIndexFile(url)
+	if err != nil {
+		return nil, err
+	}
+	var index Index
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", url, err)
+	}
+	for i := range index.Skills {
+		index.Skills[i].Source.Listed = "index.yaml"
+	}
+	return r.indexSkills(index, url, seen, depth), nil
+}
+
+// getIndexFile downloads an index.yaml over HTTP
+func getIndexFile(url string) ([]byte, error) {
+	resp, err := includeHTTPClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIncludeSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(data) > maxIncludeSize {
+		return nil, fmt.Errorf("index exceeds %d bytes", maxIncludeSize)
+	}
+	return data, nil
+}
+
+// dedupeSkills drops repeated entries for the same skill source, keeping
+// the first: an index's own entries win over included ones
+func dedupeSkills(skills []SkillEntry) []SkillEntry {
+	seen := make(map[string]bool, len(skills))
+	out := skills[:0]
+	for _, s := range skills {
+		key := s.Name + "\x00" + includeKey(s.Source.Repo) + "\x00" + strings.Trim(s.Source.Path, "/")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, s)
+	}
+	return out
+}
@@ -2,22 +2,32 @@ package registry
 
 import (
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
-	"gopkg.in/yaml.v3"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/integrity"
+	"lazyas/internal/progress"
+	"lazyas/internal/remote"
 	"lazyas/internal/skillmd"
 )
 
 // Registry handles index operations
 type Registry struct {
-	cfg   *config.Config
-	cache *CacheManager
-	index *Index
+	cfg      *config.Config
+	cache    *CacheManager
+	index    *Index
+	progress *progress.Tracker
+	warnings []string // from the last fetch, e.g. skipped index entries
 }
 
 // NewRegistry creates a new registry
@@ -28,14 +38,32 @@ func NewRegistry(cfg *config.Config) *Registry {
 	}
 }
 
-// Fetch retrieves skills from all configured repositories
+// SetProgress makes Fetch report each repository it fetches to t. A
+// cancelled tracker stops the fetch before the next repository.
+func (r *Registry) SetProgress(t *progress.Tracker) {
+	r.progress = t
+}
+
+// Fetch retrieves skills from all configured repositories. Each repo's
+// cached skill list is reused until its TTL runs out; forceRefresh
+// fetches every repo anyway.
 func (r *Registry) Fetch(forceRefresh bool) error {
-	// Try cache first unless forced refresh
-	if !forceRefresh {
-		if err := r.cache.Load(); err == nil && r.cache.IsValid() {
-			r.index = r.cache.Get()
-			return nil
-		}
+	return r.fetch(func(config.Repo) bool { return forceRefresh })
+}
+
+// FetchRepos is Fetch forcing a refresh of the named repositories only;
+// the others are fetched when their cached skill list expired
+func (r *Registry) FetchRepos(names []string) error {
+	return r.fetch(func(repo config.Repo) bool { return slices.Contains(names, repo.Name) })
+}
+
+// fetch fetches the repos that force picks or whose cached skill list
+// expired and merges them with the cached lists of the others. A repo
+// that fails keeps its cached list, however old: it beats none.
+func (r *Registry) fetch(force func(config.Repo) bool) error {
+	if err := r.cache.Load(); err != nil {
+		// A damaged cache is refetched and overwritten
+		r.cache = NewCacheManager(r.cfg)
 	}
 
 	// No repos configured
@@ -44,67 +72,290 @@ func (r *Registry) Fetch(forceRefresh bool) error {
 		return fmt.Errorf("no repositories configured - add repos to %s", r.cfg.ConfigPath)
 	}
 
-	// Fetch from all configured repos
-	var allSkills []SkillEntry
-	var errors []string
-
+	var stale []config.Repo
 	for _, repo := range r.cfg.Repos {
-		skills, err := r.fetchRepo(repo.URL)
+		if force(repo) || !r.cache.IsFresh(repo) {
+			stale = append(stale, repo)
+		}
+	}
+	if len(stale) == 0 {
+		r.index = r.cache.Get()
+		return nil
+	}
+
+	r.warnings = nil
+	var failures []string
+	r.progress.SetTotal(len(stale))
+	for i, repo := range stale {
+		if err := r.progress.Err(); err != nil {
+			return err
+		}
+		r.progress.Set(i+1, repo.Name)
+		skills, commit, err := r.fetchRepo(repo.URL)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", repo.Name, err))
+			failures = append(failures, fmt.Sprintf("%s: %v", repo.Name, err))
+			r.warnings = append(r.warnings, fmt.Sprintf("%s: not refreshed: %v", repo.Name, err))
 			continue
 		}
-		// Tag skills with their source repo name
-		for i := range skills {
-			if skills[i].Source.RepoName == "" {
-				skills[i].Source.RepoName = repo.Name
-			}
-		}
-		allSkills = append(allSkills, skills...)
+		r.cache.SetRepo(repo.URL, RepoCache{FetchedAt: time.Now(), Commit: commit, Skills: skills})
 	}
 
-	r.index = &Index{Skills: allSkills}
+	r.cache.Prune()
+	r.index = r.cache.Get()
+	if r.index == nil {
+		r.index = &Index{}
+	}
 
 	// Update cache
-	if err := r.cache.Set(r.index); err != nil {
+	if err := r.cache.Save(); err != nil {
 		// Non-fatal
 		fmt.Fprintf(os.Stderr, "warning: failed to cache index: %v\n", err)
 	}
 
-	if len(errors) > 0 && len(allSkills) == 0 {
-		return fmt.Errorf("failed to fetch from any repository:\n  %s", joinErrors(errors))
+	// Cached lists of the failed repos are still listed, but nothing was
+	// refreshed
+	if len(failures) == len(stale) {
+		return fmt.Errorf("failed to fetch from any repository:\n  %s", joinErrors(failures))
 	}
 
 	return nil
 }
 
-func (r *Registry) fetchRepo(repoURL string) ([]SkillEntry, error) {
-	// Clone repo to temp dir
-	tempDir, err := os.MkdirTemp("", "lazyas-index-*")
+// LoadCache reads the cached index metadata without fetching
+func (r *Registry) LoadCache() error {
+	return r.cache.Load()
+}
+
+// RepoSync returns when the named repository was last fetched successfully
+func (r *Registry) RepoSync(name string) (RepoSync, bool) {
+	sync, ok := r.cache.Repos()[name]
+	return sync, ok
+}
+
+// IndexSource describes where skill was listed, for provenance records:
+// the config repo, its URL and commit when fetched, and whether the skill
+// came from its index.yaml or a scan for SKILL.md files
+func (r *Registry) IndexSource(skill *SkillEntry) string {
+	name := skill.Source.RepoName
+	if name == "" {
+		return ""
+	}
+	source := name
+	if sync, ok := r.RepoSync(name); ok {
+		source += " " + sync.URL
+		if sync.Commit != "" {
+			source += "@" + sync.Commit
+		}
+	}
+	if skill.Source.Listed != "" {
+		source += " (" + skill.Source.Listed + ")"
+	}
+	return source
+}
+
+// fetchRepo lists the skills of a repository along with its head commit
+func (r *Registry) fetchRepo(repoURL string) ([]SkillEntry, string, error) {
+	return r.listRepo(repoURL, map[string]bool{includeKey(repoURL): true}, 0)
+}
+
+// listRepo lists the skills of a repository reached through depth
+// includes. The cheapest source wins: index.yaml downloaded from a GitHub
+// or GitLab host, then a partial clone holding only index.yaml and the
+// SKILL.md files, then a full shallow clone. Without git, GitHub and
+// GitLab repositories without index.yaml have their SKILL.md files
+// downloaded through the host's API instead of cloned.
+func (r *Registry) listRepo(repoURL string, seen map[string]bool, depth int) ([]SkillEntry, string, error) {
+	if data, sig, commit, ok := fetchIndexFile(repoURL); ok {
+		index, err := r.parseIndex(repoURL, data, sig)
+		if err != nil {
+			return nil, "", err
+		}
+		return r.indexSkills(*index, "index.yaml of "+repoURL, seen, depth), commit, nil
+	}
+
+	var tempDir, commit string
+	var err error
+	partial := true
+	if gitErr := git.Available(); gitErr != nil {
+		if _, hosted := remote.ParseRepo(repoURL); !hosted {
+			return nil, "", fmt.Errorf("%w: only GitHub and GitLab repositories can be fetched without it", gitErr)
+		}
+		tempDir, commit, err = downloadSkillFiles(repoURL)
+	} else {
+		tempDir, partial, err = partialClone(repoURL)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+		return nil, "", err
 	}
 	defer os.RemoveAll(tempDir)
 
-	// Shallow clone
+	skills, err := r.readRepoAt(tempDir, repoURL, seen, depth)
+	if err != nil {
+		return nil, "", err
+	}
+	if partial {
+		// Only SKILL.md was checked out, so scanned sizes would be wrong;
+		// zero means unknown
+		for i := range skills {
+			if skills[i].Source.Listed == "scan" {
+				skills[i].Size = 0
+			}
+		}
+	}
+
+	if commit == "" {
+		if out, err := exec.Command("git", "-C", tempDir, "rev-parse", "HEAD").Output(); err == nil {
+			commit = strings.TrimSpace(string(out))
+		}
+	}
+	return skills, commit, nil
+}
+
+// downloadSkillFiles fetches the SKILL.md files of a GitHub or GitLab
+// repository at its head commit into a new temp dir the caller must
+// remove, laid out like a partial clone, without git
+func downloadSkillFiles(repoURL string) (dir, commit string, err error) {
+	repo, _ := remote.ParseRepo(repoURL)
+	if commit = remoteHEAD(repoURL); commit == "" {
+		return "", "", fmt.Errorf("failed to resolve the head commit of %s", repoURL)
+	}
+	files, err := remote.TreeFiles(repo, commit)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list the files of %s: %w", repoURL, err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "lazyas-index-*")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	for _, file := range files {
+		if path.Base(file) != "SKILL.md" || git.ValidateRelPath(path.Dir(file)) != nil {
+			continue
+		}
+		data, err := remote.FetchFile(repo.RawFileURL(commit, file))
+		if err == nil {
+			dest := filepath.Join(tempDir, filepath.FromSlash(file))
+			if err = os.MkdirAll(filepath.Dir(dest), 0755); err == nil {
+				err = os.WriteFile(dest, data, 0644)
+			}
+		}
+		if err != nil {
+			os.RemoveAll(tempDir)
+			return "", "", fmt.Errorf("failed to download %s: %w", file, err)
+		}
+	}
+	return tempDir, commit, nil
+}
+
+// remoteHEAD resolves the default branch of repoURL to a commit, or "".
+// Without git the smart HTTP ref advertisement is read directly.
+func remoteHEAD(repoURL string) string {
+	if git.Available() != nil {
+		commit, _ := remote.HeadCommit(repoURL)
+		return commit
+	}
+	out, err := exec.Command("git", "ls-remote", repoURL, "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	if fields := strings.Fields(string(out)); len(fields) >= 2 {
+		return fields[0]
+	}
+	return ""
+}
+
+// fetchIndexFile downloads index.yaml of a GitHub or GitLab repository
+// at its head commit without cloning, with its signature when there is
+// one. ok is false when the host is not supported or the file cannot be
+// downloaded (a scanned or private repository), so the caller clones
+// instead.
+func fetchIndexFile(repoURL string) (data, sig []byte, commit string, ok bool) {
+	repo, known := remote.ParseRepo(repoURL)
+	if !known {
+		return nil, nil, "", false
+	}
+	if commit = remoteHEAD(repoURL); commit == "" {
+		return nil, nil, "", false
+	}
+	data, err := getIndexFile(repo.RawFileURL(commit, "index.yaml"))
+	if err != nil {
+		return nil, nil, "", false
+	}
+	sig, _ = getIndexFile(repo.RawFileURL(commit, SignatureFile))
+	return data, sig, commit, true
+}
+
+// partialClone clones the tip of repoURL into a new temp dir the caller
+// must remove, fetching only the blobs of index.yaml and SKILL.md files.
+// Servers or git versions without partial clone support get a full
+// shallow clone instead; partial reports which one happened.
+func partialClone(repoURL string) (dir string, partial bool, err error) {
+	tempDir, err := os.MkdirTemp("", "lazyas-index-*")
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
+	steps := [][]string{
+		{"clone", "--depth", "1", "--filter=blob:none", "--no-checkout", repoURL, tempDir},
+		{"-C", tempDir, "sparse-checkout", "set", "--no-cone", "/index.yaml", "/" + SignatureFile, "SKILL.md"},
+		{"-C", tempDir, "checkout"},
+	}
+	for _, args := range steps {
+		if err := exec.Command("git", args...).Run(); err != nil {
+			os.RemoveAll(tempDir)
+			dir, err := shallowClone(repoURL)
+			return dir, false, err
+		}
+	}
+	return tempDir, true, nil
+}
+
+// shallowClone clones repoURL into a new temp dir the caller must remove
+func shallowClone(repoURL string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "lazyas-index-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
 	cmd := exec.Command("git", "clone", "--depth", "1", repoURL, tempDir)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("git clone failed: %s", string(output))
+		os.RemoveAll(tempDir)
+		return "", fmt.Errorf("git clone failed: %s", string(output))
 	}
+	return tempDir, nil
+}
+
+// readRepo lists the skills of a cloned index or skills repository,
+// including those of the indexes its index.yaml includes
+func (r *Registry) readRepo(tempDir, repoURL string) ([]SkillEntry, error) {
+	return r.readRepoAt(tempDir, repoURL, map[string]bool{includeKey(repoURL): true}, 0)
+}
 
+// readRepoAt is readRepo for an index reached through depth includes
+func (r *Registry) readRepoAt(tempDir, repoURL string, seen map[string]bool, depth int) ([]SkillEntry, error) {
 	// Try index.yaml first (index repo)
 	indexPath := filepath.Join(tempDir, "index.yaml")
 	if data, err := os.ReadFile(indexPath); err == nil {
-		var index Index
-		if err := yaml.Unmarshal(data, &index); err != nil {
-			return nil, fmt.Errorf("failed to parse index.yaml: %w", err)
+		sig, _ := os.ReadFile(filepath.Join(tempDir, SignatureFile))
+		index, err := r.parseIndex(repoURL, data, sig)
+		if err != nil {
+			return nil, err
 		}
-		return index.Skills, nil
+		return r.indexSkills(*index, "index.yaml of "+repoURL, seen, depth), nil
+	}
+	if key, err := r.repoKey(repoURL); err != nil {
+		return nil, err
+	} else if key != nil {
+		return nil, fmt.Errorf("a public_key is configured but the repository has no index.yaml to verify")
 	}
 
 	// No index.yaml - scan for skills (skills repo)
-	return r.scanForSkills(tempDir, repoURL)
+	skills, err := r.scanForSkills(tempDir, repoURL)
+	for i := range skills {
+		skills[i].Source.Listed = "scan"
+	}
+	return skills, err
 }
 
 // scanForSkills discovers skills by finding SKILL.md files
@@ -198,10 +449,36 @@ func makeSkillEntry(name, skillDir, repoDir, repoURL string) SkillEntry {
 	skill.Source.Path = relPath
 	if content, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md")); err == nil {
 		skill.Description = skillmd.ExtractDescription(string(content))
+		// Tags and author make scanned repos as searchable as indexed ones
+		if fm, err := skillmd.ParseFrontmatter(string(content)); err == nil {
+			skill.Author = fm.SkillAuthor()
+			skill.Tags = fm.SkillTags()
+		}
 	}
+	skill.Size = dirSize(skillDir)
 	return skill
 }
 
+// dirSize sums regular file sizes under dir, skipping .git
+func dirSize(dir string) int64 {
+	var total int64
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
+
 func inferRootSkillName(repoURL, repoDir string) string {
 	if parsed, err := url.Parse(repoURL); err == nil && parsed.Host != "" {
 		p := strings.Trim(strings.TrimSuffix(parsed.Path, ".git"), "/")
@@ -242,20 +519,151 @@ func (r *Registry) GetIndex() *Index {
 	return r.index
 }
 
-// GetSkill finds a skill by name
+// GetSkill finds a skill by name. "repo/name" picks the skill from the
+// config repo with that name.
 func (r *Registry) GetSkill(name string) *SkillEntry {
 	if r.index == nil {
 		return nil
 	}
 
+	repo, skillName, qualified := strings.Cut(name, "/")
+	if !qualified {
+		if providers := r.Providers(name); len(providers) > 0 {
+			return providers[0]
+		}
+		return nil
+	}
+	for i := range r.index.Skills {
+		s := &r.index.Skills[i]
+		if s.Name == skillName && s.Source.RepoName == repo {
+			return s
+		}
+	}
+	return nil
+}
+
+// Providers returns every entry named name, the active one first. A repo
+// pinned for the skill in skill_repos wins, then the highest repo
+// priority, then the repo listed first in the config. The others are
+// shadowed and only reachable as "repo/name".
+func (r *Registry) Providers(name string) []*SkillEntry {
+	if r.index == nil {
+		return nil
+	}
+
+	var providers []*SkillEntry
 	for i := range r.index.Skills {
 		if r.index.Skills[i].Name == name {
-			return &r.index.Skills[i]
+			providers = append(providers, &r.index.Skills[i])
+		}
+	}
+	if len(providers) < 2 || r.cfg == nil {
+		return providers
+	}
+
+	pinned := r.cfg.SkillRepos[name]
+	rank := func(s *SkillEntry) (bool, int, int) {
+		for i, repo := range r.cfg.Repos {
+			if repo.Name == s.Source.RepoName {
+				return repo.Name == pinned, repo.Priority, i
+			}
+		}
+		return false, 0, len(r.cfg.Repos)
+	}
+	sort.SliceStable(providers, func(i, j int) bool {
+		pi, prioI, orderI := rank(providers[i])
+		pj, prioJ, orderJ := rank(providers[j])
+		if pi != pj {
+			return pi
+		}
+		if prioI != prioJ {
+			return prioI > prioJ
+		}
+		return orderI < orderJ
+	})
+	return providers
+}
+
+// CaseClashes returns groups of skill names that differ only by case, such
+// as "My-Skill" and "my-skill". Their directories collide on
+// case-insensitive filesystems, so installing both needs a rename.
+func (r *Registry) CaseClashes() [][]string {
+	if r.index == nil {
+		return nil
+	}
+	spellings := make(map[string][]string)
+	for _, s := range r.index.Skills {
+		key := strings.ToLower(s.Name)
+		if !slices.Contains(spellings[key], s.Name) {
+			spellings[key] = append(spellings[key], s.Name)
+		}
+	}
+	var clashes [][]string
+	for _, names := range spellings {
+		if len(names) > 1 {
+			sort.Strings(names)
+			clashes = append(clashes, names)
+		}
+	}
+	sort.Slice(clashes, func(i, j int) bool { return clashes[i][0] < clashes[j][0] })
+	return clashes
+}
+
+// FindDuplicate returns the registry entry a local-only skill is a copy
+// of: the skill its SKILL.md declares itself as, one with the same
+// description, or one whose files in a repo clone hash to hash (see
+// integrity.HashDir; "" skips the comparison). It returns nil when nothing
+// matches.
+func (r *Registry) FindDuplicate(name, declaredName, description, hash string) *SkillEntry {
+	if r.index == nil {
+		return nil
+	}
+	if declaredName != "" && declaredName != name {
+		if s := r.GetSkill(declaredName); s != nil {
+			return s
+		}
+	}
+	if description != "" {
+		for i := range r.index.Skills {
+			s := &r.index.Skills[i]
+			if s.Name != name && s.Description == description {
+				return s
+			}
+		}
+	}
+	if hash == "" || r.cfg == nil {
+		return nil
+	}
+	for i := range r.index.Skills {
+		s := &r.index.Skills[i]
+		if s.Name == name || s.Source.Repo == "" {
+			continue
+		}
+		// Only copies already checked out are compared; nothing is fetched
+		dir := filepath.Join(r.cfg.ReposDir, git.RepoDirName(s.Source.Repo), filepath.FromSlash(s.Source.Path))
+		if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err != nil {
+			continue
+		}
+		if h, err := integrity.HashDir(dir); err == nil && h == hash {
+			return s
 		}
 	}
 	return nil
 }
 
+// InstallRef returns the argument that reinstalls exactly this skill:
+// "repo/name@tag", without the parts that are unknown
+func (s *SkillEntry) InstallRef() string {
+	ref := s.Name
+	if s.Source.RepoName != "" {
+		ref = s.Source.RepoName + "/" + ref
+	}
+	if s.Source.Tag != "" {
+		ref += "@" + s.Source.Tag
+	}
+	return ref
+}
+
 // SearchSkills searches for skills matching a query
 func (r *Registry) SearchSkills(query string) []SkillEntry {
 	if r.index == nil {
@@ -1,10 +1,20 @@
 package registry
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
+	"time"
+
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/integrity"
 )
 
 // helper to create a directory with a SKILL.md file
@@ -170,6 +180,111 @@ func TestScanForSkills_RootSkillLayout(t *testing.T) {
 	}
 }
 
+func TestGetSkill_RepoQualified(t *testing.T) {
+	r := &Registry{index: &Index{Skills: []SkillEntry{
+		{Name: "pdf", Source: SkillSource{RepoName: "anthropic", Tag: "v1.0.0"}},
+		{Name: "pdf", Source: SkillSource{RepoName: "team"}},
+	}}}
+
+	if s := r.GetSkill("pdf"); s == nil || s.Source.RepoName != "anthropic" {
+		t.Errorf("GetSkill(pdf) = %+v", s)
+	}
+	s := r.GetSkill("team/pdf")
+	if s == nil || s.Source.RepoName != "team" {
+		t.Fatalf("GetSkill(team/pdf) = %+v", s)
+	}
+	if r.GetSkill("other/pdf") != nil {
+		t.Error("expected no skill for an unknown repo")
+	}
+
+	if ref := r.GetSkill("pdf").InstallRef(); ref != "anthropic/pdf@v1.0.0" {
+		t.Errorf("InstallRef() = %q", ref)
+	}
+	if ref := s.InstallRef(); ref != "team/pdf" {
+		t.Errorf("InstallRef() = %q", ref)
+	}
+}
+
+func TestProviders_Resolution(t *testing.T) {
+	cfg := &config.Config{Repos: []config.Repo{
+		{Name: "community"},
+		{Name: "official", Priority: 10},
+		{Name: "team"},
+	}}
+	r := &Registry{cfg: cfg, index: &Index{Skills: []SkillEntry{
+		{Name: "pdf", Source: SkillSource{RepoName: "community"}},
+		{Name: "pdf", Source: SkillSource{RepoName: "team"}},
+		{Name: "pdf", Source: SkillSource{RepoName: "official"}},
+		{Name: "docx", Source: SkillSource{RepoName: "team"}},
+		{Name: "docx", Source: SkillSource{RepoName: "community"}},
+	}}}
+
+	repos := func(name string) []string {
+		var out []string
+		for _, s := range r.Providers(name) {
+			out = append(out, s.Source.RepoName)
+		}
+		return out
+	}
+	if got := repos("pdf"); len(got) != 3 || got[0] != "official" || got[1] != "community" || got[2] != "team" {
+		t.Errorf("by priority: %v", got)
+	}
+	if got := repos("docx"); len(got) != 2 || got[0] != "community" {
+		t.Errorf("by config order: %v", got)
+	}
+
+	cfg.SkillRepos = map[string]string{"pdf": "team"}
+	if s := r.GetSkill("pdf"); s == nil || s.Source.RepoName != "team" {
+		t.Errorf("pinned GetSkill(pdf) = %+v", s)
+	}
+	if s := r.GetSkill("official/pdf"); s == nil || s.Source.RepoName != "official" {
+		t.Errorf("qualified lookup ignores the pin: %+v", s)
+	}
+}
+
+func TestFindDuplicate(t *testing.T) {
+	r := &Registry{index: &Index{Skills: []SkillEntry{
+		{Name: "pdf", Description: "Work with PDF files"},
+		{Name: "docx", Description: "Work with Word files"},
+	}}}
+
+	if s := r.FindDuplicate("my-pdf", "pdf", "", ""); s == nil || s.Name != "pdf" {
+		t.Errorf("by declared name: %+v", s)
+	}
+	if s := r.FindDuplicate("word", "", "Work with Word files", ""); s == nil || s.Name != "docx" {
+		t.Errorf("by description: %+v", s)
+	}
+	if s := r.FindDuplicate("pdf", "pdf", "Work with PDF files", ""); s != nil {
+		t.Errorf("a skill is not a duplicate of itself: %+v", s)
+	}
+	if s := r.FindDuplicate("notes", "", "Take notes", ""); s != nil {
+		t.Errorf("unexpected match: %+v", s)
+	}
+}
+
+func TestFindDuplicate_ByContent(t *testing.T) {
+	reposDir := t.TempDir()
+	repo := "https://github.com/acme/skills"
+	createSkill(t, filepath.Join(reposDir, git.RepoDirName(repo)), "skills", "pdf")
+	local := filepath.Join(t.TempDir(), "renamed")
+	createSkill(t, filepath.Dir(local), "renamed")
+
+	r := &Registry{cfg: &config.Config{ReposDir: reposDir}, index: &Index{Skills: []SkillEntry{
+		{Name: "pdf", Source: SkillSource{Repo: repo, Path: "skills/pdf"}},
+		{Name: "docx", Source: SkillSource{Repo: repo, Path: "skills/docx"}},
+	}}}
+	hash, err := integrity.HashDir(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := r.FindDuplicate("renamed", "", "", hash); s == nil || s.Name != "pdf" {
+		t.Errorf("by content: %+v", s)
+	}
+	if s := r.FindDuplicate("renamed", "", "", ""); s != nil {
+		t.Errorf("unexpected match without a hash: %+v", s)
+	}
+}
+
 func skillNames(skills []SkillEntry) []string {
 	names := make([]string, len(skills))
 	for i, s := range skills {
@@ -177,3 +292,217 @@ func skillNames(skills []SkillEntry) []string {
 	}
 	return names
 }
+
+func TestScanForSkills_FrontmatterTagsAndAuthor(t *testing.T) {
+	tmp := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		dir := filepath.Join(tmp, "skills", name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("pdf", "---\nname: pdf\ndescription: PDF tools\nauthor: Ada\ntags: [pdf, forms]\n---\n")
+	write("docx", "---\nname: docx\ndescription: Word files\nmetadata:\n  author: Grace\n  tags: office, word\n---\n")
+	write("plain", "# Plain\n\nNo frontmatter.\n")
+
+	r := &Registry{}
+	skills, err := r.scanForSkills(tmp, "https://example.com/repo.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]SkillEntry{}
+	for _, s := range skills {
+		byName[s.Name] = s
+	}
+
+	if s := byName["pdf"]; s.Author != "Ada" || len(s.Tags) != 2 || s.Tags[1] != "forms" {
+		t.Errorf("pdf: author %q tags %v", s.Author, s.Tags)
+	}
+	if s := byName["docx"]; s.Author != "Grace" || len(s.Tags) != 2 || s.Tags[0] != "office" {
+		t.Errorf("docx: author %q tags %v", s.Author, s.Tags)
+	}
+	if s := byName["plain"]; s.Author != "" || len(s.Tags) != 0 {
+		t.Errorf("plain: author %q tags %v", s.Author, s.Tags)
+	}
+	if docx := byName["docx"]; !docx.MatchesQuery("word") {
+		t.Error("expected search to match a frontmatter tag")
+	}
+}
+
+func TestCaseClashes(t *testing.T) {
+	r := &Registry{index: &Index{Skills: []SkillEntry{
+		{Name: "my-skill", Source: SkillSource{RepoName: "a"}},
+		{Name: "My-Skill", Source: SkillSource{RepoName: "b"}},
+		{Name: "my-skill", Source: SkillSource{RepoName: "c"}},
+		{Name: "pdf"},
+	}}}
+
+	clashes := r.CaseClashes()
+	if len(clashes) != 1 || len(clashes[0]) != 2 || clashes[0][0] != "My-Skill" || clashes[0][1] != "my-skill" {
+		t.Errorf("CaseClashes() = %v", clashes)
+	}
+}
+
+func TestReadRepo_FollowsIncludes(t *testing.T) {
+	indexes := map[string]string{
+		"/a.yaml": "skills:\n  - {name: a, source: {repo: https://example.com/a}}\n  - {name: top, source: {repo: https://example.com/top}}\ninclude: [%[1]s/b.yaml, %[1]s/a.yaml]\n",
+		"/b.yaml": "skills:\n  - {name: b, source: {repo: https://example.com/b}}\ninclude: [%[1]s/c.yaml, %[1]s/a.yaml]\n",
+		"/c.yaml": "skills:\n  - {name: c, source: {repo: https://example.com/c}}\ninclude: [%[1]s/d.yaml]\n",
+		"/d.yaml": "skills:\n  - {name: d, source: {repo: https://example.com/d}}\n",
+	}
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		index, ok := indexes[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		fmt.Fprintf(w, index, srv.URL)
+	}))
+	defer srv.Close()
+
+	tmp := t.TempDir()
+	index := fmt.Sprintf("skills:\n  - {name: top, source: {repo: https://example.com/top}}\ninclude: [%s/a.yaml, %s/missing.yaml]\n", srv.URL, srv.URL)
+	if err := os.WriteFile(filepath.Join(tmp, "index.yaml"), []byte(index), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Registry{}
+	skills, err := r.readRepo(tmp, "https://example.com/registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed := make(map[string]string)
+	var names []string
+	for _, s := range skills {
+		names = append(names, s.Name)
+		listed[s.Name] = s.Source.Listed
+	}
+	// d is four includes deep, past the depth limit; the loops back to a
+	// and the duplicate top entry are dropped
+	if want := []string{"top", "a", "b", "c"}; fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("skills = %v, want %v", names, want)
+	}
+	if listed["top"] != "index.yaml" || listed["b"] != "include "+srv.URL+"/b.yaml" {
+		t.Errorf("unexpected listing sources: %v", listed)
+	}
+}
+
+func TestFetchRepo_PartialCloneScansSkills(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	src := t.TempDir()
+	createSkill(t, src, "skills", "pdf")
+	createSkill(t, src, "skills", "office", "docx")
+	if err := os.WriteFile(filepath.Join(src, "skills", "pdf", "forms.md"), []byte("forms\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", src}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	r := &Registry{}
+	skills, commit, err := r.fetchRepo("file://" + src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := skillNames(skills)
+	sort.Strings(names)
+	if fmt.Sprint(names) != "[docx pdf]" {
+		t.Errorf("skills = %v, want [docx pdf]", names)
+	}
+	if len(commit) != 40 {
+		t.Errorf("commit = %q", commit)
+	}
+	for _, s := range skills {
+		// Only SKILL.md is checked out, so the size is left unknown
+		if s.Size != 0 {
+			t.Errorf("%s: size = %d, want 0", s.Name, s.Size)
+		}
+	}
+}
+
+func TestFetch_RefetchesOnlyExpiredRepos(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	src := t.TempDir()
+	createSkill(t, src, "skills", "pdf")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", src}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	tmp := t.TempDir()
+	cfg := &config.Config{
+		CacheTTL: 24,
+		Repos: []config.Repo{
+			{Name: "local", URL: "file://" + src},
+			{Name: "gone", URL: "file://" + filepath.Join(tmp, "missing")},
+		},
+	}
+	cfg.SetHome(filepath.Join(tmp, ".lazyas"))
+	// The unreachable repo is fresh in the cache, so it is not fetched
+	seed := NewCacheManager(cfg)
+	seed.SetRepo(cfg.Repos[1].URL, RepoCache{FetchedAt: time.Now(), Skills: []SkillEntry{{Name: "docx"}}})
+	if err := seed.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry(cfg)
+	if err := r.Fetch(false); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(skillNames(r.ListSkills())); got != "[pdf docx]" {
+		t.Fatalf("skills = %s, want [pdf docx]", got)
+	}
+	if r.GetSkill("docx").Source.RepoName != "gone" {
+		t.Errorf("cached skill not tagged with its repo: %+v", r.GetSkill("docx").Source)
+	}
+	local, _ := r.RepoSync("local")
+	gone, _ := r.RepoSync("gone")
+
+	// Forcing the unreachable repo fails and says so, but keeps its cached
+	// list; the local repo is still fresh and is not fetched again
+	r = NewRegistry(cfg)
+	if err := r.FetchRepos([]string{"gone"}); err == nil {
+		t.Fatal("a refresh where every repo failed should fail")
+	}
+	if got := fmt.Sprint(skillNames(r.ListSkills())); got != "[pdf docx]" {
+		t.Errorf("skills after failed refresh = %s", got)
+	}
+	if sync, _ := r.RepoSync("local"); !sync.FetchedAt.Equal(local.FetchedAt) {
+		t.Error("fresh repo was fetched again")
+	}
+	if sync, _ := r.RepoSync("gone"); !sync.FetchedAt.Equal(gone.FetchedAt) {
+		t.Error("failed fetch changed the sync time")
+	}
+
+	// When some repos refresh, the others are reported as warnings
+	r = NewRegistry(cfg)
+	if err := r.Fetch(true); err != nil {
+		t.Fatal(err)
+	}
+	if w := r.Warnings(); len(w) != 1 || !strings.HasPrefix(w[0], "gone: not refreshed") {
+		t.Errorf("warnings = %q", w)
+	}
+	if got := fmt.Sprint(skillNames(r.ListSkills())); got != "[pdf docx]" {
+		t.Errorf("skills after partial refresh = %s", got)
+	}
+}
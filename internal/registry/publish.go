@@ -0,0 +1,149 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// publishedEntry is the index.yaml form of a published skill, leaving out
+// empty fields
+type publishedEntry struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Source      struct {
+		Repo string `yaml:"repo"`
+		Path string `yaml:"path,omitempty"`
+	} `yaml:"source"`
+	Author string   `yaml:"author,omitempty"`
+	Tags   []string `yaml:"tags,omitempty,flow"`
+	Size   int64    `yaml:"size,omitempty"`
+}
+
+// PublishEntry describes the skill at skillDir, inside the clone of
+// repoURL at repoDir, as an index.yaml entry
+func PublishEntry(name, skillDir, repoDir, repoURL string) SkillEntry {
+	entry := makeSkillEntry(name, skillDir, repoDir, repoURL)
+	entry.Source.Path = filepath.ToSlash(entry.Source.Path)
+	return entry
+}
+
+// IndexEntryPath returns the source path index.yaml in repoDir lists for
+// a skill, or "" when the skill is not listed
+func IndexEntryPath(repoDir, name string) string {
+	data, err := os.ReadFile(filepath.Join(repoDir, "index.yaml"))
+	if err != nil {
+		return ""
+	}
+	var index Index
+	if yaml.Unmarshal(data, &index) != nil {
+		return ""
+	}
+	for _, s := range index.Skills {
+		if s.Name == name {
+			return s.Source.Path
+		}
+	}
+	return ""
+}
+
+// UpdateIndexFile lists entry in the index.yaml of repoDir, replacing the
+// entry of the same name, and stamps metadata.updated_at when present.
+// Comments and other entries are kept. Reports false when the repository
+// has no index.yaml, so its skills are found by scanning.
+func UpdateIndexFile(repoDir string, entry SkillEntry, now time.Time) (bool, error) {
+	path := filepath.Join(repoDir, "index.yaml")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	updated, err := updateIndex(data, entry, now)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, updated, 0644)
+}
+
+func updateIndex(data []byte, entry SkillEntry, now time.Time) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse index.yaml: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse index.yaml: not a mapping")
+	}
+	root := doc.Content[0]
+
+	published := publishedEntry{
+		Name:        entry.Name,
+		Description: entry.Description,
+		Author:      entry.Author,
+		Tags:        entry.Tags,
+		Size:        entry.Size,
+	}
+	published.Source.Repo = entry.Source.Repo
+	published.Source.Path = entry.Source.Path
+	var node yaml.Node
+	if err := node.Encode(published); err != nil {
+		return nil, err
+	}
+
+	skills := mappingValue(root, "skills")
+	if skills == nil {
+		skills = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "skills"}, skills)
+	} else if skills.Kind != yaml.SequenceNode {
+		// "skills:" without entries parses as null
+		*skills = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+
+	replaced := false
+	for i, s := range skills.Content {
+		if name := mappingValue(s, "name"); name != nil && name.Value == entry.Name {
+			node.HeadComment = s.HeadComment
+			skills.Content[i] = &node
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		skills.Content = append(skills.Content, &node)
+	}
+
+	if metadata := mappingValue(root, "metadata"); metadata != nil {
+		if stamp := mappingValue(metadata, "updated_at"); stamp != nil {
+			stamp.Value = now.UTC().Format(time.RFC3339)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
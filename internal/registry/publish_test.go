@@ -0,0 +1,76 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUpdateIndex_ReplacesAndAppends(t *testing.T) {
+	data := []byte(`version: 1
+metadata:
+  name: "team"
+  updated_at: "2025-01-30T12:00:00Z"
+
+skills:
+  # the PDF helper
+  - name: "pdf"
+    description: "Old description"
+    source:
+      repo: "https://example.com/team/skills"
+      path: "skills/pdf"
+      tag: "v1.0.0"
+  - name: "docx"
+    description: "Word documents"
+    source:
+      repo: "https://example.com/team/skills"
+      path: "skills/docx"
+`)
+	now := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	pdf := SkillEntry{Name: "pdf", Description: "Fill in PDF forms", Tags: []string{"pdf", "forms"},
+		Source: SkillSource{Repo: "https://example.com/team/skills", Path: "skills/pdf"}}
+
+	out, err := updateIndex(data, pdf, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index Index
+	if err := yaml.Unmarshal(out, &index); err != nil {
+		t.Fatalf("Updated index does not parse: %v\n%s", err, out)
+	}
+	if len(index.Skills) != 2 || index.Skills[0].Description != "Fill in PDF forms" || index.Skills[0].Source.Tag != "" {
+		t.Errorf("Expected the pdf entry replaced in place, got %+v", index.Skills)
+	}
+	if index.Skills[1].Name != "docx" {
+		t.Error("Expected other entries kept")
+	}
+	if !index.Metadata.UpdatedAt.Equal(now) {
+		t.Errorf("Expected updated_at stamped, got %q", index.Metadata.UpdatedAt)
+	}
+	if !strings.Contains(string(out), "# the PDF helper") {
+		t.Errorf("Expected comments kept:\n%s", out)
+	}
+
+	out, err = updateIndex(out, SkillEntry{Name: "xlsx", Source: SkillSource{Repo: "https://example.com/team/skills", Path: "skills/xlsx"}}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index = Index{}
+	yaml.Unmarshal(out, &index)
+	if len(index.Skills) != 3 || index.Skills[2].Name != "xlsx" || index.Skills[2].Source.Path != "skills/xlsx" {
+		t.Errorf("Expected xlsx appended, got %+v", index.Skills)
+	}
+}
+
+func TestUpdateIndex_EmptySkills(t *testing.T) {
+	out, err := updateIndex([]byte("version: 1\nskills:\n"), SkillEntry{Name: "pdf", Source: SkillSource{Repo: "r"}}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index Index
+	if err := yaml.Unmarshal(out, &index); err != nil || len(index.Skills) != 1 {
+		t.Errorf("Expected one skill, got %+v %v\n%s", index.Skills, err, out)
+	}
+}
@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SignatureFile is the detached signature of index.yaml, next to it in
+// the repository: the base64 ed25519 signature of the file's exact bytes
+const SignatureFile = "index.yaml.sig"
+
+// ListedSigned is how skills are listed by an index.yaml whose signature
+// checked out against the public_key of its config repo
+const ListedSigned = "signed index.yaml"
+
+// Signed reports whether the skill was listed by a verified index.yaml
+func (s *SkillEntry) Signed() bool {
+	return s.Source.Listed == ListedSigned
+}
+
+// ParsePublicKey decodes a public_key setting, a base64 ed25519 public key
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: expected a base64 ed25519 key")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// SignIndex returns the contents of index.yaml.sig for an index.yaml
+func SignIndex(key ed25519.PrivateKey, data []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)) + "\n")
+}
+
+// VerifyIndex checks the contents of index.yaml.sig against an index.yaml
+func VerifyIndex(key ed25519.PublicKey, data, sig []byte) error {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil || !ed25519.Verify(key, data, raw) {
+		return fmt.Errorf("%s does not match index.yaml", SignatureFile)
+	}
+	return nil
+}
+
+// repoKey returns the public key configured for the repository at
+// repoURL, or nil when its index is not verified
+func (r *Registry) repoKey(repoURL string) (ed25519.PublicKey, error) {
+	if r.cfg == nil {
+		return nil, nil
+	}
+	for _, repo := range r.cfg.Repos {
+		if repo.PublicKey != "" && includeKey(repo.URL) == includeKey(repoURL) {
+			key, err := ParsePublicKey(repo.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("repo '%s': %w", repo.Name, err)
+			}
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseIndex parses the index.yaml of repoURL. When the repository has a
+// public key, its signature sig (nil when there is none) must check out
+// before any skill source in it is trusted.
+func (r *Registry) parseIndex(repoURL string, data, sig []byte) (*Index, error) {
+	key, err := r.repoKey(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	listed := "index.yaml"
+	if key != nil {
+		if sig == nil {
+			return nil, fmt.Errorf("index.yaml is not signed (no %s) but a public_key is configured", SignatureFile)
+		}
+		if err := VerifyIndex(key, data, sig); err != nil {
+			return nil, err
+		}
+		listed = ListedSigned
+	}
+
+	index := &Index{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to parse index.yaml: %w", err)
+	}
+	for i := range index.Skills {
+		index.Skills[i].Source.Listed = listed
+	}
+	return index, nil
+}
+
+// Unverified reports whether installing skill deserves a warning: some
+// config repos are verified against a public key, but skill was not
+// listed by a signed index. Without any public_key nothing is verified
+// and nothing is warned about.
+func (r *Registry) Unverified(skill *SkillEntry) bool {
+	if r.cfg == nil || skill.Signed() {
+		return false
+	}
+	for _, repo := range r.cfg.Repos {
+		if repo.PublicKey != "" {
+			return true
+		}
+	}
+	return false
+}
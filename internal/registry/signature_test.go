@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lazyas/internal/config"
+)
+
+func TestReadRepo_VerifiesSignedIndex(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const repoURL = "https://example.com/registry"
+	index := []byte("skills:\n  - {name: pdf, source: {repo: https://example.com/pdf}}\n")
+
+	tmp := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmp, "index.yaml"), index, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Repos: []config.Repo{{Name: "reg", URL: repoURL, PublicKey: base64.StdEncoding.EncodeToString(pub)}}}
+	r := NewRegistry(cfg)
+
+	// A public key without a signature is refused
+	if _, err := r.readRepo(tmp, repoURL); err == nil || !strings.Contains(err.Error(), "not signed") {
+		t.Fatalf("unsigned index: err = %v", err)
+	}
+
+	sigPath := filepath.Join(tmp, SignatureFile)
+	if err := os.WriteFile(sigPath, SignIndex(priv, index), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	skills, err := r.readRepo(tmp, repoURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 1 || !skills[0].Signed() || r.Unverified(&skills[0]) {
+		t.Errorf("signed index: skills = %+v", skills)
+	}
+
+	// So is an index changed after signing
+	if err := os.WriteFile(filepath.Join(tmp, "index.yaml"), append(index, "  - {name: evil, source: {repo: https://example.com/evil}}\n"...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.readRepo(tmp, repoURL); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("tampered index: err = %v", err)
+	}
+
+	// Without a public key the signature is not checked, and the skills
+	// are unverified once another repo is
+	cfg.Repos = append(cfg.Repos, config.Repo{Name: "other", URL: "https://example.com/other"})
+	skills, err = r.readRepo(tmp, "https://example.com/other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 2 || skills[0].Signed() || !r.Unverified(&skills[0]) {
+		t.Errorf("unkeyed index: skills = %+v", skills)
+	}
+}
@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"lazyas/internal/i18n"
+	"lazyas/internal/scan"
+)
+
+// maxSummaryItems caps the names listed in a trust summary line
+const maxSummaryItems = 6
+
+// RepoSummary describes a repository before it is added, so the user can
+// decide whether to trust it
+type RepoSummary struct {
+	URL      string
+	IsIndex  bool     // index.yaml pointing at other repositories
+	Skills   []string // skill names
+	TopLevel []string // top-level entries; directories end with "/"
+	Risk     *scan.Report
+}
+
+// InspectRepo fetches a repository once and summarizes what it contains
+func (r *Registry) InspectRepo(repoURL string) (*RepoSummary, error) {
+	tempDir, err := shallowClone(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	summary := &RepoSummary{URL: repoURL}
+	if _, err := os.Stat(filepath.Join(tempDir, "index.yaml")); err == nil {
+		summary.IsIndex = true
+	}
+
+	skills, err := r.readRepo(tempDir, repoURL)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range skills {
+		summary.Skills = append(summary.Skills, s.Name)
+	}
+	sort.Strings(summary.Skills)
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if name == ".git" {
+			continue
+		}
+		if e.IsDir() {
+			name += "/"
+		}
+		summary.TopLevel = append(summary.TopLevel, name)
+	}
+
+	if summary.Risk, err = scan.Dir(tempDir); err != nil {
+		return nil, fmt.Errorf("failed to scan repository: %w", err)
+	}
+	return summary, nil
+}
+
+// Lines renders the summary for a confirmation prompt
+func (s *RepoSummary) Lines() []string {
+	skills := i18n.Tf("Skills: %d", len(s.Skills))
+	if s.IsIndex {
+		skills = i18n.Tf("Index listing %d skill(s)", len(s.Skills))
+	}
+	if len(s.Skills) > 0 {
+		skills += " (" + joinLimited(s.Skills) + ")"
+	}
+
+	return []string{
+		skills,
+		i18n.Tf("Contents: %s", joinLimited(s.TopLevel)),
+		i18n.Tf("Risk: %s", s.Risk.Summary()),
+	}
+}
+
+func joinLimited(items []string) string {
+	if len(items) <= maxSummaryItems {
+		return strings.Join(items, ", ")
+	}
+	return strings.Join(items[:maxSummaryItems], ", ") + i18n.Tf(", … %d more", len(items)-maxSummaryItems)
+}
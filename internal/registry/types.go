@@ -7,6 +7,7 @@ type Index struct {
 	Version  int           `yaml:"version"`
 	Metadata IndexMetadata `yaml:"metadata"`
 	Skills   []SkillEntry  `yaml:"skills"`
+	Include  []string      `yaml:"include,omitempty"` // other index repos or index.yaml URLs merged into this one
 }
 
 // IndexMetadata contains registry metadata
@@ -22,14 +23,16 @@ type SkillEntry struct {
 	Source      SkillSource `yaml:"source"`
 	Author      string      `yaml:"author"`
 	Tags        []string    `yaml:"tags"`
+	Size        int64       `yaml:"size,omitempty"` // bytes of skill files (from index.yaml or measured when scanning)
 }
 
 // SkillSource defines where to fetch the skill from
 type SkillSource struct {
 	Repo     string `yaml:"repo"`
-	Path     string `yaml:"path"` // subdirectory within repo (optional)
-	Tag      string `yaml:"tag"`  // version tag
-	RepoName string `yaml:"-"`    // name of the config repo (not serialized)
+	Path     string `yaml:"path"`             // subdirectory within repo (optional)
+	Tag      string `yaml:"tag"`              // version tag
+	Listed   string `yaml:"listed,omitempty"` // how the config repo listed it: "index.yaml", ListedSigned or "scan"
+	RepoName string `yaml:"-"`                // name of the config repo (not serialized)
 }
 
 // MatchesQuery checks if the skill matches a search query
@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"lazyas/internal/manifest"
+)
+
+var (
+	// validEntryTag is a conservative subset of git ref names
+	validEntryTag = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/+-]*$`)
+	// scpLikeURL matches git's "user@host:path" shorthand for ssh
+	scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^-/][^:]*$`)
+)
+
+// allowedSchemes are the URL schemes index entries may clone from. Others,
+// including git's "ext::" transport helpers, could run commands.
+var allowedSchemes = map[string]bool{"https": true, "http": true, "ssh": true, "git": true, "file": true}
+
+// ValidateEntry checks an index.yaml entry before its values reach git:
+// required fields, the repository URL scheme, a relative path that stays
+// inside the repository and a plausible tag
+func ValidateEntry(s SkillEntry) error {
+	if s.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if !manifest.ValidName(s.Name) {
+		return fmt.Errorf("invalid name %q (use letters, digits, '.', '_' or '-')", s.Name)
+	}
+	if err := validateRepoURL(s.Source.Repo); err != nil {
+		return err
+	}
+	if err := validateSourcePath(s.Source.Path); err != nil {
+		return err
+	}
+	if s.Source.Tag != "" && !validTag(s.Source.Tag) {
+		return fmt.Errorf("invalid tag %q", s.Source.Tag)
+	}
+	return nil
+}
+
+func validateRepoURL(repo string) error {
+	switch {
+	case repo == "":
+		return fmt.Errorf("missing source.repo")
+	case hasControl(repo) || strings.ContainsAny(repo, " \t"):
+		return fmt.Errorf("invalid source.repo %q", repo)
+	case strings.HasPrefix(repo, "-") || strings.Contains(repo, "::"):
+		return fmt.Errorf("unsupported source.repo %q", repo)
+	}
+
+	if strings.Contains(repo, "://") {
+		u, err := url.Parse(repo)
+		if err != nil {
+			return fmt.Errorf("invalid source.repo %q: %v", repo, err)
+		}
+		if !allowedSchemes[strings.ToLower(u.Scheme)] {
+			return fmt.Errorf("unsupported URL scheme %q in source.repo", u.Scheme)
+		}
+		if u.Host == "" && u.Scheme != "file" {
+			return fmt.Errorf("source.repo %q has no host", repo)
+		}
+		return nil
+	}
+	if scpLikeURL.MatchString(repo) || filepath.IsAbs(repo) {
+		return nil
+	}
+	return fmt.Errorf("unsupported source.repo %q (use an https, ssh, git or file URL)", repo)
+}
+
+func validateSourcePath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if hasControl(path) || strings.HasPrefix(path, "/") || strings.HasPrefix(path, "-") || strings.Contains(path, `\`) {
+		return fmt.Errorf("invalid source.path %q", path)
+	}
+	for _, part := range strings.Split(path, "/") {
+		if part == ".." {
+			return fmt.Errorf("source.path %q leaves the repository", path)
+		}
+	}
+	return nil
+}
+
+func validTag(tag string) bool {
+	return validEntryTag.MatchString(tag) &&
+		!strings.Contains(tag, "..") &&
+		!strings.Contains(tag, "//") &&
+		!strings.HasSuffix(tag, "/") &&
+		!strings.HasSuffix(tag, ".") &&
+		!strings.HasSuffix(tag, ".lock")
+}
+
+func hasControl(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r < 0x20 || r == 0x7f }) != -1
+}
+
+// validEntries drops the entries that fail ValidateEntry, recording a
+// warning for each that names the index it came from
+func (r *Registry) validEntries(skills []SkillEntry, from string) []SkillEntry {
+	valid := skills[:0]
+	for _, s := range skills {
+		if err := ValidateEntry(s); err != nil {
+			name := s.Name
+			if name == "" {
+				name = "(unnamed)"
+			}
+			r.warnings = append(r.warnings, fmt.Sprintf("%s: skipped %s: %v", from, name, err))
+			continue
+		}
+		valid = append(valid, s)
+	}
+	return valid
+}
+
+// Warnings returns problems found during the last fetch, such as index
+// entries that were skipped as invalid or repositories that could not be
+// refreshed
+func (r *Registry) Warnings() []string {
+	return r.warnings
+}
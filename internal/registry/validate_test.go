@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateEntry(t *testing.T) {
+	entry := func(name, repo, path, tag string) SkillEntry {
+		s := SkillEntry{Name: name}
+		s.Source.Repo, s.Source.Path, s.Source.Tag = repo, path, tag
+		return s
+	}
+	tests := []struct {
+		entry   SkillEntry
+		wantErr string
+	}{
+		{entry("pdf", "https://github.com/example/skills", "skills/pdf", "v1.2.0"), ""},
+		{entry("pdf", "git@github.com:example/skills.git", "", "release/2"), ""},
+		{entry("pdf", "ssh://git@example.com/skills", "./pdf", ""), ""},
+		{entry("pdf", "file:///srv/skills", "", ""), ""},
+		{entry("pdf", "/srv/skills", "", ""), ""},
+		{entry("", "https://github.com/example/skills", "", ""), "missing name"},
+		{entry("../pdf", "https://github.com/example/skills", "", ""), "invalid name"},
+		{entry("-pdf", "https://github.com/example/skills", "", ""), "invalid name"},
+		{entry("pdf", "", "", ""), "missing source.repo"},
+		{entry("pdf", "ext::sh -c touch% /tmp/pwned", "", ""), "source.repo"},
+		{entry("pdf", "--upload-pack=touch", "", ""), "unsupported source.repo"},
+		{entry("pdf", "ftp://example.com/skills", "", ""), "unsupported URL scheme"},
+		{entry("pdf", "https:///skills", "", ""), "has no host"},
+		{entry("pdf", "example/skills", "", ""), "unsupported source.repo"},
+		{entry("pdf", "https://github.com/example/skills", "../../etc", ""), "leaves the repository"},
+		{entry("pdf", "https://github.com/example/skills", "skills/../../x", ""), "leaves the repository"},
+		{entry("pdf", "https://github.com/example/skills", "/etc", ""), "invalid source.path"},
+		{entry("pdf", "https://github.com/example/skills", "", "--orphan"), "invalid tag"},
+		{entry("pdf", "https://github.com/example/skills", "", "v1..2"), "invalid tag"},
+		{entry("pdf", "https://github.com/example/skills", "", "main.lock"), "invalid tag"},
+		{entry("pdf", "https://github.com/example/skills", "", "v1 2"), "invalid tag"},
+	}
+	for _, tt := range tests {
+		err := ValidateEntry(tt.entry)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("ValidateEntry(%+v) = %v, want nil", tt.entry, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("ValidateEntry(%+v) = %v, want error containing %q", tt.entry, err, tt.wantErr)
+		}
+	}
+}
+
+func TestReadRepo_SkipsInvalidEntries(t *testing.T) {
+	tmp := t.TempDir()
+	index := `skills:
+  - {name: good, source: {repo: https://example.com/skills, path: good}}
+  - {name: escape, source: {repo: https://example.com/skills, path: ../../home}}
+  - {name: helper, source: {repo: "ext::sh -c id"}}
+  - {source: {repo: https://example.com/skills}}
+`
+	if err := os.WriteFile(filepath.Join(tmp, "index.yaml"), []byte(index), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Registry{}
+	skills, err := r.readRepo(tmp, "https://example.com/registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 1 || skills[0].Name != "good" {
+		t.Errorf("skills = %v, want only good", skills)
+	}
+	warnings := r.Warnings()
+	if len(warnings) != 3 {
+		t.Fatalf("warnings = %v, want 3", warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "index.yaml of https://example.com/registry: skipped escape:") {
+		t.Errorf("warning = %q", warnings[0])
+	}
+	if !strings.Contains(warnings[2], "skipped (unnamed): missing name") {
+		t.Errorf("warning = %q", warnings[2])
+	}
+}
@@ -0,0 +1,161 @@
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MaxExtractedSize caps the unpacked size of an asset
+const MaxExtractedSize = 200 << 20
+
+// Extract unpacks a .tar.gz archive into dest. Entries that would land
+// outside dest (absolute paths, "..", links pointing out) are refused, and
+// only directories, regular files and symlinks are created.
+func Extract(data []byte, dest string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+	defer gz.Close()
+
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		}
+
+		name, err := entryName(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		target := filepath.Join(dest, filepath.FromSlash(name))
+		if err := checkParents(dest, name); err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			total += hdr.Size
+			if total > MaxExtractedSize {
+				return fmt.Errorf("archive unpacks to more than %d bytes", MaxExtractedSize)
+			}
+			if err := writeFile(target, tr, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			link := hdr.Linkname
+			if path.IsAbs(link) || strings.Contains(link, `\`) {
+				return fmt.Errorf("archive entry %s links outside the archive", hdr.Name)
+			}
+			if _, err := entryName(path.Join(path.Dir(name), link)); err != nil {
+				return fmt.Errorf("archive entry %s links outside the archive", hdr.Name)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		case tar.TypeXGlobalHeader:
+			// pax metadata, e.g. the commit git archive records
+		default:
+			return fmt.Errorf("archive entry %s has unsupported type %q", hdr.Name, hdr.Typeflag)
+		}
+	}
+}
+
+// entryName cleans an archive path, refusing ones that leave the archive.
+// "" is the archive root.
+func entryName(name string) (string, error) {
+	if path.IsAbs(name) || strings.Contains(name, `\`) {
+		return "", fmt.Errorf("archive entry %s is not relative", name)
+	}
+	clean := path.Clean(name)
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("archive entry %s leaves the archive", name)
+	}
+	if clean == "." {
+		return "", nil
+	}
+	return clean, nil
+}
+
+// checkParents refuses entries below a symlink the archive created
+// earlier, which could otherwise write through it to anywhere
+func checkParents(dest, name string) error {
+	dir := dest
+	parts := strings.Split(name, "/")
+	for _, part := range parts[:len(parts)-1] {
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("archive entry %s is inside a symlink", name)
+		}
+	}
+	return nil
+}
+
+// writeFile creates a file with the archive's executable bit and nothing
+// more permissive
+func writeFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	perm := os.FileMode(0644)
+	if mode&0100 != 0 {
+		perm = 0755
+	}
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SkillDir finds the skill in an unpacked asset: dir itself when it holds
+// SKILL.md, else its only top-level directory ("pdf/SKILL.md")
+func SkillDir(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err == nil {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		sub := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(sub, "SKILL.md")); err == nil {
+			return sub, nil
+		}
+	}
+	return "", fmt.Errorf("asset has no SKILL.md at its root or in a single top-level directory")
+}
@@ -0,0 +1,265 @@
+// Package release installs skills packaged as GitHub release assets
+// (gh-release://owner/repo@tag/skill.tar.gz). Assets are only accepted when
+// the release publishes a checksums file that lists them.
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"lazyas/internal/manifest"
+)
+
+// Scheme prefixes release asset sources
+const Scheme = "gh-release://"
+
+// MaxAssetSize caps asset downloads
+const MaxAssetSize = 50 << 20
+
+// apiBase is the GitHub API; tests point it at a local server
+var apiBase = "https://api.github.com"
+
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+// Source is a release asset parsed from a gh-release:// URL
+type Source struct {
+	Owner string
+	Repo  string
+	Tag   string
+	Asset string // file name of the asset, e.g. "skill.tar.gz"
+}
+
+// IsSource reports whether s is a gh-release:// URL
+func IsSource(s string) bool {
+	return strings.HasPrefix(s, Scheme)
+}
+
+// Parse parses "gh-release://owner/repo@tag/asset". The tag may itself
+// contain "/"; the asset is the last path element.
+func Parse(s string) (Source, error) {
+	rest, ok := strings.CutPrefix(s, Scheme)
+	if !ok {
+		return Source{}, fmt.Errorf("%s is not a %s URL", s, Scheme)
+	}
+	repo, tagAsset, ok := strings.Cut(rest, "@")
+	if !ok {
+		return Source{}, fmt.Errorf("%s has no release tag (want %sowner/repo@tag/asset)", s, Scheme)
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || !manifest.ValidName(owner) || !manifest.ValidName(name) {
+		return Source{}, fmt.Errorf("%s has an invalid repository (want %sowner/repo@tag/asset)", s, Scheme)
+	}
+	i := strings.LastIndex(tagAsset, "/")
+	if i <= 0 || i == len(tagAsset)-1 {
+		return Source{}, fmt.Errorf("%s has no asset name (want %sowner/repo@tag/asset)", s, Scheme)
+	}
+	src := Source{Owner: owner, Repo: name, Tag: tagAsset[:i], Asset: tagAsset[i+1:]}
+	if strings.Contains(src.Tag, "..") || strings.ContainsAny(src.Tag+src.Asset, " \t\\?#") || src.Asset == ".." {
+		return Source{}, fmt.Errorf("invalid release asset %s", s)
+	}
+	if _, ok := archiveBase(src.Asset); !ok {
+		return Source{}, fmt.Errorf("unsupported asset %s (want .tar.gz or .tgz)", src.Asset)
+	}
+	return src, nil
+}
+
+// String returns the gh-release:// URL of the source
+func (s Source) String() string {
+	return fmt.Sprintf("%s%s/%s@%s/%s", Scheme, s.Owner, s.Repo, s.Tag, s.Asset)
+}
+
+// RepoURL returns the GitHub repository the release belongs to
+func (s Source) RepoURL() string {
+	return fmt.Sprintf("https://github.com/%s/%s", s.Owner, s.Repo)
+}
+
+// SkillName derives a skill name from the asset: "pdf-v1.2.0.tar.gz"
+// released as v1.2.0 is "pdf"
+func (s Source) SkillName() string {
+	base, _ := archiveBase(s.Asset)
+	for _, suffix := range []string{"-" + s.Tag, "-" + strings.TrimPrefix(s.Tag, "v"), "_" + s.Tag} {
+		if trimmed, ok := strings.CutSuffix(base, suffix); ok && trimmed != "" {
+			return trimmed
+		}
+	}
+	return base
+}
+
+// archiveBase strips the archive extension from an asset name
+func archiveBase(asset string) (string, bool) {
+	for _, ext := range []string{".tar.gz", ".tgz"} {
+		if base, ok := strings.CutSuffix(asset, ext); ok && base != "" {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// asset is a file attached to a release
+type asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Download fetches the asset of src and verifies it against the checksums
+// file of the release. Returns the archive, its sha256 and the name of the
+// checksums file that listed it.
+func Download(src Source) (data []byte, digest, checksums string, err error) {
+	var rel struct {
+		Assets []asset `json:"assets"`
+	}
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", apiBase, src.Owner, src.Repo, url.PathEscape(src.Tag))
+	if err := getJSON(apiURL, &rel); err != nil {
+		return nil, "", "", fmt.Errorf("failed to look up release %s: %w", src.Tag, err)
+	}
+
+	var target *asset
+	for i := range rel.Assets {
+		if rel.Assets[i].Name == src.Asset {
+			target = &rel.Assets[i]
+		}
+	}
+	if target == nil {
+		return nil, "", "", fmt.Errorf("release %s of %s/%s has no asset %s", src.Tag, src.Owner, src.Repo, src.Asset)
+	}
+	sums := checksumsAsset(rel.Assets, src.Asset)
+	if sums == nil {
+		return nil, "", "", fmt.Errorf("release %s of %s/%s publishes no checksums file; refusing unverified asset", src.Tag, src.Owner, src.Repo)
+	}
+
+	sumData, err := download(sums.URL, 1<<20)
+	if err != nil {
+		return nil, "", "", err
+	}
+	want, ok := ParseChecksums(sumData, src.Asset)
+	if !ok {
+		return nil, "", "", fmt.Errorf("%s does not list %s", sums.Name, src.Asset)
+	}
+
+	if data, err = download(target.URL, MaxAssetSize); err != nil {
+		return nil, "", "", err
+	}
+	sum := sha256.Sum256(data)
+	digest = hex.EncodeToString(sum[:])
+	if digest != want {
+		return nil, "", "", fmt.Errorf("checksum mismatch for %s: %s lists %s, downloaded %s", src.Asset, sums.Name, want, digest)
+	}
+	return data, digest, sums.Name, nil
+}
+
+// checksumsAsset picks the checksums file of a release: a per-asset
+// "<asset>.sha256" first, then a shared one like checksums.txt or SHA256SUMS
+func checksumsAsset(assets []asset, name string) *asset {
+	for i := range assets {
+		if assets[i].Name == name+".sha256" {
+			return &assets[i]
+		}
+	}
+	for i := range assets {
+		lower := strings.ToLower(assets[i].Name)
+		if strings.Contains(lower, "checksums") || strings.HasPrefix(lower, "sha256sums") {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+// ParseChecksums finds the sha256 of asset in sha256sum output
+// ("<hex>  <name>", "<hex> *<name>"). A file holding a lone hash, as
+// "<asset>.sha256" files often do, applies to any asset.
+func ParseChecksums(data []byte, asset string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !isSHA256(fields[0]) {
+			continue
+		}
+		sum := strings.ToLower(fields[0])
+		if len(fields) == 1 {
+			return sum, true
+		}
+		name := strings.TrimPrefix(fields[len(fields)-1], "*")
+		name = strings.TrimPrefix(name, "./")
+		if name == asset {
+			return sum, true
+		}
+	}
+	return "", false
+}
+
+func isSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// getJSON performs an API GET, authenticating with $GITHUB_TOKEN when set
+func getJSON(apiURL string, v any) error {
+	req, err := newRequest(apiURL)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", apiURL, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// download fetches fileURL, refusing responses over max bytes
+func download(fileURL string, max int64) ([]byte, error) {
+	req, err := newRequest(fileURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", fileURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, max+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(data)) > max {
+		return nil, fmt.Errorf("%s exceeds %d bytes", fileURL, max)
+	}
+	return data, nil
+}
+
+// newRequest builds a GET, adding $GITHUB_TOKEN for the GitHub API and
+// github.com so private releases work
+func newRequest(rawURL string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" &&
+		(strings.HasPrefix(rawURL, "https://api.github.com/") || strings.HasPrefix(rawURL, "https://github.com/")) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
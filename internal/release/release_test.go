@@ -0,0 +1,187 @@
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	src, err := Parse("gh-release://acme/skills@v1.2.0/pdf-v1.2.0.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Source{Owner: "acme", Repo: "skills", Tag: "v1.2.0", Asset: "pdf-v1.2.0.tar.gz"}
+	if src != want {
+		t.Fatalf("Parse = %+v, want %+v", src, want)
+	}
+	if src.String() != "gh-release://acme/skills@v1.2.0/pdf-v1.2.0.tar.gz" {
+		t.Errorf("String() = %s", src.String())
+	}
+	if src.SkillName() != "pdf" {
+		t.Errorf("SkillName() = %s, want pdf", src.SkillName())
+	}
+
+	nested, err := Parse("gh-release://acme/skills@pdf/v2/skill.tgz")
+	if err != nil || nested.Tag != "pdf/v2" || nested.SkillName() != "skill" {
+		t.Errorf("Parse nested tag = %+v, %v", nested, err)
+	}
+
+	for _, bad := range []string{
+		"https://github.com/acme/skills",
+		"gh-release://acme/skills/skill.tar.gz",
+		"gh-release://acme@v1/skill.tar.gz",
+		"gh-release://acme/skills@v1",
+		"gh-release://acme/skills@v1/",
+		"gh-release://acme/skills@../v1/skill.tar.gz",
+		"gh-release://acme/skills@v1/skill.zip",
+	} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	sum := strings.Repeat("ab", 32)
+	other := strings.Repeat("cd", 32)
+	data := []byte(other + "  other.tar.gz\n" + sum + " *./skill.tar.gz\n")
+	if got, ok := ParseChecksums(data, "skill.tar.gz"); !ok || got != sum {
+		t.Errorf("ParseChecksums = %s, %v", got, ok)
+	}
+	if _, ok := ParseChecksums(data, "missing.tar.gz"); ok {
+		t.Error("missing asset should not be found")
+	}
+	if got, ok := ParseChecksums([]byte(strings.ToUpper(sum)+"\n"), "skill.tar.gz"); !ok || got != sum {
+		t.Errorf("lone hash = %s, %v", got, ok)
+	}
+}
+
+type entry struct {
+	name, body, link string
+	dir              bool
+}
+
+func archive(t *testing.T, entries ...entry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		switch {
+		case e.dir:
+			hdr = &tar.Header{Name: e.name, Mode: 0755, Typeflag: tar.TypeDir}
+		case e.link != "":
+			hdr = &tar.Header{Name: e.name, Linkname: e.link, Typeflag: tar.TypeSymlink}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if e.body != "" {
+			tw.Write([]byte(e.body))
+		}
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestExtract(t *testing.T) {
+	dest := t.TempDir()
+	data := archive(t,
+		entry{name: "pdf/", dir: true},
+		entry{name: "pdf/SKILL.md", body: "# PDF\n"},
+		entry{name: "pdf/docs/ref.md", body: "ref\n"},
+		entry{name: "pdf/README.md", link: "docs/ref.md"},
+	)
+	if err := Extract(data, dest); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := SkillDir(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != filepath.Join(dest, "pdf") {
+		t.Errorf("SkillDir = %s", dir)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(got) != "ref\n" {
+		t.Errorf("README.md = %q", got)
+	}
+}
+
+func TestExtract_RejectsEscapes(t *testing.T) {
+	cases := map[string][]entry{
+		"dotdot":          {{name: "../evil", body: "x"}},
+		"absolute":        {{name: "/tmp/evil", body: "x"}},
+		"link out":        {{name: "l", link: "../../etc/passwd"}},
+		"absolute link":   {{name: "l", link: "/etc/passwd"}},
+		"through symlink": {{name: "a", link: "."}, {name: "a/b", dir: true}, {name: "a/b/c", body: "x"}},
+	}
+	for name, entries := range cases {
+		t.Run(name, func(t *testing.T) {
+			parent := t.TempDir()
+			dest := filepath.Join(parent, "dest")
+			os.Mkdir(dest, 0755)
+			if err := Extract(archive(t, entries...), dest); err == nil {
+				t.Fatal("Extract should fail")
+			}
+			if _, err := os.Stat(filepath.Join(parent, "evil")); err == nil {
+				t.Fatal("file written outside dest")
+			}
+		})
+	}
+}
+
+func TestDownload_VerifiesChecksum(t *testing.T) {
+	data := archive(t, entry{name: "SKILL.md", body: "# Skill\n"})
+	sum := sha256.Sum256(data)
+	listed := hex.EncodeToString(sum[:])
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		base := "http://" + r.Host
+		switch r.URL.Path {
+		case "/repos/acme/skills/releases/tags/v1.0.0":
+			fmt.Fprintf(w, `{"assets": [
+				{"name": "skill.tar.gz", "browser_download_url": "%[1]s/dl/skill.tar.gz"},
+				{"name": "checksums.txt", "browser_download_url": "%[1]s/dl/checksums.txt"}]}`, base)
+		case "/dl/skill.tar.gz":
+			w.Write(data)
+		case "/dl/checksums.txt":
+			fmt.Fprintf(w, "%s  skill.tar.gz\n", listed)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	defer func(old string) { apiBase = old }(apiBase)
+	apiBase = srv.URL
+
+	src := Source{Owner: "acme", Repo: "skills", Tag: "v1.0.0", Asset: "skill.tar.gz"}
+	got, digest, sums, err := Download(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) || digest != listed || sums != "checksums.txt" {
+		t.Errorf("Download = %d bytes, %s, %s", len(got), digest, sums)
+	}
+
+	listed = strings.Repeat("0", 64)
+	if _, _, _, err := Download(src); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("tampered asset: err = %v", err)
+	}
+
+	src.Tag = "v2.0.0"
+	if _, _, _, err := Download(src); err == nil {
+		t.Error("missing release should fail")
+	}
+}
@@ -0,0 +1,231 @@
+// Package relocate moves the lazyas directory, the skills directory or
+// the repos directory somewhere else and re-points every link into them,
+// so a store can follow a dotfile manager or live off a network home.
+package relocate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"lazyas/internal/config"
+	"lazyas/internal/symlink"
+)
+
+// Plan says where things go. Empty fields stay where they are.
+type Plan struct {
+	Home      string // new central lazyas directory (set LAZYAS_HOME to use it)
+	SkillsDir string // new skills directory, saved as skills_dir
+	ReposDir  string // new repos directory, saved as repos_dir
+}
+
+// Move carries out plan: it moves the directories, re-points the skill
+// links, aliases and backend links that pointed into the old locations,
+// and saves the new locations in the config. When a step fails, the
+// directories and links that were already moved are put back.
+func Move(cfg *config.Config, plan Plan) (err error) {
+	oldHome, oldSkills, oldRepos := cfg.ConfigDir, cfg.SkillsDir, cfg.ReposDir
+	var done []move
+	defer func() {
+		if err != nil {
+			back := []move{
+				{cfg.SkillsDir, oldSkills},
+				{cfg.ReposDir, oldRepos},
+				{cfg.ConfigDir, oldHome},
+			}
+			cfg.SetHome(oldHome)
+			cfg.SkillsDir, cfg.ReposDir = oldSkills, oldRepos
+			err = rollback(cfg, done, back, err)
+		}
+	}()
+
+	if plan.Home != "" {
+		home, err := absPath(plan.Home)
+		if err != nil {
+			return err
+		}
+		if home != cfg.ConfigDir {
+			if err := moveDir(cfg.ConfigDir, home); err != nil {
+				return err
+			}
+			done = append(done, move{cfg.ConfigDir, home})
+			cfg.SetHome(home)
+		}
+	}
+	if plan.SkillsDir != "" {
+		dir, err := absPath(plan.SkillsDir)
+		if err != nil {
+			return err
+		}
+		if err := moveDir(cfg.SkillsDir, dir); err != nil {
+			return err
+		}
+		done = append(done, move{cfg.SkillsDir, dir})
+		cfg.SkillsDir = dir
+	}
+	if plan.ReposDir != "" {
+		dir, err := absPath(plan.ReposDir)
+		if err != nil {
+			return err
+		}
+		if err := moveDir(cfg.ReposDir, dir); err != nil {
+			return err
+		}
+		done = append(done, move{cfg.ReposDir, dir})
+		cfg.ReposDir = dir
+	}
+
+	// Most specific first: the skills and repos directories may have
+	// moved away from the home they were in
+	if err := relinkAll(cfg, []move{
+		{oldSkills, cfg.SkillsDir},
+		{oldRepos, cfg.ReposDir},
+		{oldHome, cfg.ConfigDir},
+	}); err != nil {
+		return err
+	}
+	return cfg.Save()
+}
+
+// relinkAll re-points the links in the skills directory and the backends
+func relinkAll(cfg *config.Config, moves []move) error {
+	if err := relinkDir(cfg.SkillsDir, moves, os.Symlink); err != nil {
+		return err
+	}
+	for _, backend := range cfg.Backends {
+		if err := relinkBackend(backend, moves); err != nil {
+			return fmt.Errorf("failed to relink backend '%s': %w", backend.Name, err)
+		}
+	}
+	return nil
+}
+
+// rollback moves the directories in done back, newest first, and points
+// the links that were already re-pointed along back, into the restored
+// locations of cfg. It returns err, noting what could not be put back.
+func rollback(cfg *config.Config, done, back []move, err error) error {
+	for i := len(done) - 1; i >= 0; i-- {
+		m := done[i]
+		if undoErr := moveDir(m.to, m.from); undoErr != nil {
+			return fmt.Errorf("%w (moving %s back to %s also failed: %v)", err, m.to, m.from, undoErr)
+		}
+	}
+	if undoErr := relinkAll(cfg, back); undoErr != nil {
+		return fmt.Errorf("%w (restoring the links also failed: %v)", err, undoErr)
+	}
+	return err
+}
+
+// move is a directory that moved from one place to another
+type move struct {
+	from, to string
+}
+
+func absPath(path string) (string, error) {
+	expanded, err := config.ExpandPath(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(expanded)
+}
+
+// moveDir moves src to dst, copying when they are on different
+// filesystems. dst may exist only as an empty directory. A missing src
+// leaves nothing to move.
+func moveDir(src, dst string) error {
+	if src == dst {
+		return nil
+	}
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	if rel, err := filepath.Rel(src, dst); err == nil && !strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("cannot move %s into itself", src)
+	}
+	if entries, err := os.ReadDir(dst); err == nil {
+		if len(entries) > 0 {
+			return fmt.Errorf("%s already exists and is not empty", dst)
+		}
+		if err := os.Remove(dst); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	if err := symlink.CopyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+	}
+	return os.RemoveAll(src)
+}
+
+// relinkBackend re-points a backend's link to the skills directory, or
+// the per-skill links in its directory
+func relinkBackend(backend config.Backend, moves []move) error {
+	path, err := config.ExpandPath(backend.Path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil
+	}
+	if symlink.IsLink(path, info) {
+		return relink(path, moves, symlink.Link)
+	}
+	if info.IsDir() && backend.PerSkill() {
+		return relinkDir(path, moves, symlink.Link)
+	}
+	return nil
+}
+
+// relinkDir re-points the links directly in dir, recreating them with
+// create
+func relinkDir(dir string, moves []move, create func(target, path string) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		info, err := os.Lstat(path)
+		if err != nil || !symlink.IsLink(path, info) {
+			continue
+		}
+		if err := relink(path, moves, create); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relink replaces the link at path when its target is, or is inside, one
+// of the moved directories. Relative targets moved along with the link.
+func relink(path string, moves []move, create func(target, path string) error) error {
+	target, err := os.Readlink(path)
+	if err != nil || !filepath.IsAbs(target) {
+		return nil
+	}
+	for _, m := range moves {
+		if target != m.from && !strings.HasPrefix(target, m.from+string(filepath.Separator)) {
+			continue
+		}
+		if m.from == m.to {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		if err := create(m.to+strings.TrimPrefix(target, m.from), path); err != nil {
+			return fmt.Errorf("failed to relink %s: %w", path, err)
+		}
+		return nil
+	}
+	return nil
+}
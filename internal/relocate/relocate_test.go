@@ -0,0 +1,151 @@
+package relocate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lazyas/internal/config"
+)
+
+func TestMove(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{CacheTTL: config.DefaultCacheTTLHours}
+	cfg.SetHome(filepath.Join(tmp, "home"))
+	backendDir := filepath.Join(tmp, "agent", "skills")
+	cfg.Backends = []config.Backend{
+		{Name: "whole", Path: filepath.Join(tmp, "whole")},
+		{Name: "agent", Path: backendDir, Mode: config.BackendPerSkill},
+	}
+
+	clone := filepath.Join(cfg.ReposDir, "acme-skills", "pdf")
+	if err := os.MkdirAll(clone, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(backendDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		filepath.Join(cfg.SkillsDir, "pdf"): clone,
+		filepath.Join(cfg.SkillsDir, "p"):   "pdf",
+		filepath.Join(tmp, "whole"):         cfg.SkillsDir,
+		filepath.Join(backendDir, "pdf"):    filepath.Join(cfg.SkillsDir, "pdf"),
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	skillsDir := filepath.Join(tmp, "dotfiles", "skills")
+	home := filepath.Join(tmp, "newhome")
+	if err := Move(cfg, Plan{Home: home, SkillsDir: skillsDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		filepath.Join(skillsDir, "pdf"):  filepath.Join(home, "repos", "acme-skills", "pdf"),
+		filepath.Join(skillsDir, "p"):    "pdf",
+		filepath.Join(tmp, "whole"):      skillsDir,
+		filepath.Join(backendDir, "pdf"): filepath.Join(skillsDir, "pdf"),
+	}
+	for link, target := range want {
+		if got, err := os.Readlink(link); err != nil || got != target {
+			t.Errorf("%s -> %q (%v), want %q", link, got, err, target)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(skillsDir, "pdf")); err != nil {
+		t.Errorf("skill does not resolve after the move: %v", err)
+	}
+
+	// The new locations are saved; the repos directory follows the home
+	loaded := &config.Config{}
+	loaded.SetHome(home)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.SkillsDir != skillsDir || loaded.ReposDir != filepath.Join(home, "repos") {
+		t.Errorf("loaded skills %s, repos %s", loaded.SkillsDir, loaded.ReposDir)
+	}
+}
+
+func TestMove_RefusesNonEmptyTarget(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{}
+	cfg.SetHome(filepath.Join(tmp, "home"))
+	if err := os.MkdirAll(cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(tmp, "taken")
+	if err := os.MkdirAll(filepath.Join(target, "x"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Move(cfg, Plan{SkillsDir: target}); err == nil {
+		t.Error("expected a non-empty target to be refused")
+	}
+	if err := Move(cfg, Plan{SkillsDir: filepath.Join(cfg.SkillsDir, "inner")}); err == nil {
+		t.Error("expected a move into itself to be refused")
+	}
+}
+
+func TestMove_RollsBackOnError(t *testing.T) {
+	tmp := t.TempDir()
+	cfg := &config.Config{}
+	oldHome := filepath.Join(tmp, "home")
+	cfg.SetHome(oldHome)
+	clone := filepath.Join(cfg.ReposDir, "acme-skills", "pdf")
+	if err := os.MkdirAll(clone, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(cfg.SkillsDir, "pdf")
+	if err := os.Symlink(clone, link); err != nil {
+		t.Fatal(err)
+	}
+	oldSkills := cfg.SkillsDir
+	cfg.SkillsDir = filepath.Join(tmp, "skills")
+	if err := os.Rename(oldSkills, cfg.SkillsDir); err != nil {
+		t.Fatal(err)
+	}
+	link = filepath.Join(cfg.SkillsDir, "pdf")
+
+	// The home moves, then the repos directory cannot
+	taken := filepath.Join(tmp, "taken")
+	if err := os.MkdirAll(filepath.Join(taken, "x"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Move(cfg, Plan{Home: filepath.Join(tmp, "newhome"), ReposDir: taken}); err == nil {
+		t.Fatal("expected a non-empty target to be refused")
+	}
+	if cfg.ConfigDir != oldHome || cfg.ReposDir != filepath.Join(oldHome, "repos") {
+		t.Errorf("config not restored: home %s, repos %s", cfg.ConfigDir, cfg.ReposDir)
+	}
+	if _, err := os.Stat(clone); err != nil {
+		t.Errorf("home not moved back: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "newhome")); !os.IsNotExist(err) {
+		t.Error("the new home was left behind")
+	}
+	if got, _ := os.Readlink(link); got != clone {
+		t.Errorf("%s -> %q, want %q", link, got, clone)
+	}
+
+	// Saving the config fails after the links were re-pointed
+	if err := os.Mkdir(cfg.ConfigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Move(cfg, Plan{Home: filepath.Join(tmp, "newhome")}); err == nil {
+		t.Fatal("expected the failed save to be reported")
+	}
+	if got, _ := os.Readlink(link); got != clone {
+		t.Errorf("after a failed save: %s -> %q, want %q", link, got, clone)
+	}
+	if _, err := os.Stat(clone); err != nil {
+		t.Errorf("home not moved back after a failed save: %v", err)
+	}
+}
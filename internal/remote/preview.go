@@ -0,0 +1,68 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"lazyas/internal/git"
+)
+
+// PreviewCache stores SKILL.md files fetched for skills that are not installed
+type PreviewCache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// NewPreviewCache creates a cache rooted at dir
+func NewPreviewCache(dir string, ttl time.Duration) *PreviewCache {
+	return &PreviewCache{Dir: dir, TTL: ttl}
+}
+
+// SkillMD returns the SKILL.md of a skill without installing it. GitHub and
+// GitLab repos are fetched over raw HTTP; other hosts fall back to a
+// blob-less git clone that downloads only the one file.
+func (c *PreviewCache) SkillMD(repoURL, ref, skillPath string) (string, error) {
+	filePath := path.Join(skillPath, "SKILL.md")
+	cachePath := c.path(repoURL, ref, filePath)
+
+	if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < c.TTL {
+		if data, err := os.ReadFile(cachePath); err == nil {
+			return string(data), nil
+		}
+	}
+
+	var data []byte
+	var err error
+	if repo, ok := ParseRepo(repoURL); ok {
+		data, err = FetchFile(repo.RawFileURL(ref, filePath))
+	} else {
+		data, err = git.ShowRemoteFile(repoURL, ref, filePath)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err == nil {
+		os.WriteFile(cachePath, data, 0644)
+	}
+	return string(data), nil
+}
+
+// Cached returns a previously fetched SKILL.md, however old, without
+// going to the network
+func (c *PreviewCache) Cached(repoURL, ref, skillPath string) (string, bool) {
+	data, err := os.ReadFile(c.path(repoURL, ref, path.Join(skillPath, "SKILL.md")))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func (c *PreviewCache) path(repoURL, ref, filePath string) string {
+	sum := sha256.Sum256([]byte(repoURL + "\x00" + ref + "\x00" + filePath))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:8])+".md")
+}
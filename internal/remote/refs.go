@@ -0,0 +1,90 @@
+package remote
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxRefsSize caps the ref advertisement read by HeadCommit; HEAD is
+// advertised first, so a large repository's tags are never needed
+const maxRefsSize = 4 << 20
+
+// HeadCommit resolves the default branch of repoURL to a commit over git's
+// smart HTTP protocol, like 'git ls-remote repoURL HEAD' without needing
+// git. scp-like GitHub and GitLab URLs are asked over HTTPS; other
+// non-HTTP URLs are not supported.
+func HeadCommit(repoURL string) (string, error) {
+	base := strings.TrimSuffix(strings.TrimSpace(repoURL), "/")
+	if repo, ok := ParseRepo(repoURL); ok {
+		base = fmt.Sprintf("%s/%s/%s.git", repo.Base, repo.Owner, repo.Name)
+	}
+	u, err := url.Parse(base)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("cannot resolve %s over HTTP", repoURL)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, base+"/info/refs?service=git-upload-pack", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "git/lazyas")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-git-upload-pack-advertisement" {
+		return "", fmt.Errorf("%s does not speak git's smart HTTP protocol", repoURL)
+	}
+	return parseHeadAdvertisement(io.LimitReader(resp.Body, maxRefsSize))
+}
+
+// parseHeadAdvertisement returns the commit of HEAD in a smart HTTP ref
+// advertisement: pkt-lines, each a 4-digit hex length (including itself)
+// and a payload, with "0000" flushing a section. Ref lines are
+// "<sha> <ref>", the first one followed by a NUL and the capabilities.
+func parseHeadAdvertisement(r io.Reader) (string, error) {
+	br := bufio.NewReader(r)
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(br, size[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", errors.New("HEAD not advertised (empty repository?)")
+			}
+			return "", fmt.Errorf("failed to read refs: %w", err)
+		}
+		n, err := strconv.ParseUint(string(size[:]), 16, 16)
+		if err != nil {
+			return "", fmt.Errorf("malformed pkt-line length %q", size[:])
+		}
+		if n == 0 {
+			continue // flush
+		}
+		if n < 4 {
+			return "", fmt.Errorf("malformed pkt-line length %q", size[:])
+		}
+		payload := make([]byte, n-4)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return "", fmt.Errorf("failed to read refs: %w", err)
+		}
+
+		line, _, _ := strings.Cut(strings.TrimSuffix(string(payload), "\n"), "\x00")
+		if msg, ok := strings.CutPrefix(line, "ERR "); ok {
+			return "", fmt.Errorf("server error: %s", msg)
+		}
+		// The "# service=git-upload-pack" header matches no ref
+		sha, ref, ok := strings.Cut(line, " ")
+		if ok && ref == "HEAD" && len(sha) >= 40 {
+			return sha, nil
+		}
+	}
+}
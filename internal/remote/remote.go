@@ -0,0 +1,220 @@
+package remote
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Host identifies a git hosting service with an HTTP API
+type Host int
+
+const (
+	HostUnknown Host = iota
+	HostGitHub
+	HostGitLab
+)
+
+// MaxFileSize caps single-file downloads (SKILL.md, index.yaml, ...)
+const MaxFileSize = 2 << 20
+
+// httpClient is shared by all remote requests
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// Repo is a repository parsed from its clone URL
+type Repo struct {
+	Host  Host
+	Base  string // scheme + host, e.g. "https://github.com"
+	Owner string // GitHub owner or GitLab namespace (may contain "/")
+	Name  string
+}
+
+// ParseRepo recognizes GitHub and GitLab clone URLs
+// ("https://github.com/org/repo(.git)", "git@github.com:org/repo.git").
+// Returns false for other hosts.
+func ParseRepo(repoURL string) (Repo, bool) {
+	s := strings.TrimSpace(repoURL)
+	if strings.HasPrefix(s, "git@") {
+		// scp-like syntax: git@host:owner/repo.git
+		s = "https://" + strings.Replace(strings.TrimPrefix(s, "git@"), ":", "/", 1)
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return Repo{}, false
+	}
+
+	var host Host
+	switch strings.ToLower(u.Host) {
+	case "github.com", "www.github.com":
+		host = HostGitHub
+	case "gitlab.com", "www.gitlab.com":
+		host = HostGitLab
+	default:
+		return Repo{}, false
+	}
+
+	p := strings.Trim(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), "/")
+	idx := strings.LastIndex(p, "/")
+	if idx <= 0 {
+		return Repo{}, false
+	}
+	owner, name := p[:idx], p[idx+1:]
+	if host == HostGitHub && strings.Contains(owner, "/") {
+		return Repo{}, false
+	}
+
+	return Repo{
+		Host:  host,
+		Base:  "https://" + strings.TrimPrefix(strings.ToLower(u.Host), "www."),
+		Owner: owner,
+		Name:  name,
+	}, true
+}
+
+// RawFileURL returns the URL serving a file's raw contents at ref.
+// An empty ref means the default branch.
+func (r Repo) RawFileURL(ref, filePath string) string {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	filePath = strings.TrimPrefix(filePath, "/")
+	switch r.Host {
+	case HostGitHub:
+		return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", r.Owner, r.Name, ref, filePath)
+	case HostGitLab:
+		return fmt.Sprintf("%s/%s/%s/-/raw/%s/%s", r.Base, r.Owner, r.Name, ref, filePath)
+	}
+	return ""
+}
+
+// PullRequestURL returns the page that opens a pull (merge) request from
+// branch into base. headOwner is the fork owner; empty means the branch was
+// pushed to this repository.
+func (r Repo) PullRequestURL(base, headOwner, branch string) string {
+	switch r.Host {
+	case HostGitHub:
+		head := branch
+		if headOwner != "" && headOwner != r.Owner {
+			head = headOwner + ":" + branch
+		}
+		return fmt.Sprintf("%s/%s/%s/compare/%s...%s?expand=1", r.Base, r.Owner, r.Name, base, head)
+	case HostGitLab:
+		project := r.Owner + "/" + r.Name
+		if headOwner != "" && headOwner != r.Owner {
+			project = headOwner + "/" + r.Name
+		}
+		q := url.Values{}
+		q.Set("merge_request[source_branch]", branch)
+		q.Set("merge_request[target_branch]", base)
+		return fmt.Sprintf("%s/%s/-/merge_requests/new?%s", r.Base, project, q.Encode())
+	}
+	return ""
+}
+
+// TreeURL returns the web page browsing dir at ref. An empty ref means the
+// default branch.
+func (r Repo) TreeURL(ref, dir string) string {
+	base := fmt.Sprintf("%s/%s/%s", r.Base, r.Owner, r.Name)
+	dir = strings.Trim(dir, "/")
+	if ref == "" && dir == "" {
+		return base
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+	sep := "/tree/"
+	if r.Host == HostGitLab {
+		sep = "/-/tree/"
+	}
+	return strings.TrimSuffix(base+sep+ref+"/"+dir, "/")
+}
+
+// WebURL returns a browsable page for a repository at ref, falling back to
+// the clone URL without ".git" for hosts other than GitHub and GitLab
+func WebURL(repoURL, ref, dir string) string {
+	if r, ok := ParseRepo(repoURL); ok {
+		return r.TreeURL(ref, dir)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+}
+
+// CloneURL recognizes a GitHub or GitLab repository in text such as a
+// pasted browser address ("github.com/org/repo/tree/main/skills/pdf") and
+// returns its clone URL, "https://github.com/org/repo". Returns false for
+// anything else, including text with spaces.
+func CloneURL(text string) (string, bool) {
+	s := strings.TrimSpace(text)
+	if s == "" || strings.ContainsAny(s, " \t\n") {
+		return "", false
+	}
+	if !strings.Contains(s, "://") && !strings.HasPrefix(s, "git@") {
+		s = "https://" + s
+	}
+	if strings.HasPrefix(s, "git@") {
+		s = "https://" + strings.Replace(strings.TrimPrefix(s, "git@"), ":", "/", 1)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", false
+	}
+
+	// Drop the page within the repo: GitHub repos are owner/name, GitLab
+	// pages start after "/-/"
+	p := strings.Trim(u.Path, "/")
+	switch strings.ToLower(strings.TrimPrefix(u.Host, "www.")) {
+	case "github.com":
+		if parts := strings.SplitN(p, "/", 3); len(parts) >= 2 {
+			p = parts[0] + "/" + parts[1]
+		}
+	case "gitlab.com":
+		p, _, _ = strings.Cut(p, "/-/")
+	}
+	u.Path = "/" + p
+	r, ok := ParseRepo(u.Scheme + "://" + u.Host + u.Path)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s/%s", r.Base, r.Owner, r.Name), true
+}
+
+// OpenBrowser opens url with the platform's default handler
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// FetchFile downloads a single file over HTTP. Returns an error for
+// non-200 responses and files larger than MaxFileSize.
+func FetchFile(fileURL string) ([]byte, error) {
+	resp, err := httpClient.Get(fileURL)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", fileURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", MaxFileSize)
+	}
+	return data, nil
+}
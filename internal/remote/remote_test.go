@@ -0,0 +1,200 @@
+package remote
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRepo(t *testing.T) {
+	cases := []struct {
+		url   string
+		ok    bool
+		host  Host
+		owner string
+		name  string
+	}{
+		{"https://github.com/anthropics/skills", true, HostGitHub, "anthropics", "skills"},
+		{"https://github.com/anthropics/skills.git", true, HostGitHub, "anthropics", "skills"},
+		{"git@github.com:vercel-labs/agent-skills.git", true, HostGitHub, "vercel-labs", "agent-skills"},
+		{"https://gitlab.com/group/sub/repo", true, HostGitLab, "group/sub", "repo"},
+		{"https://example.com/org/repo", false, HostUnknown, "", ""},
+		{"https://github.com/just-owner", false, HostUnknown, "", ""},
+		{"/home/me/skills", false, HostUnknown, "", ""},
+	}
+	for _, c := range cases {
+		r, ok := ParseRepo(c.url)
+		if ok != c.ok {
+			t.Errorf("ParseRepo(%q) ok = %v, want %v", c.url, ok, c.ok)
+			continue
+		}
+		if ok && (r.Host != c.host || r.Owner != c.owner || r.Name != c.name) {
+			t.Errorf("ParseRepo(%q) = %+v", c.url, r)
+		}
+	}
+}
+
+func TestRawFileURL(t *testing.T) {
+	gh, _ := ParseRepo("https://github.com/anthropics/skills")
+	if got, want := gh.RawFileURL("", "skills/pdf/SKILL.md"), "https://raw.githubusercontent.com/anthropics/skills/HEAD/skills/pdf/SKILL.md"; got != want {
+		t.Errorf("github raw = %q, want %q", got, want)
+	}
+
+	gl, _ := ParseRepo("https://gitlab.com/group/repo")
+	if got, want := gl.RawFileURL("v1.0.0", "/SKILL.md"), "https://gitlab.com/group/repo/-/raw/v1.0.0/SKILL.md"; got != want {
+		t.Errorf("gitlab raw = %q, want %q", got, want)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	cases := map[int64]string{
+		0:               "0 B",
+		512:             "512 B",
+		1536:            "1.5 KB",
+		5 * 1024 * 1024: "5.0 MB",
+		3 << 30:         "3.0 GB",
+	}
+	for in, want := range cases {
+		if got := FormatSize(in); got != want {
+			t.Errorf("FormatSize(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPullRequestURL(t *testing.T) {
+	gh, _ := ParseRepo("https://github.com/anthropics/skills")
+	if got, want := gh.PullRequestURL("main", "me", "lazyas/pdf"), "https://github.com/anthropics/skills/compare/main...me:lazyas/pdf?expand=1"; got != want {
+		t.Errorf("github fork PR = %q, want %q", got, want)
+	}
+	if got, want := gh.PullRequestURL("main", "", "fix"), "https://github.com/anthropics/skills/compare/main...fix?expand=1"; got != want {
+		t.Errorf("github same-repo PR = %q, want %q", got, want)
+	}
+
+	gl, _ := ParseRepo("https://gitlab.com/group/repo")
+	want := "https://gitlab.com/me/repo/-/merge_requests/new?merge_request%5Bsource_branch%5D=fix&merge_request%5Btarget_branch%5D=main"
+	if got := gl.PullRequestURL("main", "me", "fix"); got != want {
+		t.Errorf("gitlab MR = %q, want %q", got, want)
+	}
+}
+
+func TestWebURL(t *testing.T) {
+	cases := []struct{ repo, ref, dir, want string }{
+		{"https://github.com/anthropics/skills.git", "abc123", "skills/pdf", "https://github.com/anthropics/skills/tree/abc123/skills/pdf"},
+		{"https://github.com/anthropics/skills", "", "", "https://github.com/anthropics/skills"},
+		{"https://gitlab.com/group/repo", "v1.0.0", "", "https://gitlab.com/group/repo/-/tree/v1.0.0"},
+		{"https://git.example.com/team/skills.git", "abc123", "pdf", "https://git.example.com/team/skills"},
+	}
+	for _, c := range cases {
+		if got := WebURL(c.repo, c.ref, c.dir); got != c.want {
+			t.Errorf("WebURL(%q, %q, %q) = %q, want %q", c.repo, c.ref, c.dir, got, c.want)
+		}
+	}
+}
+
+func TestCloneURL(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+		ok   bool
+	}{
+		{"https://github.com/anthropics/skills", "https://github.com/anthropics/skills", true},
+		{"  https://github.com/anthropics/skills.git\n", "https://github.com/anthropics/skills", true},
+		{"https://github.com/anthropics/skills/tree/main/skills/pdf", "https://github.com/anthropics/skills", true},
+		{"github.com/vercel-labs/agent-skills", "https://github.com/vercel-labs/agent-skills", true},
+		{"git@github.com:vercel-labs/agent-skills.git", "https://github.com/vercel-labs/agent-skills", true},
+		{"https://gitlab.com/group/sub/repo/-/tree/main", "https://gitlab.com/group/sub/repo", true},
+		{"https://github.com/just-owner", "", false},
+		{"https://example.com/org/repo", "", false},
+		{"see https://github.com/a/b", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		got, ok := CloneURL(c.text)
+		if ok != c.ok || got != c.want {
+			t.Errorf("CloneURL(%q) = %q, %v; want %q, %v", c.text, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestHeadCommit(t *testing.T) {
+	head := strings.Repeat("a", 40)
+	pkt := func(s string) string { return fmt.Sprintf("%04x%s", len(s)+4, s) }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/skills.git/info/refs" || r.URL.Query().Get("service") != "git-upload-pack" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+		io.WriteString(w, pkt("# service=git-upload-pack\n")+"0000"+
+			pkt(head+" HEAD\x00multi_ack symref=HEAD:refs/heads/main\n")+
+			pkt(strings.Repeat("b", 40)+" refs/heads/dev\n")+
+			pkt(head+" refs/heads/main\n")+"0000")
+	}))
+	defer server.Close()
+
+	got, err := HeadCommit(server.URL + "/skills.git/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != head {
+		t.Errorf("HeadCommit = %q, want %q", got, head)
+	}
+
+	if _, err := HeadCommit(server.URL + "/missing.git"); err == nil {
+		t.Error("expected an error for a missing repository")
+	}
+	if _, err := HeadCommit("/home/me/skills"); err == nil {
+		t.Error("expected an error for a local path")
+	}
+}
+
+func TestTreeFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github":
+			io.WriteString(w, `{"tree": [
+				{"path": "skills", "type": "tree"},
+				{"path": "skills/pdf/SKILL.md", "type": "blob"},
+				{"path": "README.md", "type": "blob"}
+			]}`)
+		case "/truncated":
+			io.WriteString(w, `{"tree": [], "truncated": true}`)
+		case "/gitlab":
+			// A full first page, then the rest
+			var entries []string
+			if r.URL.Query().Get("page") == "1" {
+				for i := range treePageSize {
+					entries = append(entries, fmt.Sprintf(`{"path": "f%d", "type": "blob"}`, i))
+				}
+			} else {
+				entries = append(entries, `{"path": "docs", "type": "tree"}`, `{"path": "docs/SKILL.md", "type": "blob"}`)
+			}
+			io.WriteString(w, "["+strings.Join(entries, ",")+"]")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	files, err := githubTreeFiles(server.URL + "/github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(files) != "[skills/pdf/SKILL.md README.md]" {
+		t.Errorf("GitHub files = %v", files)
+	}
+	if _, err := githubTreeFiles(server.URL + "/truncated"); err == nil {
+		t.Error("expected an error for a truncated tree")
+	}
+
+	files, err = gitlabTreeFiles(server.URL + "/gitlab?recursive=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != treePageSize+1 || files[len(files)-1] != "docs/SKILL.md" {
+		t.Errorf("GitLab files = %d, last %q", len(files), files[len(files)-1])
+	}
+}
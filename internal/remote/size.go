@@ -0,0 +1,123 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// SizeEstimate is the expected footprint of installing a skill
+type SizeEstimate struct {
+	SkillBytes    int64 // size of the skill's files; 0 = unknown
+	DownloadBytes int64 // repository clone size on first install from a repo; 0 = unknown or already cloned
+}
+
+// Known reports whether any size information is available
+func (e SizeEstimate) Known() bool {
+	return e.SkillBytes > 0 || e.DownloadBytes > 0
+}
+
+// EstimateSize estimates install size. indexBytes is the size recorded in
+// the registry index (0 when absent); GitHub repos fill in the gaps via the
+// API. repoCloned skips the download estimate when the repo clone exists.
+func EstimateSize(repoURL, ref, skillPath string, indexBytes int64, repoCloned bool) SizeEstimate {
+	est := SizeEstimate{SkillBytes: indexBytes}
+
+	repo, ok := ParseRepo(repoURL)
+	if !ok || repo.Host != HostGitHub {
+		return est
+	}
+
+	if est.SkillBytes == 0 {
+		if n, err := githubTreeSize(repo, ref, skillPath); err == nil {
+			est.SkillBytes = n
+		}
+	}
+	if !repoCloned {
+		if n, err := githubRepoSize(repo); err == nil {
+			est.DownloadBytes = n
+		}
+	}
+	return est
+}
+
+// githubTreeSize sums blob sizes under skillPath using the git trees API
+func githubTreeSize(repo Repo, ref, skillPath string) (int64, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	var tree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+			Size int64  `json:"size"`
+		} `json:"tree"`
+	}
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees/%s?recursive=1", repo.Owner, repo.Name, ref)
+	if err := getJSON(apiURL, &tree); err != nil {
+		return 0, err
+	}
+
+	prefix := strings.Trim(skillPath, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	var total int64
+	for _, e := range tree.Tree {
+		if e.Type == "blob" && strings.HasPrefix(e.Path, prefix) {
+			total += e.Size
+		}
+	}
+	return total, nil
+}
+
+// githubRepoSize returns the repository size reported by GitHub
+func githubRepoSize(repo Repo) (int64, error) {
+	var info struct {
+		Size int64 `json:"size"` // kilobytes
+	}
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s", repo.Owner, repo.Name)
+	if err := getJSON(apiURL, &info); err != nil {
+		return 0, err
+	}
+	return info.Size * 1024, nil
+}
+
+// getJSON performs an API GET, authenticating with $GITHUB_TOKEN when set
+func getJSON(apiURL string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && strings.HasPrefix(apiURL, "https://api.github.com/") {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", apiURL, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// FormatSize renders a byte count for humans ("512 B", "1.4 MB")
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
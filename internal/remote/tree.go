@@ -0,0 +1,76 @@
+package remote
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// GitLab lists trees in pages; maxTreePages caps how many are read
+const (
+	treePageSize = 100
+	maxTreePages = 100
+)
+
+// TreeFiles lists the paths of the files in a GitHub or GitLab repository
+// at ref through the host's API, which needs no git. An empty ref means
+// the default branch.
+func TreeFiles(repo Repo, ref string) ([]string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	switch repo.Host {
+	case HostGitHub:
+		return githubTreeFiles(fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees/%s?recursive=1", repo.Owner, repo.Name, ref))
+	case HostGitLab:
+		project := url.PathEscape(repo.Owner + "/" + repo.Name)
+		return gitlabTreeFiles(fmt.Sprintf("%s/api/v4/projects/%s/repository/tree?recursive=true&per_page=%d&ref=%s", repo.Base, project, treePageSize, url.QueryEscape(ref)))
+	}
+	return nil, fmt.Errorf("listing files needs a GitHub or GitLab repository")
+}
+
+// githubTreeFiles reads a recursive listing of the git trees API
+func githubTreeFiles(apiURL string) ([]string, error) {
+	var tree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := getJSON(apiURL, &tree); err != nil {
+		return nil, err
+	}
+	if tree.Truncated {
+		return nil, fmt.Errorf("the repository is too large to list through the API")
+	}
+	var files []string
+	for _, e := range tree.Tree {
+		if e.Type == "blob" {
+			files = append(files, e.Path)
+		}
+	}
+	return files, nil
+}
+
+// gitlabTreeFiles reads the pages of a recursive repository tree listing
+func gitlabTreeFiles(apiURL string) ([]string, error) {
+	var files []string
+	for page := 1; page <= maxTreePages; page++ {
+		var entries []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		}
+		if err := getJSON(fmt.Sprintf("%s&page=%d", apiURL, page), &entries); err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.Type == "blob" {
+				files = append(files, e.Path)
+			}
+		}
+		if len(entries) < treePageSize {
+			return files, nil
+		}
+	}
+	return nil, fmt.Errorf("the repository is too large to list through the API")
+}
@@ -0,0 +1,252 @@
+package scan
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Level is the risk a finding (or a whole skill) carries
+type Level int
+
+const (
+	LevelNone Level = iota
+	LevelLow
+	LevelMedium
+	LevelHigh
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelLow:
+		return "low"
+	case LevelMedium:
+		return "medium"
+	case LevelHigh:
+		return "high"
+	default:
+		return "none"
+	}
+}
+
+// Finding kinds
+const (
+	KindScript      = "script"
+	KindExecutable  = "executable"
+	KindBinary      = "binary"
+	KindPipeToShell = "download piped to shell"
+	KindEncodedBlob = "base64 blob"
+)
+
+// maxScanBytes bounds how much of each file is read for pattern matching
+const maxScanBytes = 1 << 20
+
+var scriptExts = map[string]bool{
+	".sh": true, ".bash": true, ".zsh": true, ".fish": true, ".ps1": true,
+	".py": true, ".js": true, ".mjs": true, ".ts": true, ".rb": true, ".pl": true,
+}
+
+var (
+	pipeToShell = regexp.MustCompile(`(?:curl|wget|base64\s+(?:-d|--decode))\b[^\n|]*\|\s*(?:sudo\s+)?(?:ba|z|da)?sh\b|\$\((?:curl|wget)\b`)
+	encodedBlob = regexp.MustCompile(`[A-Za-z0-9+/]{200,}={0,2}`)
+)
+
+// Finding is one noteworthy file in a skill
+type Finding struct {
+	Path  string // relative to the skill root
+	Kind  string
+	Level Level
+}
+
+// Report is the result of scanning a skill tree
+type Report struct {
+	Findings []Finding
+}
+
+// Level returns the highest risk among the findings
+func (r *Report) Level() Level {
+	level := LevelNone
+	for _, f := range r.Findings {
+		if f.Level > level {
+			level = f.Level
+		}
+	}
+	return level
+}
+
+// Summary is a one-line description, e.g. "high risk: script ×2, binary ×1"
+func (r *Report) Summary() string {
+	if len(r.Findings) == 0 {
+		return "no scripts or binaries"
+	}
+
+	counts := make(map[string]int)
+	var kinds []string
+	for _, f := range r.Findings {
+		if counts[f.Kind] == 0 {
+			kinds = append(kinds, f.Kind)
+		}
+		counts[f.Kind]++
+	}
+
+	parts := make([]string, len(kinds))
+	for i, k := range kinds {
+		parts[i] = fmt.Sprintf("%s ×%d", k, counts[k])
+	}
+	return fmt.Sprintf("%s risk: %s", r.Level(), strings.Join(parts, ", "))
+}
+
+// Dir scans a skill directory (following a top-level symlink) for scripts,
+// executables, native binaries and suspicious patterns. .git is skipped.
+func Dir(dir string) (*Report, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		findings, err := scanFile(path, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		report.Findings = append(report.Findings, findings...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Level > report.Findings[j].Level
+	})
+	return report, nil
+}
+
+func scanFile(path, rel string) ([]Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	content, err := io.ReadAll(io.LimitReader(f, maxScanBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	add := func(kind string, level Level) {
+		findings = append(findings, Finding{Path: rel, Kind: kind, Level: level})
+	}
+
+	if isNativeBinary(content) {
+		add(KindBinary, LevelHigh)
+		return findings, nil
+	}
+
+	executable := info.Mode()&0111 != 0
+	script := scriptExts[strings.ToLower(filepath.Ext(rel))] || bytes.HasPrefix(content, []byte("#!"))
+	switch {
+	case script:
+		add(KindScript, LevelLow)
+	case executable:
+		add(KindExecutable, LevelMedium)
+	}
+
+	// Pattern checks only make sense on text
+	if bytes.IndexByte(content, 0) != -1 {
+		return findings, nil
+	}
+	if pipeToShell.Match(content) {
+		add(KindPipeToShell, LevelHigh)
+	}
+	if encodedBlob.Match(content) {
+		add(KindEncodedBlob, LevelMedium)
+	}
+	return findings, nil
+}
+
+// isNativeBinary recognizes ELF, Mach-O and PE executables by magic number
+func isNativeBinary(b []byte) bool {
+	magics := [][]byte{
+		{0x7f, 'E', 'L', 'F'},
+		{0xfe, 0xed, 0xfa, 0xce}, {0xfe, 0xed, 0xfa, 0xcf},
+		{0xce, 0xfa, 0xed, 0xfe}, {0xcf, 0xfa, 0xed, 0xfe},
+		{0xca, 0xfe, 0xba, 0xbe},
+		{'M', 'Z'},
+	}
+	for _, m := range magics {
+		if bytes.HasPrefix(b, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy decides which risk levels block an install
+type Policy string
+
+const (
+	PolicyAllow       Policy = "allow"        // report only (default)
+	PolicyBlockHigh   Policy = "block-high"   // refuse high-risk skills
+	PolicyBlockMedium Policy = "block-medium" // refuse medium and high
+)
+
+// ParsePolicy validates a risk policy name; "" means PolicyAllow
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return PolicyAllow, nil
+	case PolicyAllow, PolicyBlockHigh, PolicyBlockMedium:
+		return p, nil
+	}
+	return "", fmt.Errorf("invalid risk policy %q (expected allow, block-high or block-medium)", s)
+}
+
+// Blocks reports whether a skill at the given level may not be installed
+func (p Policy) Blocks(level Level) bool {
+	switch p {
+	case PolicyBlockHigh:
+		return level >= LevelHigh
+	case PolicyBlockMedium:
+		return level >= LevelMedium
+	default:
+		return false
+	}
+}
+
+// Check scans dir and returns an error when the policy blocks it. The
+// report is returned either way so callers can show it.
+func Check(dir string, policy Policy) (*Report, error) {
+	report, err := Dir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan skill: %w", err)
+	}
+	if policy.Blocks(report.Level()) {
+		return report, fmt.Errorf("blocked by risk_policy %s (%s)", policy, report.Summary())
+	}
+	return report, nil
+}
@@ -0,0 +1,188 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version ("v1.2.3", "1.2.3-rc.1")
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Pre   string // pre-release suffix without the leading "-"
+}
+
+// Parse parses a semver-like tag. A leading "v" is optional and missing
+// minor/patch components default to zero ("v2" == "v2.0.0"). Build metadata
+// ("+build") is ignored, as are monorepo tag prefixes ("skills/pdf/v1.0.0",
+// "pdf@1.0.0", "pdf-v1.0.0"). Returns false when s is not a version.
+func Parse(s string) (Version, bool) {
+	s = strings.TrimPrefix(stripTagPrefix(strings.TrimSpace(s)), "v")
+	if s == "" {
+		return Version{}, false
+	}
+	if idx := strings.Index(s, "+"); idx != -1 {
+		s = s[:idx]
+	}
+
+	var v Version
+	if idx := strings.Index(s, "-"); idx != -1 {
+		v.Pre = s[idx+1:]
+		s = s[:idx]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, false
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, false
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, true
+}
+
+// stripTagPrefix removes a component prefix from a tag name
+func stripTagPrefix(s string) string {
+	if idx := strings.LastIndexAny(s, "/@"); idx != -1 {
+		s = s[idx+1:]
+	}
+	startsNumeric := func(t string) bool {
+		t = strings.TrimPrefix(t, "v")
+		return t != "" && t[0] >= '0' && t[0] <= '9'
+	}
+	if !startsNumeric(s) {
+		if idx := strings.Index(s, "-v"); idx != -1 && startsNumeric(s[idx+1:]) {
+			s = s[idx+1:]
+		}
+	}
+	return s
+}
+
+// String formats the version with a leading "v"
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 when a is older than, equal to or newer than b.
+// A pre-release sorts before its release (v1.0.0-rc.1 < v1.0.0).
+func Compare(a, b Version) int {
+	for _, d := range []int{a.Major - b.Major, a.Minor - b.Minor, a.Patch - b.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	switch {
+	case a.Pre == b.Pre:
+		return 0
+	case a.Pre == "":
+		return 1
+	case b.Pre == "":
+		return -1
+	case a.Pre < b.Pre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// SortDesc orders tags newest first. Semver tags come before other tags,
+// which keep their relative order.
+func SortDesc(tags []string) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		a, okA := Parse(tags[i])
+		b, okB := Parse(tags[j])
+		if okA && okB {
+			return Compare(a, b) > 0
+		}
+		return okA && !okB
+	})
+}
+
+// Policy limits how far an update may move a semver-tagged skill
+type Policy string
+
+const (
+	PolicyPatch Policy = "patch" // only x.y.Z changes
+	PolicyMinor Policy = "minor" // x.Y.z changes, never a new major
+	PolicyMajor Policy = "major" // any newer version (default)
+)
+
+// DefaultPolicy applies when neither the skill nor the config sets one
+const DefaultPolicy = PolicyMajor
+
+// ParsePolicy validates a policy name
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(strings.ToLower(strings.TrimSpace(s))); p {
+	case PolicyPatch, PolicyMinor, PolicyMajor:
+		return p, nil
+	}
+	return "", fmt.Errorf("invalid update policy %q (expected patch, minor or major)", s)
+}
+
+// EffectivePolicy returns the first valid policy among the candidates
+// (per-skill setting first, then global), or DefaultPolicy.
+func EffectivePolicy(candidates ...string) Policy {
+	for _, c := range candidates {
+		if p, err := ParsePolicy(c); err == nil {
+			return p
+		}
+	}
+	return DefaultPolicy
+}
+
+// Allows reports whether moving from one ref to another is permitted by the
+// policy. Refs that are not semver (branches, "latest", commits) and
+// downgrades are always allowed; the policy only guards upward jumps.
+func (p Policy) Allows(from, to string) bool {
+	a, okA := Parse(from)
+	b, okB := Parse(to)
+	if !okA || !okB || Compare(b, a) <= 0 {
+		return true
+	}
+	switch p {
+	case PolicyPatch:
+		return a.Major == b.Major && a.Minor == b.Minor
+	case PolicyMinor:
+		return a.Major == b.Major
+	default:
+		return true
+	}
+}
+
+// MatchesPin reports whether ref satisfies a version pin. A full pin
+// ("v1.2.0") matches that version under any tag prefix, a non-semver tag or
+// a commit only itself. A partial pin matches the releases it names: "v1"
+// any v1.x.y and "v1.2" any v1.2.y, but no pre-releases.
+func MatchesPin(pin, ref string) bool {
+	if pin == "" || pin == ref {
+		return true
+	}
+	p, okP := Parse(pin)
+	r, okR := Parse(ref)
+	if !okP || !okR {
+		return false
+	}
+	switch strings.Count(strings.TrimPrefix(stripTagPrefix(pin), "v"), ".") {
+	case 0:
+		return r.Pre == "" && r.Major == p.Major
+	case 1:
+		return r.Pre == "" && r.Major == p.Major && r.Minor == p.Minor
+	}
+	return Compare(p, r) == 0
+}
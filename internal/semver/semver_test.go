@@ -0,0 +1,125 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Version
+		ok   bool
+	}{
+		{"v1.2.3", Version{1, 2, 3, ""}, true},
+		{"1.2.3", Version{1, 2, 3, ""}, true},
+		{"v2", Version{2, 0, 0, ""}, true},
+		{"v1.4", Version{1, 4, 0, ""}, true},
+		{"v1.0.0-rc.1", Version{1, 0, 0, "rc.1"}, true},
+		{"v1.0.0+build.5", Version{1, 0, 0, ""}, true},
+		{"main", Version{}, false},
+		{"", Version{}, false},
+		{"v1.2.3.4", Version{}, false},
+		{"latest", Version{}, false},
+		{"skills/pdf/v1.2.0", Version{1, 2, 0, ""}, true},
+		{"pdf@1.3.0", Version{1, 3, 0, ""}, true},
+		{"pdf-v2.0.0-rc.1", Version{2, 0, 0, "rc.1"}, true},
+	}
+	for _, c := range cases {
+		got, ok := Parse(c.in)
+		if ok != c.ok || got != c.want {
+			t.Errorf("Parse(%q) = %+v, %v; want %+v, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "v1.0.1", -1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v2.0.0", "v1.99.99", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-beta", "v1.0.0-alpha", 1},
+	}
+	for _, c := range cases {
+		a, _ := Parse(c.a)
+		b, _ := Parse(c.b)
+		if got := Compare(a, b); got != c.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestSortDesc(t *testing.T) {
+	tags := []string{"v1.0.0", "nightly", "v1.10.0", "v1.2.0", "v2.0.0-rc.1", "v2.0.0"}
+	SortDesc(tags)
+	want := []string{"v2.0.0", "v2.0.0-rc.1", "v1.10.0", "v1.2.0", "v1.0.0", "nightly"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("SortDesc = %v, want %v", tags, want)
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	cases := []struct {
+		policy   Policy
+		from, to string
+		want     bool
+	}{
+		{PolicyPatch, "v1.2.3", "v1.2.4", true},
+		{PolicyPatch, "v1.2.3", "v1.3.0", false},
+		{PolicyMinor, "v1.2.3", "v1.3.0", true},
+		{PolicyMinor, "v1.2.3", "v2.0.0", false},
+		{PolicyMajor, "v1.2.3", "v2.0.0", true},
+		// Downgrades and non-semver refs are never blocked
+		{PolicyPatch, "v2.0.0", "v1.0.0", true},
+		{PolicyPatch, "main", "v9.0.0", true},
+		{PolicyPatch, "v1.0.0", "", true},
+	}
+	for _, c := range cases {
+		if got := c.policy.Allows(c.from, c.to); got != c.want {
+			t.Errorf("%s.Allows(%q, %q) = %v, want %v", c.policy, c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestEffectivePolicy(t *testing.T) {
+	if got := EffectivePolicy("", "minor"); got != PolicyMinor {
+		t.Errorf("EffectivePolicy falls back to global: got %s", got)
+	}
+	if got := EffectivePolicy("patch", "minor"); got != PolicyPatch {
+		t.Errorf("EffectivePolicy prefers skill setting: got %s", got)
+	}
+	if got := EffectivePolicy("", "bogus"); got != DefaultPolicy {
+		t.Errorf("EffectivePolicy ignores invalid values: got %s", got)
+	}
+}
+
+func TestMatchesPin(t *testing.T) {
+	cases := []struct {
+		pin, ref string
+		want     bool
+	}{
+		{"", "v2.0.0", true},
+		{"v1.2.0", "v1.2.0", true},
+		{"v1.2.0", "v1.2.1", false},
+		{"v1.2.0", "pdf/v1.2.0", true},
+		{"v1.0.0-rc.1", "v1.0.0-rc.1", true},
+		{"v1.2", "v1.2.7", true},
+		{"v1.2", "v1.3.0", false},
+		{"v1", "v1.9.0", true},
+		{"v1", "v2.0.0", false},
+		{"v1", "v1.5.0-rc.1", false},
+		{"v1", "", false},
+		{"abc1234", "abc1234", true},
+		{"abc1234", "v1.0.0", false},
+	}
+	for _, c := range cases {
+		if got := MatchesPin(c.pin, c.ref); got != c.want {
+			t.Errorf("MatchesPin(%q, %q) = %v, want %v", c.pin, c.ref, got, c.want)
+		}
+	}
+}
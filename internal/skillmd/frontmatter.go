@@ -0,0 +1,96 @@
+package skillmd
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Frontmatter is the YAML header of a SKILL.md
+type Frontmatter struct {
+	Name         string   `yaml:"name"`
+	Description  string   `yaml:"description"`
+	Dependencies []string `yaml:"dependencies"` // names of skills this skill needs installed
+	Author       string   `yaml:"author"`
+	Tags         List     `yaml:"tags"`
+	// Metadata holds free-form fields; author and tags are also read
+	// from here, as the Agent Skills spec nests them under metadata
+	Metadata map[string]any `yaml:"metadata"`
+}
+
+// List is a YAML list of strings that also accepts a single
+// comma-separated string ("tags: pdf, forms")
+type List []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (l *List) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = splitList(node.Value)
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+func splitList(s string) List {
+	var out List
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// SkillAuthor returns the author field, falling back to metadata.author
+func (fm Frontmatter) SkillAuthor() string {
+	if fm.Author != "" {
+		return fm.Author
+	}
+	author, _ := fm.Metadata["author"].(string)
+	return author
+}
+
+// SkillTags returns the tags field, falling back to metadata.tags
+func (fm Frontmatter) SkillTags() []string {
+	if len(fm.Tags) > 0 {
+		return fm.Tags
+	}
+	switch tags := fm.Metadata["tags"].(type) {
+	case string:
+		return splitList(tags)
+	case []any:
+		var out []string
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// ParseFrontmatter reads the YAML header between the leading "---" markers.
+// Content without a header yields an empty Frontmatter.
+func ParseFrontmatter(content string) (Frontmatter, error) {
+	var fm Frontmatter
+	lines := SplitLines(content)
+	if len(lines) == 0 || TrimSpace(lines[0]) != "---" {
+		return fm, nil
+	}
+	for i := 1; i < len(lines); i++ {
+		if TrimSpace(lines[i]) == "---" {
+			if err := yaml.Unmarshal([]byte(strings.Join(lines[1:i], "\n")), &fm); err != nil {
+				return fm, fmt.Errorf("invalid frontmatter: %w", err)
+			}
+			return fm, nil
+		}
+	}
+	return fm, fmt.Errorf("invalid frontmatter: missing closing ---")
+}
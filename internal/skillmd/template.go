@@ -0,0 +1,41 @@
+package skillmd
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TodoDescription stands in for a description the author has yet to write
+const TodoDescription = "TODO: what this skill does and when to use it"
+
+// Template returns a starter SKILL.md with name, description and tags in
+// its frontmatter. An empty description leaves a placeholder to fill in.
+func Template(name, description string, tags []string) string {
+	if description == "" {
+		description = TodoDescription
+	}
+	header := struct {
+		Name        string   `yaml:"name"`
+		Description string   `yaml:"description"`
+		Tags        []string `yaml:"tags,omitempty,flow"`
+	}{name, description, tags}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	enc.Encode(header)
+	enc.Close()
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.Write(buf.Bytes())
+	b.WriteString("---\n\n")
+	b.WriteString("# " + name + "\n\n")
+	b.WriteString("## When to use\n\n")
+	b.WriteString("Describe the tasks or requests this skill should be used for.\n\n")
+	b.WriteString("## Instructions\n\n")
+	b.WriteString("1. Step-by-step guidance for the agent.\n")
+	return b.String()
+}
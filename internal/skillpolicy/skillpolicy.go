@@ -0,0 +1,166 @@
+// Package skillpolicy enforces an optional policy file that limits which
+// skills may be installed or updated, by name, tag, repository and risk.
+package skillpolicy
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"lazyas/internal/scan"
+)
+
+// Rules lists glob patterns (path.Match syntax) per attribute
+type Rules struct {
+	Skills []string `toml:"skills"`
+	Tags   []string `toml:"tags"`
+	Repos  []string `toml:"repos"`
+}
+
+func (r Rules) empty() bool {
+	return len(r.Skills) == 0 && len(r.Tags) == 0 && len(r.Repos) == 0
+}
+
+// Policy restricts installable skills. Deny rules win; when any allow rule
+// is set, a skill must match at least one of them.
+type Policy struct {
+	Allow   Rules  `toml:"allow"`
+	Deny    Rules  `toml:"deny"`
+	MaxRisk string `toml:"max_risk"` // highest scan level allowed: none, low, medium, high
+
+	Path    string     `toml:"-"`
+	maxRisk scan.Level // parsed MaxRisk; LevelHigh when unset
+}
+
+// Subject describes the skill being checked
+type Subject struct {
+	Name string
+	Tags []string
+	Repo string
+}
+
+// Violation is returned when a skill breaks the policy
+type Violation struct {
+	Skill  string
+	Reason string
+	Path   string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("skill %s is not allowed by %s: %s", v.Skill, v.Path, v.Reason)
+}
+
+// Load reads a policy file. A missing file means no restrictions and
+// returns a nil Policy, whose checks always pass.
+func Load(file string) (*Policy, error) {
+	var p Policy
+	if _, err := toml.DecodeFile(file, &p); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid skill policy %s: %w", file, err)
+	}
+	p.Path = file
+
+	p.maxRisk = scan.LevelHigh
+	if p.MaxRisk != "" {
+		level, ok := parseLevel(p.MaxRisk)
+		if !ok {
+			return nil, fmt.Errorf("invalid skill policy %s: max_risk %q (expected none, low, medium or high)", file, p.MaxRisk)
+		}
+		p.maxRisk = level
+	}
+
+	for _, rules := range []Rules{p.Allow, p.Deny} {
+		for _, list := range [][]string{rules.Skills, rules.Tags, rules.Repos} {
+			for _, pattern := range list {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return nil, fmt.Errorf("invalid skill policy %s: bad pattern %q", file, pattern)
+				}
+			}
+		}
+	}
+	return &p, nil
+}
+
+// Check returns a *Violation when the skill is denied or not allowed
+func (p *Policy) Check(s Subject) error {
+	if p == nil {
+		return nil
+	}
+	if reason := p.Deny.match(s); reason != "" {
+		return &Violation{Skill: s.Name, Reason: "denied " + reason, Path: p.Path}
+	}
+	if !p.Allow.empty() && p.Allow.match(s) == "" {
+		return &Violation{Skill: s.Name, Reason: "not on the allow list", Path: p.Path}
+	}
+	return nil
+}
+
+// CheckRisk returns a *Violation when a scan found more risk than max_risk
+func (p *Policy) CheckRisk(name string, report *scan.Report) error {
+	if p == nil || report == nil {
+		return nil
+	}
+	if level := report.Level(); level > p.maxRisk {
+		return &Violation{
+			Skill:  name,
+			Reason: fmt.Sprintf("risk %s exceeds max_risk %s (%s)", level, p.maxRisk, report.Summary()),
+			Path:   p.Path,
+		}
+	}
+	return nil
+}
+
+// LimitsRisk reports whether max_risk can refuse a skill, so callers can
+// skip scanning when it cannot
+func (p *Policy) LimitsRisk() bool {
+	return p != nil && p.maxRisk < scan.LevelHigh
+}
+
+// match returns which rule matched s, or "" when none did
+func (r Rules) match(s Subject) string {
+	if pattern, ok := matchAny(r.Skills, s.Name); ok {
+		return fmt.Sprintf("by skill rule %q", pattern)
+	}
+	for _, tag := range s.Tags {
+		if pattern, ok := matchAny(r.Tags, tag); ok {
+			return fmt.Sprintf("by tag rule %q", pattern)
+		}
+	}
+	if pattern, ok := matchAny(r.Repos, normalizeRepo(s.Repo)); ok {
+		return fmt.Sprintf("by repo rule %q", pattern)
+	}
+	return ""
+}
+
+func matchAny(patterns []string, value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, value); ok {
+			return p, true
+		}
+		// Repo patterns are usually written without ".git" or a trailing slash
+		if ok, _ := path.Match(normalizeRepo(p), value); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+func normalizeRepo(repo string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+}
+
+func parseLevel(s string) (scan.Level, bool) {
+	for _, l := range []scan.Level{scan.LevelNone, scan.LevelLow, scan.LevelMedium, scan.LevelHigh} {
+		if strings.EqualFold(strings.TrimSpace(s), l.String()) {
+			return l, true
+		}
+	}
+	return 0, false
+}
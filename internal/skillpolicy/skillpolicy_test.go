@@ -0,0 +1,87 @@
+package skillpolicy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lazyas/internal/scan"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "skill-policy.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_MissingFileHasNoRestrictions(t *testing.T) {
+	p, err := Load(filepath.Join(t.TempDir(), "none.toml"))
+	if err != nil || p != nil {
+		t.Fatalf("expected nil policy, got %+v, %v", p, err)
+	}
+	if err := p.Check(Subject{Name: "anything"}); err != nil {
+		t.Errorf("nil policy should allow everything, got %v", err)
+	}
+}
+
+func TestLoad_RejectsInvalidMaxRisk(t *testing.T) {
+	if _, err := Load(writePolicy(t, `max_risk = "extreme"`)); err == nil {
+		t.Error("expected error for invalid max_risk")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p, err := Load(writePolicy(t, `
+[allow]
+repos = ["https://github.com/anthropics/*"]
+tags = ["approved"]
+
+[deny]
+skills = ["shell-*"]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		subject Subject
+		allowed bool
+	}{
+		{Subject{Name: "pdf", Repo: "https://github.com/anthropics/skills.git"}, true},
+		{Subject{Name: "lint", Repo: "https://example.com/x", Tags: []string{"approved"}}, true},
+		{Subject{Name: "other", Repo: "https://example.com/x"}, false},
+		{Subject{Name: "shell-helper", Repo: "https://github.com/anthropics/skills"}, false},
+	}
+	for _, c := range cases {
+		err := p.Check(c.subject)
+		if (err == nil) != c.allowed {
+			t.Errorf("Check(%s) = %v, want allowed=%v", c.subject.Name, err, c.allowed)
+		}
+		var v *Violation
+		if err != nil && !errors.As(err, &v) {
+			t.Errorf("expected *Violation, got %T", err)
+		}
+	}
+}
+
+func TestCheckRisk(t *testing.T) {
+	p, err := Load(writePolicy(t, `max_risk = "low"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	low := &scan.Report{Findings: []scan.Finding{{Path: "run.sh", Kind: scan.KindScript, Level: scan.LevelLow}}}
+	if err := p.CheckRisk("ok", low); err != nil {
+		t.Errorf("low risk should pass, got %v", err)
+	}
+
+	high := &scan.Report{Findings: []scan.Finding{{Path: "bin/tool", Kind: scan.KindBinary, Level: scan.LevelHigh}}}
+	if err := p.CheckRisk("risky", high); err == nil || !strings.Contains(err.Error(), "max_risk low") {
+		t.Errorf("expected max_risk violation, got %v", err)
+	}
+}
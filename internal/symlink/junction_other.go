@@ -0,0 +1,16 @@
+//go:build !windows
+
+package symlink
+
+import "os"
+
+// newLink creates a symlink at linkPath pointing to targetPath
+func newLink(targetPath, linkPath string) error {
+	return os.Symlink(targetPath, linkPath)
+}
+
+// isJunction reports whether path is a directory junction, which only
+// exist on Windows
+func isJunction(path string, info os.FileInfo) bool {
+	return false
+}
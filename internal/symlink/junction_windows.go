@@ -0,0 +1,32 @@
+//go:build windows
+
+package symlink
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// newLink creates a directory junction at linkPath pointing to
+// targetPath. Unlike symlinks, junctions need neither admin rights nor
+// developer mode. targetPath must be absolute.
+func newLink(targetPath, linkPath string) error {
+	out, err := exec.Command("cmd", "/c", "mklink", "/J", linkPath, targetPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("mklink /J failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// isJunction reports whether path, with info from os.Lstat, is a directory
+// junction. Go reports junctions as irregular files, not symlinks, but
+// os.Readlink resolves them.
+func isJunction(path string, info os.FileInfo) bool {
+	if info.Mode()&os.ModeIrregular == 0 {
+		return false
+	}
+	_, err := os.Readlink(path)
+	return err == nil
+}
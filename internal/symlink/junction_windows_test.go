@@ -0,0 +1,31 @@
+//go:build windows
+
+package symlink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lazyas/internal/config"
+)
+
+func TestCreateLink_UsesJunction(t *testing.T) {
+	tmp := t.TempDir()
+	central := filepath.Join(tmp, "central")
+	backend := config.Backend{Name: "agent", Path: filepath.Join(tmp, "agent skills")}
+
+	if err := CreateLink(backend, central); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Lstat(backend.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isJunction(backend.Path, info) {
+		t.Fatalf("Expected a junction, got mode %v", info.Mode())
+	}
+	if target, err := os.Readlink(backend.Path); err != nil || filepath.Clean(target) != central {
+		t.Errorf("Expected the junction to point at %s, got %q (%v)", central, target, err)
+	}
+}
@@ -0,0 +1,182 @@
+package symlink
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"lazyas/internal/config"
+)
+
+// EnableSkill links one skill into a per-skill backend directory
+func EnableSkill(backend config.Backend, centralDir, name string) error {
+	if !backend.PerSkill() {
+		return fmt.Errorf("backend '%s' links the whole skills directory", backend.Name)
+	}
+	backendPath, err := config.ExpandPath(backend.Path)
+	if err != nil {
+		return fmt.Errorf("failed to expand path: %w", err)
+	}
+	target := filepath.Join(centralDir, name)
+	if _, err := os.Stat(target); err != nil {
+		return fmt.Errorf("skill %s is not installed", name)
+	}
+
+	linkPath := filepath.Join(backendPath, name)
+	if _, err := os.Lstat(linkPath); err == nil {
+		if skill, ok := managedLink(linkPath, centralDir); ok && skill == name {
+			return nil
+		}
+		return fmt.Errorf("%s already exists and is not managed by lazyas", linkPath)
+	}
+	if err := os.MkdirAll(backendPath, 0755); err != nil {
+		return fmt.Errorf("failed to create backend directory: %w", err)
+	}
+	return Link(target, linkPath)
+}
+
+// DisableSkill removes the link of one skill from a per-skill backend
+// directory. Files lazyas did not create are left alone.
+func DisableSkill(backend config.Backend, centralDir, name string) error {
+	if !backend.PerSkill() {
+		return fmt.Errorf("backend '%s' links the whole skills directory", backend.Name)
+	}
+	backendPath, err := config.ExpandPath(backend.Path)
+	if err != nil {
+		return fmt.Errorf("failed to expand path: %w", err)
+	}
+	linkPath := filepath.Join(backendPath, name)
+	if _, err := os.Lstat(linkPath); os.IsNotExist(err) {
+		return nil
+	}
+	if _, ok := managedLink(linkPath, centralDir); !ok {
+		return fmt.Errorf("%s is not managed by lazyas, refusing to remove", linkPath)
+	}
+	return os.Remove(linkPath)
+}
+
+// EnabledSkills lists the skills linked into a per-skill backend directory
+func EnabledSkills(backend config.Backend, centralDir string) []string {
+	backendPath, err := config.ExpandPath(backend.Path)
+	if err != nil {
+		return nil
+	}
+	entries, err := os.ReadDir(backendPath)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if _, ok := managedLink(filepath.Join(backendPath, e.Name()), centralDir); ok {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConvertToPerSkill turns a backend into a per-skill directory, replacing
+// a whole-directory link to centralDir, and enables the given skills
+func ConvertToPerSkill(backend config.Backend, centralDir string, enable []string) error {
+	backendPath, err := config.ExpandPath(backend.Path)
+	if err != nil {
+		return fmt.Errorf("failed to expand path: %w", err)
+	}
+	if info, err := os.Lstat(backendPath); err == nil && IsLink(backendPath, info) {
+		target, err := filepath.EvalSymlinks(backendPath)
+		central, _ := filepath.EvalSymlinks(centralDir)
+		if err != nil || target != central {
+			return fmt.Errorf("%s is a symlink not managed by lazyas", backendPath)
+		}
+		if err := os.Remove(backendPath); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(backendPath, 0755); err != nil {
+		return fmt.Errorf("failed to create backend directory: %w", err)
+	}
+
+	backend.Mode = config.BackendPerSkill
+	for _, name := range enable {
+		if err := EnableSkill(backend, centralDir, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertToWhole turns a per-skill backend directory back into a link to
+// centralDir, so the backend sees every skill. A directory that holds
+// anything besides lazyas links is left alone.
+func ConvertToWhole(backend config.Backend, centralDir string) error {
+	backendPath, err := config.ExpandPath(backend.Path)
+	if err != nil {
+		return fmt.Errorf("failed to expand path: %w", err)
+	}
+	entries, err := os.ReadDir(backendPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, e := range entries {
+		if _, ok := managedLink(filepath.Join(backendPath, e.Name()), centralDir); !ok {
+			return fmt.Errorf("%s holds %s, which lazyas did not link", backendPath, e.Name())
+		}
+	}
+
+	if _, err := RemoveSkillLinks(backend, centralDir); err != nil {
+		return err
+	}
+	if err := os.Remove(backendPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	backend.Mode = ""
+	return CreateLink(backend, centralDir)
+}
+
+// RemoveSkillLinks removes every lazyas link from a per-skill backend
+// directory, keeping the directory and the user's own files. Returns how
+// many links were removed.
+func RemoveSkillLinks(backend config.Backend, centralDir string) (int, error) {
+	backend.Mode = config.BackendPerSkill
+	removed := 0
+	for _, name := range EnabledSkills(backend, centralDir) {
+		if err := DisableSkill(backend, centralDir, name); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Prune removes links of a per-skill backend whose skill is gone from
+// centralDir, e.g. after it was removed. Returns the pruned names.
+func Prune(backend config.Backend, centralDir string) []string {
+	var pruned []string
+	for _, name := range EnabledSkills(backend, centralDir) {
+		if _, err := os.Stat(filepath.Join(centralDir, name)); os.IsNotExist(err) {
+			if DisableSkill(backend, centralDir, name) == nil {
+				pruned = append(pruned, name)
+			}
+		}
+	}
+	return pruned
+}
+
+// managedLink reports whether path is a symlink lazyas created in a
+// per-skill backend: one pointing at an entry of centralDir. Returns the
+// name of that entry.
+func managedLink(path, centralDir string) (string, bool) {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return "", false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	target = filepath.Clean(target)
+	if filepath.Dir(target) != filepath.Clean(centralDir) {
+		return "", false
+	}
+	return filepath.Base(target), true
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 
 	"lazyas/internal/config"
 )
@@ -65,8 +64,8 @@ func checkSingleBackend(backend config.Backend, centralDir string) LinkStatus {
 
 	status.Exists = true
 
-	// Check if it's a symlink
-	if info.Mode()&os.ModeSymlink != 0 {
+	// Check if it's a symlink, or a junction on Windows
+	if IsLink(backendPath, info) {
 		status.IsSymlink = true
 
 		// Read the symlink target
@@ -83,11 +82,16 @@ func checkSingleBackend(backend config.Backend, centralDir string) LinkStatus {
 		}
 		target = filepath.Clean(target)
 
-		// Check if symlink points to our central directory
-		status.Linked = target == centralDir
+		// Check if symlink points to our central directory. A per-skill
+		// backend still linked as a whole needs converting first.
+		status.Linked = target == centralDir && !backend.PerSkill()
 		return status
 	}
 
+	// A per-skill backend is linked once its directory exists; its other
+	// files are the user's own skills and stay where they are
+	status.Linked = backend.PerSkill() && info.IsDir()
+
 	// It's a regular directory - check if it has files
 	entries, err := os.ReadDir(backendPath)
 	if err != nil {
@@ -117,20 +121,19 @@ func CreateLink(backend config.Backend, centralDir string) error {
 		return fmt.Errorf("failed to create central directory: %w", err)
 	}
 
-	// Create the symlink
-	if runtime.GOOS == "windows" {
-		return createWindowsLink(backendPath, centralDir)
-	}
+	return Link(centralDir, backendPath)
+}
 
-	return os.Symlink(centralDir, backendPath)
+// Link creates a backend link at path pointing to the directory target:
+// a symlink, or on Windows a junction, which needs no admin rights
+func Link(target, path string) error {
+	return newLink(target, path)
 }
 
-// createWindowsLink creates a directory junction on Windows
-func createWindowsLink(linkPath, targetPath string) error {
-	// On Windows, we use mklink /J for directory junctions
-	// This doesn't require admin privileges unlike /D for symlinks
-	// For now, use standard symlink which requires developer mode or admin
-	return os.Symlink(targetPath, linkPath)
+// IsLink reports whether path, with info from os.Lstat, is a symlink or
+// a Windows junction
+func IsLink(path string, info os.FileInfo) bool {
+	return info.Mode()&os.ModeSymlink != 0 || isJunction(path, info)
 }
 
 // RemoveLink removes a symlink (but not a real directory)
@@ -149,16 +152,25 @@ func RemoveLink(backend config.Backend) error {
 	}
 
 	// Only remove if it's a symlink
-	if info.Mode()&os.ModeSymlink == 0 {
+	if !IsLink(backendPath, info) {
 		return fmt.Errorf("path is not a symlink, refusing to remove")
 	}
 
 	return os.Remove(backendPath)
 }
 
+// migrationMarker is left in a backend directory while MigrateExistingDir
+// empties it; a directory still holding it was only partly migrated
+const migrationMarker = ".lazyas-migrating"
+
 // MigrateExistingDir moves files from an existing backend directory to the central directory
-// and creates a symlink in place of the original directory
-func MigrateExistingDir(backend config.Backend, centralDir string) error {
+// and creates a symlink in place of the original directory. Originals that had to be copied
+// across filesystems are handed to discard, or deleted when it is nil.
+func MigrateExistingDir(backend config.Backend, centralDir string, discard func(path string) error) error {
+	if discard == nil {
+		discard = os.RemoveAll
+	}
+
 	backendPath, err := config.ExpandPath(backend.Path)
 	if err != nil {
 		return fmt.Errorf("failed to expand path: %w", err)
@@ -170,7 +182,7 @@ func MigrateExistingDir(backend config.Backend, centralDir string) error {
 		return fmt.Errorf("failed to stat backend path: %w", err)
 	}
 
-	if info.Mode()&os.ModeSymlink != 0 {
+	if IsLink(backendPath, info) {
 		return fmt.Errorf("backend path is already a symlink")
 	}
 
@@ -188,13 +200,21 @@ func MigrateExistingDir(backend config.Backend, centralDir string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read backend directory: %w", err)
 	}
+	marker := filepath.Join(backendPath, migrationMarker)
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		return fmt.Errorf("failed to mark the migration: %w", err)
+	}
 
 	for _, entry := range entries {
+		if entry.Name() == migrationMarker {
+			continue
+		}
 		srcPath := filepath.Join(backendPath, entry.Name())
 		dstPath := filepath.Join(centralDir, entry.Name())
 
-		// Check if destination already exists
-		if _, err := os.Stat(dstPath); err == nil {
+		// Check if destination already exists; a dangling symlink counts,
+		// so the copy below never writes through it
+		if _, err := os.Lstat(dstPath); err == nil {
 			// Skip if already exists - user can resolve conflicts manually
 			continue
 		}
@@ -202,14 +222,19 @@ func MigrateExistingDir(backend config.Backend, centralDir string) error {
 		// Move the file/directory
 		if err := os.Rename(srcPath, dstPath); err != nil {
 			// If rename fails (cross-device), try copy+delete
-			if err := copyRecursive(srcPath, dstPath); err != nil {
+			if err := CopyTree(srcPath, dstPath); err != nil {
 				return fmt.Errorf("failed to move %s: %w", entry.Name(), err)
 			}
-			os.RemoveAll(srcPath)
+			if err := discard(srcPath); err != nil {
+				return fmt.Errorf("failed to clean up %s: %w", entry.Name(), err)
+			}
 		}
 	}
 
 	// Remove the now-empty directory
+	if err := os.Remove(marker); err != nil {
+		return fmt.Errorf("failed to remove the migration marker: %w", err)
+	}
 	if err := os.Remove(backendPath); err != nil {
 		return fmt.Errorf("failed to remove original directory: %w", err)
 	}
@@ -218,13 +243,17 @@ func MigrateExistingDir(backend config.Backend, centralDir string) error {
 	return CreateLink(backend, centralDir)
 }
 
-// copyRecursive copies a file or directory recursively
-func copyRecursive(src, dst string) error {
-	info, err := os.Stat(src)
+// CopyTree copies a file or directory recursively. Symlinks are
+// copied as links, never followed, so nothing outside src is read.
+func CopyTree(src, dst string) error {
+	info, err := os.Lstat(src)
 	if err != nil {
 		return err
 	}
 
+	if info.Mode()&os.ModeSymlink != 0 {
+		return copyLink(src, dst)
+	}
 	if info.IsDir() {
 		return copyDir(src, dst)
 	}
@@ -245,7 +274,11 @@ func copyDir(src, dst string) error {
 		srcPath := filepath.Join(src, entry.Name())
 		dstPath := filepath.Join(dst, entry.Name())
 
-		if entry.IsDir() {
+		if entry.Type()&os.ModeSymlink != 0 {
+			if err := copyLink(srcPath, dstPath); err != nil {
+				return err
+			}
+		} else if entry.IsDir() {
 			if err := copyDir(srcPath, dstPath); err != nil {
 				return err
 			}
@@ -273,6 +306,16 @@ func copyFile(src, dst string) error {
 	return os.WriteFile(dst, data, info.Mode())
 }
 
+// copyLink recreates the symlink src at dst with the same target, as a
+// rename would
+func copyLink(src, dst string) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+	return os.Symlink(target, dst)
+}
+
 // HasUnlinkedBackends returns true if any backend is not linked
 func HasUnlinkedBackends(statuses []LinkStatus) bool {
 	for _, s := range statuses {
@@ -0,0 +1,87 @@
+package symlink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lazyas/internal/config"
+)
+
+func TestCreateLink_CheckAndRemove(t *testing.T) {
+	tmp := t.TempDir()
+	central := filepath.Join(tmp, "central")
+	backend := config.Backend{Name: "agent", Path: filepath.Join(tmp, "agent", "skills")}
+	if err := os.MkdirAll(central, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(central, "marker"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CreateLink(backend, central); err != nil {
+		t.Fatal(err)
+	}
+	status := CheckBackendLinks([]config.Backend{backend}, central)[0]
+	if !status.Linked || !status.IsSymlink || status.Error != nil {
+		t.Fatalf("Expected the backend linked, got %+v", status)
+	}
+	if _, err := os.Stat(filepath.Join(backend.Path, "marker")); err != nil {
+		t.Errorf("Expected the central directory through the link: %v", err)
+	}
+	if err := MigrateExistingDir(backend, central, nil); err == nil {
+		t.Error("Expected migrating a linked backend to fail")
+	}
+
+	if err := RemoveLink(backend); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Lstat(backend.Path); !os.IsNotExist(err) {
+		t.Errorf("Expected the link removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(central, "marker")); err != nil {
+		t.Errorf("Expected the central directory untouched: %v", err)
+	}
+}
+
+func TestRemoveLink_RefusesDirectory(t *testing.T) {
+	backend := config.Backend{Name: "agent", Path: t.TempDir()}
+	if err := RemoveLink(backend); err == nil {
+		t.Error("Expected a real directory not to be removed")
+	}
+}
+
+func TestConvertToWhole(t *testing.T) {
+	tmp := t.TempDir()
+	central := filepath.Join(tmp, "central")
+	backend := config.Backend{Name: "agent", Path: filepath.Join(tmp, "agent"), Mode: config.BackendPerSkill}
+	if err := os.MkdirAll(filepath.Join(central, "pdf"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ConvertToPerSkill(backend, central, []string{"pdf"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file of the user's own keeps the directory as it is
+	own := filepath.Join(backend.Path, "notes.md")
+	if err := os.WriteFile(own, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ConvertToWhole(backend, central); err == nil {
+		t.Fatal("Expected a directory with the user's files to be refused")
+	}
+	if got := EnabledSkills(backend, central); len(got) != 1 {
+		t.Errorf("Expected the skill links kept, got %v", got)
+	}
+
+	if err := os.Remove(own); err != nil {
+		t.Fatal(err)
+	}
+	if err := ConvertToWhole(backend, central); err != nil {
+		t.Fatal(err)
+	}
+	backend.Mode = ""
+	if status := CheckBackendLinks([]config.Backend{backend}, central)[0]; !status.Linked || !status.IsSymlink {
+		t.Errorf("Expected the backend linked as a whole, got %+v", status)
+	}
+}
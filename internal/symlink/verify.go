@@ -0,0 +1,199 @@
+package symlink
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+)
+
+// ProblemKind is what is wrong with a backend's links
+type ProblemKind string
+
+const (
+	// ProblemBrokenLink: the backend link points at a directory that no
+	// longer exists
+	ProblemBrokenLink ProblemKind = "broken-link"
+	// ProblemStaleLink: the backend link points at another skills
+	// directory than the current one, e.g. after skills_dir changed
+	ProblemStaleLink ProblemKind = "stale-link"
+	// ProblemBrokenSkillLink: a per-skill link whose skill was removed
+	ProblemBrokenSkillLink ProblemKind = "broken-skill-link"
+	// ProblemStaleSkillLink: a per-skill link into a skills directory
+	// that is gone, for a skill the current one has
+	ProblemStaleSkillLink ProblemKind = "stale-skill-link"
+	// ProblemPartialMigration: a backend directory whose migration into
+	// the skills directory stopped half way, so it holds copies of skills
+	// the skills directory has too
+	ProblemPartialMigration ProblemKind = "partial-migration"
+)
+
+// Problem is something wrong with a backend's links, found by Verify.
+// Repair fixes it.
+type Problem struct {
+	Backend config.Backend
+	Kind    ProblemKind
+	Path    string   // the link or directory at fault
+	Target  string   // where the link points; "" for directories
+	Skills  []string // for partial migrations, the skills in both directories
+}
+
+// String describes the problem for the user
+func (p Problem) String() string {
+	switch p.Kind {
+	case ProblemBrokenLink:
+		return i18n.Tf("%s points to %s, which does not exist", p.Path, p.Target)
+	case ProblemStaleLink:
+		return i18n.Tf("%s points to %s instead of the skills directory", p.Path, p.Target)
+	case ProblemBrokenSkillLink:
+		return i18n.Tf("%s points to the removed skill %s", p.Path, p.Target)
+	case ProblemStaleSkillLink:
+		return i18n.Tf("%s points to %s, which does not exist; the skills directory has it", p.Path, p.Target)
+	case ProblemPartialMigration:
+		if len(p.Skills) == 0 {
+			return i18n.Tf("%s was partly migrated", p.Path)
+		}
+		return i18n.Tf("%s was partly migrated and still holds %d skill(s) the skills directory has", p.Path, len(p.Skills))
+	}
+	return p.Path
+}
+
+// Fix describes what Repair does about the problem
+func (p Problem) Fix() string {
+	switch p.Kind {
+	case ProblemBrokenLink, ProblemStaleLink:
+		return i18n.T("link it to the skills directory")
+	case ProblemBrokenSkillLink:
+		return i18n.T("remove the link")
+	case ProblemStaleSkillLink:
+		return i18n.T("link the skill in the skills directory")
+	case ProblemPartialMigration:
+		return i18n.T("discard the leftover copies, move the rest and link the directory")
+	}
+	return ""
+}
+
+// Verify checks the links of the backends against centralDir: links that
+// are dangling or point at an old skills directory, per-skill links to
+// skills that are gone, and directories a migration left half moved.
+// Backends that were never linked have no problems.
+func Verify(backends []config.Backend, centralDir string) []Problem {
+	var problems []Problem
+	for _, backend := range backends {
+		problems = append(problems, verifyBackend(backend, centralDir)...)
+	}
+	return problems
+}
+
+func verifyBackend(backend config.Backend, centralDir string) []Problem {
+	backendPath, err := config.ExpandPath(backend.Path)
+	if err != nil {
+		return nil
+	}
+	info, err := os.Lstat(backendPath)
+	if err != nil {
+		return nil
+	}
+
+	if IsLink(backendPath, info) {
+		if backend.PerSkill() {
+			return nil // still linked as a whole; linking converts it
+		}
+		target, err := os.Readlink(backendPath)
+		if err != nil {
+			return nil
+		}
+		problem := Problem{Backend: backend, Path: backendPath, Target: target}
+		if _, err := os.Stat(backendPath); err != nil {
+			problem.Kind = ProblemBrokenLink
+			return []Problem{problem}
+		}
+		resolved, _ := filepath.EvalSymlinks(backendPath)
+		central, _ := filepath.EvalSymlinks(centralDir)
+		if resolved != central {
+			problem.Kind = ProblemStaleLink
+			return []Problem{problem}
+		}
+		return nil
+	}
+	if !info.IsDir() {
+		return nil
+	}
+
+	entries, err := os.ReadDir(backendPath)
+	if err != nil {
+		return nil
+	}
+	if !backend.PerSkill() {
+		// Only a migration that stopped half way leaves its marker; a
+		// directory that was never migrated is the user's own
+		if _, err := os.Lstat(filepath.Join(backendPath, migrationMarker)); err != nil {
+			return nil
+		}
+		var both []string
+		for _, e := range entries {
+			if e.Name() == migrationMarker {
+				continue
+			}
+			if _, err := os.Lstat(filepath.Join(centralDir, e.Name())); err == nil {
+				both = append(both, e.Name())
+			}
+		}
+		sort.Strings(both)
+		return []Problem{{Backend: backend, Kind: ProblemPartialMigration, Path: backendPath, Skills: both}}
+	}
+
+	var problems []Problem
+	for _, e := range entries {
+		linkPath := filepath.Join(backendPath, e.Name())
+		if info, err := e.Info(); err != nil || !IsLink(linkPath, info) {
+			continue
+		}
+		if _, err := os.Stat(linkPath); err == nil {
+			continue // the user's own links are theirs
+		}
+		target, _ := os.Readlink(linkPath)
+		if name, ok := managedLink(linkPath, centralDir); ok {
+			problems = append(problems, Problem{Backend: backend, Kind: ProblemBrokenSkillLink, Path: linkPath, Target: name})
+		} else if _, err := os.Stat(filepath.Join(centralDir, e.Name())); err == nil {
+			problems = append(problems, Problem{Backend: backend, Kind: ProblemStaleSkillLink, Path: linkPath, Target: target})
+		}
+	}
+	return problems
+}
+
+// Repair fixes a problem found by Verify. Leftover copies of a partial
+// migration are handed to discard, or deleted when it is nil.
+func Repair(p Problem, centralDir string, discard func(path string) error) error {
+	switch p.Kind {
+	case ProblemBrokenLink, ProblemStaleLink:
+		if err := RemoveLink(p.Backend); err != nil {
+			return err
+		}
+		return CreateLink(p.Backend, centralDir)
+
+	case ProblemBrokenSkillLink:
+		return os.Remove(p.Path)
+
+	case ProblemStaleSkillLink:
+		if err := os.Remove(p.Path); err != nil {
+			return err
+		}
+		return Link(filepath.Join(centralDir, filepath.Base(p.Path)), p.Path)
+
+	case ProblemPartialMigration:
+		if discard == nil {
+			discard = os.RemoveAll
+		}
+		for _, name := range p.Skills {
+			if err := discard(filepath.Join(p.Path, name)); err != nil {
+				return fmt.Errorf("failed to discard %s: %w", name, err)
+			}
+		}
+		return MigrateExistingDir(p.Backend, centralDir, discard)
+	}
+	return fmt.Errorf("unknown problem %q", p.Kind)
+}
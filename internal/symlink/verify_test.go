@@ -0,0 +1,113 @@
+package symlink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lazyas/internal/config"
+)
+
+func TestVerifyAndRepair(t *testing.T) {
+	tmp := t.TempDir()
+	central := filepath.Join(tmp, "central")
+	old := filepath.Join(tmp, "old")
+	for _, dir := range []string{filepath.Join(central, "pdf"), filepath.Join(central, "xlsx"), filepath.Join(tmp, "elsewhere")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdir := func(path string) string {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	link := func(target, path string) {
+		if err := os.Symlink(target, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	broken := config.Backend{Name: "broken", Path: filepath.Join(tmp, "broken")}
+	link(filepath.Join(tmp, "gone"), broken.Path)
+	stale := config.Backend{Name: "stale", Path: filepath.Join(tmp, "stale")}
+	link(filepath.Join(tmp, "elsewhere"), stale.Path)
+	healthy := config.Backend{Name: "healthy", Path: filepath.Join(tmp, "healthy")}
+	link(central, healthy.Path)
+
+	perSkill := config.Backend{Name: "per", Path: mkdir(filepath.Join(tmp, "per")), Mode: config.BackendPerSkill}
+	link(filepath.Join(central, "removed"), filepath.Join(perSkill.Path, "removed"))
+	link(filepath.Join(old, "pdf"), filepath.Join(perSkill.Path, "pdf"))
+	link(filepath.Join(tmp, "mine"), filepath.Join(perSkill.Path, "mine")) // the user's own, dangling
+
+	partial := config.Backend{Name: "partial", Path: mkdir(filepath.Join(tmp, "partial"))}
+	mkdir(filepath.Join(partial.Path, "xlsx"))
+	mkdir(filepath.Join(partial.Path, "docx"))
+	if err := os.WriteFile(filepath.Join(partial.Path, migrationMarker), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Never migrated: sharing a skill name with the skills directory is
+	// no sign of a migration
+	fresh := config.Backend{Name: "fresh", Path: mkdir(filepath.Join(tmp, "fresh"))}
+	mkdir(filepath.Join(fresh.Path, "own-skill"))
+	mkdir(filepath.Join(fresh.Path, "pdf"))
+
+	backends := []config.Backend{broken, stale, healthy, perSkill, partial, fresh}
+	problems := Verify(backends, central)
+	got := make(map[ProblemKind]string)
+	for _, p := range problems {
+		got[p.Kind] = p.Backend.Name + ":" + filepath.Base(p.Path)
+	}
+	want := map[ProblemKind]string{
+		ProblemBrokenLink:       "broken:broken",
+		ProblemStaleLink:        "stale:stale",
+		ProblemBrokenSkillLink:  "per:removed",
+		ProblemStaleSkillLink:   "per:pdf",
+		ProblemPartialMigration: "partial:partial",
+	}
+	if len(problems) != len(want) {
+		t.Errorf("Expected %d problems, got %d: %v", len(want), len(problems), problems)
+	}
+	for kind, w := range want {
+		if got[kind] != w {
+			t.Errorf("Expected %s on %s, got %q", kind, w, got[kind])
+		}
+	}
+
+	var discarded []string
+	for _, p := range problems {
+		if err := Repair(p, central, func(path string) error {
+			discarded = append(discarded, filepath.Base(path))
+			return os.RemoveAll(path)
+		}); err != nil {
+			t.Fatalf("Repair %s: %v", p.Kind, err)
+		}
+	}
+	if again := Verify(backends, central); len(again) != 0 {
+		t.Errorf("Expected no problems after repair, got %v", again)
+	}
+	for _, b := range []config.Backend{broken, stale, partial} {
+		if !CheckBackendLinks([]config.Backend{b}, central)[0].Linked {
+			t.Errorf("Expected %s linked to the skills directory", b.Name)
+		}
+	}
+	if got := EnabledSkills(perSkill, central); len(got) != 1 || got[0] != "pdf" {
+		t.Errorf("Expected only pdf linked per skill, got %v", got)
+	}
+	if _, err := os.Lstat(filepath.Join(perSkill.Path, "mine")); err != nil {
+		t.Errorf("Expected the user's own link left alone: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(central, "docx")); err != nil {
+		t.Errorf("Expected the unmigrated skill moved: %v", err)
+	}
+	if len(discarded) != 1 || discarded[0] != "xlsx" {
+		t.Errorf("Expected only the leftover copy discarded, got %v", discarded)
+	}
+	if _, err := os.Stat(filepath.Join(fresh.Path, "pdf")); err != nil {
+		t.Errorf("Expected the never migrated backend left alone: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(central, migrationMarker)); !os.IsNotExist(err) {
+		t.Errorf("Expected the migration marker not moved: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package templates creates skills from the skeletons in a templates
+// repository. Every directory with a SKILL.md, at the top level of the
+// repository or under templates/, is a template named after the directory.
+// Text files may use the placeholders {{name}}, {{author}} and
+// {{description}}, which are filled in when a skill is created.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"lazyas/internal/skillmd"
+)
+
+// Values fill in a template's placeholders
+type Values struct {
+	Name        string
+	Author      string
+	Description string
+}
+
+// List returns the names of the templates in the checked-out repository
+// dir, sorted and without duplicates
+func List(dir string) ([]string, error) {
+	var names []string
+	for _, parent := range []string{dir, filepath.Join(dir, "templates")} {
+		entries, err := os.ReadDir(parent)
+		if err != nil {
+			if os.IsNotExist(err) && parent != dir {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(parent, e.Name(), "SKILL.md")); err == nil {
+				names = append(names, e.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+	return slices.Compact(names), nil
+}
+
+// Find returns the directory of the template name in the checked-out
+// repository dir, preferring templates/<name> over <name>
+func Find(dir, name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+	for _, candidate := range []string{filepath.Join(dir, "templates", name), filepath.Join(dir, name)} {
+		if _, err := os.Stat(filepath.Join(candidate, "SKILL.md")); err == nil {
+			return candidate, nil
+		}
+	}
+	available, _ := List(dir)
+	if len(available) == 0 {
+		return "", fmt.Errorf("template %s not found: the repository has no templates", name)
+	}
+	return "", fmt.Errorf("template %s not found (available: %s)", name, strings.Join(available, ", "))
+}
+
+// Render copies the template src into dest, which must not exist yet,
+// filling in the placeholders of its text files. Binary files are copied
+// as they are; git metadata is left out.
+func Render(src, dest string, v Values) error {
+	replacer := strings.NewReplacer(
+		"{{name}}", v.Name,
+		"{{author}}", v.Author,
+		"{{description}}", v.Description,
+	)
+	if err := os.Mkdir(dest, 0755); err != nil {
+		return err
+	}
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		target := filepath.Join(dest, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.Mkdir(target, info.Mode().Perm()|0700)
+		case !info.Mode().IsRegular():
+			return nil // symlinks could point outside the skill
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Contains(data, []byte{0}) {
+			content := string(data)
+			if rel == "SKILL.md" {
+				content = quoteFrontmatter(content, v)
+			}
+			data = []byte(replacer.Replace(content))
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+	if err != nil {
+		os.RemoveAll(dest)
+		return fmt.Errorf("failed to copy template: %w", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dest, "SKILL.md")); err == nil {
+		if _, err := skillmd.ParseFrontmatter(string(data)); err != nil {
+			os.RemoveAll(dest)
+			return fmt.Errorf("the template's SKILL.md is not valid once filled in: %w", err)
+		}
+	}
+	return nil
+}
+
+// frontmatterValue matches a frontmatter line whose whole value is a
+// placeholder
+var frontmatterValue = regexp.MustCompile(`(?m)^([ \t]*[\w-]+:[ \t]*)\{\{(name|author|description)\}\}[ \t]*$`)
+
+// quoteFrontmatter fills in the placeholders that make up a whole value in
+// the frontmatter of SKILL.md as YAML strings, so that a description such
+// as "Fill forms: fast" keeps it valid
+func quoteFrontmatter(content string, v Values) string {
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return content
+	}
+	end += 4
+	values := map[string]string{"name": v.Name, "author": v.Author, "description": v.Description}
+	frontmatter := frontmatterValue.ReplaceAllStringFunc(content[:end], func(line string) string {
+		m := frontmatterValue.FindStringSubmatch(line)
+		out, err := yaml.Marshal(values[m[2]])
+		if err != nil {
+			return line
+		}
+		return m[1] + strings.TrimSuffix(string(out), "\n")
+	})
+	return frontmatter + content[end:]
+}
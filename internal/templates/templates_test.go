@@ -0,0 +1,110 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListAndFind(t *testing.T) {
+	repo := t.TempDir()
+	writeFile(t, filepath.Join(repo, "python-tool", "SKILL.md"), "---\nname: top\n---\n")
+	writeFile(t, filepath.Join(repo, "templates", "python-tool", "SKILL.md"), "---\nname: nested\n---\n")
+	writeFile(t, filepath.Join(repo, "templates", "docs", "SKILL.md"), "---\nname: docs\n---\n")
+	writeFile(t, filepath.Join(repo, "scripts", "build.sh"), "#!/bin/sh\n")
+
+	names, err := List(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"docs", "python-tool"}; !slices.Equal(names, want) {
+		t.Errorf("List = %v, want %v", names, want)
+	}
+
+	dir, err := Find(repo, "python-tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != filepath.Join(repo, "templates", "python-tool") {
+		t.Errorf("Find preferred %s over templates/", dir)
+	}
+
+	_, err = Find(repo, "scripts")
+	if err == nil || !strings.Contains(err.Error(), "available: docs, python-tool") {
+		t.Errorf("expected the available templates in the error, got %v", err)
+	}
+	if _, err := Find(repo, "../python-tool"); err == nil {
+		t.Error("expected a path outside the repository to be rejected")
+	}
+}
+
+func TestRender_FillsPlaceholders(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "SKILL.md"), "---\nname: {{name}}\ndescription: {{description}}\nauthor: {{author}}\n---\n\n# {{name}}\n")
+	writeFile(t, filepath.Join(src, "scripts", "run.py"), "# {{name}} helper\n")
+	writeFile(t, filepath.Join(src, "logo.png"), "\x89PNG\x00{{name}}")
+	writeFile(t, filepath.Join(src, ".git", "HEAD"), "ref: refs/heads/main\n")
+
+	dest := filepath.Join(t.TempDir(), "csv-report")
+	err := Render(src, dest, Values{Name: "csv-report", Author: "Ada", Description: "Summarize CSV files"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skill, _ := os.ReadFile(filepath.Join(dest, "SKILL.md"))
+	want := "---\nname: csv-report\ndescription: Summarize CSV files\nauthor: Ada\n---\n\n# csv-report\n"
+	if string(skill) != want {
+		t.Errorf("SKILL.md = %q, want %q", skill, want)
+	}
+	if script, _ := os.ReadFile(filepath.Join(dest, "scripts", "run.py")); string(script) != "# csv-report helper\n" {
+		t.Errorf("run.py = %q", script)
+	}
+	if logo, _ := os.ReadFile(filepath.Join(dest, "logo.png")); string(logo) != "\x89PNG\x00{{name}}" {
+		t.Errorf("expected binary files copied unchanged, got %q", logo)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".git")); !os.IsNotExist(err) {
+		t.Error("expected the template's git metadata left out")
+	}
+
+	if err := Render(src, dest, Values{}); err == nil {
+		t.Error("expected an error when the destination exists")
+	}
+}
+
+func TestRender_QuotesFrontmatterValues(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, "SKILL.md"), "---\nname: {{name}}\ndescription: {{description}}\n---\n\n{{description}}\n")
+
+	dest := filepath.Join(t.TempDir(), "forms")
+	if err := Render(src, dest, Values{Name: "forms", Description: "Fill forms: fast"}); err != nil {
+		t.Fatal(err)
+	}
+	skill, _ := os.ReadFile(filepath.Join(dest, "SKILL.md"))
+	want := "---\nname: forms\ndescription: 'Fill forms: fast'\n---\n\nFill forms: fast\n"
+	if string(skill) != want {
+		t.Errorf("SKILL.md = %q, want %q", skill, want)
+	}
+
+	// A placeholder inside a longer value cannot be quoted; the result is
+	// checked instead
+	writeFile(t, filepath.Join(src, "SKILL.md"), "---\nname: {{name}}\ndescription: Tool for {{description}}\n---\n")
+	dest = filepath.Join(t.TempDir(), "broken")
+	if err := Render(src, dest, Values{Name: "broken", Description: "a: b"}); err == nil {
+		t.Error("expected an error for invalid frontmatter")
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Error("expected the destination removed after the error")
+	}
+}
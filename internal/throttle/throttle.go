@@ -0,0 +1,195 @@
+// Package throttle caps the bandwidth of network transfers. git has no
+// rate option of its own, so its HTTP(S) transfers are sent through a
+// local Proxy that passes bytes no faster than a shared Limiter allows.
+package throttle
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// chunk is the most a throttled read passes at once, so a slow limit
+// paces transfers in short sleeps instead of long stalls
+const chunk = 16 * 1024
+
+// Limiter paces the bytes of any number of readers to one rate. It is
+// safe for concurrent use; the zero Limiter is unlimited.
+type Limiter struct {
+	mu   sync.Mutex
+	rate int64     // bytes per second; 0 = unlimited
+	next time.Time // when the bytes passed so far are paid for
+}
+
+// NewLimiter returns a limiter passing bytesPerSec, or unlimited for 0
+func NewLimiter(bytesPerSec int64) *Limiter {
+	l := &Limiter{}
+	l.SetRate(bytesPerSec)
+	return l
+}
+
+// SetRate changes the rate; it applies to transfers already running
+func (l *Limiter) SetRate(bytesPerSec int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = max(bytesPerSec, 0)
+	l.next = time.Time{}
+}
+
+// Rate returns the rate in bytes per second, 0 when unlimited
+func (l *Limiter) Rate() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// wait blocks until n more bytes fit the rate
+func (l *Limiter) wait(n int) {
+	l.mu.Lock()
+	if l.rate == 0 || n <= 0 {
+		l.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(n) * time.Second / time.Duration(l.rate))
+	delay := l.next.Sub(now)
+	l.mu.Unlock()
+	time.Sleep(delay)
+}
+
+// Reader returns r read no faster than the limiter allows
+func (l *Limiter) Reader(r io.Reader) io.Reader {
+	return &reader{r: r, l: l}
+}
+
+type reader struct {
+	r io.Reader
+	l *Limiter
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) > chunk {
+		p = p[:chunk]
+	}
+	n, err := r.r.Read(p)
+	r.l.wait(n)
+	return n, err
+}
+
+// Proxy is an HTTP proxy on the loopback interface whose transfers, in
+// both directions, share one Limiter. It tunnels HTTPS with CONNECT and
+// forwards plain HTTP requests.
+type Proxy struct {
+	Limiter *Limiter
+
+	listener  net.Listener
+	transport *http.Transport
+}
+
+// StartProxy starts a proxy limited to bytesPerSec on a free port
+func StartProxy(bytesPerSec int64) (*Proxy, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &Proxy{
+		Limiter:   NewLimiter(bytesPerSec),
+		listener:  ln,
+		transport: &http.Transport{},
+	}
+	go http.Serve(ln, p)
+	return p, nil
+}
+
+// URL returns the address to configure as the proxy, e.g.
+// "http://127.0.0.1:41234"
+func (p *Proxy) URL() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+// Close stops accepting connections
+func (p *Proxy) Close() error {
+	return p.listener.Close()
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	p.forward(w, r)
+}
+
+// tunnel connects the client to r.Host and copies bytes both ways
+func (p *Proxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "tunneling not supported", http.StatusInternalServerError)
+		return
+	}
+	upstream, err := net.DialTimeout("tcp", r.Host, 30*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	client, buf, err := hj.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err := client.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+		client.Close()
+		upstream.Close()
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		pipe(upstream, p.Limiter.Reader(buf.Reader))
+		close(done)
+	}()
+	pipe(client, p.Limiter.Reader(bufio.NewReader(upstream)))
+	client.Close()
+	upstream.Close()
+	<-done
+}
+
+// pipe copies src into dst until either ends, then closes dst for writing
+// so the other side sees the end of the stream
+func pipe(dst net.Conn, src io.Reader) {
+	io.Copy(dst, src)
+	if tcp, ok := dst.(*net.TCPConn); ok {
+		tcp.CloseWrite()
+	}
+}
+
+// forward sends a plain HTTP request on and streams the response back
+func (p *Proxy) forward(w http.ResponseWriter, r *http.Request) {
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	if r.Body != nil {
+		out.Body = io.NopCloser(p.Limiter.Reader(r.Body))
+	}
+	resp, err := p.transport.RoundTrip(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, p.Limiter.Reader(resp.Body))
+}
@@ -0,0 +1,75 @@
+package throttle
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLimiter_PacesReads(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 64*1024)
+
+	start := time.Now()
+	got, err := io.ReadAll(NewLimiter(256 * 1024).Reader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(data) {
+		t.Fatalf("read %d bytes, want %d", len(got), len(data))
+	}
+	// 64 KB at 256 KB/s takes a quarter second
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("read took %s, want about 250ms", elapsed)
+	}
+
+	start = time.Now()
+	if _, err := io.ReadAll(NewLimiter(0).Reader(bytes.NewReader(data))); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("unlimited read took %s", elapsed)
+	}
+}
+
+func TestProxy_ForwardsAndTunnels(t *testing.T) {
+	body := strings.Repeat("skill ", 1000)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	})
+	plain := httptest.NewServer(handler)
+	defer plain.Close()
+	tls := httptest.NewTLSServer(handler)
+	defer tls.Close()
+
+	proxy, err := StartProxy(1024 * 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL())
+
+	transport := tls.Client().Transport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(proxyURL)
+	client := &http.Client{Transport: transport, Timeout: 10 * time.Second}
+
+	// Plain HTTP is forwarded, HTTPS goes through a CONNECT tunnel
+	for _, server := range []*httptest.Server{plain, tls} {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("GET %s: %v", server.URL, err)
+		}
+		got, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != body {
+			t.Errorf("GET %s returned %d bytes, want %d", server.URL, len(got), len(body))
+		}
+	}
+}
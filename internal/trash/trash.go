@@ -0,0 +1,332 @@
+// Package trash keeps skills that lazyas removes or overwrites in
+// ~/.lazyas/trash/ for a retention window, so they can be restored.
+package trash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"lazyas/internal/manifest"
+)
+
+// Reasons an item was trashed
+const (
+	ReasonRemove    = "remove"
+	ReasonOverwrite = "overwrite"
+	ReasonMigrate   = "migrate"
+	ReasonClean     = "clean"
+)
+
+const (
+	itemFile   = "item.yaml"
+	contentDir = "content"
+)
+
+// Item is a trashed skill directory or symlink
+type Item struct {
+	ID        string                   `yaml:"-"`
+	Name      string                   `yaml:"name"`
+	Path      string                   `yaml:"path"`           // where it was, and is restored to
+	Link      string                   `yaml:"link,omitempty"` // target when Path was a symlink
+	Reason    string                   `yaml:"reason"`
+	DeletedAt time.Time                `yaml:"deleted_at"`
+	Skill     *manifest.InstalledSkill `yaml:"skill,omitempty"` // manifest entry at the time, if tracked
+}
+
+// Store keeps items as <Dir>/<id>/item.yaml plus the files under
+// <Dir>/<id>/content. For a symlink the content is a copy of its target.
+type Store struct {
+	Dir       string
+	Retention time.Duration // 0 keeps items until emptied
+}
+
+// NewStore creates a trash rooted at dir
+func NewStore(dir string, retention time.Duration) *Store {
+	return &Store{Dir: dir, Retention: retention}
+}
+
+// Move puts what is at path in the trash and removes it from path. Items
+// past the retention window are purged first.
+func (s *Store) Move(path, name, reason string, skill *manifest.InstalledSkill) (*Item, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	s.Purge(time.Now())
+
+	item := &Item{Name: name, Path: path, Reason: reason, DeletedAt: time.Now().UTC(), Skill: skill}
+	dir, err := s.newItemDir(item)
+	if err != nil {
+		return nil, err
+	}
+	content := filepath.Join(dir, contentDir)
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		if item.Link, err = os.Readlink(path); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		// A dangling link has nothing to keep but the link itself
+		if target, err := filepath.EvalSymlinks(path); err == nil {
+			if err := copyTree(target, content); err != nil {
+				os.RemoveAll(dir)
+				return nil, fmt.Errorf("failed to copy %s to the trash: %w", name, err)
+			}
+		}
+		if err := writeItem(dir, item); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		if err := os.Remove(path); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		return item, nil
+	}
+
+	if err := writeItem(dir, item); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	if err := os.Rename(path, content); err != nil {
+		// Different filesystems: copy, then delete the original
+		if err := copyTree(path, content); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to move %s to the trash: %w", name, err)
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+// Discard returns a function that trashes a path under its base name, for
+// cleanups that take a delete function
+func (s *Store) Discard(reason string) func(path string) error {
+	return func(path string) error {
+		_, err := s.Move(path, filepath.Base(path), reason, nil)
+		return err
+	}
+}
+
+// newItemDir creates the directory for a new item and sets its ID
+func (s *Store) newItemDir(item *Item) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	base := item.DeletedAt.Format("20060102-150405") + "-" + item.Name
+	for i := 1; ; i++ {
+		item.ID = base
+		if i > 1 {
+			item.ID = fmt.Sprintf("%s.%d", base, i)
+		}
+		dir := filepath.Join(s.Dir, item.ID)
+		err := os.Mkdir(dir, 0755)
+		if err == nil {
+			return dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", fmt.Errorf("failed to create trash directory: %w", err)
+		}
+	}
+}
+
+func writeItem(dir string, item *Item) error {
+	data, err := yaml.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, itemFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write trash entry: %w", err)
+	}
+	return nil
+}
+
+// List returns the trashed items, newest first
+func (s *Store) List() ([]Item, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.Dir, e.Name(), itemFile))
+		if err != nil {
+			continue
+		}
+		var item Item
+		if err := yaml.Unmarshal(data, &item); err != nil {
+			continue
+		}
+		item.ID = e.Name()
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.After(items[j].DeletedAt)
+		}
+		return items[i].ID > items[j].ID
+	})
+	return items, nil
+}
+
+// Find returns the item with the given ID, or the newest item for a skill
+// name
+func (s *Store) Find(ref string) (*Item, error) {
+	items, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.ID == ref {
+			return &item, nil
+		}
+	}
+	for _, item := range items {
+		if item.Name == ref {
+			return &item, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not in the trash", ref)
+}
+
+// Restore puts an item back where it was and drops it from the trash. A
+// symlink is recreated while its target exists, otherwise the kept copy
+// is restored as a directory. Nothing may exist at the original path.
+func (s *Store) Restore(id string) (*Item, error) {
+	item, err := s.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Lstat(item.Path); err == nil {
+		return nil, fmt.Errorf("%s already exists; remove it first", item.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(item.Path), 0755); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(s.Dir, item.ID)
+	content := filepath.Join(dir, contentDir)
+	switch {
+	case item.Link != "" && targetExists(item.Path, item.Link):
+		if err := os.Symlink(item.Link, item.Path); err != nil {
+			return nil, err
+		}
+	case item.Link != "" && !exists(content):
+		return nil, fmt.Errorf("the target of %s is gone: %s", item.Name, item.Link)
+	default:
+		if err := os.Rename(content, item.Path); err != nil {
+			if err := copyTree(content, item.Path); err != nil {
+				os.RemoveAll(item.Path)
+				return nil, fmt.Errorf("failed to restore %s: %w", item.Name, err)
+			}
+		}
+	}
+	return item, os.RemoveAll(dir)
+}
+
+// Delete removes an item from the trash for good
+func (s *Store) Delete(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return fmt.Errorf("invalid trash item %q", id)
+	}
+	return os.RemoveAll(filepath.Join(s.Dir, id))
+}
+
+// Empty removes every item and returns how many there were
+func (s *Store) Empty() (int, error) {
+	items, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range items {
+		if err := s.Delete(item.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(items), nil
+}
+
+// Purge removes the items trashed longer than the retention window before
+// now and returns how many it removed
+func (s *Store) Purge(now time.Time) int {
+	if s.Retention <= 0 {
+		return 0
+	}
+	items, _ := s.List()
+	purged := 0
+	for _, item := range items {
+		if now.Sub(item.DeletedAt) > s.Retention && s.Delete(item.ID) == nil {
+			purged++
+		}
+	}
+	return purged
+}
+
+// Expires returns when an item will be purged, or the zero time when it
+// is kept until emptied
+func (s *Store) Expires(item Item) time.Time {
+	if s.Retention <= 0 {
+		return time.Time{}
+	}
+	return item.DeletedAt.Add(s.Retention)
+}
+
+func targetExists(link, target string) bool {
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(link), target)
+	}
+	return exists(target)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// copyTree copies src to dst, keeping symlinks as they are
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		out := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(out, info.Mode().Perm()|0700)
+		case d.Type()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, out)
+		default:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(out, data, info.Mode().Perm())
+		}
+	})
+}
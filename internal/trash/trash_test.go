@@ -0,0 +1,131 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lazyas/internal/manifest"
+)
+
+func writeSkill(t *testing.T, dir, body string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMoveAndRestore_Directory(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "trash"), 0)
+	skill := filepath.Join(tmp, "skills", "pdf")
+	writeSkill(t, skill, "local edits")
+
+	item, err := store.Move(skill, "pdf", ReasonRemove, &manifest.InstalledSkill{Commit: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Lstat(skill); !os.IsNotExist(err) {
+		t.Fatal("Expected the skill to be gone from its directory")
+	}
+
+	items, _ := store.List()
+	if len(items) != 1 || items[0].ID != item.ID || items[0].Reason != ReasonRemove || items[0].Skill.Commit != "abc" {
+		t.Fatalf("Unexpected trash contents: %+v", items)
+	}
+
+	if _, err := store.Restore("pdf"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(skill, "SKILL.md"))
+	if err != nil || string(data) != "local edits" {
+		t.Errorf("Expected the files back, got %q %v", data, err)
+	}
+	if items, _ := store.List(); len(items) != 0 {
+		t.Errorf("Expected the item to leave the trash, got %+v", items)
+	}
+}
+
+func TestMoveAndRestore_Symlink(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "trash"), 0)
+	target := filepath.Join(tmp, "repos", "skills", "pdf")
+	writeSkill(t, target, "from the repo")
+	link := filepath.Join(tmp, "skills", "pdf")
+	os.MkdirAll(filepath.Dir(link), 0755)
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := store.Move(link, "pdf", ReasonOverwrite, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Link != target {
+		t.Errorf("Expected the link target to be recorded, got %q", item.Link)
+	}
+	if _, err := os.Stat(filepath.Join(target, "SKILL.md")); err != nil {
+		t.Fatal("Expected the link target to be left alone")
+	}
+
+	// The target is gone by the time of the restore: the copy comes back
+	os.RemoveAll(target)
+	if _, err := store.Restore(item.ID); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Lstat(link)
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("Expected a directory restored from the copy, got %v %v", info, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(link, "SKILL.md")); string(data) != "from the repo" {
+		t.Errorf("Unexpected restored content %q", data)
+	}
+}
+
+func TestRestore_RefusesToOverwrite(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "trash"), 0)
+	skill := filepath.Join(tmp, "skills", "pdf")
+	writeSkill(t, skill, "old")
+	if _, err := store.Move(skill, "pdf", ReasonOverwrite, nil); err != nil {
+		t.Fatal(err)
+	}
+	writeSkill(t, skill, "new")
+
+	if _, err := store.Restore("pdf"); err == nil {
+		t.Error("Expected restore to refuse replacing an existing skill")
+	}
+}
+
+func TestPurge_DropsExpiredItems(t *testing.T) {
+	tmp := t.TempDir()
+	store := NewStore(filepath.Join(tmp, "trash"), 24*time.Hour)
+	for _, name := range []string{"old", "new"} {
+		dir := filepath.Join(tmp, "skills", name)
+		writeSkill(t, dir, name)
+		if _, err := store.Move(dir, name, ReasonRemove, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := store.Purge(time.Now().Add(2 * time.Hour)); n != 0 {
+		t.Errorf("Expected nothing purged within the window, got %d", n)
+	}
+	if n := store.Purge(time.Now().Add(48 * time.Hour)); n != 2 {
+		t.Errorf("Expected both items purged, got %d", n)
+	}
+
+	forever := NewStore(store.Dir, 0)
+	writeSkill(t, filepath.Join(tmp, "skills", "kept"), "kept")
+	forever.Move(filepath.Join(tmp, "skills", "kept"), "kept", ReasonRemove, nil)
+	if n := forever.Purge(time.Now().Add(10000 * time.Hour)); n != 0 {
+		t.Errorf("Expected no retention to keep items, purged %d", n)
+	}
+	if n, err := forever.Empty(); err != nil || n != 1 {
+		t.Errorf("Expected empty to delete 1 item, got %d %v", n, err)
+	}
+}
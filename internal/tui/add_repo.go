@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/remote"
+)
+
+// reachTimeout bounds the ls-remote run when a repository is submitted
+const reachTimeout = 15 * time.Second
+
+// Controls of the add repository form, in tab order
+const (
+	addRepoFieldName = iota
+	addRepoFieldURL
+	addRepoSubmit
+	addRepoCancel
+	addRepoControls // number of controls
+)
+
+// readClipboard returns the clipboard text; tests replace it
+var readClipboard = clipboard.ReadAll
+
+// repoURLCheckedMsg reports whether a submitted repository URL answered
+type repoURLCheckedMsg struct {
+	name string
+	url  string
+	err  error
+}
+
+// openAddRepo shows the Add Repository form, or the edit form when
+// editRepo names the repo being edited. A new, empty form is prefilled with
+// a GitHub or GitLab repository found on the clipboard.
+func (a *App) openAddRepo(name, url, editRepo string) (tea.Model, tea.Cmd) {
+	a.addRepoErr = ""
+	a.addRepoHint = ""
+	a.addRepoChecking = false
+	a.addRepoAutoName = false
+	if url == "" && editRepo == "" {
+		if text, err := readClipboard(); err == nil {
+			if clone, ok := remote.CloneURL(text); ok && !a.repoConfigured(clone) {
+				url = clone
+				name = a.suggestRepoName(clone)
+				a.addRepoAutoName = true
+				a.addRepoHint = i18n.T("URL from the clipboard")
+			}
+		}
+	}
+
+	a.addRepoName.SetValue(name)
+	a.addRepoURL.SetValue(url)
+	a.setAddRepoFocus(addRepoFieldName)
+	a.editRepo = editRepo
+	a.mode = ModeAddRepo
+	return a, textinput.Blink
+}
+
+// setAddRepoFocus moves the focus of the add repository form; only a
+// focused field shows its cursor
+func (a *App) setAddRepoFocus(focus int) {
+	a.addRepoFocus = focus
+	a.addRepoName.Blur()
+	a.addRepoURL.Blur()
+	switch focus {
+	case addRepoFieldName:
+		a.addRepoName.Focus()
+	case addRepoFieldURL:
+		a.addRepoURL.Focus()
+	}
+}
+
+// repoConfigured reports whether a repo with url is already configured
+func (a *App) repoConfigured(url string) bool {
+	for _, repo := range a.cfg.Repos {
+		if repo.URL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// addRepoInput passes a key to the focused field and revalidates. While the
+// name is empty or was filled in automatically it follows the URL. A URL
+// pasted into the name field goes to the URL field, and pasted browser
+// addresses of GitHub and GitLab repos become clone URLs.
+func (a *App) addRepoInput(msg tea.KeyMsg) tea.Cmd {
+	a.addRepoHint = ""
+	var cmd tea.Cmd
+	if msg.Paste && a.addRepoFocus == addRepoFieldName && git.CheckURL(strings.TrimSpace(string(msg.Runes))) == nil && strings.ContainsAny(string(msg.Runes), ":/") {
+		a.setAddRepoFocus(addRepoFieldURL)
+		a.addRepoURL.SetValue("")
+	}
+	if a.addRepoFocus == addRepoFieldName {
+		before := a.addRepoName.Value()
+		a.addRepoName, cmd = a.addRepoName.Update(msg)
+		if a.addRepoName.Value() != before {
+			a.addRepoAutoName = false
+			a.addRepoErr = ""
+		}
+		return cmd
+	}
+
+	a.addRepoURL, cmd = a.addRepoURL.Update(msg)
+	if msg.Paste {
+		if clone, ok := remote.CloneURL(a.addRepoURL.Value()); ok {
+			a.addRepoURL.SetValue(clone)
+		}
+	}
+	url := strings.TrimSpace(a.addRepoURL.Value())
+	a.addRepoErr = ""
+	if url != "" {
+		if err := git.CheckURL(url); err != nil {
+			a.addRepoErr = err.Error()
+		}
+	}
+	if a.editRepo == "" && (a.addRepoAutoName || a.addRepoName.Value() == "") {
+		a.addRepoName.SetValue(a.suggestRepoName(url))
+		a.addRepoAutoName = a.addRepoName.Value() != ""
+	}
+	return cmd
+}
+
+// suggestRepoName derives a repo name from its URL, qualifying it with the
+// owner when a configured repo already has the plain name
+func (a *App) suggestRepoName(url string) string {
+	if git.CheckURL(url) != nil {
+		return ""
+	}
+	name := git.RepoName(url)
+	if a.cfg.GetRepo(name) != nil {
+		if qualified := git.RepoDirName(url); qualified != name {
+			return qualified
+		}
+	}
+	return name
+}
+
+// submitAddRepo validates the form and, when the URL changed, checks that
+// it answers before the repository is inspected or saved
+func (a *App) submitAddRepo() (tea.Model, tea.Cmd) {
+	if a.addRepoChecking {
+		return a, nil
+	}
+	name := strings.TrimSpace(a.addRepoName.Value())
+	url := strings.TrimSpace(a.addRepoURL.Value())
+	if name == "" {
+		name = a.suggestRepoName(url)
+		a.addRepoName.SetValue(name)
+	}
+
+	if err := git.CheckURL(url); err != nil {
+		a.addRepoErr = err.Error()
+		return a, nil
+	}
+	if name == "" {
+		a.addRepoErr = i18n.T("Name is required")
+		return a, nil
+	}
+	if name != a.editRepo && a.cfg.GetRepo(name) != nil {
+		a.addRepoErr = i18n.Tf("A repository named '%s' already exists", name)
+		return a, nil
+	}
+
+	if repo := a.cfg.GetRepo(a.editRepo); a.gitErr != nil || (repo != nil && repo.URL == url) {
+		return a.applyAddRepo(name, url)
+	}
+	a.addRepoErr = ""
+	a.addRepoChecking = true
+	return a, func() tea.Msg {
+		return repoURLCheckedMsg{name, url, git.CheckReachable(url, reachTimeout)}
+	}
+}
+
+// handleRepoURLChecked continues a submission once ls-remote answered. A
+// result for a form that was closed or changed meanwhile is dropped.
+func (a *App) handleRepoURLChecked(msg repoURLCheckedMsg) (tea.Model, tea.Cmd) {
+	if a.mode != ModeAddRepo || !a.addRepoChecking || strings.TrimSpace(a.addRepoURL.Value()) != msg.url {
+		return a, nil
+	}
+	a.addRepoChecking = false
+	if msg.err != nil {
+		a.addRepoErr = msg.err.Error()
+		return a, nil
+	}
+	return a.applyAddRepo(msg.name, msg.url)
+}
+
+// applyAddRepo saves an edited repo, or fetches a new one once so the user
+// can review it before it is added
+func (a *App) applyAddRepo(name, url string) (tea.Model, tea.Cmd) {
+	if a.editRepo != "" {
+		a.loadingMsg = i18n.T("Updating repository...")
+		a.mode = ModeLoading
+		return a, tea.Batch(
+			a.editRepoCmd(a.editRepo, name, url),
+			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		)
+	}
+
+	a.loadingMsg = i18n.T("Inspecting repository...")
+	a.mode = ModeLoading
+	return a, tea.Batch(
+		a.inspectRepos([]config.Repo{{Name: name, URL: url}}, false),
+		tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+	)
+}
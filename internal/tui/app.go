@@ -1,24 +1,47 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+	"lazyas/internal/adhoc"
 	"lazyas/internal/config"
+	"lazyas/internal/fulltext"
 	"lazyas/internal/git"
+	"lazyas/internal/history"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
 	"lazyas/internal/manifest"
+	"lazyas/internal/notify"
+	"lazyas/internal/progress"
 	"lazyas/internal/registry"
+	"lazyas/internal/release"
+	"lazyas/internal/remote"
+	"lazyas/internal/scan"
+	"lazyas/internal/semver"
+	"lazyas/internal/skillpolicy"
 	"lazyas/internal/symlink"
+	"lazyas/internal/trash"
 	"lazyas/internal/tui/layout"
 	"lazyas/internal/tui/panels"
+	"lazyas/internal/tui/styles"
+	"lazyas/internal/usage"
+	"lazyas/internal/watch"
 )
 
 // Mode represents the application mode
@@ -33,6 +56,13 @@ const (
 	ModeStarterKit
 	ModeUpdateResult
 	ModeError
+	ModeVersionPicker
+	ModeManifest
+	ModeQueue
+	ModeLegend
+	ModeSettings
+	ModeTags
+	ModeRepos
 )
 
 // ConfirmAction represents the action to confirm
@@ -43,6 +73,11 @@ const (
 	ConfirmRemove
 	ConfirmRemoveRepo
 	ConfirmOverwrite
+	ConfirmTrustRepo
+	ConfirmAdopt
+	ConfirmKeepLocal
+	ConfirmDiscard
+	ConfirmInstallAs
 )
 
 // App is the main TUI application model
@@ -51,6 +86,8 @@ type App struct {
 	registry *registry.Registry
 	manifest *manifest.Manager
 
+	manifestMu sync.Mutex // serializes manifest writes of concurrent updates
+
 	// Layout
 	layout *layout.PanelLayout
 
@@ -63,8 +100,30 @@ type App struct {
 	confirmAction ConfirmAction
 	confirmSkill  *registry.SkillEntry
 	confirmRepo   string // Repo name for removal confirmation
+	adoptName     string // local skill replaced by confirmSkill on ConfirmAdopt
+	installAs     string // free name to install confirmSkill under on ConfirmInstallAs
+	occupant      string // what holds confirmSkill's name on ConfirmInstallAs (manifest.Occupant)
 	confirmSel    int    // 0 = yes, 1 = no
 
+	// Skills installed from the repo on ConfirmRemoveRepo, and whether to
+	// remove them along with it rather than keep them orphaned
+	repoSkills      []string
+	repoSkillsPurge bool
+
+	// Install size estimate shown in the install confirmation
+	confirmSize        *remote.SizeEstimate
+	confirmSizeCloned  bool // repo clone already exists, nothing to download
+	confirmSizeLoading bool
+
+	// Risk scan of the skill files, when they are already available locally
+	confirmRisk        *scan.Report
+	confirmRiskLoading bool
+
+	// Repositories awaiting trust confirmation before they are added
+	trustRepos      []config.Repo
+	trustSummaries  []*registry.RepoSummary
+	trustStarterKit bool
+
 	// Loading
 	loadingMsg string
 	spinnerIdx int
@@ -72,20 +131,74 @@ type App struct {
 	// Add repo dialog
 	addRepoName  textinput.Model
 	addRepoURL   textinput.Model
-	addRepoFocus int // 0 = name, 1 = url
+	addRepoFocus int    // addRepoFieldName, addRepoFieldURL or a button
+	editRepo     string // repo being edited with the add-repo form; "" when adding
+
+	addRepoErr      string // inline validation error under the URL field
+	addRepoHint     string // note under the URL field, e.g. where the URL came from
+	addRepoChecking bool   // ls-remote of the submitted URL is running
+	addRepoAutoName bool   // name was derived from the URL and follows it
 
 	// Backend setup
 	backendStatuses  []symlink.LinkStatus
 	backendSelection []bool // Checkboxes for backend setup
 	backendCursor    int    // Cursor in backend setup modal
+	backendFocus     int    // 0 = list, 1 = link button, 2 = skip button
+	backendProblems  []symlink.Problem
 
 	// Starter kit
 	starterKitSelection []bool
 	starterKitCursor    int
+	starterKitFocus     int // 0 = list, 1 = add button, 2 = skip button
 
 	// Update results
 	updateResult *updateDoneMsg
 
+	// Version picker
+	versionSkill    string
+	versionTags     []git.RemoteTag
+	versionCursor   int
+	versionModified bool // skill has local changes that block switching
+
+	// Manifest browser
+	manifestRows   []manifestRow
+	manifestCursor int
+	manifestSort   manifestSort
+
+	// Settings screen
+	settings        []setting
+	settingsCursor  int
+	settingsEditing bool // the selected setting is being typed in
+	settingsInput   textinput.Model
+	settingsErr     string // why the last change was refused
+
+	// Tag filter
+	tags      []tagCount
+	tagCursor int
+	tagOffset int
+
+	// Repository scope and picker
+	repoScope  string // configured repository the list is scoped to; "" = all
+	repos      []repoCount
+	repoCursor int
+	repoOffset int
+
+	// Action queue, run together after a single review
+	queue        []queueItem
+	queueCursor  int
+	queueResults []queueResult // outcome of the last run; nil while reviewing
+	failed       []failedOp    // failed installs and updates, listed below the queue
+
+	// Undo and redo of view changes and queue edits made this session
+	history undoHistory
+
+	// Local usage stats of this session, flushed when the TUI exits
+	usage usage.Counts
+
+	// Progress of the running long operation, drawn in the footer while
+	// loading; esc cancels it
+	progress *progress.Tracker
+
 	// Error modal
 	errorTitle  string
 	errorDetail string
@@ -97,6 +210,37 @@ type App struct {
 	// Staleness
 	outdated map[string]bool
 
+	// Whether installed commits still exist upstream, by commit
+	provenance map[string]git.Provenance
+
+	// Signatures of installed commits, by skill; only with verify_signatures
+	signatures map[string]git.Signature
+
+	// Background index refresh; a finished refresh waits in pendingRefresh
+	// until the user applies it
+	refreshSeq     int
+	pendingRefresh *refreshReadyMsg
+
+	// Skills directory watcher; changes made by other processes refresh
+	// the installed/modified state. nil when watching is unavailable.
+	watcher *watch.Watcher
+
+	// Set when no git binary was found at startup: the cached index is
+	// shown and actions that need git are disabled
+	gitErr error
+
+	// Set when status_icons or status_precedence is invalid; the list
+	// keeps the default icons and order
+	statusDisplayErr error
+
+	// Set when the [theme] section is invalid; the default theme is used
+	themeErr error
+
+	// Remote SKILL.md previews for skills that are not installed
+	previews   *remote.PreviewCache
+	previewed  map[string]previewLoadedMsg // fetched since the last refresh, by previewKey
+	previewSeq int                         // debounces fetches while scrolling
+
 	// State
 	message string
 	err     error
@@ -122,47 +266,51 @@ type AppStyles struct {
 	Button       lipgloss.Style
 	ButtonActive lipgloss.Style
 	Muted        lipgloss.Style
+	Warning      lipgloss.Style
 }
 
 func defaultAppStyles() AppStyles {
+	t := styles.Current()
 	return AppStyles{
 		Title: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#7C3AED")).
+			Foreground(t.Primary).
 			MarginBottom(1),
 		StatusBar: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")),
+			Foreground(t.Muted),
 		HelpKey: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#7C3AED")),
+			Foreground(t.Primary),
 		HelpText: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")),
+			Foreground(t.Muted),
 		ActivePanel: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7C3AED")),
+			BorderForeground(t.Primary),
 		Panel: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#374151")),
+			BorderForeground(t.Border),
 		Error: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#EF4444")).
+			Foreground(t.Danger).
 			Bold(true),
 		Success: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#10B981")).
+			Foreground(t.Success).
 			Bold(true),
 		ConfirmBox: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7C3AED")).
+			BorderForeground(t.Primary).
 			Padding(1, 2),
 		Button: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
+			Foreground(t.Text).
 			Padding(0, 2),
 		ButtonActive: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(lipgloss.Color("#7C3AED")).
+			Foreground(t.SelectedText).
+			Background(t.Primary).
 			Bold(true).
 			Padding(0, 2),
 		Muted: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")),
+			Foreground(t.Muted),
+		Warning: lipgloss.NewStyle().
+			Foreground(t.Warning),
 	}
 }
 
@@ -176,17 +324,21 @@ func NewApp(cfg *config.Config) *App {
 	urlInput.Placeholder = "https://github.com/org/skills-repo"
 	urlInput.CharLimit = 200
 
-	return &App{
+	a := &App{
 		cfg:         cfg,
 		registry:    registry.NewRegistry(cfg),
 		manifest:    manifest.NewManager(cfg),
 		layout:      layout.NewPanelLayout(),
 		mode:        ModeLoading,
-		loadingMsg:  "Fetching skill index...",
-		styles:      defaultAppStyles(),
+		loadingMsg:  i18n.T("Fetching skill index..."),
 		addRepoName: nameInput,
 		addRepoURL:  urlInput,
+		previews:    remote.NewPreviewCache(cfg.PreviewCacheDir, time.Duration(cfg.CacheTTL)*time.Hour),
+		previewed:   make(map[string]previewLoadedMsg),
+		gitErr:      git.Available(),
 	}
+	a.applyTheme()
+	return a
 }
 
 // Init initializes the application
@@ -194,41 +346,123 @@ func (a *App) Init() tea.Cmd {
 	return tea.Batch(
 		a.fetchIndex,
 		tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		a.waitForSkillsChange(),
 	)
 }
 
+// waitForSkillsChange blocks until the watcher reports a change to the
+// skills directory
+func (a *App) waitForSkillsChange() tea.Cmd {
+	if a.watcher == nil {
+		return nil
+	}
+	events := a.watcher.Events
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return nil
+		}
+		return skillsChangedMsg{ev}
+	}
+}
+
 // Messages
 type (
-	indexFetchedMsg  struct{ outdated map[string]bool }
-	indexErrorMsg    struct{ err error }
-	installDoneMsg   struct{ skill string }
+	indexFetchedMsg struct{ outdated map[string]bool }
+	indexErrorMsg   struct{ err error }
+	installDoneMsg  struct {
+		skill string
+		clash string // installed skill whose name differs only by case
+	}
 	installErrMsg    struct{ err error }
 	removeDoneMsg    struct{ skill string }
 	removeErrMsg     struct{ err error }
 	repoAddedMsg     struct{ name string }
 	repoAddErrMsg    struct{ err error }
-	repoRemovedMsg   struct{ name string }
+	repoInspectedMsg struct {
+		repos      []config.Repo
+		summaries  []*registry.RepoSummary
+		starterKit bool
+	}
+	repoInspectErrMsg struct{ err error }
+	repoRemovedMsg    struct {
+		name     string
+		removed  []string // skills removed along with the repo
+		orphaned []string // skills kept without a source repo
+	}
 	repoRemoveErrMsg struct{ err error }
+	repoEditedMsg    struct{ name string }
+	repoEditErrMsg   struct{ err error }
 	syncDoneMsg      struct{ skillCount int }
 	syncErrMsg       struct{ err error }
 	updateDoneMsg    struct {
-		updated int
-		skipped int
-		failed  int
-		results []updateSkillResult
+		updated   int
+		skipped   int
+		failed    int
+		cancelled int // skills not reached before the run was cancelled
+		results   []updateSkillResult
+		title     string // set when replaying a recorded run
+		running   bool   // results are still coming in (updateSkillMsg)
+		total     int    // skills in a running update
 	}
 	updateErrMsg       struct{ err error }
 	backendLinkDoneMsg struct{ linked int }
 	backendLinkErrMsg  struct{ err error }
+	backendRepairedMsg struct{ repaired int }
 	starterKitDoneMsg  struct{ count int }
 	starterKitErrMsg   struct{ err error }
-	tickMsg            struct{}
-	glowDoneMsg        struct{ err error }
+	versionsLoadedMsg  struct {
+		name string
+		tags []git.RemoteTag
+	}
+	versionsErrMsg       struct{ err error }
+	versionSwitchDoneMsg struct{ name, ref string }
+	versionSwitchErrMsg  struct{ err error }
+	mergeDoneMsg         struct {
+		name      string
+		conflicts []string
+	}
+	mergeErrMsg      struct{ err error }
+	sizeEstimatedMsg struct {
+		name   string
+		est    remote.SizeEstimate
+		cloned bool
+	}
+	riskScannedMsg struct {
+		name   string
+		report *scan.Report // nil when the skill is not available locally
+	}
+	previewDueMsg struct {
+		seq   int
+		skill registry.SkillEntry
+	}
+	previewLoadedMsg struct {
+		key     string
+		content string
+		err     error
+	}
+	skillsChangedMsg struct{ event watch.Event }
+	refreshDueMsg    struct{ seq int }
+	refreshReadyMsg  struct {
+		seq       int
+		registry  *registry.Registry
+		outdated  map[string]bool
+		newSkills int
+		updates   int
+		updated   []string // skills newly found outdated, sorted
+	}
+	tickMsg     struct{}
+	glowDoneMsg struct {
+		err error
+		tmp string // temporary copy of a previewed SKILL.md to delete
+	}
 )
 
 type updateSkillResult struct {
-	name   string
-	status string // "updated", "skipped", "failed", "up-to-date"
+	name    string
+	status  string   // "updated", "skipped", "failed", "up-to-date", "held", "invalid", "blocked"
+	changes []string // changelog lines for updated skills
+	problem string   // validation or skill policy error for "invalid" and "blocked"
 }
 
 func (a *App) fetchIndex() tea.Msg {
@@ -248,41 +482,49 @@ func (a *App) doFetchIndex(force bool) tea.Msg {
 		return indexErrorMsg{err}
 	}
 
-	outdated := a.checkStaleness()
+	outdated := a.checkStaleness(a.registry, a.manifest.ListInstalled())
 	return indexFetchedMsg{outdated: outdated}
 }
 
-// checkStaleness groups installed skills by source repo and checks each unique
-// repo for remote updates. Returns a map of outdated skill names.
-func (a *App) checkStaleness() map[string]bool {
-	installed := a.manifest.ListInstalled()
+// checkStaleness compares each installed skill with what 'lazyas update'
+// would move it to: its tracked branch or registry tag (from reg) on the
+// remote, or the newest version within its pin. Each repo and ref is
+// resolved once. Returns a map of outdated skill names.
+func (a *App) checkStaleness(reg *registry.Registry, installed map[string]manifest.InstalledSkill) map[string]bool {
 	if len(installed) == 0 {
 		return nil
 	}
 
-	// Group skill names by repo dir
-	repoSkills := make(map[string][]string) // repoDir -> []skillName
+	result := make(map[string]bool)
+	remote := make(map[string]string) // repoDir + "\x00" + ref -> commit, "" when unresolved
 	for name, info := range installed {
 		if info.SourceRepo == "" {
 			continue
 		}
 		repoDir := filepath.Join(a.cfg.ReposDir, git.RepoDirName(info.SourceRepo))
-		repoSkills[repoDir] = append(repoSkills[repoDir], name)
-	}
-
-	result := make(map[string]bool)
-	for repoDir, names := range repoSkills {
 		if _, err := os.Stat(repoDir); err != nil {
 			continue // repo dir missing, skip
 		}
-		outdated, err := git.IsRepoOutdated(repoDir)
-		if err != nil {
-			continue // silently ignore errors
-		}
-		if outdated {
-			for _, name := range names {
+		if info.Pin != "" {
+			if git.PinTarget(repoDir, info.RegistryName(name), info.SourcePath, info.Pin) != info.Version {
 				result[name] = true
 			}
+			continue
+		}
+
+		registryTag := ""
+		if skill := reg.GetSkill(info.RegistryName(name)); skill != nil && !info.AdHoc {
+			registryTag = skill.Source.Tag
+		}
+		ref := info.TargetRef(registryTag)
+		key := repoDir + "\x00" + ref
+		commit, ok := remote[key]
+		if !ok {
+			commit, _ = git.RemoteRef(repoDir, ref) // errors leave the skill as is
+			remote[key] = commit
+		}
+		if commit != "" && commit != info.Commit {
+			result[name] = true
 		}
 	}
 
@@ -299,9 +541,13 @@ func (a *App) initPanels() {
 	modified := make(map[string]bool)
 	localOnly := make(map[string]bool)
 	manifestInstalled := a.manifest.ListInstalled()
+	orphaned := a.orphanedSkills()
 	for name, local := range localSkills {
-		if mi, tracked := manifestInstalled[name]; tracked {
+		if mi, tracked := manifestInstalled[name]; tracked && !orphaned[name] {
 			installed[name] = mi.SourceRepo
+		} else if tracked {
+			// No registry lists it any more; it is shown from disk
+			installed[name] = local.Path
 		} else {
 			installed[name] = local.Path
 			localOnly[name] = true
@@ -316,8 +562,10 @@ func (a *App) initPanels() {
 
 	// Preserve collapse state from existing panel or config
 	var collapseMap map[string]bool
+	var marked []registry.SkillEntry
 	if a.skills != nil {
 		collapseMap = a.skills.GetCollapseMap()
+		marked = a.skills.Marked()
 	} else if len(a.cfg.CollapsedGroups) > 0 {
 		collapseMap = make(map[string]bool, len(a.cfg.CollapsedGroups))
 		for _, name := range a.cfg.CollapsedGroups {
@@ -327,11 +575,22 @@ func (a *App) initPanels() {
 
 	// Create panels
 	a.skills = panels.NewSkillsPanel(skills, installed, modified)
+	a.skills.SetMarked(marked)
 	if collapseMap != nil {
 		a.skills.SetCollapseMap(collapseMap)
 	}
 	a.skills.SetLocalOnly(localOnly)
+	a.skills.SetOrphaned(orphaned)
 	a.skills.SetOutdated(a.outdated)
+	a.skills.SetUnreachable(a.unreachableSkills())
+	a.skills.SetVerified(a.verifiedSkills())
+	a.skills.SetEnabled(a.enabledBackends(installed))
+	a.skills.SetSynced(a.repoSyncTimes())
+	if a.repoScope != "" && a.cfg.GetRepo(a.repoScope) == nil {
+		a.repoScope = ""
+	}
+	a.skills.SetRepoFilter(a.repoScope)
+	a.statusDisplayErr = a.skills.SetStatusDisplay(a.cfg.StatusIcons, a.cfg.StatusPrecedence)
 	a.skills.SetFocused(true)
 	a.skills.SetSize(a.layout.LeftContentWidth(), a.layout.ContentHeight())
 
@@ -375,6 +634,7 @@ func mergeSkills(registrySkills []registry.SkillEntry, localSkills map[string]ma
 			result = append(result, registry.SkillEntry{
 				Name:        name,
 				Description: local.Description,
+				Tags:        local.Tags,
 				Source: registry.SkillSource{
 					Repo: local.Path,
 				},
@@ -398,6 +658,7 @@ func (a *App) updateDetailPanel() {
 
 	var installed *manifest.InstalledSkill
 	if info, ok := a.manifest.GetInstalled(skill.Name); ok {
+		info.Orphaned = info.Orphaned || a.skills.IsOrphaned(skill.Name)
 		installed = &info
 	}
 
@@ -409,6 +670,76 @@ func (a *App) updateDetailPanel() {
 
 	a.detail.SetSkill(skill, installed, local, a.cfg.SkillsDir)
 	a.detail.SetOutdated(a.outdated[skill.Name])
+	a.detail.SetProvenance(a.skillProvenance(skill.Name))
+	a.detail.SetSignature(a.skillSignature(skill.Name))
+	if local != nil && installed == nil {
+		a.detail.SetDuplicate(a.findDuplicate(skill.Name, local))
+	}
+
+	if p, ok := a.previewed[previewKey(*skill)]; ok && a.detail.NeedsRemoteSkillMD() {
+		a.detail.SetRemoteSkillMD(p.content, p.err)
+	}
+}
+
+// findDuplicate returns the registry skill a local-only skill is a copy of:
+// by its SKILL.md, or by content when its files hash the same as an
+// installed skill or a checked-out registry copy
+func (a *App) findDuplicate(name string, local *manifest.LocalSkill) *registry.SkillEntry {
+	if s := a.registry.FindDuplicate(name, local.DeclaredName, local.Description, ""); s != nil {
+		return s
+	}
+	hash, err := integrity.HashDir(local.Path)
+	if err != nil {
+		return nil
+	}
+	for other, info := range a.manifest.ListInstalled() {
+		if other != name && info.Hash == hash && !info.AdHoc {
+			if s := a.registry.GetSkill(info.RegistryName(other)); s != nil {
+				return s
+			}
+		}
+	}
+	return a.registry.FindDuplicate(name, local.DeclaredName, local.Description, hash)
+}
+
+// requestPreview schedules a SKILL.md fetch for the selected skill when it
+// is not installed. The short delay keeps fast scrolling from issuing a
+// request for every row passed.
+func (a *App) requestPreview() tea.Cmd {
+	if a.skills == nil || a.detail == nil {
+		return nil
+	}
+	skill := a.skills.Selected()
+	if skill == nil || !a.detail.NeedsRemoteSkillMD() {
+		return nil
+	}
+	if _, ok := a.previewed[previewKey(*skill)]; ok {
+		return nil
+	}
+	if strings.HasPrefix(skill.Source.Repo, "/") || strings.HasPrefix(skill.Source.Repo, "~") {
+		return nil
+	}
+
+	a.previewSeq++
+	seq := a.previewSeq
+	entry := *skill
+	a.detail.SetRemoteLoading()
+	return tea.Tick(300*time.Millisecond, func(_ time.Time) tea.Msg {
+		return previewDueMsg{seq, entry}
+	})
+}
+
+func (a *App) fetchPreview(skill registry.SkillEntry) tea.Cmd {
+	return func() tea.Msg {
+		content, err := a.previews.SkillMD(skill.Source.Repo, skill.Source.Tag, skill.Source.Path)
+		return previewLoadedMsg{previewKey(skill), content, err}
+	}
+}
+
+// previewKey identifies the SKILL.md a preview shows: registries may list
+// skills of the same name, and a skill's tag changes between refreshes
+func previewKey(skill registry.SkillEntry) string {
+	return skill.Source.Repo + "\x00" + skill.Source.Tag + "\x00" + skill.Source.Path
 }
 
 // checkBackendStatus updates the backend status for the header display
@@ -427,6 +758,78 @@ func (a *App) checkBackendStatus() {
 	a.backendStatuses = statuses
 }
 
+// enabledBackends maps installed skills to the linked backends that see
+// them, for the skills list column. Without per-skill backends every
+// linked backend sees every skill, so there is no column (nil).
+func (a *App) enabledBackends(installed map[string]string) map[string][]string {
+	statuses := symlink.CheckBackendLinks(a.cfg.Backends, a.cfg.SkillsDir)
+	perSkill := false
+	for _, s := range statuses {
+		perSkill = perSkill || (s.Linked && s.Backend.PerSkill())
+	}
+	if !perSkill {
+		return nil
+	}
+
+	enabled := make(map[string][]string, len(installed))
+	for _, s := range statuses {
+		if !s.Linked {
+			continue
+		}
+		if !s.Backend.PerSkill() {
+			for name := range installed {
+				enabled[name] = append(enabled[name], s.Backend.Name)
+			}
+			continue
+		}
+		for _, name := range symlink.EnabledSkills(s.Backend, a.cfg.SkillsDir) {
+			enabled[name] = append(enabled[name], s.Backend.Name)
+		}
+	}
+	return enabled
+}
+
+// updateKey routes a key to the handler of the current mode
+func (a *App) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch a.mode {
+	case ModeNormal:
+		return a.updateNormal(msg)
+	case ModeConfirm:
+		return a.updateConfirm(msg)
+	case ModeAddRepo:
+		return a.updateAddRepo(msg)
+	case ModeBackendSetup:
+		return a.updateBackendSetup(msg)
+	case ModeStarterKit:
+		return a.updateStarterKit(msg)
+	case ModeUpdateResult:
+		return a.updateUpdateResult(msg)
+	case ModeError:
+		return a.updateError(msg)
+	case ModeVersionPicker:
+		return a.updateVersionPicker(msg)
+	case ModeManifest:
+		return a.updateManifestBrowser(msg)
+	case ModeQueue:
+		return a.updateQueue(msg)
+	case ModeLegend:
+		return a.updateLegend(msg)
+	case ModeSettings:
+		return a.updateSettings(msg)
+	case ModeTags:
+		return a.updateTagFilter(msg)
+	case ModeRepos:
+		return a.updateRepoPicker(msg)
+	case ModeLoading:
+		if msg.String() == "esc" && a.progress != nil && a.progress.Err() == nil {
+			a.progress.Cancel()
+			a.loadingMsg = i18n.T("Cancelling after the current step...")
+		}
+		return a, nil
+	}
+	return a, nil
+}
+
 // Update handles all application events
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -447,31 +850,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return a, tea.Quit
 		}
-
-		switch a.mode {
-		case ModeNormal:
-			return a.updateNormal(msg)
-		case ModeConfirm:
-			return a.updateConfirm(msg)
-		case ModeAddRepo:
-			return a.updateAddRepo(msg)
-		case ModeBackendSetup:
-			return a.updateBackendSetup(msg)
-		case ModeStarterKit:
-			return a.updateStarterKit(msg)
-		case ModeUpdateResult:
-			return a.updateUpdateResult(msg)
-		case ModeError:
-			return a.updateError(msg)
+		before, ok := a.viewState()
+		model, cmd := a.updateKey(msg)
+		if ok {
+			a.recordUndo(before)
 		}
+		return model, cmd
 
 	case indexFetchedMsg:
 		a.outdated = msg.outdated
+		clear(a.previewed)
 		a.initPanels()
 		a.checkBackendStatus()
 		// Replace stale "refreshing..." message with completion summary
 		if a.message != "" {
-			a.message = a.styles.Success.Render(fmt.Sprintf("Done. %d skill(s) available.", len(a.registry.ListSkills())))
+			a.message = a.styles.Success.Render(i18n.Tf("Done. %d skill(s) available.", len(a.registry.ListSkills())))
 		}
 		// Show backend setup modal if there are new available backends
 		if symlink.HasNewBackends(a.backendStatuses, a.cfg.DismissedBackends) {
@@ -483,6 +876,40 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			a.mode = ModeNormal
 		}
+		if found := a.cfg.WorkspaceBackends("."); len(found) > 0 {
+			a.message = a.styles.Muted.Render(i18n.Tf("This project has %s: 'lazyas backend workspace' exposes skills to it", found[0].Folder))
+		}
+		if orphaned := a.orphanedSkills(); len(orphaned) > 0 {
+			a.message = a.styles.Muted.Render(i18n.Tf("%d orphaned skill(s): their repository is no longer configured", len(orphaned)))
+		}
+		if warnings := a.registry.Warnings(); len(warnings) > 0 {
+			a.message = a.styles.Error.Render(i18n.Tf("%d problem(s) during the last sync (see 'lazyas sync')", len(warnings)))
+		}
+		if a.statusDisplayErr != nil {
+			a.message = a.styles.Error.Render(i18n.Tf("Ignoring status display settings: %v", a.statusDisplayErr))
+		}
+		if a.themeErr != nil {
+			a.message = a.styles.Error.Render(i18n.Tf("Ignoring theme settings: %v", a.themeErr))
+		}
+		if a.gitErr != nil {
+			a.message = a.noGitNotice()
+		}
+		return a, tea.Batch(a.scheduleRefresh(), a.checkProvenance(), a.checkSignatures())
+
+	case provenanceCheckedMsg:
+		a.provenance = msg.results
+		if a.skills != nil {
+			a.skills.SetUnreachable(a.unreachableSkills())
+			a.updateDetailPanel()
+		}
+		return a, nil
+
+	case signaturesCheckedMsg:
+		a.signatures = msg.results
+		if a.skills != nil {
+			a.skills.SetVerified(a.verifiedSkills())
+			a.updateDetailPanel()
+		}
 		return a, nil
 
 	case indexErrorMsg:
@@ -500,87 +927,231 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			a.mode = ModeNormal
 		}
-		return a, nil
+		if a.gitErr != nil {
+			a.message = a.noGitNotice()
+		}
+		return a, a.scheduleRefresh()
+
+	case skillsChangedMsg:
+		// Another process (CLI, agent, editor) touched the skills directory
+		if a.skills != nil {
+			a.manifest.Load()
+			a.refreshPanels()
+			a.filterSkills() // picks up skills dropped in by hand
+		}
+		return a, a.waitForSkillsChange()
+
+	case refreshDueMsg:
+		if msg.seq != a.refreshSeq {
+			return a, nil // superseded by a newer fetch
+		}
+		return a, a.backgroundRefresh(msg.seq)
+
+	case refreshReadyMsg:
+		if msg.seq != a.refreshSeq {
+			return a, nil
+		}
+		next := a.scheduleRefresh()
+		if msg.registry != nil && (msg.newSkills > 0 || msg.updates > 0) {
+			a.pendingRefresh = &msg
+			a.message = a.styles.Muted.Render(i18n.Tf("%d new skill(s), %d update(s) available - press R to refresh", msg.newSkills, msg.updates))
+			if msg.updates > 0 {
+				return a, tea.Batch(next, a.notifyUpdates(msg.updated))
+			}
+		}
+		return a, next
 
 	case glowDoneMsg:
-		// Returned from glow viewer, nothing to do
+		// Returned from the external viewer
+		if msg.tmp != "" {
+			os.Remove(msg.tmp)
+		}
+		if msg.err != nil {
+			a.message = a.styles.Error.Render(i18n.Tf("Viewer failed: %v", msg.err))
+		}
 		return a, nil
 
 	case installDoneMsg:
-		a.message = a.styles.Success.Render(fmt.Sprintf("Installed %s", msg.skill))
+		a.progress = nil
+		a.usage.Add(usage.Installs, 1)
+		a.clearFailure(queueInstall, msg.skill)
+		a.message = a.styles.Success.Render(i18n.Tf("Installed %s", msg.skill))
+		if msg.clash != "" {
+			a.message = a.styles.Warning.Render(i18n.Tf("Installed as %s: the name differs from the installed %s only by case", msg.skill, msg.clash))
+		}
 		a.refreshPanels()
 		a.mode = ModeNormal
 		return a, nil
 
 	case installErrMsg:
-		a.errorTitle = "Install Failed"
+		a.progress = nil
+		a.errorTitle = i18n.T("Install Failed")
 		a.errorDetail = msg.err.Error()
+		if a.confirmAction == ConfirmInstall && a.confirmSkill != nil {
+			a.recordFailure(queueInstall, *a.confirmSkill, msg.err.Error())
+			a.errorDetail += "\n\n" + i18n.T("Press Q to retry it later.")
+		}
 		a.mode = ModeError
 		return a, nil
 
 	case removeDoneMsg:
-		a.message = a.styles.Success.Render(fmt.Sprintf("Removed %s", msg.skill))
+		a.usage.Add(usage.Removals, 1)
+		a.message = a.styles.Success.Render(i18n.Tf("Removed %s", msg.skill))
+		a.refreshPanels()
+		a.mode = ModeNormal
+		return a, nil
+
+	case keptLocalMsg:
+		if msg.err != nil {
+			a.errorTitle = i18n.T("Keep as Local Failed")
+			a.errorDetail = msg.err.Error()
+			a.mode = ModeError
+			return a, nil
+		}
+		a.message = a.styles.Success.Render(i18n.Tf("%s is now a local skill", msg.name))
+		a.refreshPanels()
+		a.mode = ModeNormal
+		return a, nil
+
+	case discardedMsg:
+		if msg.err != nil {
+			a.errorTitle = i18n.T("Discard Failed")
+			a.errorDetail = msg.err.Error()
+			a.mode = ModeError
+			return a, nil
+		}
+		a.message = a.styles.Success.Render(i18n.Tf("Discarded local changes to %s", msg.name))
 		a.refreshPanels()
 		a.mode = ModeNormal
 		return a, nil
 
+	case keptChangesMsg:
+		if msg.err != nil {
+			a.message = a.styles.Error.Render(i18n.Tf("Could not save changes to %s: %v", msg.name, msg.err))
+			return a, nil
+		}
+		a.message = a.styles.Success.Render(i18n.Tf("Saved changes to %s; re-apply with lazyas patch apply %s", msg.name, msg.name))
+		return a, nil
+
 	case removeErrMsg:
-		a.errorTitle = "Remove Failed"
+		a.errorTitle = i18n.T("Remove Failed")
 		a.errorDetail = msg.err.Error()
 		a.mode = ModeError
 		return a, nil
 
 	case repoAddedMsg:
-		a.message = a.styles.Success.Render(fmt.Sprintf("Added repository '%s' - refreshing...", msg.name))
+		a.message = a.styles.Success.Render(i18n.Tf("Added repository '%s' - refreshing...", msg.name))
 		a.err = nil
 		// Refresh registry with new repo (force to bypass cache)
 		a.registry = registry.NewRegistry(a.cfg)
-		a.loadingMsg = "Fetching skill index..."
+		a.loadingMsg = i18n.T("Fetching skill index...")
 		a.mode = ModeLoading
 		return a, tea.Batch(
 			a.fetchIndexForced,
 			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
 		)
 
+	case repoURLCheckedMsg:
+		return a.handleRepoURLChecked(msg)
+
 	case repoAddErrMsg:
-		a.errorTitle = "Add Repository Failed"
+		a.errorTitle = i18n.T("Add Repository Failed")
+		a.errorDetail = msg.err.Error()
+		a.mode = ModeError
+		return a, nil
+
+	case repoInspectedMsg:
+		a.trustRepos = msg.repos
+		a.trustSummaries = msg.summaries
+		a.trustStarterKit = msg.starterKit
+		a.confirmAction = ConfirmTrustRepo
+		a.confirmSel = 1 // adding a repository should be a deliberate choice
+		a.mode = ModeConfirm
+		return a, nil
+
+	case repoInspectErrMsg:
+		a.errorTitle = i18n.T("Inspect Repository Failed")
 		a.errorDetail = msg.err.Error()
 		a.mode = ModeError
 		return a, nil
 
 	case repoRemovedMsg:
-		a.message = a.styles.Success.Render(fmt.Sprintf("Removed repository '%s' - refreshing...", msg.name))
+		a.message = a.styles.Success.Render(i18n.Tf("Removed repository '%s' - refreshing...", msg.name))
+		if len(msg.removed) > 0 || len(msg.orphaned) > 0 {
+			a.message = a.styles.Success.Render(i18n.Tf("Removed repository '%s' and %d skill(s), %d kept as orphaned - refreshing...", msg.name, len(msg.removed), len(msg.orphaned)))
+		}
 		a.err = nil
 		// Refresh registry without removed repo (force to bypass cache)
 		a.registry = registry.NewRegistry(a.cfg)
-		a.loadingMsg = "Fetching skill index..."
+		a.loadingMsg = i18n.T("Fetching skill index...")
+		a.mode = ModeLoading
+		return a, tea.Batch(
+			a.fetchIndexForced,
+			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		)
+
+	case repoEditedMsg:
+		a.message = a.styles.Success.Render(i18n.Tf("Updated repository '%s' - refreshing...", msg.name))
+		a.err = nil
+		a.registry = registry.NewRegistry(a.cfg)
+		a.loadingMsg = i18n.T("Fetching skill index...")
 		a.mode = ModeLoading
 		return a, tea.Batch(
 			a.fetchIndexForced,
 			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
 		)
 
+	case repoEditErrMsg:
+		a.errorTitle = i18n.T("Edit Repository Failed")
+		a.errorDetail = msg.err.Error()
+		a.mode = ModeError
+		return a, nil
+
 	case repoRemoveErrMsg:
-		a.errorTitle = "Remove Repository Failed"
+		a.errorTitle = i18n.T("Remove Repository Failed")
 		a.errorDetail = msg.err.Error()
 		a.mode = ModeError
 		return a, nil
 
 	case syncDoneMsg:
-		a.message = a.styles.Success.Render(fmt.Sprintf("Synced. %d skill(s) available.", msg.skillCount))
+		a.progress = nil
+		a.message = a.styles.Success.Render(i18n.Tf("Synced. %d skill(s) available.", msg.skillCount))
 		a.refreshPanels()
 		a.filterSkills()
 		a.mode = ModeNormal
-		return a, nil
+		return a, a.scheduleRefresh()
 
 	case syncErrMsg:
-		a.errorTitle = "Sync Failed"
+		a.progress = nil
+		a.errorTitle = i18n.T("Sync Failed")
 		a.errorDetail = msg.err.Error()
 		a.mode = ModeError
 		return a, nil
 
+	case updateStartedMsg:
+		a.updateResult = &updateDoneMsg{running: true, total: msg.total}
+		a.mode = ModeUpdateResult
+		return a, waitUpdate(msg.next)
+
+	case updateSkillMsg:
+		if a.updateResult != nil && a.updateResult.running {
+			a.updateResult.results = append(a.updateResult.results, msg.result)
+			switch {
+			case !msg.ok:
+				a.updateResult.failed++
+			case msg.result.status == "updated":
+				a.updateResult.updated++
+			default:
+				a.updateResult.skipped++
+			}
+		}
+		return a, waitUpdate(msg.next)
+
 	case updateDoneMsg:
+		a.progress = nil
 		a.updateResult = &msg
+		a.usage.Add(usage.Updates, msg.updated)
+		a.recordUpdateFailures(msg.results)
 		// Clear outdated status for skills that were updated or are up-to-date
 		if a.outdated != nil {
 			for _, r := range msg.results {
@@ -597,13 +1168,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case updateErrMsg:
-		a.errorTitle = "Update Failed"
+		a.progress = nil
+		a.errorTitle = i18n.T("Update Failed")
 		a.errorDetail = msg.err.Error()
 		a.mode = ModeError
 		return a, nil
 
 	case backendLinkDoneMsg:
-		a.message = a.styles.Success.Render(fmt.Sprintf("Linked %d backend(s)", msg.linked))
+		a.message = a.styles.Success.Render(i18n.Tf("Linked %d backend(s)", msg.linked))
 		a.checkBackendStatus()
 		// Undismiss newly linked backends
 		for _, s := range a.backendStatuses {
@@ -621,18 +1193,30 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case backendRepairedMsg:
+		a.message = a.styles.Success.Render(i18n.Tf("Repaired %d backend link problem(s)", msg.repaired))
+		a.checkBackendStatus()
+		cursor := a.backendCursor
+		a.initBackendSetup()
+		a.backendCursor = min(cursor, max(len(a.backendStatuses)-1, 0))
+		if a.skills != nil {
+			a.refreshPanels()
+		}
+		a.mode = ModeBackendSetup
+		return a, nil
+
 	case backendLinkErrMsg:
-		a.errorTitle = "Backend Link Failed"
+		a.errorTitle = i18n.T("Backend Link Failed")
 		a.errorDetail = msg.err.Error()
 		a.mode = ModeError
 		return a, nil
 
 	case starterKitDoneMsg:
-		a.message = a.styles.Success.Render(fmt.Sprintf("Added %d repository(ies) - refreshing...", msg.count))
+		a.message = a.styles.Success.Render(i18n.Tf("Added %d repository(ies) - refreshing...", msg.count))
 		a.err = nil
 		// Refresh registry with new repos (force to bypass cache)
 		a.registry = registry.NewRegistry(a.cfg)
-		a.loadingMsg = "Fetching skill index..."
+		a.loadingMsg = i18n.T("Fetching skill index...")
 		a.mode = ModeLoading
 		return a, tea.Batch(
 			a.fetchIndexForced,
@@ -640,11 +1224,130 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 
 	case starterKitErrMsg:
-		a.errorTitle = "Starter Kit Failed"
+		a.errorTitle = i18n.T("Starter Kit Failed")
+		a.errorDetail = msg.err.Error()
+		a.mode = ModeError
+		return a, nil
+
+	case versionsLoadedMsg:
+		if len(msg.tags) == 0 {
+			a.message = a.styles.Muted.Render(i18n.Tf("%s has no tagged versions", msg.name))
+			a.mode = ModeNormal
+			return a, nil
+		}
+		a.versionSkill = msg.name
+		a.versionTags = msg.tags
+		a.versionCursor = 0
+		if info, ok := a.manifest.GetInstalled(msg.name); ok {
+			for i, tag := range msg.tags {
+				if isInstalledTag(tag, info) {
+					a.versionCursor = i
+					break
+				}
+			}
+		}
+		a.versionModified, _ = git.IsModified(a.manifest.GetSkillPath(msg.name))
+		a.mode = ModeVersionPicker
+		return a, nil
+
+	case versionsErrMsg:
+		a.errorTitle = i18n.T("Cannot List Versions")
+		a.errorDetail = msg.err.Error()
+		a.mode = ModeError
+		return a, nil
+
+	case mergeDoneMsg:
+		if len(msg.conflicts) > 0 {
+			a.message = a.styles.Error.Render(i18n.Tf("%s: %d conflict(s) — fix the markers, then run 'lazyas resolve %s'", msg.name, len(msg.conflicts), msg.name))
+		} else {
+			a.message = a.styles.Success.Render(i18n.Tf("Merged upstream changes into %s", msg.name))
+		}
+		a.refreshPanels()
+		a.mode = ModeNormal
+		return a, nil
+
+	case mergeErrMsg:
+		a.errorTitle = i18n.T("Merge Failed")
+		a.errorDetail = msg.err.Error()
+		a.mode = ModeError
+		return a, nil
+
+	case versionSwitchDoneMsg:
+		a.message = a.styles.Success.Render(i18n.Tf("%s is now at %s", msg.name, msg.ref))
+		a.refreshPanels()
+		a.mode = ModeNormal
+		return a, nil
+
+	case queueDoneMsg:
+		a.progress = nil
+		a.queueResults = msg.results
+		a.queueCursor = 0
+		for _, r := range msg.results {
+			if r.ok && r.item.op == queueUpdate {
+				delete(a.outdated, r.item.skill.Name)
+			}
+			if r.ok {
+				a.usage.Add(queueUsageEvents[r.item.op], 1)
+				a.clearFailure(r.item.op, r.item.skill.Name)
+			} else if r.retry {
+				a.recordFailure(r.item.op, r.item.skill, r.detail)
+			}
+		}
+		a.refreshPanels()
+		a.mode = ModeQueue
+		return a, nil
+
+	case manifestActionDoneMsg:
+		a.message = a.styles.Success.Render(msg.text)
+		a.refreshPanels()
+		a.buildManifestRows()
+		a.mode = ModeManifest
+		return a, nil
+
+	case manifestActionErrMsg:
+		a.errorTitle = msg.title
+		a.errorDetail = msg.err.Error()
+		a.mode = ModeError
+		return a, nil
+
+	case versionSwitchErrMsg:
+		a.errorTitle = i18n.T("Version Change Failed")
 		a.errorDetail = msg.err.Error()
 		a.mode = ModeError
 		return a, nil
 
+	case sizeEstimatedMsg:
+		if a.mode == ModeConfirm && a.confirmSkill != nil && a.confirmSkill.Name == msg.name {
+			a.confirmSize = &msg.est
+			a.confirmSizeCloned = msg.cloned
+			a.confirmSizeLoading = false
+		}
+		return a, nil
+
+	case riskScannedMsg:
+		if a.mode == ModeConfirm && a.confirmSkill != nil && a.confirmSkill.Name == msg.name {
+			a.confirmRisk = msg.report
+			a.confirmRiskLoading = false
+		}
+		return a, nil
+
+	case previewDueMsg:
+		if msg.seq != a.previewSeq {
+			return a, nil // selection moved on
+		}
+		return a, a.fetchPreview(msg.skill)
+
+	case previewLoadedMsg:
+		if msg.err == nil {
+			a.previewed[msg.key] = msg // failures are retried on the next selection
+		}
+		if a.skills != nil && a.detail != nil {
+			if sel := a.skills.Selected(); sel != nil && previewKey(*sel) == msg.key && a.detail.NeedsRemoteSkillMD() {
+				a.detail.SetRemoteSkillMD(msg.content, msg.err)
+			}
+		}
+		return a, nil
+
 	case tickMsg:
 		if a.mode == ModeLoading {
 			a.spinnerIdx = (a.spinnerIdx + 1) % 4
@@ -655,21 +1358,96 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// tuiActions names the normal-mode keys counted in the usage stats
+var tuiActions = map[string]string{
+	"i":      "install",
+	"r":      "remove",
+	"U":      "update all",
+	"S":      "sync",
+	"/":      "search",
+	"+":      "queue",
+	"-":      "queue",
+	"Q":      "review queue",
+	" ":      "mark",
+	"V":      "view SKILL.md",
+	"y":      "copy install command",
+	"v":      "versions",
+	"m":      "merge",
+	"M":      "manifest",
+	"H":      "last update",
+	"A":      "add repo",
+	"b":      "backends",
+	"K":      "starter kit",
+	"f":      "verified filter",
+	",":      "settings",
+	"t":      "tag filter",
+	"P":      "repo picker",
+	"ctrl+r": "redo",
+}
+
 func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
-	// Global keys
-	switch key {
-	case "q":
-		if a.skills != nil && a.skills.IsSearching() {
-			// Let the panel handle it
-		} else {
-			return a, tea.Quit
+	if a.showEmptyState() {
+		if cmd, handled := a.updateEmptyState(msg); handled {
+			return a, cmd
 		}
+	}
 
-	case "tab":
-		if a.skills != nil && !a.skills.IsSearching() {
-			if a.layout.Focus() == layout.PanelLeft {
+	// Orphaned skills take A and L to re-add their repo or keep them local
+	if orphan := a.selectedOrphan(); orphan != "" {
+		switch key {
+		case "A":
+			return a.readdOrphanRepo(orphan)
+		case "L":
+			a.confirmAction = ConfirmKeepLocal
+			a.confirmSkill = a.skills.Selected()
+			a.confirmSel = 0
+			a.mode = ModeConfirm
+			return a, nil
+		}
+	}
+
+	// Without git these actions could only fail with exec errors
+	if gitKeys[key] && a.skills != nil && !a.skills.IsSearching() && !a.requireGit() {
+		return a, nil
+	}
+
+	if action, ok := tuiActions[key]; ok && a.skills != nil && !a.skills.IsSearching() {
+		a.usage.AddAction("tui " + action)
+	}
+
+	// With skills marked, install, update and remove act on all of them
+	if a.skills != nil && !a.skills.IsSearching() && a.markedCount() > 0 {
+		switch key {
+		case "i":
+			a.queueMarked(queueInstall)
+			return a, nil
+		case "U":
+			a.queueMarked(queueUpdate)
+			return a, nil
+		case "r":
+			a.queueMarked(queueRemove)
+			return a, nil
+		case "esc":
+			a.skills.ClearMarks()
+			a.message = a.styles.Muted.Render(i18n.T("Marks cleared"))
+			return a, nil
+		}
+	}
+
+	// Global keys
+	switch key {
+	case "q":
+		if a.skills != nil && a.skills.IsSearching() {
+			// Let the panel handle it
+		} else {
+			return a, tea.Quit
+		}
+
+	case "tab":
+		if a.skills != nil && !a.skills.IsSearching() {
+			if a.layout.Focus() == layout.PanelLeft {
 				a.layout.FocusRight()
 				a.skills.SetFocused(false)
 				a.detail.SetFocused(true)
@@ -700,36 +1478,36 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "i":
 		if a.skills != nil && !a.skills.IsSearching() {
 			if skill := a.skills.Selected(); skill != nil {
-				// If source is a local path, resolve from registry
-				installSkill := skill
-				if strings.HasPrefix(skill.Source.Repo, "/") || strings.HasPrefix(skill.Source.Repo, "~") {
-					regSkill := a.registry.GetSkill(skill.Name)
-					if regSkill == nil {
-						a.errorTitle = "Cannot Install"
-						a.errorDetail = fmt.Sprintf("%s is not found in any configured registry", skill.Name)
-						a.mode = ModeError
-						return a, nil
-					}
-					installSkill = regSkill
+				installSkill := a.installCandidate(skill)
+				if installSkill == nil {
+					a.errorTitle = i18n.T("Cannot Install")
+					a.errorDetail = fmt.Sprintf("%s is not found in any configured registry", skill.Name)
+					a.mode = ModeError
+					return a, nil
 				}
 
-				onDisk := a.manifest.IsInstalled(installSkill.Name)
-				if !onDisk {
-					// Not on disk: install directly
-					a.confirmSkill = installSkill
-					a.loadingMsg = fmt.Sprintf("Installing %s...", installSkill.Name)
-					a.mode = ModeLoading
-					return a, tea.Batch(
-						a.installSkill(installSkill),
-						tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
-					)
+				// On case-insensitive filesystems a skill differing only by
+				// case looks installed; it will get a name of its own instead
+				_, clash := a.manifest.CaseClash(installSkill.Name)
+				onDisk := a.manifest.IsInstalled(installSkill.Name) && !clash
+				occupant := a.manifest.Occupant(installSkill.Name, installSkill.Source.Repo, installSkill.Source.Path)
+				switch {
+				case !onDisk:
+					// Not on disk: confirm install with size estimate
+					a.confirmAction = ConfirmInstall
+				case occupant != "":
+					// A different skill has the name: offer a free one
+					a.confirmAction = ConfirmInstallAs
+					a.installAs = a.manifest.FreeName(installSkill.Name)
+					a.occupant = occupant
+				default:
+					// This skill is already on disk: confirm overwrite
+					a.confirmAction = ConfirmOverwrite
 				}
-				// Already on disk (tracked or untracked): confirm overwrite
-				a.confirmAction = ConfirmOverwrite
 				a.confirmSkill = installSkill
 				a.confirmSel = 0
 				a.mode = ModeConfirm
-				return a, nil
+				return a, tea.Batch(a.estimateInstallSize(installSkill), a.scanInstallCandidate(installSkill))
 			}
 		}
 
@@ -742,6 +1520,8 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					if repo.URL == header.RepoURL {
 						a.confirmAction = ConfirmRemoveRepo
 						a.confirmRepo = repo.Name
+						a.repoSkills = a.manifest.FromRepo(repo.URL)
+						a.repoSkillsPurge = false
 						a.confirmSel = 0
 						a.mode = ModeConfirm
 						return a, nil
@@ -749,6 +1529,12 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			} else if skill := a.skills.Selected(); skill != nil {
 				if a.manifest.IsInstalled(skill.Name) {
+					if dependents := a.manifest.Dependents(skill.Name); len(dependents) > 0 {
+						a.errorTitle = i18n.T("Cannot Remove")
+						a.errorDetail = i18n.Tf("%s is required by %s.\nRemove them first, or run 'lazyas remove --cascade %s'.", skill.Name, strings.Join(dependents, ", "), skill.Name)
+						a.mode = ModeError
+						return a, nil
+					}
 					a.confirmAction = ConfirmRemove
 					a.confirmSkill = skill
 					a.confirmSel = 0
@@ -758,16 +1544,174 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case " ":
+		if a.skills != nil && !a.skills.IsSearching() && a.skills.ToggleMark() {
+			if n := a.markedCount(); n > 0 {
+				a.message = a.styles.Muted.Render(i18n.Tf("%d marked - i: install  u: update  r: remove  esc: clear", n))
+			} else {
+				a.message = ""
+			}
+			return a, nil
+		}
+
+	case "+":
+		if a.skills != nil && !a.skills.IsSearching() {
+			if skill := a.skills.Selected(); skill != nil {
+				a.queueInstallOrUpdate(skill)
+				return a, nil
+			}
+		}
+
+	case "-":
+		if a.skills != nil && !a.skills.IsSearching() {
+			if skill := a.skills.Selected(); skill != nil {
+				a.queueRemoval(skill)
+				return a, nil
+			}
+		}
+
+	case "Q":
+		if a.skills != nil && !a.skills.IsSearching() && (len(a.queue) > 0 || len(a.failed) > 0) {
+			a.openQueue()
+			return a, nil
+		}
+
+	case "L":
+		// Adopt an untracked copy of a registry skill
+		if a.skills != nil && !a.skills.IsSearching() {
+			if skill, dup := a.skills.Selected(), a.detail.Duplicate(); skill != nil && dup != nil {
+				a.confirmAction = ConfirmAdopt
+				a.confirmSkill = dup
+				a.adoptName = skill.Name
+				a.confirmSel = 0
+				a.mode = ModeConfirm
+				return a, tea.Batch(a.estimateInstallSize(dup), a.scanInstallCandidate(dup))
+			}
+		}
+
+	case "e":
+		// Edit the name or URL of the repo under the cursor
+		if a.skills != nil && !a.skills.IsSearching() {
+			if header := a.skills.SelectedHeader(); header != nil && header.RepoURL != "" {
+				for _, repo := range a.cfg.Repos {
+					if repo.URL != header.RepoURL {
+						continue
+					}
+					if repo.Team {
+						a.message = a.styles.Error.Render(i18n.Tf("Repository '%s' is managed by the team config", repo.Name))
+						return a, nil
+					}
+					return a.openAddRepo(repo.Name, repo.URL, repo.Name)
+				}
+			}
+		}
+
 	case "V":
 		if a.skills != nil && !a.skills.IsSearching() {
 			if skill := a.skills.Selected(); skill != nil {
-				mdPath := filepath.Join(a.cfg.SkillsDir, skill.Name, "SKILL.md")
-				if _, err := os.Stat(mdPath); err == nil {
-					cmd := a.viewerCmd(mdPath)
-					if cmd != nil {
-						return a, tea.ExecProcess(cmd, func(err error) tea.Msg {
-							return glowDoneMsg{err}
-						})
+				return a, a.openSkillMD(skill)
+			}
+		}
+
+	case "enter":
+		// In the SKILL.md tab, enter opens the file in the external viewer
+		if a.layout.Focus() == layout.PanelRight && a.skills != nil && a.detail.ActiveTab() == panels.TabSkillMD {
+			if skill := a.skills.Selected(); skill != nil {
+				return a, a.openSkillMD(skill)
+			}
+		}
+		// On a repository header, enter scopes the list to the repository
+		if a.layout.Focus() == layout.PanelLeft && a.skills != nil && !a.skills.IsSearching() && a.toggleHeaderScope() {
+			return a, nil
+		}
+
+	case "x":
+		// In the Diff tab, discard the skill's local modifications
+		if a.layout.Focus() == layout.PanelRight {
+			if name := a.selectedModified(); name != "" {
+				a.confirmAction = ConfirmDiscard
+				a.confirmSkill = a.skills.Selected()
+				a.confirmSel = 1 // the changes are gone for good
+				a.mode = ModeConfirm
+				return a, nil
+			}
+		}
+
+	case "p":
+		// In the Diff tab, keep the local modifications as a patch
+		if a.layout.Focus() == layout.PanelRight {
+			if name := a.selectedModified(); name != "" {
+				return a, a.keepChanges(name)
+			}
+		}
+
+	case "u":
+		// Undo the last fold, filter, mark or queue change
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.usage.AddAction("tui undo")
+			a.undo()
+			return a, nil
+		}
+
+	case "ctrl+r":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.redo()
+			return a, nil
+		}
+
+	case "d":
+		if a.layout.Focus() == layout.PanelRight && a.detail.ShowDiff() {
+			return a, nil
+		}
+
+	case "o":
+		if a.layout.Focus() == layout.PanelRight && a.skills != nil {
+			if skill := a.skills.Selected(); skill != nil {
+				return a, a.openSkillMD(skill)
+			}
+		}
+
+	case "y":
+		if a.skills != nil && !a.skills.IsSearching() {
+			if command := a.detail.InstallCommand(); command != "" {
+				copyToClipboard(command)
+				a.message = a.styles.Success.Render(i18n.Tf("Copied: %s", command))
+				return a, nil
+			}
+		}
+
+	case "v":
+		if a.skills != nil && !a.skills.IsSearching() {
+			if skill := a.skills.Selected(); skill != nil {
+				if _, tracked := a.manifest.GetInstalled(skill.Name); tracked && a.manifest.IsInstalled(skill.Name) {
+					a.loadingMsg = i18n.Tf("Fetching versions of %s...", skill.Name)
+					a.mode = ModeLoading
+					return a, tea.Batch(
+						a.loadVersions(skill.Name),
+						tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+					)
+				}
+			}
+		}
+
+	case "M":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.openManifestBrowser()
+			return a, nil
+		}
+
+	case "m":
+		if a.skills != nil && !a.skills.IsSearching() {
+			if skill := a.skills.Selected(); skill != nil {
+				info, tracked := a.manifest.GetInstalled(skill.Name)
+				if tracked && !info.NeedsResolution() {
+					if modified, _ := git.IsModified(a.manifest.GetSkillPath(skill.Name)); modified {
+						a.loadingMsg = i18n.Tf("Merging upstream into %s...", skill.Name)
+						a.mode = ModeLoading
+						return a, tea.Batch(
+							a.mergeSkill(skill.Name),
+							tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+						)
 					}
 				}
 			}
@@ -782,12 +1726,31 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "A":
 		if a.skills != nil && !a.skills.IsSearching() {
-			a.addRepoName.Reset()
-			a.addRepoURL.Reset()
-			a.addRepoFocus = 0
-			a.addRepoName.Focus()
-			a.mode = ModeAddRepo
-			return a, textinput.Blink
+			return a.openAddRepo("", "", "")
+		}
+
+	case "?":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.mode = ModeLegend
+			return a, nil
+		}
+
+	case ",":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.openSettings()
+			return a, nil
+		}
+
+	case "t":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.openTagFilter()
+			return a, nil
+		}
+
+	case "P":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.openRepoPicker()
+			return a, nil
 		}
 
 	case "b":
@@ -799,21 +1762,43 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "U":
+		// From the detail panel footer: update the shown skill after review
+		if a.layout.Focus() == layout.PanelRight && a.skills != nil {
+			if skill := a.skills.Selected(); skill != nil {
+				if _, tracked := a.manifest.GetInstalled(skill.Name); tracked && a.outdated[skill.Name] {
+					a.enqueue(queueUpdate, *skill)
+					a.openQueue()
+					return a, nil
+				}
+			}
+		}
 		if a.skills != nil && !a.skills.IsSearching() {
-			a.loadingMsg = "Updating skills..."
+			a.loadingMsg = i18n.T("Updating skills...")
 			a.mode = ModeLoading
 			return a, tea.Batch(
-				a.updateAllSkills(),
+				a.updateAllSkills(a.startProgress(i18n.T("Updating"))),
 				tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
 			)
 		}
 
+	case "H":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.showLastUpdate()
+			return a, nil
+		}
+
+	case "f":
+		if a.skills != nil && !a.skills.IsSearching() {
+			a.toggleVerifiedFilter()
+			return a, nil
+		}
+
 	case "S":
 		if a.skills != nil && !a.skills.IsSearching() {
-			a.loadingMsg = "Syncing repositories..."
+			a.loadingMsg = i18n.T("Syncing repositories...")
 			a.mode = ModeLoading
 			return a, tea.Batch(
-				a.syncRepos(),
+				a.syncRepos(a.startProgress(i18n.T("Syncing"))),
 				tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
 			)
 		}
@@ -824,6 +1809,12 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.mode = ModeStarterKit
 			return a, nil
 		}
+
+	case "R":
+		if a.skills != nil && !a.skills.IsSearching() && a.pendingRefresh != nil {
+			a.applyRefresh()
+			return a, nil
+		}
 	}
 
 	// Route to focused panel
@@ -846,6 +1837,7 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Update detail if selection changed
 		if a.skills.Selected() != prevSelected {
 			a.updateDetailPanel()
+			cmd = tea.Batch(cmd, a.requestPreview())
 		}
 	} else if a.detail != nil {
 		cmd = a.detail.Update(msg)
@@ -855,6 +1847,10 @@ func (a *App) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (a *App) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if focus, ok := cycleFocus(msg.String(), a.confirmSel, 2); ok {
+		a.confirmSel = focus
+		return a, nil
+	}
 	switch msg.String() {
 	case "left", "h":
 		a.confirmSel = 0
@@ -868,152 +1864,207 @@ func (a *App) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	case "enter":
 		return a.executeConfirm()
+	case "s":
+		if a.confirmAction == ConfirmRemoveRepo && len(a.repoSkills) > 0 {
+			a.repoSkillsPurge = !a.repoSkillsPurge
+		}
+	case "o":
+		if a.confirmAction == ConfirmInstallAs {
+			a.confirmAction = ConfirmOverwrite
+		}
 	}
 	return a, nil
 }
 
 func (a *App) updateAddRepo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
+	key := msg.String()
+	if key == "down" {
+		key = "tab"
+	} else if key == "up" {
+		key = "shift+tab"
+	}
+	if focus, ok := cycleFocus(key, a.addRepoFocus, addRepoControls); ok {
+		a.setAddRepoFocus(focus)
+		return a, textinput.Blink
+	}
+
+	switch key {
 	case "esc":
 		a.mode = ModeNormal
 		return a, nil
 
-	case "tab", "down":
-		if a.addRepoFocus == 0 {
-			a.addRepoFocus = 1
-			a.addRepoName.Blur()
-			a.addRepoURL.Focus()
-		} else {
-			a.addRepoFocus = 0
-			a.addRepoURL.Blur()
-			a.addRepoName.Focus()
-		}
-		return a, textinput.Blink
-
-	case "shift+tab", "up":
-		if a.addRepoFocus == 1 {
-			a.addRepoFocus = 0
-			a.addRepoURL.Blur()
-			a.addRepoName.Focus()
-		} else {
-			a.addRepoFocus = 1
-			a.addRepoName.Blur()
-			a.addRepoURL.Focus()
-		}
-		return a, textinput.Blink
-
 	case "enter":
-		name := strings.TrimSpace(a.addRepoName.Value())
-		url := strings.TrimSpace(a.addRepoURL.Value())
-
-		if name == "" || url == "" {
-			a.message = a.styles.Error.Render("Name and URL are required")
+		if a.addRepoFocus == addRepoCancel {
+			a.mode = ModeNormal
 			return a, nil
 		}
-
-		// Add repo in background
-		return a, func() tea.Msg {
-			if err := a.cfg.AddRepo(name, url); err != nil {
-				return repoAddErrMsg{err}
-			}
-			return repoAddedMsg{name}
-		}
+		return a.submitAddRepo()
 	}
 
-	// Update the focused input
-	var cmd tea.Cmd
-	if a.addRepoFocus == 0 {
-		a.addRepoName, cmd = a.addRepoName.Update(msg)
-	} else {
-		a.addRepoURL, cmd = a.addRepoURL.Update(msg)
+	if a.addRepoFocus > addRepoFieldURL {
+		return a, nil
 	}
-	return a, cmd
+	return a, a.addRepoInput(msg)
 }
 
 // Backend setup modal handling
 func (a *App) initBackendSetup() {
+	a.backendProblems = symlink.Verify(a.cfg.Backends, a.cfg.SkillsDir)
 	a.backendSelection = make([]bool, len(a.backendStatuses))
-	// Pre-select available+unlinked backends
+	// Pre-select available+unlinked backends; broken ones need a repair
 	for i, s := range a.backendStatuses {
-		a.backendSelection[i] = s.Available && !s.Linked && s.Error == nil
+		a.backendSelection[i] = a.linkable(s)
 	}
 	a.backendCursor = 0
+	a.backendFocus = 0
 }
 
 func (a *App) updateBackendSetup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if focus, ok := cycleFocus(msg.String(), a.backendFocus, 3); ok {
+		a.backendFocus = focus
+		return a, nil
+	}
+
 	switch msg.String() {
 	case "esc", "q":
-		// Dismiss all available+unlinked backends so modal doesn't re-appear
-		for _, s := range a.backendStatuses {
-			if s.Available && !s.Linked && s.Error == nil {
-				a.cfg.DismissBackend(s.Backend.Name)
-			}
-		}
-		a.cfg.Save()
-		// Chain: show starter kit if no repos configured yet
-		if len(a.cfg.Repos) == 0 && !a.cfg.StarterKitDismissed {
-			a.initStarterKit()
-			a.mode = ModeStarterKit
-		} else {
-			a.mode = ModeNormal
-		}
-		return a, nil
+		return a.skipBackendSetup()
 
 	case "j", "down":
+		a.backendFocus = 0
 		if a.backendCursor < len(a.backendStatuses)-1 {
 			a.backendCursor++
 		}
 		return a, nil
 
 	case "k", "up":
+		a.backendFocus = 0
 		if a.backendCursor > 0 {
 			a.backendCursor--
 		}
 		return a, nil
 
+	case "g", "home":
+		a.backendFocus = 0
+		a.backendCursor = 0
+		return a, nil
+
+	case "G", "end":
+		a.backendFocus = 0
+		a.backendCursor = max(len(a.backendStatuses)-1, 0)
+		return a, nil
+
 	case " ", "x":
+		if a.backendFocus == 2 {
+			return a.skipBackendSetup()
+		}
+		if a.backendFocus == 1 {
+			return a.linkSelectedBackends()
+		}
 		// Toggle selection (only for available+unlinked backends)
 		if a.backendCursor < len(a.backendStatuses) {
-			s := a.backendStatuses[a.backendCursor]
-			if s.Available && !s.Linked {
+			if a.linkable(a.backendStatuses[a.backendCursor]) {
 				a.backendSelection[a.backendCursor] = !a.backendSelection[a.backendCursor]
 			}
 		}
 		return a, nil
 
-	case "enter":
-		// Link selected backends
-		var toLink []symlink.LinkStatus
-		for i, sel := range a.backendSelection {
-			if sel && !a.backendStatuses[i].Linked {
-				toLink = append(toLink, a.backendStatuses[i])
+	case "r":
+		if a.backendCursor < len(a.backendStatuses) {
+			if problems := a.problemsOf(a.backendStatuses[a.backendCursor].Backend.Name); len(problems) > 0 {
+				a.loadingMsg = i18n.T("Repairing backend links...")
+				a.mode = ModeLoading
+				return a, tea.Batch(
+					a.repairBackends(problems),
+					tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+				)
 			}
 		}
+		return a, nil
 
-		if len(toLink) == 0 {
-			// Chain: show starter kit if no repos configured yet
-			if len(a.cfg.Repos) == 0 && !a.cfg.StarterKitDismissed {
-				a.initStarterKit()
-				a.mode = ModeStarterKit
-			} else {
-				a.mode = ModeNormal
-			}
-			return a, nil
+	case "enter":
+		if a.backendFocus == 2 {
+			return a.skipBackendSetup()
 		}
+		return a.linkSelectedBackends()
+	}
 
-		a.loadingMsg = "Linking backends..."
-		a.mode = ModeLoading
-		return a, tea.Batch(
-			a.linkBackends(toLink),
-			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
-		)
+	return a, nil
+}
+
+// linkable reports whether a backend can be checked for linking in
+// backend setup
+func (a *App) linkable(s symlink.LinkStatus) bool {
+	return s.Available && !s.Linked && s.Error == nil && len(a.problemsOf(s.Backend.Name)) == 0
+}
+
+// problemsOf returns the link problems of a backend
+func (a *App) problemsOf(name string) []symlink.Problem {
+	var problems []symlink.Problem
+	for _, p := range a.backendProblems {
+		if p.Backend.Name == name {
+			problems = append(problems, p)
+		}
 	}
+	return problems
+}
 
+// skipBackendSetup closes backend setup without linking anything
+func (a *App) skipBackendSetup() (tea.Model, tea.Cmd) {
+	// Dismiss all available+unlinked backends so modal doesn't re-appear
+	for _, s := range a.backendStatuses {
+		if s.Available && !s.Linked && s.Error == nil {
+			a.cfg.DismissBackend(s.Backend.Name)
+		}
+	}
+	a.cfg.Save()
+	a.closeBackendSetup()
 	return a, nil
 }
 
+// closeBackendSetup moves on from backend setup. Chain: show starter kit
+// if no repos configured yet
+func (a *App) closeBackendSetup() {
+	if len(a.cfg.Repos) == 0 && !a.cfg.StarterKitDismissed {
+		a.initStarterKit()
+		a.mode = ModeStarterKit
+	} else {
+		a.mode = ModeNormal
+	}
+}
+
+// linkSelectedBackends links the backends checked in backend setup
+func (a *App) linkSelectedBackends() (tea.Model, tea.Cmd) {
+	var toLink []symlink.LinkStatus
+	for i, sel := range a.backendSelection {
+		if sel && !a.backendStatuses[i].Linked {
+			toLink = append(toLink, a.backendStatuses[i])
+		}
+	}
+
+	if len(toLink) == 0 {
+		a.closeBackendSetup()
+		return a, nil
+	}
+
+	a.loadingMsg = i18n.T("Linking backends...")
+	a.mode = ModeLoading
+	return a, tea.Batch(
+		a.linkBackends(toLink),
+		tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+	)
+}
+
 // Update result modal handling
 func (a *App) updateUpdateResult(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.updateResult != nil && a.updateResult.running {
+		switch msg.String() {
+		case "esc", "c":
+			if a.progress != nil {
+				a.progress.Cancel()
+			}
+		}
+		return a, nil
+	}
 	switch msg.String() {
 	case "esc", "enter", "q":
 		a.mode = ModeNormal
@@ -1035,6 +2086,15 @@ func (a *App) updateError(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// Legend modal handling
+func (a *App) updateLegend(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q", "?":
+		a.mode = ModeNormal
+	}
+	return a, nil
+}
+
 func (a *App) executeConfirm() (tea.Model, tea.Cmd) {
 	if a.confirmSel == 1 {
 		a.mode = ModeNormal
@@ -1043,29 +2103,86 @@ func (a *App) executeConfirm() (tea.Model, tea.Cmd) {
 
 	switch a.confirmAction {
 	case ConfirmInstall:
-		a.loadingMsg = fmt.Sprintf("Installing %s...", a.confirmSkill.Name)
+		a.loadingMsg = i18n.Tf("Installing %s...", a.confirmSkill.Name)
 		a.mode = ModeLoading
-		return a, a.installSkill(a.confirmSkill)
+		return a, tea.Batch(
+			a.installSkill(a.confirmSkill, a.confirmSkill.Name, a.startProgress(i18n.Tf("Installing %s", a.confirmSkill.Name))),
+			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		)
+	case ConfirmInstallAs:
+		a.loadingMsg = i18n.Tf("Installing %s as %s...", a.confirmSkill.Name, a.installAs)
+		a.mode = ModeLoading
+		return a, tea.Batch(
+			a.installSkill(a.confirmSkill, a.installAs, a.startProgress(i18n.Tf("Installing %s", a.installAs))),
+			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		)
 	case ConfirmRemove:
-		a.loadingMsg = fmt.Sprintf("Removing %s...", a.confirmSkill.Name)
+		a.loadingMsg = i18n.Tf("Removing %s...", a.confirmSkill.Name)
 		a.mode = ModeLoading
 		return a, a.removeSkill(a.confirmSkill)
 	case ConfirmRemoveRepo:
 		repoName := a.confirmRepo
-		a.loadingMsg = "Removing repository..."
+		a.loadingMsg = i18n.T("Removing repository...")
 		a.mode = ModeLoading
-		return a, a.removeRepo(repoName)
+		return a, a.removeRepo(repoName, a.repoSkills, a.repoSkillsPurge)
 	case ConfirmOverwrite:
-		a.loadingMsg = fmt.Sprintf("Installing %s...", a.confirmSkill.Name)
+		a.loadingMsg = i18n.Tf("Installing %s...", a.confirmSkill.Name)
 		a.mode = ModeLoading
 		return a, tea.Batch(
-			a.overwriteAndInstall(a.confirmSkill),
+			a.overwriteAndInstall(a.confirmSkill, a.confirmSkill.Name, a.startProgress(i18n.Tf("Installing %s", a.confirmSkill.Name))),
 			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
 		)
+	case ConfirmKeepLocal:
+		a.loadingMsg = i18n.Tf("Copying %s...", a.confirmSkill.Name)
+		a.mode = ModeLoading
+		return a, a.keepLocal(a.confirmSkill.Name)
+	case ConfirmDiscard:
+		a.loadingMsg = i18n.Tf("Discarding changes to %s...", a.confirmSkill.Name)
+		a.mode = ModeLoading
+		return a, a.discardChanges(a.confirmSkill.Name)
+	case ConfirmAdopt:
+		a.loadingMsg = i18n.Tf("Adopting %s as %s...", a.confirmSkill.Name, a.adoptName)
+		a.mode = ModeLoading
+		return a, tea.Batch(
+			a.overwriteAndInstall(a.confirmSkill, a.adoptName, a.startProgress(i18n.Tf("Installing %s", a.adoptName))),
+			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		)
+	case ConfirmTrustRepo:
+		repos := a.trustRepos
+		if a.trustStarterKit {
+			a.loadingMsg = i18n.T("Adding repositories...")
+			a.mode = ModeLoading
+			return a, a.addStarterKitRepos(repos)
+		}
+		a.loadingMsg = i18n.T("Adding repository...")
+		a.mode = ModeLoading
+		return a, func() tea.Msg {
+			if err := a.addRepo(repos[0].Name, repos[0].URL); err != nil {
+				return repoAddErrMsg{err}
+			}
+			return repoAddedMsg{repos[0].Name}
+		}
 	}
 	return a, nil
 }
 
+// inspectRepos fetches each repository once and summarizes it for the
+// trust confirmation
+func (a *App) inspectRepos(repos []config.Repo, starterKit bool) tea.Cmd {
+	reg := a.registry
+	return func() tea.Msg {
+		summaries := make([]*registry.RepoSummary, 0, len(repos))
+		for _, r := range repos {
+			summary, err := reg.InspectRepo(r.URL)
+			if err != nil {
+				return repoInspectErrMsg{fmt.Errorf("failed to inspect %s: %w", r.Name, err)}
+			}
+			summaries = append(summaries, summary)
+		}
+		return repoInspectedMsg{repos, summaries, starterKit}
+	}
+}
+
 func (a *App) filterSkills() {
 	if a.skills == nil {
 		return
@@ -1077,6 +2194,8 @@ func (a *App) filterSkills() {
 	var skills []registry.SkillEntry
 	if query == "" {
 		skills = mergeSkills(a.registry.ListSkills(), localSkills, "")
+	} else if a.skills.ContentSearch() {
+		skills = a.contentMatches(query, mergeSkills(a.registry.ListSkills(), localSkills, ""))
 	} else {
 		skills = mergeSkills(a.registry.SearchSkills(query), localSkills, query)
 	}
@@ -1084,15 +2203,42 @@ func (a *App) filterSkills() {
 	a.updateDetailPanel()
 }
 
+// contentMatches returns the skills whose SKILL.md matches query, best
+// first. The footer notes how many skills had no SKILL.md on disk.
+func (a *App) contentMatches(query string, all []registry.SkillEntry) []registry.SkillEntry {
+	byName := make(map[string]registry.SkillEntry, len(all))
+	for _, skill := range all {
+		byName[skill.Name] = skill
+	}
+	docs, missing := fulltext.Collect(a.cfg, a.registry.ListSkills())
+	var skills []registry.SkillEntry
+	for _, m := range fulltext.Search(query, docs) {
+		if skill, ok := byName[m.Name]; ok {
+			skills = append(skills, skill)
+		}
+	}
+
+	msg := i18n.Tf("%d skill(s) whose SKILL.md matches", len(skills))
+	if missing > 0 {
+		msg += i18n.Tf("; %d not searched (SKILL.md not on disk, preview to include)", missing)
+	}
+	a.message = a.styles.Muted.Render(msg)
+	return skills
+}
+
 func (a *App) refreshPanels() {
 	localSkills := a.manifest.ScanLocalSkills()
 	installed := make(map[string]string)
 	modified := make(map[string]bool)
 	localOnly := make(map[string]bool)
 	manifestInstalled := a.manifest.ListInstalled()
+	orphaned := a.orphanedSkills()
 	for name, local := range localSkills {
-		if mi, tracked := manifestInstalled[name]; tracked {
+		if mi, tracked := manifestInstalled[name]; tracked && !orphaned[name] {
 			installed[name] = mi.SourceRepo
+		} else if tracked {
+			// No registry lists it any more; it is shown from disk
+			installed[name] = local.Path
 		} else {
 			installed[name] = local.Path
 			localOnly[name] = true
@@ -1104,28 +2250,98 @@ func (a *App) refreshPanels() {
 	a.skills.SetInstalled(installed)
 	a.skills.SetModified(modified)
 	a.skills.SetLocalOnly(localOnly)
+	a.skills.SetOrphaned(orphaned)
 	a.skills.SetOutdated(a.outdated)
+	a.skills.SetUnreachable(a.unreachableSkills())
+	a.skills.SetVerified(a.verifiedSkills())
+	a.skills.SetEnabled(a.enabledBackends(installed))
+	a.skills.SetSynced(a.repoSyncTimes())
 	a.updateDetailPanel()
 }
 
-func (a *App) installSkill(skill *registry.SkillEntry) tea.Cmd {
+// repoSyncTimes maps each skill repo URL shown as a group header to the last
+// successful fetch of the configured repository that listed it
+func (a *App) repoSyncTimes() map[string]time.Time {
+	synced := make(map[string]time.Time)
+	for _, repo := range a.cfg.Repos {
+		if sync, ok := a.registry.RepoSync(repo.Name); ok {
+			synced[repo.URL] = sync.FetchedAt
+		}
+	}
+	for _, skill := range a.registry.ListSkills() {
+		if _, ok := synced[skill.Source.Repo]; ok {
+			continue
+		}
+		if sync, ok := a.registry.RepoSync(skill.Source.RepoName); ok {
+			synced[skill.Source.Repo] = sync.FetchedAt
+		}
+	}
+	return synced
+}
+
+// repoSyncLine describes when a configured repository was last fetched
+func (a *App) repoSyncLine(name string) string {
+	sync, ok := a.registry.RepoSync(name)
+	if !ok {
+		return i18n.T("Never synced")
+	}
+	line := i18n.Tf("Synced %s", registry.FormatAge(sync.FetchedAt, time.Now()))
+	if len(sync.Commit) >= 7 {
+		line += fmt.Sprintf(" (%s)", sync.Commit[:7])
+	}
+	return line
+}
+
+// startProgress tracks a long operation started from the TUI; its progress
+// is drawn in the footer until the operation's result arrives
+func (a *App) startProgress(op string) *progress.Tracker {
+	a.progress = progress.New(op, nil)
+	return a.progress
+}
+
+// installCandidate returns the registry entry to install for a row of the
+// skills panel. Local-only rows are resolved from the registry by name;
+// nil means the registry has no such skill.
+func (a *App) installCandidate(skill *registry.SkillEntry) *registry.SkillEntry {
+	if strings.HasPrefix(skill.Source.Repo, "/") || strings.HasPrefix(skill.Source.Repo, "~") {
+		return a.registry.GetSkill(skill.Name)
+	}
+	return skill
+}
+
+// installSkill installs a registry skill under localName; t, when set,
+// receives the clone and checkout progress
+func (a *App) installSkill(skill *registry.SkillEntry, localName string, t *progress.Tracker) tea.Cmd {
 	return func() tea.Msg {
+		if err := a.checkSkillPolicy(skill); err != nil {
+			return installErrMsg{err}
+		}
+
+		// A name that differs from an installed one only by case would
+		// share its directory on case-insensitive filesystems
+		clash, _ := a.manifest.CaseClash(localName)
+		if clash != "" {
+			localName = a.manifest.UniqueName(localName)
+		}
+
 		repoDir := filepath.Join(a.cfg.ReposDir, git.RepoDirName(skill.Source.Repo))
-		skillLink := a.manifest.GetSkillPath(skill.Name)
+		skillLink := a.manifest.GetSkillPath(localName)
 
 		result, err := git.RepoInstall(git.RepoInstallOptions{
 			RepoURL:   skill.Source.Repo,
 			Path:      skill.Source.Path,
 			RepoDir:   repoDir,
-			SkillName: skill.Name,
+			SkillName: localName,
 			SkillLink: skillLink,
+			Check:     a.riskCheck(localName),
+			Progress:  t,
 		})
 		if err != nil {
 			return installErrMsg{err}
 		}
 
 		if err := a.manifest.AddSkill(
-			skill.Name,
+			localName,
 			skill.Source.Tag,
 			result.Commit,
 			skill.Source.Repo,
@@ -1133,19 +2349,179 @@ func (a *App) installSkill(skill *registry.SkillEntry) tea.Cmd {
 		); err != nil {
 			return installErrMsg{err}
 		}
+		if err := a.manifest.SetIndexSource(localName, a.registry.IndexSource(skill)); err != nil {
+			return installErrMsg{err}
+		}
+		if localName != skill.Name {
+			if err := a.manifest.SetUpstream(localName, skill.Name); err != nil {
+				return installErrMsg{err}
+			}
+		}
+		if hash, err := integrity.HashDir(skillLink); err == nil {
+			a.manifest.SetHashes(map[string]string{localName: hash})
+		}
+
+		return installDoneMsg{skill: localName, clash: clash}
+	}
+}
+
+// estimateInstallSize looks up the expected install size in the background
+func (a *App) estimateInstallSize(skill *registry.SkillEntry) tea.Cmd {
+	a.confirmSize = nil
+	a.confirmSizeLoading = true
+	entry := *skill
+	return func() tea.Msg {
+		repoDir := filepath.Join(a.cfg.ReposDir, git.RepoDirName(entry.Source.Repo))
+		_, err := os.Stat(repoDir)
+		cloned := err == nil
+		est := remote.EstimateSize(entry.Source.Repo, entry.Source.Tag, entry.Source.Path, entry.Size, cloned)
+		return sizeEstimatedMsg{entry.Name, est, cloned}
+	}
+}
+
+// scanInstallCandidate runs the risk scan in the background when the skill's
+// files are already present in the shared repo clone. Otherwise the scan
+// happens during install, after download.
+func (a *App) scanInstallCandidate(skill *registry.SkillEntry) tea.Cmd {
+	a.confirmRisk = nil
+	a.confirmRiskLoading = true
+	entry := *skill
+	return func() tea.Msg {
+		skillPath := filepath.Join(a.cfg.ReposDir, git.RepoDirName(entry.Source.Repo), entry.Source.Path)
+		if _, err := os.Stat(skillPath); err != nil {
+			return riskScannedMsg{entry.Name, nil}
+		}
+		report, err := scan.Dir(skillPath)
+		if err != nil {
+			return riskScannedMsg{entry.Name, nil}
+		}
+		return riskScannedMsg{entry.Name, report}
+	}
+}
+
+// riskCheck returns the install-time scan hook for RepoInstall, applying
+// risk_policy and max_risk of the skill policy file
+func (a *App) riskCheck(name string) func(string) error {
+	return func(skillPath string) error {
+		policy, err := scan.ParsePolicy(a.cfg.RiskPolicy)
+		if err != nil {
+			return err
+		}
+		rules, err := skillpolicy.Load(a.cfg.SkillPolicyPath())
+		if err != nil {
+			return err
+		}
+		report, err := scan.Check(skillPath, policy)
+		if err != nil {
+			return err
+		}
+		return rules.CheckRisk(name, report)
+	}
+}
+
+// checkSkillPolicy applies the allow/deny rules of the skill policy file
+func (a *App) checkSkillPolicy(skill *registry.SkillEntry) error {
+	rules, err := skillpolicy.Load(a.cfg.SkillPolicyPath())
+	if err != nil {
+		return err
+	}
+	return rules.Check(skillpolicy.Subject{Name: skill.Name, Tags: skill.Tags, Repo: skill.Source.Repo})
+}
+
+// confirmDetails returns extra lines for the confirmation modal
+func (a *App) confirmDetails() []string {
+	var details []string
+	switch a.confirmAction {
+	case ConfirmInstall, ConfirmOverwrite, ConfirmAdopt, ConfirmInstallAs:
+		if a.confirmSkill != nil {
+			if err := a.checkSkillPolicy(a.confirmSkill); err != nil {
+				details = append(details, i18n.Tf("Blocked: %v", err))
+			}
+			if a.registry.Unverified(a.confirmSkill) {
+				details = append(details, i18n.T("⚠ Unverified: not listed by a signed index.yaml"))
+			}
+		}
+		switch {
+		case a.confirmSizeLoading:
+			details = append(details, i18n.T("Estimating size..."))
+		case a.confirmSize != nil && a.confirmSize.SkillBytes > 0:
+			line := i18n.Tf("Size: %s", remote.FormatSize(a.confirmSize.SkillBytes))
+			if !a.confirmSizeCloned && a.confirmSize.DownloadBytes > 0 {
+				line += i18n.Tf(" (download ~%s)", remote.FormatSize(a.confirmSize.DownloadBytes))
+			}
+			details = append(details, line)
+		case a.confirmSize != nil && a.confirmSize.DownloadBytes > 0:
+			details = append(details, i18n.Tf("Download: ~%s", remote.FormatSize(a.confirmSize.DownloadBytes)))
+		default:
+			details = append(details, i18n.T("Size: unknown"))
+		}
 
-		return installDoneMsg{skill.Name}
+		switch {
+		case a.confirmRiskLoading:
+			details = append(details, i18n.T("Scanning files..."))
+		case a.confirmRisk == nil:
+			details = append(details, i18n.T("Risk: scanned after download"))
+		default:
+			details = append(details, i18n.Tf("Risk: %s", a.confirmRisk.Summary()))
+			const maxFindings = 3
+			for i, f := range a.confirmRisk.Findings {
+				if i == maxFindings {
+					details = append(details, i18n.Tf("  … %d more", len(a.confirmRisk.Findings)-maxFindings))
+					break
+				}
+				details = append(details, fmt.Sprintf("  [%s] %s", f.Level, f.Path))
+			}
+			if policy, err := scan.ParsePolicy(a.cfg.RiskPolicy); err == nil && policy.Blocks(a.confirmRisk.Level()) {
+				details = append(details, i18n.Tf("Blocked by risk_policy %s", policy))
+			}
+		}
+		if a.confirmAction == ConfirmInstallAs {
+			details = append(details, "", i18n.Tf("o: replace the existing %s instead", a.confirmSkill.Name))
+		}
+	case ConfirmRemoveRepo:
+		details = append(details, a.repoSyncLine(a.confirmRepo))
+		if len(a.repoSkills) > 0 {
+			details = append(details, "", i18n.Tf("Installed from this repo (%d):", len(a.repoSkills)))
+			const maxSkills = 6
+			for i, name := range a.repoSkills {
+				if i == maxSkills {
+					details = append(details, i18n.Tf("  … %d more", len(a.repoSkills)-maxSkills))
+					break
+				}
+				details = append(details, "  "+name)
+			}
+			if a.repoSkillsPurge {
+				details = append(details, "", i18n.T("[x] s: remove these skills too"))
+			} else {
+				details = append(details, "", i18n.T("[ ] s: remove these skills too (kept skills are marked orphaned)"))
+			}
+		}
+	case ConfirmTrustRepo:
+		for i, summary := range a.trustSummaries {
+			if i > 0 {
+				details = append(details, "")
+			}
+			details = append(details, fmt.Sprintf("%s  %s", a.trustRepos[i].Name, summary.URL))
+			for _, line := range summary.Lines() {
+				details = append(details, "  "+line)
+			}
+		}
 	}
+	return details
 }
 
-func (a *App) overwriteAndInstall(skill *registry.SkillEntry) tea.Cmd {
+func (a *App) overwriteAndInstall(skill *registry.SkillEntry, localName string, t *progress.Tracker) tea.Cmd {
 	return func() tea.Msg {
-		skillLink := a.manifest.GetSkillPath(skill.Name)
-		backupDir := skillLink + ".lazyas-backup"
+		if err := a.checkSkillPolicy(skill); err != nil {
+			return installErrMsg{err}
+		}
 
-		// Move existing item (directory or symlink target) to backup
-		if err := os.Rename(skillLink, backupDir); err != nil {
-			return installErrMsg{fmt.Errorf("failed to backup existing skill: %w", err)}
+		skillLink := a.manifest.GetSkillPath(localName)
+
+		// Move the existing item (directory or symlink) to the trash
+		trashed, err := a.trashSkill(localName, trash.ReasonOverwrite)
+		if err != nil {
+			return installErrMsg{fmt.Errorf("failed to move existing skill to the trash: %w", err)}
 		}
 
 		// Install via repo sparse checkout
@@ -1154,20 +2530,19 @@ func (a *App) overwriteAndInstall(skill *registry.SkillEntry) tea.Cmd {
 			RepoURL:   skill.Source.Repo,
 			Path:      skill.Source.Path,
 			RepoDir:   repoDir,
-			SkillName: skill.Name,
+			SkillName: localName,
 			SkillLink: skillLink,
+			Check:     a.riskCheck(localName),
+			Progress:  t,
 		})
 		if err != nil {
-			// Restore backup on failure
-			os.Rename(backupDir, skillLink)
+			// Put the previous item back on failure
+			a.trash().Restore(trashed.ID)
 			return installErrMsg{err}
 		}
 
-		// Success: remove backup
-		os.RemoveAll(backupDir)
-
 		if err := a.manifest.AddSkill(
-			skill.Name,
+			localName,
 			skill.Source.Tag,
 			result.Commit,
 			skill.Source.Repo,
@@ -1175,26 +2550,58 @@ func (a *App) overwriteAndInstall(skill *registry.SkillEntry) tea.Cmd {
 		); err != nil {
 			return installErrMsg{err}
 		}
-		return installDoneMsg{skill.Name}
+		if err := a.manifest.SetUpstream(localName, skill.Name); err != nil {
+			return installErrMsg{err}
+		}
+		if err := a.manifest.SetIndexSource(localName, a.registry.IndexSource(skill)); err != nil {
+			return installErrMsg{err}
+		}
+		if hash, err := integrity.HashDir(skillLink); err == nil {
+			a.manifest.SetHashes(map[string]string{localName: hash})
+		}
+		return installDoneMsg{skill: localName}
 	}
 }
 
 func (a *App) removeSkill(skill *registry.SkillEntry) tea.Cmd {
 	return func() tea.Msg {
-		skillDir := a.manifest.GetSkillPath(skill.Name)
-
-		if err := os.RemoveAll(skillDir); err != nil {
+		if _, err := a.trashSkill(skill.Name, trash.ReasonRemove); err != nil && !os.IsNotExist(err) {
 			return removeErrMsg{err}
 		}
 
 		if err := a.manifest.RemoveSkill(skill.Name); err != nil {
 			return removeErrMsg{err}
 		}
+		a.pruneBackendLinks()
 
 		return removeDoneMsg{skill.Name}
 	}
 }
 
+// trash returns the trash that removed and overwritten skills go to
+func (a *App) trash() *trash.Store {
+	return trash.NewStore(a.cfg.TrashDir, a.cfg.TrashRetentionPeriod())
+}
+
+// trashSkill moves a skill's directory or link to the trash, keeping its
+// manifest entry so it can be restored
+func (a *App) trashSkill(name, reason string) (*trash.Item, error) {
+	var entry *manifest.InstalledSkill
+	if info, ok := a.manifest.GetInstalled(name); ok {
+		entry = &info
+	}
+	return a.trash().Move(a.manifest.GetSkillPath(name), name, reason, entry)
+}
+
+// pruneBackendLinks drops per-skill backend links to skills that are gone
+func (a *App) pruneBackendLinks() {
+	for _, b := range a.cfg.Backends {
+		if b.PerSkill() {
+			symlink.Prune(b, a.cfg.SkillsDir)
+		}
+	}
+}
+
 // viewerCmd returns an exec.Cmd for viewing a file.
 // If config.Viewer is set, use that command directly.
 // Otherwise fall back to glow -t, then $PAGER, then less.
@@ -1218,82 +2625,433 @@ func (a *App) viewerCmd(path string) *exec.Cmd {
 	return nil
 }
 
-func (a *App) syncRepos() tea.Cmd {
+// openSkillMD suspends the TUI and shows a skill's SKILL.md in the
+// external viewer. Skills that are not installed are shown from their
+// fetched preview.
+func (a *App) openSkillMD(skill *registry.SkillEntry) tea.Cmd {
+	mdPath := filepath.Join(a.cfg.SkillsDir, skill.Name, "SKILL.md")
+	tmp := ""
+	if _, err := os.Stat(mdPath); err != nil {
+		content, remote := a.detail.SkillMD()
+		if !remote || content == "" {
+			a.message = a.styles.Muted.Render(i18n.Tf("No SKILL.md to view for %s", skill.Name))
+			return nil
+		}
+		f, err := os.CreateTemp("", "lazyas-*-SKILL.md")
+		if err != nil {
+			a.message = a.styles.Error.Render(i18n.Tf("Viewer failed: %v", err))
+			return nil
+		}
+		f.WriteString(content)
+		f.Close()
+		mdPath, tmp = f.Name(), f.Name()
+	}
+
+	cmd := a.viewerCmd(mdPath)
+	if cmd == nil {
+		if tmp != "" {
+			os.Remove(tmp)
+		}
+		a.message = a.styles.Error.Render(i18n.T("No viewer found: set viewer in config.toml or install glow"))
+		return nil
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return glowDoneMsg{err, tmp}
+	})
+}
+
+// copyToClipboard puts text on the system clipboard, falling back to an
+// OSC 52 escape sequence (which also works over SSH) when no clipboard
+// tool is available
+func copyToClipboard(text string) {
+	if err := clipboard.WriteAll(text); err != nil {
+		termenv.Copy(text)
+	}
+}
+
+func (a *App) syncRepos(t *progress.Tracker) tea.Cmd {
+	reg := a.registry
 	return func() tea.Msg {
-		if err := a.registry.Fetch(true); err != nil {
+		reg.SetProgress(t)
+		defer reg.SetProgress(nil)
+		if err := reg.Fetch(true); err != nil {
 			return syncErrMsg{err}
 		}
-		return syncDoneMsg{len(a.registry.ListSkills())}
+		return syncDoneMsg{len(reg.ListSkills())}
+	}
+}
+
+// scheduleRefresh arms the next background index refresh. Any refresh that
+// is already armed or in flight is superseded.
+func (a *App) scheduleRefresh() tea.Cmd {
+	a.refreshSeq++
+	a.pendingRefresh = nil
+	interval := a.cfg.BackgroundRefresh()
+	if interval <= 0 {
+		return nil
+	}
+	seq := a.refreshSeq
+	return tea.Tick(interval, func(_ time.Time) tea.Msg { return refreshDueMsg{seq} })
+}
+
+// backgroundRefresh fetches the index into a separate registry and diffs it
+// against what the panels show, leaving the panels untouched
+func (a *App) backgroundRefresh(seq int) tea.Cmd {
+	current := a.registry.ListSkills()
+	known := a.outdated
+	// Install and remove write the manifest while this runs
+	installed := maps.Clone(a.manifest.ListInstalled())
+	return func() tea.Msg {
+		reg := registry.NewRegistry(a.cfg)
+		if err := reg.Fetch(true); err != nil {
+			return refreshReadyMsg{seq: seq} // try again next interval
+		}
+
+		seen := make(map[string]bool, len(current))
+		for _, s := range current {
+			seen[s.Name] = true
+		}
+		msg := refreshReadyMsg{seq: seq, registry: reg, outdated: a.checkStaleness(reg, installed)}
+		for _, s := range reg.ListSkills() {
+			if !seen[s.Name] {
+				msg.newSkills++
+			}
+		}
+		for name := range msg.outdated {
+			if !known[name] {
+				msg.updates++
+				msg.updated = append(msg.updated, name)
+			}
+		}
+		sort.Strings(msg.updated)
+		return msg
 	}
 }
 
-func (a *App) removeRepo(name string) tea.Cmd {
+// notifyUpdates shows a desktop notification for skills a background
+// refresh found outdated, when notifications are enabled
+func (a *App) notifyUpdates(names []string) tea.Cmd {
+	if !a.cfg.Notifications || len(names) == 0 {
+		return nil
+	}
+	n, err := notify.New(true, a.cfg.QuietHours)
+	if err != nil {
+		return nil
+	}
+	return func() tea.Msg {
+		n.Notify(i18n.T("lazyas: updates available"), strings.Join(names, ", "))
+		return nil
+	}
+}
+
+// applyRefresh swaps in the registry from the last background refresh,
+// keeping the cursor and collapsed groups
+func (a *App) applyRefresh() {
+	pending := a.pendingRefresh
+	a.pendingRefresh = nil
+	a.registry = pending.registry
+	a.outdated = pending.outdated
+	clear(a.previewed)
+	a.filterSkills()
+	a.refreshPanels()
+	a.message = a.styles.Success.Render(i18n.Tf("Refreshed. %d skill(s) available.", len(a.registry.ListSkills())))
+}
+
+// removeRepo removes a repo from the config along with the skills installed
+// from it when purge is set. Skills it keeps, including those other skills
+// depend on, are marked orphaned.
+func (a *App) removeRepo(name string, skills []string, purge bool) tea.Cmd {
+	var url string
+	if repo := a.cfg.GetRepo(name); repo != nil {
+		url = repo.URL
+	}
 	return func() tea.Msg {
 		if err := a.cfg.RemoveRepo(name); err != nil {
 			return repoRemoveErrMsg{err}
 		}
-		return repoRemovedMsg{name}
+		done := repoRemovedMsg{name: name}
+		if len(skills) == 0 {
+			return done
+		}
+		if purge {
+			for _, skill := range skills {
+				if slices.ContainsFunc(a.manifest.Dependents(skill), func(d string) bool { return !slices.Contains(skills, d) }) {
+					continue
+				}
+				if _, err := a.trashSkill(skill, trash.ReasonRemove); err != nil && !os.IsNotExist(err) {
+					return repoRemoveErrMsg{err}
+				}
+				if err := a.manifest.RemoveSkill(skill); err != nil {
+					return repoRemoveErrMsg{err}
+				}
+				done.removed = append(done.removed, skill)
+			}
+			a.pruneBackendLinks()
+		}
+		orphaned, err := a.manifest.SetOrphaned(url, true)
+		if err != nil {
+			return repoRemoveErrMsg{err}
+		}
+		done.orphaned = orphaned
+		return done
+	}
+}
+
+// addRepo adds a repo to the config, re-attaching skills orphaned when it
+// was removed earlier
+func (a *App) addRepo(name, url string) error {
+	if err := a.cfg.AddRepo(name, url); err != nil {
+		return err
 	}
+	_, err := a.manifest.SetOrphaned(url, false)
+	return err
 }
 
-func (a *App) updateAllSkills() tea.Cmd {
+// editRepoCmd renames a repo and/or changes its URL, carrying over the
+// cached index and the skills installed from it
+func (a *App) editRepoCmd(oldName, name, url string) tea.Cmd {
 	return func() tea.Msg {
-		// Get installed skills
-		installed := a.manifest.ListInstalled()
-		if len(installed) == 0 {
-			return updateDoneMsg{0, 0, 0, nil}
+		repo := a.cfg.GetRepo(oldName)
+		if repo == nil {
+			return repoEditErrMsg{fmt.Errorf("repository %s not found", oldName)}
+		}
+		oldURL := repo.URL
+
+		if name != oldName {
+			if err := a.cfg.RenameRepo(oldName, name); err != nil {
+				return repoEditErrMsg{err}
+			}
+		}
+		if url != oldURL {
+			// Move the installed skills before the config names the new URL
+			a.manifestMu.Lock()
+			defer a.manifestMu.Unlock()
+			if _, err := a.manifest.MoveRepo(oldURL, url); err != nil {
+				return repoEditErrMsg{err}
+			}
+			if err := a.cfg.SetRepoURL(name, url); err != nil {
+				if _, undoErr := a.manifest.MoveRepo(url, oldURL); undoErr != nil {
+					err = fmt.Errorf("%w (moving the installed skills back failed: %v)", err, undoErr)
+				}
+				return repoEditErrMsg{err}
+			}
 		}
 
-		// Force refresh registry first
-		a.registry.Fetch(true)
+		cache := registry.NewCacheManager(a.cfg)
+		if err := cache.Load(); err == nil {
+			cache.RemapRepo(oldURL, url)
+		}
+		return repoEditedMsg{name}
+	}
+}
 
-		var updated, skipped, failed int
-		var results []updateSkillResult
+// recordUpdateRun saves the results of an update to the history file so
+// they can be reviewed after the modal is closed
+func (a *App) recordUpdateRun(before map[string]manifest.InstalledSkill, results []updateSkillResult) {
+	run := history.Run{At: time.Now(), Source: "tui"}
+	for _, r := range results {
+		old := before[r.name]
+		res := history.Result{
+			Name:       r.name,
+			Status:     r.status,
+			OldVersion: old.Version,
+			OldCommit:  old.Commit,
+			Error:      r.problem,
+			Changes:    r.changes,
+		}
+		if now, ok := a.manifest.GetInstalled(r.name); ok {
+			res.NewVersion, res.NewCommit = now.Version, now.Commit
+		}
+		run.Set(res)
+	}
+	if len(run.Results) > 0 {
+		history.Append(a.cfg.HistoryPath, run)
+	}
+}
 
-		for name, info := range installed {
-			skillPath := a.manifest.GetSkillPath(name)
+// showLastUpdate reopens the results of the most recent update run
+func (a *App) showLastUpdate() {
+	run, err := history.Last(a.cfg.HistoryPath)
+	if err != nil {
+		a.message = a.styles.Error.Render(i18n.Tf("Failed to read update history: %v", err))
+		return
+	}
+	if run == nil {
+		a.message = a.styles.Muted.Render(i18n.T("No update has been recorded yet"))
+		return
+	}
 
-			// Check for modifications
-			modified, _ := git.IsModified(skillPath)
-			if modified {
-				results = append(results, updateSkillResult{name, "skipped"})
-				skipped++
-				continue
-			}
+	msg := updateDoneMsg{title: i18n.Tf("Last Update (%s)", run.At.Local().Format("Jan 2 15:04"))}
+	msg.updated, msg.skipped, msg.failed = run.Counts()
+	for _, res := range run.Results {
+		msg.results = append(msg.results, updateSkillResult{
+			name:    res.Name,
+			status:  res.Status,
+			changes: res.Changes,
+			problem: res.Error,
+		})
+	}
+	a.updateResult = &msg
+	a.mode = ModeUpdateResult
+}
 
-			// Check if update available
-			skill := a.registry.GetSkill(name)
+// updateSkill moves one installed skill to its target version, respecting
+// local changes, the skill policy and the semver update policy. ok is
+// false when the update was attempted and failed.
+func (a *App) updateSkill(name string, info manifest.InstalledSkill, rules *skillpolicy.Policy, rulesErr error) (updateSkillResult, bool) {
+	skillPath := a.manifest.GetSkillPath(name)
 
-			// Determine target version
-			targetTag := ""
-			if skill != nil {
-				targetTag = skill.Source.Tag
-			}
+	// Release assets are updated by installing a newer release
+	if release.IsSource(info.SourceRepo) {
+		return updateSkillResult{name: name, status: "skipped", problem: i18n.T("release asset")}, true
+	}
 
-			result, err := git.Update(skillPath, targetTag)
-			if err != nil {
-				results = append(results, updateSkillResult{name, "failed"})
-				failed++
-				continue
-			}
+	// Copies of local directories are copied again when they changed
+	if info.AdHoc && adhoc.IsLocal(info.SourceRepo) {
+		return a.updateLocalCopy(name, info, rules, rulesErr)
+	}
+
+	// Check for modifications
+	modified, _ := git.IsModified(skillPath)
+	if modified {
+		return updateSkillResult{name: name, status: "skipped"}, true
+	}
+
+	// Check if update available
+	skill := a.registry.GetSkill(info.RegistryName(name))
+	if info.AdHoc {
+		skill = nil // installed with --from; a registry skill of that name is another one
+	}
+
+	// Determine target ref: tracked branch, else the registry tag
+	registryTag := ""
+	if skill != nil {
+		registryTag = skill.Source.Tag
+	}
+	targetRef := info.TargetRef(registryTag)
+
+	// A pinned skill only moves within its pin
+	if info.Pin != "" {
+		targetRef = git.PinTarget(skillPath, info.RegistryName(name), info.SourcePath, info.Pin)
+		if targetRef == info.Version {
+			return updateSkillResult{name: name, status: "held", problem: i18n.Tf("pinned to %s", info.Pin)}, true
+		}
+	}
+
+	// Respect the skill policy file
+	subject := skillpolicy.Subject{Name: name, Repo: info.SourceRepo}
+	if skill != nil {
+		subject.Tags = skill.Tags
+		if info.ForkedFrom == "" {
+			subject.Repo = skill.Source.Repo
+		}
+	}
+	violation := rulesErr
+	if violation == nil {
+		violation = rules.Check(subject)
+	}
+	if violation != nil {
+		return updateSkillResult{name: name, status: "blocked", problem: violation.Error()}, true
+	}
+
+	// Respect the semver update policy: move to the newest version it allows
+	// (major jumps need the CLI --major)
+	policy := semver.EffectivePolicy(info.Policy, a.cfg.UpdatePolicy)
+	if !policy.Allows(info.Version, targetRef) {
+		targetRef = git.PolicyTarget(skillPath, info.RegistryName(name), info.SourcePath, info.Version, targetRef, policy)
+		if targetRef == info.Version {
+			return updateSkillResult{name: name, status: "held"}, true
+		}
+	}
+
+	// Collect the changelog before the update moves the checkout
+	var changes []string
+	if cl, err := git.FetchChangelog(skillPath, info.Commit, targetRef); err == nil {
+		changes = append(cl.Notes, cl.Commits...)
+	}
+
+	// Scan the new version against max_risk before the checkout moves
+	if rules.LimitsRisk() {
+		staged, err := git.StageRef(skillPath, targetRef)
+		if err != nil {
+			return updateSkillResult{name: name, status: "failed", problem: err.Error()}, false
+		}
+		report, err := scan.Dir(staged)
+		os.RemoveAll(staged)
+		if err != nil {
+			return updateSkillResult{name: name, status: "failed", problem: err.Error()}, false
+		}
+		if violation := rules.CheckRisk(name, report); violation != nil {
+			return updateSkillResult{name: name, status: "blocked", problem: violation.Error()}, true
+		}
+	}
+
+	result, err := git.Update(skillPath, targetRef)
+	if err != nil {
+		return updateSkillResult{name: name, status: "failed", problem: err.Error()}, false
+	}
+
+	if result.Commit == info.Commit {
+		return updateSkillResult{name: name, status: "up-to-date"}, true
+	}
+	sourceRepo := info.SourceRepo
+	sourcePath := info.SourcePath
+	if skill != nil && info.ForkedFrom == "" {
+		sourceRepo = skill.Source.Repo
+		sourcePath = skill.Source.Path
+	}
+	a.manifestMu.Lock()
+	a.manifest.AddSkill(name, targetRef, result.Commit, sourceRepo, sourcePath)
+	a.manifestMu.Unlock()
+	return updateSkillResult{name: name, status: "updated", changes: changes}, true
+}
+
+// updateLocalCopy is updateSkill for a skill installed from a local
+// directory: the directory is copied again unless it is unchanged or the
+// installed copy was edited
+func (a *App) updateLocalCopy(name string, info manifest.InstalledSkill, rules *skillpolicy.Policy, rulesErr error) (updateSkillResult, bool) {
+	skillPath := a.manifest.GetSkillPath(name)
+	if hash, err := integrity.HashDir(skillPath); err == nil && info.Hash != "" && hash != info.Hash {
+		return updateSkillResult{name: name, status: "skipped"}, true
+	}
+	violation := rulesErr
+	if violation == nil {
+		violation = rules.Check(skillpolicy.Subject{Name: name, Repo: info.SourceRepo})
+	}
+	if violation != nil {
+		return updateSkillResult{name: name, status: "blocked", problem: violation.Error()}, true
+	}
+
+	digest, err := adhoc.Refresh(info.SourceRepo, skillPath, info.Commit, func(dir string) error {
+		report, err := scan.Dir(dir)
+		if err != nil {
+			return err
+		}
+		return rules.CheckRisk(name, report)
+	})
+	if err != nil {
+		return updateSkillResult{name: name, status: "failed", problem: err.Error()}, false
+	}
+	if digest == "" {
+		return updateSkillResult{name: name, status: "up-to-date"}, true
+	}
+	a.manifestMu.Lock()
+	a.manifest.AddSkill(name, info.Version, digest, info.SourceRepo, info.SourcePath)
+	a.manifestMu.Unlock()
+	return updateSkillResult{name: name, status: "updated"}, true
+}
 
-			if result.Commit != info.Commit {
-				sourceRepo := info.SourceRepo
-				sourcePath := info.SourcePath
-				if skill != nil {
-					sourceRepo = skill.Source.Repo
-					sourcePath = skill.Source.Path
-				}
-				a.manifest.AddSkill(name, targetTag, result.Commit, sourceRepo, sourcePath)
-				results = append(results, updateSkillResult{name, "updated"})
-				updated++
-			} else {
-				results = append(results, updateSkillResult{name, "up-to-date"})
-				skipped++
+// repairBackends fixes link problems found by symlink.Verify
+func (a *App) repairBackends(problems []symlink.Problem) tea.Cmd {
+	return func() tea.Msg {
+		repaired := 0
+		for _, p := range problems {
+			if err := symlink.Repair(p, a.cfg.SkillsDir, a.trash().Discard(trash.ReasonMigrate)); err != nil {
+				return backendLinkErrMsg{fmt.Errorf("failed to repair %s: %w", p.Backend.Name, err)}
 			}
+			repaired++
 		}
-
-		return updateDoneMsg{updated, skipped, failed, results}
+		return backendRepairedMsg{repaired}
 	}
 }
 
@@ -1301,9 +3059,14 @@ func (a *App) linkBackends(toLink []symlink.LinkStatus) tea.Cmd {
 	return func() tea.Msg {
 		linked := 0
 		for _, s := range toLink {
-			if s.HasFiles && !s.IsSymlink {
+			if s.Backend.PerSkill() {
+				// Per-skill backends only need their directory
+				if err := symlink.ConvertToPerSkill(s.Backend, a.cfg.SkillsDir, nil); err != nil {
+					return backendLinkErrMsg{fmt.Errorf("failed to link %s: %w", s.Backend.Name, err)}
+				}
+			} else if s.HasFiles && !s.IsSymlink {
 				// Migrate existing directory
-				if err := symlink.MigrateExistingDir(s.Backend, a.cfg.SkillsDir); err != nil {
+				if err := symlink.MigrateExistingDir(s.Backend, a.cfg.SkillsDir, a.trash().Discard(trash.ReasonMigrate)); err != nil {
 					return backendLinkErrMsg{fmt.Errorf("failed to migrate %s: %w", s.Backend.Name, err)}
 				}
 			} else {
@@ -1321,7 +3084,7 @@ func (a *App) linkBackends(toLink []symlink.LinkStatus) tea.Cmd {
 // View renders the application
 func (a *App) View() string {
 	if !a.ready {
-		return "Initializing..."
+		return i18n.T("Initializing...")
 	}
 
 	var b strings.Builder
@@ -1329,7 +3092,7 @@ func (a *App) View() string {
 	// Title with backend status
 	b.WriteString(a.styles.Title.Render("lazyas"))
 	b.WriteString("  ")
-	b.WriteString(a.styles.StatusBar.Render("Lazy Agent Skills"))
+	b.WriteString(a.styles.StatusBar.Render(i18n.T("Lazy Agent Skills")))
 
 	// Backend status in header
 	if a.totalBackends > 0 {
@@ -1360,12 +3123,29 @@ func (a *App) View() string {
 		b.WriteString(a.overlayModal(a.renderPanels(), a.renderUpdateResultContent()))
 	case ModeError:
 		b.WriteString(a.overlayModal(a.renderPanels(), a.renderErrorContent()))
+	case ModeVersionPicker:
+		b.WriteString(a.overlayModal(a.renderPanels(), a.renderVersionPickerContent()))
+	case ModeManifest:
+		b.WriteString(a.overlayModal(a.renderPanels(), a.renderManifestBrowserContent()))
+	case ModeQueue:
+		b.WriteString(a.overlayModal(a.renderPanels(), a.renderQueueContent()))
+	case ModeLegend:
+		b.WriteString(a.overlayModal(a.renderPanels(), a.renderLegendContent()))
+	case ModeSettings:
+		b.WriteString(a.overlayModal(a.renderPanels(), a.renderSettingsContent()))
+	case ModeTags:
+		b.WriteString(a.overlayModal(a.renderPanels(), a.renderTagFilterContent()))
+	case ModeRepos:
+		b.WriteString(a.overlayModal(a.renderPanels(), a.renderRepoPickerContent()))
 	}
 
 	// Error or message (always reserve the line to prevent layout jumps)
 	b.WriteString("\n")
-	if a.err != nil {
-		b.WriteString(a.styles.Error.Render(fmt.Sprintf("Error: %v", a.err)))
+	if a.mode == ModeLoading && a.progress != nil && a.progress.State().Total > 0 {
+		state := a.progress.State()
+		b.WriteString(a.styles.Muted.Render(state.Op+" "+state.Bar(30)) + "  " + a.styles.HelpText.Render(i18n.T("esc: cancel")))
+	} else if a.err != nil {
+		b.WriteString(a.styles.Error.Render(i18n.Tf("Error: %v", a.err)))
 	} else if a.message != "" {
 		b.WriteString(a.message)
 	}
@@ -1396,7 +3176,7 @@ func (a *App) renderLoading() string {
 }
 
 func (a *App) renderLoadingContent() string {
-	modalBg := lipgloss.Color("#1a1a2e")
+	modalBg := styles.Current().Background
 	contentWidth := 40
 	if len(a.loadingMsg)+6 > contentWidth {
 		contentWidth = len(a.loadingMsg) + 6
@@ -1406,15 +3186,28 @@ func (a *App) renderLoadingContent() string {
 		Background(modalBg).
 		Width(contentWidth)
 
+	// The step being worked on, e.g. the phase of a clone and its progress
+	var step string
+	if a.progress != nil {
+		if state := a.progress.State(); state.Step != "" {
+			step = "    " + state.Step
+			if state.Total > 0 {
+				step += fmt.Sprintf(" %d%%", state.Percent())
+			}
+			lineBg = lineBg.Width(max(contentWidth, len(step)+2))
+		}
+	}
+
 	spinners := []string{"⠋", "⠙", "⠹", "⠸"}
 	spinner := spinners[a.spinnerIdx%len(spinners)]
 	line := fmt.Sprintf("  %s %s", spinner, a.loadingMsg)
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		lineBg.Render(""),
-		lineBg.Render(line),
-		lineBg.Render(""),
-	)
+	lines := []string{lineBg.Render(""), lineBg.Render(line)}
+	if step != "" {
+		lines = append(lines, lineBg.Inherit(a.styles.Muted).Render(step))
+	}
+	lines = append(lines, lineBg.Render(""))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
 func (a *App) renderPanels() string {
@@ -1422,6 +3215,14 @@ func (a *App) renderPanels() string {
 		return ""
 	}
 
+	if a.showEmptyState() {
+		width := a.layout.LeftWidth() + a.layout.RightWidth() + 1
+		return a.styles.ActivePanel.
+			Width(width - 2).
+			Height(a.layout.ContentHeight()).
+			Render(a.renderEmptyState(width-2, a.layout.ContentHeight()))
+	}
+
 	// Left panel
 	leftStyle := a.styles.Panel
 	if a.layout.Focus() == layout.PanelLeft {
@@ -1452,8 +3253,8 @@ func (a *App) overlayModal(background, modalContent string) string {
 	// Create modal box with solid background
 	modalStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7C3AED")).
-		Background(lipgloss.Color("#1a1a2e")).
+		BorderForeground(styles.Current().Primary).
+		Background(styles.Current().Background).
 		Padding(1, 2)
 
 	modal := modalStyle.Render(modalContent)
@@ -1527,37 +3328,58 @@ func (a *App) renderConfirmContent() string {
 	var title, message string
 	switch a.confirmAction {
 	case ConfirmInstall:
-		title = "Install Skill"
-		message = fmt.Sprintf("Install %s?", a.confirmSkill.Name)
+		title = i18n.T("Install Skill")
+		message = i18n.Tf("Install %s?", a.confirmSkill.Name)
 	case ConfirmRemove:
-		title = "Remove Skill"
-		message = fmt.Sprintf("Remove %s?", a.confirmSkill.Name)
+		title = i18n.T("Remove Skill")
+		message = i18n.Tf("Remove %s?", a.confirmSkill.Name)
 	case ConfirmRemoveRepo:
-		title = "Remove Repository"
-		message = fmt.Sprintf("Remove repo '%s'?", a.confirmRepo)
+		title = i18n.T("Remove Repository")
+		message = i18n.Tf("Remove repo '%s'?", a.confirmRepo)
 	case ConfirmOverwrite:
-		title = "Install from Registry"
-		message = fmt.Sprintf("Replace local %s with registry version?", a.confirmSkill.Name)
+		title = i18n.T("Install from Registry")
+		message = i18n.Tf("Replace local %s with registry version?", a.confirmSkill.Name)
+	case ConfirmKeepLocal:
+		title = i18n.T("Keep as Local Skill")
+		message = i18n.Tf("Stop tracking %s and keep its files?", a.confirmSkill.Name)
+	case ConfirmInstallAs:
+		title = i18n.T("Name Taken")
+		if a.occupant == "local" {
+			message = i18n.Tf("%s is taken by a local skill. Install as %s?", a.confirmSkill.Name, a.installAs)
+		} else {
+			message = i18n.Tf("%s is taken by a skill from %s. Install as %s?", a.confirmSkill.Name, a.occupant, a.installAs)
+		}
+	case ConfirmDiscard:
+		title = i18n.T("Discard Local Changes")
+		message = i18n.Tf("Discard all local changes to %s? This cannot be undone.", a.confirmSkill.Name)
+	case ConfirmAdopt:
+		title = i18n.T("Adopt Registry Skill")
+		message = i18n.Tf("Replace local %s with registry skill %s?", a.adoptName, a.confirmSkill.InstallRef())
+	case ConfirmTrustRepo:
+		title = i18n.T("Trust Repository")
+		message = i18n.T("Add this repository?")
+		if len(a.trustRepos) > 1 {
+			message = i18n.Tf("Add these %d repositories?", len(a.trustRepos))
+		}
 	}
 
 	// Modal background color for consistent styling
-	modalBg := lipgloss.Color("#1a1a2e")
+	modalBg := styles.Current().Background
 
-	yesBtn := a.styles.Button.Background(modalBg).Render(" Yes ")
-	noBtn := a.styles.Button.Background(modalBg).Render(" No ")
-	if a.confirmSel == 0 {
-		yesBtn = a.styles.ButtonActive.Render(" Yes ")
-		noBtn = a.styles.Button.Background(modalBg).Render(" No ")
-	} else {
-		yesBtn = a.styles.Button.Background(modalBg).Render(" Yes ")
-		noBtn = a.styles.ButtonActive.Render(" No ")
-	}
+	buttons := a.renderButtons(modalBg, a.confirmSel, i18n.T("Yes"), i18n.T("No"))
+
+	details := a.confirmDetails()
 
 	// Calculate content width for consistent background
 	contentWidth := 30
 	if len(message) > contentWidth {
 		contentWidth = len(message) + 4
 	}
+	for _, d := range details {
+		if len(d)+4 > contentWidth {
+			contentWidth = len(d) + 4
+		}
+	}
 
 	// Style for consistent background on all lines
 	lineBg := lipgloss.NewStyle().
@@ -1567,17 +3389,15 @@ func (a *App) renderConfirmContent() string {
 	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(title)
 	messageStyled := lineBg.Render(message)
 	emptyLine := lineBg.Render("")
-	spacer := lipgloss.NewStyle().Background(modalBg).Render("  ")
-	buttons := lipgloss.JoinHorizontal(lipgloss.Top, yesBtn, spacer, noBtn)
 	buttonsStyled := lineBg.Render(buttons)
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		titleStyled,
-		emptyLine,
-		messageStyled,
-		emptyLine,
-		buttonsStyled,
-	)
+	lines := []string{titleStyled, emptyLine, messageStyled}
+	for _, d := range details {
+		lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(d))
+	}
+	lines = append(lines, emptyLine, buttonsStyled)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
 func (a *App) renderAddRepoContent() string {
@@ -1586,11 +3406,11 @@ func (a *App) renderAddRepoContent() string {
 	a.addRepoURL.Width = 50
 
 	// Modal background color for consistent styling
-	modalBg := lipgloss.Color("#1a1a2e")
+	modalBg := styles.Current().Background
 	contentWidth := 70
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#6B7280")).
+		Foreground(styles.Current().Muted).
 		Background(modalBg).
 		Width(8)
 
@@ -1598,21 +3418,37 @@ func (a *App) renderAddRepoContent() string {
 		Background(modalBg).
 		Width(contentWidth)
 
-	var nameIndicator, urlIndicator string
-	if a.addRepoFocus == 0 {
+	nameIndicator := lipgloss.NewStyle().Background(modalBg).Render("  ")
+	urlIndicator := nameIndicator
+	switch a.addRepoFocus {
+	case addRepoFieldName:
 		nameIndicator = a.styles.Title.Background(modalBg).Render("> ")
-		urlIndicator = lipgloss.NewStyle().Background(modalBg).Render("  ")
-	} else {
-		nameIndicator = lipgloss.NewStyle().Background(modalBg).Render("  ")
+	case addRepoFieldURL:
 		urlIndicator = a.styles.Title.Background(modalBg).Render("> ")
 	}
 
-	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render("Add Repository")
-	descStyled := lineBg.Render("Add a skills repository to fetch skills from.")
+	title, desc, action, button := i18n.T("Add Repository"), i18n.T("Add a skills repository to fetch skills from."), i18n.T("add"), i18n.T("Add")
+	if a.editRepo != "" {
+		title = i18n.T("Edit Repository")
+		desc = i18n.T("Installed skills follow the repository to its new URL.")
+		action, button = i18n.T("save"), i18n.T("Save")
+	}
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(title)
+	descStyled := lineBg.Render(desc)
 	emptyLine := lineBg.Render("")
-	nameRow := lineBg.Render(lipgloss.JoinHorizontal(lipgloss.Top, nameIndicator, labelStyle.Render("Name"), a.addRepoName.View()))
-	urlRow := lineBg.Render(lipgloss.JoinHorizontal(lipgloss.Top, urlIndicator, labelStyle.Render("URL"), a.addRepoURL.View()))
-	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render("tab: next    enter: add    esc: cancel")
+	nameRow := lineBg.Render(lipgloss.JoinHorizontal(lipgloss.Top, nameIndicator, labelStyle.Render(i18n.T("Name")), a.addRepoName.View()))
+	urlRow := lineBg.Render(lipgloss.JoinHorizontal(lipgloss.Top, urlIndicator, labelStyle.Render(i18n.T("URL")), a.addRepoURL.View()))
+	statusRow := emptyLine
+	switch {
+	case a.addRepoChecking:
+		statusRow = a.styles.Muted.Background(modalBg).Width(contentWidth).Render("          " + i18n.T("Checking the URL..."))
+	case a.addRepoErr != "":
+		statusRow = a.styles.Error.Background(modalBg).Width(contentWidth).Render("          ✗ " + truncate(a.addRepoErr, contentWidth-12))
+	case a.addRepoHint != "":
+		statusRow = a.styles.Muted.Background(modalBg).Width(contentWidth).Render("          " + a.addRepoHint)
+	}
+	buttonsRow := lineBg.Render("          " + a.renderButtons(modalBg, a.addRepoFocus-addRepoSubmit, button, i18n.T("Cancel")))
+	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(i18n.Tf("tab: next    enter: %s    esc: cancel", action))
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		titleStyled,
@@ -1622,23 +3458,25 @@ func (a *App) renderAddRepoContent() string {
 		nameRow,
 		emptyLine,
 		urlRow,
+		statusRow,
+		buttonsRow,
 		emptyLine,
 		helpStyled,
 	)
 }
 
 func (a *App) renderBackendSetupContent() string {
-	modalBg := lipgloss.Color("#1a1a2e")
+	modalBg := styles.Current().Background
 	contentWidth := 50
 
 	lineBg := lipgloss.NewStyle().
 		Background(modalBg).
 		Width(contentWidth)
 
-	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render("Backend Setup")
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(i18n.T("Backend Setup"))
 	emptyLine := lineBg.Render("")
-	descStyled := lineBg.Render("lazyas manages skills in ~/.lazyas/skills/")
-	desc2Styled := lineBg.Render("Select backends to link:")
+	descStyled := lineBg.Render(i18n.T("lazyas manages skills in ~/.lazyas/skills/"))
+	desc2Styled := lineBg.Render(i18n.T("Select backends to link:"))
 
 	var lines []string
 	lines = append(lines, titleStyled, emptyLine, descStyled, desc2Styled, emptyLine)
@@ -1650,7 +3488,11 @@ func (a *App) renderBackendSetupContent() string {
 		var line string
 		var suffix string
 
-		if s.Linked {
+		repair := len(a.problemsOf(s.Backend.Name)) > 0
+		if repair {
+			line = fmt.Sprintf("  [ ] %s (%s)", s.Backend.Name, expandedPath)
+			suffix = " ⚠ needs repair"
+		} else if s.Linked {
 			line = fmt.Sprintf("  [ ] %s (%s)", s.Backend.Name, expandedPath)
 			suffix = " ✓ linked"
 		} else if s.Error != nil {
@@ -1674,18 +3516,13 @@ func (a *App) renderBackendSetupContent() string {
 		if selected && !s.Available && !s.Linked && s.Error == nil {
 			// Dim highlight for unavailable backends
 			dimCursorStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color("#374151")).
-				Foreground(lipgloss.Color("#6B7280")).
+				Background(styles.Current().Border).
+				Foreground(styles.Current().Muted).
 				Width(contentWidth)
 			lines = append(lines, dimCursorStyle.Render(line+suffix))
 		} else if selected {
 			// Render entire line uniformly with cursor highlight
-			cursorStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color("#7C3AED")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Width(contentWidth).
-				Bold(true)
-			lines = append(lines, cursorStyle.Render(line+suffix))
+			lines = append(lines, modalCursorStyle(a.backendFocus == 0, contentWidth).Render(line+suffix))
 		} else if !s.Available && !s.Linked && s.Error == nil {
 			// Muted/gray for unavailable backends
 			mutedLine := a.styles.Muted.Render(line + suffix)
@@ -1693,7 +3530,9 @@ func (a *App) renderBackendSetupContent() string {
 		} else {
 			// Render label + styled suffix separately on modal background
 			var styledLine string
-			if s.Linked {
+			if repair {
+				styledLine = line + a.styles.Warning.Render(suffix)
+			} else if s.Linked {
 				styledLine = line + a.styles.Success.Render(suffix)
 			} else if s.Error != nil {
 				styledLine = line + a.styles.Error.Render(suffix)
@@ -1704,8 +3543,20 @@ func (a *App) renderBackendSetupContent() string {
 		}
 	}
 
-	lines = append(lines, emptyLine)
-	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render("space: toggle  enter: link  esc: skip")
+	// What is wrong with the backend under the cursor
+	help := i18n.T("space: toggle  tab: focus  enter: link  esc: skip")
+	if a.backendCursor < len(a.backendStatuses) {
+		if problems := a.problemsOf(a.backendStatuses[a.backendCursor].Backend.Name); len(problems) > 0 {
+			lines = append(lines, emptyLine)
+			for _, p := range problems {
+				lines = append(lines, a.styles.Warning.Background(modalBg).Width(contentWidth).Render(ansi.Truncate("  "+p.String(), contentWidth, "…")))
+			}
+			help = i18n.T("r: repair  space: toggle  tab: focus  enter: link  esc: skip")
+		}
+	}
+
+	lines = append(lines, emptyLine, lineBg.Render("  "+a.renderButtons(modalBg, a.backendFocus-1, i18n.T("Link"), i18n.T("Skip"))), emptyLine)
+	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(help)
 	lines = append(lines, helpStyled)
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
@@ -1716,14 +3567,18 @@ func (a *App) renderUpdateResultContent() string {
 		return ""
 	}
 
-	modalBg := lipgloss.Color("#1a1a2e")
+	modalBg := styles.Current().Background
 	contentWidth := 45
 
 	lineBg := lipgloss.NewStyle().
 		Background(modalBg).
 		Width(contentWidth)
 
-	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render("Update Skills")
+	title := i18n.T("Update Skills")
+	if a.updateResult.title != "" {
+		title = a.updateResult.title
+	}
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(title)
 	emptyLine := lineBg.Render("")
 
 	var lines []string
@@ -1733,33 +3588,237 @@ func (a *App) renderUpdateResultContent() string {
 		var statusIcon string
 		switch r.status {
 		case "updated":
-			statusIcon = a.styles.Success.Background(modalBg).Render("✓ updated")
+			statusIcon = a.styles.Success.Background(modalBg).Render(i18n.T("✓ updated"))
 		case "up-to-date":
-			statusIcon = a.styles.Muted.Background(modalBg).Render("  up to date")
+			statusIcon = a.styles.Muted.Background(modalBg).Render(i18n.T("  up to date"))
 		case "skipped":
-			statusIcon = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Background(modalBg).Render("⚠ local changes")
+			statusIcon = a.styles.Warning.Background(modalBg).Render(i18n.T("⚠ local changes"))
 		case "failed":
-			statusIcon = a.styles.Error.Background(modalBg).Render("✗ failed")
+			statusIcon = a.styles.Error.Background(modalBg).Render(i18n.T("✗ failed"))
+		case "held":
+			label := i18n.T("⏸ held by policy")
+			if r.problem != "" {
+				label = "⏸ " + r.problem
+			}
+			statusIcon = a.styles.Muted.Background(modalBg).Render(label)
+		case "invalid":
+			statusIcon = a.styles.Error.Background(modalBg).Render(i18n.T("✗ failed validation"))
+		case "blocked":
+			statusIcon = a.styles.Error.Background(modalBg).Render(i18n.T("⊘ blocked by skill policy"))
 		}
 		line := fmt.Sprintf("  %-20s %s", r.name, statusIcon)
 		lines = append(lines, lineBg.Render(line))
+		if r.problem != "" && r.status != "held" {
+			problem := ansi.Truncate("    "+r.problem, contentWidth, "…")
+			lines = append(lines, a.styles.Error.Background(modalBg).Width(contentWidth).Render(problem))
+		}
+
+		// Changelog preview (first few entries)
+		const maxChanges = 3
+		for i, c := range r.changes {
+			if i == maxChanges {
+				more := i18n.Tf("    … %d more", len(r.changes)-maxChanges)
+				lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(more))
+				break
+			}
+			entry := ansi.Truncate("    • "+c, contentWidth, "…")
+			lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(entry))
+		}
 	}
 
 	lines = append(lines, emptyLine)
 
-	summary := fmt.Sprintf("Updated: %d  Skipped: %d  Failed: %d",
+	summary := i18n.Tf("Updated: %d  Skipped: %d  Failed: %d",
 		a.updateResult.updated, a.updateResult.skipped, a.updateResult.failed)
 	lines = append(lines, lineBg.Render(summary))
+	if a.updateResult.cancelled > 0 {
+		lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(
+			i18n.Tf("Cancelled: %d skill(s) not updated", a.updateResult.cancelled)))
+	}
 	lines = append(lines, emptyLine)
 
-	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render("enter/esc: close")
+	help := i18n.T("enter/esc: close")
+	if a.updateResult.running {
+		done := len(a.updateResult.results)
+		status := i18n.Tf("Updating %d/%d...", done, a.updateResult.total)
+		if a.progress != nil && a.progress.Err() != nil {
+			status = i18n.Tf("Cancelling after the skills being updated (%d/%d)...", done, a.updateResult.total)
+		}
+		lines = append(lines[:len(lines)-1], lineBg.Render(status), emptyLine)
+		help = i18n.T("esc/c: cancel")
+	}
+	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(help)
+	lines = append(lines, helpStyled)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (a *App) updateVersionPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		a.mode = ModeNormal
+		return a, nil
+
+	case "j", "down":
+		if a.versionCursor < len(a.versionTags)-1 {
+			a.versionCursor++
+		}
+		return a, nil
+
+	case "k", "up":
+		if a.versionCursor > 0 {
+			a.versionCursor--
+		}
+		return a, nil
+
+	case "g", "home":
+		a.versionCursor = 0
+		return a, nil
+
+	case "G", "end":
+		a.versionCursor = len(a.versionTags) - 1
+		return a, nil
+
+	case "enter":
+		if a.versionModified || a.versionCursor >= len(a.versionTags) {
+			return a, nil
+		}
+		ref := a.versionTags[a.versionCursor].Name
+		a.loadingMsg = i18n.Tf("Checking out %s@%s...", a.versionSkill, ref)
+		a.mode = ModeLoading
+		return a, tea.Batch(
+			a.switchVersion(a.versionSkill, ref),
+			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		)
+	}
+
+	return a, nil
+}
+
+// isInstalledTag reports whether a tag is the version a skill is installed at
+func isInstalledTag(tag git.RemoteTag, info manifest.InstalledSkill) bool {
+	return tag.Name == info.Version || (info.Commit != "" && tag.Commit == info.Commit)
+}
+
+// loadVersions lists the tags of a skill's source repo, newest first
+func (a *App) loadVersions(name string) tea.Cmd {
+	return func() tea.Msg {
+		info, _ := a.manifest.GetInstalled(name)
+		tags, err := git.SkillVersions(a.manifest.GetSkillPath(name), info.RegistryName(name), info.SourcePath)
+		if err != nil {
+			return versionsErrMsg{err}
+		}
+		return versionsLoadedMsg{name, tags}
+	}
+}
+
+// switchVersion checks out an exact tag and records it in the manifest
+func (a *App) switchVersion(name, ref string) tea.Cmd {
+	return func() tea.Msg {
+		info, _ := a.manifest.GetInstalled(name)
+		if !semver.MatchesPin(info.Pin, ref) {
+			return versionSwitchErrMsg{fmt.Errorf("%s is pinned to %s (change the pin with 'lazyas pin %s %s')", name, info.Pin, name, ref)}
+		}
+		result, err := git.Update(a.manifest.GetSkillPath(name), ref)
+		if err != nil {
+			return versionSwitchErrMsg{err}
+		}
+		if err := a.manifest.AddSkill(name, ref, result.Commit, info.SourceRepo, info.SourcePath); err != nil {
+			return versionSwitchErrMsg{err}
+		}
+		return versionSwitchDoneMsg{name, ref}
+	}
+}
+
+// mergeSkill three-way merges the upstream version into a modified skill
+func (a *App) mergeSkill(name string) tea.Cmd {
+	return func() tea.Msg {
+		info, _ := a.manifest.GetInstalled(name)
+		registryTag := ""
+		if skill := a.registry.GetSkill(info.RegistryName(name)); skill != nil {
+			registryTag = skill.Source.Tag
+		}
+		targetRef := info.TargetRef(registryTag)
+
+		result, err := git.MergeUpstream(a.manifest.GetSkillPath(name), info.Commit, targetRef)
+		if err != nil {
+			return mergeErrMsg{err}
+		}
+		if err := a.manifest.AddSkill(name, targetRef, result.Commit, info.SourceRepo, info.SourcePath); err != nil {
+			return mergeErrMsg{err}
+		}
+		if err := a.manifest.SetConflicts(name, result.Conflicts); err != nil {
+			return mergeErrMsg{err}
+		}
+		return mergeDoneMsg{name, result.Conflicts}
+	}
+}
+
+func (a *App) renderVersionPickerContent() string {
+	modalBg := styles.Current().Background
+	contentWidth := 45
+
+	lineBg := lipgloss.NewStyle().
+		Background(modalBg).
+		Width(contentWidth)
+
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(i18n.Tf("Versions of %s", a.versionSkill))
+	emptyLine := lineBg.Render("")
+
+	var lines []string
+	lines = append(lines, titleStyled, emptyLine)
+
+	if a.versionModified {
+		warn := a.styles.Warning.Background(modalBg).Width(contentWidth)
+		lines = append(lines,
+			warn.Render(i18n.T("⚠ Local changes: commit or discard them first")),
+			emptyLine)
+	}
+
+	installed, _ := a.manifest.GetInstalled(a.versionSkill)
+
+	// Show a window of tags around the cursor
+	const maxVisible = 12
+	start := 0
+	if a.versionCursor >= maxVisible {
+		start = a.versionCursor - maxVisible + 1
+	}
+	end := start + maxVisible
+	if end > len(a.versionTags) {
+		end = len(a.versionTags)
+	}
+
+	for i := start; i < end; i++ {
+		tag := a.versionTags[i]
+		line := "  " + tag.Name
+		if isInstalledTag(tag, installed) {
+			line += "  " + i18n.T("(installed)")
+		}
+		if i == a.versionCursor {
+			cursorStyle := lipgloss.NewStyle().
+				Background(styles.Current().Primary).
+				Foreground(styles.Current().SelectedText).
+				Width(contentWidth).
+				Bold(true)
+			lines = append(lines, cursorStyle.Render(line))
+		} else {
+			lines = append(lines, lineBg.Render(line))
+		}
+	}
+	if len(a.versionTags) > maxVisible {
+		lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(
+			fmt.Sprintf("  %d/%d", a.versionCursor+1, len(a.versionTags))))
+	}
+
+	lines = append(lines, emptyLine)
+	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(i18n.T("enter: check out  esc: cancel"))
 	lines = append(lines, helpStyled)
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
 func (a *App) renderErrorContent() string {
-	modalBg := lipgloss.Color("#1a1a2e")
+	modalBg := styles.Current().Background
 	contentWidth := 60
 
 	lineBg := lipgloss.NewStyle().
@@ -1786,7 +3845,41 @@ func (a *App) renderErrorContent() string {
 	}
 
 	lines = append(lines, emptyLine)
-	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render("enter/esc: close")
+	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(i18n.T("enter/esc: close"))
+	lines = append(lines, helpStyled)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderLegendContent explains the icons of the skills list. Installed
+// states are listed in precedence order: the first that applies is shown.
+func (a *App) renderLegendContent() string {
+	modalBg := styles.Current().Background
+	contentWidth := 60
+
+	lineBg := lipgloss.NewStyle().
+		Background(modalBg).
+		Width(contentWidth)
+
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(i18n.T("Status icons"))
+	emptyLine := lineBg.Render("")
+
+	var lines []string
+	lines = append(lines, titleStyled, emptyLine)
+
+	row := func(icon, text string) {
+		desc := a.styles.Muted.Background(modalBg).Render(" " + text)
+		lines = append(lines, lineBg.Render("  "+icon+desc))
+	}
+	for _, state := range slices.Concat(a.skills.StatePrecedence(), []string{panels.StateAvailable}) {
+		row(a.skills.StatusIcon(state), i18n.T(panels.StateDescriptions[state]))
+	}
+	row(panels.DefaultSkillsPanelStyles().Marked.String(), i18n.T("marked for a batch action"))
+
+	lines = append(lines, emptyLine)
+	note := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(i18n.T("When several apply, the first listed is shown."))
+	lines = append(lines, note, emptyLine)
+	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(i18n.T("enter/esc: close"))
 	lines = append(lines, helpStyled)
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
@@ -1799,6 +3892,7 @@ func (a *App) initStarterKit() {
 
 	a.starterKitSelection = make([]bool, len(config.StarterKitRepos))
 	a.starterKitCursor = 0
+	a.starterKitFocus = 0
 }
 
 // pruneDeadStarterKitRepos removes starter kit repos from config that have
@@ -1850,26 +3944,46 @@ func (a *App) hasRepo(name, url string) bool {
 }
 
 func (a *App) updateStarterKit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if focus, ok := cycleFocus(msg.String(), a.starterKitFocus, 3); ok {
+		a.starterKitFocus = focus
+		return a, nil
+	}
+
 	switch msg.String() {
 	case "esc", "q":
-		a.cfg.StarterKitDismissed = true
-		a.cfg.Save()
-		a.mode = ModeNormal
-		return a, nil
+		return a.skipStarterKit()
 
 	case "j", "down":
+		a.starterKitFocus = 0
 		if a.starterKitCursor < len(config.StarterKitRepos)-1 {
 			a.starterKitCursor++
 		}
 		return a, nil
 
 	case "k", "up":
+		a.starterKitFocus = 0
 		if a.starterKitCursor > 0 {
 			a.starterKitCursor--
 		}
 		return a, nil
 
+	case "g", "home":
+		a.starterKitFocus = 0
+		a.starterKitCursor = 0
+		return a, nil
+
+	case "G", "end":
+		a.starterKitFocus = 0
+		a.starterKitCursor = max(len(config.StarterKitRepos)-1, 0)
+		return a, nil
+
 	case " ", "x":
+		if a.starterKitFocus == 2 {
+			return a.skipStarterKit()
+		}
+		if a.starterKitFocus == 1 {
+			return a.addSelectedStarterKit()
+		}
 		if a.starterKitCursor < len(config.StarterKitRepos) {
 			repo := config.StarterKitRepos[a.starterKitCursor]
 			if !a.hasRepo(repo.Name, repo.URL) {
@@ -1895,36 +4009,53 @@ func (a *App) updateStarterKit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case "enter":
-		var selected []config.Repo
-		for i, sel := range a.starterKitSelection {
-			if sel {
-				selected = append(selected, config.StarterKitRepos[i])
-			}
+		if a.starterKitFocus == 2 {
+			return a.skipStarterKit()
 		}
+		return a.addSelectedStarterKit()
+	}
 
-		a.cfg.StarterKitDismissed = true
-		a.cfg.Save()
+	return a, nil
+}
 
-		if len(selected) == 0 {
-			a.mode = ModeNormal
-			return a, nil
+// skipStarterKit closes the starter kit for good without adding repos
+func (a *App) skipStarterKit() (tea.Model, tea.Cmd) {
+	a.cfg.StarterKitDismissed = true
+	a.cfg.Save()
+	a.mode = ModeNormal
+	return a, nil
+}
+
+// addSelectedStarterKit inspects the starter kit repos checked in the modal
+// before they are added
+func (a *App) addSelectedStarterKit() (tea.Model, tea.Cmd) {
+	var selected []config.Repo
+	for i, sel := range a.starterKitSelection {
+		if sel {
+			selected = append(selected, config.StarterKitRepos[i])
 		}
+	}
 
-		a.loadingMsg = "Adding repositories..."
-		a.mode = ModeLoading
-		return a, tea.Batch(
-			a.addStarterKitRepos(selected),
-			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
-		)
+	a.cfg.StarterKitDismissed = true
+	a.cfg.Save()
+
+	if len(selected) == 0 {
+		a.mode = ModeNormal
+		return a, nil
 	}
 
-	return a, nil
+	a.loadingMsg = i18n.T("Inspecting repositories...")
+	a.mode = ModeLoading
+	return a, tea.Batch(
+		a.inspectRepos(selected, true),
+		tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+	)
 }
 
 func (a *App) addStarterKitRepos(repos []config.Repo) tea.Cmd {
 	return func() tea.Msg {
 		for _, r := range repos {
-			if err := a.cfg.AddRepo(r.Name, r.URL); err != nil {
+			if err := a.addRepo(r.Name, r.URL); err != nil {
 				return starterKitErrMsg{fmt.Errorf("failed to add %s: %w", r.Name, err)}
 			}
 		}
@@ -1933,16 +4064,16 @@ func (a *App) addStarterKitRepos(repos []config.Repo) tea.Cmd {
 }
 
 func (a *App) renderStarterKitContent() string {
-	modalBg := lipgloss.Color("#1a1a2e")
+	modalBg := styles.Current().Background
 	contentWidth := 60
 
 	lineBg := lipgloss.NewStyle().
 		Background(modalBg).
 		Width(contentWidth)
 
-	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render("Starter Kit Repositories")
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(i18n.T("Starter Kit Repositories"))
 	emptyLine := lineBg.Render("")
-	descStyled := lineBg.Render("Add popular skill repositories to get started.")
+	descStyled := lineBg.Render(i18n.T("Add popular skill repositories to get started."))
 
 	var lines []string
 	lines = append(lines, titleStyled, emptyLine, descStyled, emptyLine)
@@ -1957,6 +4088,9 @@ func (a *App) renderStarterKitContent() string {
 		if alreadyAdded {
 			line = fmt.Sprintf("  [x] %s", repo.Name)
 			suffix = "  added"
+			if sync, ok := a.registry.RepoSync(repo.Name); ok {
+				suffix += ", synced " + registry.FormatAge(sync.FetchedAt, time.Now())
+			}
 		} else {
 			checkbox := " "
 			if a.starterKitSelection[i] {
@@ -1967,17 +4101,12 @@ func (a *App) renderStarterKitContent() string {
 
 		if selected && alreadyAdded {
 			dimCursorStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color("#374151")).
-				Foreground(lipgloss.Color("#6B7280")).
+				Background(styles.Current().Border).
+				Foreground(styles.Current().Muted).
 				Width(contentWidth)
 			lines = append(lines, dimCursorStyle.Render(line+suffix))
 		} else if selected {
-			cursorStyle := lipgloss.NewStyle().
-				Background(lipgloss.Color("#7C3AED")).
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Width(contentWidth).
-				Bold(true)
-			lines = append(lines, cursorStyle.Render(line))
+			lines = append(lines, modalCursorStyle(a.starterKitFocus == 0, contentWidth).Render(line))
 		} else if alreadyAdded {
 			styledLine := a.styles.Muted.Render(line) + a.styles.Success.Render(suffix)
 			lines = append(lines, lineBg.Render(styledLine))
@@ -1986,8 +4115,8 @@ func (a *App) renderStarterKitContent() string {
 		}
 	}
 
-	lines = append(lines, emptyLine)
-	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render("space: toggle  a: toggle all  enter: add  esc: skip")
+	lines = append(lines, emptyLine, lineBg.Render("  "+a.renderButtons(modalBg, a.starterKitFocus-1, i18n.T("Add"), i18n.T("Skip"))), emptyLine)
+	helpStyled := a.styles.Muted.Background(modalBg).Width(contentWidth).Render(i18n.T("space: toggle  a: all  tab: focus  enter: add  esc: skip"))
 	lines = append(lines, helpStyled)
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
@@ -1999,24 +4128,30 @@ func (a *App) renderStatusBar() string {
 	if a.skills != nil && a.skills.IsSearching() {
 		pairs = []string{
 			"enter", "search",
+			"ctrl+f", "search SKILL.md text",
 			"esc", "cancel",
 		}
 	} else if a.mode == ModeConfirm {
 		pairs = []string{
 			"y", "yes",
 			"n", "no",
-			"←/→", "select",
+			"tab/←/→", "select",
 			"enter", "confirm",
 		}
 	} else if a.mode == ModeAddRepo {
+		action := "add"
+		if a.editRepo != "" {
+			action = "save"
+		}
 		pairs = []string{
-			"tab", "next field",
-			"enter", "add",
+			"tab", "next",
+			"enter", action,
 			"esc", "cancel",
 		}
 	} else if a.mode == ModeBackendSetup {
 		pairs = []string{
 			"j/k", "navigate",
+			"tab", "focus",
 			"space", "toggle",
 			"enter", "link",
 			"esc", "skip",
@@ -2024,15 +4159,78 @@ func (a *App) renderStatusBar() string {
 	} else if a.mode == ModeStarterKit {
 		pairs = []string{
 			"j/k", "navigate",
+			"tab", "focus",
 			"space", "toggle",
 			"enter", "add",
 			"esc", "skip",
 		}
-	} else if a.mode == ModeUpdateResult || a.mode == ModeError {
+	} else if a.mode == ModeVersionPicker {
+		pairs = []string{
+			"j/k", "navigate",
+			"enter", "check out",
+			"esc", "cancel",
+		}
+	} else if a.mode == ModeManifest {
+		pairs = []string{
+			"j/k", "navigate",
+			"s", "sort",
+			"u", "unpin",
+			"b", "rollback",
+			"o", "open source",
+			"esc", "close",
+		}
+	} else if a.mode == ModeSettings && a.settingsEditing {
+		pairs = []string{
+			"enter", "save",
+			"esc", "cancel",
+		}
+	} else if a.mode == ModeSettings {
+		pairs = []string{
+			"j/k", "navigate",
+			"enter", "change",
+			"esc", "close",
+		}
+	} else if a.mode == ModeTags {
+		pairs = []string{
+			"j/k", "navigate",
+			"space", "toggle",
+			"c", "clear",
+			"esc", "close",
+		}
+	} else if a.mode == ModeRepos {
+		pairs = []string{
+			"j/k", "navigate",
+			"enter", "show",
+			"esc", "close",
+		}
+	} else if a.mode == ModeQueue && a.queueResults == nil {
+		pairs = []string{
+			"j/k", "navigate",
+			"enter", "run all",
+			"d", "drop",
+			"c", "clear",
+			"esc", "keep browsing",
+		}
+		if len(a.failed) > 0 {
+			pairs = append(pairs[:len(pairs)-2], "r", "retry", "R", "retry all failed", "esc", "keep browsing")
+		}
+	} else if a.mode == ModeUpdateResult && a.updateResult != nil && a.updateResult.running {
+		pairs = []string{
+			"c", "cancel",
+		}
+	} else if a.mode == ModeUpdateResult || a.mode == ModeError || a.mode == ModeQueue || a.mode == ModeLegend {
 		pairs = []string{
 			"enter", "close",
 			"esc", "close",
 		}
+	} else if a.showEmptyState() {
+		pairs = []string{
+			"A", "add repo",
+			"K", "starter kit",
+			"b", "backends",
+			"o", "quick start",
+			"q", "quit",
+		}
 	} else {
 		if a.skills != nil && a.skills.GetQuery() != "" {
 			pairs = []string{
@@ -2050,42 +4248,84 @@ func (a *App) renderStatusBar() string {
 				"z", "fold",
 				"i", "install",
 				"r", "remove",
+				"+/-", "queue",
+				"space", "mark",
 				"V", "view SKILL.md",
+				"y", "copy install",
+				"v", "versions",
+				"m", "merge",
+				"M", "manifest",
 				"U", "update",
+				"H", "last update",
 				"A", "add repo",
 				"S", "sync",
 				"b", "backends",
 				"K", "starter kit",
 				"/", "search",
+				"u", "undo",
+				"t", "tags",
+				"P", "repo",
+				"?", "legend",
+				",", "settings",
 				"q", "quit",
 			}
+			if len(a.failed) > 0 {
+				pairs = append([]string{"Q", i18n.Tf("review queue (%d, %d failed)", len(a.queue), len(a.failed))}, pairs...)
+			} else if len(a.queue) > 0 {
+				pairs = append([]string{"Q", i18n.Tf("review queue (%d)", len(a.queue))}, pairs...)
+			}
+			if marked := a.markedCount(); marked > 0 {
+				pairs = append([]string{"i/u/r", i18n.Tf("batch (%d marked)", marked), "esc", "clear marks"}, pairs...)
+			}
+			if a.pendingRefresh != nil {
+				pairs = append([]string{"R", "apply refresh"}, pairs...)
+			}
+			if a.layout.Focus() == layout.PanelRight && a.selectedModified() != "" {
+				pairs = append([]string{"x", "discard changes", "p", "keep as patch"}, pairs...)
+			}
+			if a.cfg.VerifySignatures {
+				pairs = append(pairs[:len(pairs)-2], "f", "verified only", "q", "quit")
+			}
 		}
 	}
 
 	var items []string
 	for i := 0; i < len(pairs); i += 2 {
 		items = append(items,
-			a.styles.HelpKey.Render(pairs[i])+" "+a.styles.HelpText.Render(pairs[i+1]))
+			a.styles.HelpKey.Render(pairs[i])+" "+a.styles.HelpText.Render(i18n.T(pairs[i+1])))
 	}
 
 	return a.styles.StatusBar.Render(strings.Join(items, "  "))
 }
 
-// Run starts the TUI application
-func Run(cfg *config.Config) error {
+// Run starts the TUI application. A repo names the configured repository
+// the skills list starts scoped to; "" lists every repository.
+func Run(cfg *config.Config, repo string) error {
 	if err := cfg.EnsureDirs(); err != nil {
 		return fmt.Errorf("failed to create directories: %w", err)
 	}
 
 	app := NewApp(cfg)
-	p := tea.NewProgram(app, tea.WithAltScreen())
-	model, err := p.Run()
-	if err != nil {
+	app.repoScope = repo
+	if w, err := watch.New(cfg.SkillsDir, watch.DefaultDebounce); err == nil {
+		app.watcher = w
+		defer w.Close()
+	}
+	guard := &crashGuard{app: app}
+	p := tea.NewProgram(guard, tea.WithAltScreen())
+	guard.quit = func() { go p.Quit() }
+	if _, err := p.Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramPanic) {
+			fmt.Fprintln(os.Stderr, i18n.T("If the terminal still looks wrong, run 'reset'."))
+		}
 		return fmt.Errorf("TUI error: %w", err)
 	}
-	// Check if the app stored an error (e.g., index fetch failure)
-	if finalApp, ok := model.(*App); ok && finalApp.err != nil {
-		return finalApp.err
+	if guard.crash != nil {
+		return reportCrash(cfg, guard.crash)
 	}
-	return nil
+
+	app.usage.Add(usage.TUISessions, 1)
+	usage.New(cfg.UsagePath, !cfg.DisableUsageStats).Flush(&app.usage)
+	// Check if the app stored an error (e.g., index fetch failure)
+	return app.err
 }
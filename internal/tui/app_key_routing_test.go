@@ -2,6 +2,7 @@ package tui
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -21,7 +22,9 @@ func newAppForPageKeyRoutingTest(t *testing.T) *App {
 		ConfigPath:   filepath.Join(tmpDir, ".lazyas", "config.toml"),
 		ManifestPath: filepath.Join(tmpDir, ".lazyas", "manifest.yaml"),
 		CachePath:    filepath.Join(tmpDir, ".lazyas", "cache.yaml"),
+		HistoryPath:  filepath.Join(tmpDir, ".lazyas", "update-history.yaml"),
 		ReposDir:     filepath.Join(tmpDir, "repos"),
+		TrashDir:     filepath.Join(tmpDir, ".lazyas", "trash"),
 		CacheTTL:     24,
 	}
 
@@ -67,3 +70,20 @@ func TestApp_PageKeys_RoutedToSkillsPanel(t *testing.T) {
 		t.Fatalf("expected Home routed through App.Update to return to top header, got %q", got.Name)
 	}
 }
+
+func TestApp_LegendToggle(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if app.mode != ModeLegend {
+		t.Fatalf("expected ? to open the legend, got mode %d", app.mode)
+	}
+	if legend := app.renderLegendContent(); !strings.Contains(legend, "a newer version is available") {
+		t.Errorf("expected the legend to explain the outdated icon, got:\n%s", legend)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if app.mode != ModeNormal {
+		t.Fatalf("expected ? to close the legend, got mode %d", app.mode)
+	}
+}
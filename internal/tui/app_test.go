@@ -1,16 +1,32 @@
 package tui
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/history"
 	"lazyas/internal/manifest"
+	"lazyas/internal/progress"
 	"lazyas/internal/registry"
+	"lazyas/internal/skillmd"
+	"lazyas/internal/symlink"
 	"lazyas/internal/tui/panels"
+	"lazyas/internal/tui/styles"
 	ttesting "lazyas/internal/tui/testing"
+	"lazyas/internal/usage"
 )
 
 func TestApp_CtrlC_Quits(t *testing.T) {
@@ -224,3 +240,1404 @@ func TestApp_InitPanels_PreservesCollapseAcrossRebuild(t *testing.T) {
 		t.Errorf("Expected exactly [my-group] collapsed, got %v", collapsed)
 	}
 }
+
+func TestApp_BackgroundRefresh_WaitsForApplyKey(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.scheduleRefresh()
+
+	fresh := registry.NewRegistry(app.cfg)
+	app.Update(refreshReadyMsg{seq: app.refreshSeq, registry: fresh, newSkills: 3, updates: 2})
+	if app.pendingRefresh == nil {
+		t.Fatal("Expected refresh to be held until applied")
+	}
+	if app.registry == fresh {
+		t.Fatal("Expected panels to keep the old registry before applying")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if app.pendingRefresh != nil {
+		t.Error("Expected pending refresh to be cleared after applying")
+	}
+	if app.registry != fresh {
+		t.Error("Expected R to swap in the refreshed registry")
+	}
+}
+
+func TestApp_PreviewCache_SkipsErrorsAndClearsOnRefresh(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	skill := registry.SkillEntry{Name: "pdf"}
+	skill.Source.Repo, skill.Source.Tag, skill.Source.Path = "https://github.com/a/skills", "v1", "pdf"
+	key := previewKey(skill)
+
+	app.Update(previewLoadedMsg{key: key, err: errors.New("offline")})
+	if _, ok := app.previewed[key]; ok {
+		t.Fatal("Expected a failed preview not to be cached")
+	}
+	app.Update(previewLoadedMsg{key: key, content: "# PDF"})
+	if _, ok := app.previewed[key]; !ok {
+		t.Fatal("Expected the preview to be cached")
+	}
+	skill.Source.Tag = "v2"
+	if _, ok := app.previewed[previewKey(skill)]; ok {
+		t.Error("Expected another tag of the skill not to hit the cache")
+	}
+
+	app.scheduleRefresh()
+	app.Update(refreshReadyMsg{seq: app.refreshSeq, registry: registry.NewRegistry(app.cfg), newSkills: 1})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if len(app.previewed) != 0 {
+		t.Error("Expected applying a refresh to clear the preview cache")
+	}
+}
+
+func TestApp_BackgroundRefresh_NotifiesOnlyWhenEnabled(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	if cmd := app.notifyUpdates([]string{"alpha"}); cmd != nil {
+		t.Error("Expected no notification with notifications off")
+	}
+
+	app.cfg.Notifications = true
+	if cmd := app.notifyUpdates(nil); cmd != nil {
+		t.Error("Expected no notification without updates")
+	}
+	if cmd := app.notifyUpdates([]string{"alpha"}); cmd == nil {
+		t.Error("Expected a notification for new updates")
+	}
+}
+
+func TestApp_BackgroundRefresh_IgnoresStaleResults(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.scheduleRefresh()
+	stale := app.refreshSeq
+	app.scheduleRefresh()
+
+	app.Update(refreshReadyMsg{seq: stale, registry: registry.NewRegistry(app.cfg), newSkills: 1})
+	if app.pendingRefresh != nil {
+		t.Error("Expected superseded refresh to be dropped")
+	}
+}
+
+func TestApp_ManifestBrowser_SortsAndKeepsSelection(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.manifest.AddSkill("beta", "v1.0.0", "b1", "https://github.com/a/skills", "beta")
+	app.manifest.AddSkill("alpha", "v2.0.0", "a1", "https://github.com/z/skills", "alpha")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if app.mode != ModeManifest {
+		t.Fatalf("Expected M to open the manifest browser, got mode %v", app.mode)
+	}
+	if len(app.manifestRows) != 2 || app.manifestRows[0].name != "alpha" {
+		t.Fatalf("Expected rows sorted by name, got %+v", app.manifestRows)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}) // installed
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}) // source
+	if app.manifestSort != manifestSortSource || app.manifestRows[0].name != "beta" {
+		t.Errorf("Expected rows sorted by source, got %v %+v", app.manifestSort, app.manifestRows)
+	}
+	if app.manifestRows[app.manifestCursor].name != "alpha" {
+		t.Error("Expected the cursor to stay on the selected skill after sorting")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.mode != ModeNormal {
+		t.Error("Expected esc to close the manifest browser")
+	}
+}
+
+func TestApp_Queue_ToggleReviewAndDrop(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	skill := app.skills.Selected()
+	if skill == nil {
+		t.Fatal("expected a selected skill")
+	}
+
+	plus := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")}
+	app.Update(plus)
+	if len(app.queue) != 1 || app.queue[0].op != queueInstall || app.queue[0].skill.Name != skill.Name {
+		t.Fatalf("Expected + to queue an install, got %+v", app.queue)
+	}
+	app.Update(plus)
+	if len(app.queue) != 0 {
+		t.Fatalf("Expected a second + to drop the install, got %+v", app.queue)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	if len(app.queue) != 0 {
+		t.Error("Expected - on a skill that is not installed to queue nothing")
+	}
+
+	app.Update(plus)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Q")})
+	if app.mode != ModeQueue {
+		t.Fatalf("Expected Q to open the queue, got mode %v", app.mode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if len(app.queue) != 0 || app.mode != ModeNormal {
+		t.Errorf("Expected d to drop the last item and close the queue, got %+v mode %v", app.queue, app.mode)
+	}
+}
+
+func TestApp_MarkedSkills_QueuedAsOneBatch(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}
+
+	app.Update(down)
+	first := app.skills.Selected().Name
+	app.Update(space)
+	app.Update(down)
+	app.Update(space)
+	if app.markedCount() != 2 {
+		t.Fatalf("Expected space to mark two skills, got %d", app.markedCount())
+	}
+
+	// Nothing marked is installed, so there is nothing to remove
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if app.mode != ModeNormal || len(app.queue) != 0 || app.markedCount() != 2 {
+		t.Fatalf("Expected r to leave the marks alone, got mode %v queue %+v", app.mode, app.queue)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if app.mode != ModeQueue {
+		t.Fatalf("Expected i to open the queue for review, got mode %v", app.mode)
+	}
+	if len(app.queue) != 2 || app.queue[0].op != queueInstall || app.queue[0].skill.Name != first {
+		t.Fatalf("Expected both marked skills queued for install, got %+v", app.queue)
+	}
+	if app.markedCount() != 0 {
+		t.Errorf("Expected queued skills to be unmarked, %d still marked", app.markedCount())
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(space)
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.markedCount() != 0 {
+		t.Error("Expected esc to clear the marks")
+	}
+}
+
+func TestApp_Usage_CountsActionsAndResults(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if app.usage.Actions["tui queue"] != 2 || app.usage.Actions["tui navigate"] != 0 {
+		t.Errorf("actions = %v, want two queue actions and no navigation", app.usage.Actions)
+	}
+
+	skill := registry.SkillEntry{Name: "pdf"}
+	app.Update(queueDoneMsg{results: []queueResult{
+		{item: queueItem{op: queueInstall, skill: skill}, ok: true},
+		{item: queueItem{op: queueRemove, skill: skill}, ok: true},
+		{item: queueItem{op: queueUpdate, skill: skill}, ok: false},
+	}})
+	if app.usage.Events[usage.Installs] != 1 || app.usage.Events[usage.Removals] != 1 || app.usage.Events[usage.Updates] != 0 {
+		t.Errorf("events = %v, want one install and one removal", app.usage.Events)
+	}
+}
+
+func TestApp_LastUpdate_ReplaysRecordedRun(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")}
+
+	app.Update(key)
+	if app.mode != ModeNormal || app.updateResult != nil {
+		t.Fatalf("Expected no results without history, got mode %v", app.mode)
+	}
+
+	run := history.Run{At: time.Now(), Source: "cli", Results: []history.Result{
+		{Name: "pdf", Status: history.StatusUpdated, Changes: []string{"abc1234 Fix forms"}},
+		{Name: "docx", Status: history.StatusFailed, Error: "network down"},
+	}}
+	if err := history.Append(app.cfg.HistoryPath, run); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Update(key)
+	if app.mode != ModeUpdateResult || app.updateResult == nil {
+		t.Fatalf("Expected H to open the last update, got mode %v", app.mode)
+	}
+	r := app.updateResult
+	if r.updated != 1 || r.failed != 1 || len(r.results) != 2 || r.results[1].problem != "network down" {
+		t.Errorf("Unexpected replayed results: %+v", r)
+	}
+	if !strings.Contains(app.renderUpdateResultContent(), "Last Update") {
+		t.Error("Expected the modal to be titled Last Update")
+	}
+}
+
+func TestApp_EmptyState_ReplacesSkillList(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.width, app.height = 100, 30
+	app.layout.SetSize(100, 30)
+	app.skills = panels.NewSkillsPanel(nil, map[string]string{}, map[string]bool{})
+
+	if app.showEmptyState() {
+		t.Fatal("Expected the starter kit, not the empty state, before it is dismissed")
+	}
+
+	app.cfg.StarterKitDismissed = true
+	if !app.showEmptyState() {
+		t.Fatal("Expected the empty state with no repos and no skills")
+	}
+	view := app.renderPanels()
+	if strings.Contains(view, "No skills found") || !strings.Contains(view, "No skill repositories yet") {
+		t.Errorf("Expected the onboarding panel, got:\n%s", view)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if app.mode != ModeAddRepo {
+		t.Errorf("Expected A to open add repo from the empty state, got mode %v", app.mode)
+	}
+
+	app.cfg.Repos = []config.Repo{{Name: "a", URL: "file:///tmp/a"}}
+	if app.showEmptyState() {
+		t.Error("Expected no empty state once a repo is configured")
+	}
+}
+
+func TestApp_NoGit_DisablesGitActions(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.gitErr = git.ErrNotInstalled
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if app.mode != ModeNormal {
+		t.Fatalf("Expected install to be unavailable without git, got mode %v", app.mode)
+	}
+	if !strings.Contains(app.message, "git is not installed") {
+		t.Errorf("Expected an explanation in the status line, got %q", app.message)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	if app.mode != ModeNormal {
+		t.Errorf("Expected update to be unavailable without git, got mode %v", app.mode)
+	}
+}
+
+func TestApp_Provenance_MarksUnreachableCommits(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	if err := app.manifest.AddSkill("kept", "", "aaa111", "https://example.com/a", "kept"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.manifest.AddSkill("gone", "", "bbb222", "https://example.com/a", "gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Update(provenanceCheckedMsg{map[string]git.Provenance{
+		"aaa111": {Ref: "main", Reachable: true},
+		"bbb222": {},
+	}})
+
+	unreachable := app.unreachableSkills()
+	if len(unreachable) != 1 || !unreachable["gone"] {
+		t.Errorf("unreachableSkills() = %v, want only gone", unreachable)
+	}
+	if p := app.skillProvenance("kept"); p == nil || p.Ref != "main" {
+		t.Errorf("skillProvenance(kept) = %+v", p)
+	}
+	if app.skillProvenance("unknown") != nil {
+		t.Error("expected no provenance for a skill that is not tracked")
+	}
+}
+
+func TestApp_RemoveRepo_RemovesOrOrphansItsSkills(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	const url = "https://example.com/a"
+	app.cfg.Repos = []config.Repo{{Name: "a", URL: url}, {Name: "b", URL: "https://example.com/b"}}
+	for _, name := range []string{"kept", "other"} {
+		if err := app.manifest.AddSkill(name, "", "aaa111", url, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := app.manifest.AddSkill("elsewhere", "", "bbb222", "https://example.com/b", "elsewhere"); err != nil {
+		t.Fatal(err)
+	}
+
+	app.confirmAction = ConfirmRemoveRepo
+	app.confirmRepo = "a"
+	app.repoSkills = app.manifest.FromRepo(url)
+	app.mode = ModeConfirm
+	if !strings.Contains(strings.Join(app.confirmDetails(), "\n"), "other") {
+		t.Error("Expected the confirmation to list the repo's skills")
+	}
+
+	msg, ok := app.removeRepo("a", app.repoSkills, false)().(repoRemovedMsg)
+	if !ok || len(msg.orphaned) != 2 || len(msg.removed) != 0 {
+		t.Fatalf("Expected both skills kept as orphaned, got %+v", msg)
+	}
+	if info, _ := app.manifest.GetInstalled("kept"); !info.Orphaned {
+		t.Error("Expected kept to be marked orphaned")
+	}
+	if info, _ := app.manifest.GetInstalled("elsewhere"); info.Orphaned {
+		t.Error("Expected a skill from another repo to be left alone")
+	}
+
+	if err := app.addRepo("a", url); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := app.manifest.GetInstalled("kept"); info.Orphaned {
+		t.Error("Expected adding the repo back to clear the orphaned mark")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if !app.repoSkillsPurge {
+		t.Fatal("Expected s to toggle removing the repo's skills")
+	}
+	msg, _ = app.removeRepo("a", app.repoSkills, app.repoSkillsPurge)().(repoRemovedMsg)
+	if _, tracked := app.manifest.GetInstalled("kept"); len(msg.removed) != 2 || tracked {
+		t.Errorf("Expected the repo's skills removed, got %+v", msg)
+	}
+	if _, tracked := app.manifest.GetInstalled("elsewhere"); !tracked {
+		t.Error("Expected a skill from another repo to stay installed")
+	}
+}
+
+func TestApp_Orphans_GroupedWithActions(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.cfg.Repos = []config.Repo{{Name: "b", URL: "https://example.com/b"}}
+
+	clone := filepath.Join(app.cfg.ReposDir, "a", "gone")
+	if err := os.MkdirAll(clone, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(clone, "SKILL.md"), []byte("---\nname: gone\ndescription: Gone\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(app.cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(clone, app.manifest.GetSkillPath("gone")); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.manifest.AddSkill("gone", "", "aaa111", "https://example.com/a", "gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	app.width, app.height = 100, 30
+	app.layout.SetSize(100, 30)
+	app.initPanels()
+	if !app.skills.IsOrphaned("gone") {
+		t.Fatal("Expected gone to be detected as orphaned")
+	}
+	for i := 0; i < 5 && (app.skills.Selected() == nil || app.skills.Selected().Name != "gone"); i++ {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	if !strings.Contains(app.skills.View(), "Orphaned") {
+		t.Error("Expected an Orphaned group in the skill list")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if app.mode != ModeAddRepo || app.addRepoURL.Value() != "https://example.com/a" {
+		t.Fatalf("Expected A to prefill the orphan's repo, got mode %v url %q", app.mode, app.addRepoURL.Value())
+	}
+	app.mode = ModeNormal
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if app.mode != ModeConfirm || app.confirmAction != ConfirmKeepLocal {
+		t.Fatalf("Expected L to confirm keeping the skill local, got mode %v", app.mode)
+	}
+	if msg := app.keepLocal("gone")().(keptLocalMsg); msg.err != nil {
+		t.Fatal(msg.err)
+	}
+	if fi, err := os.Lstat(app.manifest.GetSkillPath("gone")); err != nil || fi.Mode()&os.ModeSymlink != 0 {
+		t.Errorf("Expected the link to be replaced by a copy, got %v %v", fi, err)
+	}
+	if _, tracked := app.manifest.GetInstalled("gone"); tracked {
+		t.Error("Expected the skill to no longer be tracked")
+	}
+}
+
+func TestApp_PinnedSkill_HeldByUpdates(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	if err := app.manifest.AddSkill("pdf", "v1.0.0", "aaa111", "https://example.com/a", "pdf"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.manifest.SetPin("pdf", "v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	info, _ := app.manifest.GetInstalled("pdf")
+
+	res, ok := app.updateSkill("pdf", info, nil, nil)
+	if !ok || res.status != "held" || !strings.Contains(res.problem, "pinned to v1.0.0") {
+		t.Errorf("Expected the pinned skill to be held, got %+v", res)
+	}
+	if msg, isErr := app.switchVersion("pdf", "v2.0.0")().(versionSwitchErrMsg); !isErr || !strings.Contains(msg.err.Error(), "pinned") {
+		t.Errorf("Expected switching outside the pin to fail, got %#v", msg)
+	}
+	if got := app.pinStatus("pdf", info); got != "pinned v1.0.0" {
+		t.Errorf("pinStatus = %q, want %q", got, "pinned v1.0.0")
+	}
+
+	if err := app.manifest.SetBranch("pdf", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := app.manifest.GetInstalled("pdf"); info.Pin != "" {
+		t.Error("Expected tracking a branch to drop the pin")
+	}
+}
+
+func TestApp_VerifiedFilter_ShowsSigner(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.detail.SetSize(80, 30)
+	if err := app.manifest.AddSkill("skill-001", "", "aaa111", "https://github.com/repo-a/skills", "skill-001"); err != nil {
+		t.Fatal(err)
+	}
+	app.skills.SetInstalled(map[string]string{
+		"skill-001": "https://github.com/repo-a/skills",
+		"skill-002": "https://github.com/repo-b/skills",
+	})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if app.skills.VerifiedOnly() {
+		t.Fatal("Expected the filter to need verify_signatures")
+	}
+
+	app.cfg.VerifySignatures = true
+	app.Update(signaturesCheckedMsg{map[string]git.Signature{
+		"skill-001": {Status: "G", Signer: "dev@example.com", Issuer: "https://github.com/login/oauth"},
+		"skill-002": {Status: "N"},
+	}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	view := app.skills.View()
+	if !app.skills.VerifiedOnly() || !strings.Contains(view, "skill-001") || strings.Contains(view, "skill-002") || strings.Contains(view, "skill-003") {
+		t.Fatalf("Expected only the verified skill to be listed, got:\n%s", view)
+	}
+
+	for i := 0; i < 3 && app.skills.Selected() == nil; i++ {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	if !strings.Contains(app.detail.View(), "dev@example.com via https://github.com/login/oauth") {
+		t.Errorf("Expected the detail panel to show the signer, got:\n%s", app.detail.View())
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if view := app.skills.View(); !strings.Contains(view, "skill-002") && !strings.Contains(view, "repo-b") {
+		t.Errorf("Expected all skills back after toggling the filter off, got:\n%s", view)
+	}
+}
+
+// stubClipboard makes the clipboard hold text for the rest of the test
+func stubClipboard(t *testing.T, text string) {
+	t.Helper()
+	orig := readClipboard
+	readClipboard = func() (string, error) { return text, nil }
+	t.Cleanup(func() { readClipboard = orig })
+}
+
+func TestApp_AddRepo_ValidatesURLAndDerivesName(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	stubClipboard(t, "")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if app.mode != ModeAddRepo {
+		t.Fatalf("Expected A to open Add Repository, got mode %v", app.mode)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	typeText := func(s string) {
+		for _, r := range s {
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	typeText("github.com/acme/skills")
+	if !strings.Contains(app.addRepoErr, "missing scheme") {
+		t.Errorf("Expected an inline scheme error, got %q", app.addRepoErr)
+	}
+	if app.addRepoName.Value() != "" {
+		t.Errorf("Expected no name for an invalid URL, got %q", app.addRepoName.Value())
+	}
+
+	app.addRepoURL.SetValue("")
+	typeText("file:///nonexistent/acme/tools.git")
+	if app.addRepoErr != "" {
+		t.Errorf("Expected a valid URL, got error %q", app.addRepoErr)
+	}
+	if app.addRepoName.Value() != "tools" {
+		t.Errorf("Expected the name derived from the URL, got %q", app.addRepoName.Value())
+	}
+
+	app.gitErr = nil
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.addRepoChecking || cmd == nil {
+		t.Fatal("Expected enter to check the URL with ls-remote")
+	}
+	app.Update(repoURLCheckedMsg{name: "tools", url: "file:///nonexistent/acme/tools.git", err: fmt.Errorf("not a reachable git repository")})
+	if app.mode != ModeAddRepo || !strings.Contains(app.addRepoErr, "not a reachable") {
+		t.Errorf("Expected the modal to stay open with the error, got mode %v err %q", app.mode, app.addRepoErr)
+	}
+	if !strings.Contains(app.renderAddRepoContent(), "not a reachable") {
+		t.Error("Expected the error to be shown inline in the modal")
+	}
+}
+
+func TestApp_AddRepo_ClipboardAndPaste(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	stubClipboard(t, "https://github.com/acme/skills/tree/main/pdf\n")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if got := app.addRepoURL.Value(); got != "https://github.com/acme/skills" {
+		t.Errorf("Expected the clipboard URL to be prefilled, got %q", got)
+	}
+	if app.addRepoName.Value() != "skills" || !strings.Contains(app.renderAddRepoContent(), "clipboard") {
+		t.Errorf("Expected a derived name and a clipboard note, got name %q", app.addRepoName.Value())
+	}
+
+	stubClipboard(t, "not a url")
+	app.mode = ModeNormal
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if app.addRepoURL.Value() != "" {
+		t.Errorf("Expected other clipboard text to be ignored, got %q", app.addRepoURL.Value())
+	}
+
+	// Pasting a browser address into the focused name field fills the URL
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("https://github.com/acme/tools/blob/main/README.md"), Paste: true})
+	if app.addRepoFocus != 1 || app.addRepoURL.Value() != "https://github.com/acme/tools" {
+		t.Errorf("Expected the paste to land in the URL field as a clone URL, got focus %d url %q", app.addRepoFocus, app.addRepoURL.Value())
+	}
+	if app.addRepoName.Value() != "tools" {
+		t.Errorf("Expected the name derived from the pasted URL, got %q", app.addRepoName.Value())
+	}
+}
+
+func TestApp_ModalFocus_TabReachesEveryControl(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	stubClipboard(t, "")
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	shiftTab := tea.KeyMsg{Type: tea.KeyShiftTab}
+
+	// Add repository: name, URL, Add, Cancel, wrapping around
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	for _, want := range []int{addRepoFieldURL, addRepoSubmit, addRepoCancel, addRepoFieldName} {
+		app.Update(tab)
+		if app.addRepoFocus != want {
+			t.Fatalf("Expected tab to focus control %d, got %d", want, app.addRepoFocus)
+		}
+	}
+	app.Update(shiftTab)
+	if app.addRepoFocus != addRepoCancel || app.addRepoName.Focused() || app.addRepoURL.Focused() {
+		t.Fatalf("Expected shift+tab to wrap to Cancel with no field focused, got %d", app.addRepoFocus)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if app.addRepoName.Value() != "" || app.addRepoURL.Value() != "" {
+		t.Errorf("Expected typing on a button to leave the fields alone")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.mode != ModeNormal {
+		t.Errorf("Expected enter on Cancel to close the form, got mode %d", app.mode)
+	}
+
+	// Backend setup: list with home/end, then Link and Skip
+	app.backendStatuses = []symlink.LinkStatus{
+		{Backend: config.Backend{Name: "one"}, Available: true},
+		{Backend: config.Backend{Name: "two"}, Available: true},
+		{Backend: config.Backend{Name: "three"}, Available: true},
+	}
+	app.cfg.StarterKitDismissed = true
+	app.initBackendSetup()
+	app.mode = ModeBackendSetup
+	app.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if app.backendCursor != 2 {
+		t.Errorf("Expected end to move to the last backend, got %d", app.backendCursor)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if app.backendCursor != 0 {
+		t.Errorf("Expected home to move to the first backend, got %d", app.backendCursor)
+	}
+	app.Update(tab)
+	app.Update(tab)
+	if app.backendFocus != 2 || !strings.Contains(app.renderBackendSetupContent(), "Skip") {
+		t.Fatalf("Expected the Skip button to be focused, got %d", app.backendFocus)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.mode != ModeNormal || len(app.cfg.DismissedBackends) != 3 {
+		t.Errorf("Expected Skip to dismiss the backends, got mode %d dismissed %v", app.mode, app.cfg.DismissedBackends)
+	}
+}
+
+func TestApp_BackendSetup_RepairsBrokenLink(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	if err := os.MkdirAll(app.cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	backendPath := filepath.Join(t.TempDir(), "agent", "skills")
+	if err := os.MkdirAll(filepath.Dir(backendPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(t.TempDir(), "moved"), backendPath); err != nil {
+		t.Fatal(err)
+	}
+	app.cfg.Backends = []config.Backend{{Name: "agent", Path: backendPath}}
+	app.cfg.StarterKitDismissed = true
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if app.mode != ModeBackendSetup || len(app.problemsOf("agent")) != 1 {
+		t.Fatalf("Expected the broken link found, got mode %v problems %v", app.mode, app.backendProblems)
+	}
+	if app.backendSelection[0] {
+		t.Error("Expected a backend needing repair not to be selected for linking")
+	}
+	if content := app.renderBackendSetupContent(); !strings.Contains(content, "needs repair") || !strings.Contains(content, "r: repair") {
+		t.Errorf("Expected the problem and the repair key shown, got:\n%s", content)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if app.mode != ModeLoading {
+		t.Fatalf("Expected the repair to run, got mode %v", app.mode)
+	}
+	app.Update(app.repairBackends(app.problemsOf("agent"))())
+	if app.mode != ModeBackendSetup || len(app.backendProblems) != 0 || !app.backendStatuses[0].Linked {
+		t.Errorf("Expected the backend linked again, got mode %v problems %v", app.mode, app.backendProblems)
+	}
+}
+
+func TestApp_RepoScope_HeaderAndPicker(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.cfg.Repos = []config.Repo{
+		{Name: "alpha", URL: "https://github.com/acme/alpha"},
+		{Name: "beta", URL: "https://github.com/acme/beta"},
+	}
+	var skills []registry.SkillEntry
+	for i, name := range []string{"a1", "a2", "b1"} {
+		repo := app.cfg.Repos[min(i/2, 1)]
+		skills = append(skills, registry.SkillEntry{Name: name, Source: registry.SkillSource{Repo: repo.URL, RepoName: repo.Name}})
+	}
+	app.skills = panels.NewSkillsPanel(skills, map[string]string{}, map[string]bool{})
+	app.skills.SetSize(60, 20)
+	shown := func() []string {
+		var names []string
+		for _, g := range app.skills.Groups() {
+			for _, skill := range g.Skills {
+				names = append(names, skill.Name)
+			}
+		}
+		return names
+	}
+
+	// Enter on the first repository header scopes to it, and again clears it
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := shown(); app.repoScope != "alpha" || !slices.Equal(got, []string{"a1", "a2"}) {
+		t.Fatalf("Expected the list scoped to alpha, got scope %q skills %v", app.repoScope, got)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := shown(); app.repoScope != "" || len(got) != 3 {
+		t.Fatalf("Expected enter to clear the scope, got scope %q skills %v", app.repoScope, got)
+	}
+
+	// The picker lists every repository after "All repositories"
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if app.mode != ModeRepos || len(app.repos) != 3 {
+		t.Fatalf("Expected the repository picker with 3 rows, got mode %d rows %v", app.mode, app.repos)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := shown(); app.mode != ModeNormal || !slices.Equal(got, []string{"b1"}) {
+		t.Errorf("Expected the list scoped to beta, got mode %d skills %v", app.mode, got)
+	}
+	if !strings.Contains(app.skills.View(), "Repo: beta") {
+		t.Error("Expected the scope shown above the list")
+	}
+}
+
+func TestApp_Undo_RestoresScopeAndQueue(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.cfg.Repos = []config.Repo{
+		{Name: "alpha", URL: "https://github.com/acme/alpha"},
+		{Name: "beta", URL: "https://github.com/acme/beta"},
+	}
+	var skills []registry.SkillEntry
+	for i, name := range []string{"a1", "a2", "b1"} {
+		repo := app.cfg.Repos[min(i/2, 1)]
+		skills = append(skills, registry.SkillEntry{Name: name, Source: registry.SkillSource{Repo: repo.URL, RepoName: repo.Name}})
+	}
+	app.skills = panels.NewSkillsPanel(skills, map[string]string{}, map[string]bool{})
+	app.skills.SetSize(60, 20)
+	key := func(k string) {
+		if k == "ctrl+r" {
+			app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+		} else {
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+
+	// Scope to alpha, then queue its first skill
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	key("j")
+	key("+")
+	if app.repoScope != "alpha" || len(app.queue) != 1 {
+		t.Fatalf("Expected scope alpha and one queued action, got %q and %d", app.repoScope, len(app.queue))
+	}
+
+	key("u")
+	if len(app.queue) != 0 || app.repoScope != "alpha" {
+		t.Fatalf("Expected the first undo to drop the queued action only, got %q and %d", app.repoScope, len(app.queue))
+	}
+	if !strings.Contains(app.message, "Undid queue") {
+		t.Errorf("Expected the status line to name the undone change, got %q", app.message)
+	}
+	key("u")
+	if app.repoScope != "" || app.skills.RepoFilter() != "" {
+		t.Fatalf("Expected the second undo to clear the scope, got %q", app.repoScope)
+	}
+	key("u")
+	if !strings.Contains(app.message, "Nothing to undo") {
+		t.Errorf("Expected an empty history, got %q", app.message)
+	}
+
+	key("ctrl+r")
+	key("ctrl+r")
+	if app.repoScope != "alpha" || len(app.queue) != 1 {
+		t.Fatalf("Expected redo to restore scope and queue, got %q and %d", app.repoScope, len(app.queue))
+	}
+
+	// A new change after an undo drops what was left to redo
+	key("u")
+	key("+")
+	key("ctrl+r")
+	if !strings.Contains(app.message, "Nothing to redo") {
+		t.Errorf("Expected a new change to clear the redo history, got %q", app.message)
+	}
+
+	// With skills marked, u undoes the mark rather than updating them
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if app.markedCount() != 1 {
+		t.Fatalf("Expected one marked skill, got %d", app.markedCount())
+	}
+	key("u")
+	if app.markedCount() != 0 || app.mode != ModeNormal {
+		t.Errorf("Expected u to undo the mark, got %d marked in mode %v", app.markedCount(), app.mode)
+	}
+}
+
+func TestApp_RemoveSkill_MovesToTrash(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	skillDir := filepath.Join(app.cfg.SkillsDir, "alpha")
+	os.MkdirAll(skillDir, 0755)
+	os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: alpha\n---\n"), 0644)
+	app.manifest.AddSkill("alpha", "v1.0.0", "a1", "https://github.com/a/skills", "alpha")
+	backend := config.Backend{Name: "per", Path: t.TempDir(), Mode: config.BackendPerSkill}
+	app.cfg.Backends = []config.Backend{backend}
+	if err := os.Symlink(skillDir, filepath.Join(backend.Path, "alpha")); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := app.removeSkill(&registry.SkillEntry{Name: "alpha"})()
+	if _, ok := msg.(removeDoneMsg); !ok {
+		t.Fatalf("Expected removeDoneMsg, got %#v", msg)
+	}
+	if _, err := os.Stat(skillDir); !os.IsNotExist(err) {
+		t.Error("Expected the skill directory to be gone")
+	}
+	if _, err := os.Lstat(filepath.Join(backend.Path, "alpha")); !os.IsNotExist(err) {
+		t.Error("Expected the per-skill backend link to be pruned")
+	}
+
+	items, _ := app.trash().List()
+	if len(items) != 1 || items[0].Name != "alpha" || items[0].Skill == nil || items[0].Skill.Commit != "a1" {
+		t.Fatalf("Expected alpha in the trash with its manifest entry, got %+v", items)
+	}
+}
+
+func TestMergeSkills_ScaffoldedSkillIsLocalOnly(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	skillDir := filepath.Join(app.cfg.SkillsDir, "pdf-forms")
+	os.MkdirAll(skillDir, 0755)
+	os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skillmd.Template("pdf-forms", "Fill in PDF forms", []string{"pdf", "forms"})), 0644)
+
+	skills := mergeSkills(nil, app.manifest.ScanLocalSkills(), "")
+	if len(skills) != 1 {
+		t.Fatalf("Expected the new skill listed, got %+v", skills)
+	}
+	got := skills[0]
+	if got.Name != "pdf-forms" || got.Description != "Fill in PDF forms" || strings.Join(got.Tags, ",") != "pdf,forms" {
+		t.Errorf("Unexpected local skill entry %+v", got)
+	}
+}
+
+func TestApp_FailedActions_KeptForRetry(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	pdf := registry.SkillEntry{Name: "pdf"}
+	docx := registry.SkillEntry{Name: "docx"}
+
+	app.Update(queueDoneMsg{results: []queueResult{
+		{item: queueItem{op: queueInstall, skill: pdf}, detail: "network down", retry: true},
+		{item: queueItem{op: queueRemove, skill: docx}, detail: "required by pdf"},
+		{item: queueItem{op: queueUpdate, skill: docx}, detail: "local changes"},
+	}})
+	if len(app.failed) != 1 || app.failed[0].item.skill.Name != "pdf" || app.failed[0].err != "network down" {
+		t.Fatalf("Expected only the retryable install to be kept, got %+v", app.failed)
+	}
+
+	app.Update(updateDoneMsg{results: []updateSkillResult{
+		{name: "docx", status: "failed", problem: "fetch failed"},
+		{name: "xlsx", status: "skipped"},
+	}})
+	if len(app.failed) != 2 || app.failed[1].item.op != queueUpdate || app.failed[1].item.skill.Name != "docx" {
+		t.Fatalf("Expected the failed update to be kept, got %+v", app.failed)
+	}
+
+	app.mode = ModeNormal
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Q")})
+	if app.mode != ModeQueue {
+		t.Fatalf("Expected Q to open the queue with only failures, got mode %v", app.mode)
+	}
+	if content := app.renderQueueContent(); !strings.Contains(content, "Failed (2)") || !strings.Contains(content, "network down") {
+		t.Errorf("Expected a Failed section with the errors, got:\n%s", content)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if len(app.failed) != 1 || app.failed[0].item.skill.Name != "docx" {
+		t.Fatalf("Expected d to drop the failure under the cursor, got %+v", app.failed)
+	}
+
+	app.Update(queueDoneMsg{results: []queueResult{
+		{item: queueItem{op: queueUpdate, skill: docx}, ok: true},
+	}})
+	if len(app.failed) != 0 {
+		t.Errorf("Expected a successful retry to clear the failure, got %+v", app.failed)
+	}
+}
+
+func TestApp_Print_GroupsAsTextAndMarkdown(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.skills.SetCollapseMap(map[string]bool{"github.com/repo-b/skills": true})
+
+	var text bytes.Buffer
+	app.printText(&text)
+	for _, want := range []string{
+		"github.com/repo-a/skills (10)\n",
+		"  ○ skill-001@v1.0.0  Test skill number 1\n",
+		"  ○ skill-002@v1.0.0  Test skill number 2\n", // collapsed groups are expanded
+		"○ available",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected text output to contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var md bytes.Buffer
+	app.printMarkdown(&md)
+	for _, want := range []string{
+		"# Skills\n",
+		"## github.com/repo-c/skills (10)\n\n- ○ `skill-003` v1.0.0: Test skill number 3\n",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Expected markdown output to contain %q, got:\n%s", want, md.String())
+		}
+	}
+}
+
+func TestApp_DiffTab_DiscardOrKeepChanges(t *testing.T) {
+	if err := git.Available(); err != nil {
+		t.Skip("git not available")
+	}
+	app := newAppForPageKeyRoutingTest(t)
+	app.cfg.PatchesDir = filepath.Join(t.TempDir(), "patches")
+	// Installed skills link into a clone shared by their repository
+	clone := t.TempDir()
+	skillDir := filepath.Join(clone, "skills", "skill-001")
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("# Skill\n\nold line\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", clone}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	dir := app.manifest.GetSkillPath("skill-001")
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(skillDir, dir); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := filepath.Join(dir, "SKILL.md")
+	if err := os.WriteFile(skillMD, []byte("# Skill\n\nnew line\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	skill := app.skills.Selected()
+	if skill == nil || skill.Name != "skill-001" {
+		t.Fatalf("Expected skill-001 selected, got %v", skill)
+	}
+	app.detail.SetSize(80, 30)
+	app.detail.SetSkill(skill, nil, &manifest.LocalSkill{Name: skill.Name, IsGitRepo: true, IsModified: true}, app.cfg.SkillsDir)
+	app.layout.FocusRight()
+	for range 2 {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	}
+	if app.detail.ActiveTab() != panels.TabDiff {
+		t.Fatalf("Expected ] to reach the Diff tab of a modified skill, got %v", app.detail.ActiveTab())
+	}
+	if view := app.detail.View(); !strings.Contains(view, "-old line") || !strings.Contains(view, "+new line") {
+		t.Errorf("Expected the diff in the Diff tab, got:\n%s", view)
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if cmd == nil {
+		t.Fatal("Expected p to save the changes")
+	}
+	if msg := cmd().(keptChangesMsg); msg.err != nil {
+		t.Fatal(msg.err)
+	}
+	if _, err := os.Stat(filepath.Join(app.cfg.PatchesDir, skill.Name, "local.patch")); err != nil {
+		t.Errorf("Expected the changes saved as the local patch: %v", err)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if app.mode != ModeConfirm || app.confirmAction != ConfirmDiscard || app.confirmSel != 1 {
+		t.Fatalf("Expected x to confirm discarding with No selected, got mode %v sel %d", app.mode, app.confirmSel)
+	}
+	app.confirmSel = 0
+	_, cmd = app.executeConfirm()
+	if msg := cmd().(discardedMsg); msg.err != nil {
+		t.Fatal(msg.err)
+	}
+	if data, _ := os.ReadFile(skillMD); string(data) != "# Skill\n\nold line\n" {
+		t.Errorf("Expected the change to be discarded, got %q", data)
+	}
+
+	app.detail.SetSkill(skill, nil, &manifest.LocalSkill{Name: skill.Name, IsGitRepo: true}, app.cfg.SkillsDir)
+	if app.detail.ActiveTab() != panels.TabSkillMD {
+		t.Errorf("Expected an unmodified skill to leave the Diff tab, got %v", app.detail.ActiveTab())
+	}
+}
+
+func TestApp_DetailActions_FollowSkillState(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	app.detail.SetSize(80, 30)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	skill := app.skills.Selected()
+	if skill == nil || skill.Name != "skill-001" {
+		t.Fatalf("Expected skill-001 selected, got %v", skill)
+	}
+
+	keys := func() string {
+		var k string
+		for _, action := range app.detail.Actions() {
+			k += action.Key
+		}
+		return k
+	}
+	if got := keys(); got != "io" {
+		t.Errorf("Expected install and open for a skill that is not installed, got %q", got)
+	}
+	if view := app.detail.View(); !strings.Contains(view, "i install") || !strings.Contains(view, "o open") {
+		t.Errorf("Expected the actions footer in the panel, got:\n%s", view)
+	}
+
+	if err := app.manifest.AddSkill("skill-001", "", "aaa111", "https://github.com/repo-a/skills", "skill-001"); err != nil {
+		t.Fatal(err)
+	}
+	info, _ := app.manifest.GetInstalled("skill-001")
+	app.outdated = map[string]bool{"skill-001": true}
+	app.detail.SetSkill(skill, &info, &manifest.LocalSkill{Name: skill.Name, IsModified: true}, app.cfg.SkillsDir)
+	app.detail.SetOutdated(true)
+	if got := keys(); got != "Urdo" {
+		t.Errorf("Expected update, remove, diff and open for a modified outdated skill, got %q", got)
+	}
+
+	// The keys only act from the detail panel
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if app.detail.ActiveTab() == panels.TabDiff {
+		t.Fatal("Expected d to do nothing with the list focused")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if app.detail.ActiveTab() != panels.TabDiff {
+		t.Errorf("Expected d to show the Diff tab, got %v", app.detail.ActiveTab())
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	if app.mode != ModeQueue || len(app.queue) != 1 || app.queue[0].op != queueUpdate {
+		t.Errorf("Expected U to queue the update for review, got mode %v queue %+v", app.mode, app.queue)
+	}
+}
+
+func TestApp_Install_ReportsGitProgress(t *testing.T) {
+	if err := git.Available(); err != nil {
+		t.Skip("git not available")
+	}
+	app := newAppForPageKeyRoutingTest(t)
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "skills", "pdf"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "skills", "pdf", "SKILL.md"), []byte("---\nname: pdf\ndescription: PDF\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", src}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	if err := os.MkdirAll(app.cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	var steps []string
+	tracker := progress.New("Installing pdf", func(s progress.State) {
+		if len(steps) == 0 || steps[len(steps)-1] != s.Step {
+			steps = append(steps, s.Step)
+		}
+	})
+	skill := &registry.SkillEntry{Name: "pdf", Source: registry.SkillSource{Repo: "file://" + src, Path: "skills/pdf"}}
+	if msg, failed := app.installSkill(skill, skill.Name, tracker)().(installErrMsg); failed {
+		t.Fatal(msg.err)
+	}
+	if !slices.Contains(steps, "Receiving objects") || !slices.Contains(steps, "Checking out files") || steps[len(steps)-1] != "Linking" {
+		t.Errorf("Expected the install phases to be reported, got %q", steps)
+	}
+
+	app.mode = ModeLoading
+	app.loadingMsg = "Installing pdf..."
+	app.progress = progress.New("Installing pdf", nil)
+	app.progress.SetTotal(100)
+	app.progress.Set(45, "Receiving objects")
+	if content := app.renderLoadingContent(); !strings.Contains(content, "Receiving objects 45%") {
+		t.Errorf("Expected the loading modal to show the phase, got:\n%s", content)
+	}
+}
+
+func TestApp_Install_OffersFreeNameWhenTaken(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	if err := os.MkdirAll(filepath.Join(app.cfg.SkillsDir, "skill-001"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(app.cfg.SkillsDir, "skill-001", "SKILL.md"), []byte("# mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if app.mode != ModeConfirm || app.confirmAction != ConfirmInstallAs || app.installAs != "skill-001-2" {
+		t.Fatalf("Expected an offer to install as skill-001-2, got mode %v action %v name %q", app.mode, app.confirmAction, app.installAs)
+	}
+	if content := app.renderConfirmContent(); !strings.Contains(content, "taken by a local skill") {
+		t.Errorf("Expected the local skill to be named, got:\n%s", content)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if app.confirmAction != ConfirmOverwrite {
+		t.Errorf("Expected o to switch to replacing the local skill, got %v", app.confirmAction)
+	}
+
+	// The same skill installed before is reinstalled in place
+	skill := app.skills.Selected()
+	if err := app.manifest.AddSkill(skill.Name, "v1.0.0", "aaa111", skill.Source.Repo, skill.Source.Path); err != nil {
+		t.Fatal(err)
+	}
+	app.mode = ModeNormal
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if app.confirmAction != ConfirmOverwrite {
+		t.Errorf("Expected the tracked skill to be overwritten, got %v", app.confirmAction)
+	}
+}
+
+func TestApp_Settings_EditAndSave(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	store := app.cfg.Store.(*ttesting.MockConfigStore)
+	press := func(keys ...string) {
+		for _, k := range keys {
+			switch k {
+			case "enter":
+				app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			case "backspace":
+				app.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+			default:
+				app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+			}
+		}
+	}
+
+	press(",")
+	if app.mode != ModeSettings {
+		t.Fatalf("Expected the settings screen, got mode %v", app.mode)
+	}
+
+	// Cache TTL is typed in; a bad value is refused and nothing is saved
+	press("enter", "backspace", "backspace", "0", "enter")
+	if !app.settingsEditing || app.settingsErr == "" || store.SaveCount != 0 {
+		t.Fatalf("Expected 0 hours refused, editing=%v err=%q saves=%d", app.settingsEditing, app.settingsErr, store.SaveCount)
+	}
+	press("backspace", "6", "enter")
+	if app.settingsEditing || app.cfg.CacheTTL != 6 || store.Data.CacheTTL != 6 {
+		t.Fatalf("Expected the TTL saved as 6, got %d", app.cfg.CacheTTL)
+	}
+	if app.previews.TTL != 6*time.Hour {
+		t.Errorf("Expected the preview cache to use the new TTL, got %v", app.previews.TTL)
+	}
+
+	// Choices step through their values on enter
+	press("j", "j", "j", "enter")
+	if app.cfg.UpdatePolicy != "minor" || store.Data.UpdatePolicy != "minor" {
+		t.Errorf("Expected the update policy stepped to minor, got %q", app.cfg.UpdatePolicy)
+	}
+
+	press("esc")
+	if app.mode != ModeNormal {
+		t.Errorf("Expected esc to close the settings, got mode %v", app.mode)
+	}
+}
+
+func TestApp_Settings_SwitchTheme(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	t.Cleanup(func() { styles.SetTheme(styles.Themes[styles.DefaultTheme]) })
+	app.cfg.Theme = map[string]string{"success": "#00FF00"}
+	app.applyTheme()
+	app.openSettings()
+	for i, s := range app.settings {
+		if s.label == "Theme" {
+			app.settingsCursor = i
+		}
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.cfg.Theme["preset"] != "light" || app.cfg.Theme["success"] != "#00FF00" {
+		t.Fatalf("Expected the light preset saved beside the override, got %v", app.cfg.Theme)
+	}
+	if got := styles.Current().Background; got != styles.Themes["light"].Background {
+		t.Errorf("Expected the light background, got %v", got)
+	}
+	if got := app.styles.ButtonActive.GetBackground(); got != styles.Themes["light"].Primary {
+		t.Errorf("Expected the app styles rebuilt, got %v", got)
+	}
+	if got := styles.Current().Success; got != lipgloss.Color("#00FF00") {
+		t.Errorf("Expected the override kept, got %v", got)
+	}
+
+	// An invalid section falls back to the default theme
+	app.cfg.Theme = map[string]string{"preset": "sepia"}
+	app.applyTheme()
+	if app.themeErr == nil || styles.Current() != styles.Themes[styles.DefaultTheme] {
+		t.Errorf("Expected the default theme and an error, got %v", app.themeErr)
+	}
+}
+
+func TestCrashGuard_RecoversAndWritesLog(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	guard := &crashGuard{app: app}
+
+	guard.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	app.layout = nil // the next resize dereferences it
+	_, cmd := guard.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if guard.crash == nil || cmd == nil {
+		t.Fatal("Expected the panic to be recovered and the program to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected a quit after the panic")
+	}
+	if got := strings.Join(guard.crash.recent, ", "); got != "key j, resize 80x24" {
+		t.Errorf("Expected the recent messages, got %q", got)
+	}
+
+	// A panic in a command, even inside a batch, comes back as a message
+	batch := guardCmd(tea.Batch(func() tea.Msg { return nil }, func() tea.Msg { panic("boom") }))
+	cmds := batch().(tea.BatchMsg)
+	msg, ok := cmds[1]().(crashMsg)
+	if !ok || msg.crash.value != "boom" {
+		t.Fatalf("Expected a crashMsg, got %#v", msg)
+	}
+
+	app.cfg.LogsDir = filepath.Join(t.TempDir(), "logs")
+	path, err := writeCrashLog(app.cfg, guard.crash, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "key j") || !strings.Contains(string(data), "goroutine") {
+		t.Errorf("Expected the messages and stack in the log, got:\n%s", data)
+	}
+}
+
+func TestApp_UpdateAll_StreamsResultsAndCancels(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	for i, repo := range []string{"a", "b", "c", "a", "b"} {
+		name := fmt.Sprintf("skill-%d", i)
+		if err := app.manifest.AddSkill(name, "v1.0.0", "aaa111", "https://example.com/"+repo, name); err != nil {
+			t.Fatal(err)
+		}
+		// Pinned skills are held without touching git
+		if err := app.manifest.SetPin(name, "v1.0.0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run := func(cancel bool) (streamed int) {
+		tracker := app.startProgress("Updating")
+		if cancel {
+			tracker.Cancel()
+		}
+		next := make(chan tea.Msg)
+		go app.runUpdates(app.manifest.ListInstalled(), tracker, next)
+		_, cmd := app.Update(updateStartedMsg{total: 5, next: next})
+		if app.mode != ModeUpdateResult || !app.updateResult.running {
+			t.Fatalf("Expected the result modal to open while running, got mode %v", app.mode)
+		}
+		for cmd != nil {
+			msg := cmd()
+			if _, ok := msg.(updateSkillMsg); ok {
+				streamed++
+			}
+			_, cmd = app.Update(msg)
+		}
+		return streamed
+	}
+
+	if streamed := run(false); streamed != 5 {
+		t.Errorf("Expected 5 streamed results, got %d", streamed)
+	}
+	if r := app.updateResult; r.running || len(r.results) != 5 || r.skipped != 5 || r.results[0].name != "skill-0" || r.cancelled != 0 {
+		t.Errorf("Unexpected final result: %+v", r)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if streamed := run(true); streamed != 0 || app.updateResult.cancelled != 5 {
+		t.Errorf("Expected a cancelled run to update nothing, got %d streamed, %+v", streamed, app.updateResult)
+	}
+	if !strings.Contains(app.renderUpdateResultContent(), "Cancelled: 5") {
+		t.Error("Expected the modal to note the cancelled skills")
+	}
+}
+
+func TestApp_CheckStaleness_ComparesWithTarget(t *testing.T) {
+	if err := git.Available(); err != nil {
+		t.Skip("git not available")
+	}
+	app := newAppForPageKeyRoutingTest(t)
+	remote := filepath.Join(t.TempDir(), "skills.git")
+	seed := t.TempDir()
+	if err := os.WriteFile(filepath.Join(seed, "SKILL.md"), []byte("# Skill\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repoDir := filepath.Join(app.cfg.ReposDir, git.RepoDirName(remote))
+	run := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	run("init", "-q", "--bare", remote)
+	run("--git-dir", remote, "symbolic-ref", "HEAD", "refs/heads/main")
+	run("-C", seed, "init", "-q")
+	run("-C", seed, "add", "-A")
+	run("-C", seed, "commit", "-qm", "v1.0.0")
+	run("-C", seed, "tag", "v1.0.0")
+	run("-C", seed, "commit", "-q", "--allow-empty", "-m", "v1.0.1")
+	run("-C", seed, "tag", "v1.0.1")
+	run("-C", seed, "push", "-q", "--tags", remote, "HEAD:refs/heads/main")
+	run("clone", "-q", remote, repoDir)
+	run("-C", seed, "commit", "-q", "--allow-empty", "-m", "v2.0.0")
+	run("-C", seed, "tag", "v2.0.0")
+	run("-C", seed, "push", "-q", "--tags", remote, "HEAD:refs/heads/main")
+
+	commit := func(ref string) string {
+		out, err := exec.Command("git", "-C", seed, "rev-parse", ref).Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	// The shared clone stays at v1.0.1: skills are compared with their
+	// target on the remote, not with the clone's HEAD
+	outdated := app.checkStaleness(app.registry, map[string]manifest.InstalledSkill{
+		"held":     {SourceRepo: remote, Version: "v1.0.1", Pin: "v1.0"},
+		"in-pin":   {SourceRepo: remote, Version: "v1.0.0", Pin: "v1.0"},
+		"unpinned": {SourceRepo: remote, Version: "v1.0.1", Commit: commit("v1.0.1")},
+		"on-main":  {SourceRepo: remote, Branch: "main", Commit: commit("v2.0.0")},
+	})
+	if outdated["held"] || !outdated["in-pin"] || !outdated["unpinned"] || outdated["on-main"] {
+		t.Errorf("outdated = %v, want in-pin and unpinned only", outdated)
+	}
+}
+
+// sharedInstall pushes one commit per element of commits, tagged v1, v2,
+// ..., to a new remote and installs the skills at paths from it into one
+// shared clone of app's repos directory. It returns the remote.
+func sharedInstall(t *testing.T, app *App, commits []map[string]string, paths ...string) string {
+	t.Helper()
+	if err := git.Available(); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	remote, seed := filepath.Join(root, "skills.git"), filepath.Join(root, "seed")
+	run := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	run("init", "-q", "--bare", remote)
+	run("--git-dir", remote, "symbolic-ref", "HEAD", "refs/heads/main")
+	run("init", "-q", seed)
+	for i, files := range commits {
+		for name, content := range files {
+			path := filepath.Join(seed, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		run("-C", seed, "add", "-A")
+		run("-C", seed, "commit", "-qm", fmt.Sprintf("v%d", i+1))
+		run("-C", seed, "tag", fmt.Sprintf("v%d", i+1))
+	}
+	run("-C", seed, "push", "-q", "--tags", remote, "HEAD:refs/heads/main")
+
+	if err := os.MkdirAll(app.cfg.SkillsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range paths {
+		name := filepath.Base(p)
+		result, err := git.RepoInstall(git.RepoInstallOptions{
+			RepoURL:   remote,
+			Path:      p,
+			RepoDir:   filepath.Join(app.cfg.ReposDir, git.RepoDirName(remote)),
+			SkillName: name,
+			SkillLink: app.manifest.GetSkillPath(name),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := app.manifest.AddSkill(name, "", result.Commit, remote, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return remote
+}
+
+func TestApp_SwitchVersion_LeavesSiblingsAlone(t *testing.T) {
+	app := newAppForPageKeyRoutingTest(t)
+	sharedInstall(t, app, []map[string]string{
+		{"skills/a/SKILL.md": "a1\n", "skills/b/SKILL.md": "b1\n"},
+		{"skills/a/SKILL.md": "a2\n", "skills/b/SKILL.md": "b2\n"},
+	}, "skills/a", "skills/b")
+	before, _ := app.manifest.GetInstalled("b")
+
+	if msg, ok := app.switchVersion("a", "v1")().(versionSwitchDoneMsg); !ok {
+		t.Fatalf("switchVersion = %#v", msg)
+	}
+	if data, _ := os.ReadFile(filepath.Join(app.manifest.GetSkillPath("a"), "SKILL.md")); string(data) != "a1\n" {
+		t.Errorf("a's SKILL.md = %q, want v1", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(app.manifest.GetSkillPath("b"), "SKILL.md")); string(data) != "b2\n" {
+		t.Errorf("b's SKILL.md = %q, want it left at v2", data)
+	}
+	if commit, _ := git.HeadCommit(app.manifest.GetSkillPath("b")); commit != before.Commit {
+		t.Errorf("b moved from %s to %s", before.Commit, commit)
+	}
+}
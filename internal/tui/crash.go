@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/manifest"
+)
+
+// recentMessages is how many of the last messages a crash log shows
+const recentMessages = 20
+
+// crash is a recovered panic, with what led up to it
+type crash struct {
+	value  any
+	stack  []byte
+	recent []string
+}
+
+// crashMsg reports a panic in a command, which runs on its own goroutine
+type crashMsg struct{ crash *crash }
+
+// crashGuard runs the App so that a panic in Update, View or a command
+// ends the program through the normal shutdown, which restores the
+// terminal, instead of leaving it in the alt screen. Run writes the crash
+// log afterwards.
+type crashGuard struct {
+	app    *App
+	crash  *crash
+	recent []string // descriptions of the last messages, oldest first
+	quit   func()   // asks the program to quit from outside Update
+}
+
+func (g *crashGuard) Init() tea.Cmd {
+	return guardCmd(g.app.Init())
+}
+
+func (g *crashGuard) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if g.crash != nil {
+		return g, nil
+	}
+	if m, ok := msg.(crashMsg); ok {
+		m.crash.recent = append([]string(nil), g.recent...)
+		g.crash = m.crash
+		return g, tea.Quit
+	}
+	g.recent = append(g.recent, describeMsg(msg))
+	if len(g.recent) > recentMessages {
+		g.recent = g.recent[1:]
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash = newCrash(r, g.recent)
+			model, cmd = g, tea.Quit
+		}
+	}()
+	_, cmd = g.app.Update(msg)
+	return g, guardCmd(cmd)
+}
+
+func (g *crashGuard) View() (view string) {
+	if g.crash != nil {
+		return ""
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash = newCrash(r, g.recent)
+			view = ""
+			if g.quit != nil {
+				g.quit()
+			}
+		}
+	}()
+	return g.app.View()
+}
+
+// guardCmd wraps cmd so a panic in it is reported as a crashMsg. The
+// commands of a batch are wrapped as they are run.
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = crashMsg{newCrash(r, nil)}
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			guarded := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				guarded[i] = guardCmd(c)
+			}
+			return guarded
+		}
+		return msg
+	}
+}
+
+func newCrash(value any, recent []string) *crash {
+	return &crash{value: value, stack: debug.Stack(), recent: append([]string(nil), recent...)}
+}
+
+// describeMsg names a message for the crash log without its contents,
+// which may hold skill files or paths; keys are kept to retrace the steps
+func describeMsg(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return "key " + msg.String()
+	case tea.WindowSizeMsg:
+		return fmt.Sprintf("resize %dx%d", msg.Width, msg.Height)
+	default:
+		return fmt.Sprintf("%T", msg)
+	}
+}
+
+// writeCrashLog writes a crash to a new file in the logs directory and
+// returns its path
+func writeCrashLog(cfg *config.Config, c *crash, at time.Time) (string, error) {
+	if err := os.MkdirAll(cfg.LogsDir, 0755); err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "lazyas %s crashed at %s\n", manifest.InstallerVersion, at.Format(time.RFC3339))
+	fmt.Fprintf(&b, "%s %s/%s\n\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "panic: %v\n\n", c.value)
+	b.WriteString("Recent messages, oldest first:\n")
+	for _, m := range c.recent {
+		fmt.Fprintf(&b, "  %s\n", m)
+	}
+	b.WriteString("\n")
+	b.Write(c.stack)
+
+	path := filepath.Join(cfg.LogsDir, "crash-"+at.Format("20060102-150405")+".log")
+	return path, os.WriteFile(path, b.Bytes(), 0600)
+}
+
+// reportCrash writes the crash log and tells the user what happened and
+// what to do next
+func reportCrash(cfg *config.Config, c *crash) error {
+	fmt.Fprintln(os.Stderr, i18n.Tf("lazyas crashed: %v", c.value))
+	if path, err := writeCrashLog(cfg, c, time.Now()); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("Could not write the crash log: %v", err))
+		os.Stderr.Write(c.stack)
+	} else {
+		fmt.Fprintln(os.Stderr, i18n.Tf("The details are in %s.", path))
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("Start lazyas again to continue; 'lazyas check' finds skills an interrupted install or update left broken."))
+	fmt.Fprintln(os.Stderr, i18n.T("To report it, attach the output of 'lazyas debug-report', which includes the log."))
+	return fmt.Errorf("TUI crashed: %v", c.value)
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/i18n"
+)
+
+// quickStart is the offline guide opened from the empty state
+const quickStart = `# lazyas quick start
+
+lazyas installs agent skills from git repositories into ~/.lazyas/skills
+and links that directory into each agent's skills folder.
+
+## 1. Add a repository
+
+Press A in the TUI, or run:
+
+    lazyas config repo add anthropics https://github.com/anthropics/skills
+
+The starter kit (K) adds a few well-known repositories in one step.
+
+## 2. Link your agents
+
+Press b to link ~/.lazyas/skills into Claude Code, Codex, Gemini CLI,
+Cursor and other detected backends.
+
+## 3. Install skills
+
+Select a skill and press i, or run:
+
+    lazyas install pdf
+
+## More
+
+    lazyas --help          # All commands
+    lazyas check           # Check installed skills work
+    lazyas debug-report    # Collect details for a bug report
+`
+
+// showEmptyState reports whether there is nothing to browse: no repos,
+// no local skills and the starter kit already dismissed
+func (a *App) showEmptyState() bool {
+	return len(a.cfg.Repos) == 0 && a.cfg.StarterKitDismissed &&
+		a.skills != nil && a.skills.Empty() && a.skills.GetQuery() == ""
+}
+
+// updateEmptyState handles the keys only offered by the empty state. The
+// other actions (A, K, b) are regular normal-mode keys.
+func (a *App) updateEmptyState(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "o":
+		return a.openQuickStart(), true
+	}
+	return nil, false
+}
+
+// openQuickStart shows the quick start guide in the external viewer
+func (a *App) openQuickStart() tea.Cmd {
+	f, err := os.CreateTemp("", "lazyas-*-quickstart.md")
+	if err != nil {
+		a.message = a.styles.Error.Render(i18n.Tf("Viewer failed: %v", err))
+		return nil
+	}
+	f.WriteString(quickStart)
+	f.Close()
+
+	cmd := a.viewerCmd(f.Name())
+	if cmd == nil {
+		os.Remove(f.Name())
+		a.message = a.styles.Error.Render(i18n.T("No viewer found: set viewer in config.toml or install glow"))
+		return nil
+	}
+	tmp := f.Name()
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return glowDoneMsg{err, tmp}
+	})
+}
+
+// renderEmptyState draws the onboarding panel shown instead of an empty
+// skills list
+func (a *App) renderEmptyState(width, height int) string {
+	backends := i18n.T("Link ~/.lazyas/skills into your agents")
+	if a.totalBackends > 0 {
+		backends = i18n.Tf("Link your agents (%d of %d linked)", a.linkedBackends, a.totalBackends)
+	}
+	actions := []struct{ key, label string }{
+		{"A", i18n.T("Add a skill repository by URL")},
+		{"K", i18n.T("Re-open the starter kit of popular repositories")},
+		{"b", backends},
+		{"o", i18n.T("Open the quick start guide")},
+	}
+
+	var lines []string
+	lines = append(lines,
+		a.styles.Title.Render(i18n.T("No skill repositories yet")),
+		"",
+		a.styles.Muted.Render(i18n.T("lazyas browses skills from git repositories. Add one to get started:")),
+		"",
+	)
+	for _, action := range actions {
+		lines = append(lines, "  "+a.styles.HelpKey.Render(action.key)+"  "+action.label)
+	}
+	lines = append(lines,
+		"",
+		a.styles.Muted.Render(i18n.T("From a shell: lazyas config repo add <name> <url>")),
+	)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/tui/styles"
+)
+
+// Modals share one focus model: tab and shift+tab move the focus over all
+// of a modal's controls - fields, lists and buttons - and wrap around. The
+// focused control is drawn in the accent colour: a field gets the "> "
+// marker, a list its cursor bar and a button the active button style.
+// While the focus is elsewhere a list keeps its cursor row, in grey.
+
+// cycleFocus returns the control focused after key among n controls, and
+// false when key does not move the focus
+func cycleFocus(key string, focus, n int) (int, bool) {
+	switch key {
+	case "tab":
+		return (focus + 1) % n, true
+	case "shift+tab":
+		return (focus + n - 1) % n, true
+	}
+	return focus, false
+}
+
+// modalCursorStyle styles the cursor row of a modal list, bright while the
+// list has the focus
+func modalCursorStyle(focused bool, width int) lipgloss.Style {
+	if !focused {
+		return lipgloss.NewStyle().
+			Background(styles.Current().Border).
+			Foreground(styles.Current().Text).
+			Width(width)
+	}
+	return lipgloss.NewStyle().
+		Background(styles.Current().Primary).
+		Foreground(styles.Current().SelectedText).
+		Width(width).
+		Bold(true)
+}
+
+// renderButtons renders a row of buttons on the modal background with the
+// button at index focused highlighted; an index out of range highlights none
+func (a *App) renderButtons(modalBg lipgloss.Color, focused int, labels ...string) string {
+	spacer := lipgloss.NewStyle().Background(modalBg).Render("  ")
+	var row []string
+	for i, label := range labels {
+		if i > 0 {
+			row = append(row, spacer)
+		}
+		if i == focused {
+			row = append(row, a.styles.ButtonActive.Render(" "+label+" "))
+		} else {
+			row = append(row, a.styles.Button.Background(modalBg).Render(" "+label+" "))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, row...)
+}
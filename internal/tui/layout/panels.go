@@ -2,6 +2,7 @@ package layout
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/tui/styles"
 )
 
 // Panel represents the currently focused panel
@@ -100,12 +101,13 @@ type PanelStyles struct {
 
 // DefaultPanelStyles returns the default panel styles
 func DefaultPanelStyles() PanelStyles {
+	t := styles.Current()
 	return PanelStyles{
 		ActiveBorder: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7C3AED")), // Purple
+			BorderForeground(t.Primary),
 		InactiveBorder: lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#374151")), // Dark gray
+			BorderForeground(t.Border),
 	}
 }
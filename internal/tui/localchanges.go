@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/patch"
+	"lazyas/internal/tui/panels"
+)
+
+// discardedMsg reports that a skill's local modifications were discarded
+type discardedMsg struct {
+	name string
+	err  error
+}
+
+// keptChangesMsg reports that a skill's local modifications were saved as
+// its "local" patch
+type keptChangesMsg struct {
+	name string
+	err  error
+}
+
+// selectedModified returns the selected skill when the Diff tab shows its
+// local modifications
+func (a *App) selectedModified() string {
+	if a.skills == nil || a.skills.IsSearching() || a.detail.ActiveTab() != panels.TabDiff {
+		return ""
+	}
+	if skill := a.skills.Selected(); skill != nil {
+		return skill.Name
+	}
+	return ""
+}
+
+// discardChanges resets a skill to the commit it was installed at,
+// removing new files too
+func (a *App) discardChanges(name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.ResetChanges(a.manifest.GetSkillPath(name)); err != nil {
+			return discardedMsg{name, err}
+		}
+		return discardedMsg{name: name}
+	}
+}
+
+// keepChanges saves a skill's local modifications as its "local" patch, so
+// they can be re-applied after the skill is updated or reinstalled
+func (a *App) keepChanges(name string) tea.Cmd {
+	return func() tea.Msg {
+		info, _ := a.manifest.GetInstalled(name)
+		diff, err := git.CaptureDiff(a.manifest.GetSkillPath(name))
+		if err != nil {
+			return keptChangesMsg{name, fmt.Errorf("failed to capture changes: %w", err)}
+		}
+		if len(diff) == 0 {
+			return keptChangesMsg{name, fmt.Errorf("%s", i18n.Tf("%s has no local modifications", name))}
+		}
+		_, err = patch.NewStore(a.cfg.PatchesDir).Save(name, "local", info.Commit, diff)
+		return keptChangesMsg{name, err}
+	}
+}
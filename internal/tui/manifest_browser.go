@@ -0,0 +1,329 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/manifest"
+	"lazyas/internal/remote"
+	"lazyas/internal/tui/styles"
+)
+
+// manifestSort is the column the manifest browser is ordered by
+type manifestSort int
+
+const (
+	manifestSortName manifestSort = iota
+	manifestSortInstalled
+	manifestSortSource
+	manifestSortVersion
+	manifestSortCount
+)
+
+func (s manifestSort) String() string {
+	switch s {
+	case manifestSortInstalled:
+		return "installed"
+	case manifestSortSource:
+		return "source"
+	case manifestSortVersion:
+		return "version"
+	default:
+		return "name"
+	}
+}
+
+// manifestRow is one tracked skill in the manifest browser
+type manifestRow struct {
+	name string
+	info manifest.InstalledSkill
+	pin  string // "tags", "pinned", "pinned <version>" or "branch <name>"
+}
+
+type (
+	manifestActionDoneMsg struct{ text string }
+	manifestActionErrMsg  struct {
+		title string
+		err   error
+	}
+)
+
+// openManifestBrowser switches to the manifest table
+func (a *App) openManifestBrowser() {
+	a.manifestCursor = 0
+	a.buildManifestRows()
+	a.mode = ModeManifest
+}
+
+// buildManifestRows reads the manifest and orders it by the current sort
+// column, keeping the cursor on the same skill
+func (a *App) buildManifestRows() {
+	selected := ""
+	if a.manifestCursor < len(a.manifestRows) {
+		selected = a.manifestRows[a.manifestCursor].name
+	}
+
+	rows := make([]manifestRow, 0)
+	for name, info := range a.manifest.ListInstalled() {
+		rows = append(rows, manifestRow{name: name, info: info, pin: a.pinStatus(name, info)})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		ri, rj := rows[i], rows[j]
+		switch a.manifestSort {
+		case manifestSortInstalled:
+			if !ri.info.InstalledAt.Equal(rj.info.InstalledAt) {
+				return ri.info.InstalledAt.After(rj.info.InstalledAt)
+			}
+		case manifestSortSource:
+			if ri.info.SourceRepo != rj.info.SourceRepo {
+				return ri.info.SourceRepo < rj.info.SourceRepo
+			}
+		case manifestSortVersion:
+			if ri.info.Version != rj.info.Version {
+				return ri.info.Version < rj.info.Version
+			}
+		}
+		return ri.name < rj.name
+	})
+	a.manifestRows = rows
+
+	a.manifestCursor = 0
+	for i, r := range rows {
+		if r.name == selected {
+			a.manifestCursor = i
+		}
+	}
+}
+
+// pinStatus describes how a skill follows upstream: a tracked branch, an
+// explicit pin, the registry tag, or pinned to another version
+func (a *App) pinStatus(name string, info manifest.InstalledSkill) string {
+	if info.Branch != "" {
+		return "branch " + info.Branch
+	}
+	if info.Pin != "" {
+		return "pinned " + info.Pin
+	}
+	if a.registry != nil {
+		if skill := a.registry.GetSkill(info.RegistryName(name)); skill != nil && info.Version != skill.Source.Tag {
+			return "pinned"
+		}
+	}
+	return "tags"
+}
+
+func (a *App) selectedManifestRow() *manifestRow {
+	if a.manifestCursor < len(a.manifestRows) {
+		return &a.manifestRows[a.manifestCursor]
+	}
+	return nil
+}
+
+func (a *App) updateManifestBrowser(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "M":
+		a.mode = ModeNormal
+		return a, nil
+
+	case "j", "down":
+		if a.manifestCursor < len(a.manifestRows)-1 {
+			a.manifestCursor++
+		}
+
+	case "k", "up":
+		if a.manifestCursor > 0 {
+			a.manifestCursor--
+		}
+
+	case "g", "home":
+		a.manifestCursor = 0
+
+	case "G", "end":
+		if len(a.manifestRows) > 0 {
+			a.manifestCursor = len(a.manifestRows) - 1
+		}
+
+	case "s":
+		a.manifestSort = (a.manifestSort + 1) % manifestSortCount
+		a.buildManifestRows()
+
+	case "u":
+		if row := a.selectedManifestRow(); row != nil && row.pin != "tags" {
+			a.loadingMsg = i18n.Tf("Unpinning %s...", row.name)
+			a.mode = ModeLoading
+			return a, tea.Batch(
+				a.unpinSkill(row.name),
+				tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+			)
+		}
+
+	case "b":
+		if row := a.selectedManifestRow(); row != nil && len(row.info.History) > 0 && a.requireGit() {
+			a.loadingMsg = i18n.Tf("Rolling back %s...", row.name)
+			a.mode = ModeLoading
+			return a, tea.Batch(
+				a.rollbackSkill(row.name),
+				tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+			)
+		}
+
+	case "o":
+		if row := a.selectedManifestRow(); row != nil && row.info.SourceRepo != "" {
+			url := remote.WebURL(row.info.SourceRepo, row.info.Commit, row.info.SourcePath)
+			if err := remote.OpenBrowser(url); err != nil {
+				a.message = a.styles.Error.Render(i18n.Tf("Failed to open browser: %v", err))
+			} else {
+				a.message = a.styles.Muted.Render(i18n.Tf("Opened %s", url))
+			}
+		}
+	}
+	return a, nil
+}
+
+// rollbackSkill moves a skill back to the newest commit of its history,
+// the one it had before its last update
+func (a *App) rollbackSkill(name string) tea.Cmd {
+	return func() tea.Msg {
+		info, _ := a.manifest.GetInstalled(name)
+		skillDir := a.manifest.GetSkillPath(name)
+		if _, err := git.Update(skillDir, info.History[0].Commit); err != nil {
+			return manifestActionErrMsg{i18n.T("Rollback Failed"), err}
+		}
+		prev, err := a.manifest.RollBack(name)
+		if err != nil {
+			return manifestActionErrMsg{i18n.T("Rollback Failed"), err}
+		}
+		if hash, err := integrity.HashDir(skillDir); err == nil {
+			a.manifest.SetHashes(map[string]string{name: hash})
+		}
+		return manifestActionDoneMsg{i18n.Tf("Rolled back %s to %s (%s)", name, refOrLatest(prev.Version), truncate(prev.Commit, 7))}
+	}
+}
+
+// unpinSkill stops tracking a branch, drops the pin and returns the skill
+// to the registry version
+func (a *App) unpinSkill(name string) tea.Cmd {
+	return func() tea.Msg {
+		info, _ := a.manifest.GetInstalled(name)
+		if info.Branch != "" {
+			if err := a.manifest.SetBranch(name, ""); err != nil {
+				return manifestActionErrMsg{i18n.T("Unpin Failed"), err}
+			}
+		}
+		if info.Pin != "" {
+			if err := a.manifest.SetPin(name, ""); err != nil {
+				return manifestActionErrMsg{i18n.T("Unpin Failed"), err}
+			}
+		}
+
+		skill := a.registry.GetSkill(info.RegistryName(name))
+		if skill == nil || info.Version == skill.Source.Tag {
+			return manifestActionDoneMsg{i18n.Tf("%s follows release tags", name)}
+		}
+		result, err := git.Update(a.manifest.GetSkillPath(name), skill.Source.Tag)
+		if err != nil {
+			return manifestActionErrMsg{i18n.T("Unpin Failed"), err}
+		}
+		if err := a.manifest.AddSkill(name, skill.Source.Tag, result.Commit, info.SourceRepo, info.SourcePath); err != nil {
+			return manifestActionErrMsg{i18n.T("Unpin Failed"), err}
+		}
+		return manifestActionDoneMsg{i18n.Tf("%s is now at %s", name, refOrLatest(skill.Source.Tag))}
+	}
+}
+
+func refOrLatest(ref string) string {
+	if ref == "" {
+		return "latest"
+	}
+	return ref
+}
+
+func (a *App) renderManifestBrowserContent() string {
+	modalBg := styles.Current().Background
+	contentWidth := 96
+
+	lineBg := lipgloss.NewStyle().
+		Background(modalBg).
+		Width(contentWidth)
+
+	title := i18n.Tf("Manifest (%d skills, sorted by %s)", len(a.manifestRows), a.manifestSort)
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(title)
+	emptyLine := lineBg.Render("")
+
+	var lines []string
+	lines = append(lines, titleStyled, emptyLine)
+
+	if len(a.manifestRows) == 0 {
+		lines = append(lines, lineBg.Render(i18n.T("  No skills installed")))
+	} else {
+		const rowFormat = "  %-22s %-12s %-8s %-24s %-11s %s"
+		header := fmt.Sprintf(rowFormat,
+			i18n.T("NAME"), i18n.T("VERSION"), i18n.T("COMMIT"), i18n.T("SOURCE"), i18n.T("INSTALLED"), i18n.T("PIN"))
+		lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(header))
+
+		// Show a window of rows around the cursor
+		const maxVisible = 15
+		start := 0
+		if a.manifestCursor >= maxVisible {
+			start = a.manifestCursor - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(a.manifestRows))
+
+		for i := start; i < end; i++ {
+			r := a.manifestRows[i]
+			installed := ""
+			if !r.info.InstalledAt.IsZero() {
+				installed = r.info.InstalledAt.Format("2006-01-02")
+			}
+			line := fmt.Sprintf(rowFormat,
+				truncate(r.name, 22),
+				truncate(refOrLatest(r.info.Version), 12),
+				truncate(r.info.Commit, 7),
+				truncate(git.RepoDirName(r.info.SourceRepo), 24),
+				installed,
+				r.pin)
+			if i == a.manifestCursor {
+				cursorStyle := lipgloss.NewStyle().
+					Background(styles.Current().Primary).
+					Foreground(styles.Current().SelectedText).
+					Width(contentWidth).
+					Bold(true)
+				lines = append(lines, cursorStyle.Render(line))
+			} else {
+				lines = append(lines, lineBg.Render(line))
+			}
+		}
+		if len(a.manifestRows) > maxVisible {
+			lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(
+				fmt.Sprintf("  %d/%d", a.manifestCursor+1, len(a.manifestRows))))
+		}
+
+		if row := a.selectedManifestRow(); row != nil && len(row.info.History) > 0 {
+			prev := row.info.History[0]
+			target := i18n.Tf("  Rollback target: %s (%s), %d earlier commit(s) kept", refOrLatest(prev.Version), truncate(prev.Commit, 7), len(row.info.History))
+			lines = append(lines, emptyLine, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(target))
+		}
+	}
+
+	lines = append(lines, emptyLine)
+	help := i18n.T("s: sort  u: unpin  b: rollback  o: open source  esc: close")
+	lines = append(lines, a.styles.Muted.Background(modalBg).Width(contentWidth).Render(help))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// truncate cuts s to at most n runes
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+)
+
+// gitKeys are the normal-mode keys whose actions run git
+var gitKeys = map[string]bool{
+	"i": true, // install
+	"L": true, // adopt a local copy
+	"+": true, // queue install or update
+	"U": true, // update all, marked or shown skills
+	"v": true, // versions
+	"m": true, // merge upstream
+}
+
+// requireGit reports whether git can run, otherwise it explains in the
+// status line why the action is unavailable
+func (a *App) requireGit() bool {
+	if a.gitErr == nil {
+		return true
+	}
+	a.message = a.styles.Error.Render(i18n.Tf("Unavailable: %v. %s", a.gitErr, git.InstallHint()))
+	return false
+}
+
+// noGitNotice is shown after startup when git is missing
+func (a *App) noGitNotice() string {
+	return a.styles.Error.Render(i18n.T("git not found: install and update are disabled; sync downloads only GitHub and GitLab index files"))
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keptLocalMsg reports that an orphaned skill now lives on as a local skill
+type keptLocalMsg struct {
+	name string
+	err  error
+}
+
+// orphanedSkills lists the tracked skills whose repository is no longer
+// configured. They are checked on every refresh, so skills orphaned by
+// editing the config by hand show up too.
+func (a *App) orphanedSkills() map[string]bool {
+	urls := make([]string, 0, len(a.cfg.Repos))
+	for _, repo := range a.cfg.Repos {
+		urls = append(urls, repo.URL)
+	}
+	orphaned := make(map[string]bool)
+	for _, name := range a.manifest.Orphans(urls) {
+		orphaned[name] = true
+	}
+	return orphaned
+}
+
+// selectedOrphan returns the selected skill when it is orphaned
+func (a *App) selectedOrphan() string {
+	if a.skills == nil || a.skills.IsSearching() {
+		return ""
+	}
+	if skill := a.skills.Selected(); skill != nil && a.skills.IsOrphaned(skill.Name) {
+		return skill.Name
+	}
+	return ""
+}
+
+// readdOrphanRepo opens Add Repository filled in with the source of an
+// orphaned skill, so adding it back re-attaches the skill
+func (a *App) readdOrphanRepo(name string) (tea.Model, tea.Cmd) {
+	info, _ := a.manifest.GetInstalled(name)
+	return a.openAddRepo(a.suggestRepoName(info.SourceRepo), info.SourceRepo, "")
+}
+
+// keepLocal turns an orphaned skill into a local skill: its files are
+// copied out of the repository clone and it is no longer tracked
+func (a *App) keepLocal(name string) tea.Cmd {
+	return func() tea.Msg {
+		return keptLocalMsg{name, a.manifest.Untrack(name)}
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/git"
 	"lazyas/internal/manifest"
 	"lazyas/internal/registry"
+	"lazyas/internal/tui/styles"
 )
 
 // Tab represents the current detail tab
@@ -19,6 +21,7 @@ type Tab int
 const (
 	TabInfo Tab = iota
 	TabSkillMD
+	TabDiff // only for skills with local modifications
 )
 
 // DetailPanel displays skill details with tabs
@@ -32,8 +35,20 @@ type DetailPanel struct {
 	focused      bool
 	viewport     viewport.Model
 	infoViewport viewport.Model
+	diffViewport viewport.Model
 	skillMD      string
+	skillsDir    string
+	diff         string // local modifications, loaded when the Diff tab is shown
+	diffErr      string
 	isOutdated   bool
+	duplicate    *registry.SkillEntry // registry entry an untracked skill is a copy of
+	provenance   *git.Provenance      // where the installed commit is upstream; nil until checked
+	signature    *git.Signature       // signature of the installed commit; nil unless verify_signatures
+
+	// Remote SKILL.md preview for skills that are not installed
+	remoteMD      bool
+	remoteLoading bool
+	remoteErr     string
 
 	// Styles
 	styles DetailPanelStyles
@@ -52,46 +67,55 @@ type DetailPanelStyles struct {
 	Badge         lipgloss.Style
 	BadgeModified lipgloss.Style
 	BadgeOutdated lipgloss.Style
+	BadgeConflict lipgloss.Style
+	ActionKey     lipgloss.Style
 }
 
 // DefaultDetailPanelStyles returns the default styles
 func DefaultDetailPanelStyles() DetailPanelStyles {
+	t := styles.Current()
 	return DetailPanelStyles{
 		Title: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#7C3AED")),
+			Foreground(t.Primary),
 		TabActive: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(lipgloss.Color("#7C3AED")).
+			Foreground(t.SelectedText).
+			Background(t.Primary).
 			Padding(0, 1),
 		TabInactive: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")).
+			Foreground(t.Muted).
 			Padding(0, 1),
 		TabBar: lipgloss.NewStyle().
 			BorderBottom(true).
 			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("#374151")),
+			BorderForeground(t.Border),
 		Label: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")).
+			Foreground(t.Muted).
 			Width(12),
 		Value: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")),
+			Foreground(t.Text),
 		Muted: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")),
+			Foreground(t.Muted),
 		Tag: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F59E0B")).
-			Background(lipgloss.Color("#1F2937")).
+			Foreground(t.Warning).
+			Background(t.TagBackground).
 			Padding(0, 1).
 			MarginRight(1),
 		Badge: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#10B981")).
+			Foreground(t.Success).
 			Bold(true),
 		BadgeModified: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F59E0B")).
+			Foreground(t.Warning).
 			Bold(true),
 		BadgeOutdated: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#818CF8")).
+			Foreground(t.Outdated).
+			Bold(true),
+		BadgeConflict: lipgloss.NewStyle().
+			Foreground(t.Danger).
+			Bold(true),
+		ActionKey: lipgloss.NewStyle().
+			Foreground(t.Primary).
 			Bold(true),
 	}
 }
@@ -100,11 +124,13 @@ func DefaultDetailPanelStyles() DetailPanelStyles {
 func NewDetailPanel() *DetailPanel {
 	vp := viewport.New(80, 20)
 	ivp := viewport.New(80, 20)
+	dvp := viewport.New(80, 20)
 	return &DetailPanel{
 		tab:          TabInfo,
 		styles:       DefaultDetailPanelStyles(),
 		viewport:     vp,
 		infoViewport: ivp,
+		diffViewport: dvp,
 		height:       24,
 		width:        60,
 	}
@@ -115,7 +141,14 @@ func (p *DetailPanel) SetSkill(skill *registry.SkillEntry, installed *manifest.I
 	p.skill = skill
 	p.installed = installed
 	p.localInfo = local
+	p.duplicate = nil
 	p.skillMD = ""
+	p.remoteMD = false
+	p.remoteLoading = false
+	p.remoteErr = ""
+	p.skillsDir = skillsDir
+	p.diff = ""
+	p.diffErr = ""
 
 	// Try to load SKILL.md if installed
 	if skill != nil && local != nil {
@@ -130,11 +163,93 @@ func (p *DetailPanel) SetSkill(skill *registry.SkillEntry, installed *manifest.I
 		p.infoViewport.SetContent(p.renderInfo())
 		p.infoViewport.GotoTop()
 	}
+	if p.tab == TabDiff && !p.HasDiff() {
+		p.tab = TabSkillMD
+	}
+	if p.tab == TabSkillMD {
+		p.renderSkillMDContent()
+	}
+	if p.tab == TabDiff {
+		p.loadDiff()
+	}
+}
+
+// HasDiff reports whether the current skill has local modifications, and
+// so a Diff tab
+func (p *DetailPanel) HasDiff() bool {
+	return p.skill != nil && p.localInfo != nil && p.localInfo.IsModified
+}
+
+// lastTab is the rightmost tab for the current skill
+func (p *DetailPanel) lastTab() Tab {
+	if p.HasDiff() {
+		return TabDiff
+	}
+	return TabSkillMD
+}
+
+// loadDiff reads the skill's local modifications into the Diff tab
+func (p *DetailPanel) loadDiff() {
+	diff, err := git.GetDiff(filepath.Join(p.skillsDir, p.skill.Name))
+	if err != nil {
+		p.diffErr = err.Error()
+		return
+	}
+	p.diff = diff
+	p.diffViewport.SetContent(colorDiff(diff))
+	p.diffViewport.GotoTop()
+}
+
+// NeedsRemoteSkillMD reports whether the current skill has no local
+// SKILL.md and should be previewed from its source repository
+func (p *DetailPanel) NeedsRemoteSkillMD() bool {
+	return p.skill != nil && p.localInfo == nil && !p.remoteMD
+}
+
+// SetRemoteLoading marks a remote SKILL.md preview as being fetched
+func (p *DetailPanel) SetRemoteLoading() {
+	p.remoteLoading = true
+}
+
+// SetRemoteSkillMD shows a SKILL.md fetched from the skill's source repo
+func (p *DetailPanel) SetRemoteSkillMD(content string, err error) {
+	p.remoteLoading = false
+	if err != nil {
+		p.remoteErr = err.Error()
+		return
+	}
+	p.remoteMD = true
+	p.remoteErr = ""
+	p.skillMD = content
 	if p.tab == TabSkillMD {
-		p.viewport.SetContent(p.skillMD)
+		p.renderSkillMDContent()
 	}
 }
 
+// ActiveTab returns the tab being shown
+func (p *DetailPanel) ActiveTab() Tab {
+	return p.tab
+}
+
+// SkillMD returns the SKILL.md content being shown and whether it is a
+// remote preview of a skill that is not installed
+func (p *DetailPanel) SkillMD() (string, bool) {
+	return p.skillMD, p.remoteMD
+}
+
+// SetDuplicate marks the untracked skill as a copy of a registry entry
+func (p *DetailPanel) SetDuplicate(entry *registry.SkillEntry) {
+	p.duplicate = entry
+	if p.skill != nil {
+		p.infoViewport.SetContent(p.renderInfo())
+	}
+}
+
+// Duplicate returns the registry entry the untracked skill is a copy of
+func (p *DetailPanel) Duplicate() *registry.SkillEntry {
+	return p.duplicate
+}
+
 // SetOutdated sets whether the current skill has an update available
 func (p *DetailPanel) SetOutdated(outdated bool) {
 	p.isOutdated = outdated
@@ -143,14 +258,40 @@ func (p *DetailPanel) SetOutdated(outdated bool) {
 	}
 }
 
+// SetProvenance sets where the installed commit was found upstream
+func (p *DetailPanel) SetProvenance(provenance *git.Provenance) {
+	p.provenance = provenance
+	if p.skill != nil {
+		p.infoViewport.SetContent(p.renderInfo())
+	}
+}
+
+// SetSignature sets the verified signature of the installed commit
+func (p *DetailPanel) SetSignature(signature *git.Signature) {
+	p.signature = signature
+	if p.skill != nil {
+		p.infoViewport.SetContent(p.renderInfo())
+	}
+}
+
+// SetStyles replaces the panel's styles, e.g. after the theme changed
+func (p *DetailPanel) SetStyles(styles DetailPanelStyles) {
+	p.styles = styles
+}
+
 // SetSize sets the panel dimensions
 func (p *DetailPanel) SetSize(width, height int) {
 	p.width = width
 	p.height = height
 	p.viewport.Width = width - 4
-	p.viewport.Height = height - 8 // Account for tabs and padding
+	p.viewport.Height = height - 9 // Account for tabs, padding and the actions footer
 	p.infoViewport.Width = width - 4
-	p.infoViewport.Height = height - 8
+	p.infoViewport.Height = height - 9
+	p.diffViewport.Width = width - 4
+	p.diffViewport.Height = height - 10 // and the key hint line
+	if p.tab == TabSkillMD && p.skillMD != "" {
+		p.renderSkillMDContent()
+	}
 }
 
 // SetFocused sets whether the panel is focused
@@ -190,13 +331,19 @@ func (p *DetailPanel) Update(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, km.PrevTab):
 			if p.tab > 0 {
 				p.tab--
+				if p.tab == TabSkillMD {
+					p.renderSkillMDContent()
+				}
 			}
 		case key.Matches(msg, km.NextTab):
-			if p.tab < TabSkillMD {
+			if p.tab < p.lastTab() {
 				p.tab++
-				if p.tab == TabSkillMD {
-					p.viewport.SetContent(p.skillMD)
+				switch p.tab {
+				case TabSkillMD:
+					p.renderSkillMDContent()
 					p.viewport.GotoTop()
+				case TabDiff:
+					p.loadDiff()
 				}
 			}
 		case key.Matches(msg, km.Up), key.Matches(msg, km.Down):
@@ -209,6 +356,10 @@ func (p *DetailPanel) Update(msg tea.Msg) tea.Cmd {
 				var cmd tea.Cmd
 				p.viewport, cmd = p.viewport.Update(msg)
 				return cmd
+			case TabDiff:
+				var cmd tea.Cmd
+				p.diffViewport, cmd = p.diffViewport.Update(msg)
+				return cmd
 			}
 		}
 	}
@@ -233,13 +384,72 @@ func (p *DetailPanel) View() string {
 		b.WriteString(p.infoViewport.View())
 	case TabSkillMD:
 		b.WriteString(p.renderSkillMD())
+	case TabDiff:
+		b.WriteString(p.renderDiff())
 	}
 
+	b.WriteString("\n")
+	b.WriteString(p.renderActions())
+
 	return b.String()
 }
 
+// Action is a quick action offered in the panel footer. Its key works
+// while the panel is focused.
+type Action struct {
+	Key   string
+	Label string
+}
+
+// Actions returns the quick actions that apply to the shown skill
+func (p *DetailPanel) Actions() []Action {
+	if p.skill == nil {
+		return nil
+	}
+	var actions []Action
+	if p.localInfo == nil {
+		actions = append(actions, Action{"i", "install"})
+	} else {
+		if p.installed != nil && p.isOutdated {
+			actions = append(actions, Action{"U", "update"})
+		}
+		actions = append(actions, Action{"r", "remove"})
+		if p.HasDiff() {
+			actions = append(actions, Action{"d", "diff"})
+		}
+	}
+	return append(actions, Action{"o", "open"})
+}
+
+// ShowDiff switches to the Diff tab, when the skill has one
+func (p *DetailPanel) ShowDiff() bool {
+	if !p.HasDiff() {
+		return false
+	}
+	p.tab = TabDiff
+	p.loadDiff()
+	return true
+}
+
+// renderActions renders the footer of quick actions, dimmed while the
+// panel is not focused and its keys do not apply
+func (p *DetailPanel) renderActions() string {
+	var items []string
+	for _, action := range p.Actions() {
+		keyStyle := p.styles.ActionKey
+		if !p.focused {
+			keyStyle = p.styles.Muted
+		}
+		items = append(items, keyStyle.Render(action.Key)+" "+p.styles.Muted.Render(action.Label))
+	}
+	return strings.Join(items, "  ")
+}
+
 func (p *DetailPanel) renderTabs() string {
 	tabs := []string{"Info", "SKILL.md"}
+	if p.HasDiff() {
+		tabs = append(tabs, "Diff")
+	}
 	var rendered []string
 
 	for i, tab := range tabs {
@@ -266,7 +476,9 @@ func (p *DetailPanel) renderInfo() string {
 	isUntracked := p.localInfo != nil && p.installed == nil
 	if p.localInfo != nil {
 		b.WriteString("  ")
-		if p.localInfo.IsModified {
+		if p.installed != nil && p.installed.NeedsResolution() {
+			b.WriteString(p.styles.BadgeConflict.Render("⚠ NEEDS RESOLUTION"))
+		} else if p.localInfo.IsModified {
 			b.WriteString(p.styles.BadgeModified.Render("● MODIFIED"))
 		} else if p.isOutdated {
 			b.WriteString(p.styles.BadgeOutdated.Render("↑ UPDATE AVAILABLE"))
@@ -284,10 +496,23 @@ func (p *DetailPanel) renderInfo() string {
 	}
 	b.WriteString("\n")
 
-	// Show hint when both modified AND outdated
-	if p.localInfo != nil && p.localInfo.IsModified && p.isOutdated {
+	if p.installed != nil && p.installed.Orphaned {
+		b.WriteString(p.styles.Muted.Render("  Orphaned: its repository was removed, so it gets no updates"))
+		b.WriteString("\n")
+		b.WriteString(p.styles.Muted.Render("  A: add the repo back  L: keep as local  r: remove"))
+		b.WriteString("\n")
+	}
+
+	if p.installed != nil && p.installed.NeedsResolution() {
+		// Merge left conflict markers behind
+		b.WriteString(p.styles.Muted.Render("  Fix conflict markers in: " + strings.Join(p.installed.Conflicts, ", ")))
+		b.WriteString("\n")
+		b.WriteString(p.styles.Muted.Render("  then run 'lazyas resolve " + p.skill.Name + "'"))
+		b.WriteString("\n")
+	} else if p.localInfo != nil && p.localInfo.IsModified && p.isOutdated {
+		// Show hint when both modified AND outdated
 		b.WriteString(p.styles.BadgeOutdated.Render("  ↑ Update available"))
-		b.WriteString(p.styles.Muted.Render(" (commit or discard local changes first)"))
+		b.WriteString(p.styles.Muted.Render(" (press m to merge with local changes)"))
 		b.WriteString("\n")
 	}
 
@@ -300,7 +525,13 @@ func (p *DetailPanel) renderInfo() string {
 		}
 		b.WriteString(p.styles.Value.Render(loc))
 		b.WriteString("\n")
-		b.WriteString(p.styles.Muted.Render("Not managed by lazyas. Use 'i' to install from registry."))
+		if p.duplicate != nil {
+			b.WriteString(p.styles.BadgeOutdated.Render("Available in registry as " + p.duplicate.InstallRef()))
+			b.WriteString("\n")
+			b.WriteString(p.styles.Muted.Render("Press L to adopt it: replace this copy with the registry skill, keeping the name."))
+		} else {
+			b.WriteString(p.styles.Muted.Render("Not managed by lazyas. Use 'i' to install from registry."))
+		}
 		b.WriteString("\n")
 	} else {
 		// Author
@@ -319,6 +550,17 @@ func (p *DetailPanel) renderInfo() string {
 		b.WriteString(p.styles.Value.Render(repo))
 		b.WriteString("\n")
 
+		// Whether the listing came from an index signed with the repo's key
+		if p.skill.Source.Listed != "" {
+			b.WriteString(p.styles.Label.Render("Index"))
+			if p.skill.Signed() {
+				b.WriteString(p.styles.Badge.Render("✓ verified"))
+			} else {
+				b.WriteString(p.styles.Muted.Render("unverified (" + p.skill.Source.Listed + ")"))
+			}
+			b.WriteString("\n")
+		}
+
 		// Path (if present)
 		if p.skill.Source.Path != "" {
 			b.WriteString(p.styles.Label.Render("Path"))
@@ -334,6 +576,58 @@ func (p *DetailPanel) renderInfo() string {
 		}
 		b.WriteString(p.styles.Value.Render(version))
 		b.WriteString("\n")
+
+		// Update channel (only shown when following a branch or pinned)
+		if p.installed != nil && p.installed.Branch != "" {
+			b.WriteString(p.styles.Label.Render("Tracking"))
+			b.WriteString(p.styles.Value.Render("branch " + p.installed.Branch))
+			b.WriteString("\n")
+		}
+		if p.installed != nil && p.installed.Pin != "" {
+			at := p.installed.Version
+			if at == "" {
+				at = truncate(p.installed.Commit, 7)
+			}
+			b.WriteString(p.styles.Label.Render("Pinned"))
+			b.WriteString(p.styles.Value.Render(p.installed.Pin + " (at " + at + ")"))
+			b.WriteString("\n")
+		}
+
+		// Whether the installed commit still exists upstream
+		if p.installed != nil && p.provenance != nil {
+			b.WriteString(p.styles.Label.Render("Upstream"))
+			switch {
+			case p.provenance.Ref != "":
+				b.WriteString(p.styles.Value.Render(truncate(p.installed.Commit, 7) + " at " + p.provenance.Ref))
+			case p.provenance.Reachable:
+				b.WriteString(p.styles.Value.Render(truncate(p.installed.Commit, 7) + " in history"))
+			default:
+				b.WriteString(p.styles.BadgeConflict.Render("⚠ " + truncate(p.installed.Commit, 7) + " not found upstream"))
+				b.WriteString("\n")
+				b.WriteString(p.styles.Muted.Render("  Force-pushed away or its branch deleted; provenance cannot be verified."))
+			}
+			b.WriteString("\n")
+		}
+
+		// Who signed the installed commit
+		if p.installed != nil && p.signature != nil {
+			b.WriteString(p.styles.Label.Render("Signed by"))
+			switch {
+			case p.signature.Verified():
+				b.WriteString(p.styles.Value.Render("✓ " + p.signature.Identity()))
+			case p.signature.Status == "N":
+				b.WriteString(p.styles.Muted.Render("unsigned"))
+			default:
+				b.WriteString(p.styles.BadgeConflict.Render("⚠ " + p.signature.Describe()))
+			}
+			b.WriteString("\n")
+		}
+
+		// Reproducible install command, copied with y
+		b.WriteString(p.styles.Label.Render("Install"))
+		b.WriteString(p.styles.Value.Render(p.InstallCommand()))
+		b.WriteString(p.styles.Muted.Render("  (y to copy)"))
+		b.WriteString("\n")
 	}
 
 	// Tags
@@ -354,15 +648,65 @@ func (p *DetailPanel) renderInfo() string {
 	return b.String()
 }
 
+// InstallCommand returns the command that installs exactly the displayed
+// skill, or "" when no skill is selected. An installed skill keeps its pin,
+// or the version or commit it is at, rather than the registry's tag.
+func (p *DetailPanel) InstallCommand() string {
+	if p.skill == nil {
+		return ""
+	}
+	ref := p.skill.InstallRef()
+	if p.installed != nil {
+		at := p.installed.Pin
+		if at == "" {
+			at = p.installed.Version
+		}
+		if at == "" {
+			at = p.installed.Commit
+		}
+		if at != "" {
+			ref, _, _ = strings.Cut(ref, "@")
+			ref += "@" + at
+		}
+	}
+	return "lazyas install " + ref
+}
+
+// renderSkillMDContent renders SKILL.md into its viewport at the current
+// width and theme
+func (p *DetailPanel) renderSkillMDContent() {
+	p.viewport.SetContent(renderMarkdown(p.skillMD, p.viewport.Width))
+}
+
 func (p *DetailPanel) renderSkillMD() string {
 	if p.skillMD == "" {
 		if p.localInfo == nil {
+			if p.remoteLoading {
+				return p.styles.Muted.Render("Fetching SKILL.md preview...")
+			}
+			if p.remoteErr != "" {
+				return p.styles.Muted.Render("Preview unavailable: " + p.remoteErr + "\nInstall skill to view SKILL.md")
+			}
 			return p.styles.Muted.Render("Install skill to view SKILL.md")
 		}
 		return p.styles.Muted.Render("SKILL.md not found")
 	}
 
-	return p.viewport.View()
+	if p.remoteMD {
+		return p.styles.Muted.Render("Preview · not installed · o: open in viewer") + "\n" + p.viewport.View()
+	}
+	return p.styles.Muted.Render("o: open in viewer") + "\n" + p.viewport.View()
+}
+
+func (p *DetailPanel) renderDiff() string {
+	hint := p.styles.Muted.Render("x: discard changes  p: keep as patch")
+	if p.diffErr != "" {
+		return hint + "\n" + p.styles.Muted.Render("Diff unavailable: "+p.diffErr)
+	}
+	if p.diff == "" {
+		return hint + "\n" + p.styles.Muted.Render("Only new files changed; git diff does not show them")
+	}
+	return hint + "\n" + p.diffViewport.View()
 }
 
 func truncate(s string, n int) string {
@@ -0,0 +1,31 @@
+package panels
+
+import (
+	"testing"
+
+	"lazyas/internal/manifest"
+	"lazyas/internal/registry"
+)
+
+func TestDetailPanel_InstallCommand(t *testing.T) {
+	skill := &registry.SkillEntry{Name: "pdf", Source: registry.SkillSource{RepoName: "anthropic", Tag: "v2.0.0"}}
+	p := NewDetailPanel()
+	if got := p.InstallCommand(); got != "" {
+		t.Errorf("no skill: InstallCommand() = %q", got)
+	}
+
+	for _, tc := range []struct {
+		installed *manifest.InstalledSkill
+		want      string
+	}{
+		{nil, "lazyas install anthropic/pdf@v2.0.0"},
+		{&manifest.InstalledSkill{Version: "v1.0.0", Commit: "abc1234"}, "lazyas install anthropic/pdf@v1.0.0"},
+		{&manifest.InstalledSkill{Version: "v1.2.3", Pin: "v1.2", Commit: "abc1234"}, "lazyas install anthropic/pdf@v1.2"},
+		{&manifest.InstalledSkill{Commit: "abc1234"}, "lazyas install anthropic/pdf@abc1234"},
+	} {
+		p.SetSkill(skill, tc.installed, nil, t.TempDir())
+		if got := p.InstallCommand(); got != tc.want {
+			t.Errorf("installed %+v: InstallCommand() = %q, want %q", tc.installed, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package panels
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/tui/styles"
+)
+
+// colorDiff colors unified diff output line by line: file headers, hunk
+// headers, added and removed lines
+func colorDiff(diff string) string {
+	t := styles.Current()
+	diffAdded := lipgloss.NewStyle().Foreground(t.Success)
+	diffRemoved := lipgloss.NewStyle().Foreground(t.Danger)
+	diffHunk := lipgloss.NewStyle().Foreground(t.Info)
+	diffHeader := lipgloss.NewStyle().Bold(true).Foreground(t.Warning)
+	diffMeta := lipgloss.NewStyle().Foreground(t.Muted)
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git"):
+			lines[i] = diffHeader.Render(line)
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+			strings.HasPrefix(line, "index "), strings.HasPrefix(line, "new file"),
+			strings.HasPrefix(line, "deleted file"), strings.HasPrefix(line, "similarity"),
+			strings.HasPrefix(line, "rename "):
+			lines[i] = diffMeta.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = diffHunk.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = diffAdded.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = diffRemoved.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
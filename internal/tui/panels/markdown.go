@@ -0,0 +1,191 @@
+package panels
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"lazyas/internal/tui/styles"
+)
+
+var (
+	mdHeading    = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	mdRule       = regexp.MustCompile(`^\s{0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+	mdListItem   = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdBlockquote = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	mdInline     = regexp.MustCompile("`([^`]+)`|\\*\\*([^*]+)\\*\\*|__([^_]+)__|\\*([^*\\s][^*]*)\\*|\\[([^\\]]+)\\]\\(([^)\\s]+)\\)")
+)
+
+// markdownStyles are the styles SKILL.md is rendered with, taken from the
+// current theme
+type markdownStyles struct {
+	heading, subheading, code, link, muted, bold, italic lipgloss.Style
+}
+
+func newMarkdownStyles() markdownStyles {
+	t := styles.Current()
+	return markdownStyles{
+		heading:    lipgloss.NewStyle().Bold(true).Foreground(t.Primary),
+		subheading: lipgloss.NewStyle().Bold(true).Foreground(t.Text),
+		code:       lipgloss.NewStyle().Foreground(t.Info),
+		link:       lipgloss.NewStyle().Underline(true).Foreground(t.Primary),
+		muted:      lipgloss.NewStyle().Foreground(t.Muted),
+		bold:       lipgloss.NewStyle().Bold(true),
+		italic:     lipgloss.NewStyle().Italic(true),
+	}
+}
+
+// renderMarkdown renders a SKILL.md for the terminal, wrapped to width:
+// the frontmatter dimmed, headings, lists, quotes, rules and code blocks
+// set apart, and inline code, emphasis and links styled. It covers what
+// skill files use rather than all of CommonMark; anything else is shown
+// as plain text.
+func renderMarkdown(src string, width int) string {
+	s := newMarkdownStyles()
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var out []string
+	blank := func() {
+		if len(out) > 0 && out[len(out)-1] != "" {
+			out = append(out, "")
+		}
+	}
+
+	// Frontmatter
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				for _, line := range lines[1:i] {
+					out = append(out, s.muted.Render(clip(line, width)))
+				}
+				lines = lines[i+1:]
+				blank()
+				break
+			}
+		}
+	}
+
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			out = append(out, wrapIndented(s.inline(strings.Join(para, " ")), width, "", ""))
+			para = nil
+		}
+	}
+
+	fence := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+				blank()
+				continue
+			}
+			out = append(out, "  "+s.code.Render(clip(strings.ReplaceAll(line, "\t", "    "), width-2)))
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+			fence = trimmed[:3]
+			continue
+		}
+		if trimmed == "" {
+			flush()
+			blank()
+			continue
+		}
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			flush()
+			blank()
+			style := s.subheading
+			if len(m[1]) <= 2 {
+				style = s.heading
+			}
+			out = append(out, wrapIndented(style.Render(m[2]), width, "", ""))
+			continue
+		}
+		if mdRule.MatchString(line) {
+			flush()
+			out = append(out, s.muted.Render(strings.Repeat("─", max(width, 3))))
+			continue
+		}
+		if m := mdListItem.FindStringSubmatch(line); m != nil {
+			flush()
+			indent := strings.Repeat("  ", len(strings.ReplaceAll(m[1], "\t", "  "))/2)
+			marker := "• "
+			if m[2][0] >= '0' && m[2][0] <= '9' {
+				marker = m[2] + " "
+			}
+			out = append(out, wrapIndented(s.inline(m[3]), width, indent+marker, indent+strings.Repeat(" ", len(marker))))
+			continue
+		}
+		if m := mdBlockquote.FindStringSubmatch(line); m != nil {
+			flush()
+			bar := s.muted.Render("│ ")
+			out = append(out, wrapIndented(s.inline(m[1]), width, bar, bar))
+			continue
+		}
+		if strings.HasPrefix(trimmed, "|") {
+			// Tables keep their layout
+			flush()
+			out = append(out, clip(line, width))
+			continue
+		}
+		para = append(para, trimmed)
+	}
+	flush()
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+// inline styles code spans, strong and emphasized text and links
+func (s markdownStyles) inline(text string) string {
+	return mdInline.ReplaceAllStringFunc(text, func(match string) string {
+		m := mdInline.FindStringSubmatch(match)
+		switch {
+		case m[1] != "":
+			return s.code.Render(m[1])
+		case m[2] != "":
+			return s.bold.Render(m[2])
+		case m[3] != "":
+			return s.bold.Render(m[3])
+		case m[4] != "":
+			return s.italic.Render(m[4])
+		case m[5] == m[6]:
+			return s.link.Render(m[5])
+		default:
+			return s.link.Render(m[5]) + " " + s.muted.Render("("+m[6]+")")
+		}
+	})
+}
+
+// wrapIndented wraps text to width, starting the first line with first
+// and the others with rest
+func wrapIndented(text string, width int, first, rest string) string {
+	if width <= 0 {
+		return first + text
+	}
+	limit := max(width-max(ansi.StringWidth(first), ansi.StringWidth(rest)), 10)
+	lines := strings.Split(ansi.Wrap(text, limit, ""), "\n")
+	for i, line := range lines {
+		if i == 0 {
+			lines[i] = first + line
+		} else {
+			lines[i] = rest + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// clip cuts a line that is not wrapped, like code, to width
+func clip(line string, width int) string {
+	if width <= 0 {
+		return line
+	}
+	return ansi.Truncate(line, width, "…")
+}
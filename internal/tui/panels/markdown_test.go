@@ -0,0 +1,54 @@
+package panels
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	src := strings.Join([]string{
+		"---",
+		"name: pdf",
+		"description: Work with PDF files",
+		"---",
+		"",
+		"# PDF tools",
+		"",
+		"Use **pypdf** to merge files and `pdftotext` to extract text from documents that are long enough to wrap.",
+		"",
+		"- merge",
+		"  - split",
+		"1. See [the docs](https://example.com/docs)",
+		"",
+		"> Keep the originals",
+		"",
+		"```python",
+		"import pypdf  # a comment long enough to be cut at the panel width",
+		"```",
+		"***",
+	}, "\n")
+
+	got := ansi.Strip(renderMarkdown(src, 40))
+	lines := strings.Split(got, "\n")
+	for _, line := range lines {
+		if w := ansi.StringWidth(line); w > 40 {
+			t.Errorf("Expected lines within 40 columns, got %d: %q", w, line)
+		}
+	}
+	for _, want := range []string{
+		"name: pdf\ndescription: Work with PDF files\n\nPDF tools\n\nUse pypdf to merge files and pdftotext",
+		"\n• merge\n  • split\n1. See the docs\n   (https://example.com/docs)\n",
+		"\n│ Keep the originals\n",
+		"\n  import pypdf  # a comment long enough…\n",
+		"\n" + strings.Repeat("─", 40),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "```") || strings.Contains(got, "**") || strings.Contains(got, "---") {
+		t.Errorf("Expected markdown syntax rendered away, got:\n%s", got)
+	}
+}
@@ -2,14 +2,17 @@ package panels
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"lazyas/internal/registry"
+	"lazyas/internal/tui/styles"
 )
 
 // ListItemType indicates whether a list item is a skill or a group header
@@ -25,7 +28,9 @@ type ListItem struct {
 	Type       ListItemType
 	Skill      *registry.SkillEntry
 	HeaderName string
-	RepoURL    string // Original repo URL (for headers)
+	RepoURL    string    // Original repo URL (for headers)
+	RepoName   string    // Configured repository listing the group (for headers)
+	SyncedAt   time.Time // Last successful fetch of the repo (for headers)
 	Collapsed  bool
 	SkillCount int
 }
@@ -33,34 +38,48 @@ type ListItem struct {
 // SkillGroup represents a group of skills
 type SkillGroup struct {
 	Name      string
-	RepoURL   string // Original repo URL (empty for "Installed" group)
+	RepoURL   string    // Original repo URL (empty for "Installed" group)
+	RepoName  string    // Configured repository listing the skills (empty for "Installed" group)
+	SyncedAt  time.Time // Last successful fetch of the repo
 	Skills    []registry.SkillEntry
 	Collapsed bool
 }
 
 // SkillsPanel displays skills in a grouped list
 type SkillsPanel struct {
-	skills      []registry.SkillEntry
-	groups      []SkillGroup
-	flatItems   []ListItem
-	installed   map[string]string
-	modified    map[string]bool
-	localOnly   map[string]bool // On disk but not tracked in manifest
-	outdated    map[string]bool
-	cursor      int
-	height      int
-	width       int
-	offset      int
-	collapseMap map[string]bool
-	focused     bool
+	skills       []registry.SkillEntry
+	groups       []SkillGroup
+	flatItems    []ListItem
+	installed    map[string]string
+	modified     map[string]bool
+	localOnly    map[string]bool // On disk but not tracked in manifest
+	outdated     map[string]bool
+	unreachable  map[string]bool       // installed commit no longer exists upstream
+	orphaned     map[string]bool       // tracked skills whose repository is no longer configured
+	verified     map[string]bool       // installed skills whose commit has a good signature
+	verifiedOnly bool                  // hide skills without a verified signature
+	tagFilter    []string              // show only skills with every one of these tags, lowercase
+	repoFilter   string                // show only skills listed by this configured repository
+	synced       map[string]time.Time  // repo URL -> last successful fetch
+	marked       []registry.SkillEntry // marked for a batch action, in marking order
+	enabled      map[string][]string   // installed skill -> backends that see it; nil hides the column
+	cursor       int
+	height       int
+	width        int
+	offset       int
+	collapseMap  map[string]bool
+	focused      bool
 
 	// Search
-	searchInput textinput.Model
-	searching   bool
-	query       string
+	searchInput   textinput.Model
+	searching     bool
+	query         string
+	contentInput  bool // ctrl+f while typing: the query will search SKILL.md text
+	contentSearch bool // the query searched SKILL.md text; skills are in rank order
 
 	// Styles
-	styles SkillsPanelStyles
+	styles     SkillsPanelStyles
+	precedence []string // Order in which states win, nil for the default
 }
 
 // SkillsPanelStyles holds the panel styles
@@ -71,6 +90,9 @@ type SkillsPanelStyles struct {
 	StatusAvailable      lipgloss.Style
 	StatusOutdated       lipgloss.Style
 	StatusModified       lipgloss.Style
+	StatusUnreachable    lipgloss.Style
+	StatusOrphaned       lipgloss.Style
+	Marked               lipgloss.Style
 	SelectedItem         lipgloss.Style
 	NormalItem           lipgloss.Style
 	GroupHeader          lipgloss.Style
@@ -81,41 +103,52 @@ type SkillsPanelStyles struct {
 
 // DefaultSkillsPanelStyles returns the default styles
 func DefaultSkillsPanelStyles() SkillsPanelStyles {
+	t := styles.Current()
 	return SkillsPanelStyles{
 		Title: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#7C3AED")),
+			Foreground(t.Primary),
 		StatusInstalled: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#10B981")).
+			Foreground(t.Success).
 			SetString("●"),
 		StatusLocal: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#38BDF8")).
+			Foreground(t.Info).
 			SetString("●"),
 		StatusAvailable: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")).
+			Foreground(t.Muted).
 			SetString("○"),
 		StatusOutdated: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#818CF8")).
+			Foreground(t.Outdated).
 			SetString("↑"),
 		StatusModified: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F59E0B")).
+			Foreground(t.Warning).
 			SetString("◉"),
+		StatusUnreachable: lipgloss.NewStyle().
+			Foreground(t.Danger).
+			SetString("!"),
+		StatusOrphaned: lipgloss.NewStyle().
+			Foreground(t.Warning).
+			SetString("?"),
+		Marked: lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Bold(true).
+			SetString("✓"),
 		SelectedItem: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(lipgloss.Color("#7C3AED")),
+			Foreground(t.SelectedText).
+			Background(t.Primary),
 		NormalItem: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")),
+			Foreground(t.Text),
 		GroupHeader: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#6B7280")),
+			Foreground(t.Muted),
 		GroupHeaderInstalled: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#10B981")),
+			Foreground(t.Success),
 		Muted: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")),
+			Foreground(t.Muted),
 		SearchPrompt: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7C3AED")).
+			Foreground(t.Primary).
 			Bold(true),
 	}
 }
@@ -145,12 +178,38 @@ func NewSkillsPanel(skills []registry.SkillEntry, installed map[string]string, m
 func (p *SkillsPanel) buildGroups() {
 	p.groups = nil
 
-	var installedSkills []registry.SkillEntry
+	// Content matches keep their ranking instead of being grouped
+	if p.contentSearch && p.query != "" {
+		var matches []registry.SkillEntry
+		for _, skill := range p.skills {
+			if !p.passesFilters(skill) {
+				continue
+			}
+			matches = append(matches, skill)
+		}
+		if len(matches) > 0 {
+			p.groups = append(p.groups, SkillGroup{
+				Name:      "Matches",
+				Skills:    matches,
+				Collapsed: p.collapseMap["Matches"],
+			})
+		}
+		return
+	}
+
+	var installedSkills, orphanedSkills []registry.SkillEntry
 	repoGroups := make(map[string][]registry.SkillEntry)
 
 	for _, skill := range p.skills {
+		if !p.passesFilters(skill) {
+			continue
+		}
 		if p.isInstalled(skill) {
-			installedSkills = append(installedSkills, skill)
+			if p.orphaned[skill.Name] {
+				orphanedSkills = append(orphanedSkills, skill)
+			} else {
+				installedSkills = append(installedSkills, skill)
+			}
 		}
 		// Add to repo group (so installed skills also appear under their repo)
 		// Skip skills whose "repo" is a local filesystem path, not a real URL
@@ -164,6 +223,9 @@ func (p *SkillsPanel) buildGroups() {
 	sort.Slice(installedSkills, func(i, j int) bool {
 		return installedSkills[i].Name < installedSkills[j].Name
 	})
+	sort.Slice(orphanedSkills, func(i, j int) bool {
+		return orphanedSkills[i].Name < orphanedSkills[j].Name
+	})
 
 	// Add Installed group first (if any)
 	if len(installedSkills) > 0 {
@@ -174,6 +236,15 @@ func (p *SkillsPanel) buildGroups() {
 		})
 	}
 
+	// Skills whose repository was removed come next, so they are not lost
+	if len(orphanedSkills) > 0 {
+		p.groups = append(p.groups, SkillGroup{
+			Name:      "Orphaned",
+			Skills:    orphanedSkills,
+			Collapsed: p.collapseMap["Orphaned"],
+		})
+	}
+
 	// Sort repo names
 	var repos []string
 	for repo := range repoGroups {
@@ -191,6 +262,8 @@ func (p *SkillsPanel) buildGroups() {
 		p.groups = append(p.groups, SkillGroup{
 			Name:      displayName,
 			RepoURL:   repo,
+			RepoName:  skills[0].Source.RepoName,
+			SyncedAt:  p.synced[repo],
 			Skills:    skills,
 			Collapsed: p.collapseMap[displayName],
 		})
@@ -217,6 +290,8 @@ func (p *SkillsPanel) rebuildFlatList() {
 			Type:       ItemTypeHeader,
 			HeaderName: group.Name,
 			RepoURL:    group.RepoURL,
+			RepoName:   group.RepoName,
+			SyncedAt:   group.SyncedAt,
 			Collapsed:  group.Collapsed,
 			SkillCount: len(group.Skills),
 		})
@@ -247,6 +322,11 @@ func (p *SkillsPanel) adjustCursor() {
 	}
 }
 
+// SetStyles replaces the panel's styles, e.g. after the theme changed
+func (p *SkillsPanel) SetStyles(styles SkillsPanelStyles) {
+	p.styles = styles
+}
+
 // SetSize sets the panel dimensions
 func (p *SkillsPanel) SetSize(width, height int) {
 	p.width = width
@@ -263,6 +343,11 @@ func (p *SkillsPanel) IsFocused() bool {
 	return p.focused
 }
 
+// Groups returns the groups as listed, collapsed ones included
+func (p *SkillsPanel) Groups() []SkillGroup {
+	return p.groups
+}
+
 // SetSkills updates the skills list
 func (p *SkillsPanel) SetSkills(skills []registry.SkillEntry) {
 	p.skills = skills
@@ -298,6 +383,13 @@ func (p *SkillsPanel) isInstalled(skill registry.SkillEntry) bool {
 	return repo == "" || repo == skill.Source.Repo
 }
 
+// SetSynced updates the last sync time per repo URL shown on group headers
+func (p *SkillsPanel) SetSynced(synced map[string]time.Time) {
+	p.synced = synced
+	p.buildGroups()
+	p.rebuildFlatList()
+}
+
 // SetModified updates the modified map
 func (p *SkillsPanel) SetModified(modified map[string]bool) {
 	p.modified = modified
@@ -313,6 +405,119 @@ func (p *SkillsPanel) SetOutdated(outdated map[string]bool) {
 	p.outdated = outdated
 }
 
+// SetOrphaned moves tracked skills whose repository is no longer configured
+// into an "Orphaned" group
+func (p *SkillsPanel) SetOrphaned(orphaned map[string]bool) {
+	p.orphaned = orphaned
+	p.buildGroups()
+	p.rebuildFlatList()
+}
+
+// IsOrphaned reports whether a skill's repository is no longer configured
+func (p *SkillsPanel) IsOrphaned(name string) bool {
+	return p.orphaned[name]
+}
+
+// SetVerified marks installed skills whose commit has a good signature
+func (p *SkillsPanel) SetVerified(verified map[string]bool) {
+	p.verified = verified
+	if p.verifiedOnly {
+		p.buildGroups()
+		p.rebuildFlatList()
+	}
+}
+
+// SetVerifiedOnly hides, or shows again, the skills without a verified
+// signature
+func (p *SkillsPanel) SetVerifiedOnly(on bool) {
+	p.verifiedOnly = on
+	p.buildGroups()
+	p.rebuildFlatList()
+}
+
+// VerifiedOnly reports whether only verified skills are shown
+func (p *SkillsPanel) VerifiedOnly() bool {
+	return p.verifiedOnly
+}
+
+// SetTagFilter shows only the skills that have all of tags; none shows
+// every skill again. Tags match regardless of case.
+func (p *SkillsPanel) SetTagFilter(tags []string) {
+	p.tagFilter = nil
+	for _, tag := range tags {
+		p.tagFilter = append(p.tagFilter, strings.ToLower(tag))
+	}
+	p.buildGroups()
+	p.rebuildFlatList()
+}
+
+// TagFilter returns the tags skills must have to be shown
+func (p *SkillsPanel) TagFilter() []string {
+	return p.tagFilter
+}
+
+// SetRepoFilter shows only the skills listed by the configured repository
+// name; "" shows the skills of every repository again
+func (p *SkillsPanel) SetRepoFilter(name string) {
+	p.repoFilter = name
+	p.buildGroups()
+	p.rebuildFlatList()
+}
+
+// RepoFilter returns the repository the list is scoped to, or ""
+func (p *SkillsPanel) RepoFilter() string {
+	return p.repoFilter
+}
+
+// passesFilters reports whether the verified, tag and repository filters
+// let skill through
+func (p *SkillsPanel) passesFilters(skill registry.SkillEntry) bool {
+	if p.verifiedOnly && !(p.isInstalled(skill) && p.verified[skill.Name]) {
+		return false
+	}
+	if p.repoFilter != "" && skill.Source.RepoName != p.repoFilter {
+		return false
+	}
+	for _, want := range p.tagFilter {
+		if !slices.ContainsFunc(skill.Tags, func(tag string) bool { return strings.EqualFold(tag, want) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetUnreachable marks skills whose installed commit is gone upstream
+func (p *SkillsPanel) SetUnreachable(unreachable map[string]bool) {
+	p.unreachable = unreachable
+}
+
+// SetEnabled sets the backends each installed skill is enabled for, shown
+// as a column; nil hides it
+func (p *SkillsPanel) SetEnabled(enabled map[string][]string) {
+	p.enabled = enabled
+}
+
+// backendsColumn returns the backends column text of a skill, or ""
+func (p *SkillsPanel) backendsColumn(skill registry.SkillEntry) string {
+	if p.enabled == nil || !p.isInstalled(skill) {
+		return ""
+	}
+	if backends := p.enabled[skill.Name]; len(backends) > 0 {
+		return strings.Join(backends, " ")
+	}
+	return "-"
+}
+
+// withColumn right-aligns column after a row of visible width used, when
+// it fits
+func (p *SkillsPanel) withColumn(line string, used int, column string) string {
+	pad := p.width - used - lipgloss.Width(column) - 1
+	if column == "" || pad < 1 {
+		return line
+	}
+	return line + strings.Repeat(" ", pad) + column
+}
+
 // Selected returns the currently selected skill
 func (p *SkillsPanel) Selected() *registry.SkillEntry {
 	if len(p.flatItems) == 0 || p.cursor >= len(p.flatItems) {
@@ -337,6 +542,53 @@ func (p *SkillsPanel) SelectedHeader() *ListItem {
 	return nil
 }
 
+// ToggleMark marks the selected skill for a batch action, or unmarks it.
+// It reports false when the cursor is not on a skill.
+func (p *SkillsPanel) ToggleMark() bool {
+	skill := p.Selected()
+	if skill == nil {
+		return false
+	}
+	for i, m := range p.marked {
+		if m.Name == skill.Name {
+			p.marked = append(p.marked[:i], p.marked[i+1:]...)
+			return true
+		}
+	}
+	p.marked = append(p.marked, *skill)
+	return true
+}
+
+// Marked returns the marked skills in the order they were marked. Marks
+// survive searches, so skills hidden by a filter stay marked.
+func (p *SkillsPanel) Marked() []registry.SkillEntry {
+	return append([]registry.SkillEntry(nil), p.marked...)
+}
+
+// SetMarked replaces the marked skills
+func (p *SkillsPanel) SetMarked(marked []registry.SkillEntry) {
+	p.marked = marked
+}
+
+// ClearMarks unmarks every skill
+func (p *SkillsPanel) ClearMarks() {
+	p.marked = nil
+}
+
+func (p *SkillsPanel) isMarked(name string) bool {
+	for _, m := range p.marked {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether the panel has no skills at all, ignoring any search
+func (p *SkillsPanel) Empty() bool {
+	return len(p.skills) == 0
+}
+
 // IsSearching returns whether the panel is in search mode
 func (p *SkillsPanel) IsSearching() bool {
 	return p.searching
@@ -414,6 +666,7 @@ func (p *SkillsPanel) Update(msg tea.Msg) tea.Cmd {
 			p.toggleCurrentGroup()
 		case msg.String() == "/":
 			p.searching = true
+			p.contentInput = p.contentSearch
 			p.searchInput.Focus()
 			return textinput.Blink
 		}
@@ -428,10 +681,15 @@ func (p *SkillsPanel) handleSearchInput(msg tea.Msg) tea.Cmd {
 		case "enter":
 			p.searching = false
 			p.query = p.searchInput.Value()
+			p.contentSearch = p.contentInput
 			return nil
 		case "esc":
 			p.searching = false
 			p.searchInput.SetValue(p.query)
+			p.contentInput = p.contentSearch
+			return nil
+		case "ctrl+f":
+			p.contentInput = !p.contentInput
 			return nil
 		}
 	}
@@ -446,10 +704,27 @@ func (p *SkillsPanel) GetQuery() string {
 	return p.query
 }
 
+// ContentSearch reports whether the query searches SKILL.md text. The
+// skills set for such a query are shown in the order given, best first.
+func (p *SkillsPanel) ContentSearch() bool {
+	return p.contentSearch
+}
+
+// SetSearch restores a submitted query; content searches SKILL.md text.
+// The caller sets the matching skills.
+func (p *SkillsPanel) SetSearch(query string, content bool) {
+	p.query = query
+	p.searchInput.SetValue(query)
+	p.contentSearch = content
+	p.contentInput = content
+}
+
 // ClearSearch clears the search
 func (p *SkillsPanel) ClearSearch() {
 	p.query = ""
 	p.searchInput.SetValue("")
+	p.contentSearch = false
+	p.contentInput = false
 }
 
 func (p *SkillsPanel) moveUp() {
@@ -550,11 +825,31 @@ func (p *SkillsPanel) View() string {
 
 	// Search bar
 	if p.searching {
-		b.WriteString(p.styles.SearchPrompt.Render("/") + " ")
+		prompt := "/"
+		if p.contentInput {
+			prompt = "content /"
+		}
+		b.WriteString(p.styles.SearchPrompt.Render(prompt) + " ")
 		b.WriteString(p.searchInput.View())
 		b.WriteString("\n")
 	} else if p.query != "" {
-		b.WriteString(p.styles.Muted.Render("Search: " + p.query))
+		label := "Search: "
+		if p.contentSearch {
+			label = "Content: "
+		}
+		b.WriteString(p.styles.Muted.Render(label + p.query))
+		b.WriteString("\n")
+	}
+	if p.verifiedOnly {
+		b.WriteString(p.styles.Muted.Render("Filter: verified signatures"))
+		b.WriteString("\n")
+	}
+	if len(p.tagFilter) > 0 {
+		b.WriteString(p.styles.Muted.Render("Tags: " + strings.Join(p.tagFilter, " + ")))
+		b.WriteString("\n")
+	}
+	if p.repoFilter != "" {
+		b.WriteString(p.styles.Muted.Render("Repo: " + p.repoFilter))
 		b.WriteString("\n")
 	}
 
@@ -567,6 +862,15 @@ func (p *SkillsPanel) View() string {
 	if p.searching || p.query != "" {
 		visibleHeight--
 	}
+	if p.verifiedOnly {
+		visibleHeight--
+	}
+	if len(p.tagFilter) > 0 {
+		visibleHeight--
+	}
+	if p.repoFilter != "" {
+		visibleHeight--
+	}
 
 	end := p.offset + visibleHeight
 	if end > len(p.flatItems) {
@@ -600,8 +904,14 @@ func (p *SkillsPanel) renderHeader(item ListItem, selected bool) string {
 
 	headerText := fmt.Sprintf("%s %s (%d)", indicator, item.HeaderName, item.SkillCount)
 
-	// Truncate if too wide
+	// Truncate if too wide; the sync age is dropped first
 	maxWidth := p.width - 2
+	if !item.SyncedAt.IsZero() {
+		synced := fmt.Sprintf("  synced %s", registry.FormatAge(item.SyncedAt, time.Now()))
+		if len(headerText)+len(synced) <= maxWidth {
+			headerText += synced
+		}
+	}
 	if len(headerText) > maxWidth {
 		headerText = headerText[:maxWidth-3] + "..."
 	}
@@ -630,24 +940,18 @@ func (p *SkillsPanel) renderSkill(skill *registry.SkillEntry, selected bool) str
 		name = name[:maxWidth-3] + "..."
 	}
 
-	isInst := p.isInstalled(*skill)
+	state := p.StateOf(*skill)
+	style := p.styles.statusStyle(state)
+	marked := p.isMarked(skill.Name)
 	if selected && p.focused {
 		// Use plain status chars to avoid ANSI conflicts with highlight
-		var statusChar string
-		if isInst {
-			if p.modified[skill.Name] {
-				statusChar = "◉"
-			} else if p.outdated[skill.Name] {
-				statusChar = "↑"
-			} else if p.localOnly[skill.Name] {
-				statusChar = "●"
-			} else {
-				statusChar = "●"
-			}
-		} else {
-			statusChar = "○"
+		statusChar := style.Value()
+		mark := " "
+		if marked {
+			mark = "✓"
 		}
-		line := fmt.Sprintf("  %s %s", statusChar, name)
+		line := fmt.Sprintf(" %s%s %s", mark, statusChar, name)
+		line = p.withColumn(line, 4+len(name), p.backendsColumn(*skill))
 		// Pad to full width for full-line highlight
 		if len(line) < p.width {
 			line = line + strings.Repeat(" ", p.width-len(line))
@@ -655,21 +959,15 @@ func (p *SkillsPanel) renderSkill(skill *registry.SkillEntry, selected bool) str
 		return p.styles.SelectedItem.Render(line)
 	}
 
-	var status string
-	if isInst {
-		if p.modified[skill.Name] {
-			status = p.styles.StatusModified.String()
-		} else if p.outdated[skill.Name] {
-			status = p.styles.StatusOutdated.String()
-		} else if p.localOnly[skill.Name] {
-			status = p.styles.StatusLocal.String()
-		} else {
-			status = p.styles.StatusInstalled.String()
-		}
-	} else {
-		status = p.styles.StatusAvailable.String()
-	}
+	status := style.String()
 
-	line := fmt.Sprintf("  %s %s", status, name)
+	mark := " "
+	if marked {
+		mark = p.styles.Marked.String()
+	}
+	line := fmt.Sprintf(" %s%s %s", mark, status, name)
+	if column := p.backendsColumn(*skill); column != "" {
+		line = p.withColumn(line, 4+len(name), p.styles.Muted.Render(column))
+	}
 	return p.styles.NormalItem.Render(line)
 }
@@ -272,3 +272,188 @@ func TestSkillsPanel_PageNav_EmptyList(t *testing.T) {
 		t.Errorf("empty list: expected cursor=0, got %d", p.cursor)
 	}
 }
+
+func TestSkillsPanel_ToggleMark(t *testing.T) {
+	p := NewSkillsPanel(makeSkills(4), map[string]string{}, map[string]bool{})
+	p.SetSize(60, 20)
+
+	if p.ToggleMark() {
+		t.Fatal("expected no mark with the cursor on a group header")
+	}
+	p.moveDown()
+	first := p.Selected().Name
+	p.ToggleMark()
+	p.moveDown()
+	second := p.Selected().Name
+	p.ToggleMark()
+
+	marked := p.Marked()
+	if len(marked) != 2 || marked[0].Name != first || marked[1].Name != second {
+		t.Fatalf("Marked() = %v, want [%s %s]", marked, first, second)
+	}
+	if !strings.Contains(p.View(), "✓") {
+		t.Error("expected marked skills to show a check mark")
+	}
+
+	p.ToggleMark()
+	if marked := p.Marked(); len(marked) != 1 || marked[0].Name != first {
+		t.Errorf("expected a second toggle to unmark %s, got %v", second, marked)
+	}
+	p.ClearMarks()
+	if len(p.Marked()) != 0 {
+		t.Error("expected ClearMarks to unmark every skill")
+	}
+}
+
+func TestSkillsPanel_EnabledBackendsColumn(t *testing.T) {
+	skills := makeSkills(4)
+	installed := map[string]string{
+		skills[0].Name: skills[0].Source.Repo,
+		skills[2].Name: skills[2].Source.Repo,
+	}
+	p := NewSkillsPanel(skills, installed, map[string]bool{})
+	p.SetSize(60, 20)
+
+	if strings.Contains(p.View(), "claude") {
+		t.Fatal("expected no backends column before SetEnabled")
+	}
+
+	p.SetEnabled(map[string][]string{skills[0].Name: {"claude", "codex"}})
+	view := p.View()
+	rows := make(map[string]string)
+	for _, line := range strings.Split(view, "\n") {
+		for _, s := range skills {
+			if strings.Contains(line, s.Name) {
+				rows[s.Name] = strings.TrimRight(line, " ")
+			}
+		}
+	}
+	if !strings.HasSuffix(rows[skills[0].Name], "claude codex") {
+		t.Errorf("expected %s to list its backends, got %q", skills[0].Name, rows[skills[0].Name])
+	}
+	if !strings.HasSuffix(rows[skills[2].Name], " -") {
+		t.Errorf("expected %s to show no backends, got %q", skills[2].Name, rows[skills[2].Name])
+	}
+	if !strings.HasSuffix(rows[skills[1].Name], skills[1].Name) {
+		t.Errorf("expected no column for a skill that is not installed, got %q", rows[skills[1].Name])
+	}
+}
+
+func TestSkillsPanel_StatusDisplay(t *testing.T) {
+	skills := makeSkills(2)
+	name := skills[0].Name
+	p := NewSkillsPanel(skills, map[string]string{name: skills[0].Source.Repo}, map[string]bool{})
+	p.SetOutdated(map[string]bool{name: true})
+	p.SetLocalOnly(map[string]bool{name: true})
+
+	if got := p.StateOf(skills[0]); got != StateOutdated {
+		t.Fatalf("expected outdated by default, got %s", got)
+	}
+	if got := p.StateOf(skills[1]); got != StateAvailable {
+		t.Fatalf("expected available for a skill that is not installed, got %s", got)
+	}
+
+	if err := p.SetStatusDisplay(map[string]string{StateLocal: "L"}, []string{StateLocal}); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.StateOf(skills[0]); got != StateLocal {
+		t.Errorf("expected local to win once listed first, got %s", got)
+	}
+	if got := p.StatePrecedence(); got[0] != StateLocal || len(got) != len(DefaultStatePrecedence) {
+		t.Errorf("expected the remaining states appended, got %v", got)
+	}
+	p.SetSize(60, 20)
+	if !strings.Contains(p.View(), p.StatusIcon(StateLocal)+" "+name) {
+		t.Errorf("expected the custom icon in the list, got:\n%s", p.View())
+	}
+
+	for _, bad := range []struct {
+		icons      map[string]string
+		precedence []string
+	}{
+		{map[string]string{"stale": "s"}, nil},
+		{map[string]string{StateLocal: "LL"}, nil},
+		{map[string]string{StateLocal: ""}, nil},
+		{nil, []string{StateAvailable}},
+		{nil, []string{StateLocal, StateLocal}},
+	} {
+		if err := p.SetStatusDisplay(bad.icons, bad.precedence); err == nil {
+			t.Errorf("SetStatusDisplay(%v, %v) should fail", bad.icons, bad.precedence)
+		}
+	}
+	if got := p.StateOf(skills[0]); got != StateLocal {
+		t.Errorf("expected an invalid setting to leave the display unchanged, got %s", got)
+	}
+}
+
+func TestSkillsPanel_ContentSearchKeepsRanking(t *testing.T) {
+	skills := makeSkills(3)
+	p := NewSkillsPanel(skills, map[string]string{}, map[string]bool{})
+	p.SetSize(60, 20)
+
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	p.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pdf")})
+	if p.ContentSearch() {
+		t.Fatal("expected content search to apply only on enter")
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !p.ContentSearch() {
+		t.Fatal("expected ctrl+f to switch the search to SKILL.md text")
+	}
+
+	ranked := []registry.SkillEntry{skills[2], skills[0]}
+	p.SetSkills(ranked)
+	p.moveDown()
+	if got := p.Selected(); got == nil || got.Name != skills[2].Name {
+		t.Fatalf("expected the best match first, got %v", got)
+	}
+	p.moveDown()
+	if got := p.Selected(); got == nil || got.Name != skills[0].Name {
+		t.Fatalf("expected rank order to be kept, got %v", got)
+	}
+
+	p.ClearSearch()
+	if p.ContentSearch() {
+		t.Error("expected ClearSearch to leave content search")
+	}
+}
+
+func TestSkillsPanel_TagFilter(t *testing.T) {
+	skills := makeSkills(4)
+	skills[0].Tags = []string{"pdf", "forms"}
+	skills[1].Tags = []string{"PDF"}
+	skills[2].Tags = []string{"forms"}
+	p := NewSkillsPanel(skills, map[string]string{}, map[string]bool{})
+	p.SetSize(60, 20)
+
+	shown := func() []string {
+		var names []string
+		for _, item := range p.flatItems {
+			if item.Type != ItemTypeHeader {
+				names = append(names, item.Skill.Name)
+			}
+		}
+		return names
+	}
+
+	p.SetTagFilter([]string{"Pdf"})
+	if got := shown(); len(got) != 2 || got[0] != skills[0].Name || got[1] != skills[1].Name {
+		t.Errorf("tag pdf: shown %v", got)
+	}
+	if !strings.Contains(p.View(), "Tags: pdf") {
+		t.Error("expected the filter in the panel")
+	}
+
+	// Every tag must match, and the filter outlives a new search result
+	p.SetTagFilter([]string{"pdf", "forms"})
+	p.SetSkills(skills[:3])
+	if got := shown(); len(got) != 1 || got[0] != skills[0].Name {
+		t.Errorf("tags pdf+forms: shown %v", got)
+	}
+
+	p.SetTagFilter(nil)
+	if got := shown(); len(got) != 3 {
+		t.Errorf("no filter: shown %v", got)
+	}
+}
@@ -0,0 +1,157 @@
+package panels
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/registry"
+)
+
+// Skill states shown in the status column of the skills list. They are
+// also the keys of status_icons and status_precedence in the config.
+const (
+	StateModified    = "modified"
+	StateOrphaned    = "orphaned"
+	StateUnreachable = "unreachable"
+	StateOutdated    = "outdated"
+	StateLocal       = "local"
+	StateInstalled   = "installed"
+	StateAvailable   = "available"
+)
+
+// DefaultStatePrecedence is the order in which the states of an installed
+// skill win when several apply
+var DefaultStatePrecedence = []string{
+	StateModified,
+	StateOrphaned,
+	StateUnreachable,
+	StateOutdated,
+	StateLocal,
+	StateInstalled,
+}
+
+// StateDescriptions explains each state for the legend
+var StateDescriptions = map[string]string{
+	StateModified:    "installed, with local changes",
+	StateOrphaned:    "installed, no longer in any repository",
+	StateUnreachable: "installed, its commit no longer exists upstream",
+	StateOutdated:    "installed, a newer version is available",
+	StateLocal:       "in the skills directory, not installed by lazyas",
+	StateInstalled:   "installed",
+	StateAvailable:   "available to install",
+}
+
+// ResolvePrecedence completes a configured precedence: the listed states
+// come first, the remaining installed states follow in default order
+func ResolvePrecedence(precedence []string) ([]string, error) {
+	var order []string
+	for _, state := range precedence {
+		if state == StateAvailable {
+			return nil, fmt.Errorf("status_precedence: %s is not a state of an installed skill", state)
+		}
+		if !slices.Contains(DefaultStatePrecedence, state) {
+			return nil, fmt.Errorf("status_precedence: unknown state %q", state)
+		}
+		if slices.Contains(order, state) {
+			return nil, fmt.Errorf("status_precedence: %s is listed twice", state)
+		}
+		order = append(order, state)
+	}
+	for _, state := range DefaultStatePrecedence {
+		if !slices.Contains(order, state) {
+			order = append(order, state)
+		}
+	}
+	return order, nil
+}
+
+// SetStatusDisplay overrides the status icons (state -> glyph) and the
+// order in which states win. Nothing changes when either is invalid.
+func (p *SkillsPanel) SetStatusDisplay(icons map[string]string, precedence []string) error {
+	order, err := ResolvePrecedence(precedence)
+	if err != nil {
+		return err
+	}
+	styles := p.styles
+	for state, icon := range icons {
+		style := styles.statusStyle(state)
+		if style == nil {
+			return fmt.Errorf("status_icons: unknown state %q", state)
+		}
+		// The list is laid out assuming one cell per icon
+		if lipgloss.Width(icon) != 1 {
+			return fmt.Errorf("status_icons: icon for %s must be one character wide, got %q", state, icon)
+		}
+		*style = style.SetString(icon)
+	}
+	p.styles = styles
+	p.precedence = order
+	return nil
+}
+
+// StatePrecedence returns the order in which states of an installed skill win
+func (p *SkillsPanel) StatePrecedence() []string {
+	if p.precedence == nil {
+		return DefaultStatePrecedence
+	}
+	return p.precedence
+}
+
+// StatusIcon returns the styled icon of a state
+func (p *SkillsPanel) StatusIcon(state string) string {
+	if style := p.styles.statusStyle(state); style != nil {
+		return style.String()
+	}
+	return ""
+}
+
+// StatusGlyph returns the icon of a state without styling
+func (p *SkillsPanel) StatusGlyph(state string) string {
+	if style := p.styles.statusStyle(state); style != nil {
+		return style.Value()
+	}
+	return ""
+}
+
+// StateOf picks the state shown for a skill
+func (p *SkillsPanel) StateOf(skill registry.SkillEntry) string {
+	if !p.isInstalled(skill) {
+		return StateAvailable
+	}
+	applies := map[string]bool{
+		StateModified:    p.modified[skill.Name],
+		StateOrphaned:    p.orphaned[skill.Name],
+		StateUnreachable: p.unreachable[skill.Name],
+		StateOutdated:    p.outdated[skill.Name],
+		StateLocal:       p.localOnly[skill.Name],
+		StateInstalled:   true,
+	}
+	for _, state := range p.StatePrecedence() {
+		if applies[state] {
+			return state
+		}
+	}
+	return StateInstalled
+}
+
+// statusStyle returns the style of a state, nil for unknown states
+func (s *SkillsPanelStyles) statusStyle(state string) *lipgloss.Style {
+	switch state {
+	case StateModified:
+		return &s.StatusModified
+	case StateOrphaned:
+		return &s.StatusOrphaned
+	case StateUnreachable:
+		return &s.StatusUnreachable
+	case StateOutdated:
+		return &s.StatusOutdated
+	case StateLocal:
+		return &s.StatusLocal
+	case StateInstalled:
+		return &s.StatusInstalled
+	case StateAvailable:
+		return &s.StatusAvailable
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"lazyas/internal/config"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+	"lazyas/internal/tui/panels"
+)
+
+// Print writes the browser's skill list to w as static text, or as
+// markdown: the same groups, statuses and descriptions, with collapsed
+// groups expanded. Nothing is interactive, so the output can be piped. A
+// repo limits the list to that configured repository.
+func Print(cfg *config.Config, w io.Writer, markdown bool, repo string) error {
+	if err := cfg.EnsureDirs(); err != nil {
+		return fmt.Errorf("failed to create directories: %w", err)
+	}
+
+	app := NewApp(cfg)
+	app.repoScope = repo
+	switch msg := app.doFetchIndex(false).(type) {
+	case indexErrorMsg:
+		return fmt.Errorf("failed to fetch index: %w", msg.err)
+	case indexFetchedMsg:
+		app.outdated = msg.outdated
+	}
+	app.initPanels()
+	if app.statusDisplayErr != nil {
+		return app.statusDisplayErr
+	}
+
+	if markdown {
+		app.printMarkdown(w)
+	} else {
+		app.printText(w)
+	}
+	return nil
+}
+
+func (a *App) printText(w io.Writer) {
+	groups := a.skills.Groups()
+	if len(groups) == 0 {
+		fmt.Fprintln(w, i18n.T("No skills found"))
+		return
+	}
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%d)\n", g.Name, len(g.Skills))
+		for _, skill := range g.Skills {
+			line := "  " + a.skills.StatusGlyph(a.skills.StateOf(skill)) + " " + skill.Name
+			if version := a.printVersion(skill); version != "" {
+				line += "@" + version
+			}
+			if skill.Description != "" {
+				line += "  " + skill.Description
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, a.printLegend())
+}
+
+func (a *App) printMarkdown(w io.Writer) {
+	groups := a.skills.Groups()
+	fmt.Fprintln(w, i18n.T("# Skills"))
+	if len(groups) == 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, i18n.T("No skills found."))
+		return
+	}
+	for _, g := range groups {
+		fmt.Fprintf(w, "\n## %s (%d)\n\n", g.Name, len(g.Skills))
+		for _, skill := range g.Skills {
+			line := "- " + a.skills.StatusGlyph(a.skills.StateOf(skill)) + " `" + skill.Name + "`"
+			if version := a.printVersion(skill); version != "" {
+				line += " " + version
+			}
+			if skill.Description != "" {
+				line += ": " + skill.Description
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "_"+a.printLegend()+"_")
+}
+
+// printVersion is the installed version of a skill, else the registry tag
+func (a *App) printVersion(skill registry.SkillEntry) string {
+	if info, ok := a.manifest.GetInstalled(skill.Name); ok && a.manifest.IsInstalled(skill.Name) {
+		return info.Version
+	}
+	return skill.Source.Tag
+}
+
+// printLegend explains the icons in the order they win
+func (a *App) printLegend() string {
+	var parts []string
+	for _, state := range slices.Concat(a.skills.StatePrecedence(), []string{panels.StateAvailable}) {
+		parts = append(parts, a.skills.StatusGlyph(state)+" "+i18n.T(state))
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"lazyas/internal/git"
+)
+
+// provenanceCheckedMsg carries where installed commits were found upstream
+type provenanceCheckedMsg struct {
+	results map[string]git.Provenance // by commit
+}
+
+// checkProvenance looks up, in the background, whether the recorded commit
+// of each tracked skill still exists in its source repository. Repos that
+// cannot be reached are left out rather than reported as broken.
+func (a *App) checkProvenance() tea.Cmd {
+	if a.gitErr != nil {
+		return nil
+	}
+	commits := make(map[string][]string) // repo URL -> commits
+	for _, info := range a.manifest.ListInstalled() {
+		if info.SourceRepo != "" && info.Commit != "" {
+			commits[info.SourceRepo] = append(commits[info.SourceRepo], info.Commit)
+		}
+	}
+	if len(commits) == 0 {
+		return nil
+	}
+	upstreamDir := a.cfg.UpstreamDir
+
+	return func() tea.Msg {
+		results := make(map[string]git.Provenance)
+		for repoURL, list := range commits {
+			mirror := filepath.Join(upstreamDir, git.RepoDirName(repoURL)+".git")
+			found, err := git.CheckProvenance(repoURL, mirror, list)
+			if err != nil {
+				continue
+			}
+			for commit, p := range found {
+				results[commit] = p
+			}
+		}
+		return provenanceCheckedMsg{results}
+	}
+}
+
+// skillProvenance returns what is known upstream about the installed
+// commit of a skill, or nil when it has not been checked
+func (a *App) skillProvenance(name string) *git.Provenance {
+	info, ok := a.manifest.GetInstalled(name)
+	if !ok {
+		return nil
+	}
+	p, ok := a.provenance[info.Commit]
+	if !ok {
+		return nil
+	}
+	return &p
+}
+
+// unreachableSkills lists the installed skills whose commit is gone upstream
+func (a *App) unreachableSkills() map[string]bool {
+	unreachable := make(map[string]bool)
+	for name, info := range a.manifest.ListInstalled() {
+		if p, ok := a.provenance[info.Commit]; ok && !p.Reachable {
+			unreachable[name] = true
+		}
+	}
+	return unreachable
+}
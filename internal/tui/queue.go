@@ -0,0 +1,460 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/progress"
+	"lazyas/internal/registry"
+	"lazyas/internal/skillpolicy"
+	"lazyas/internal/tui/styles"
+	"lazyas/internal/usage"
+)
+
+// queueOp is an action waiting in the queue
+type queueOp int
+
+const (
+	queueInstall queueOp = iota
+	queueUpdate
+	queueRemove
+)
+
+func (op queueOp) String() string {
+	switch op {
+	case queueUpdate:
+		return "update"
+	case queueRemove:
+		return "remove"
+	default:
+		return "install"
+	}
+}
+
+// queueUsageEvents maps queued actions to their usage stats counter
+var queueUsageEvents = map[queueOp]string{
+	queueInstall: usage.Installs,
+	queueUpdate:  usage.Updates,
+	queueRemove:  usage.Removals,
+}
+
+// queueItem is one queued action on a skill
+type queueItem struct {
+	op    queueOp
+	skill registry.SkillEntry
+}
+
+// queueResult is the outcome of a queued action
+type queueResult struct {
+	item   queueItem
+	ok     bool
+	detail string
+	retry  bool // a failure worth retrying; kept in the failed list
+}
+
+type queueDoneMsg struct{ results []queueResult }
+
+// toggleQueued adds op for skill to the queue, replacing another action on
+// the same skill, or drops it when that exact action is already queued
+func (a *App) toggleQueued(op queueOp, skill registry.SkillEntry) {
+	for i, item := range a.queue {
+		if item.skill.Name != skill.Name {
+			continue
+		}
+		if item.op == op {
+			a.queue = append(a.queue[:i], a.queue[i+1:]...)
+			a.message = a.styles.Muted.Render(i18n.Tf("Dropped %s %s from the queue (%d queued)", op, skill.Name, len(a.queue)))
+			return
+		}
+		a.queue[i].op = op
+		a.message = a.styles.Muted.Render(i18n.Tf("Queued %s %s (%d queued) - Q to review", op, skill.Name, len(a.queue)))
+		return
+	}
+	a.queue = append(a.queue, queueItem{op: op, skill: skill})
+	a.message = a.styles.Muted.Render(i18n.Tf("Queued %s %s (%d queued) - Q to review", op, skill.Name, len(a.queue)))
+}
+
+// enqueue adds op for skill to the queue, replacing another action on the
+// same skill
+func (a *App) enqueue(op queueOp, skill registry.SkillEntry) {
+	for i, item := range a.queue {
+		if item.skill.Name == skill.Name {
+			a.queue[i].op = op
+			return
+		}
+	}
+	a.queue = append(a.queue, queueItem{op: op, skill: skill})
+}
+
+// queueMarked queues op for every marked skill it applies to and opens the
+// queue for review, so the batch runs after a single confirmation. Skills
+// the action does not apply to stay marked.
+func (a *App) queueMarked(op queueOp) {
+	marked := a.skills.Marked()
+	var kept, skipped []registry.SkillEntry
+	for _, skill := range marked {
+		_, clash := a.manifest.CaseClash(skill.Name)
+		onDisk := a.manifest.IsInstalled(skill.Name) && !clash
+		_, tracked := a.manifest.GetInstalled(skill.Name)
+
+		switch {
+		case op == queueInstall && !onDisk:
+			if candidate := a.installCandidate(&skill); candidate != nil {
+				a.enqueue(op, *candidate)
+				continue
+			}
+		case op == queueUpdate && onDisk && tracked && a.outdated[skill.Name]:
+			a.enqueue(op, skill)
+			continue
+		case op == queueRemove && a.manifest.IsInstalled(skill.Name):
+			a.enqueue(op, skill)
+			continue
+		}
+		kept = append(kept, skill)
+		skipped = append(skipped, skill)
+	}
+
+	if len(skipped) == len(marked) {
+		a.message = a.styles.Muted.Render(i18n.Tf("None of the %d marked skill(s) can be %s", len(marked), a.queueOpDone(op)))
+		return
+	}
+	a.skills.SetMarked(kept)
+	a.openQueue()
+	if len(skipped) > 0 {
+		names := make([]string, len(skipped))
+		for i, skill := range skipped {
+			names[i] = skill.Name
+		}
+		a.message = a.styles.Muted.Render(i18n.Tf("Left marked (cannot be %s): %s", a.queueOpDone(op), strings.Join(names, ", ")))
+	} else {
+		a.message = ""
+	}
+}
+
+// markedCount returns how many skills are marked for a batch action
+func (a *App) markedCount() int {
+	if a.skills == nil {
+		return 0
+	}
+	return len(a.skills.Marked())
+}
+
+// queueOpDone is the past participle of op for messages
+func (a *App) queueOpDone(op queueOp) string {
+	switch op {
+	case queueUpdate:
+		return i18n.T("updated")
+	case queueRemove:
+		return i18n.T("removed")
+	}
+	return i18n.T("installed")
+}
+
+// queueInstallOrUpdate queues the selected skill for install when it is
+// not on disk, or for update when a newer version is available
+func (a *App) queueInstallOrUpdate(skill *registry.SkillEntry) {
+	if _, clash := a.manifest.CaseClash(skill.Name); !a.manifest.IsInstalled(skill.Name) || clash {
+		candidate := a.installCandidate(skill)
+		if candidate == nil {
+			a.message = a.styles.Error.Render(i18n.Tf("%s is not found in any configured registry", skill.Name))
+			return
+		}
+		a.toggleQueued(queueInstall, *candidate)
+		return
+	}
+	if _, tracked := a.manifest.GetInstalled(skill.Name); tracked && a.outdated[skill.Name] {
+		a.toggleQueued(queueUpdate, *skill)
+		return
+	}
+	a.message = a.styles.Muted.Render(i18n.Tf("%s is installed and up to date", skill.Name))
+}
+
+// queueRemoval queues an installed skill for removal
+func (a *App) queueRemoval(skill *registry.SkillEntry) {
+	if !a.manifest.IsInstalled(skill.Name) {
+		a.message = a.styles.Muted.Render(i18n.Tf("%s is not installed", skill.Name))
+		return
+	}
+	a.toggleQueued(queueRemove, *skill)
+}
+
+// openQueue shows the queue for review
+func (a *App) openQueue() {
+	a.queueCursor = 0
+	a.queueResults = nil
+	a.mode = ModeQueue
+}
+
+func (a *App) updateQueue(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// After a run the modal only shows the results
+	if a.queueResults != nil {
+		switch msg.String() {
+		case "esc", "enter", "q":
+			a.queueResults = nil
+			a.mode = ModeNormal
+		}
+		return a, nil
+	}
+
+	// The cursor moves over the queue, then the failed actions below it
+	rows := len(a.queue) + len(a.failed)
+	failedRow := a.queueCursor - len(a.queue)
+
+	switch msg.String() {
+	case "esc", "q", "Q":
+		a.mode = ModeNormal
+		return a, nil
+
+	case "j", "down":
+		if a.queueCursor < rows-1 {
+			a.queueCursor++
+		}
+
+	case "k", "up":
+		if a.queueCursor > 0 {
+			a.queueCursor--
+		}
+
+	case "g", "home":
+		a.queueCursor = 0
+
+	case "G", "end":
+		a.queueCursor = max(rows-1, 0)
+
+	case "d", "x", "backspace", "delete":
+		if a.queueCursor < len(a.queue) {
+			a.queue = append(a.queue[:a.queueCursor], a.queue[a.queueCursor+1:]...)
+		} else if failedRow < len(a.failed) {
+			a.failed = append(a.failed[:failedRow], a.failed[failedRow+1:]...)
+		}
+		if a.queueCursor > 0 && a.queueCursor >= rows-1 {
+			a.queueCursor--
+		}
+		if len(a.queue) == 0 && len(a.failed) == 0 {
+			a.mode = ModeNormal
+		}
+
+	case "r":
+		if failedRow >= 0 && failedRow < len(a.failed) {
+			return a.retryFailed([]failedOp{a.failed[failedRow]})
+		}
+
+	case "R":
+		return a.retryFailed(a.failed)
+
+	case "c":
+		a.queue = nil
+		a.message = a.styles.Muted.Render(i18n.T("Queue cleared"))
+		a.mode = ModeNormal
+
+	case "enter":
+		if len(a.queue) == 0 {
+			if len(a.failed) == 0 {
+				a.mode = ModeNormal
+			}
+			return a, nil
+		}
+		if !a.requireGit() {
+			return a, nil
+		}
+		items := a.queue
+		a.queue = nil
+		a.forgetUndo()
+		a.loadingMsg = i18n.Tf("Running %d queued action(s)...", len(items))
+		a.mode = ModeLoading
+		return a, tea.Batch(
+			a.runQueue(items, a.startProgress(i18n.T("Running queue"))),
+			tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+		)
+	}
+	return a, nil
+}
+
+// runQueue executes the queued actions in order. A failed action does not
+// stop the ones after it; cancelling skips the rest.
+func (a *App) runQueue(items []queueItem, t *progress.Tracker) tea.Cmd {
+	return func() tea.Msg {
+		rules, rulesErr := skillpolicy.Load(a.cfg.SkillPolicyPath())
+		results := make([]queueResult, 0, len(items))
+		t.SetTotal(len(items))
+		for i, item := range items {
+			if t.Err() != nil {
+				results = append(results, queueResult{item: item, detail: i18n.T("cancelled"), retry: item.op != queueRemove})
+				continue
+			}
+			t.Set(i+1, item.op.String()+" "+item.skill.Name)
+			results = append(results, a.runQueueItem(item, rules, rulesErr))
+		}
+		return queueDoneMsg{results}
+	}
+}
+
+func (a *App) runQueueItem(item queueItem, rules *skillpolicy.Policy, rulesErr error) queueResult {
+	skill := item.skill
+	failed := func(err error) queueResult {
+		return queueResult{item: item, detail: err.Error(), retry: item.op != queueRemove}
+	}
+
+	switch item.op {
+	case queueInstall:
+		if _, clash := a.manifest.CaseClash(skill.Name); a.manifest.IsInstalled(skill.Name) && !clash {
+			return queueResult{item: item, ok: true, detail: i18n.T("already installed")}
+		}
+		switch msg := a.installSkill(&skill, skill.Name, nil)().(type) {
+		case installErrMsg:
+			return failed(msg.err)
+		case installDoneMsg:
+			if msg.clash != "" {
+				return queueResult{item: item, ok: true, detail: i18n.Tf("installed as %s (case clash with %s)", msg.skill, msg.clash)}
+			}
+		}
+		return queueResult{item: item, ok: true, detail: i18n.T("installed")}
+
+	case queueRemove:
+		if dependents := a.manifest.Dependents(skill.Name); len(dependents) > 0 {
+			return failed(fmt.Errorf("required by %s", strings.Join(dependents, ", ")))
+		}
+		if msg, ok := a.removeSkill(&skill)().(removeErrMsg); ok {
+			return failed(msg.err)
+		}
+		return queueResult{item: item, ok: true, detail: i18n.T("removed")}
+
+	case queueUpdate:
+		info, tracked := a.manifest.GetInstalled(skill.Name)
+		if !tracked {
+			return failed(fmt.Errorf("%s is not tracked", skill.Name))
+		}
+		r, ok := a.updateSkill(skill.Name, info, rules, rulesErr)
+		if ok && r.status == "updated" {
+			path := a.manifest.GetSkillPath(skill.Name)
+			checks := integrity.ValidateAll([]integrity.Target{{Name: skill.Name, Path: path}})
+			a.manifest.SetHashes(integrity.Hashes(checks))
+			if invalid := integrity.Failed(checks); len(invalid) > 0 {
+				return failed(invalid[0].Err)
+			}
+		}
+		detail := strings.ReplaceAll(r.status, "-", " ")
+		if r.status == "skipped" {
+			detail = i18n.T("local changes")
+		}
+		if r.problem != "" {
+			detail += ": " + r.problem
+		}
+		done := r.status == "updated" || r.status == "up-to-date"
+		return queueResult{item: item, ok: ok && done, detail: detail, retry: r.status == "failed"}
+	}
+	return queueResult{item: item}
+}
+
+// queueItemDetail describes what a queued action will do
+func (a *App) queueItemDetail(item queueItem) string {
+	switch item.op {
+	case queueInstall:
+		detail := refOrLatest(item.skill.Source.Tag)
+		if item.skill.Source.RepoName != "" {
+			detail = item.skill.Source.RepoName + " " + detail
+		}
+		return detail
+	case queueUpdate:
+		info, _ := a.manifest.GetInstalled(item.skill.Name)
+		target := ""
+		if skill := a.registry.GetSkill(info.RegistryName(item.skill.Name)); skill != nil {
+			target = skill.Source.Tag
+		}
+		return refOrLatest(info.Version) + " → " + refOrLatest(info.TargetRef(target))
+	case queueRemove:
+		if dependents := a.manifest.Dependents(item.skill.Name); len(dependents) > 0 {
+			return i18n.Tf("required by %s", strings.Join(dependents, ", "))
+		}
+	}
+	return ""
+}
+
+func (a *App) renderQueueContent() string {
+	modalBg := styles.Current().Background
+	contentWidth := 72
+
+	lineBg := lipgloss.NewStyle().
+		Background(modalBg).
+		Width(contentWidth)
+	muted := a.styles.Muted.Background(modalBg).Width(contentWidth)
+
+	title := i18n.Tf("Queue (%d action(s))", len(a.queue))
+	if a.queueResults != nil {
+		title = i18n.T("Queue Results")
+	}
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(title)
+	emptyLine := lineBg.Render("")
+
+	var lines []string
+	lines = append(lines, titleStyled, emptyLine)
+
+	const rowFormat = "  %-8s %-24s %s"
+	if a.queueResults != nil {
+		var failures int
+		for _, r := range a.queueResults {
+			line := fmt.Sprintf(rowFormat, i18n.T(r.item.op.String()), truncate(r.item.skill.Name, 24), truncate(r.detail, 34))
+			if r.ok {
+				lines = append(lines, a.styles.Success.Background(modalBg).Width(contentWidth).Render("✓"+line[1:]))
+			} else {
+				failures++
+				lines = append(lines, a.styles.Error.Background(modalBg).Width(contentWidth).Render("✗"+line[1:]))
+			}
+		}
+		lines = append(lines, emptyLine)
+		lines = append(lines, lineBg.Render(i18n.Tf("Done: %d  Failed: %d", len(a.queueResults)-failures, failures)))
+		if len(a.failed) > 0 {
+			lines = append(lines, muted.Render(i18n.T("Failed installs and updates stay under Q to retry.")))
+		}
+		lines = append(lines, emptyLine, muted.Render(i18n.T("enter/esc: close")))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	cursorStyle := lipgloss.NewStyle().
+		Background(styles.Current().Primary).
+		Foreground(styles.Current().SelectedText).
+		Width(contentWidth).
+		Bold(true)
+	for i, item := range a.queue {
+		line := fmt.Sprintf(rowFormat, i18n.T(item.op.String()), truncate(item.skill.Name, 24), truncate(a.queueItemDetail(item), 36))
+		if i == a.queueCursor {
+			lines = append(lines, cursorStyle.Render(line))
+		} else {
+			lines = append(lines, lineBg.Render(line))
+		}
+	}
+
+	if len(a.queue) == 0 {
+		lines = append(lines, muted.Render(i18n.T("  Nothing queued")))
+	}
+
+	lines = append(lines, emptyLine)
+	lines = append(lines, muted.Render(i18n.T("Actions run in this order; a failure does not stop the rest.")))
+
+	if len(a.failed) > 0 {
+		lines = append(lines, emptyLine)
+		lines = append(lines, a.styles.Error.Background(modalBg).Width(contentWidth).Render(i18n.Tf("Failed (%d)", len(a.failed))))
+		for i, f := range a.failed {
+			line := fmt.Sprintf(rowFormat, i18n.T(f.item.op.String()), truncate(f.item.skill.Name, 24), truncate(f.err, 36))
+			if len(a.queue)+i == a.queueCursor {
+				lines = append(lines, cursorStyle.Render(line))
+			} else {
+				lines = append(lines, lineBg.Render(line))
+			}
+		}
+	}
+
+	lines = append(lines, emptyLine)
+	help := i18n.T("enter: run all  d: drop  c: clear  esc: keep browsing")
+	if len(a.failed) > 0 {
+		help = i18n.T("enter: run all  r: retry  R: retry all failed  d: drop  esc: close")
+	}
+	lines = append(lines, muted.Render(help))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/i18n"
+	"lazyas/internal/tui/styles"
+)
+
+// repoRows is how many repositories the repository picker shows at once
+const repoRows = 15
+
+// repoCount is a configured repository and how many skills it lists
+type repoCount struct {
+	name  string // "" for every repository
+	count int
+}
+
+// openRepoPicker lists the configured repositories to scope the skills
+// list to, with the cursor on the current scope
+func (a *App) openRepoPicker() {
+	counts := make(map[string]int)
+	total := 0
+	for _, skill := range a.registry.ListSkills() {
+		counts[skill.Source.RepoName]++
+		total++
+	}
+
+	a.repos = append(a.repos[:0], repoCount{"", total})
+	a.repoCursor = 0
+	for _, repo := range a.cfg.Repos {
+		if repo.Name == a.repoScope {
+			a.repoCursor = len(a.repos)
+		}
+		a.repos = append(a.repos, repoCount{repo.Name, counts[repo.Name]})
+	}
+	a.repoOffset = max(a.repoCursor-repoRows+1, 0)
+	a.mode = ModeRepos
+}
+
+// scopeToRepo shows only the skills of the configured repository name, or
+// of every repository for ""
+func (a *App) scopeToRepo(name string) {
+	a.repoScope = name
+	a.skills.SetRepoFilter(name)
+	if name != "" {
+		a.message = a.styles.Muted.Render(i18n.Tf("Showing only skills from %s (P to pick another)", name))
+	} else {
+		a.message = ""
+	}
+	a.updateDetailPanel()
+}
+
+// toggleHeaderScope scopes the list to the repository of the group header
+// under the cursor, or shows every repository again when it already is
+func (a *App) toggleHeaderScope() bool {
+	header := a.skills.SelectedHeader()
+	if header == nil || header.RepoName == "" {
+		return false
+	}
+	if a.repoScope == header.RepoName {
+		a.scopeToRepo("")
+	} else {
+		a.scopeToRepo(header.RepoName)
+	}
+	return true
+}
+
+func (a *App) updateRepoPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "P":
+		a.mode = ModeNormal
+	case "j", "down":
+		if a.repoCursor < len(a.repos)-1 {
+			a.repoCursor++
+		}
+	case "k", "up":
+		if a.repoCursor > 0 {
+			a.repoCursor--
+		}
+	case "g", "home":
+		a.repoCursor = 0
+	case "G", "end":
+		a.repoCursor = max(len(a.repos)-1, 0)
+	case "enter", " ":
+		if a.repoCursor < len(a.repos) {
+			a.scopeToRepo(a.repos[a.repoCursor].name)
+		}
+		a.mode = ModeNormal
+	}
+
+	if a.repoCursor < a.repoOffset {
+		a.repoOffset = a.repoCursor
+	} else if a.repoCursor >= a.repoOffset+repoRows {
+		a.repoOffset = a.repoCursor - repoRows + 1
+	}
+	return a, nil
+}
+
+func (a *App) renderRepoPickerContent() string {
+	modalBg := styles.Current().Background
+	contentWidth := 44
+
+	lineBg := lipgloss.NewStyle().
+		Background(modalBg).
+		Width(contentWidth)
+	muted := a.styles.Muted.Background(modalBg).Width(contentWidth)
+
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(i18n.T("Show Repository"))
+	emptyLine := lineBg.Render("")
+
+	var lines []string
+	lines = append(lines, titleStyled, emptyLine)
+
+	end := min(a.repoOffset+repoRows, len(a.repos))
+	for i := a.repoOffset; i < end; i++ {
+		repo := a.repos[i]
+		mark := "( )"
+		if repo.name == a.repoScope {
+			mark = "(•)"
+		}
+		name := repo.name
+		if name == "" {
+			name = i18n.T("All repositories")
+		}
+		line := fmt.Sprintf("  %s %-30s %5d", mark, truncate(name, 30), repo.count)
+		if i == a.repoCursor {
+			lines = append(lines, modalCursorStyle(true, contentWidth).Render(line))
+		} else {
+			lines = append(lines, lineBg.Render(line))
+		}
+	}
+	if len(a.repos) > repoRows {
+		lines = append(lines, muted.Render(i18n.Tf("  %d-%d of %d", a.repoOffset+1, end, len(a.repos))))
+	}
+
+	lines = append(lines, emptyLine, muted.Render(i18n.T("enter: show  esc: close")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+)
+
+// failedOp is an install or update that failed this session. Failures are
+// listed under the queue and can be retried from there.
+type failedOp struct {
+	item queueItem
+	err  string
+}
+
+// recordFailure remembers a failed action, replacing an earlier failure of
+// the same action on the same skill
+func (a *App) recordFailure(op queueOp, skill registry.SkillEntry, err string) {
+	a.clearFailure(op, skill.Name)
+	a.failed = append(a.failed, failedOp{item: queueItem{op: op, skill: skill}, err: err})
+}
+
+// clearFailure forgets a failure once the action succeeded
+func (a *App) clearFailure(op queueOp, name string) {
+	for i, f := range a.failed {
+		if f.item.op == op && f.item.skill.Name == name {
+			a.failed = append(a.failed[:i], a.failed[i+1:]...)
+			return
+		}
+	}
+}
+
+// recordUpdateFailures tracks the skills an update run could not update.
+// Skipped, held and blocked skills are not failures: retrying would not
+// change their outcome.
+func (a *App) recordUpdateFailures(results []updateSkillResult) {
+	for _, r := range results {
+		switch r.status {
+		case "failed":
+			a.recordFailure(queueUpdate, registry.SkillEntry{Name: r.name}, r.problem)
+		case "updated", "up-to-date":
+			a.clearFailure(queueUpdate, r.name)
+		}
+	}
+}
+
+// retryFailed runs failed actions again through the queue runner; the ones
+// that fail again are recorded anew when the results come in
+func (a *App) retryFailed(failed []failedOp) (tea.Model, tea.Cmd) {
+	if len(failed) == 0 || !a.requireGit() {
+		return a, nil
+	}
+	items := make([]queueItem, len(failed))
+	for i, f := range failed {
+		items[i] = f.item
+	}
+	for _, item := range items {
+		a.clearFailure(item.op, item.skill.Name)
+	}
+	a.loadingMsg = i18n.Tf("Retrying %d failed action(s)...", len(items))
+	a.mode = ModeLoading
+	return a, tea.Batch(
+		a.runQueue(items, a.startProgress(i18n.T("Retrying"))),
+		tea.Tick(100*time.Millisecond, func(_ time.Time) tea.Msg { return tickMsg{} }),
+	)
+}
@@ -0,0 +1,425 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/config"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+	"lazyas/internal/symlink"
+	"lazyas/internal/tui/styles"
+)
+
+// settingKind is how a row of the settings screen is edited
+type settingKind int
+
+const (
+	settingNumber settingKind = iota // a whole number, typed in
+	settingText                      // free text, typed in
+	settingChoice                    // enter steps through the choices
+)
+
+// setting is one row of the settings screen. set checks the new value,
+// applies it to the config and returns a command that puts it into effect
+// in the running TUI, if one is needed.
+type setting struct {
+	label   string
+	hint    string
+	kind    settingKind
+	choices []string
+	get     func() string
+	set     func(value string) (tea.Cmd, error)
+}
+
+// openSettings switches to the settings screen
+func (a *App) openSettings() {
+	a.settingsCursor = 0
+	a.settingsEditing = false
+	a.settingsErr = ""
+	a.buildSettings()
+	a.mode = ModeSettings
+}
+
+// buildSettings lists the settings that can be changed from the TUI: the
+// common config.toml keys and the link mode of each linked backend
+func (a *App) buildSettings() {
+	cfg := a.cfg
+	a.settings = []setting{
+		{
+			label: i18n.T("Cache TTL (hours)"),
+			hint:  i18n.T("How long the fetched index and SKILL.md previews are reused"),
+			kind:  settingNumber,
+			get:   func() string { return strconv.Itoa(cfg.CacheTTL) },
+			set: func(value string) (tea.Cmd, error) {
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("cache TTL must be at least 1 hour")
+				}
+				cfg.CacheTTL = n
+				a.previews.TTL = time.Duration(n) * time.Hour
+				if cfg.RefreshInterval == 0 {
+					return a.scheduleRefresh(), nil
+				}
+				return nil, nil
+			},
+		},
+		{
+			label: i18n.T("Background refresh (minutes)"),
+			hint:  i18n.T("0 refreshes every cache TTL, a negative number turns it off"),
+			kind:  settingNumber,
+			get:   func() string { return strconv.Itoa(cfg.RefreshInterval) },
+			set: func(value string) (tea.Cmd, error) {
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("refresh interval must be a whole number of minutes")
+				}
+				cfg.RefreshInterval = n
+				return a.scheduleRefresh(), nil
+			},
+		},
+		{
+			label: i18n.T("Viewer"),
+			hint:  i18n.T("Command that shows SKILL.md, e.g. \"glow -t\"; empty tries glow, $PAGER, less"),
+			kind:  settingText,
+			get:   func() string { return cfg.Viewer },
+			set: func(value string) (tea.Cmd, error) {
+				cfg.Viewer = value
+				return nil, nil
+			},
+		},
+		{
+			label:   i18n.T("Update policy"),
+			hint:    i18n.T("Largest semver step an update may take; per-skill policies win"),
+			kind:    settingChoice,
+			choices: []string{"major", "minor", "patch"},
+			get:     func() string { return valueOr(cfg.UpdatePolicy, "major") },
+			set: func(value string) (tea.Cmd, error) {
+				cfg.SetUpdatePolicy(value)
+				return nil, nil
+			},
+		},
+		{
+			label:   i18n.T("Risk policy"),
+			hint:    i18n.T("Which install-time risk levels block an install"),
+			kind:    settingChoice,
+			choices: []string{"allow", "block-high", "block-medium"},
+			get:     func() string { return valueOr(cfg.RiskPolicy, "allow") },
+			set: func(value string) (tea.Cmd, error) {
+				cfg.SetRiskPolicy(value)
+				return nil, nil
+			},
+		},
+		{
+			label:   i18n.T("Verify signatures"),
+			hint:    i18n.T("Check who signed the installed commits; f filters to verified skills"),
+			kind:    settingChoice,
+			choices: []string{"off", "on"},
+			get:     func() string { return onOff(cfg.VerifySignatures) },
+			set: func(value string) (tea.Cmd, error) {
+				cfg.VerifySignatures = value == "on"
+				if cfg.VerifySignatures {
+					return a.checkSignatures(), nil
+				}
+				a.signatures = nil
+				if a.skills != nil {
+					a.skills.SetVerifiedOnly(false)
+					a.skills.SetVerified(a.verifiedSkills())
+					a.updateDetailPanel()
+				}
+				return nil, nil
+			},
+		},
+		{
+			label:   i18n.T("Notifications"),
+			hint:    i18n.T("Desktop notification when a background refresh finds updates"),
+			kind:    settingChoice,
+			choices: []string{"off", "on"},
+			get:     func() string { return onOff(cfg.Notifications) },
+			set: func(value string) (tea.Cmd, error) {
+				cfg.Notifications = value == "on"
+				return nil, nil
+			},
+		},
+		{
+			label: i18n.T("Trash retention (days)"),
+			hint:  i18n.T("0 keeps removed skills 30 days, a negative number until emptied"),
+			kind:  settingNumber,
+			get:   func() string { return strconv.Itoa(cfg.TrashRetention) },
+			set: func(value string) (tea.Cmd, error) {
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("trash retention must be a whole number of days")
+				}
+				cfg.TrashRetention = n
+				return nil, nil
+			},
+		},
+		{
+			label: i18n.T("Parallel git jobs"),
+			hint:  i18n.T("Repositories updated at once by update all; 0 = 4"),
+			kind:  settingNumber,
+			get:   func() string { return strconv.Itoa(cfg.MaxGitJobs) },
+			set: func(value string) (tea.Cmd, error) {
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("parallel git jobs must be 0 or more")
+				}
+				cfg.MaxGitJobs = n
+				return nil, nil
+			},
+		},
+		{
+			label: i18n.T("Git bandwidth (KB/s)"),
+			hint:  i18n.T("Cap on git's HTTP(S) transfers; 0 = unlimited"),
+			kind:  settingNumber,
+			get:   func() string { return strconv.Itoa(cfg.GitBandwidthLimit) },
+			set: func(value string) (tea.Cmd, error) {
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("git bandwidth must be 0 or more KB/s")
+				}
+				if err := git.LimitBandwidth(int64(n) * 1024); err != nil {
+					return nil, err
+				}
+				cfg.GitBandwidthLimit = n
+				return nil, nil
+			},
+		},
+		{
+			label:   i18n.T("Theme"),
+			hint:    i18n.T("Color preset; colors set in the [theme] section of config.toml override it"),
+			kind:    settingChoice,
+			choices: styles.ThemeNames(),
+			get:     func() string { return valueOr(cfg.Theme["preset"], styles.DefaultTheme) },
+			set: func(value string) (tea.Cmd, error) {
+				theme := maps.Clone(cfg.Theme)
+				if theme == nil {
+					theme = make(map[string]string)
+				}
+				theme["preset"] = value
+				if _, err := styles.ResolveTheme(theme); err != nil {
+					return nil, err
+				}
+				cfg.Theme = theme
+				a.applyTheme()
+				return nil, nil
+			},
+		},
+	}
+
+	a.checkBackendStatus()
+	for _, status := range a.backendStatuses {
+		if !status.Linked {
+			continue
+		}
+		name := status.Backend.Name
+		a.settings = append(a.settings, setting{
+			label:   i18n.Tf("Link mode: %s", name),
+			hint:    i18n.T("whole links the skills directory; per-skill links only the skills enabled for it"),
+			kind:    settingChoice,
+			choices: []string{"whole", config.BackendPerSkill},
+			get: func() string {
+				if backend := cfg.GetBackend(name); backend != nil && backend.PerSkill() {
+					return config.BackendPerSkill
+				}
+				return "whole"
+			},
+			set: func(value string) (tea.Cmd, error) {
+				return nil, a.setLinkMode(name, value == config.BackendPerSkill)
+			},
+		})
+	}
+}
+
+// setLinkMode relinks a linked backend as a whole or per skill. Switching
+// to per skill keeps every skill the backend could see.
+func (a *App) setLinkMode(name string, perSkill bool) error {
+	backend := a.cfg.GetBackend(name)
+	if backend == nil {
+		return fmt.Errorf("backend '%s' not found", name)
+	}
+	if backend.PerSkill() == perSkill {
+		return nil
+	}
+
+	mode := ""
+	if perSkill {
+		mode = config.BackendPerSkill
+		var enable []string
+		for skill := range a.manifest.ScanLocalSkills() {
+			enable = append(enable, skill)
+		}
+		converted := *backend
+		converted.Mode = mode
+		if err := symlink.ConvertToPerSkill(converted, a.cfg.SkillsDir, enable); err != nil {
+			return err
+		}
+	} else if err := symlink.ConvertToWhole(*backend, a.cfg.SkillsDir); err != nil {
+		return err
+	}
+	if err := a.cfg.SetBackendMode(name, mode); err != nil {
+		return err
+	}
+	a.checkBackendStatus()
+	a.refreshPanels()
+	return nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// applySetting sets the selected setting and saves the config
+func (a *App) applySetting(value string) tea.Cmd {
+	s := a.settings[a.settingsCursor]
+	cmd, err := s.set(value)
+	if err == nil {
+		err = a.cfg.Save()
+	}
+	if err != nil {
+		a.settingsErr = err.Error()
+		return nil
+	}
+	a.settingsErr = ""
+	return cmd
+}
+
+func (a *App) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.settingsEditing {
+		switch msg.String() {
+		case "esc":
+			a.settingsEditing = false
+			a.settingsErr = ""
+			return a, nil
+		case "enter":
+			cmd := a.applySetting(strings.TrimSpace(a.settingsInput.Value()))
+			if a.settingsErr == "" {
+				a.settingsEditing = false
+			}
+			return a, cmd
+		}
+		var cmd tea.Cmd
+		a.settingsInput, cmd = a.settingsInput.Update(msg)
+		return a, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q", ",":
+		a.mode = ModeNormal
+		return a, nil
+
+	case "j", "down":
+		if a.settingsCursor < len(a.settings)-1 {
+			a.settingsCursor++
+			a.settingsErr = ""
+		}
+
+	case "k", "up":
+		if a.settingsCursor > 0 {
+			a.settingsCursor--
+			a.settingsErr = ""
+		}
+
+	case "g", "home":
+		a.settingsCursor = 0
+		a.settingsErr = ""
+
+	case "G", "end":
+		a.settingsCursor = max(len(a.settings)-1, 0)
+		a.settingsErr = ""
+
+	case "enter", " ":
+		if a.settingsCursor >= len(a.settings) {
+			return a, nil
+		}
+		s := a.settings[a.settingsCursor]
+		if s.kind == settingChoice {
+			current := s.get()
+			next := s.choices[0]
+			for i, choice := range s.choices {
+				if choice == current {
+					next = s.choices[(i+1)%len(s.choices)]
+				}
+			}
+			return a, a.applySetting(next)
+		}
+		input := textinput.New()
+		input.CharLimit = 200
+		input.SetValue(s.get())
+		input.CursorEnd()
+		input.Focus()
+		a.settingsInput = input
+		a.settingsEditing = true
+		return a, textinput.Blink
+	}
+	return a, nil
+}
+
+func (a *App) renderSettingsContent() string {
+	modalBg := styles.Current().Background
+	contentWidth := 72
+
+	lineBg := lipgloss.NewStyle().
+		Background(modalBg).
+		Width(contentWidth)
+	muted := a.styles.Muted.Background(modalBg).Width(contentWidth)
+
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(i18n.T("Settings"))
+	emptyLine := lineBg.Render("")
+
+	var lines []string
+	lines = append(lines, titleStyled, emptyLine)
+
+	for i, s := range a.settings {
+		value := s.get()
+		if i == a.settingsCursor && a.settingsEditing {
+			value = a.settingsInput.View()
+		} else if value == "" {
+			value = i18n.T("(auto)")
+		}
+		line := fmt.Sprintf("  %-30s %s", truncate(s.label, 30), value)
+		if i == a.settingsCursor {
+			cursorStyle := lipgloss.NewStyle().
+				Background(styles.Current().Primary).
+				Foreground(styles.Current().SelectedText).
+				Width(contentWidth).
+				Bold(true)
+			lines = append(lines, cursorStyle.Render(line))
+		} else {
+			lines = append(lines, lineBg.Render(line))
+		}
+	}
+
+	lines = append(lines, emptyLine)
+	if a.settingsErr != "" {
+		lines = append(lines, a.styles.Error.Background(modalBg).Width(contentWidth).Render("  "+a.settingsErr))
+	} else if a.settingsCursor < len(a.settings) {
+		lines = append(lines, muted.Render("  "+a.settings[a.settingsCursor].hint))
+	}
+	lines = append(lines, muted.Render(i18n.Tf("  Saved to %s", a.cfg.ConfigPath)), emptyLine)
+
+	help := i18n.T("enter: change  esc: close")
+	if a.settingsEditing {
+		help = i18n.T("enter: save  esc: cancel")
+	}
+	lines = append(lines, muted.Render(help))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
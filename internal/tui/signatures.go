@@ -0,0 +1,82 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"lazyas/internal/git"
+	"lazyas/internal/i18n"
+)
+
+// signaturesCheckedMsg carries the verified signatures of installed skills
+type signaturesCheckedMsg struct {
+	results map[string]git.Signature // by skill name
+}
+
+// checkSignatures verifies, in the background, the signature of the
+// installed commit of each tracked skill. It only runs with
+// verify_signatures enabled.
+func (a *App) checkSignatures() tea.Cmd {
+	if a.gitErr != nil || !a.cfg.VerifySignatures {
+		return nil
+	}
+	installed := a.manifest.ListInstalled()
+	if len(installed) == 0 {
+		return nil
+	}
+	paths := make(map[string]string, len(installed))
+	commits := make(map[string]string, len(installed))
+	for name, info := range installed {
+		paths[name] = a.manifest.GetSkillPath(name)
+		commits[name] = info.Commit
+	}
+
+	return func() tea.Msg {
+		results := make(map[string]git.Signature)
+		for name, commit := range commits {
+			if commit == "" {
+				continue
+			}
+			if sig, err := git.CommitSignature(paths[name], commit); err == nil {
+				results[name] = sig
+			}
+		}
+		return signaturesCheckedMsg{results}
+	}
+}
+
+// skillSignature returns the checked signature of an installed skill, or
+// nil when it has not been checked
+func (a *App) skillSignature(name string) *git.Signature {
+	sig, ok := a.signatures[name]
+	if !ok {
+		return nil
+	}
+	return &sig
+}
+
+// verifiedSkills lists the installed skills with a good signature
+func (a *App) verifiedSkills() map[string]bool {
+	verified := make(map[string]bool)
+	for name, sig := range a.signatures {
+		if sig.Verified() {
+			verified[name] = true
+		}
+	}
+	return verified
+}
+
+// toggleVerifiedFilter shows only skills with a verified signature, or
+// everything again
+func (a *App) toggleVerifiedFilter() {
+	if !a.cfg.VerifySignatures {
+		a.message = a.styles.Muted.Render(i18n.T("Turn on Verify signatures in settings (,) to check signatures"))
+		return
+	}
+	on := !a.skills.VerifiedOnly()
+	a.skills.SetVerifiedOnly(on)
+	if on {
+		a.message = a.styles.Muted.Render(i18n.T("Showing only skills with a verified signature (f to show all)"))
+	} else {
+		a.message = ""
+	}
+	a.updateDetailPanel()
+}
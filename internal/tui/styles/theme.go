@@ -0,0 +1,166 @@
+package styles
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the palette the TUI is drawn with. Every style of the app, the
+// panels and the modals takes its colors from the current theme.
+type Theme struct {
+	Primary       lipgloss.Color // titles, keys, focused borders, selection
+	SelectedText  lipgloss.Color // text on Primary
+	Text          lipgloss.Color // skill names and values
+	Muted         lipgloss.Color // hints, labels, available skills
+	Border        lipgloss.Color // unfocused borders and cursors
+	Background    lipgloss.Color // modal dialogs
+	TagBackground lipgloss.Color // tag chips
+	Success       lipgloss.Color // installed
+	Warning       lipgloss.Color // modified, orphaned, tags
+	Danger        lipgloss.Color // errors, unreachable, conflicts
+	Info          lipgloss.Color // local skills, diff hunks
+	Outdated      lipgloss.Color // updates available
+}
+
+// Themes are the built-in presets, selected by the preset key of the
+// [theme] config section
+var Themes = map[string]Theme{
+	"dark": {
+		Primary:       "#7C3AED",
+		SelectedText:  "#FFFFFF",
+		Text:          "#FFFFFF",
+		Muted:         "#6B7280",
+		Border:        "#374151",
+		Background:    "#1a1a2e",
+		TagBackground: "#1F2937",
+		Success:       "#10B981",
+		Warning:       "#F59E0B",
+		Danger:        "#EF4444",
+		Info:          "#38BDF8",
+		Outdated:      "#818CF8",
+	},
+	"light": {
+		Primary:       "#6D28D9",
+		SelectedText:  "#FFFFFF",
+		Text:          "#111827",
+		Muted:         "#6B7280",
+		Border:        "#D1D5DB",
+		Background:    "#F3F4F6",
+		TagBackground: "#E5E7EB",
+		Success:       "#047857",
+		Warning:       "#B45309",
+		Danger:        "#B91C1C",
+		Info:          "#0369A1",
+		Outdated:      "#4338CA",
+	},
+	"solarized": {
+		Primary:       "#268BD2",
+		SelectedText:  "#FDF6E3",
+		Text:          "#93A1A1",
+		Muted:         "#657B83",
+		Border:        "#073642",
+		Background:    "#002B36",
+		TagBackground: "#073642",
+		Success:       "#859900",
+		Warning:       "#B58900",
+		Danger:        "#DC322F",
+		Info:          "#2AA198",
+		Outdated:      "#6C71C4",
+	},
+}
+
+// DefaultTheme is the preset used when none is configured
+const DefaultTheme = "dark"
+
+var current = Themes[DefaultTheme]
+
+// Current returns the theme styles are built from
+func Current() Theme {
+	return current
+}
+
+// SetTheme makes t the current theme. Styles built before keep their
+// colors, so callers rebuild them.
+func SetTheme(t Theme) {
+	current = t
+}
+
+// ThemeNames returns the names of the presets, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// color returns the field of t a [theme] key sets, nil for unknown keys
+func (t *Theme) color(key string) *lipgloss.Color {
+	switch key {
+	case "primary":
+		return &t.Primary
+	case "selected_text":
+		return &t.SelectedText
+	case "text":
+		return &t.Text
+	case "muted":
+		return &t.Muted
+	case "border":
+		return &t.Border
+	case "background":
+		return &t.Background
+	case "tag_background":
+		return &t.TagBackground
+	case "success":
+		return &t.Success
+	case "warning":
+		return &t.Warning
+	case "danger":
+		return &t.Danger
+	case "info":
+		return &t.Info
+	case "outdated":
+		return &t.Outdated
+	}
+	return nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ResolveTheme builds the theme the [theme] config section describes: the
+// preset named by its preset key (dark by default) with any of its colors
+// replaced by the other keys. A color is "#RRGGBB", "#RGB" or an ANSI
+// color number from 0 to 255. When the section is invalid the default
+// theme is returned with the error.
+func ResolveTheme(settings map[string]string) (Theme, error) {
+	name := DefaultTheme
+	if preset, ok := settings["preset"]; ok {
+		name = strings.ToLower(strings.TrimSpace(preset))
+	}
+	t, ok := Themes[name]
+	if !ok {
+		return Themes[DefaultTheme], fmt.Errorf("theme: unknown preset %q (want %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+
+	for key, value := range settings {
+		if key == "preset" {
+			continue
+		}
+		field := t.color(key)
+		if field == nil {
+			return Themes[DefaultTheme], fmt.Errorf("theme: unknown color %q", key)
+		}
+		value = strings.TrimSpace(value)
+		if n, err := strconv.Atoi(value); !hexColor.MatchString(value) && (err != nil || n < 0 || n > 255) {
+			return Themes[DefaultTheme], fmt.Errorf("theme: %s must be #RRGGBB or an ANSI color number, got %q", key, value)
+		}
+		*field = lipgloss.Color(value)
+	}
+	return t, nil
+}
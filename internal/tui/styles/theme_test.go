@@ -0,0 +1,42 @@
+package styles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestResolveTheme(t *testing.T) {
+	if got, err := ResolveTheme(nil); err != nil || got != Themes[DefaultTheme] {
+		t.Errorf("Expected the default theme without settings, got %+v, %v", got, err)
+	}
+
+	got, err := ResolveTheme(map[string]string{"preset": "Light", "primary": "#005f87", "muted": "244"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Themes["light"]
+	want.Primary = lipgloss.Color("#005f87")
+	want.Muted = lipgloss.Color("244")
+	if got != want {
+		t.Errorf("Expected the light preset with two colors replaced, got %+v", got)
+	}
+
+	for settings, wantErr := range map[string]string{
+		"preset=sepia":      "unknown preset",
+		"accent=#ffffff":    "unknown color",
+		"danger=red":        "danger must be",
+		"background=#12345": "background must be",
+		"text=256":          "text must be",
+	} {
+		key, value, _ := strings.Cut(settings, "=")
+		got, err := ResolveTheme(map[string]string{key: value})
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%s: expected an error containing %q, got %v", settings, wantErr, err)
+		}
+		if got != Themes[DefaultTheme] {
+			t.Errorf("%s: expected the default theme on error", settings)
+		}
+	}
+}
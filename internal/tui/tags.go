@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"lazyas/internal/i18n"
+	"lazyas/internal/tui/styles"
+)
+
+// tagRows is how many tags the tag filter shows at once
+const tagRows = 15
+
+// tagCount is a tag of the registry and how many skills have it
+type tagCount struct {
+	name  string // lowercase
+	count int
+}
+
+// openTagFilter lists the tags of all registry skills for the tag filter
+func (a *App) openTagFilter() {
+	counts := make(map[string]int)
+	for _, skill := range a.registry.ListSkills() {
+		seen := make(map[string]bool, len(skill.Tags))
+		for _, tag := range skill.Tags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag != "" && !seen[tag] {
+				seen[tag] = true
+				counts[tag]++
+			}
+		}
+	}
+
+	a.tags = a.tags[:0]
+	for name, count := range counts {
+		a.tags = append(a.tags, tagCount{name, count})
+	}
+	sort.Slice(a.tags, func(i, j int) bool {
+		if a.tags[i].count != a.tags[j].count {
+			return a.tags[i].count > a.tags[j].count
+		}
+		return a.tags[i].name < a.tags[j].name
+	})
+	a.tagCursor = 0
+	a.tagOffset = 0
+	a.mode = ModeTags
+}
+
+// toggleTag adds the tag under the cursor to the filter, or drops it
+func (a *App) toggleTag() {
+	if a.tagCursor >= len(a.tags) {
+		return
+	}
+	tag := a.tags[a.tagCursor].name
+	filter := slices.Clone(a.skills.TagFilter())
+	if i := slices.Index(filter, tag); i >= 0 {
+		filter = slices.Delete(filter, i, i+1)
+	} else {
+		filter = append(filter, tag)
+	}
+	a.skills.SetTagFilter(filter)
+	a.updateDetailPanel()
+}
+
+func (a *App) updateTagFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "t", "enter":
+		a.mode = ModeNormal
+	case "j", "down":
+		if a.tagCursor < len(a.tags)-1 {
+			a.tagCursor++
+		}
+	case "k", "up":
+		if a.tagCursor > 0 {
+			a.tagCursor--
+		}
+	case "g", "home":
+		a.tagCursor = 0
+	case "G", "end":
+		a.tagCursor = max(len(a.tags)-1, 0)
+	case " ", "x":
+		a.toggleTag()
+	case "c":
+		a.skills.SetTagFilter(nil)
+		a.updateDetailPanel()
+	}
+
+	if a.tagCursor < a.tagOffset {
+		a.tagOffset = a.tagCursor
+	} else if a.tagCursor >= a.tagOffset+tagRows {
+		a.tagOffset = a.tagCursor - tagRows + 1
+	}
+	return a, nil
+}
+
+func (a *App) renderTagFilterContent() string {
+	modalBg := styles.Current().Background
+	contentWidth := 44
+
+	lineBg := lipgloss.NewStyle().
+		Background(modalBg).
+		Width(contentWidth)
+	muted := a.styles.Muted.Background(modalBg).Width(contentWidth)
+
+	titleStyled := a.styles.Title.Background(modalBg).Width(contentWidth).Render(i18n.T("Filter by Tag"))
+	emptyLine := lineBg.Render("")
+
+	var lines []string
+	lines = append(lines, titleStyled, emptyLine)
+
+	if len(a.tags) == 0 {
+		lines = append(lines, muted.Render(i18n.T("  No skill in the registry has tags")))
+	}
+	filter := a.skills.TagFilter()
+	end := min(a.tagOffset+tagRows, len(a.tags))
+	for i := a.tagOffset; i < end; i++ {
+		tag := a.tags[i]
+		check := "[ ]"
+		if slices.Contains(filter, tag.name) {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("  %s %-30s %5d", check, truncate(tag.name, 30), tag.count)
+		if i == a.tagCursor {
+			cursorStyle := lipgloss.NewStyle().
+				Background(styles.Current().Primary).
+				Foreground(styles.Current().SelectedText).
+				Width(contentWidth).
+				Bold(true)
+			lines = append(lines, cursorStyle.Render(line))
+		} else {
+			lines = append(lines, lineBg.Render(line))
+		}
+	}
+	if len(a.tags) > tagRows {
+		lines = append(lines, muted.Render(i18n.Tf("  %d-%d of %d tags", a.tagOffset+1, end, len(a.tags))))
+	}
+
+	lines = append(lines, emptyLine)
+	if len(filter) > 0 {
+		lines = append(lines, muted.Render(i18n.Tf("  Showing skills tagged %s", strings.Join(filter, " + "))))
+	} else {
+		lines = append(lines, muted.Render(i18n.T("  Skills need every checked tag")))
+	}
+	lines = append(lines, emptyLine, muted.Render(i18n.T("space: toggle  c: clear  esc: close")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,25 @@
+package tui
+
+import (
+	"lazyas/internal/tui/panels"
+	"lazyas/internal/tui/styles"
+)
+
+// applyTheme makes the [theme] config section the current theme and
+// rebuilds the styles drawn with it. An invalid section leaves the default
+// theme and is reported in the status line.
+func (a *App) applyTheme() {
+	theme, err := styles.ResolveTheme(a.cfg.Theme)
+	a.themeErr = err
+	styles.SetTheme(theme)
+
+	a.styles = defaultAppStyles()
+	if a.skills != nil {
+		a.skills.SetStyles(panels.DefaultSkillsPanelStyles())
+		a.statusDisplayErr = a.skills.SetStatusDisplay(a.cfg.StatusIcons, a.cfg.StatusPrecedence)
+	}
+	if a.detail != nil {
+		a.detail.SetStyles(panels.DefaultDetailPanelStyles())
+		a.updateDetailPanel()
+	}
+}
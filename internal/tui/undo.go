@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"lazyas/internal/i18n"
+	"lazyas/internal/registry"
+)
+
+// maxUndo is how many changes the undo history keeps
+const maxUndo = 100
+
+// viewState is what undo restores: how the skills list is folded,
+// filtered and marked, and the queued actions. Actions that changed files
+// (installs, removals, settings) are not part of it.
+type viewState struct {
+	collapsed     []string // sorted
+	query         string
+	contentSearch bool
+	tags          []string
+	verifiedOnly  bool
+	repoScope     string
+	marked        []registry.SkillEntry
+	queue         []queueItem
+}
+
+// undoHistory holds the states before each change, and after each undo
+// the states to redo
+type undoHistory struct {
+	undo []viewState
+	redo []viewState
+	skip bool // the last key restored a state or ran the queue
+}
+
+// viewState captures the current state; false before the panels exist
+func (a *App) viewState() (viewState, bool) {
+	if a.skills == nil {
+		return viewState{}, false
+	}
+	return viewState{
+		collapsed:     slices.Sorted(maps.Keys(a.collapsedGroups())),
+		query:         a.skills.GetQuery(),
+		contentSearch: a.skills.ContentSearch(),
+		tags:          slices.Clone(a.skills.TagFilter()),
+		verifiedOnly:  a.skills.VerifiedOnly(),
+		repoScope:     a.repoScope,
+		marked:        a.skills.Marked(),
+		queue:         slices.Clone(a.queue),
+	}, true
+}
+
+// changes names what differs between two states, for the status line
+func (s viewState) changes(other viewState) []string {
+	var changed []string
+	if !slices.Equal(s.collapsed, other.collapsed) {
+		changed = append(changed, i18n.T("fold"))
+	}
+	if s.query != other.query || s.contentSearch != other.contentSearch {
+		changed = append(changed, i18n.T("search"))
+	}
+	if !slices.Equal(s.tags, other.tags) {
+		changed = append(changed, i18n.T("tag filter"))
+	}
+	if s.verifiedOnly != other.verifiedOnly {
+		changed = append(changed, i18n.T("verified filter"))
+	}
+	if s.repoScope != other.repoScope {
+		changed = append(changed, i18n.T("repository scope"))
+	}
+	if !slices.EqualFunc(s.marked, other.marked, sameSkill) {
+		changed = append(changed, i18n.T("marks"))
+	}
+	if !slices.EqualFunc(s.queue, other.queue, func(x, y queueItem) bool {
+		return x.op == y.op && sameSkill(x.skill, y.skill)
+	}) {
+		changed = append(changed, i18n.T("queue"))
+	}
+	return changed
+}
+
+func sameSkill(x, y registry.SkillEntry) bool {
+	return x.Name == y.Name && x.Source.Repo == y.Source.Repo && x.Source.Path == y.Source.Path
+}
+
+// recordUndo adds before to the undo history when the last key changed
+// the state. A new change drops the states to redo.
+func (a *App) recordUndo(before viewState) {
+	if a.history.skip {
+		a.history.skip = false
+		return
+	}
+	after, ok := a.viewState()
+	if !ok || len(before.changes(after)) == 0 {
+		return
+	}
+	a.history.undo = append(a.history.undo, before)
+	if len(a.history.undo) > maxUndo {
+		a.history.undo = a.history.undo[1:]
+	}
+	a.history.redo = nil
+}
+
+// forgetUndo clears the history once the queue ran: its actions changed
+// files, so restoring the queue from before would run them again
+func (a *App) forgetUndo() {
+	a.history = undoHistory{skip: true}
+}
+
+// undo restores the state before the last change
+func (a *App) undo() {
+	if changed, ok := a.stepHistory(&a.history.undo, &a.history.redo); ok {
+		a.message = a.styles.Muted.Render(i18n.Tf("Undid %s (ctrl+r to redo)", changed))
+	} else {
+		a.message = a.styles.Muted.Render(i18n.T("Nothing to undo"))
+	}
+}
+
+// redo restores the state the last undo left
+func (a *App) redo() {
+	if changed, ok := a.stepHistory(&a.history.redo, &a.history.undo); ok {
+		a.message = a.styles.Muted.Render(i18n.Tf("Redid %s (u to undo)", changed))
+	} else {
+		a.message = a.styles.Muted.Render(i18n.T("Nothing to redo"))
+	}
+}
+
+// stepHistory moves the current state onto to and restores the last
+// state of from, returning what changed; false when from is empty
+func (a *App) stepHistory(from, to *[]viewState) (string, bool) {
+	current, ok := a.viewState()
+	if !ok || len(*from) == 0 {
+		return "", false
+	}
+	state := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, current)
+	a.history.skip = true
+
+	a.restoreViewState(state)
+	return strings.Join(current.changes(state), ", "), true
+}
+
+// restoreViewState applies state to the skills list and the queue
+func (a *App) restoreViewState(state viewState) {
+	collapseMap := make(map[string]bool, len(state.collapsed))
+	for _, name := range state.collapsed {
+		collapseMap[name] = true
+	}
+	if !maps.Equal(collapseMap, a.collapsedGroups()) {
+		a.skills.SetCollapseMap(collapseMap)
+		a.saveCollapseState()
+	}
+
+	a.skills.SetTagFilter(state.tags)
+	a.skills.SetVerifiedOnly(state.verifiedOnly)
+	a.repoScope = state.repoScope
+	a.skills.SetRepoFilter(state.repoScope)
+	if state.query != a.skills.GetQuery() || state.contentSearch != a.skills.ContentSearch() {
+		a.skills.SetSearch(state.query, state.contentSearch)
+		a.filterSkills()
+	}
+	a.skills.SetMarked(slices.Clone(state.marked))
+	a.queue = slices.Clone(state.queue)
+	a.updateDetailPanel()
+}
+
+// collapsedGroups returns the groups folded now
+func (a *App) collapsedGroups() map[string]bool {
+	collapsed := make(map[string]bool)
+	for name, isCollapsed := range a.skills.GetCollapseMap() {
+		if isCollapsed {
+			collapsed[name] = true
+		}
+	}
+	return collapsed
+}
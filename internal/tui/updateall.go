@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"maps"
+	"sort"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"lazyas/internal/i18n"
+	"lazyas/internal/integrity"
+	"lazyas/internal/manifest"
+	"lazyas/internal/progress"
+	"lazyas/internal/skillpolicy"
+)
+
+type (
+	// updateStartedMsg opens the update result modal once the registry is
+	// fresh; results arrive on next
+	updateStartedMsg struct {
+		total int
+		next  <-chan tea.Msg
+	}
+	// updateSkillMsg is the result of one skill of a running update all
+	updateSkillMsg struct {
+		result updateSkillResult
+		ok     bool
+		next   <-chan tea.Msg
+	}
+)
+
+// updateAllSkills refreshes the registry and updates every installed skill
+// on a pool of max_git_jobs workers. Skills of one repository share a
+// clone, so each repository is updated by one worker, skill after skill.
+// Results stream into the update result modal as they finish, and a final
+// updateDoneMsg carries the validated results; cancelling t stops before
+// the next skill.
+func (a *App) updateAllSkills(t *progress.Tracker) tea.Cmd {
+	// The workers write the manifest as they go; they read a copy taken
+	// here, on the UI goroutine
+	installed := maps.Clone(a.manifest.ListInstalled())
+	return func() tea.Msg {
+		if len(installed) == 0 {
+			return updateDoneMsg{}
+		}
+
+		// Force refresh registry first
+		a.registry.SetProgress(t)
+		a.registry.Fetch(true)
+		a.registry.SetProgress(nil)
+
+		next := make(chan tea.Msg)
+		go a.runUpdates(installed, t, next)
+		return updateStartedMsg{total: len(installed), next: next}
+	}
+}
+
+// waitUpdate waits for the next message of a running update all
+func waitUpdate(next <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-next
+	}
+}
+
+// runUpdates updates the installed skills and sends an updateSkillMsg per
+// skill, then the updateDoneMsg. A crash is sent instead and stops the
+// run: the program quits on it and reads nothing more from next.
+func (a *App) runUpdates(installed map[string]manifest.InstalledSkill, t *progress.Tracker, next chan tea.Msg) {
+	stop := make(chan struct{})
+	var crashOnce sync.Once
+	crashed := func(r any) {
+		crashOnce.Do(func() {
+			next <- crashMsg{newCrash(r, nil)}
+			close(stop)
+		})
+	}
+	send := func(msg tea.Msg) bool {
+		select {
+		case next <- msg:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			crashed(r)
+		}
+	}()
+
+	byRepo := make(map[string][]string)
+	for name, info := range installed {
+		byRepo[info.SourceRepo] = append(byRepo[info.SourceRepo], name)
+	}
+	jobs := make(chan []string, len(byRepo))
+	for _, names := range byRepo {
+		sort.Strings(names)
+		jobs <- names
+	}
+	close(jobs)
+
+	rules, rulesErr := skillpolicy.Load(a.cfg.SkillPolicyPath())
+	t.Begin(i18n.T("Updating"), len(installed))
+	done := make(chan updateSkillMsg)
+	var wg sync.WaitGroup
+	for w := 0; w < min(a.cfg.GitJobs(), len(byRepo)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					crashed(r)
+				}
+			}()
+			for names := range jobs {
+				for _, name := range names {
+					if t.Err() != nil {
+						break
+					}
+					r, ok := a.updateSkill(name, installed[name], rules, rulesErr)
+					select {
+					case done <- updateSkillMsg{result: r, ok: ok, next: next}:
+					case <-stop:
+						return
+					}
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	var results []updateSkillResult
+	var updated, skipped, failed int
+	for msg := range done {
+		r := msg.result
+		switch {
+		case !msg.ok:
+			failed++
+		case r.status == "updated":
+			updated++
+		default:
+			skipped++
+		}
+		results = append(results, r)
+		t.Set(len(results), r.name)
+		if !send(msg) {
+			return
+		}
+	}
+
+	// Validate and hash everything that changed, concurrently
+	var targets []integrity.Target
+	for _, r := range results {
+		if r.status == "updated" {
+			targets = append(targets, integrity.Target{Name: r.name, Path: a.manifest.GetSkillPath(r.name)})
+		}
+	}
+	if len(targets) > 0 {
+		checks := integrity.ValidateAll(targets)
+		a.manifest.SetHashes(integrity.Hashes(checks))
+		for _, c := range integrity.Failed(checks) {
+			for i := range results {
+				if results[i].name == c.Name {
+					results[i].status = "invalid"
+					results[i].problem = c.Err.Error()
+					updated--
+					failed++
+				}
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].name < results[j].name })
+	a.recordUpdateRun(installed, results)
+	send(updateDoneMsg{
+		updated:   updated,
+		skipped:   skipped,
+		failed:    failed,
+		cancelled: len(installed) - len(results),
+		results:   results,
+	})
+}
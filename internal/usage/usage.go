@@ -0,0 +1,153 @@
+// Package usage keeps local usage counters in ~/.lazyas/usage.yaml so
+// users can see their own workflows with 'lazyas stats --usage'. The
+// counters never leave the machine: nothing in lazyas sends them anywhere.
+package usage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Event counters
+const (
+	Installs    = "installs"
+	Updates     = "updates"
+	Removals    = "removals"
+	TUISessions = "tui_sessions"
+)
+
+// Counts are usage counters, either recorded on disk or collected during
+// one run before they are flushed
+type Counts struct {
+	Since   time.Time      `yaml:"since,omitempty"`   // first recorded use
+	Events  map[string]int `yaml:"events,omitempty"`  // installs, updates, removals, TUI sessions
+	Actions map[string]int `yaml:"actions,omitempty"` // CLI commands ("cli sync") and TUI actions ("tui install")
+}
+
+// Count is one named counter
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Add adds n to an event counter
+func (c *Counts) Add(event string, n int) {
+	if n == 0 {
+		return
+	}
+	if c.Events == nil {
+		c.Events = make(map[string]int)
+	}
+	c.Events[event] += n
+}
+
+// AddAction counts one use of an action
+func (c *Counts) AddAction(name string) {
+	if c.Actions == nil {
+		c.Actions = make(map[string]int)
+	}
+	c.Actions[name]++
+}
+
+// Empty reports whether nothing was counted
+func (c *Counts) Empty() bool {
+	return len(c.Events) == 0 && len(c.Actions) == 0
+}
+
+// TopActions returns the n most used actions, most used first. n <= 0
+// returns all of them.
+func (c *Counts) TopActions(n int) []Count {
+	counts := make([]Count, 0, len(c.Actions))
+	for name, count := range c.Actions {
+		counts = append(counts, Count{Name: name, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	if n > 0 && len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// Load reads the recorded counters. A missing file has none.
+func Load(path string) (*Counts, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Counts{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c Counts
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid usage file %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Reset deletes the recorded counters
+func Reset(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Recorder adds counts to the usage file at Path. A disabled recorder
+// drops them.
+type Recorder struct {
+	Path    string
+	Enabled bool
+}
+
+// New returns a recorder for the usage file at path
+func New(path string, enabled bool) *Recorder {
+	return &Recorder{Path: path, Enabled: enabled}
+}
+
+// Flush adds c to the recorded counters. Counting is best-effort, so a
+// failure never gets in the way of the command that was counted.
+func (r *Recorder) Flush(c *Counts) {
+	if r == nil || !r.Enabled || c.Empty() {
+		return
+	}
+	r.flushAt(c, time.Now())
+}
+
+func (r *Recorder) flushAt(c *Counts, now time.Time) error {
+	recorded, err := Load(r.Path)
+	if err != nil {
+		// A corrupt file is not worth failing over; start again
+		recorded = &Counts{}
+	}
+	if recorded.Since.IsZero() {
+		recorded.Since = now.UTC().Truncate(time.Second)
+	}
+	for name, n := range c.Events {
+		recorded.Add(name, n)
+	}
+	for name, n := range c.Actions {
+		if recorded.Actions == nil {
+			recorded.Actions = make(map[string]int)
+		}
+		recorded.Actions[name] += n
+	}
+
+	data, err := yaml.Marshal(recorded)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.Path, data, 0644)
+}
@@ -0,0 +1,85 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecorder_FlushAccumulates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.yaml")
+	r := New(path, true)
+	first := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	run := &Counts{}
+	run.Add(Installs, 2)
+	run.AddAction("cli install")
+	if err := r.flushAt(run, first); err != nil {
+		t.Fatal(err)
+	}
+
+	run = &Counts{}
+	run.Add(Installs, 1)
+	run.Add(TUISessions, 1)
+	run.AddAction("tui install")
+	run.AddAction("tui install")
+	run.AddAction("tui sync")
+	if err := r.flushAt(run, first.Add(48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Since.Equal(first) {
+		t.Errorf("Since = %v, want the first flush %v", got.Since, first)
+	}
+	if got.Events[Installs] != 3 || got.Events[TUISessions] != 1 {
+		t.Errorf("events = %v", got.Events)
+	}
+	top := got.TopActions(2)
+	if len(top) != 2 || top[0] != (Count{"tui install", 2}) || top[1] != (Count{"cli install", 1}) {
+		t.Errorf("TopActions(2) = %v", top)
+	}
+}
+
+func TestRecorder_DisabledAndReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.yaml")
+	run := &Counts{}
+	run.Add(Updates, 4)
+
+	New(path, false).Flush(run)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("a disabled recorder wrote %s", path)
+	}
+
+	New(path, true).Flush(run)
+	if got, _ := Load(path); got.Events[Updates] != 4 {
+		t.Fatalf("events = %v", got.Events)
+	}
+	if err := Reset(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Load(path); err != nil || !got.Empty() || !got.Since.IsZero() {
+		t.Errorf("after Reset: %+v, %v", got, err)
+	}
+	if err := Reset(path); err != nil {
+		t.Errorf("Reset of a missing file: %v", err)
+	}
+}
+
+func TestRecorder_StartsOverOnCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.yaml")
+	os.WriteFile(path, []byte("events: [not, a, map"), 0644)
+
+	run := &Counts{}
+	run.AddAction("cli sync")
+	if err := New(path, true).flushAt(run, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Load(path); err != nil || got.Actions["cli sync"] != 1 {
+		t.Errorf("got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,237 @@
+// Package watch reports changes made to the skills directory from outside
+// lazyas: skills dropped in or deleted, and files edited inside a skill.
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"lazyas/internal/git"
+)
+
+// DefaultDebounce is how long a skill must be quiet before its changes are
+// reported, so editors saving several files produce a single event
+const DefaultDebounce = 500 * time.Millisecond
+
+// Kind describes what happened to a skill
+type Kind int
+
+const (
+	KindAdded   Kind = iota // a new entry appeared in the skills directory
+	KindRemoved             // an entry disappeared from the skills directory
+	KindChanged             // files inside a skill were created, edited or deleted
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindAdded:
+		return "added"
+	case KindRemoved:
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+// Event is a debounced change to one skill
+type Event struct {
+	Skill string
+	Kind  Kind
+	Files []string // changed paths relative to the skill, for KindChanged
+}
+
+// Watcher watches a skills directory and every skill below it
+type Watcher struct {
+	Events chan Event
+	Errors chan error
+
+	dir      string
+	debounce time.Duration
+	fs       *fsnotify.Watcher
+
+	mu      sync.Mutex
+	pending map[string]*Event
+	timers  map[string]*time.Timer
+	done    chan struct{}
+}
+
+// New starts watching skillsDir. Call Close to stop.
+func New(skillsDir string, debounce time.Duration) (*Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(skillsDir); err != nil {
+		fw.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		Events:   make(chan Event, 16),
+		Errors:   make(chan error, 1),
+		dir:      skillsDir,
+		debounce: debounce,
+		fs:       fw,
+		pending:  make(map[string]*Event),
+		timers:   make(map[string]*time.Timer),
+		done:     make(chan struct{}),
+	}
+
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		fw.Close()
+		return nil, err
+	}
+	for _, e := range entries {
+		w.addSkill(e.Name())
+	}
+
+	go w.loop()
+	return w, nil
+}
+
+// Close stops the watcher
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.fs.Close()
+}
+
+// addSkill watches every directory of a skill. Skills are usually symlinks
+// into a shared clone, so the real tree is walked and each directory is
+// watched through the link, which keeps event paths under the skills dir.
+func (w *Watcher) addSkill(name string) {
+	link := filepath.Join(w.dir, name)
+	real, err := filepath.EvalSymlinks(link)
+	if err != nil {
+		return
+	}
+	if info, err := os.Stat(real); err != nil || !info.IsDir() {
+		return
+	}
+	filepath.WalkDir(real, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(real, path)
+		w.fs.Add(filepath.Join(link, rel))
+		return nil
+	})
+}
+
+func (w *Watcher) loop() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			select {
+			case w.Errors <- err:
+			default:
+			}
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			w.handle(ev)
+		}
+	}
+}
+
+func (w *Watcher) handle(ev fsnotify.Event) {
+	rel, err := filepath.Rel(w.dir, ev.Name)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return
+	}
+	parts := strings.SplitN(filepath.ToSlash(rel), "/", 2)
+	skill := parts[0]
+	if skill == ".lazyas" || skill == git.IgnoreFileName {
+		return
+	}
+
+	// Top-level entry: a skill (or link) appeared or went away
+	if len(parts) == 1 {
+		switch {
+		case ev.Has(fsnotify.Create):
+			w.addSkill(skill)
+			w.record(skill, KindAdded, "")
+		case ev.Has(fsnotify.Remove), ev.Has(fsnotify.Rename):
+			w.record(skill, KindRemoved, "")
+		}
+		return
+	}
+
+	file := parts[1]
+	if strings.HasPrefix(file, ".git/") || file == ".git" {
+		return
+	}
+	if ev.Has(fsnotify.Create) {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+			w.fs.Add(ev.Name)
+		}
+	}
+	if ignore := git.LoadIgnore(filepath.Join(w.dir, skill)); ignore.Match(file) {
+		return
+	}
+	w.record(skill, KindChanged, file)
+}
+
+// record merges a change into the skill's pending event and restarts its
+// debounce timer
+func (w *Watcher) record(skill string, kind Kind, file string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ev, ok := w.pending[skill]
+	if !ok {
+		ev = &Event{Skill: skill, Kind: kind}
+		w.pending[skill] = ev
+	}
+	// Added or removed wins over edits inside the skill
+	if kind != KindChanged {
+		ev.Kind = kind
+	}
+	if file != "" && !contains(ev.Files, file) {
+		ev.Files = append(ev.Files, file)
+	}
+
+	if t, ok := w.timers[skill]; ok {
+		t.Stop()
+	}
+	w.timers[skill] = time.AfterFunc(w.debounce, func() { w.flush(skill) })
+}
+
+func (w *Watcher) flush(skill string) {
+	w.mu.Lock()
+	ev := w.pending[skill]
+	delete(w.pending, skill)
+	delete(w.timers, skill)
+	w.mu.Unlock()
+
+	if ev == nil {
+		return
+	}
+	select {
+	case w.Events <- *ev:
+	case <-w.done:
+	}
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testDebounce = 50 * time.Millisecond
+
+func newTestWatcher(t *testing.T) (*Watcher, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "existing", "docs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	w, err := New(dir, testDebounce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { w.Close() })
+	return w, dir
+}
+
+func nextEvent(t *testing.T, w *Watcher) Event {
+	t.Helper()
+	select {
+	case ev := <-w.Events:
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event{}
+	}
+}
+
+func TestWatcher_CoalescesEditsInsideSkill(t *testing.T) {
+	w, dir := newTestWatcher(t)
+
+	os.WriteFile(filepath.Join(dir, "existing", "SKILL.md"), []byte("# a\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "existing", "docs", "notes.md"), []byte("x\n"), 0o644)
+
+	ev := nextEvent(t, w)
+	if ev.Skill != "existing" || ev.Kind != KindChanged {
+		t.Fatalf("got %+v, want existing changed", ev)
+	}
+	if len(ev.Files) != 2 {
+		t.Errorf("expected both files in one event, got %v", ev.Files)
+	}
+}
+
+func TestWatcher_AddedAndRemoved(t *testing.T) {
+	w, dir := newTestWatcher(t)
+
+	if err := os.Mkdir(filepath.Join(dir, "new-skill"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if ev := nextEvent(t, w); ev.Skill != "new-skill" || ev.Kind != KindAdded {
+		t.Fatalf("got %+v, want new-skill added", ev)
+	}
+
+	if err := os.RemoveAll(filepath.Join(dir, "existing")); err != nil {
+		t.Fatal(err)
+	}
+	if ev := nextEvent(t, w); ev.Skill != "existing" || ev.Kind != KindRemoved {
+		t.Fatalf("got %+v, want existing removed", ev)
+	}
+}
+
+func TestWatcher_SkipsIgnoredFiles(t *testing.T) {
+	w, dir := newTestWatcher(t)
+
+	os.WriteFile(filepath.Join(dir, "existing", ".lazyasignore"), []byte("*.log\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "existing", "run.log"), []byte("x\n"), 0o644)
+
+	select {
+	case ev := <-w.Events:
+		t.Fatalf("unexpected event %+v", ev)
+	case <-time.After(4 * testDebounce):
+	}
+}